@@ -3,11 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/cy-infamous/purewin/internal/analyze"
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
 	"github.com/spf13/cobra"
 )
 
@@ -16,12 +23,81 @@ var analyzeCmd = &cobra.Command{
 	Short: "Explore disk usage",
 	Long: `Interactive disk space analyzer with visual tree view.
 
-Defaults to the current working directory when no path is given.
+Defaults to the current working directory when no path is given. --drives
+starts from an overview of every mounted drive instead — capacity, free
+space, file system, media type and BitLocker status — and analyzes the one
+picked.
+
+Junk recognised by 'pw clean <path>' (temp files, logs, caches, build
+output) is tagged in the tree, with the junk total shown next to each
+folder. Press J to review and clean all junk under the current folder.
+
+Act on what you find without leaving the analyzer: Backspace then Enter
+deletes the selection, m moves it to another folder or drive, Enter shows
+it in Explorer and y copies its path. Whitelisted paths are never deleted
+or moved.
+
+Junctions and symbolic links are listed but never followed, and a file
+with several hard links is counted once. Sizes are apparent sizes; the
+header, and entries where it differs, also show the size on disk, which
+accounts for cluster rounding and NTFS compression and sparse files.
+
+Analyzing a whole NTFS drive as administrator reads the Master File Table
+directly, which is much faster than listing millions of folders; other
+paths, and non-elevated runs, list folders as usual.
+
+Scans are cached. A cache older than five minutes is refreshed by reading
+only the folders that changed since, found from the NTFS change journal
+when running as administrator and from folder modification times
+otherwise; --rescan scans everything again.
+
+Without a usable cache the analyzer opens while the scan runs: the header
+shows bytes and files counted so far, the files/s rate and, for whole
+drives and previously scanned folders, an estimate of the time left.
+Top-level folders appear as soon as they are complete and can be browsed
+straight away; deleting and moving wait until the scan is done.
+
+Tab switches to the largest files at any depth, with their extension, age
+and owner, and then to the space taken by each file type (video, images,
+archives, executables, code, ...), and then to stale folders: folders whose
+newest file is older than --stale-age (default a year), with the space they
+would free. --top, --types and --stale print those views instead of opening
+the analyzer.
+
+Press i to show each entry's owner and last-access time, and O to list only
+entries whose owner contains a name — handy on shared machines. Both are
+read as entries come into view, so they do not slow the scan down.
+
+Press / to search the scan without reading the disk again: a name glob or
+text, optionally with a minimum size, such as "*.vhdx >10GB". The views
+narrow to the matches and the folders holding them; n and N jump between
+matches and Esc clears the search.
+
+Press a on a .zip, .7z, .iso, .rar, .cab or .tar file to list its contents
+without extracting it: file count, unpacked size, compression ratio and the
+largest entries. Formats other than zip are read with Windows' tar.exe.
+
+--export writes the scan to a file, with its time, root and excluded
+folders: a JSON tree (.json), a flat CSV listing (.csv) or the ncdu export
+format (.ncdu, open with 'ncdu -f'). --export-format overrides the choice
+made from the file extension.
+
+--snapshot <name> saves the scan's folder sizes under a name; a later
+--compare <name> shows a tree of what grew (red) or shrank (green) since,
+--depth levels deep and hiding changes below --min-size (default 1MB),
+followed by the folders where most growth happened.
 
 Examples:
   pw analyze              Analyze current directory
   pw analyze D:\Projects  Analyze a specific directory
-  pw analyze C:\          Analyze an entire drive`,
+  pw analyze C:\          Analyze an entire drive
+  pw analyze --drives     Pick a drive from an overview
+  pw analyze C:\ --top 50 List the 50 largest files on C:
+  pw analyze D:\ --types  Show what kinds of files fill D:
+  pw analyze D:\ --stale --stale-age 2y  Folders untouched for two years
+  pw analyze C:\ --export scan.ncdu  Save the scan for ncdu
+  pw analyze C:\ --snapshot october  Snapshot C: for later comparison
+  pw analyze C:\ --compare october   What grew since the snapshot`,
 	Args:  cobra.MaximumNArgs(1),
 	Run:   runAnalyze,
 }
@@ -30,17 +106,39 @@ func init() {
 	analyzeCmd.Flags().Int("depth", 0, "Maximum directory depth to display")
 	analyzeCmd.Flags().String("min-size", "", "Minimum size to display (e.g., 100MB)")
 	analyzeCmd.Flags().StringSlice("exclude", nil, "Directories to exclude from scan")
+	analyzeCmd.Flags().Int("top", 0, "List the N largest files at any depth and exit")
+	analyzeCmd.Flags().Bool("rescan", false, "Scan everything again instead of refreshing the cached scan")
+	analyzeCmd.Flags().Bool("types", false, "Show sizes by file type and extension and exit")
+	analyzeCmd.Flags().Bool("stale", false, "List folders untouched for --stale-age and exit")
+	analyzeCmd.Flags().String("stale-age", "1y", "Age after which an untouched folder counts as stale (e.g. 1y, 180d)")
+	analyzeCmd.Flags().String("export", "", "Write the scan to a file (.json, .csv or .ncdu) and exit")
+	analyzeCmd.Flags().String("export-format", "", "Export format: json, csv or ncdu (default: from the file extension)")
+	analyzeCmd.Flags().Bool("drives", false, "Start from an overview of all drives and pick one to analyze")
+	analyzeCmd.Flags().String("snapshot", "", "Save the scan as a named snapshot and exit")
+	analyzeCmd.Flags().String("compare", "", "Show size changes since a named snapshot (or exported .json) and exit")
+	addNiceFlag(analyzeCmd.Flags())
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) {
+	defer applyNiceFlag(cmd)()
+
 	// Determine target path (default: current working directory).
 	target := ""
 	if len(args) > 0 {
 		target = args[0]
 	}
+	if drives, _ := cmd.Flags().GetBool("drives"); drives && target == "" {
+		target = pickDrive()
+		if target == "" {
+			return
+		}
+	}
 	if target == "" {
 		cwd, err := os.Getwd()
 		if err != nil {
+			if jsonOutput {
+				output.Fail("analyze", err)
+			}
 			fmt.Fprintf(os.Stderr, "Error: cannot determine current directory: %v\n", err)
 			os.Exit(1)
 		}
@@ -49,6 +147,9 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 
 	// Validate the path exists.
 	if _, err := os.Stat(target); err != nil {
+		if jsonOutput {
+			output.Fail("analyze", err)
+		}
 		fmt.Fprintf(os.Stderr, "Error: cannot access %s: %v\n", target, err)
 		os.Exit(1)
 	}
@@ -56,48 +157,484 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	// Parse exclude list.
 	exclude, _ := cmd.Flags().GetStringSlice("exclude")
 
+	rescan, _ := cmd.Flags().GetBool("rescan")
+
 	// Try loading from cache first.
-	root, err := analyze.LoadCache(target)
+	var root *analyze.DirEntry
+	err := os.ErrNotExist
+	if !rescan {
+		root, err = analyze.LoadCache(target)
+	}
 	if err != nil {
-		// No valid cache — run a fresh scan with a progress spinner.
-		scanner := analyze.NewScanner(8, exclude)
-
-		done := make(chan struct{})
-		go func() {
-			frame := 0
-			ticker := time.NewTicker(100 * time.Millisecond)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					frame = (frame + 1) % len(ui.SpinnerFrames)
-					count := scanner.ScannedCount()
-					fmt.Fprintf(os.Stderr, "\r  %s Scanning %s … %d entries",
-						ui.SpinnerFrames[frame], target, count)
-				}
-			}
-		}()
-
-		root, err = scanner.Scan(target)
-		close(done)
-		fmt.Fprint(os.Stderr, "\r\033[K") // clear spinner line
-
+		// An expired cache is brought up to date from what changed since;
+		// without one, run a fresh scan — live in the analyzer, or behind a
+		// progress spinner for reports.
+		if !rescan {
+			root, err = refreshWithProgress(target, exclude)
+		}
+		if err != nil && interactiveAnalyze(cmd) {
+			// Open the analyzer straight away and fill it in as the scan
+			// completes each folder.
+			scanner := analyze.NewScanner(8, exclude)
+			scanner.ExpectScanSize(analyze.ExpectedScanSize(target))
+			runAnalyzeTUI(analyze.NewScanningAnalyzeModel(target, scanner), analyzeStaleAge(cmd))
+			return
+		}
 		if err != nil {
+			root, err = scanWithProgress(target, exclude)
+		}
+		if err != nil {
+			if jsonOutput {
+				output.Fail("analyze", err)
+			}
 			fmt.Fprintf(os.Stderr, "Error scanning: %v\n", err)
 			os.Exit(1)
 		}
 
 		// Persist results for next time.
 		_ = analyze.SaveCache(root, target)
+
+		// Tracked roots get an extra trend sample from interactive scans.
+		if analyze.HasHistory(root.Path) {
+			_ = analyze.RecordHistory(root)
+		}
 	}
 
-	// Launch the TUI.
-	model := analyze.NewAnalyzeModel(root)
+	if path, _ := cmd.Flags().GetString("export"); path != "" {
+		format, _ := cmd.Flags().GetString("export-format")
+		exportAnalysis(root, path, format, exclude)
+		return
+	}
+	if name, _ := cmd.Flags().GetString("snapshot"); name != "" {
+		saveSnapshot(root, name, exclude)
+		return
+	}
+	if ref, _ := cmd.Flags().GetString("compare"); ref != "" {
+		depth, _ := cmd.Flags().GetInt("depth")
+		compareSnapshot(root, ref, depth, analyzeMinSize(cmd))
+		return
+	}
+	if top, _ := cmd.Flags().GetInt("top"); top > 0 {
+		printTopFiles(root, top)
+		return
+	}
+	if types, _ := cmd.Flags().GetBool("types"); types {
+		printTypeBreakdown(root)
+		return
+	}
+	staleAge := analyzeStaleAge(cmd)
+	if stale, _ := cmd.Flags().GetBool("stale"); stale {
+		printStaleDirs(root, staleAge)
+		return
+	}
+
+	if jsonOutput {
+		writeAnalyzeJSON(cmd, root)
+		return
+	}
+
+	runAnalyzeTUI(analyze.NewAnalyzeModel(root), staleAge)
+}
+
+// interactiveAnalyze reports whether the run ends in the analyzer rather
+// than printing a report.
+func interactiveAnalyze(cmd *cobra.Command) bool {
+	if jsonOutput {
+		return false
+	}
+	for _, name := range []string{"export", "snapshot", "compare"} {
+		if v, _ := cmd.Flags().GetString(name); v != "" {
+			return false
+		}
+	}
+	if top, _ := cmd.Flags().GetInt("top"); top > 0 {
+		return false
+	}
+	for _, name := range []string{"types", "stale"} {
+		if v, _ := cmd.Flags().GetBool(name); v {
+			return false
+		}
+	}
+	return true
+}
+
+// runAnalyzeTUI runs the analyzer and hands any junk the user picked over
+// to the cleaner.
+func runAnalyzeTUI(model analyze.AnalyzeModel, staleAge time.Duration) {
+	model.DryRun = dryRun
+	model.IsWhitelisted = analyzeWhitelist()
+	model.StaleAge = staleAge
 	p := tea.NewProgram(model, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fm, ok := final.(analyze.AnalyzeModel); ok && len(fm.JunkRequest()) > 0 {
+		cleanAnalyzedJunk(fm.JunkRequest())
+	}
+}
+
+// pickDrive shows the drive overview and returns the root of the drive
+// picked, or "" if the user quit. In JSON mode it writes the drive list and
+// returns "".
+func pickDrive() string {
+	if jsonOutput {
+		drives, err := analyze.ListDrives()
+		if err != nil {
+			output.Fail("analyze", err)
+		}
+		output.JSON(drives)
+		return ""
+	}
+
+	p := tea.NewProgram(analyze.NewDrivesModel(), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if fm, ok := final.(analyze.DrivesModel); ok {
+		return fm.Selected()
+	}
+	return ""
+}
+
+// writeAnalyzeJSON writes the scanned tree to stdout, limited by the
+// --depth (default 1) and --min-size flags.
+func writeAnalyzeJSON(cmd *cobra.Command, root *analyze.DirEntry) {
+	depth, _ := cmd.Flags().GetInt("depth")
+	if depth <= 0 {
+		depth = 1
+	}
+
+	output.JSON(root.Trim(depth, analyzeMinSize(cmd)))
+}
+
+// analyzeMinSize returns the --min-size flag in bytes, or 0 when unset.
+func analyzeMinSize(cmd *cobra.Command) int64 {
+	s, _ := cmd.Flags().GetString("min-size")
+	if s == "" {
+		return 0
+	}
+	size, err := config.ParseSize(s)
+	if err != nil {
+		if jsonOutput {
+			output.Fail("analyze", fmt.Errorf("invalid size format: %w", err))
+		}
+		fmt.Fprintf(os.Stderr, "Error: invalid size format: %v\n", err)
+		os.Exit(1)
+	}
+	return size
+}
+
+// exportAnalysis writes the scan to path in format, or the format implied
+// by the file extension when format is empty.
+func exportAnalysis(root *analyze.DirEntry, path, format string, exclude []string) {
+	if format == "" {
+		format = analyze.ExportFormatFor(path)
+	}
+	meta := scanMeta(root, exclude)
+	err := writeExportFile(path, func(f *os.File) error {
+		return analyze.WriteExport(f, root, meta, format)
+	})
+	if err != nil {
+		if jsonOutput {
+			output.Fail("analyze", err)
+		}
+		fmt.Fprintf(os.Stderr, "Error: cannot export to %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		output.JSON(map[string]any{"exported": path, "format": format, "root": root.Path, "size": root.Size})
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s  Exported %s (%s) to %s",
+		ui.IconSuccess, root.Path, core.FormatSize(root.Size), path)))
+	fmt.Println()
+}
+
+// writeExportFile creates path and fills it with write, removing the file
+// again if writing fails.
+func writeExportFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// printTopFiles lists the n largest files under root.
+func printTopFiles(root *analyze.DirEntry, n int) {
+	files := analyze.TopFiles(root, n)
+	if jsonOutput {
+		output.JSON(files)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader(fmt.Sprintf("Largest files in %s", root.Path), 60))
+	fmt.Println()
+	if len(files) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No files found."))
+		fmt.Println()
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %10s  %-7s %-6s %-20s %s", "Size", "Ext", "Age", "Owner", "Path")))
+	var total int64
+	for _, f := range files {
+		total += f.Size
+		fmt.Printf("  %10s  %-7.7s %-6s %-20.20s %s\n",
+			core.FormatSize(f.Size), f.Ext, analyze.FormatAge(f.ModTime), f.Owner, f.Path)
+	}
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %d files, %s total", len(files), core.FormatSize(total))))
+	fmt.Println()
+}
+
+// printTypeBreakdown shows the space taken by each file category and the
+// largest extensions under root.
+func printTypeBreakdown(root *analyze.DirEntry) {
+	b := analyze.BreakdownTypes(root)
+	if jsonOutput {
+		output.JSON(b)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader(fmt.Sprintf("File types in %s", root.Path), 60))
+	fmt.Println()
+	if b.Total == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No files found."))
+		fmt.Println()
+		return
+	}
+
+	const maxExtensions = 20
+	row := func(st analyze.TypeStat, note string) {
+		pct := float64(st.Size) / float64(b.Total) * 100
+		fmt.Printf("  %-12.12s %s %5.1f%%  %10s  %s\n", st.Name, ui.GradientBar(pct, 20), pct,
+			core.FormatSize(st.Size), ui.MutedStyle().Render(fmt.Sprintf("%d files%s", st.Files, note)))
+	}
+	fmt.Println(ui.BoldStyle().Render("  By category"))
+	for _, st := range b.Categories {
+		row(st, "")
+	}
+	fmt.Println()
+	fmt.Println(ui.BoldStyle().Render("  By extension"))
+	for i, st := range b.Extensions {
+		if i == maxExtensions {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  ... and %d more", len(b.Extensions)-maxExtensions)))
+			break
+		}
+		row(st, "  "+st.Category)
+	}
+	fmt.Println()
+}
+
+// analyzeStaleAge returns the --stale-age flag.
+func analyzeStaleAge(cmd *cobra.Command) time.Duration {
+	raw, _ := cmd.Flags().GetString("stale-age")
+	age, err := config.ParseAge(raw)
+	if err != nil || age <= 0 {
+		if err == nil {
+			err = fmt.Errorf("invalid age %q", raw)
+		}
+		if jsonOutput {
+			output.Fail("analyze", err)
+		}
+		fmt.Fprintf(os.Stderr, "Error: --stale-age: %v\n", err)
+		os.Exit(1)
+	}
+	return age
+}
+
+// printStaleDirs lists the folders under root untouched for age, with the
+// space they would free.
+func printStaleDirs(root *analyze.DirEntry, age time.Duration) {
+	dirs := analyze.StaleDirs(root, age, time.Now())
+	if jsonOutput {
+		output.JSON(map[string]any{
+			"root":        root.Path,
+			"age_days":    int(age.Hours() / 24),
+			"reclaimable": analyze.StaleTotal(dirs),
+			"folders":     dirs,
+		})
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader(fmt.Sprintf("Stale folders in %s", root.Path), 60))
+	fmt.Println()
+	if len(dirs) == 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Nothing untouched for %s or more.", analyze.FormatStaleAge(age))))
+		fmt.Println()
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %10s  %-11s %s", "Size", "Newest file", "Folder")))
+	for _, d := range dirs {
+		fmt.Printf("  %10s  %-11s %s\n", core.FormatSize(d.Size), d.Newest.Format("2006-01-02"), d.Path)
+	}
+	fmt.Println()
+	fmt.Println(ui.BoldStyle().Render(fmt.Sprintf("  %d folders untouched for %s or more, %s reclaimable",
+		len(dirs), analyze.FormatStaleAge(age), core.FormatSize(analyze.StaleTotal(dirs)))))
+	fmt.Println()
+}
+
+// cleanAnalyzedJunk runs the clean selection flow over junk picked in the
+// analyzer: a checkbox selector, confirmation, then whitelist-aware deletion.
+func cleanAnalyzedJunk(items []clean.CleanItem) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Description != items[j].Description {
+			return items[i].Description < items[j].Description
+		}
+		return items[i].Size > items[j].Size
+	})
+
+	selectorItems := make([]ui.SelectorItem, 0, len(items))
+	for _, item := range items {
+		selectorItems = append(selectorItems, ui.SelectorItem{
+			Label:       filepath.Base(item.Path),
+			Description: item.Path,
+			Value:       item.Path,
+			Size:        core.FormatSize(item.Size),
+			Bytes:       item.Size,
+			Selected:    true,
+			Category:    item.Description,
+		})
+	}
+
+	selected, err := ui.RunSelector(selectorItems, "Select junk to clean:")
+	if err != nil {
+		fmt.Printf("%s Selector error: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
+		os.Exit(1)
+	}
+	if len(selected) == 0 {
+		fmt.Println()
+		fmt.Println(ui.MutedStyle().Render("  Nothing selected."))
+		fmt.Println()
+		return
+	}
+
+	bySize := make(map[string]int64, len(items))
+	for _, item := range items {
+		bySize[item.Path] = item.Size
+	}
+	var totalSize int64
+	for _, item := range selected {
+		totalSize += bySize[item.Value]
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.BoldStyle().Render(fmt.Sprintf("Will delete %d items (%s)",
+		len(selected), core.FormatSize(totalSize))))
+	fmt.Println()
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] No files were deleted"))
+		fmt.Println()
+		return
+	}
+
+	confirmed, err := ui.Confirm("Proceed with deletion?")
+	if err != nil || !confirmed {
+		fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+		fmt.Println()
+		return
+	}
+
+	isWhitelisted := analyzeWhitelist()
+
+	var freed int64
+	var cleaned, failed int
+	for _, item := range selected {
+		n, delErr := core.SafeDeleteWithWhitelist(item.Value, false, isWhitelisted)
+		if delErr != nil {
+			failed++
+			continue
+		}
+		freed += n
+		cleaned++
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s  Freed %s across %d items", ui.IconSuccess, core.FormatSize(freed), cleaned)))
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  %d items skipped (locked, access denied, whitelisted, or safety check)",
+				ui.IconWarning, failed)))
+	}
+	fmt.Println()
+}
+
+// analyzeWhitelist returns the user's whitelist check, or nil when none is
+// configured.
+func analyzeWhitelist() func(string) bool {
+	if cfg, cfgErr := config.Load(); cfgErr == nil {
+		if wl, wlErr := whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt")); wlErr == nil && wl != nil {
+			return wl.IsWhitelisted
+		}
+	}
+	return nil
+}
+
+// scanWithProgress scans target while showing a spinner on stderr.
+func scanWithProgress(target string, exclude []string) (*analyze.DirEntry, error) {
+	scanner := analyze.NewScanner(8, exclude)
+	scanner.ExpectScanSize(analyze.ExpectedScanSize(target))
+	stop := showScanProgress(scanner, "Scanning "+target)
+	root, err := scanner.Scan(target)
+	stop()
+	return root, err
+}
+
+// refreshWithProgress brings the cached scan of target up to date, reading
+// only folders that changed since, while showing a spinner on stderr.
+func refreshWithProgress(target string, exclude []string) (*analyze.DirEntry, error) {
+	root, err := analyze.LoadStaleCache(target)
+	if err != nil {
+		return nil, err
+	}
+	scanner := analyze.NewScanner(8, exclude)
+	stop := showScanProgress(scanner, "Refreshing "+target)
+	_, err = scanner.Refresh(root)
+	stop()
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// showScanProgress draws a spinner with the scanner's progress on stderr
+// until the returned function is called.
+func showScanProgress(scanner *analyze.Scanner, label string) func() {
+	done := make(chan struct{})
+	go func() {
+		frame := 0
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				frame = (frame + 1) % len(ui.SpinnerFrames)
+				fmt.Fprintf(os.Stderr, "\r\033[K  %s %s … %s",
+					ui.SpinnerFrames[frame], label, analyze.FormatScanProgress(scanner.Progress()))
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		fmt.Fprint(os.Stderr, "\r\033[K") // clear spinner line
+	}
 }
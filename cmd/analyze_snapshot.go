@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/analyze"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Snapshots ───────────────────────────────────────────────────────────────
+
+const (
+	// compareDefaultDepth is how many folder levels --compare shows when
+	// --depth is not given.
+	compareDefaultDepth = 3
+
+	// compareMaxChildren caps the folders listed under each folder.
+	compareMaxChildren = 10
+
+	// compareDefaultMinChange hides folders that changed by less than this
+	// when --min-size is not given.
+	compareDefaultMinChange = 1 << 20
+
+	// compareTopGrowth is the number of folders listed as biggest growth.
+	compareTopGrowth = 10
+)
+
+// scanMeta describes the scan of root for exports and snapshots.
+func scanMeta(root *analyze.DirEntry, exclude []string) analyze.ExportMeta {
+	scannedAt := root.ScannedAt()
+	if scannedAt.IsZero() {
+		scannedAt = time.Now()
+	}
+	return analyze.ExportMeta{
+		Tool:      "purewin",
+		Version:   appVersion,
+		ScannedAt: scannedAt,
+		Root:      root.Path,
+		Excluded:  exclude,
+	}
+}
+
+// saveSnapshot stores the scan of root under name.
+func saveSnapshot(root *analyze.DirEntry, name string, exclude []string) {
+	if err := analyze.SaveSnapshot(name, root, scanMeta(root, exclude)); err != nil {
+		if jsonOutput {
+			output.Fail("analyze", err)
+		}
+		fmt.Fprintf(os.Stderr, "Error: cannot save snapshot: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		output.JSON(map[string]any{"snapshot": name, "root": root.Path, "size": root.Size})
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s  Saved snapshot %q of %s (%s)",
+		ui.IconSuccess, name, root.Path, ui.FormatSize(root.Size))))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Compare later with: pw analyze %s --compare %s", root.Path, name)))
+	fmt.Println()
+}
+
+// compareSnapshot shows how the folders of root changed since a snapshot,
+// depth levels deep, hiding folders that changed by less than minChange.
+func compareSnapshot(root *analyze.DirEntry, ref string, depth int, minChange int64) {
+	old, meta, err := analyze.LoadSnapshot(ref)
+	if err != nil {
+		if jsonOutput {
+			output.Fail("analyze", err)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if names, _ := analyze.ListSnapshots(); len(names) > 0 {
+			fmt.Fprintf(os.Stderr, "Saved snapshots: %s\n", strings.Join(names, ", "))
+		}
+		os.Exit(1)
+	}
+
+	delta := analyze.CompareTrees(old, root)
+	growth := analyze.BiggestGrowth(delta, compareTopGrowth)
+	if jsonOutput {
+		output.JSON(map[string]any{
+			"snapshot":       ref,
+			"snapshot_time":  meta.ScannedAt,
+			"root":           root.Path,
+			"tree":           delta,
+			"biggest_growth": growth,
+		})
+		return
+	}
+
+	if depth <= 0 {
+		depth = compareDefaultDepth
+	}
+	if minChange <= 0 {
+		minChange = compareDefaultMinChange
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader(fmt.Sprintf("Changes in %s", root.Path), 60))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Since snapshot %q of %s, taken %s",
+		ref, meta.Root, meta.ScannedAt.Local().Format("2006-01-02 15:04"))))
+	fmt.Println()
+	fmt.Printf("  %s %s  %s\n", ui.BoldStyle().Render("Net change:"), formatSizeDelta(delta.Delta),
+		ui.MutedStyle().Render(fmt.Sprintf("(%s → %s)", ui.FormatSize(delta.Old), ui.FormatSize(delta.New))))
+	fmt.Println()
+
+	printDeltaTree(delta.Children, "  ", depth, minChange)
+
+	if len(growth) > 0 {
+		fmt.Println()
+		fmt.Println(ui.BoldStyle().Render("  Biggest growth"))
+		for i, g := range growth {
+			path := g.Path
+			if i < 3 {
+				path = ui.WarningStyle().Bold(true).Render(path)
+			}
+			fmt.Printf("    %s %s  %s\n", ui.IconArrow, formatSizeDelta(g.Delta), path)
+		}
+	}
+	fmt.Println()
+}
+
+// printDeltaTree prints the folders in ds that changed by at least
+// minChange, with their subfolders down to depth levels.
+func printDeltaTree(ds []*analyze.SizeDelta, indent string, depth int, minChange int64) {
+	var shown []*analyze.SizeDelta
+	hidden := 0
+	for _, d := range ds {
+		if absSize(d.Delta) >= minChange {
+			shown = append(shown, d)
+		} else if d.Delta != 0 {
+			hidden++
+		}
+	}
+	// Biggest changes first, growth or shrinkage.
+	sort.SliceStable(shown, func(i, j int) bool { return absSize(shown[i].Delta) > absSize(shown[j].Delta) })
+	if len(shown) > compareMaxChildren {
+		hidden += len(shown) - compareMaxChildren
+		shown = shown[:compareMaxChildren]
+	}
+
+	for i, d := range shown {
+		branch, next := "├─ ", "│  "
+		if i == len(shown)-1 && hidden == 0 {
+			branch, next = "└─ ", "   "
+		}
+		note := ui.MutedStyle().Render(fmt.Sprintf("%s → %s", ui.FormatSize(d.Old), ui.FormatSize(d.New)))
+		switch {
+		case d.Added:
+			note = ui.MutedStyle().Render("new")
+		case d.Removed:
+			note = ui.MutedStyle().Render("removed")
+		}
+		fmt.Printf("%s%s%-32s %s  %s\n", indent, ui.MutedStyle().Render(branch), d.Name, formatSizeDelta(d.Delta), note)
+		if depth > 1 {
+			printDeltaTree(d.Children, indent+ui.MutedStyle().Render(next), depth-1, minChange)
+		}
+	}
+	if hidden > 0 {
+		fmt.Printf("%s%s%s\n", indent, ui.MutedStyle().Render("└─ "),
+			ui.MutedStyle().Render(fmt.Sprintf("%d smaller changes", hidden)))
+	}
+}
+
+func absSize(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
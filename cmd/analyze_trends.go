@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/analyze"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var analyzeRecordCmd = &cobra.Command{
+	Use:   "record [paths...]",
+	Short: "Scan roots and record a growth sample",
+	Long: `Scan each root without opening the TUI and append its size breakdown to
+the trend history used by 'pw analyze trends'.
+
+With no paths, the roots saved by 'pw analyze schedule' are scanned. This is
+what the scheduled background task runs.`,
+	Run: runAnalyzeRecord,
+}
+
+var analyzeScheduleCmd = &cobra.Command{
+	Use:   "schedule [paths...]",
+	Short: "Schedule periodic background scans",
+	Long: `Register a Task Scheduler task that periodically records growth samples
+for the given roots. Run without paths to show the current schedule.
+
+Examples:
+  pw analyze schedule C:\Users D:\Projects --every weekly
+  pw analyze schedule --remove`,
+	Run: runAnalyzeSchedule,
+}
+
+var analyzeTrendsCmd = &cobra.Command{
+	Use:   "trends [path]",
+	Short: "Show directory growth over time",
+	Long: `Chart how a scanned root has grown week over week and highlight the
+subdirectories that grew the most.
+
+Without a path, every scheduled root is reported.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runAnalyzeTrends,
+}
+
+func init() {
+	analyzeRecordCmd.Flags().StringSlice("exclude", nil, "Directories to exclude from scan")
+
+	analyzeScheduleCmd.Flags().String("every", "weekly", "Scan frequency: daily, weekly or monthly")
+	analyzeScheduleCmd.Flags().String("at", "03:00", "Local start time (HH:MM)")
+	analyzeScheduleCmd.Flags().Bool("remove", false, "Remove the scheduled scan")
+
+	analyzeTrendsCmd.Flags().Int("weeks", 12, "Number of weeks to report")
+	analyzeTrendsCmd.Flags().Int("top", 10, "Number of top growers to show")
+
+	analyzeCmd.AddCommand(analyzeRecordCmd)
+	analyzeCmd.AddCommand(analyzeScheduleCmd)
+	analyzeCmd.AddCommand(analyzeTrendsCmd)
+}
+
+// ─── Record ──────────────────────────────────────────────────────────────────
+
+func runAnalyzeRecord(cmd *cobra.Command, args []string) {
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+
+	roots := args
+	if len(roots) == 0 {
+		sched, err := analyze.LoadSchedule()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot read schedule: %v\n", err)
+			os.Exit(1)
+		}
+		roots = sched.Roots
+	}
+	if len(roots) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no roots given and no scheduled roots configured")
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, target := range roots {
+		if abs, absErr := filepath.Abs(target); absErr == nil {
+			target = abs
+		}
+		root, err := scanWithProgress(target, exclude)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning %s: %v\n", target, err)
+			failed++
+			continue
+		}
+		if err := analyze.RecordHistory(root); err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording %s: %v\n", target, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s %-40s %s\n", ui.IconSuccess, root.Path, ui.FormatSize(root.Size))
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// ─── Schedule ────────────────────────────────────────────────────────────────
+
+func runAnalyzeSchedule(cmd *cobra.Command, args []string) {
+	remove, _ := cmd.Flags().GetBool("remove")
+	every, _ := cmd.Flags().GetString("every")
+	at, _ := cmd.Flags().GetString("at")
+
+	sched, err := analyze.LoadSchedule()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Cannot read schedule: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if remove {
+		if err := core.DeleteScheduledTask(analyze.ScheduleTaskName); err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		sched.Roots = nil
+		_ = analyze.SaveSchedule(sched)
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Scheduled scan removed.", ui.IconSuccess)))
+		return
+	}
+
+	if len(args) == 0 {
+		printAnalyzeSchedule(sched)
+		return
+	}
+
+	roots := make([]string, 0, len(args))
+	for _, a := range args {
+		abs, absErr := filepath.Abs(a)
+		if absErr != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s Cannot resolve %s: %v", ui.IconError, a, absErr)))
+			os.Exit(1)
+		}
+		if _, statErr := os.Stat(abs); statErr != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s Cannot access %s: %v", ui.IconError, abs, statErr)))
+			os.Exit(1)
+		}
+		roots = append(roots, abs)
+	}
+
+	sched.Roots = roots
+	sched.Frequency = strings.ToLower(every)
+	sched.StartTime = at
+	if err := core.CreateScheduledTask(sched.Task()); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if err := analyze.SaveSchedule(sched); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Cannot save schedule: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s Scheduled %s scans at %s", ui.IconSuccess, sched.Frequency, at)))
+	printAnalyzeSchedule(sched)
+}
+
+func printAnalyzeSchedule(sched *analyze.Schedule) {
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Scheduled Scans", 50))
+	if len(sched.Roots) == 0 || !core.ScheduledTaskExists(analyze.ScheduleTaskName) {
+		fmt.Println(ui.MutedStyle().Render("  No scheduled scans. Use 'pw analyze schedule <paths...>'."))
+		fmt.Println()
+		return
+	}
+	fmt.Printf("  %s %s at %s\n", ui.MutedStyle().Render("Runs:"), sched.Frequency, sched.StartTime)
+	for _, r := range sched.Roots {
+		fmt.Printf("  %s %s\n", ui.IconBullet, r)
+	}
+	fmt.Println()
+}
+
+// ─── Trends ──────────────────────────────────────────────────────────────────
+
+func runAnalyzeTrends(cmd *cobra.Command, args []string) {
+	weeks, _ := cmd.Flags().GetInt("weeks")
+	top, _ := cmd.Flags().GetInt("top")
+	if weeks < 1 {
+		weeks = 1
+	}
+
+	var roots []string
+	if len(args) > 0 {
+		abs, err := filepath.Abs(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s Cannot resolve path: %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		roots = []string{abs}
+	} else if sched, err := analyze.LoadSchedule(); err == nil {
+		roots = sched.Roots
+	}
+	if len(roots) == 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			"  No roots to report. Pass a path or set up 'pw analyze schedule'."))
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -7*weeks)
+	for _, r := range roots {
+		samples, err := analyze.LoadHistory(filepath.Clean(r))
+		if err != nil || len(samples) == 0 {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("  No history for %s. Run 'pw analyze record %s' first.", r, r)))
+			continue
+		}
+		printTrend(analyze.ComputeTrend(samples, since), weeks, top)
+	}
+}
+
+// printTrend renders a weekly size chart and the top growers for one root.
+func printTrend(t analyze.Trend, weeks, top int) {
+	fmt.Println()
+	fmt.Println(ui.SectionHeader(fmt.Sprintf("Growth: %s", t.RootPath), 60))
+
+	if len(t.Weeks) == 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  No samples in the last %d weeks.", weeks)))
+		return
+	}
+
+	var maxSize int64
+	for _, w := range t.Weeks {
+		if w.Size > maxSize {
+			maxSize = w.Size
+		}
+	}
+
+	const barWidth = 30
+	var prev int64
+	for i, w := range t.Weeks {
+		filled := 0
+		if maxSize > 0 {
+			filled = int(float64(w.Size) / float64(maxSize) * barWidth)
+		}
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+		change := ""
+		if i > 0 {
+			change = formatSizeDelta(w.Size - prev)
+		}
+		fmt.Printf("  %s  %s  %10s  %s\n",
+			ui.MutedStyle().Render(w.WeekStart.Format("2006-01-02")),
+			ui.InfoStyle().Render(bar),
+			ui.FormatSize(w.Size),
+			change)
+		prev = w.Size
+	}
+
+	total := t.Last.Size - t.First.Size
+	fmt.Println()
+	fmt.Printf("  %s %s over %s\n",
+		ui.BoldStyle().Render("Net change:"),
+		formatSizeDelta(total),
+		t.Last.Timestamp.Sub(t.First.Timestamp).Round(time.Hour))
+
+	if len(t.Growers) == 0 {
+		fmt.Println()
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.BoldStyle().Render("  Top growers"))
+	shown := 0
+	for _, g := range t.Growers {
+		if shown >= top || g.Delta <= 0 {
+			break
+		}
+		name := g.Name
+		if shown < 3 {
+			name = ui.WarningStyle().Bold(true).Render(name)
+		}
+		perWeek := ""
+		if g.PerWeek > 0 {
+			perWeek = ui.MutedStyle().Render(fmt.Sprintf("(%s/week)", ui.FormatSize(g.PerWeek)))
+		}
+		fmt.Printf("    %s %-40s %s  %s %s\n",
+			ui.IconArrow, name, formatSizeDelta(g.Delta),
+			ui.MutedStyle().Render(ui.FormatSize(g.Last)), perWeek)
+		shown++
+	}
+	fmt.Println()
+}
+
+// formatSizeDelta renders a signed size change, red for growth and green
+// for shrinkage.
+func formatSizeDelta(delta int64) string {
+	switch {
+	case delta > 0:
+		return ui.ErrorStyle().Render("+" + ui.FormatSize(delta))
+	case delta < 0:
+		return ui.SuccessStyle().Render("-" + ui.FormatSize(-delta))
+	}
+	return ui.MutedStyle().Render("±0")
+}
@@ -5,13 +5,19 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/cy-infamous/purewin/internal/analyze"
 	"github.com/cy-infamous/purewin/internal/clean"
 	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/report"
 	"github.com/cy-infamous/purewin/internal/ui"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
 )
@@ -24,28 +30,615 @@ var cleanCmd = &cobra.Command{
 When run without arguments or category flags, scans the current working directory for junk —
 temp files, logs, caches, build artifacts, and OS-generated clutter.
 
-Use category flags (--all, --user, --system, --browser, --dev) for system-wide cleanup of
-known cache and temp locations.
+Use category flags (--all, --user, --system, --browser, --dev, --apps) or
+--category <name> for system-wide cleanup of known cache and temp locations.
+
+--dry-run lists what would be removed and, in a terminal, opens a tree view
+of every file and folder per target (skip it with --no-tree).
+
+--older-than skips files modified within the given period. A flagged folder
+counts as modified when anything inside it is, and items whose age cannot
+be read are skipped. Temp folders already skip files younger than a day,
+since those may still be in use.
+
+--prefetch (admin) removes prefetch and ReadyBoot traces older than 30 days.
+It is never part of --all: Windows uses the traces to speed up boot and
+app launches, and rebuilds them slowly after they are deleted.
+
+--all-users (admin) applies the user and browser targets, including
+per-user error reports, to every profile under C:\Users and reports the
+size found per user. On its own it implies --user --browser.
+
+--exclude skips paths matching a glob for this run only, on top of the
+saved whitelist. "**" spans folders, and a pattern without a folder
+(e.g. "*.log") matches at any depth. Repeat it for several patterns.
+
+Every target has a risk level (low, medium or high). Before high-risk
+targets or Windows.old are cleaned, a System Restore point is created
+(admin only); --no-restore-point skips it. --max-risk limits a
+run to targets at or below a level; runs with --yes default to low.
+
+Online-only OneDrive and other cloud files are never scanned or deleted, so
+a clean cannot trigger downloads or remove files that exist only in the
+cloud. Locally stored items inside a sync folder are deleted from the cloud
+as well unless --free-cloud frees only their local copy.
+
+--archive-logs zips .log files older than 7 days into one archive per folder
+instead of deleting them, and keeps newer logs. To make this the default, set
+"log_retention": {"mode": "archive", "archive_after": "14d"} in config.json.
 
 Examples:
   pw clean                 Scan current directory for junk
   pw clean D:\Projects     Scan a specific directory
   pw clean D:\             Scan an entire drive
+  pw clean D:\ --prune-empty
+                           Also remove folders left with no files in them
+  pw clean $env:OneDrive --free-cloud
+                           Free up local space instead of deleting synced files
+  pw clean D:\Logs --archive-logs
+                           Zip old logs per folder instead of deleting them
   pw clean --all           System-wide cleanup (all categories)
-  pw clean --user --dev    System-wide cleanup (user + dev caches only)`,
+  pw clean --user --dev    System-wide cleanup (user + dev caches only)
+  pw clean --all-users     Clean temp files and browser caches of every user
+  pw clean --all --exclude "**/*.log" --exclude "C:\Work\**"
+                           Keep logs and everything under C:\Work this run
+  pw clean --category apps Desktop app and Store (UWP) app caches
+  pw clean --all --select  Choose targets from a checklist after scanning
+  pw clean --all --diff    Show which targets grew since the last run
+  pw clean --all --older-than 30d
+                           Only clean files untouched for 30 days
+  pw clean --all --emit-script cleanup.ps1
+                           Write a reviewable removal script instead of deleting
+  pw clean --user --yes    Clean without prompting (used by 'pw schedule')
+  pw clean --all --yes --max-risk medium
+                           Unattended, but also clean medium-risk targets
+  pw clean --all --on-reboot
+                           Queue files held open by running programs for deletion at reboot
+  pw clean --all --report cleanup.html
+                           Save an audit report (.html or .md) of the run
+  pw clean scan --all --json | pw filter --min-size 50MB | pw clean apply --from - --yes`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runClean,
 }
 
 func init() {
-	cleanCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview the cleanup plan without deleting")
 	cleanCmd.Flags().Bool("whitelist", false, "Manage protected caches")
-	cleanCmd.Flags().Bool("all", false, "Clean all categories")
-	cleanCmd.Flags().Bool("user", false, "Clean user caches only")
-	cleanCmd.Flags().Bool("system", false, "Clean system caches only (requires admin)")
-	cleanCmd.Flags().Bool("browser", false, "Clean browser caches only")
-	cleanCmd.Flags().Bool("dev", false, "Clean developer tool caches only")
-	cleanCmd.Flags().Int("depth", 0, "Maximum directory depth to scan (path mode only, 0 = unlimited)")
+	cleanCmd.Flags().String("emit-script", "", "Write a PowerShell script performing the cleanup instead of deleting")
+	cleanCmd.Flags().Bool("no-tree", false, "With --dry-run, skip the tree view of files that would be deleted")
+	cleanCmd.Flags().Bool("diff", false, "Show how each target grew or shrank since the last run, then exit")
+	cleanCmd.Flags().Bool("select", false, "Pick which targets to clean from a checklist after scanning")
+	cleanCmd.Flags().Bool("on-reboot", false, "Queue files locked by running programs for deletion at next reboot (requires admin)")
+	cleanCmd.Flags().Bool("free-cloud", false, "Free up space on OneDrive and other cloud-synced files instead of deleting them")
+	cleanCmd.Flags().Bool("prune-empty", false, "Also remove folder trees that hold no files (path mode only)")
+	cleanCmd.Flags().Bool("archive-logs", false, "Zip old .log files per folder instead of deleting them (path mode only)")
+	cleanCmd.Flags().String("report", "", "Write an audit report of the run (.html or .md)")
+	cleanCmd.Flags().BoolP("yes", "y", false, "Clean without prompting (unattended; skips Windows.old)")
+	cleanCmd.PersistentFlags().Bool("all", false, "Clean all categories")
+	cleanCmd.PersistentFlags().Bool("user", false, "Clean user caches only")
+	cleanCmd.PersistentFlags().Bool("system", false, "Clean system caches only (requires admin)")
+	cleanCmd.PersistentFlags().Bool("browser", false, "Clean browser caches only")
+	cleanCmd.PersistentFlags().Bool("dev", false, "Clean developer tool caches only")
+	cleanCmd.PersistentFlags().Bool("apps", false, "Clean desktop app caches only (Teams, Discord, Slack, Spotify, WhatsApp, Store apps)")
+	cleanCmd.PersistentFlags().StringSlice("category", nil, "Categories to clean: user, system, browser, dev, apps, ai, prefetch (repeatable)")
+	cleanCmd.PersistentFlags().String("older-than", "", "Only clean files not modified within this period (e.g. 30d, 2w, 12h); items of unknown age are skipped")
+	cleanCmd.PersistentFlags().String("max-risk", "", "Only clean targets at or below this risk: low, medium, high (default: low with --yes, otherwise high)")
+	cleanCmd.PersistentFlags().Bool("prefetch", false, "Clean prefetch and ReadyBoot traces older than 30 days (requires admin, not part of --all)")
+	cleanCmd.PersistentFlags().Bool("all-users", false, "Apply user and browser targets to every profile under C:\\Users (requires admin)")
+	cleanCmd.PersistentFlags().Bool("ai", false, "Clean Recall, Copilot and semantic index data (privacy-sensitive, not part of --all)")
+	cleanCmd.PersistentFlags().Int("depth", 0, "Maximum directory depth to scan (path mode only, 0 = unlimited)")
+	addNiceFlag(cleanCmd.PersistentFlags())
+	addExcludeFlag(cleanCmd.PersistentFlags())
+	addRestorePointFlag(cleanCmd.PersistentFlags())
+}
+
+// ─── Category Selection ──────────────────────────────────────────────────────
+
+// cleanCategories records which category flags were set on the command line.
+// AI data and prefetch traces are opt-in only and are not implied by all.
+// allUsers applies the user and browser categories to every user profile.
+type cleanCategories struct {
+	all, user, system, browser, dev, apps, ai, prefetch bool
+
+	allUsers bool
+}
+
+// cleanCategoriesFromFlags reads the category flags from cmd, including
+// names given with --category. An unknown name exits with an error.
+func cleanCategoriesFromFlags(cmd *cobra.Command) cleanCategories {
+	var c cleanCategories
+	c.all, _ = cmd.Flags().GetBool("all")
+	c.user, _ = cmd.Flags().GetBool("user")
+	c.system, _ = cmd.Flags().GetBool("system")
+	c.browser, _ = cmd.Flags().GetBool("browser")
+	c.dev, _ = cmd.Flags().GetBool("dev")
+	c.apps, _ = cmd.Flags().GetBool("apps")
+	c.ai, _ = cmd.Flags().GetBool("ai")
+	c.prefetch, _ = cmd.Flags().GetBool("prefetch")
+	c.allUsers, _ = cmd.Flags().GetBool("all-users")
+
+	fail := func(err error) {
+		if jsonOutput {
+			output.Fail(cmd.CommandPath(), err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	names, _ := cmd.Flags().GetStringSlice("category")
+	for _, name := range names {
+		if err := c.set(name); err != nil {
+			fail(err)
+		}
+	}
+
+	// --all-users on its own cleans the per-user categories.
+	if c.allUsers {
+		if err := core.RequireAdmin("clean all user profiles"); err != nil {
+			fail(err)
+		}
+		if !c.any() {
+			c.user, c.browser = true, true
+		}
+	}
+	return c
+}
+
+// set selects the category with the given name.
+func (c *cleanCategories) set(name string) error {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "all":
+		c.all = true
+	case "user":
+		c.user = true
+	case "system":
+		c.system = true
+	case "browser":
+		c.browser = true
+	case "dev":
+		c.dev = true
+	case config.CategoryApps:
+		c.apps = true
+	case config.CategoryAI:
+		c.ai = true
+	case config.CategoryPrefetch:
+		c.prefetch = true
+	default:
+		return fmt.Errorf("unknown category %q (use user, system, browser, dev, apps, ai or prefetch)", name)
+	}
+	return nil
+}
+
+// cleanMinAge reads --older-than. Targets may enforce a longer default age
+// of their own (config.CleanTarget.MinAge); the stricter limit wins.
+func cleanMinAge(cmd *cobra.Command) time.Duration {
+	raw, _ := cmd.Flags().GetString("older-than")
+	age, err := config.ParseAge(raw)
+	if err != nil {
+		if jsonOutput {
+			output.Fail(cmd.CommandPath(), err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return age
+}
+
+// formatAge renders an age in whole days when it is at least one day.
+func formatAge(d time.Duration) string {
+	if days := d / (24 * time.Hour); days >= 1 && d%(24*time.Hour) == 0 {
+		if days == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", days)
+	}
+	return d.String()
+}
+
+// any reports whether at least one category flag was set.
+func (c cleanCategories) any() bool {
+	return c.all || c.user || c.system || c.browser || c.dev || c.apps || c.ai || c.prefetch
+}
+
+// Picker keys for items cleaned through APIs rather than scan results. The
+// "@" prefix keeps them apart from target names.
+const (
+	pickRecycleBin = "@recycle-bin"
+	pickGoModCache = "@go-modcache"
+	pickWindowsOld = "@windows-old"
+)
+
+// pickCleanTargets lets the user choose which scanned targets to clean and
+// drops the rest from scan. Windows.old starts unselected. It returns false
+// if nothing was kept.
+func pickCleanTargets(scan *cleanScan) bool {
+	entries := clean.ResultEntries(scan.results)
+	if scan.recycleBinSize > 0 {
+		entries = append(entries, clean.PickerEntry{Key: pickRecycleBin, Label: "RecycleBin",
+			Description: "Windows Recycle Bin", Category: "user", Size: scan.recycleBinSize, Selected: true})
+	}
+	if scan.goModSize > 0 {
+		entries = append(entries, clean.PickerEntry{Key: pickGoModCache, Label: "GoModCache",
+			Description: "Go module cache (go clean -modcache)", Category: "dev", Size: scan.goModSize, Selected: true})
+	}
+	if scan.windowsOldSize > 0 {
+		entries = append(entries, clean.PickerEntry{Key: pickWindowsOld, Label: "WindowsOld",
+			Description: "Previous Windows installation", Category: "system", Size: scan.windowsOldSize})
+	}
+
+	selected, err := clean.PickTargets(entries, "Select what to clean")
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if len(selected) == 0 {
+		return false
+	}
+
+	kept := scan.results[:0]
+	for _, r := range scan.results {
+		if selected[r.Category] {
+			kept = append(kept, r)
+		}
+	}
+	scan.results = kept
+	if !selected[pickRecycleBin] {
+		scan.recycleBinSize = 0
+	}
+	if !selected[pickGoModCache] {
+		scan.goModSize = 0
+	}
+	if !selected[pickWindowsOld] {
+		scan.windowsOldSize = 0
+	}
+	return true
+}
+
+// includes reports whether targets of the given config category are selected.
+// AI targets are never part of --all.
+func (c cleanCategories) includes(category string) bool {
+	switch category {
+	case "user":
+		return c.all || c.user
+	case "system":
+		return c.all || c.system
+	case "browser":
+		return c.all || c.browser
+	case "dev":
+		return c.all || c.dev
+	case config.CategoryApps:
+		return c.all || c.apps
+	case config.CategoryAI:
+		return c.ai
+	case config.CategoryPrefetch:
+		return c.prefetch
+	}
+	return false
+}
+
+// cleanScan holds the output of a system-wide scan. Besides the path-based
+// results it carries the sizes of items that are cleaned through APIs or
+// external tools rather than by deleting paths.
+type cleanScan struct {
+	results        []clean.ScanResult
+	recycleBinSize int64
+	goModSize      int64
+	windowsOldSize int64
+
+	// runningBrowsers lists browsers skipped because they are open.
+	runningBrowsers []string
+
+	// profileOf maps result names to the user profile they were found in
+	// (--all-users only).
+	profileOf map[string]string
+}
+
+// totalSize returns the combined size of everything found by the scan.
+func (s cleanScan) totalSize() int64 {
+	return clean.TotalSizeAll(s.results) + s.recycleBinSize + s.goModSize + s.windowsOldSize
+}
+
+// scanCleanCategories runs the system-wide scan for the selected categories.
+// progress may be nil.
+func scanCleanCategories(cats cleanCategories, wl *whitelist.Whitelist, isAdmin bool, progress *clean.ScanProgress) cleanScan {
+	var scan cleanScan
+
+	// Every user profile (--all-users): user and browser targets per user.
+	if cats.allUsers && (cats.all || cats.user || cats.browser) {
+		var profileResults []clean.ScanResult
+		profileResults, scan.profileOf, scan.runningBrowsers = scanUserProfiles(cats, wl, isAdmin, progress)
+		scan.results = append(scan.results, profileResults...)
+	}
+
+	// User caches: use config targets via ScanAll.
+	if (cats.all || cats.user) && !cats.allUsers {
+		userTargets := config.GetTargetsByCategory("user")
+		userResults := clean.ScanAllProgress(userTargets, wl, isAdmin, progress)
+		scan.results = append(scan.results, userResults...)
+	}
+
+	// Browser caches: use specialized multi-profile scanner.
+	if (cats.all || cats.browser) && !cats.allUsers {
+		scan.runningBrowsers = clean.RunningBrowsers()
+		browserItems := clean.ScanBrowserCaches(wl)
+		if len(browserItems) > 0 {
+			// One group per browser profile, in a stable order.
+			browserGroups := groupItemsByDescription(browserItems)
+			names := make([]string, 0, len(browserGroups))
+			for name := range browserGroups {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				scan.results = append(scan.results, clean.ItemsToResult(name, browserGroups[name]))
+			}
+		}
+	}
+
+	// Developer caches: use specialized scanner for safety.
+	if cats.all || cats.dev {
+		devItems := clean.ScanDevCaches(wl)
+		if len(devItems) > 0 {
+			devGroups := groupItemsByDescription(devItems)
+			for name, items := range devGroups {
+				scan.results = append(scan.results, clean.ItemsToResult(name, items))
+			}
+		}
+	}
+
+	// System caches: use config targets via ScanAll (admin-gated).
+	if cats.all || cats.system {
+		systemTargets := config.GetTargetsByCategory("system")
+		systemResults := clean.ScanAllProgress(systemTargets, wl, isAdmin, progress)
+		scan.results = append(scan.results, systemResults...)
+
+		// Memory dumps (separate scan).
+		dumpItems := clean.ScanMemoryDumps()
+		if len(dumpItems) > 0 {
+			scan.results = append(scan.results, clean.ItemsToResult("MemoryDumps", dumpItems))
+		}
+
+		// WER user-level reports (no admin needed). With --all-users
+		// they are part of each profile's scan.
+		if !cats.allUsers {
+			werItems := clean.ScanWERUserReports(wl)
+			if len(werItems) > 0 {
+				scan.results = append(scan.results, clean.ItemsToResult("WER User Reports", werItems))
+			}
+		}
+	}
+
+	// Desktop app caches (Electron and WebView2 apps).
+	if cats.all || cats.apps {
+		appTargets := config.GetTargetsByCategory(config.CategoryApps)
+		scan.results = append(scan.results, clean.ScanAllProgress(appTargets, wl, isAdmin, progress)...)
+
+		// Store app caches, one group per app.
+		uwpGroups := groupItemsByDescription(clean.ScanUWPCaches(wl))
+		names := make([]string, 0, len(uwpGroups))
+		for name := range uwpGroups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scan.results = append(scan.results, clean.ItemsToResult(name, uwpGroups[name]))
+		}
+	}
+
+	// AI feature data: explicit opt-in only.
+	if cats.ai {
+		aiTargets := config.GetTargetsByCategory(config.CategoryAI)
+		scan.results = append(scan.results, clean.ScanAllProgress(aiTargets, wl, isAdmin, progress)...)
+	}
+
+	// Prefetch and ReadyBoot traces: explicit opt-in only, admin-gated.
+	if cats.prefetch {
+		prefetchTargets := config.GetTargetsByCategory(config.CategoryPrefetch)
+		scan.results = append(scan.results, clean.ScanAllProgress(prefetchTargets, wl, isAdmin, progress)...)
+	}
+
+	// User-defined targets from targets.json, by their declared category.
+	var customTargets []config.CleanTarget
+	for _, t := range config.GetCustomTargets() {
+		if cats.includes(t.Category) {
+			customTargets = append(customTargets, t)
+		}
+	}
+	if len(customTargets) > 0 {
+		scan.results = append(scan.results, clean.ScanAllProgress(customTargets, wl, isAdmin, progress)...)
+	}
+
+	// Recycle Bin (user category, via Shell API).
+	if cats.all || cats.user {
+		scan.recycleBinSize, _ = clean.ScanRecycleBin()
+	}
+
+	// Go module cache size.
+	if cats.all || cats.dev {
+		scan.goModSize = clean.GoModCacheSize()
+	}
+
+	// Windows.old size.
+	if (cats.all || cats.system) && isAdmin {
+		scan.windowsOldSize = clean.WindowsOldSize()
+	}
+
+	return scan
+}
+
+// trackScanProgress returns a ScanProgress whose events drive spinner with
+// the scan's running totals and the last item found, until the returned
+// stop function is called.
+func trackScanProgress(spinner *ui.InlineSpinner) (*clean.ScanProgress, func()) {
+	events := make(chan clean.Event, 256)
+	progress := clean.NewScanProgress(events)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		var last string
+		for {
+			select {
+			case <-done:
+				return
+			case e := <-events:
+				last = e.Path
+			case <-ticker.C:
+				msg := fmt.Sprintf("Scanning... %d entries checked, %d items (%s) found",
+					progress.Entries(), progress.Found(), core.FormatSize(progress.FoundSize()))
+				if last != "" {
+					msg += " — " + filepath.Base(last)
+				}
+				spinner.UpdateMessage(msg)
+			}
+		}
+	}()
+	return progress, func() {
+		close(done)
+		<-finished
+	}
+}
+
+// ─── JSON Report ─────────────────────────────────────────────────────────────
+
+// cleanReport is the --json form of `pw clean`. JSON mode only reports what
+// would be cleaned; nothing is deleted. Use `pw clean scan` with
+// `pw clean apply` to act on the list non-interactively.
+type cleanReport struct {
+	Mode            string             `json:"mode"` // "path" or "categories"
+	Target          string             `json:"target,omitempty"`
+	Groups          []cleanReportGroup `json:"groups"`
+	RecycleBinSize  int64              `json:"recycle_bin_size,omitempty"`
+	GoModCacheSize  int64              `json:"go_mod_cache_size,omitempty"`
+	WindowsOldSize  int64              `json:"windows_old_size,omitempty"`
+	RunningBrowsers []string           `json:"running_browsers,omitempty"`
+	TotalSize       int64              `json:"total_size"`
+	TotalCount      int                `json:"total_count"`
+
+	// Diff and DiffSince are set with --diff when an earlier run exists.
+	Diff      []clean.GroupDelta `json:"diff,omitempty"`
+	DiffSince *time.Time         `json:"diff_since,omitempty"`
+
+	// MaxRisk and HeldBack are set with --max-risk; held-back groups are
+	// not part of the totals.
+	MaxRisk  string             `json:"max_risk,omitempty"`
+	HeldBack []cleanReportGroup `json:"held_back,omitempty"`
+}
+
+// cleanReportGroup is one target or junk category of a cleanReport.
+type cleanReportGroup struct {
+	Name  string            `json:"name"`
+	Size  int64             `json:"size"`
+	Count int               `json:"count"`
+	Risk  string            `json:"risk"`
+	Items []clean.CleanItem `json:"items"`
+}
+
+// add appends a group and updates the report totals.
+func (r *cleanReport) add(name string, items []clean.CleanItem) {
+	res := clean.ItemsToResult(name, items)
+	r.Groups = append(r.Groups, cleanReportGroup{
+		Name: name, Size: res.TotalSize, Count: res.ItemCount, Risk: res.RiskLevel, Items: items,
+	})
+	r.TotalSize += res.TotalSize
+	r.TotalCount += res.ItemCount
+}
+
+// holdBack lists the targets left out by the risk gate.
+func (r *cleanReport) holdBack(maxRisk string, held []clean.ScanResult) {
+	r.MaxRisk = maxRisk
+	for _, h := range held {
+		items := h.Items
+		if items == nil {
+			items = []clean.CleanItem{}
+		}
+		r.HeldBack = append(r.HeldBack, cleanReportGroup{
+			Name: h.Category, Size: h.TotalSize, Count: h.ItemCount, Risk: h.RiskLevel, Items: items,
+		})
+	}
+}
+
+// setDiff fills in the --diff fields from the previous run, if any.
+func (r *cleanReport) setDiff(cmd *cobra.Command, prev *clean.RunSummary, groups map[string]clean.GroupStat) {
+	if show, _ := cmd.Flags().GetBool("diff"); !show || prev == nil {
+		return
+	}
+	r.Diff = clean.DiffRuns(*prev, clean.RunSummary{Groups: groups})
+	r.DiffSince = &prev.Timestamp
+}
+
+// runCleanJSON scans like runClean and writes a cleanReport to stdout.
+func runCleanJSON(cmd *cobra.Command, args []string, cfg *config.Config, wl *whitelist.Whitelist) {
+	report := cleanReport{Groups: make([]cleanReportGroup, 0)}
+	cats := cleanCategoriesFromFlags(cmd)
+	// JSON output is a report, so only an explicit --max-risk filters it.
+	maxRisk, err := cleanMaxRisk(cmd, false)
+	if err != nil {
+		output.Fail("clean", err)
+	}
+
+	if len(args) > 0 || !cats.any() {
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		}
+		target, err := filepath.Abs(target)
+		if err != nil {
+			output.Fail("clean", err)
+		}
+		if info, statErr := os.Stat(target); statErr != nil {
+			output.Fail("clean", statErr)
+		} else if !info.IsDir() {
+			output.Fail("clean", fmt.Errorf("path is not a directory: %s", target))
+		}
+
+		maxDepth, _ := cmd.Flags().GetInt("depth")
+		report.Mode = "path"
+		report.Target = target
+		minAge := cleanMinAge(cmd)
+		results := clean.FilterPathResultsOlderThan(clean.ScanPath(target, wl, maxDepth), minAge, time.Now())
+		groups := clean.SummarizePathResults(results)
+		report.setDiff(cmd, recordCleanRun(cfg, pathCleanScope(target, minAge), groups), groups)
+		if cmd.Flags().Changed("max-risk") {
+			var heldPaths []clean.PathScanResult
+			results, heldPaths = clean.FilterPathResultsByRisk(results, maxRisk)
+			var held []clean.ScanResult
+			for _, r := range heldPaths {
+				held = append(held, clean.ItemsToResult(r.Label, r.Items))
+			}
+			report.holdBack(maxRisk, held)
+		}
+		for _, r := range results {
+			report.add(r.Label, r.Items)
+		}
+		if prune, _ := cmd.Flags().GetBool("prune-empty"); prune {
+			if dirs := clean.FindEmptyDirs(target, wl, maxDepth); len(dirs) > 0 {
+				report.add("Empty Folders", clean.EmptyDirItems(dirs))
+			}
+		}
+		output.JSON(report)
+		return
+	}
+
+	minAge := cleanMinAge(cmd)
+	scan := scanCleanCategories(cats, wl, core.IsElevated(), nil)
+	scan.results = clean.FilterResultsOlderThan(scan.results, minAge, time.Now())
+	groups := scan.groups()
+	report.setDiff(cmd, recordCleanRun(cfg, cats.scope(minAge), groups), groups)
+	if cmd.Flags().Changed("max-risk") {
+		report.holdBack(maxRisk, scan.limitRisk(maxRisk))
+	}
+	report.Mode = "categories"
+	for _, r := range scan.results {
+		report.add(r.Category, r.Items)
+	}
+	report.RecycleBinSize = scan.recycleBinSize
+	report.GoModCacheSize = scan.goModSize
+	report.WindowsOldSize = scan.windowsOldSize
+	report.RunningBrowsers = scan.runningBrowsers
+	report.TotalSize = scan.totalSize()
+	output.JSON(report)
 }
 
 // ─── Main Entry Point ────────────────────────────────────────────────────────
@@ -54,27 +647,35 @@ func runClean(cmd *cobra.Command, args []string) {
 	// Load configuration.
 	cfg, err := config.Load()
 	if err != nil {
+		if jsonOutput {
+			output.Fail("clean", err)
+		}
 		fmt.Println(ui.ErrorStyle().Render(
 			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
 		os.Exit(1)
 	}
 
-	// Override dry-run from config if flag not explicitly set.
-	if !cmd.Flags().Changed("dry-run") && cfg.DryRunMode {
-		dryRun = true
-	}
-
 	// Debug mode.
 	debugMode := debug || cfg.DebugMode
 
+	defer applyNiceFlag(cmd)()
+
 	// Load whitelist.
 	wlPath := filepath.Join(cfg.ConfigDir, "whitelist.txt")
 	wl, wlErr := whitelist.Load(wlPath)
 	if wlErr != nil {
-		fmt.Println(ui.WarningStyle().Render(
-			fmt.Sprintf("  %s Could not load whitelist: %v", ui.IconWarning, wlErr)))
+		if !jsonOutput {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s Could not load whitelist: %v", ui.IconWarning, wlErr)))
+		}
 		wl = nil
 	}
+	wl = applyExcludeFlag(cmd, wl)
+
+	if jsonOutput {
+		runCleanJSON(cmd, args, cfg, wl)
+		return
+	}
 
 	// ── Path mode: explicit path argument ───────────────────────────────
 	if len(args) > 0 {
@@ -82,15 +683,10 @@ func runClean(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Parse category flags.
-	allFlag, _ := cmd.Flags().GetBool("all")
-	userFlag, _ := cmd.Flags().GetBool("user")
-	systemFlag, _ := cmd.Flags().GetBool("system")
-	browserFlag, _ := cmd.Flags().GetBool("browser")
-	devFlag, _ := cmd.Flags().GetBool("dev")
+	cats := cleanCategoriesFromFlags(cmd)
 
 	// ── CWD mode: no path and no category flags → scan current directory
-	if !allFlag && !userFlag && !systemFlag && !browserFlag && !devFlag {
+	if !cats.any() {
 		cwd, cwdErr := os.Getwd()
 		if cwdErr != nil {
 			fmt.Println(ui.ErrorStyle().Render(
@@ -104,6 +700,8 @@ func runClean(cmd *cobra.Command, args []string) {
 	// ── System-wide mode: category flags were set ───────────────────────
 
 	isAdmin := core.IsElevated()
+	minAge := cleanMinAge(cmd)
+	rep, reportPath := startCleanReport(cmd, "clean")
 
 	// ── Header ───────────────────────────────────────────────────────────
 	fmt.Println()
@@ -113,88 +711,83 @@ func runClean(cmd *cobra.Command, args []string) {
 		fmt.Println(ui.WarningStyle().Render(
 			fmt.Sprintf("  %s  DRY RUN MODE — no files will be deleted", ui.IconWarning)))
 	}
-	if !isAdmin && (allFlag || systemFlag) {
+	if !isAdmin && (cats.all || cats.system) {
 		fmt.Println(ui.WarningStyle().Render(
 			fmt.Sprintf("  %s  Not running as admin — system items will be skipped", ui.IconWarning)))
 	}
+	if cats.prefetch {
+		if !isAdmin {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s  Not running as admin — prefetch traces will be skipped", ui.IconWarning)))
+		}
+		fmt.Println(ui.MutedStyle().Render("  " + config.PrefetchTradeoff))
+	}
+	if minAge > 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  Only files older than %s", formatAge(minAge))))
+	}
 	fmt.Println()
 
 	// ── Scan Phase ───────────────────────────────────────────────────────
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Scanning for cleanable files...")
 
-	var allResults []clean.ScanResult
-
-	// User caches: use config targets via ScanAll.
-	if allFlag || userFlag {
-		userTargets := config.GetTargetsByCategory("user")
-		userResults := clean.ScanAll(userTargets, wl, isAdmin)
-		allResults = append(allResults, userResults...)
+	progress, stopProgress := trackScanProgress(spinner)
+	scan := scanCleanCategories(cats, wl, isAdmin, progress)
+	stopProgress()
+	scan.results = clean.FilterResultsOlderThan(scan.results, minAge, time.Now())
+	// Unattended runs never remove Windows.old: it cannot be undone and
+	// always needs an interactive confirmation.
+	unattended, _ := cmd.Flags().GetBool("yes")
+	if unattended && scan.windowsOldSize > 0 {
+		rep.Skipped("WindowsOld", `C:\Windows.old`, "not removed in unattended runs")
+		scan.windowsOldSize = 0
 	}
+	allResults := scan.results
+	recycleBinSize := scan.recycleBinSize
+	goModSize := scan.goModSize
+	windowsOldSize := scan.windowsOldSize
 
-	// Browser caches: use specialized multi-profile scanner.
-	if allFlag || browserFlag {
-		browserItems := clean.ScanBrowserCaches(wl)
-		if len(browserItems) > 0 {
-			browserGroups := groupItemsByDescription(browserItems)
-			for name, items := range browserGroups {
-				allResults = append(allResults, clean.ItemsToResult(name, items))
-			}
-		}
-	}
+	spinner.Stop("Scan complete")
 
-	// Developer caches: use specialized scanner for safety.
-	if allFlag || devFlag {
-		devItems := clean.ScanDevCaches(wl)
-		if len(devItems) > 0 {
-			devGroups := groupItemsByDescription(devItems)
-			for name, items := range devGroups {
-				allResults = append(allResults, clean.ItemsToResult(name, items))
-			}
-		}
+	for _, name := range scan.runningBrowsers {
+		rep.Skipped("Browsers", name, "browser is running")
 	}
-
-	// System caches: use config targets via ScanAll (admin-gated).
-	if allFlag || systemFlag {
-		systemTargets := config.GetTargetsByCategory("system")
-		systemResults := clean.ScanAll(systemTargets, wl, isAdmin)
-		allResults = append(allResults, systemResults...)
-
-		// Memory dumps (separate scan).
-		dumpItems := clean.ScanMemoryDumps()
-		if len(dumpItems) > 0 {
-			allResults = append(allResults, clean.ItemsToResult("MemoryDumps", dumpItems))
-		}
-
-		// WER user-level reports (no admin needed).
-		werItems := clean.ScanWERUserReports(wl)
-		if len(werItems) > 0 {
-			allResults = append(allResults, clean.ItemsToResult("WER User Reports", werItems))
-		}
+	if len(scan.runningBrowsers) > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Skipped running browsers: %s — close them to clean their caches",
+				ui.IconWarning, strings.Join(scan.runningBrowsers, ", "))))
 	}
-
-	// Recycle Bin (user category, via Shell API).
-	var recycleBinSize int64
-	if allFlag || userFlag {
-		recycleBinSize, _ = clean.ScanRecycleBin()
+	freeCloud, _ := cmd.Flags().GetBool("free-cloud")
+	var scannedItems []clean.CleanItem
+	for _, r := range allResults {
+		scannedItems = append(scannedItems, r.Items...)
 	}
-
-	// Go module cache size.
-	var goModSize int64
-	if allFlag || devFlag {
-		goModSize = clean.GoModCacheSize()
+	printCloudNotice(progress, scannedItems, freeCloud)
+
+	// ── Record Run / Diff ────────────────────────────────────────────────
+	scope := cats.scope(minAge)
+	found := scan.groups()
+	prevRun := recordCleanRun(cfg, scope, found)
+	if showDiff, _ := cmd.Flags().GetBool("diff"); showDiff {
+		printCleanDiff(prevRun, found)
+		return
 	}
 
-	// Windows.old size.
-	var windowsOldSize int64
-	if (allFlag || systemFlag) && isAdmin {
-		windowsOldSize = clean.WindowsOldSize()
+	// ── Risk Gate ────────────────────────────────────────────────────────
+	maxRisk, riskErr := cleanMaxRisk(cmd, unattended)
+	if riskErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, riskErr)))
+		os.Exit(1)
 	}
-
-	spinner.Stop("Scan complete")
+	recordRiskHeldBack(rep, scan.limitRisk(maxRisk), maxRisk)
+	allResults = scan.results
+	recycleBinSize = scan.recycleBinSize
+	goModSize = scan.goModSize
+	windowsOldSize = scan.windowsOldSize
 
 	// ── Calculate Totals ─────────────────────────────────────────────────
-	totalSize := clean.TotalSizeAll(allResults) + recycleBinSize + goModSize + windowsOldSize
+	totalSize := scan.totalSize()
 	totalItems := clean.TotalItemCount(allResults)
 
 	if totalSize == 0 {
@@ -202,9 +795,25 @@ func runClean(cmd *cobra.Command, args []string) {
 		fmt.Println(ui.SuccessStyle().Render(
 			fmt.Sprintf("  %s  System is clean! Nothing to remove.", ui.IconSuccess)))
 		fmt.Println()
+		saveCleanReport(rep, reportPath)
 		return
 	}
 
+	// ── Pick Targets ─────────────────────────────────────────────────────
+	if pick, _ := cmd.Flags().GetBool("select"); pick && !unattended {
+		if !pickCleanTargets(&scan) {
+			fmt.Println(ui.MutedStyle().Render("  Nothing selected."))
+			fmt.Println()
+			return
+		}
+		allResults = scan.results
+		recycleBinSize = scan.recycleBinSize
+		goModSize = scan.goModSize
+		windowsOldSize = scan.windowsOldSize
+		totalSize = scan.totalSize()
+		totalItems = clean.TotalItemCount(allResults)
+	}
+
 	// ── Display Results ──────────────────────────────────────────────────
 	displayCleanResults(allResults, recycleBinSize, goModSize, windowsOldSize)
 
@@ -215,6 +824,36 @@ func runClean(cmd *cobra.Command, args []string) {
 		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totalItems)),
 	)
 	fmt.Println()
+	printProfileTotals(allResults, scan.profileOf)
+
+	// ── Emit Script: Write and Exit ──────────────────────────────────────
+	if scriptPath, _ := cmd.Flags().GetString("emit-script"); scriptPath != "" {
+		drc := core.NewDryRunContext()
+		for _, r := range allResults {
+			for _, item := range r.Items {
+				drc.Add(item.Path, item.Size, item.Category)
+			}
+		}
+		var actions []core.ScriptAction
+		if recycleBinSize > 0 {
+			actions = append(actions, core.ScriptAction{
+				Description: "Empty Recycle Bin",
+				Size:        recycleBinSize,
+				Command:     "Clear-RecycleBin -Force -ErrorAction Continue",
+			})
+		}
+		if goModSize > 0 {
+			actions = append(actions, core.ScriptAction{
+				Description: "Clean Go module cache",
+				Size:        goModSize,
+				Command:     "go clean -modcache",
+			})
+		}
+		// Windows.old is deliberately left out: it needs an interactive
+		// DangerConfirm and is better handled by Disk Cleanup / DISM.
+		writeCleanScript(drc, scriptPath, "clean", actions)
+		return
+	}
 
 	// ── Dry Run: Export and Exit ─────────────────────────────────────────
 	if dryRun {
@@ -236,6 +875,31 @@ func runClean(cmd *cobra.Command, args []string) {
 
 		drc.PrintSummary()
 
+		if rep != nil {
+			rep.DryRun = true
+			for _, r := range allResults {
+				for _, item := range r.Items {
+					rep.Planned(r.Category, item.Path, item.Size)
+				}
+			}
+			if recycleBinSize > 0 {
+				rep.Planned("RecycleBin", "Recycle Bin (Shell API)", recycleBinSize)
+			}
+			if goModSize > 0 {
+				rep.Planned("GoModCache", "Go module cache", goModSize)
+			}
+			if windowsOldSize > 0 {
+				rep.Planned("WindowsOld", `C:\Windows.old`, windowsOldSize)
+			}
+			saveCleanReport(rep, reportPath)
+		}
+
+		groups := make([]analyze.PreviewGroup, 0, len(allResults))
+		for _, r := range allResults {
+			groups = append(groups, analyze.PreviewGroup{Name: r.Category, Items: r.Items})
+		}
+		browseCleanPreview(cmd, "Clean Preview", groups)
+
 		exportPath := filepath.Join(cfg.ConfigDir, "clean-list.txt")
 		if exportErr := drc.ExportToFile(exportPath); exportErr != nil {
 			fmt.Println(ui.WarningStyle().Render(
@@ -248,15 +912,34 @@ func runClean(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// ── Confirm ──────────────────────────────────────────────────────────
-	confirmed, confirmErr := ui.Confirm(
-		fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
-	if confirmErr != nil || !confirmed {
+	// ── Privacy-sensitive targets need an explicit warning ───────────────
+	// This prompt is shown even with --yes.
+	if !confirmPrivacyTargets(allResults) {
 		fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
 		fmt.Println()
 		return
 	}
 
+	// ── Confirm ──────────────────────────────────────────────────────────
+	if !unattended {
+		confirmed, confirmErr := ui.Confirm(
+			fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
+	// ── Restore Point ────────────────────────────────────────────────────
+	if windowsOldSize > 0 || hasHighRisk(allResults) {
+		if !ensureRestorePoint(cmd, "before pw clean", unattended) {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
 	// ── Initialize Logger ────────────────────────────────────────────────
 	logger, logErr := core.NewLogger(cfg.LogFile)
 	if logErr != nil {
@@ -271,36 +954,41 @@ func runClean(cmd *cobra.Command, args []string) {
 	}
 
 	// ── Execute Cleanup ──────────────────────────────────────────────────
+	outcomes := runCleanItems(allResults, freeCloud, !unattended)
+
 	cleanSpinner := ui.NewInlineSpinner()
 	cleanSpinner.Start("Cleaning...")
 
 	var totalFreed int64
 	var totalCleaned int
 	var errCount int
+	var locked []lockedItem
+	cleaned := make(map[string]clean.GroupStat)
 
 	// Delete all scanned items via SafeDelete.
-	for _, r := range allResults {
-		for _, item := range r.Items {
-			cleanSpinner.UpdateMessage(
-				fmt.Sprintf("Cleaning %s...", filepath.Base(item.Path)))
-
-			freed, delErr := core.SafeDelete(item.Path, false)
-			if delErr != nil {
-				errCount++
-				if debugMode {
-					fmt.Printf("\n  %s %v\n", ui.IconError, delErr)
-				}
-				if logger != nil {
-					logger.Log("DELETE", item.Path, 0, delErr)
-				}
-				continue
+	for _, o := range outcomes {
+		freed, op, delErr := o.Freed, o.Op, o.Err
+		if delErr != nil {
+			errCount++
+			if core.IsLockedError(delErr) {
+				locked = append(locked, lockedItem{path: o.Item.Path, file: core.LockedPath(delErr), size: o.Item.Size})
+			}
+			rep.Failed(o.Target, o.Item.Path, o.Item.Size, delErr)
+			if debugMode {
+				fmt.Printf("\n  %s %v\n", ui.IconError, delErr)
 			}
-
-			totalFreed += freed
-			totalCleaned++
 			if logger != nil {
-				logger.Log("DELETE", item.Path, freed, nil)
+				logger.Log(op, o.Item.Path, 0, delErr)
 			}
+			continue
+		}
+
+		totalFreed += freed
+		totalCleaned++
+		addCleaned(cleaned, o.Target, freed)
+		rep.Cleaned(o.Target, o.Item.Path, freed)
+		if logger != nil {
+			logger.Log(op, o.Item.Path, freed, nil)
 		}
 	}
 
@@ -309,12 +997,15 @@ func runClean(cmd *cobra.Command, args []string) {
 		cleanSpinner.UpdateMessage("Emptying Recycle Bin...")
 		if rbErr := clean.EmptyRecycleBin(false); rbErr != nil {
 			errCount++
+			rep.Failed("RecycleBin", "Recycle Bin", recycleBinSize, rbErr)
 			if logger != nil {
 				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", 0, rbErr)
 			}
 		} else {
 			totalFreed += recycleBinSize
 			totalCleaned++
+			addCleaned(cleaned, "RecycleBin", recycleBinSize)
+			rep.Cleaned("RecycleBin", "Recycle Bin", recycleBinSize)
 			if logger != nil {
 				logger.Log("EMPTY_RECYCLE_BIN", "RecycleBin", recycleBinSize, nil)
 			}
@@ -327,12 +1018,15 @@ func runClean(cmd *cobra.Command, args []string) {
 		freed, goErr := clean.CleanGoModCache(false)
 		if goErr != nil {
 			errCount++
+			rep.Failed("GoModCache", "Go module cache", goModSize, goErr)
 			if logger != nil {
 				logger.Log("GO_CLEAN_MODCACHE", "go mod cache", 0, goErr)
 			}
 		} else {
 			totalFreed += freed
 			totalCleaned++
+			addCleaned(cleaned, "GoModCache", goModSize)
+			rep.Cleaned("GoModCache", "Go module cache", freed)
 			if logger != nil {
 				logger.Log("GO_CLEAN_MODCACHE", "go mod cache", freed, nil)
 			}
@@ -346,15 +1040,20 @@ func runClean(cmd *cobra.Command, args []string) {
 		freed, woErr := clean.CleanWindowsOld(false)
 		if woErr != nil {
 			errCount++
+			rep.Failed("WindowsOld", `C:\Windows.old`, windowsOldSize, woErr)
 			if logger != nil {
 				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, 0, woErr)
 			}
 		} else if freed > 0 {
 			totalFreed += freed
 			totalCleaned++
+			addCleaned(cleaned, "WindowsOld", windowsOldSize)
+			rep.Cleaned("WindowsOld", `C:\Windows.old`, freed)
 			if logger != nil {
 				logger.Log("DELETE_WINDOWS_OLD", `C:\Windows.old`, freed, nil)
 			}
+		} else {
+			rep.Skipped("WindowsOld", `C:\Windows.old`, "declined at confirmation")
 		}
 
 		// Restart spinner for remaining work.
@@ -362,7 +1061,7 @@ func runClean(cmd *cobra.Command, args []string) {
 		cleanSpinner.Start("Finishing cleanup...")
 	}
 
-	cleanSpinner.Stop("Cleanup complete")
+	recordCleanedRun(cfg, scope, found, cleaned)
 
 	// Log session summary.
 	if logger != nil {
@@ -387,6 +1086,8 @@ func runClean(cmd *cobra.Command, args []string) {
 			fmt.Sprintf("  %s  %d items skipped (locked, access denied, or safety check)",
 				ui.IconWarning, errCount)))
 	}
+	handleLockedItems(cmd, locked, wl, logger)
+	saveCleanReport(rep, reportPath)
 	fmt.Println()
 }
 
@@ -422,6 +1123,11 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 	}
 
 	maxDepth, _ := cmd.Flags().GetInt("depth")
+	minAge := cleanMinAge(cmd)
+	rep, reportPath := startCleanReport(cmd, "clean")
+	if rep != nil {
+		rep.Target = target
+	}
 
 	// ── Header ───────────────────────────────────────────────────────
 	fmt.Println()
@@ -436,25 +1142,77 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 		fmt.Println(ui.MutedStyle().Render(
 			fmt.Sprintf("  Max depth: %d", maxDepth)))
 	}
+	if minAge > 0 {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  Only files older than %s", formatAge(minAge))))
+	}
 	fmt.Println()
 
 	// ── Scan Phase ───────────────────────────────────────────────────
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Scanning for junk files...")
 
-	results := clean.ScanPath(target, wl, maxDepth)
+	progress, stopProgress := trackScanProgress(spinner)
+	results := clean.ScanPathProgress(target, wl, maxDepth, progress)
+	stopProgress()
+	results = clean.FilterPathResultsOlderThan(results, minAge, time.Now())
 
 	spinner.Stop("Scan complete")
 
+	freeCloud, _ := cmd.Flags().GetBool("free-cloud")
+	var scannedItems []clean.CleanItem
+	for _, r := range results {
+		scannedItems = append(scannedItems, r.Items...)
+	}
+	printCloudNotice(progress, scannedItems, freeCloud)
+
+	// ── Record Run / Diff ───────────────────────────────────────────
+	scope := pathCleanScope(target, minAge)
+	found := clean.SummarizePathResults(results)
+	prevRun := recordCleanRun(cfg, scope, found)
+	if showDiff, _ := cmd.Flags().GetBool("diff"); showDiff {
+		printCleanDiff(prevRun, found)
+		return
+	}
+
+	// ── Risk Gate ───────────────────────────────────────────────────
+	unattended, _ := cmd.Flags().GetBool("yes")
+	maxRisk, riskErr := cleanMaxRisk(cmd, unattended)
+	if riskErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, riskErr)))
+		os.Exit(1)
+	}
+	var heldPaths []clean.PathScanResult
+	results, heldPaths = clean.FilterPathResultsByRisk(results, maxRisk)
+	var held []clean.ScanResult
+	for _, r := range heldPaths {
+		held = append(held, clean.ItemsToResult(r.Label, r.Items))
+	}
+	recordRiskHeldBack(rep, held, maxRisk)
+
+	// ── Log Retention ───────────────────────────────────────────────
+	retention, retErr := pathLogRetention(cmd, cfg)
+	if retErr != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, retErr)))
+		os.Exit(1)
+	}
+	var oldLogs []clean.CleanItem
+	logAge, _ := retention.Age()
+	if retention.Archive() {
+		results, oldLogs = clean.SplitLogsForArchive(results, logAge, time.Now())
+	}
+
 	// ── Check for empty results ─────────────────────────────────────
 	totalSize := clean.PathScanTotalSize(results)
 	totalItems := clean.PathScanTotalItems(results)
 
-	if totalSize == 0 || totalItems == 0 {
+	if (totalSize == 0 || totalItems == 0) && len(oldLogs) == 0 {
 		fmt.Println()
 		fmt.Println(ui.SuccessStyle().Render(
 			fmt.Sprintf("  %s  Directory is clean! No junk files found.", ui.IconSuccess)))
 		fmt.Println()
+		pruneEmptyFolders(cmd, target, wl, maxDepth, cfg, nil, rep)
+		saveCleanReport(rep, reportPath)
 		return
 	}
 
@@ -475,8 +1233,23 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 		ui.FormatSize(totalSize),
 		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totalItems)),
 	)
+	if retention.Archive() {
+		printLogArchivePlan(oldLogs, logAge)
+	}
 	fmt.Println()
 
+	// ── Emit Script: Write and Exit ─────────────────────────────────
+	if scriptPath, _ := cmd.Flags().GetString("emit-script"); scriptPath != "" {
+		drc := core.NewDryRunContext()
+		for _, r := range results {
+			for _, item := range r.Items {
+				drc.Add(item.Path, item.Size, item.Category)
+			}
+		}
+		writeCleanScript(drc, scriptPath, "clean "+target, nil)
+		return
+	}
+
 	// ── Dry Run: Export and Exit ────────────────────────────────────
 	if dryRun {
 		drc := core.NewDryRunContext()
@@ -487,6 +1260,23 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 		}
 
 		drc.PrintSummary()
+		pruneEmptyFolders(cmd, target, wl, maxDepth, cfg, nil, rep)
+
+		if rep != nil {
+			rep.DryRun = true
+			for _, r := range results {
+				for _, item := range r.Items {
+					rep.Planned(r.Label, item.Path, item.Size)
+				}
+			}
+			saveCleanReport(rep, reportPath)
+		}
+
+		groups := make([]analyze.PreviewGroup, 0, len(results))
+		for _, r := range results {
+			groups = append(groups, analyze.PreviewGroup{Name: r.Label, Items: r.Items})
+		}
+		browseCleanPreview(cmd, "Clean Preview — "+target, groups)
 
 		exportPath := filepath.Join(cfg.ConfigDir, "clean-path-list.txt")
 		if exportErr := drc.ExportToFile(exportPath); exportErr != nil {
@@ -501,12 +1291,28 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 	}
 
 	// ── Confirm ─────────────────────────────────────────────────────
-	confirmed, confirmErr := ui.Confirm(
-		fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
-	if confirmErr != nil || !confirmed {
-		fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
-		fmt.Println()
-		return
+	if !unattended {
+		confirmed, confirmErr := ui.Confirm(
+			fmt.Sprintf("  Proceed to free %s?", core.FormatSize(totalSize)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
+	toClean := make([]clean.ScanResult, 0, len(results))
+	for _, r := range results {
+		toClean = append(toClean, clean.ItemsToResult(r.Label, r.Items))
+	}
+
+	// ── Restore Point ───────────────────────────────────────────────
+	if hasHighRisk(toClean) {
+		if !ensureRestorePoint(cmd, "before pw clean", unattended) {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return
+		}
 	}
 
 	// ── Initialize Logger ───────────────────────────────────────────
@@ -523,39 +1329,42 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 	}
 
 	// ── Execute Cleanup ─────────────────────────────────────────────
-	cleanSpinner := ui.NewInlineSpinner()
-	cleanSpinner.Start("Cleaning...")
+	outcomes := runCleanItems(toClean, freeCloud, !unattended)
+	outcomes = append(outcomes, archiveOldLogs(oldLogs)...)
 
 	var totalFreed int64
 	var totalCleaned int
 	var errCount int
+	var locked []lockedItem
+	cleaned := make(map[string]clean.GroupStat)
 
-	for _, r := range results {
-		for _, item := range r.Items {
-			cleanSpinner.UpdateMessage(
-				fmt.Sprintf("Cleaning %s...", filepath.Base(item.Path)))
-
-			freed, delErr := core.SafeDelete(item.Path, false)
-			if delErr != nil {
-				errCount++
-				if debugMode {
-					fmt.Printf("\n  %s %v\n", ui.IconError, delErr)
-				}
-				if logger != nil {
-					logger.Log("DELETE", item.Path, 0, delErr)
-				}
-				continue
+	for _, o := range outcomes {
+		freed, op, delErr := o.Freed, o.Op, o.Err
+		if delErr != nil {
+			errCount++
+			if core.IsLockedError(delErr) {
+				locked = append(locked, lockedItem{path: o.Item.Path, file: core.LockedPath(delErr), size: o.Item.Size})
+			}
+			rep.Failed(o.Target, o.Item.Path, o.Item.Size, delErr)
+			if debugMode {
+				fmt.Printf("\n  %s %v\n", ui.IconError, delErr)
 			}
-
-			totalFreed += freed
-			totalCleaned++
 			if logger != nil {
-				logger.Log("DELETE", item.Path, freed, nil)
+				logger.Log(op, o.Item.Path, 0, delErr)
 			}
+			continue
+		}
+
+		totalFreed += freed
+		totalCleaned++
+		addCleaned(cleaned, o.Target, freed)
+		rep.Cleaned(o.Target, o.Item.Path, freed)
+		if logger != nil {
+			logger.Log(op, o.Item.Path, freed, nil)
 		}
 	}
 
-	cleanSpinner.Stop("Cleanup complete")
+	recordCleanedRun(cfg, scope, found, cleaned)
 
 	// Log session summary.
 	if logger != nil {
@@ -580,6 +1389,166 @@ func runPathClean(cmd *cobra.Command, target string, cfg *config.Config, wl *whi
 			fmt.Sprintf("  %s  %d items skipped (locked, access denied, or safety check)",
 				ui.IconWarning, errCount)))
 	}
+	handleLockedItems(cmd, locked, wl, logger)
+	pruneEmptyFolders(cmd, target, wl, maxDepth, cfg, logger, rep)
+	saveCleanReport(rep, reportPath)
+	fmt.Println()
+}
+
+// ─── Locked Files ────────────────────────────────────────────────────────────
+
+// lockedItem is a clean item that could not be deleted because a running
+// process holds it, or a file inside it, open.
+type lockedItem struct {
+	path string // Item passed to SafeDelete.
+	file string // File named in the error; may be inside path.
+	size int64
+}
+
+// maxLockedShown caps how many locked items are listed with their holders.
+const maxLockedShown = 15
+
+// handleLockedItems lists the items that failed because they are in use,
+// naming the holding processes via the Restart Manager, and queues them for
+// deletion at the next reboot with --on-reboot or after a prompt. Whitelisted
+// paths are never queued.
+func handleLockedItems(cmd *cobra.Command, locked []lockedItem, wl *whitelist.Whitelist, logger *core.Logger) {
+	if len(locked) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Locked Files", 55))
+	fmt.Println()
+	for i, item := range locked {
+		if i == maxLockedShown {
+			fmt.Println(ui.MutedStyle().Render(
+				fmt.Sprintf("  ... and %d more", len(locked)-maxLockedShown)))
+			break
+		}
+		fmt.Printf("  %s %s  %s\n", ui.IconBullet, ui.FormatPath(item.path), ui.FormatSize(item.size))
+
+		file := item.file
+		if file == "" {
+			file = item.path
+		}
+		procs, err := core.FindLockingProcesses([]string{file})
+		switch {
+		case err != nil:
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("      could not identify holder: %v", err)))
+		case len(procs) == 0:
+			fmt.Println(ui.MutedStyle().Render("      holder has since released it"))
+		default:
+			for _, p := range procs {
+				name := p.Name
+				if p.Service != "" {
+					name += " (service " + p.Service + ")"
+				}
+				fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("      held by %s, PID %d", name, p.PID)))
+			}
+		}
+	}
+	fmt.Println()
+
+	onReboot, _ := cmd.Flags().GetBool("on-reboot")
+	unattended, _ := cmd.Flags().GetBool("yes")
+	isAdmin := core.IsElevated()
+
+	if !onReboot {
+		if unattended || !isAdmin {
+			fmt.Println(ui.MutedStyle().Render(
+				"  Close these programs and re-run, or run as admin with --on-reboot to delete them at next reboot."))
+			fmt.Println()
+			return
+		}
+		confirmed, err := ui.Confirm(fmt.Sprintf("  Delete %d locked items at next reboot?", len(locked)))
+		if err != nil || !confirmed {
+			fmt.Println()
+			return
+		}
+	} else if !isAdmin {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  --on-reboot requires admin — locked items were left in place", ui.IconWarning)))
+		fmt.Println()
+		return
+	}
+
+	var isWhitelisted func(string) bool
+	if wl != nil {
+		isWhitelisted = wl.IsWhitelisted
+	}
+	queued := 0
+	for _, item := range locked {
+		n, err := core.ScheduleDeleteOnReboot(item.path, isWhitelisted)
+		if logger != nil {
+			logger.Log("DELETE_ON_REBOOT", item.path, item.size, err)
+		}
+		if err != nil {
+			fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s  %v", ui.IconWarning, err)))
+			continue
+		}
+		if n > 0 {
+			queued++
+		}
+	}
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s  %d items will be deleted at next reboot", ui.IconSuccess, queued)))
+	fmt.Println()
+}
+
+// ─── Audit Report ────────────────────────────────────────────────────────────
+
+// startCleanReport begins the audit report requested with --report. It
+// returns a nil report when none was requested; a path with an unsupported
+// extension exits before anything is scanned.
+func startCleanReport(cmd *cobra.Command, command string) (*report.Report, string) {
+	path, _ := cmd.Flags().GetString("report")
+	if path == "" {
+		return nil, ""
+	}
+	if err := report.CheckPath(path); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return report.New(command), path
+}
+
+// saveCleanReport finishes rep and writes it to path. A nil rep is ignored.
+func saveCleanReport(rep *report.Report, path string) {
+	if rep == nil {
+		return
+	}
+	rep.Finish()
+	if err := rep.Save(path); err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Could not write report: %v", ui.IconWarning, err)))
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Report saved to %s", path)))
+}
+
+// ─── Script Export ───────────────────────────────────────────────────────────
+
+// writeCleanScript writes the removal script for `--emit-script` and reports
+// where it was saved. Nothing is deleted.
+func writeCleanScript(drc *core.DryRunContext, scriptPath, command string, actions []core.ScriptAction) {
+	if abs, err := filepath.Abs(scriptPath); err == nil {
+		scriptPath = abs
+	}
+
+	if err := drc.ExportScript(scriptPath, command, actions); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s  Could not write script: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s  Removal script written to %s", ui.IconSuccess, scriptPath)))
+	fmt.Println(ui.MutedStyle().Render(
+		"  No files were deleted. Review the script, then run it (use -WhatIf to preview)."))
 	fmt.Println()
 }
 
@@ -602,6 +1571,8 @@ func displayCleanResults(
 		{"browser", "Browser Caches"},
 		{"dev", "Developer Tools"},
 		{"system", "System"},
+		{config.CategoryAI, "AI & Recall Data"},
+		{config.CategoryPrefetch, "Prefetch"},
 	}
 
 	fmt.Println()
@@ -680,6 +1651,39 @@ func displayCleanResults(
 	}
 }
 
+// confirmPrivacyTargets lists the privacy notes of any scanned targets that
+// carry one and asks for a danger confirmation. It returns true when there
+// is nothing sensitive or the user accepted.
+func confirmPrivacyTargets(results []clean.ScanResult) bool {
+	notes := make(map[string]string)
+	for _, t := range config.GetCleanTargets() {
+		if t.PrivacyNote != "" {
+			notes[t.Name] = t.PrivacyNote
+		}
+	}
+
+	var shown int
+	for _, r := range results {
+		note, ok := notes[r.Category]
+		if !ok {
+			continue
+		}
+		if shown == 0 {
+			fmt.Println(ui.SectionHeader("Privacy", 55))
+		}
+		shown++
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s  %s", ui.IconWarning, r.Category)))
+		fmt.Println(ui.MutedStyle().Render("     " + note))
+	}
+	if shown == 0 {
+		return true
+	}
+	fmt.Println()
+
+	confirmed, err := ui.DangerConfirm("Permanently delete this AI feature data?")
+	return err == nil && confirmed
+}
+
 // groupItemsByDescription groups CleanItems by their Description field.
 func groupItemsByDescription(items []clean.CleanItem) map[string][]clean.CleanItem {
 	groups := make(map[string][]clean.CleanItem)
@@ -688,3 +1692,110 @@ func groupItemsByDescription(items []clean.CleanItem) map[string][]clean.CleanIt
 	}
 	return groups
 }
+
+// pruneEmptyFolders handles --prune-empty in path mode: it finds folder
+// trees under target that hold no files and, after confirmation, removes
+// them. logger may be nil, in which case one is opened for the removal.
+func pruneEmptyFolders(cmd *cobra.Command, target string, wl *whitelist.Whitelist, maxDepth int,
+	cfg *config.Config, logger *core.Logger, rep *report.Report) {
+	if prune, _ := cmd.Flags().GetBool("prune-empty"); !prune {
+		return
+	}
+
+	dirs := clean.FindEmptyDirs(target, wl, maxDepth)
+	if len(dirs) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No empty folders found."))
+		fmt.Println()
+		return
+	}
+	total := clean.EmptyDirCount(dirs)
+
+	fmt.Println(ui.SectionHeader("Empty Folders", 55))
+	const maxShown = 10
+	for i, d := range dirs {
+		if i == maxShown {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    ... and %d more", len(dirs)-maxShown)))
+			break
+		}
+		fmt.Printf("    %s %s\n", ui.IconBullet, d.Path)
+	}
+	fmt.Printf("  %s\n", ui.BoldStyle().Render(
+		fmt.Sprintf("%d empty folder trees (%d folders)", len(dirs), total)))
+	fmt.Println()
+
+	if dryRun {
+		for _, d := range dirs {
+			rep.Planned("Empty Folders", d.Path, 0)
+		}
+		fmt.Println(ui.InfoStyle().Render(
+			fmt.Sprintf("  [DRY RUN] Would remove %d empty folders", total)))
+		fmt.Println()
+		return
+	}
+
+	if unattended, _ := cmd.Flags().GetBool("yes"); !unattended {
+		confirmed, err := ui.Confirm(fmt.Sprintf("  Remove %d empty folders?", total))
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Empty folders kept."))
+			fmt.Println()
+			return
+		}
+	}
+
+	if logger == nil {
+		if l, err := core.NewLogger(cfg.LogFile); err == nil {
+			logger = l
+			defer logger.Close()
+			logger.LogSession("clean-prune-empty")
+		}
+	}
+
+	var removed, failed int
+	for _, d := range dirs {
+		n, err := clean.PruneEmptyDir(d, false)
+		removed += n
+		if logger != nil {
+			logger.Log("RMDIR", d.Path, 0, err)
+		}
+		if err != nil {
+			failed++
+			rep.Failed("Empty Folders", d.Path, 0, err)
+			if debug {
+				fmt.Printf("  %s %v\n", ui.IconError, err)
+			}
+			continue
+		}
+		rep.Cleaned("Empty Folders", d.Path, 0)
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s  Removed %d empty folders", ui.IconSuccess, removed)))
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  %d folder trees could not be removed", ui.IconWarning, failed)))
+	}
+	fmt.Println()
+}
+
+// browseCleanPreview opens a read-only tree of everything a dry run would
+// delete, grouped by target, so risky targets can be audited file by file.
+// It only runs in an interactive terminal and is skipped with --yes.
+func browseCleanPreview(cmd *cobra.Command, title string, groups []analyze.PreviewGroup) {
+	if unattended, _ := cmd.Flags().GetBool("yes"); unattended || !ui.IsTerminal() {
+		return
+	}
+	if noTree, _ := cmd.Flags().GetBool("no-tree"); noTree {
+		return
+	}
+
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Building preview tree...")
+	root := analyze.BuildPreviewTree(title, groups)
+	spinner.Stop("Preview ready")
+
+	p := tea.NewProgram(analyze.NewPreviewModel(root, title), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  Cannot open preview: %v", ui.IconWarning, err)))
+	}
+}
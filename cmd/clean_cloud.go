@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Cloud Files ─────────────────────────────────────────────────────────────
+// Online-only placeholders are never scanned or deleted. Locally stored items
+// inside a sync folder are deleted like any other unless --free-cloud asks
+// for "Free up space", which keeps the cloud copy and only drops the local
+// data. See clean.RemoveItem.
+
+// printCloudNotice reports skipped placeholders and, without --free-cloud,
+// warns that deleting synced items also deletes them from the cloud.
+func printCloudNotice(progress *clean.ScanProgress, items []clean.CleanItem, freeCloud bool) {
+	if n := progress.CloudSkipped(); n > 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  Skipped %d online-only cloud files (they use no local space)", n)))
+	}
+
+	var synced int
+	var syncedSize int64
+	for _, item := range items {
+		if clean.InCloudSyncRoot(item.Path) {
+			synced++
+			syncedSize += item.Size
+		}
+	}
+	if synced == 0 {
+		return
+	}
+	if freeCloud {
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  %s  %d items (%s) in cloud folders will be freed up, not deleted",
+			ui.IconArrow, synced, core.FormatSize(syncedSize))))
+		return
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s  %d items (%s) are in cloud folders — deleting them also deletes the cloud copy",
+		ui.IconWarning, synced, core.FormatSize(syncedSize))))
+	fmt.Println(ui.MutedStyle().Render("     Online-only files inside them are kept."))
+	fmt.Println(ui.MutedStyle().Render("     Use --free-cloud to only free their local space."))
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Run History and --diff ──────────────────────────────────────────────────
+// Every clean scan is summarised into the config directory. A cleanup adds
+// a second summary of what it left behind, so `pw clean --diff` shows what
+// regrew since the last cleanup rather than since the scan before it.
+
+// scope identifies which category runs are comparable: the same set of
+// categories and the same --older-than limit.
+func (c cleanCategories) scope(minAge time.Duration) string {
+	var names []string
+	for _, f := range []struct {
+		set  bool
+		name string
+	}{
+		{c.all, "all"}, {c.user, "user"}, {c.system, "system"}, {c.browser, "browser"},
+		{c.dev, "dev"}, {c.apps, config.CategoryApps}, {c.ai, config.CategoryAI},
+		{c.prefetch, config.CategoryPrefetch},
+	} {
+		if f.set {
+			names = append(names, f.name)
+		}
+	}
+	scope := "categories:" + strings.Join(names, ",")
+	if minAge > 0 {
+		scope += ";older-than=" + minAge.String()
+	}
+	return scope
+}
+
+// pathCleanScope identifies runs of path mode over the same directory.
+func pathCleanScope(target string, minAge time.Duration) string {
+	scope := "path:" + strings.ToLower(target)
+	if minAge > 0 {
+		scope += ";older-than=" + minAge.String()
+	}
+	return scope
+}
+
+// groups summarises the scan per target, including the API-cleaned extras.
+func (s cleanScan) groups() map[string]clean.GroupStat {
+	groups := clean.SummarizeResults(s.results)
+	for name, size := range map[string]int64{
+		"RecycleBin": s.recycleBinSize,
+		"GoModCache": s.goModSize,
+		"WindowsOld": s.windowsOldSize,
+	} {
+		if size > 0 {
+			groups[name] = clean.GroupStat{Size: size, Items: 1}
+		}
+	}
+	return groups
+}
+
+// recordCleanRun appends a scan summary to the history and returns the
+// previous run of the same scope, or nil. History errors are not fatal.
+func recordCleanRun(cfg *config.Config, scope string, groups map[string]clean.GroupStat) *clean.RunSummary {
+	prev, _ := clean.LastRun(cfg.ConfigDir, scope)
+	_ = clean.RecordRun(cfg.ConfigDir, clean.RunSummary{
+		Timestamp: time.Now(),
+		Scope:     scope,
+		Groups:    groups,
+	})
+	return prev
+}
+
+// recordCleanedRun appends a summary of what a cleanup left behind.
+func recordCleanedRun(cfg *config.Config, scope string, found, cleaned map[string]clean.GroupStat) {
+	_ = clean.RecordRun(cfg.ConfigDir, clean.RunSummary{
+		Timestamp:  time.Now(),
+		Scope:      scope,
+		Groups:     clean.Remaining(found, cleaned),
+		AfterClean: true,
+	})
+}
+
+// addCleaned counts a successfully cleaned item towards its group.
+func addCleaned(cleaned map[string]clean.GroupStat, name string, size int64) {
+	g := cleaned[name]
+	g.Size += size
+	g.Items++
+	cleaned[name] = g
+}
+
+// printCleanDiff shows how each group changed since the previous run.
+func printCleanDiff(prev *clean.RunSummary, groups map[string]clean.GroupStat) {
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Changes Since Last Run", 55))
+
+	if prev == nil {
+		fmt.Println(ui.MutedStyle().Render("  No earlier run with these options to compare against."))
+		fmt.Println(ui.MutedStyle().Render("  This scan has been recorded; run --diff again later."))
+		fmt.Println()
+		return
+	}
+
+	since := "scan"
+	if prev.AfterClean {
+		since = "cleanup"
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  Compared with the %s on %s (%s ago)",
+		since, prev.Timestamp.Format("2006-01-02 15:04"), formatDuration(time.Since(prev.Timestamp)))))
+	fmt.Println()
+
+	deltas := clean.DiffRuns(*prev, clean.RunSummary{Groups: groups})
+	if len(deltas) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s  Nothing changed.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	var grew, shrank int64
+	for _, d := range deltas {
+		if d.DeltaSize > 0 {
+			grew += d.DeltaSize
+		} else {
+			shrank -= d.DeltaSize
+		}
+		fmt.Printf("    %-31s  %s  %s\n",
+			d.Name,
+			formatSizeDelta(d.DeltaSize),
+			ui.MutedStyle().Render(fmt.Sprintf("%s → %s (%+d items)",
+				core.FormatSize(d.PrevSize), core.FormatSize(d.Size), d.DeltaItems)),
+		)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Divider(55))
+	fmt.Printf("  Grew %s, shrank %s\n",
+		ui.ErrorStyle().Render(core.FormatSize(grew)), ui.SuccessStyle().Render(core.FormatSize(shrank)))
+	fmt.Println()
+}
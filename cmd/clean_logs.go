@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Log Retention ───────────────────────────────────────────────────────────
+// A path clean deletes .log files unless the log retention policy in
+// config.json (or --archive-logs) says to archive them: old logs are then
+// zipped per directory and newer ones kept.
+
+// pathLogRetention returns the log retention policy for a path clean.
+func pathLogRetention(cmd *cobra.Command, cfg *config.Config) (config.LogRetention, error) {
+	retention := cfg.LogRetention
+	if archive, _ := cmd.Flags().GetBool("archive-logs"); archive {
+		retention.Mode = config.LogRetentionArchive
+	}
+	return retention, retention.Validate()
+}
+
+// printLogArchivePlan shows what archiving will do with the logs.
+func printLogArchivePlan(logs []clean.CleanItem, age time.Duration) {
+	if len(logs) == 0 {
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+			"  No logs older than %s to archive; newer logs are kept", formatAge(age))))
+		return
+	}
+	var size int64
+	for _, item := range logs {
+		size += item.Size
+	}
+	prefix := ui.IconArrow + " Archiving"
+	if dryRun {
+		prefix = "[DRY RUN] Would archive"
+	}
+	fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+		"  %s %d logs older than %s (%s) into %d zip files, one per folder",
+		prefix, len(logs), formatAge(age), core.FormatSize(size), clean.LogDirs(logs))))
+}
+
+// archiveOldLogs zips logs per directory and returns an outcome per log for
+// the report and operations log.
+func archiveOldLogs(logs []clean.CleanItem) []clean.ItemOutcome {
+	if len(logs) == 0 {
+		return nil
+	}
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Archiving logs...")
+	archives, outcomes := clean.ArchiveLogs(logs, "Log Files", time.Now())
+	spinner.Stop(fmt.Sprintf("Archived logs into %d zip files", len(archives)))
+	return outcomes
+}
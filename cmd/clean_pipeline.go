@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/pipeline"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+var cleanScanCmd = &cobra.Command{
+	Use:   "scan [path]",
+	Short: "Scan for cleanable files without deleting",
+	Long: `Scan for cleanable files and print the results without deleting anything.
+
+With --json, the item list is written to stdout so it can be piped into
+'pw filter' or 'pw clean apply --from -'.
+
+Examples:
+  pw clean scan --all --json > items.json
+  pw clean scan --user --json | pw filter --min-size 50MB | pw clean apply --from - --yes`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runCleanScan,
+}
+
+var cleanApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Delete the items in a JSON item list",
+	Long: `Read a JSON item list (as produced by 'pw clean scan --json') and delete
+every listed path through the same safety checks as 'pw clean'.
+
+Use --from - to read the list from stdin. A piped list cannot share stdin
+with a confirmation prompt, so reading from stdin requires --yes (or
+--dry-run), and only low-risk items are deleted unless --max-risk says
+otherwise.`,
+	Args: cobra.NoArgs,
+	Run:  runCleanApply,
+}
+
+func init() {
+	cleanApplyCmd.Flags().String("from", "", "Item list file to read (- for stdin)")
+	cleanApplyCmd.Flags().Bool("yes", false, "Skip the confirmation prompt")
+	_ = cleanApplyCmd.MarkFlagRequired("from")
+
+	cleanCmd.AddCommand(cleanScanCmd)
+	cleanCmd.AddCommand(cleanApplyCmd)
+}
+
+// ─── Scan ────────────────────────────────────────────────────────────────────
+
+// runCleanScan handles `pw clean scan`. In JSON mode nothing but the item
+// list is written to stdout; diagnostics go to stderr.
+func runCleanScan(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Failed to load config: %v\n", ui.IconError, err)
+		os.Exit(1)
+	}
+	wl := applyExcludeFlag(cmd, loadPipelineWhitelist(cfg))
+
+	cats := cleanCategoriesFromFlags(cmd)
+	minAge := cleanMinAge(cmd)
+	doc := pipeline.NewDocument("clean")
+
+	if len(args) > 0 || !cats.any() {
+		target := ""
+		if len(args) > 0 {
+			target = args[0]
+		} else if target, err = os.Getwd(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Cannot determine current directory: %v\n", ui.IconError, err)
+			os.Exit(1)
+		}
+		target, err = filepath.Abs(target)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s Cannot resolve path: %v\n", ui.IconError, err)
+			os.Exit(1)
+		}
+
+		maxDepth, _ := cmd.Flags().GetInt("depth")
+		results := clean.FilterPathResultsOlderThan(clean.ScanPath(target, wl, maxDepth), minAge, time.Now())
+		for _, r := range results {
+			doc.Items = append(doc.Items, cleanItemsToPipeline(r.Items)...)
+		}
+	} else {
+		scan := scanCleanCategories(cats, wl, core.IsElevated(), nil)
+		for _, r := range clean.FilterResultsOlderThan(scan.results, minAge, time.Now()) {
+			doc.Items = append(doc.Items, cleanItemsToPipeline(r.Items)...)
+		}
+	}
+
+	if jsonOutput {
+		if err := pipeline.Write(os.Stdout, doc); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %v\n", ui.IconError, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	printPipelineSummary(doc)
+}
+
+// cleanItemsToPipeline converts scanner items into pipeline items.
+func cleanItemsToPipeline(items []clean.CleanItem) []pipeline.Item {
+	out := make([]pipeline.Item, 0, len(items))
+	for _, item := range items {
+		out = append(out, pipeline.Item{
+			Path:        item.Path,
+			Size:        item.Size,
+			Category:    item.Category,
+			Description: item.Description,
+			Risk:        item.RiskLevel,
+		})
+	}
+	return out
+}
+
+// printPipelineSummary prints a per-category summary of an item list.
+func printPipelineSummary(doc *pipeline.Document) {
+	drc := core.NewDryRunContext()
+	for _, item := range doc.Items {
+		drc.Add(item.Path, item.Size, item.Category)
+	}
+	drc.PrintSummary()
+	fmt.Println()
+}
+
+// ─── Apply ───────────────────────────────────────────────────────────────────
+
+// runCleanApply handles `pw clean apply --from <file|->`.
+func runCleanApply(cmd *cobra.Command, args []string) {
+	from, _ := cmd.Flags().GetString("from")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	// A list on stdin leaves no way to confirm, so deleting it must be
+	// asked for explicitly.
+	if from == "-" && !skipConfirm && !dryRun {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s --from - cannot prompt for confirmation; pass --yes to delete the piped list", ui.IconError)))
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	debugMode := debug || cfg.DebugMode
+
+	defer applyNiceFlag(cmd)()
+
+	in, err := pipeline.Open(from)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	doc, err := pipeline.Read(in)
+	in.Close()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Apply Item List", 55))
+
+	// Runs without a prompt only delete items within the risk ceiling.
+	unattended := from == "-" || skipConfirm
+	maxRisk, err := cleanMaxRisk(cmd, unattended)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	heldItems, heldSize := doc.LimitRisk(maxRisk)
+	printRiskHeldBack(heldItems, heldSize, maxRisk)
+
+	if len(doc.Items) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s  Item list is empty. Nothing to remove.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	if dryRun {
+		printPipelineSummary(doc)
+		return
+	}
+
+	fmt.Printf("  %-35s %s  %s\n",
+		ui.BoldStyle().Render("Total"),
+		ui.FormatSize(doc.TotalSize()),
+		ui.MutedStyle().Render(fmt.Sprintf("(%d items)", len(doc.Items))),
+	)
+	fmt.Println()
+
+	if !skipConfirm {
+		confirmed, confirmErr := ui.Confirm(
+			fmt.Sprintf("  Proceed to free %s?", core.FormatSize(doc.TotalSize())))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cleanup cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
+	wl := applyExcludeFlag(cmd, loadPipelineWhitelist(cfg))
+	var isWhitelisted func(string) bool
+	if wl != nil {
+		isWhitelisted = wl.IsWhitelisted
+	}
+
+	logger, logErr := core.NewLogger(cfg.LogFile)
+	if logErr != nil {
+		logger = nil
+	} else {
+		defer logger.Close()
+		logger.LogSession("clean apply")
+	}
+
+	var totalFreed int64
+	var totalCleaned int
+	var errCount int
+
+	for _, item := range doc.Items {
+		freed, delErr := core.SafeDeleteWithWhitelist(item.Path, false, isWhitelisted)
+		if delErr != nil {
+			errCount++
+			if debugMode {
+				fmt.Printf("  %s %v\n", ui.IconError, delErr)
+			}
+			if logger != nil {
+				logger.Log("DELETE", item.Path, 0, delErr)
+			}
+			continue
+		}
+		totalFreed += freed
+		totalCleaned++
+		if logger != nil {
+			logger.Log("DELETE", item.Path, freed, nil)
+		}
+	}
+
+	if logger != nil {
+		logger.LogSummary(totalFreed, totalCleaned, errCount)
+	}
+
+	successBanner := lipgloss.NewStyle().
+		Foreground(ui.ColorSuccess).
+		Bold(true)
+
+	fmt.Println(successBanner.Render(
+		fmt.Sprintf("  %s  Freed %s across %d items",
+			ui.IconSuccess, core.FormatSize(totalFreed), totalCleaned)))
+
+	if errCount > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s  %d items skipped (locked, access denied, whitelisted, or safety check)",
+				ui.IconWarning, errCount)))
+	}
+	fmt.Println()
+}
+
+// loadPipelineWhitelist loads the user whitelist, reporting failures on
+// stderr so stdout stays clean for piped JSON.
+func loadPipelineWhitelist(cfg *config.Config) *whitelist.Whitelist {
+	wl, err := whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s Could not load whitelist: %v\n", ui.IconWarning, err)
+		return nil
+	}
+	return wl
+}
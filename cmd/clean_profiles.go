@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── All User Profiles ───────────────────────────────────────────────────────
+// --all-users (admin) applies the user and browser targets to every profile
+// under C:\Users instead of only the current one. Each target is reported
+// per user, e.g. "UserTemp (alice)".
+
+// scanUserProfiles scans the per-user targets selected by cats in every
+// profile. It returns the results, the profile each result belongs to, and
+// the running browsers whose caches were skipped.
+func scanUserProfiles(cats cleanCategories, wl *whitelist.Whitelist, isAdmin bool, progress *clean.ScanProgress) ([]clean.ScanResult, map[string]string, []string) {
+	runningBrowsers := clean.RunningBrowserTargets()
+	var skipped []string
+	for _, name := range runningBrowsers {
+		skipped = append(skipped, name)
+	}
+	sort.Strings(skipped)
+
+	var results []clean.ScanResult
+	owner := make(map[string]string)
+	for _, p := range config.ListUserProfiles() {
+		var targets []config.CleanTarget
+		for _, t := range config.ProfileTargets(p.Dir) {
+			if t.Category == "browser" {
+				if !(cats.all || cats.browser) || runningBrowsers[t.Name] != "" {
+					continue
+				}
+			} else if !(cats.all || cats.user) {
+				continue
+			}
+			t.Name = fmt.Sprintf("%s (%s)", t.Name, p.Name)
+			targets = append(targets, t)
+		}
+		for _, r := range clean.ScanAllProgress(targets, wl, isAdmin, progress) {
+			owner[r.Category] = p.Name
+			results = append(results, r)
+		}
+	}
+	return results, owner, skipped
+}
+
+// printProfileTotals shows how much each user profile contributes to the
+// results.
+func printProfileTotals(results []clean.ScanResult, owner map[string]string) {
+	type total struct {
+		size  int64
+		items int
+	}
+	totals := make(map[string]*total)
+	var names []string
+	for _, r := range results {
+		name, ok := owner[r.Category]
+		if !ok {
+			continue
+		}
+		if totals[name] == nil {
+			totals[name] = &total{}
+			names = append(names, name)
+		}
+		totals[name].size += r.TotalSize
+		totals[name].items += r.ItemCount
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Slice(names, func(i, j int) bool { return totals[names[i]].size > totals[names[j]].size })
+
+	fmt.Println(ui.BoldStyle().Render("  Per user"))
+	for _, name := range names {
+		fmt.Printf("    %-31s  %10s  %s\n",
+			name,
+			core.FormatSize(totals[name].size),
+			ui.MutedStyle().Render(fmt.Sprintf("(%d items)", totals[name].items)),
+		)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Cleanup Progress ────────────────────────────────────────────────────────
+// Deletion runs in clean.CleanResults, which reports typed progress events.
+// Interactive terminals get a live per-target progress screen; everything
+// else (--yes, scheduled, piped) gets the inline spinner.
+
+// runCleanItems removes every item in results and returns the per-item
+// outcomes in order. live selects the progress screen over the spinner.
+func runCleanItems(results []clean.ScanResult, freeCloud, live bool) []clean.ItemOutcome {
+	if len(results) == 0 {
+		return nil
+	}
+	remove := func(path string) (int64, string, error) {
+		return clean.RemoveItem(path, freeCloud)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan clean.Event, 64)
+	outcomes := make(chan []clean.ItemOutcome, 1)
+	go func() {
+		outcomes <- clean.CleanResults(ctx, results, remove, events)
+	}()
+
+	if live && ui.IsTerminal() {
+		specs := make([]ui.TargetSpec, 0, len(results))
+		for _, r := range results {
+			specs = append(specs, ui.TargetSpec{Name: r.Category, Items: len(r.Items), Size: r.TotalSize})
+		}
+		p := tea.NewProgram(ui.NewTargetProgress("Cleaning", specs, cancel))
+		go func() {
+			for e := range events {
+				switch e.Kind {
+				case clean.EventTargetStart, clean.EventItemDone:
+					p.Send(ui.TargetProgressMsg{
+						Target: e.Target, Path: e.Path, Done: e.Done, Total: e.Total,
+						Freed: e.Freed, Failed: e.Err != nil,
+					})
+				}
+			}
+			p.Send(ui.TargetProgressDoneMsg{})
+		}()
+		// If the screen fails to start, the cleanup still runs to the end
+		// and its outcomes are reported below.
+		_, _ = p.Run()
+		return <-outcomes
+	}
+
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Cleaning...")
+	for e := range events {
+		if e.Kind == clean.EventItemDone {
+			spinner.UpdateMessage(fmt.Sprintf("Cleaning %s (%d/%d) %s...",
+				e.Target, e.Done, e.Total, filepath.Base(e.Path)))
+		}
+	}
+	spinner.Stop("Files cleaned")
+	return <-outcomes
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var cleanRecycleBinCmd = &cobra.Command{
+	Use:   "recyclebin [drive...]",
+	Short: "Empty the Recycle Bin per drive",
+	Long: `Show the Recycle Bin size of every drive and empty the selected ones
+through the Windows Shell API.
+
+Without arguments, a selector lists every drive whose bin holds items.
+Pass drive letters to empty only those drives.
+
+Examples:
+  pw clean recyclebin              # Pick drives interactively
+  pw clean recyclebin D: E:        # Empty the bins on D: and E:
+  pw clean recyclebin --dry-run    # Show what would be emptied`,
+	Aliases: []string{"recycle"},
+	Run:     runCleanRecycleBin,
+}
+
+func init() {
+	cleanRecycleBinCmd.Flags().BoolP("yes", "y", false, "Empty without prompting")
+	cleanCmd.AddCommand(cleanRecycleBinCmd)
+}
+
+// recycleBinReport is the JSON form of `pw clean recyclebin`.
+type recycleBinReport struct {
+	Drives    []clean.RecycleBinDrive `json:"drives"`
+	TotalSize int64                   `json:"total_size"`
+}
+
+// runCleanRecycleBin handles `pw clean recyclebin`.
+func runCleanRecycleBin(cmd *cobra.Command, args []string) {
+	drives := clean.ScanRecycleBinDrives()
+
+	if len(args) > 0 {
+		var err error
+		drives, err = filterRecycleBinDrives(drives, args)
+		if err != nil {
+			if jsonOutput {
+				output.Fail("clean recyclebin", err)
+			}
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+			os.Exit(1)
+		}
+	}
+
+	if jsonOutput {
+		report := recycleBinReport{Drives: drives}
+		if report.Drives == nil {
+			report.Drives = []clean.RecycleBinDrive{}
+		}
+		for _, d := range drives {
+			report.TotalSize += d.Size
+		}
+		output.JSON(report)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Recycle Bin", 50))
+	fmt.Println()
+
+	if len(drives) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Recycle Bin is already empty", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	for _, d := range drives {
+		fmt.Printf("  %s %s  %s  %s\n", ui.IconBullet, ui.BoldStyle().Render(d.Drive),
+			core.FormatSize(d.Size), ui.MutedStyle().Render(fmt.Sprintf("(%d items)", d.Items)))
+	}
+	fmt.Println()
+
+	unattended, _ := cmd.Flags().GetBool("yes")
+
+	// ── Select drives ────────────────────────────────────────────────────
+	selected := drives
+	if len(args) == 0 && len(drives) > 1 && !unattended && !dryRun {
+		items := make([]ui.SelectorItem, 0, len(drives))
+		for _, d := range drives {
+			items = append(items, ui.SelectorItem{
+				Label:       d.Drive,
+				Description: fmt.Sprintf("%d items", d.Items),
+				Value:       d.Drive,
+				Size:        core.FormatSize(d.Size),
+				Bytes:       d.Size,
+				Selected:    true,
+			})
+		}
+		picked, err := ui.RunSelector(items, "Select Recycle Bins to empty")
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+			os.Exit(1)
+		}
+		chosen := make(map[string]bool, len(picked))
+		for _, p := range picked {
+			chosen[p.Value] = true
+		}
+		selected = nil
+		for _, d := range drives {
+			if chosen[d.Drive] {
+				selected = append(selected, d)
+			}
+		}
+		if len(selected) == 0 {
+			fmt.Println(ui.MutedStyle().Render("  Nothing selected."))
+			fmt.Println()
+			return
+		}
+	}
+
+	var totalSize int64
+	for _, d := range selected {
+		totalSize += d.Size
+	}
+
+	// ── Dry run ──────────────────────────────────────────────────────────
+	if dryRun {
+		for _, d := range selected {
+			fmt.Println(ui.InfoStyle().Render(fmt.Sprintf("  [DRY RUN] Would empty Recycle Bin on %s (%s)",
+				d.Drive, core.FormatSize(d.Size))))
+		}
+		fmt.Println()
+		return
+	}
+
+	// ── Confirm ──────────────────────────────────────────────────────────
+	if !unattended {
+		confirmed, err := ui.Confirm(fmt.Sprintf("  Permanently delete %s from the Recycle Bin?", core.FormatSize(totalSize)))
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
+	var logger *core.Logger
+	if cfg, err := config.Load(); err == nil {
+		if l, logErr := core.NewLogger(cfg.LogFile); logErr == nil {
+			logger = l
+			defer logger.Close()
+			logger.LogSession("clean recyclebin")
+		}
+	}
+
+	// ── Empty ────────────────────────────────────────────────────────────
+	var freed int64
+	var failed int
+	for _, d := range selected {
+		err := clean.EmptyRecycleBinDrive(d.Drive, false)
+		if logger != nil {
+			logged := d.Size
+			if err != nil {
+				logged = 0
+			}
+			logger.Log("EMPTY_RECYCLE_BIN", d.Drive, logged, err)
+		}
+		if err != nil {
+			failed++
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s: %s", ui.IconError, d.Drive, err)))
+			continue
+		}
+		freed += d.Size
+	}
+	if logger != nil {
+		logger.LogSummary(freed, len(selected)-failed, failed)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s Freed %s", ui.IconSuccess, core.FormatSize(freed))))
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %d drives could not be emptied", ui.IconWarning, failed)))
+	}
+	fmt.Println()
+}
+
+// filterRecycleBinDrives keeps the scanned drives named in args. Letters may
+// be given as "D", "D:" or "D:\". A named drive with an empty bin is not an
+// error; it is simply left out.
+func filterRecycleBinDrives(drives []clean.RecycleBinDrive, args []string) ([]clean.RecycleBinDrive, error) {
+	wanted := make(map[string]bool, len(args))
+	for _, a := range args {
+		letter := strings.ToUpper(strings.TrimRight(a, `:\/`))
+		if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+			return nil, fmt.Errorf("invalid drive %q", a)
+		}
+		wanted[letter+":"] = true
+	}
+
+	var out []clean.RecycleBinDrive
+	for _, d := range drives {
+		if wanted[d.Drive] {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/report"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Risk Gate ───────────────────────────────────────────────────────────────
+// --max-risk limits a run to targets at or below a risk level. Runs that do
+// not prompt (--yes, scheduled, piped) default to low so nothing riskier is
+// ever removed without someone having looked at it.
+
+// cleanMaxRisk returns the risk ceiling for a run: --max-risk when given,
+// otherwise low for unattended runs and high (no limit) for interactive ones.
+func cleanMaxRisk(cmd *cobra.Command, unattended bool) (string, error) {
+	if cmd.Flags().Changed("max-risk") {
+		s, _ := cmd.Flags().GetString("max-risk")
+		return config.ParseRiskLevel(s)
+	}
+	if unattended {
+		return config.RiskLow, nil
+	}
+	return config.RiskHigh, nil
+}
+
+// limitRisk drops everything above maxRisk from the scan and returns what
+// was held back, one result per target.
+func (s *cleanScan) limitRisk(maxRisk string) []clean.ScanResult {
+	var held []clean.ScanResult
+	s.results, held = clean.FilterResultsByRisk(s.results, maxRisk)
+
+	for _, extra := range []struct {
+		name string
+		size *int64
+	}{
+		{"RecycleBin", &s.recycleBinSize},
+		{"GoModCache", &s.goModSize},
+		{"WindowsOld", &s.windowsOldSize},
+	} {
+		risk := config.TargetRiskLevel(extra.name)
+		if *extra.size > 0 && !config.RiskAllowed(risk, maxRisk) {
+			held = append(held, clean.ScanResult{
+				Category:  extra.name,
+				TotalSize: *extra.size,
+				ItemCount: 1,
+				RiskLevel: risk,
+			})
+			*extra.size = 0
+		}
+	}
+	return held
+}
+
+// recordRiskHeldBack adds held-back targets to the audit report and prints
+// a one-line summary.
+func recordRiskHeldBack(rep *report.Report, held []clean.ScanResult, maxRisk string) {
+	var items int
+	var size int64
+	for _, r := range held {
+		items += r.ItemCount
+		size += r.TotalSize
+		rep.Skipped(r.Category, fmt.Sprintf("%d items", r.ItemCount),
+			fmt.Sprintf("%s risk is above --max-risk %s", r.RiskLevel, maxRisk))
+	}
+	printRiskHeldBack(items, size, maxRisk)
+}
+
+// printRiskHeldBack tells the user how much was left alone by the risk gate.
+func printRiskHeldBack(items int, size int64, maxRisk string) {
+	if items == 0 {
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Held back %d items (%s) above %s risk — raise --max-risk to include them",
+		items, core.FormatSize(size), maxRisk)))
+}
+
+// hasHighRisk reports whether any result is a high-risk target with items
+// to clean; such runs get a restore point first.
+func hasHighRisk(results []clean.ScanResult) bool {
+	for _, r := range results {
+		if r.RiskLevel == config.RiskHigh && r.ItemCount > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+// riskCommand returns a command with a --max-risk flag set to value, or
+// left unset when value is empty.
+func riskCommand(t *testing.T, value string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("max-risk", "", "")
+	if value != "" {
+		if err := cmd.Flags().Set("max-risk", value); err != nil {
+			t.Fatalf("cannot set --max-risk: %v", err)
+		}
+	}
+	return cmd
+}
+
+func TestCleanMaxRisk(t *testing.T) {
+	tests := []struct {
+		flag       string
+		unattended bool
+		want       string
+	}{
+		{"", true, config.RiskLow},
+		{"", false, config.RiskHigh},
+		{"medium", true, config.RiskMedium},
+		{"HIGH", true, config.RiskHigh},
+		{"low", false, config.RiskLow},
+	}
+	for _, tt := range tests {
+		got, err := cleanMaxRisk(riskCommand(t, tt.flag), tt.unattended)
+		if err != nil || got != tt.want {
+			t.Errorf("cleanMaxRisk(%q, unattended=%v) = %q, %v; want %q", tt.flag, tt.unattended, got, err, tt.want)
+		}
+	}
+	if _, err := cleanMaxRisk(riskCommand(t, "extreme"), true); err == nil {
+		t.Error("cleanMaxRisk should reject an unknown level")
+	}
+}
+
+// riskScan returns a scan with one low- and one medium-risk item plus the
+// API-cleaned extras: the Recycle Bin (medium), the Go module cache (low)
+// and Windows.old (high).
+func riskScan() cleanScan {
+	return cleanScan{
+		results: []clean.ScanResult{clean.ItemsToResult("Temp", []clean.CleanItem{
+			{Path: `C:\Temp\a.tmp`, Size: 1, RiskLevel: config.RiskLow},
+			{Path: `C:\Temp\b.tmp`, Size: 2, RiskLevel: config.RiskMedium},
+		})},
+		recycleBinSize: 10,
+		goModSize:      20,
+		windowsOldSize: 100,
+	}
+}
+
+func TestCleanScan_LimitRisk(t *testing.T) {
+	// The unattended default.
+	scan := riskScan()
+	held := scan.limitRisk(config.RiskLow)
+	if len(scan.results) != 1 || scan.results[0].ItemCount != 1 {
+		t.Errorf("low ceiling kept %+v, want only the low-risk item", scan.results)
+	}
+	if scan.recycleBinSize != 0 || scan.windowsOldSize != 0 || scan.goModSize != 20 {
+		t.Errorf("low ceiling left recycle bin %d, Windows.old %d, Go cache %d; want 0, 0, 20",
+			scan.recycleBinSize, scan.windowsOldSize, scan.goModSize)
+	}
+	var heldSize int64
+	for _, r := range held {
+		heldSize += r.TotalSize
+	}
+	if len(held) != 3 || heldSize != 112 {
+		t.Errorf("low ceiling held %d results (%d bytes), want 3 (112)", len(held), heldSize)
+	}
+
+	scan = riskScan()
+	if held := scan.limitRisk(config.RiskHigh); len(held) != 0 {
+		t.Errorf("high ceiling held %+v, want nothing", held)
+	}
+}
+
+func TestMaintainPlan_LimitCleanRisk(t *testing.T) {
+	plan := maintainPlan{cleanScan: riskScan()}
+	plan.cleanScan.windowsOldSize = 0 // maintain never cleans Windows.old
+	plan.limitCleanRisk(config.RiskLow)
+	if plan.heldItems != 2 || plan.heldSize != 12 {
+		t.Errorf("maintain held %d items (%d bytes), want 2 (12)", plan.heldItems, plan.heldSize)
+	}
+	if plan.cleanScan.recycleBinSize != 0 {
+		t.Error("maintain should hold the medium-risk Recycle Bin back at low risk")
+	}
+}
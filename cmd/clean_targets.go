@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var cleanTargetsCmd = &cobra.Command{
+	Use:   "targets",
+	Short: "List or edit user-defined clean targets",
+	Long: `List the user-defined clean targets from targets.json in the config
+directory, along with any entries that were rejected.
+
+Each target names one or more absolute paths (environment variables such as
+%LOCALAPPDATA% and glob patterns are allowed), a category (user, system,
+browser, dev, apps or ai) and a risk level (low, medium or high). A target joins
+the scan whenever its category is selected, e.g. a "dev" target is cleaned
+by 'pw clean --dev' and 'pw clean --all'. Targets that could reach a
+protected system path are rejected.
+
+Examples:
+  pw clean targets          # Show custom targets
+  pw clean targets --edit   # Create or open targets.json in $EDITOR`,
+	Args: cobra.NoArgs,
+	Run:  runCleanTargets,
+}
+
+func init() {
+	cleanTargetsCmd.Flags().Bool("edit", false, "Open targets.json in an editor")
+	cleanCmd.AddCommand(cleanTargetsCmd)
+}
+
+// customTargetsReport is the JSON form of `pw clean targets`.
+type customTargetsReport struct {
+	File     string               `json:"file"`
+	Targets  []config.CleanTarget `json:"targets"`
+	Rejected []string             `json:"rejected,omitempty"`
+}
+
+// runCleanTargets handles `pw clean targets`.
+func runCleanTargets(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		if jsonOutput {
+			output.Fail("clean targets", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to load config: %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if edit, _ := cmd.Flags().GetBool("edit"); edit {
+		editCustomTargets(cfg)
+		return
+	}
+
+	file := filepath.Join(cfg.ConfigDir, config.CustomTargetsFileName)
+	targets, problems, err := config.LoadCustomTargets(cfg.ConfigDir)
+	if err != nil {
+		if jsonOutput {
+			output.Fail("clean targets", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		report := customTargetsReport{File: file, Targets: targets}
+		if report.Targets == nil {
+			report.Targets = []config.CleanTarget{}
+		}
+		for _, p := range problems {
+			report.Rejected = append(report.Rejected, p.Error())
+		}
+		output.JSON(report)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Custom Clean Targets", 50))
+	fmt.Println()
+	fmt.Printf("  %s\n", ui.MutedStyle().Render(file))
+	fmt.Println()
+
+	if len(targets) == 0 && len(problems) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No custom targets defined. Run 'pw clean targets --edit' to add some."))
+		fmt.Println()
+		return
+	}
+
+	for _, t := range targets {
+		risk := ui.SuccessStyle().Render(t.RiskLevel)
+		switch t.RiskLevel {
+		case "medium":
+			risk = ui.WarningStyle().Render(t.RiskLevel)
+		case "high":
+			risk = ui.ErrorStyle().Render(t.RiskLevel)
+		}
+		fmt.Printf("  %s %s  %s  %s\n", ui.IconBullet, ui.BoldStyle().Render(t.Name),
+			ui.MutedStyle().Render("["+t.Category+"]"), risk)
+		if t.Description != t.Name {
+			fmt.Printf("      %s\n", t.Description)
+		}
+		for _, p := range t.Paths {
+			fmt.Printf("      %s\n", ui.MutedStyle().Render(p))
+		}
+	}
+
+	if len(problems) > 0 {
+		fmt.Println()
+		for _, p := range problems {
+			fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s Skipped %s", ui.IconWarning, p)))
+		}
+	}
+	fmt.Println()
+}
+
+// editCustomTargets opens targets.json in $EDITOR, creating it from a
+// template first if needed.
+func editCustomTargets(cfg *config.Config) {
+	file := filepath.Join(cfg.ConfigDir, config.CustomTargetsFileName)
+
+	if _, err := os.Stat(file); os.IsNotExist(err) {
+		if err := os.MkdirAll(cfg.ConfigDir, 0o755); err == nil {
+			err = os.WriteFile(file, []byte(config.CustomTargetsTemplate), 0o644)
+		}
+		if err != nil {
+			fmt.Printf("%s Failed to create %s: %v\n", ui.ErrorStyle().Render(ui.IconError), config.CustomTargetsFileName, err)
+			os.Exit(1)
+		}
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "notepad.exe"
+	}
+
+	c := exec.Command(editor, file)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	fmt.Println()
+	fmt.Printf("  Opening %s in %s...\n", file, editor)
+	fmt.Println()
+
+	if err := c.Run(); err != nil {
+		fmt.Printf("%s Failed to open editor: %v\n", ui.WarningStyle().Render(ui.IconWarning), err)
+		fmt.Printf("  Edit manually: %s\n", file)
+	}
+}
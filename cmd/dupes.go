@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/dupes"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var dupesCmd = &cobra.Command{
+	Use:   "dupes [path...]",
+	Short: "Find duplicate files",
+	Long: `Find files with identical content and reclaim the space taken by the extra copies.
+
+Files are grouped by size, then compared by a SHA-256 of their first 64 KB,
+and only the remaining candidates are hashed in full. The oldest copy in each
+group is kept; pick which of the others to delete, or use --link to replace
+them with hard links to the kept copy (same volume only).
+
+Defaults to scanning the current working directory.
+
+Examples:
+  pw dupes                      Find duplicates under the current directory
+  pw dupes D:\Photos E:\Backup  Compare across several folders
+  pw dupes --min-size 10MB      Only consider files of 10 MB or more
+  pw dupes --link               Hard-link duplicates instead of deleting them
+  pw dupes --json               List duplicate groups as JSON`,
+	Run: runDupes,
+}
+
+func init() {
+	dupesCmd.Flags().String("min-size", "1KB", "Ignore files smaller than this (e.g., 10MB)")
+	dupesCmd.Flags().Bool("link", false, "Replace duplicates with hard links instead of deleting them")
+	addNiceFlag(dupesCmd.Flags())
+}
+
+// dupesReport is the --json form of `pw dupes`. Nothing is changed in JSON
+// mode.
+type dupesReport struct {
+	ScanPaths []string      `json:"scan_paths"`
+	Groups    []dupes.Group `json:"groups"`
+	Copies    int           `json:"copies"`
+	Wasted    int64         `json:"wasted"`
+}
+
+func runDupes(cmd *cobra.Command, args []string) {
+	minSizeStr, _ := cmd.Flags().GetString("min-size")
+	minSize, err := config.ParseSize(minSizeStr)
+	if err != nil {
+		if jsonOutput {
+			output.Fail("dupes", fmt.Errorf("invalid --min-size: %w", err))
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Invalid --min-size: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	action := dupes.ActionDelete
+	if link, _ := cmd.Flags().GetBool("link"); link {
+		action = dupes.ActionLink
+	}
+
+	roots := args
+	if len(roots) == 0 {
+		cwd, cwdErr := os.Getwd()
+		if cwdErr != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Cannot determine current directory: %v", ui.IconError, cwdErr)))
+			os.Exit(1)
+		}
+		roots = []string{cwd}
+	}
+	for i, r := range roots {
+		if abs, absErr := filepath.Abs(r); absErr == nil {
+			roots[i] = abs
+		}
+	}
+
+	defer applyNiceFlag(cmd)()
+
+	if jsonOutput {
+		groups, err := dupes.Find(roots, dupes.Options{MinSize: minSize})
+		if err != nil {
+			output.Fail("dupes", err)
+		}
+		report := dupesReport{ScanPaths: roots, Groups: groups}
+		if report.Groups == nil {
+			report.Groups = []dupes.Group{}
+		}
+		report.Copies, report.Wasted = dupes.Summary(groups)
+		output.JSON(report)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Duplicate Files", 50))
+	for _, r := range roots {
+		fmt.Printf("  Scanning: %s\n", ui.BoldStyle().Render(r))
+	}
+	fmt.Println()
+
+	// ── Scan ─────────────────────────────────────────────────────────────
+	progress := &dupes.Progress{}
+	spinner := ui.NewInlineSpinner()
+	spinner.Start("Looking for duplicates...")
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				spinner.UpdateMessage(fmt.Sprintf("Looking for duplicates... %d files, %d hashed",
+					progress.Files(), progress.Hashed()))
+			}
+		}
+	}()
+	groups, err := dupes.Find(roots, dupes.Options{MinSize: minSize, Progress: progress})
+	close(done)
+	if err != nil {
+		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
+		os.Exit(1)
+	}
+
+	copies, wasted := dupes.Summary(groups)
+	spinner.Stop(fmt.Sprintf("Checked %d files", progress.Files()))
+	fmt.Println()
+
+	if len(groups) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s No duplicate files found", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  Found %s duplicate copies in %d groups, wasting %s\n",
+		ui.BoldStyle().Render(fmt.Sprint(copies)), len(groups), ui.BoldStyle().Render(core.FormatSize(wasted)))
+	fmt.Println()
+
+	// ── Select copies ────────────────────────────────────────────────────
+	// Each group becomes a category; its keeper is named in the header and
+	// only the redundant copies can be ticked.
+	keepers := make(map[string]string)
+	sizes := make(map[string]int64)
+	hashes := make(map[string]string)
+	var items []ui.SelectorItem
+	for i, g := range groups {
+		keep := g.Files[0].Path
+		category := fmt.Sprintf("#%d %s (%s each, keeping %s)", i+1,
+			filepath.Base(keep), core.FormatSize(g.Size), keep)
+		for _, f := range g.Files[1:] {
+			keepers[f.Path] = keep
+			sizes[f.Path] = g.Size
+			hashes[f.Path] = g.Hash
+			items = append(items, ui.SelectorItem{
+				Label:       filepath.Base(f.Path),
+				Description: filepath.Dir(f.Path),
+				Value:       f.Path,
+				Size:        core.FormatSize(g.Size),
+				Bytes:       g.Size,
+				Selected:    true,
+				Category:    category,
+			})
+		}
+	}
+
+	verb, question := "delete", "Delete"
+	if action == dupes.ActionLink {
+		verb, question = "hard-link", "Hard-link"
+	}
+	picked, err := ui.RunSelector(items, fmt.Sprintf("Select duplicates to %s:", verb))
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Selector error: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if len(picked) == 0 {
+		fmt.Println()
+		fmt.Println(ui.MutedStyle().Render("  No duplicates selected. Exiting."))
+		fmt.Println()
+		return
+	}
+
+	var selectedSize int64
+	for _, p := range picked {
+		selectedSize += sizes[p.Value]
+	}
+
+	// ── Dry run ──────────────────────────────────────────────────────────
+	if dryRun {
+		fmt.Println()
+		for _, p := range picked {
+			fmt.Println(ui.InfoStyle().Render(fmt.Sprintf("  [DRY RUN] Would %s %s", verb, p.Value)))
+		}
+		fmt.Printf("  Would free: %s from %d files\n", core.FormatSize(selectedSize), len(picked))
+		fmt.Println()
+		return
+	}
+
+	// ── Confirm ──────────────────────────────────────────────────────────
+	fmt.Println()
+	confirmed, err := ui.Confirm(fmt.Sprintf("%s %d duplicates (%s)?", question, len(picked), core.FormatSize(selectedSize)))
+	if err != nil || !confirmed {
+		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+		fmt.Println()
+		return
+	}
+
+	var logger *core.Logger
+	if cfg, err := config.Load(); err == nil {
+		if l, logErr := core.NewLogger(cfg.LogFile); logErr == nil {
+			logger = l
+			defer logger.Close()
+			logger.LogSession("dupes")
+		}
+	}
+
+	// ── Resolve ──────────────────────────────────────────────────────────
+	var freed int64
+	var resolved, failed int
+	for _, p := range picked {
+		n, err := dupes.Resolve(keepers[p.Value], p.Value, sizes[p.Value], hashes[p.Value], action, false)
+		if logger != nil {
+			logger.Log(action.String(), p.Value, n, err)
+		}
+		if err != nil {
+			failed++
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+			continue
+		}
+		freed += n
+		resolved++
+	}
+	if logger != nil {
+		logger.LogSummary(freed, resolved, failed)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s Freed %s from %d duplicates", ui.IconSuccess, core.FormatSize(freed), resolved)))
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %d duplicates could not be changed", ui.IconWarning, failed)))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// addExcludeFlag registers the repeatable --exclude flag on a flag set.
+func addExcludeFlag(fs *pflag.FlagSet) {
+	fs.StringArray("exclude", nil, `Skip paths matching this glob for this run, on top of the whitelist (e.g. "**/*.log", "C:\Work\**"; repeatable)`)
+}
+
+// applyExcludeFlag returns wl with the --exclude globs merged in. wl may be
+// nil. Invalid patterns exit.
+func applyExcludeFlag(cmd *cobra.Command, wl *whitelist.Whitelist) *whitelist.Whitelist {
+	globs, _ := cmd.Flags().GetStringArray("exclude")
+	if len(globs) == 0 {
+		return wl
+	}
+	merged, err := wl.WithExclusions(globs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return merged
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/pipeline"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Filter a JSON item list from stdin",
+	Long: `Read a JSON item list from stdin, keep the items that match the given
+criteria, and write the filtered list to stdout.
+
+Examples:
+  pw clean scan --all --json | pw filter --min-size 50MB
+  pw clean scan --dev --json | pw filter --exclude "*.lock" | pw clean apply --from - --yes`,
+	Args: cobra.NoArgs,
+	Run:  runFilter,
+}
+
+func init() {
+	filterCmd.Flags().String("min-size", "", "Drop items smaller than this size (e.g., 50MB)")
+	filterCmd.Flags().String("max-size", "", "Drop items larger than this size (e.g., 1GB)")
+	filterCmd.Flags().StringSlice("category", nil, "Keep only items in these categories")
+	filterCmd.Flags().StringSlice("exclude", nil, "Drop items whose name or path matches these globs")
+	filterCmd.Flags().String("from", "-", "Item list file to read (- for stdin)")
+}
+
+func runFilter(cmd *cobra.Command, args []string) {
+	minSizeStr, _ := cmd.Flags().GetString("min-size")
+	maxSizeStr, _ := cmd.Flags().GetString("max-size")
+	from, _ := cmd.Flags().GetString("from")
+
+	var f pipeline.Filter
+	f.Categories, _ = cmd.Flags().GetStringSlice("category")
+	f.Exclude, _ = cmd.Flags().GetStringSlice("exclude")
+
+	var err error
+	if minSizeStr != "" {
+		if f.MinSize, err = config.ParseSize(minSizeStr); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Invalid --min-size: %v\n", ui.IconError, err)
+			os.Exit(1)
+		}
+	}
+	if maxSizeStr != "" {
+		if f.MaxSize, err = config.ParseSize(maxSizeStr); err != nil {
+			fmt.Fprintf(os.Stderr, "%s Invalid --max-size: %v\n", ui.IconError, err)
+			os.Exit(1)
+		}
+	}
+
+	in, err := pipeline.Open(from)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", ui.IconError, err)
+		os.Exit(1)
+	}
+	doc, err := pipeline.Read(in)
+	in.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", ui.IconError, err)
+		os.Exit(1)
+	}
+
+	doc.Items = f.Apply(doc.Items)
+
+	if err := pipeline.Write(os.Stdout, doc); err != nil {
+		fmt.Fprintf(os.Stderr, "%s %v\n", ui.IconError, err)
+		os.Exit(1)
+	}
+}
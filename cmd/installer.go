@@ -4,12 +4,12 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/internal/installer"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +31,6 @@ Examples:
 }
 
 func init() {
-	installerCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without deleting")
 	installerCmd.Flags().Bool("all", false, "Scan default locations (Downloads, Desktop, Temp, package manager caches)")
 	installerCmd.Flags().Int("min-age", 0, "Minimum file age in days")
 	installerCmd.Flags().String("min-size", "", "Minimum file size (e.g., 10MB)")
@@ -44,8 +43,11 @@ func runInstaller(cmd *cobra.Command, args []string) {
 
 	var minSize int64
 	if minSizeStr != "" {
-		size, err := parseSize(minSizeStr)
+		size, err := config.ParseSize(minSizeStr)
 		if err != nil {
+			if jsonOutput {
+				output.Fail("installer", fmt.Errorf("invalid size format: %w", err))
+			}
 			fmt.Printf("%s Invalid size format: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
 			fmt.Println(ui.MutedStyle().Render("  Examples: 10MB, 1GB, 500KB"))
 			os.Exit(1)
@@ -61,6 +63,11 @@ func runInstaller(cmd *cobra.Command, args []string) {
 		scanTarget = args[0]
 	}
 
+	if jsonOutput {
+		runInstallerJSON(allFlag, scanTarget, minAge, minSize)
+		return
+	}
+
 	// Start scanning
 	fmt.Println()
 	fmt.Println(ui.SectionHeader("Installer Cleanup", 50))
@@ -179,6 +186,45 @@ func runInstaller(cmd *cobra.Command, args []string) {
 	}
 }
 
+// installerReport is the --json form of `pw installer`. It lists the
+// installer files found; nothing is deleted in JSON mode.
+type installerReport struct {
+	Target     string                    `json:"target,omitempty"` // empty for --all
+	Files      []installer.InstallerFile `json:"files"`
+	TotalSize  int64                     `json:"total_size"`
+	TotalCount int                       `json:"total_count"`
+}
+
+// runInstallerJSON scans like runInstaller and writes an installerReport
+// to stdout.
+func runInstallerJSON(allFlag bool, scanTarget string, minAge int, minSize int64) {
+	var files []installer.InstallerFile
+	var err error
+	if allFlag {
+		files, err = installer.ScanInstallers(minAge, minSize)
+		scanTarget = ""
+	} else {
+		if scanTarget == "" {
+			if scanTarget, err = os.Getwd(); err != nil {
+				output.Fail("installer", err)
+			}
+		}
+		files, err = installer.ScanInstallersInPath(scanTarget, minAge, minSize)
+	}
+	if err != nil {
+		output.Fail("installer", err)
+	}
+
+	report := installerReport{
+		Target:     scanTarget,
+		Files:      make([]installer.InstallerFile, 0, len(files)),
+		TotalSize:  installer.GetTotalSize(files),
+		TotalCount: len(files),
+	}
+	report.Files = append(report.Files, files...)
+	output.JSON(report)
+}
+
 // installerFilesToSelectorItems converts installer files to selector items.
 func installerFilesToSelectorItems(files []installer.InstallerFile) []ui.SelectorItem {
 	// Group by source
@@ -210,6 +256,7 @@ func installerFilesToSelectorItems(files []installer.InstallerFile) []ui.Selecto
 				Description: fmt.Sprintf("%s • %s old", file.Path, ageStr),
 				Value:       file.Path,
 				Size:        core.FormatSize(file.Size),
+				Bytes:       file.Size,
 				Selected:    true,
 				Disabled:    false,
 				Category:    source,
@@ -249,47 +296,3 @@ func formatInstallerAge(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d months", months)
 }
-
-// parseSize parses a size string like "10MB", "1.5GB" to bytes.
-func parseSize(s string) (int64, error) {
-	s = strings.TrimSpace(strings.ToUpper(s))
-
-	// Extract number and unit
-	var numStr string
-	var unit string
-	for i, r := range s {
-		if r >= '0' && r <= '9' || r == '.' {
-			numStr += string(r)
-		} else {
-			unit = s[i:]
-			break
-		}
-	}
-
-	if numStr == "" {
-		return 0, fmt.Errorf("no number found in size string")
-	}
-
-	num, err := strconv.ParseFloat(numStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid number: %w", err)
-	}
-
-	multiplier := int64(1)
-	switch unit {
-	case "B", "":
-		multiplier = 1
-	case "KB", "K":
-		multiplier = 1024
-	case "MB", "M":
-		multiplier = 1024 * 1024
-	case "GB", "G":
-		multiplier = 1024 * 1024 * 1024
-	case "TB", "T":
-		multiplier = 1024 * 1024 * 1024 * 1024
-	default:
-		return 0, fmt.Errorf("unknown unit: %s", unit)
-	}
-
-	return int64(num * float64(multiplier)), nil
-}
@@ -0,0 +1,529 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/installer"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/purge"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/internal/uninstall"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+var maintainCmd = &cobra.Command{
+	Use:   "maintain",
+	Short: "Run clean, purge, installer, remnants and optimize in one pass",
+	Long: `Run maintenance modules in one pass and report totals per module.
+
+Only clean runs by default. The other modules delete or change more than
+caches and are opt-in: name them with --only ("--only all" selects every
+module).
+
+With --dry-run nothing is changed; the result is an aggregated simulation
+report of what the selected modules would do. Add --json for
+machine-readable output.
+
+Modules and their risk levels: clean (per target), purge (medium),
+installer (medium), optimize (high), remnants (high).
+
+remnants removes what apps deleted by hand left behind: their stale
+Apps & Features entries, backed up to .reg files first, and the files,
+shortcuts and registry keys pw uninstall would preselect for them. A
+restore point is created first. Entries whose files are still on disk are
+left to pw uninstall --stale; leftover services and scheduled tasks are
+only removed interactively, by pw uninstall.
+
+--max-risk holds back clean targets and whole modules above a risk level;
+with --yes it defaults to low, as for pw clean, so the other modules also
+need --max-risk to run unattended.
+
+Examples:
+  pw maintain --dry-run --only all      Preview every module
+  pw maintain --dry-run --only all --json > plan.json
+  pw maintain --only clean,installer    Run two modules
+  pw maintain --yes                     Clean low-risk targets without prompting`,
+	Run: runMaintain,
+}
+
+func init() {
+	maintainCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+	maintainCmd.Flags().StringSlice("only", nil, "Run these modules, or \"all\" (default: clean)")
+	maintainCmd.Flags().StringSlice("skip", nil, "Skip these modules")
+	maintainCmd.Flags().Int("installer-age", 30, "Minimum age in days for installer files")
+	addRestorePointFlag(maintainCmd.Flags())
+	maintainCmd.Flags().String("max-risk", "", "Only run targets and modules at or below this risk: low, medium, high (default: low with --yes, otherwise high)")
+}
+
+// maintainModules lists the modules of `pw maintain`, in run order.
+var maintainModules = []string{"clean", "purge", "installer", "remnants", "optimize"}
+
+// maintainModuleRisk is the risk level of each module other than clean,
+// whose targets carry their own. Modules above --max-risk are held back.
+var maintainModuleRisk = map[string]string{
+	"purge":     config.RiskMedium, // Build output, rebuilt on demand.
+	"installer": config.RiskMedium, // Downloads the user may still want.
+	"optimize":  config.RiskHigh,   // Changes services and system settings.
+	"remnants":  config.RiskHigh,   // Deletes registry keys and app files.
+}
+
+// maintainPlan is the scanned state of every selected module. The report
+// holds the previews; the remaining fields hold what is needed to apply them.
+type maintainPlan struct {
+	report     *core.SimulationReport
+	cleanScan  cleanScan
+	heldItems  int   // items held back by --max-risk
+	heldSize   int64 // and their size
+	artifacts  []purge.ProjectArtifact
+	installers []installer.InstallerFile
+	stale      []uninstall.StaleEntry
+	leftovers  []uninstall.Leftover
+	optimize   []optimizeTask
+}
+
+func runMaintain(cmd *cobra.Command, args []string) {
+	jsonOut := jsonOutput
+	yes, _ := cmd.Flags().GetBool("yes")
+	only, _ := cmd.Flags().GetStringSlice("only")
+	skip, _ := cmd.Flags().GetStringSlice("skip")
+	installerAge, _ := cmd.Flags().GetInt("installer-age")
+
+	modules, err := selectMaintainModules(only, skip)
+	if err != nil {
+		if jsonOut {
+			output.Fail("maintain", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if jsonOut && !dryRun && !yes {
+		output.Fail("maintain", fmt.Errorf("--json without --dry-run requires --yes"))
+	}
+
+	maxRisk, err := cleanMaxRisk(cmd, yes)
+	if err != nil {
+		if jsonOut {
+			output.Fail("maintain", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		if jsonOut {
+			output.Fail("maintain", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	wl, wlErr := whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+	if wlErr != nil {
+		wl = nil
+	}
+
+	var spinner *ui.InlineSpinner
+	if !jsonOut {
+		fmt.Println()
+		fmt.Println(ui.SectionHeader("Maintenance", 50))
+		fmt.Println()
+		spinner = ui.NewInlineSpinner()
+		spinner.Start("Scanning...")
+	}
+
+	plan := scanMaintainPlan(cfg, wl, modules, installerAge, maxRisk)
+
+	if spinner != nil {
+		spinner.Stop(fmt.Sprintf("Scanned %d modules", len(plan.report.Modules)))
+		fmt.Println()
+	}
+
+	if dryRun {
+		if jsonOut {
+			output.JSON(plan.report)
+			return
+		}
+		printSimulationReport(plan.report)
+		printRiskHeldBack(plan.heldItems, plan.heldSize, maxRisk)
+		return
+	}
+
+	if !jsonOut {
+		printSimulationReport(plan.report)
+		printRiskHeldBack(plan.heldItems, plan.heldSize, maxRisk)
+	}
+
+	if plan.report.TotalCount == 0 {
+		return
+	}
+
+	if !yes {
+		confirmed, confirmErr := ui.Confirm(fmt.Sprintf(
+			"Apply %d changes and free %s?", plan.report.TotalCount, ui.FormatSize(plan.report.TotalSize)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			return
+		}
+		fmt.Println()
+	}
+
+	// Remnants delete registry keys: take a restore point first.
+	if len(plan.stale)+len(plan.leftovers) > 0 && !ensureRestorePoint(cmd, "before pw maintain", yes) {
+		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+		return
+	}
+
+	result := applyMaintainPlan(plan, wl, jsonOut)
+	if jsonOut {
+		output.JSON(result)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Results", 50))
+	fmt.Println()
+	printSimulationReport(result)
+}
+
+// selectMaintainModules applies --only and --skip to the module list.
+// Without --only just clean is selected; "all" selects every module.
+func selectMaintainModules(only, skip []string) ([]string, error) {
+	known := make(map[string]bool, len(maintainModules))
+	for _, m := range maintainModules {
+		known[m] = true
+	}
+	for _, name := range append(append([]string{}, only...), skip...) {
+		if !known[strings.ToLower(strings.TrimSpace(name))] && !strings.EqualFold(strings.TrimSpace(name), "all") {
+			return nil, fmt.Errorf("unknown module %q (valid: %s, all)", name, strings.Join(maintainModules, ", "))
+		}
+	}
+	if len(only) == 0 {
+		only = []string{"clean"}
+	}
+
+	contains := func(list []string, name string) bool {
+		for _, s := range list {
+			if strings.EqualFold(strings.TrimSpace(s), name) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var selected []string
+	for _, m := range maintainModules {
+		if !contains(only, m) && !contains(only, "all") {
+			continue
+		}
+		if contains(skip, m) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no modules selected")
+	}
+	return selected, nil
+}
+
+// scanMaintainPlan scans every selected module and builds the preview report.
+// Clean targets and modules above maxRisk are held back.
+func scanMaintainPlan(cfg *config.Config, wl *whitelist.Whitelist, modules []string, installerAge int, maxRisk string) maintainPlan {
+	plan := maintainPlan{report: core.NewSimulationReport(true)}
+	isAdmin := core.IsElevated()
+
+	for _, module := range modules {
+		drc := core.NewDryRunContext()
+		var skipped string
+
+		switch module {
+		case "clean":
+			// Windows.old is excluded: removing it cannot be undone and
+			// always needs its own confirmation via `pw clean --system`.
+			cats := cleanCategories{user: true, browser: true, dev: true, apps: true, system: isAdmin}
+			plan.cleanScan = scanCleanCategories(cats, wl, isAdmin, nil)
+			plan.cleanScan.windowsOldSize = 0
+			plan.limitCleanRisk(maxRisk)
+			for _, r := range plan.cleanScan.results {
+				for _, item := range r.Items {
+					drc.Add(item.Path, item.Size, item.Category)
+				}
+			}
+			if plan.cleanScan.recycleBinSize > 0 {
+				drc.Add("Recycle Bin (Shell API)", plan.cleanScan.recycleBinSize, "user")
+			}
+			if plan.cleanScan.goModSize > 0 {
+				drc.Add("Go module cache", plan.cleanScan.goModSize, "dev")
+			}
+			if !isAdmin {
+				skipped = "system caches need administrator privileges"
+			}
+
+		case "purge":
+			artifacts, err := purge.ScanProjects(getScanPaths(cfg))
+			if err != nil {
+				skipped = err.Error()
+			}
+			for _, a := range artifacts {
+				if a.IsRecent {
+					continue
+				}
+				plan.artifacts = append(plan.artifacts, a)
+				drc.Add(a.ArtifactPath, a.Size, a.ArtifactType)
+			}
+
+		case "installer":
+			files, err := installer.ScanInstallers(installerAge, 0)
+			if err != nil {
+				skipped = err.Error()
+			}
+			plan.installers = files
+			for _, f := range files {
+				drc.Add(f.Path, f.Size, f.Source)
+			}
+
+		case "remnants":
+			plan.stale, plan.leftovers = scanRemnants()
+			for _, e := range plan.stale {
+				drc.Add(e.App.RegistryKey, 0, "uninstall entry")
+			}
+			for _, l := range plan.leftovers {
+				drc.Add(l.Path, l.Size, l.Kind)
+			}
+
+		case "optimize":
+			plan.optimize = optimizeTaskPlan()
+			if !isAdmin {
+				skipped = "requires administrator privileges"
+			}
+		}
+
+		if risk, ok := maintainModuleRisk[module]; ok && !config.RiskAllowed(risk, maxRisk) {
+			plan.holdModule(module, drc)
+			plan.report.Add(core.SimulationModule{
+				Module:  module,
+				Skipped: fmt.Sprintf("%s risk is above --max-risk %s", risk, maxRisk),
+			})
+			continue
+		}
+
+		m := drc.Module(module)
+		m.Skipped = skipped
+		if module == "optimize" {
+			for _, task := range plan.optimize {
+				m.Changes = append(m.Changes, task.Name)
+			}
+		}
+		plan.report.Add(m)
+	}
+
+	return plan
+}
+
+// limitCleanRisk holds the clean targets above maxRisk back and counts them.
+func (plan *maintainPlan) limitCleanRisk(maxRisk string) {
+	for _, r := range plan.cleanScan.limitRisk(maxRisk) {
+		plan.heldItems += r.ItemCount
+		plan.heldSize += r.TotalSize
+	}
+}
+
+// holdModule drops a module held back by --max-risk from the plan and
+// counts what it would have removed.
+func (plan *maintainPlan) holdModule(module string, drc *core.DryRunContext) {
+	plan.heldItems += drc.Count()
+	plan.heldSize += drc.TotalSize()
+	switch module {
+	case "purge":
+		plan.artifacts = nil
+	case "installer":
+		plan.installers = nil
+	case "remnants":
+		plan.stale, plan.leftovers = nil, nil
+	case "optimize":
+		plan.optimize = nil
+	}
+}
+
+// applyMaintainPlan runs every scanned module and reports what was done.
+// Optimize tasks are skipped when the process is not elevated.
+func applyMaintainPlan(plan maintainPlan, wl *whitelist.Whitelist, quiet bool) *core.SimulationReport {
+	result := core.NewSimulationReport(false)
+
+	var isWhitelisted func(string) bool
+	if wl != nil {
+		isWhitelisted = wl.IsWhitelisted
+	}
+
+	for _, preview := range plan.report.Modules {
+		m := core.SimulationModule{Module: preview.Module}
+		if _, gated := maintainModuleRisk[preview.Module]; gated && preview.Skipped != "" && preview.Count == 0 {
+			m.Skipped = preview.Skipped
+			result.Add(m)
+			continue
+		}
+
+		switch preview.Module {
+		case "clean":
+			for _, r := range plan.cleanScan.results {
+				for _, item := range r.Items {
+					freed, err := core.SafeDeleteWithWhitelist(item.Path, false, isWhitelisted)
+					if err != nil {
+						m.Failed++
+						continue
+					}
+					m.Size += freed
+					m.Count++
+				}
+			}
+			if plan.cleanScan.recycleBinSize > 0 {
+				if err := clean.EmptyRecycleBin(false); err != nil {
+					m.Failed++
+				} else {
+					m.Size += plan.cleanScan.recycleBinSize
+					m.Count++
+				}
+			}
+			if plan.cleanScan.goModSize > 0 {
+				if freed, err := clean.CleanGoModCache(false); err != nil {
+					m.Failed++
+				} else {
+					m.Size += freed
+					m.Count++
+				}
+			}
+
+		case "purge":
+			freed, count, err := purge.PurgeArtifacts(plan.artifacts, false)
+			m.Size, m.Count = freed, count
+			if err != nil {
+				m.Failed = len(plan.artifacts) - count
+			}
+
+		case "installer":
+			freed, count, err := installer.CleanInstallers(plan.installers, false)
+			m.Size, m.Count = freed, count
+			if err != nil {
+				m.Failed = len(plan.installers) - count
+			}
+
+		case "remnants":
+			backupDir := registryBackupDir()
+			for _, e := range plan.stale {
+				if _, err := uninstall.RemoveStaleEntry(e, backupDir, false); err != nil {
+					m.Failed++
+					continue
+				}
+				m.Count++
+			}
+			for _, l := range plan.leftovers {
+				freed, err := uninstall.RemoveLeftover(l, backupDir, false)
+				if err != nil {
+					m.Failed++
+					continue
+				}
+				m.Size += freed
+				m.Count++
+			}
+
+		case "optimize":
+			for _, task := range plan.optimize {
+				var err error
+				if quiet {
+					err = journalOptimizeTask(task.Name, task.Run)
+				} else {
+					err = runOptimizeTask(task.Name, task.Run).Error
+				}
+				if err != nil {
+					m.Failed++
+					continue
+				}
+				m.Changes = append(m.Changes, task.Name)
+				m.Count++
+			}
+		}
+
+		result.Add(m)
+	}
+
+	return result
+}
+
+// scanRemnants finds the stale uninstall entries of apps deleted by hand and
+// the leftovers of those apps that pw uninstall would preselect. Broken
+// entries, whose files are still on disk, are skipped; so is each entry's
+// own key among the leftovers, since removing the entry deletes it.
+// Services and scheduled tasks are never removed unattended: a missing
+// program is not proof enough that nothing needs them.
+func scanRemnants() ([]uninstall.StaleEntry, []uninstall.Leftover) {
+	entries, err := uninstall.FindStaleEntries()
+	if err != nil {
+		return nil, nil
+	}
+	installed, _ := uninstall.GetInstalledApps(true)
+
+	var stale []uninstall.StaleEntry
+	var leftovers []uninstall.Leftover
+	for _, e := range entries {
+		if e.Broken {
+			continue
+		}
+		stale = append(stale, e)
+		for _, l := range uninstall.FindLeftovers(e.App, installed) {
+			if !l.Likely || strings.EqualFold(l.Path, e.App.RegistryKey) ||
+				l.Kind == uninstall.LeftoverService || l.Kind == uninstall.LeftoverTask {
+				continue
+			}
+			leftovers = append(leftovers, l)
+		}
+	}
+	return stale, leftovers
+}
+
+// printSimulationReport prints per-module totals and the overall total.
+func printSimulationReport(r *core.SimulationReport) {
+	verb := "Freed"
+	if r.DryRun {
+		verb = "Would free"
+		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] Nothing will be changed."))
+		fmt.Println()
+	}
+
+	for _, m := range r.Modules {
+		label := ui.BoldStyle().Render(fmt.Sprintf("%-10s", m.Module))
+		var detail string
+		switch {
+		case len(m.Changes) > 0 || m.Module == "optimize":
+			detail = fmt.Sprintf("%d tasks", m.Count)
+		default:
+			detail = fmt.Sprintf("%d items  %s", m.Count, ui.FormatSize(m.Size))
+		}
+		fmt.Printf("  %s %s %s\n", ui.IconBullet, label, detail)
+		if m.Failed > 0 {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("      %s %d failed", ui.IconWarning, m.Failed)))
+		}
+		if m.Skipped != "" {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("      Skipped: %s", m.Skipped)))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(ui.Divider(50))
+	fmt.Printf("  %s: %s across %d entries\n", verb,
+		ui.SuccessStyle().Render(ui.FormatSize(r.TotalSize)), r.TotalCount)
+	if r.TotalFailed > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %d entries failed", r.TotalFailed)))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+func TestSelectMaintainModules(t *testing.T) {
+	tests := []struct {
+		only, skip []string
+		want       []string
+	}{
+		{nil, nil, []string{"clean"}},
+		{[]string{"all"}, nil, maintainModules},
+		{[]string{"all"}, []string{"optimize"}, []string{"clean", "purge", "installer", "remnants"}},
+		{[]string{"Installer", "clean"}, nil, []string{"clean", "installer"}},
+	}
+	for _, tt := range tests {
+		got, err := selectMaintainModules(tt.only, tt.skip)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("selectMaintainModules(%v, %v) = %v, %v; want %v", tt.only, tt.skip, got, err, tt.want)
+		}
+	}
+	if _, err := selectMaintainModules(nil, []string{"clean"}); err == nil {
+		t.Error("skipping the only default module should leave nothing to run")
+	}
+	if _, err := selectMaintainModules([]string{"bogus"}, nil); err == nil {
+		t.Error("an unknown module should be rejected")
+	}
+}
+
+func TestMaintainModuleRisk_LowCeilingHoldsEveryModule(t *testing.T) {
+	for _, m := range maintainModules {
+		if m == "clean" {
+			continue
+		}
+		risk, ok := maintainModuleRisk[m]
+		if !ok {
+			t.Errorf("module %s has no risk level", m)
+			continue
+		}
+		if config.RiskAllowed(risk, config.RiskLow) {
+			t.Errorf("module %s (%s) would run unattended at the low default", m, risk)
+		}
+	}
+}
+
+func TestMaintainPlan_HoldModule(t *testing.T) {
+	drc := core.NewDryRunContext()
+	drc.Add(`C:\Users\me\Downloads\setup.exe`, 50, "Downloads")
+	plan := maintainPlan{}
+	plan.holdModule("installer", drc)
+	if plan.heldItems != 1 || plan.heldSize != 50 || plan.installers != nil {
+		t.Errorf("held %d items (%d bytes), installers %v; want 1 (50), none", plan.heldItems, plan.heldSize, plan.installers)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/migrate"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// runPendingMigrations upgrades data left by older versions before any
+// command runs. Output goes to stderr so piped output (completion scripts,
+// JSON) stays clean, and failures only warn: the command still runs and the
+// failed step is retried next time.
+func runPendingMigrations() {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	report, err := migrate.Run(cfg, appVersion)
+	if report != nil && len(report.Applied) > 0 {
+		from := report.FromVersion
+		if from == "" {
+			from = "an earlier version"
+		}
+		fmt.Fprintln(os.Stderr, ui.MutedStyle().Render(
+			fmt.Sprintf("  Upgraded data from %s to %s:", from, appVersion)))
+		for _, a := range report.Applied {
+			if a.Note != "" {
+				fmt.Fprintln(os.Stderr, ui.MutedStyle().Render("    "+ui.IconBullet+" "+a.Note))
+			}
+		}
+		if report.BackupDir != "" {
+			fmt.Fprintln(os.Stderr, ui.MutedStyle().Render("    Backup: "+report.BackupDir))
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	}
+}
+
+// printMigrationState shows the migration history for `pw version --migrations`.
+func printMigrationState() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	state, err := migrate.LoadState(cfg.ConfigDir)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Data Migrations", 50))
+	fmt.Printf("  Schema: %d of %d\n", state.SchemaVersion, migrate.LatestVersion())
+	if len(state.Applied) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No migrations recorded."))
+	}
+	for _, a := range state.Applied {
+		note := a.Note
+		if note == "" {
+			note = "nothing to change"
+		}
+		fmt.Printf("  %s %2d %-22s %s  %s\n", ui.IconBullet, a.Version, a.Name,
+			ui.MutedStyle().Render(a.AppliedAt.Format("2006-01-02 15:04")), note)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// addNiceFlag registers --nice on a flag set. A bare --nice selects
+// background I/O priority; --nice=200 caps deletions at 200 ops/sec.
+func addNiceFlag(fs *pflag.FlagSet) {
+	fs.String("nice", "", `Throttle deletions: "background" for low-priority I/O, a number for ops/sec, or both ("background,200")`)
+	fs.Lookup("nice").NoOptDefVal = core.NiceBackground
+}
+
+// applyNiceFlag applies the --nice setting for the rest of the command and
+// returns the function that restores normal priority. Invalid values exit.
+func applyNiceFlag(cmd *cobra.Command) func() {
+	value, _ := cmd.Flags().GetString("nice")
+	n, err := core.ParseNiceness(value)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if !n.Enabled() {
+		return func() {}
+	}
+
+	restore, err := core.ApplyNiceness(n)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle().Render(fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+	}
+	if debug {
+		fmt.Fprintln(os.Stderr, ui.MutedStyle().Render("  Deletion throttle: "+n.String()))
+	}
+	return restore
+}
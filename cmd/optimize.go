@@ -2,23 +2,62 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/ui"
 )
 
 var optimizeCmd = &cobra.Command{
 	Use:   "optimize",
 	Short: "Check and maintain system",
-	Long:  "Refresh caches, restart services, and optimize system performance.",
-	Run:   runOptimize,
+	Long: `Refresh caches, restart services, and optimize system performance.
+
+A snapshot of the current state is saved before each run; use
+'pw optimize restore' to revert to it. Every applied optimize action is
+also appended to a change journal, and --revert undoes the journaled
+changes newest first. --dry-run lists the services, registry values and
+commands each action would change.
+
+--drives optimizes fixed volumes instead: SSDs are retrimmed and hard disks
+defragmented, detected per volume.
+
+--memory trims the working sets of processes that are idle and, when
+elevated, purges the standby list, showing RAM before and after.
+
+--power shows the active power plan and the installed ones. --plan switches
+plans (Ultimate Performance is unlocked on first use), --create-tuned builds
+a High performance plan with USB selective suspend, PCIe link power
+management and disk spin-down off while plugged in, and --usb-suspend and
+--pcie-aspm change those settings in the active plan.
+
+--boot-report lists recent boot durations from the event log, whether they
+are getting faster, and the drivers, services and applications that slowed
+them down. Reading the log requires administrator privileges.
+
+Examples:
+  pw optimize                          Run all services and maintenance tasks
+  pw optimize --drives                 TRIM SSDs and defragment hard disks
+  pw optimize --drives --drive D:      Only optimize D:
+  pw optimize --drives --schedule weekly --at 02:00
+                                       Optimize drives every week
+  pw optimize --drives --unschedule    Remove the scheduled drive optimization
+  pw optimize --memory                 Trim idle working sets, purge standby
+  pw optimize --power                  Show the active power plan
+  pw optimize --power --plan ultimate  Switch to Ultimate Performance
+  pw optimize --power --create-tuned   Create and activate the tuned plan
+  pw optimize --power --usb-suspend off --pcie-aspm off
+  pw optimize --boot-report            Show recent boot times and offenders
+  pw optimize --revert --dry-run       Preview undoing the journaled changes`,
+	Run: runOptimize,
 }
 
 func init() {
-	optimizeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview optimization actions")
 	optimizeCmd.Flags().Bool("whitelist", false, "Manage protected optimization rules")
 	optimizeCmd.Flags().Bool("services", false, "Restart system services only")
 	optimizeCmd.Flags().Bool("maintenance", false, "Run maintenance tasks only")
@@ -37,6 +76,27 @@ func runOptimize(cmd *cobra.Command, args []string) {
 	maintenanceOnly, _ := cmd.Flags().GetBool("maintenance")
 	startupOnly, _ := cmd.Flags().GetBool("startup")
 
+	if revert, _ := cmd.Flags().GetBool("revert"); revert {
+		runOptimizeRevert(cmd)
+		return
+	}
+	if drives, _ := cmd.Flags().GetBool("drives"); drives {
+		runOptimizeDrives(cmd)
+		return
+	}
+	if memory, _ := cmd.Flags().GetBool("memory"); memory {
+		runOptimizeMemory()
+		return
+	}
+	if power, _ := cmd.Flags().GetBool("power"); power {
+		runOptimizePower(cmd)
+		return
+	}
+	if bootReport, _ := cmd.Flags().GetBool("boot-report"); bootReport {
+		runOptimizeBootReport(cmd)
+		return
+	}
+
 	// If --startup, show startup items and return.
 	if startupOnly {
 		optimize.ListStartupItems()
@@ -56,6 +116,17 @@ func runOptimize(cmd *cobra.Command, args []string) {
 	fmt.Println(ui.SectionHeader("System Optimization", 50))
 	fmt.Println()
 
+	// Save the current state so `pw optimize restore` can revert this run.
+	if !dryRun {
+		if cfg, err := config.Load(); err == nil {
+			if path, snapErr := saveOptimizeSnapshot(cfg); snapErr == nil {
+				fmt.Println(ui.MutedStyle().Render(
+					fmt.Sprintf("  Snapshot saved to %s", path)))
+				fmt.Println()
+			}
+		}
+	}
+
 	var results []optimizeResult
 	runAll := !servicesOnly && !maintenanceOnly
 
@@ -73,77 +144,112 @@ func runOptimize(cmd *cobra.Command, args []string) {
 	printOptimizeSummary(results)
 }
 
-// runServiceOptimizations executes service-related optimizations.
-func runServiceOptimizations() []optimizeResult {
-	fmt.Println(ui.SectionHeader("Services", 50))
-	fmt.Println()
-
-	var results []optimizeResult
+// optimizeTask is one step of `pw optimize`.
+type optimizeTask struct {
+	Section string // "Services" or "Maintenance"
+	Name    string
+	Run     func() error
+	Changes []optimize.Change // What the step changes, shown in dry runs.
+}
 
-	// DNS flush.
-	results = append(results, runOptimizeTask("Flush DNS cache", func() error {
-		return optimize.FlushDNS()
-	}))
+// optimizeTaskPlan lists every task `pw optimize` runs, in order. It is
+// shared with `pw maintain` so both preview and run the same steps.
+func optimizeTaskPlan() []optimizeTask {
+	tasks := []optimizeTask{
+		{
+			Section: "Services", Name: "Flush DNS cache", Run: optimize.FlushDNS,
+			Changes: []optimize.Change{optimize.CommandChange("ipconfig /flushdns")},
+		},
+	}
 
 	// Restart managed services.
 	for _, svc := range optimize.GetManagedServices() {
 		svc := svc // capture for closure
-		results = append(results, runOptimizeTask(
-			fmt.Sprintf("Restart %s", svc.DisplayName),
-			func() error {
-				return optimize.RestartService(svc.Name)
-			},
-		))
+		tasks = append(tasks, optimizeTask{
+			Section: "Services",
+			Name:    fmt.Sprintf("Restart %s", svc.DisplayName),
+			Run:     func() error { return optimize.RestartService(svc.Name) },
+			Changes: []optimize.Change{optimize.ServiceChange(svc.Name, "restarted with its dependents")},
+		})
 	}
 
-	fmt.Println()
-	return results
+	return append(tasks,
+		optimizeTask{
+			Section: "Maintenance", Name: "DISM component cleanup", Run: optimize.RunDISMCleanup,
+			Changes: []optimize.Change{optimize.CommandChange("DISM /Online /Cleanup-Image /StartComponentCleanup")},
+		},
+		optimizeTask{
+			Section: "Maintenance", Name: "System file integrity check", Run: optimize.RunSFCCheck,
+			Changes: []optimize.Change{optimize.CommandChange("sfc /verifyonly (read-only)")},
+		},
+		optimizeTask{
+			Section: "Maintenance", Name: "Rebuild icon cache", Run: optimize.RebuildIconCache,
+			Changes: []optimize.Change{
+				optimize.CommandChange("taskkill /F /IM explorer.exe"),
+				{Kind: "file", Target: `%LOCALAPPDATA%\Microsoft\Windows\Explorer\iconcache*`, To: "deleted"},
+				{Kind: "file", Target: `%LOCALAPPDATA%\IconCache.db`, To: "deleted"},
+				optimize.CommandChange("start explorer.exe"),
+			},
+		},
+		optimizeTask{
+			Section: "Maintenance", Name: "Rebuild search index", Run: optimize.RebuildSearchIndex,
+			Changes: []optimize.Change{optimize.ServiceChange("WSearch", "restarted with its dependents")},
+		},
+		optimizeTask{
+			Section: "Maintenance", Name: "Clear event logs", Run: optimize.ClearEventLogs,
+			Changes: []optimize.Change{
+				optimize.CommandChange("wevtutil cl Application"),
+				optimize.CommandChange("wevtutil cl System"),
+				optimize.CommandChange("wevtutil cl Security"),
+			},
+		},
+	)
+}
+
+// runServiceOptimizations executes service-related optimizations.
+func runServiceOptimizations() []optimizeResult {
+	return runOptimizeSection("Services")
 }
 
 // runMaintenanceOptimizations executes maintenance tasks.
 func runMaintenanceOptimizations() []optimizeResult {
-	fmt.Println(ui.SectionHeader("Maintenance", 50))
+	return runOptimizeSection("Maintenance")
+}
+
+// runOptimizeSection runs the planned tasks of one section under a header.
+func runOptimizeSection(section string) []optimizeResult {
+	fmt.Println(ui.SectionHeader(section, 50))
 	fmt.Println()
 
 	var results []optimizeResult
-
-	results = append(results, runOptimizeTask("DISM component cleanup", func() error {
-		return optimize.RunDISMCleanup()
-	}))
-
-	results = append(results, runOptimizeTask("System file integrity check", func() error {
-		return optimize.RunSFCCheck()
-	}))
-
-	results = append(results, runOptimizeTask("Rebuild icon cache", func() error {
-		return optimize.RebuildIconCache()
-	}))
-
-	results = append(results, runOptimizeTask("Rebuild search index", func() error {
-		return optimize.RebuildSearchIndex()
-	}))
-
-	results = append(results, runOptimizeTask("Clear event logs", func() error {
-		return optimize.ClearEventLogs()
-	}))
+	for _, task := range optimizeTaskPlan() {
+		if task.Section == section {
+			results = append(results, runOptimizeTask(task.Name, task.Run, task.Changes...))
+		}
+	}
 
 	fmt.Println()
 	return results
 }
 
-// runOptimizeTask runs a single optimization task with spinner feedback.
-func runOptimizeTask(name string, fn func() error) optimizeResult {
+// runOptimizeTask runs a single optimization task with spinner feedback
+// and records it in the change journal. Dry runs list the changes the
+// task would make instead.
+func runOptimizeTask(name string, fn func() error, changes ...optimize.Change) optimizeResult {
 	if dryRun {
 		fmt.Printf("  %s %s\n",
 			ui.WarningStyle().Render(ui.IconArrow),
 			ui.MutedStyle().Render(fmt.Sprintf("[DRY RUN] %s", name)))
+		for _, c := range changes {
+			fmt.Println(ui.MutedStyle().Render("      " + c.String()))
+		}
 		return optimizeResult{Name: name, Success: true}
 	}
 
 	spin := ui.NewInlineSpinner()
 	spin.Start(name + "...")
 
-	err := fn()
+	err := journalOptimizeTask(name, fn, changes...)
 	if err != nil {
 		spin.StopWithError(fmt.Sprintf("%s: %s", name, err))
 		return optimizeResult{Name: name, Success: false, Error: err}
@@ -153,6 +259,16 @@ func runOptimizeTask(name string, fn func() error) optimizeResult {
 	return optimizeResult{Name: name, Success: true}
 }
 
+// optimizeFail reports an optimize subcommand error, as a JSON error
+// document under --json, and exits.
+func optimizeFail(cmd *cobra.Command, err error) {
+	if jsonOutput {
+		output.Fail(cmd.CommandPath(), err)
+	}
+	fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+	os.Exit(1)
+}
+
 // printOptimizeSummary displays the final results of all operations.
 func printOptimizeSummary(results []optimizeResult) {
 	if len(results) == 0 {
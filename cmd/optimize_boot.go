@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Boot Report ─────────────────────────────────────────────────────────────
+// `pw optimize --boot-report` lists recent boot durations from the event
+// log, the trend across them, and what slowed them down, so the effect of
+// an optimization can be measured over the next few boots.
+
+// bootBarWidth is the width of the longest boot's bar.
+const bootBarWidth = 24
+
+// bootOffenderLimit is how many offenders the report lists.
+const bootOffenderLimit = 10
+
+func init() {
+	optimizeCmd.Flags().Bool("boot-report", false, "Report recent boot times and what slowed them down")
+	optimizeCmd.Flags().Int("boots", 10, "With --boot-report, how many recent boots to include")
+}
+
+// runOptimizeBootReport handles `pw optimize --boot-report`.
+func runOptimizeBootReport(cmd *cobra.Command) {
+	boots, _ := cmd.Flags().GetInt("boots")
+
+	var report optimize.BootReport
+	var err error
+	if jsonOutput {
+		report, err = optimize.GetBootReport(boots)
+	} else {
+		fmt.Println()
+		spin := ui.NewInlineSpinner()
+		spin.Start("Reading boot performance events...")
+		report, err = optimize.GetBootReport(boots)
+		spin.Stop("Boot events read")
+	}
+	if err != nil {
+		optimizeFail(cmd, fmt.Errorf("%w (reading the boot log requires administrator rights)", err))
+	}
+	if jsonOutput {
+		output.JSON(report)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Boot Report", 50))
+	fmt.Println()
+	if len(report.Boots) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No boots recorded yet; Windows logs one after each restart."))
+		fmt.Println()
+		return
+	}
+
+	printBootTimes(report.Boots)
+	printBootTrend(report)
+	printBootOffenders(report.Offenders)
+}
+
+// printBootTimes lists each boot with a bar scaled to the slowest one.
+func printBootTimes(boots []optimize.BootRecord) {
+	var slowest int64
+	for _, b := range boots {
+		slowest = max(slowest, b.BootMs)
+	}
+
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("  %-17s %9s %9s %9s",
+		"Boot", "Total", "Desktop", "Settle")))
+	for _, b := range boots {
+		bar := ""
+		if slowest > 0 {
+			bar = strings.Repeat("█", max(1, int(b.BootMs*bootBarWidth/slowest)))
+		}
+		line := fmt.Sprintf("  %-17s %9s %9s %9s  ",
+			b.Time.Local().Format("2006-01-02 15:04"),
+			formatBootMs(b.BootMs), formatBootMs(b.MainPathMs), formatBootMs(b.PostBootMs))
+		if b.Degraded {
+			fmt.Println(line + ui.WarningStyle().Render(bar+" slow"))
+			continue
+		}
+		fmt.Println(line + ui.InfoStyle().Render(bar))
+	}
+	fmt.Println()
+}
+
+// printBootTrend compares the newer half of the boots with the older half.
+func printBootTrend(report optimize.BootReport) {
+	n := len(report.Boots)
+	if n < 2 {
+		return
+	}
+	half := n / 2
+	recent := report.AverageMs(0, half)
+	earlier := report.AverageMs(half, n)
+	delta := recent - earlier
+
+	fmt.Printf("  %-28s %s\n", fmt.Sprintf("Last %d boot(s) average", half), formatBootMs(recent))
+	fmt.Printf("  %-28s %s\n", fmt.Sprintf("Previous %d boot(s) average", n-half), formatBootMs(earlier))
+	change, style := formatBootMs(delta)+" slower", ui.WarningStyle()
+	if delta <= 0 {
+		change, style = formatBootMs(-delta)+" faster", ui.SuccessStyle()
+	}
+	fmt.Printf("  %-28s %s\n", "Trend", style.Render(change))
+	fmt.Println()
+}
+
+// printBootOffenders lists what delayed boots the most.
+func printBootOffenders(offenders []optimize.BootOffender) {
+	fmt.Println(ui.BoldStyle().Render("  Main offenders"))
+	if len(offenders) == 0 {
+		fmt.Println(ui.MutedStyle().Render("    Nothing slowed these boots down."))
+		fmt.Println()
+		return
+	}
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    %-36s %-12s %6s %10s",
+		"Name", "Kind", "Boots", "Delay")))
+	for _, o := range offenders[:min(len(offenders), bootOffenderLimit)] {
+		fmt.Printf("    %-36.36s %-12s %6d %10s\n",
+			o.Name, o.Kind, o.Occurrences, formatBootMs(o.TotalDelayMs))
+	}
+	fmt.Println()
+}
+
+// formatBootMs shows milliseconds as seconds with one decimal.
+func formatBootMs(ms int64) string {
+	return fmt.Sprintf("%.1fs", (time.Duration(ms) * time.Millisecond).Seconds())
+}
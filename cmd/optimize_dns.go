@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeDNSCmd = &cobra.Command{
+	Use:   "dns",
+	Short: "Benchmark DNS resolvers and switch between them",
+	Long: `Measure how fast the current DNS servers and popular public resolvers
+(Cloudflare, Google, Quad9) answer, and optionally switch connected
+adapters to one of them.
+
+The previous servers are saved before the first change; --revert puts them
+back (adapters that used DHCP go back to DHCP-provided servers).
+
+Examples:
+  pw optimize dns                         Benchmark resolvers
+  pw optimize dns --set fastest           Use the fastest public resolver
+  pw optimize dns --set cloudflare --adapter Wi-Fi
+  pw optimize dns --revert                Restore the previous servers
+  pw optimize dns --flush                 Clear the DNS cache`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeDNS,
+}
+
+func init() {
+	optimizeDNSCmd.Flags().String("set", "", "Switch to a resolver: cloudflare, google, quad9 or fastest")
+	optimizeDNSCmd.Flags().StringSlice("adapter", nil, "With --set, only change these adapters (repeatable)")
+	optimizeDNSCmd.Flags().Bool("revert", false, "Restore the DNS servers from before the first change")
+	optimizeDNSCmd.Flags().Bool("flush", false, "Clear the DNS resolver cache")
+	optimizeDNSCmd.Flags().Int("rounds", 3, "Queries per test domain when benchmarking")
+	optimizeDNSCmd.MarkFlagsMutuallyExclusive("set", "revert")
+	optimizeCmd.AddCommand(optimizeDNSCmd)
+}
+
+func runOptimizeDNS(cmd *cobra.Command, args []string) {
+	set, _ := cmd.Flags().GetString("set")
+	only, _ := cmd.Flags().GetStringSlice("adapter")
+	revert, _ := cmd.Flags().GetBool("revert")
+	flush, _ := cmd.Flags().GetBool("flush")
+	rounds, _ := cmd.Flags().GetInt("rounds")
+	if rounds < 1 {
+		rounds = 1
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		optimizeFail(cmd, fmt.Errorf("failed to load config: %w", err))
+	}
+
+	switch {
+	case revert:
+		revertDNS(cfg)
+		return
+	case flush && set == "":
+		fmt.Println()
+		requireAdminOrExit("flush DNS")
+		runOptimizeTask("Flush DNS cache", optimize.FlushDNS)
+		fmt.Println()
+		return
+	}
+
+	adapters, err := optimize.ListAdapterDNS()
+	if err != nil {
+		optimizeFail(cmd, err)
+	}
+	if len(only) > 0 {
+		adapters = filterAdapters(adapters, only)
+		if len(adapters) == 0 {
+			optimizeFail(cmd, fmt.Errorf("no connected adapter named %s", strings.Join(only, ", ")))
+		}
+	}
+
+	var resolvers []optimize.Resolver
+	if current := optimize.CurrentResolver(adapters); len(current.Servers) > 0 {
+		resolvers = append(resolvers, current)
+	}
+	resolvers = append(resolvers, optimize.PublicResolvers()...)
+
+	if !jsonOutput {
+		fmt.Println()
+		fmt.Println(ui.SectionHeader("DNS", 50))
+		fmt.Println()
+	}
+
+	var results []optimize.DNSBenchmark
+	if set == "" || strings.EqualFold(set, "fastest") {
+		if jsonOutput {
+			results = optimize.BenchmarkResolvers(resolvers, rounds)
+		} else {
+			spin := ui.NewInlineSpinner()
+			spin.Start(fmt.Sprintf("Benchmarking %d resolvers...", len(resolvers)))
+			results = optimize.BenchmarkResolvers(resolvers, rounds)
+			spin.Stop("Benchmark complete")
+			fmt.Println()
+		}
+	}
+
+	if set == "" {
+		if jsonOutput {
+			output.JSON(struct {
+				Adapters  []optimize.AdapterDNS   `json:"adapters"`
+				Benchmark []optimize.DNSBenchmark `json:"benchmark"`
+			}{adapters, results})
+			return
+		}
+		printAdapterDNS(adapters)
+		printDNSBenchmark(results)
+		fmt.Println(ui.MutedStyle().Render("  Switch with: pw optimize dns --set <cloudflare|google|quad9|fastest>"))
+		fmt.Println()
+		return
+	}
+
+	resolver, err := pickResolver(set, results)
+	if err != nil {
+		optimizeFail(cmd, err)
+	}
+	if len(results) > 0 {
+		printDNSBenchmark(results)
+	}
+
+	requireAdminOrExit("change DNS servers")
+	var tasks []optimizeResult
+	for _, a := range adapters {
+		a := a // capture for closure
+		tasks = append(tasks, runOptimizeTask(
+			fmt.Sprintf("Set %s to %s (%s)", a.Alias, resolver.Name, strings.Join(resolver.Servers, ", ")),
+			func() error { return optimize.SetAdapterDNS(cfg.ConfigDir, a, resolver.Servers) },
+			optimize.NotRevertible("pw optimize dns --revert", optimize.Change{
+				Kind: "dns", Target: a.Alias,
+				From: strings.Join(a.Servers, ", "), To: strings.Join(resolver.Servers, ", ") + " (manual)",
+			})...))
+	}
+	if flush {
+		tasks = append(tasks, runOptimizeTask("Flush DNS cache", optimize.FlushDNS,
+			optimize.CommandChange("ipconfig /flushdns")))
+	}
+	fmt.Println()
+	printOptimizeSummary(tasks)
+	if !dryRun {
+		fmt.Println(ui.MutedStyle().Render("  Revert with: pw optimize dns --revert"))
+		fmt.Println()
+	}
+}
+
+// pickResolver resolves --set to a public resolver; "fastest" takes the
+// quickest public resolver that answered every query.
+func pickResolver(set string, results []optimize.DNSBenchmark) (optimize.Resolver, error) {
+	if !strings.EqualFold(set, "fastest") {
+		return optimize.FindResolver(set)
+	}
+	for _, r := range results {
+		if r.Resolver == "current" || !r.Reliable() {
+			continue
+		}
+		return optimize.FindResolver(r.Resolver)
+	}
+	return optimize.Resolver{}, fmt.Errorf("no public resolver answered every query")
+}
+
+// revertDNS restores the adapters recorded before the first change.
+func revertDNS(cfg *config.Config) {
+	saved, err := optimize.LoadDNSBackup(cfg.ConfigDir)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println()
+	if len(saved) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No DNS changes to revert."))
+		fmt.Println()
+		return
+	}
+	for _, a := range saved {
+		fmt.Printf("  %s %-24s %s\n", ui.IconBullet, a.Alias, describeAdapterDNS(a))
+	}
+	fmt.Println()
+
+	requireAdminOrExit("change DNS servers")
+	result := runOptimizeTask("Restore DNS servers", func() error {
+		_, err := optimize.RevertAdapterDNS(cfg.ConfigDir)
+		return err
+	})
+	fmt.Println()
+	printOptimizeSummary([]optimizeResult{result})
+}
+
+// printAdapterDNS lists connected adapters and their DNS servers.
+func printAdapterDNS(adapters []optimize.AdapterDNS) {
+	fmt.Println(ui.BoldStyle().Render("  Adapters"))
+	if len(adapters) == 0 {
+		fmt.Println(ui.MutedStyle().Render("    No connected adapters."))
+	}
+	for _, a := range adapters {
+		fmt.Printf("    %s %-24s %s\n", ui.IconBullet, a.Alias, describeAdapterDNS(a))
+	}
+	fmt.Println()
+}
+
+// describeAdapterDNS formats an adapter's servers and where they came from.
+func describeAdapterDNS(a optimize.AdapterDNS) string {
+	source := "DHCP"
+	if a.Static {
+		source = "manual"
+	}
+	servers := strings.Join(a.Servers, ", ")
+	if servers == "" {
+		servers = "none"
+	}
+	return servers + " " + ui.MutedStyle().Render("("+source+")")
+}
+
+// printDNSBenchmark shows the benchmark as a table, fastest first.
+func printDNSBenchmark(results []optimize.DNSBenchmark) {
+	fmt.Println(ui.BoldStyle().Render("  Resolvers"))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    %-12s %-16s %9s %9s %9s %9s",
+		"Resolver", "Server", "Median", "Min", "Max", "Answered")))
+	for i, r := range results {
+		answered := fmt.Sprintf("%d/%d", r.Answered, r.Queries)
+		if r.Answered == 0 {
+			fmt.Printf("    %-12s %-16s %s\n", r.Resolver, r.Server, ui.ErrorStyle().Render("no answer"))
+			continue
+		}
+		line := fmt.Sprintf("    %-12s %-16s %9s %9s %9s %9s",
+			r.Resolver, r.Server, formatLatency(r.Median), formatLatency(r.Min), formatLatency(r.Max), answered)
+		switch {
+		case i == 0:
+			line = ui.SuccessStyle().Render(line)
+		case !r.Reliable():
+			line = ui.WarningStyle().Render(line)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// formatLatency shows a duration in milliseconds.
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.1f ms", float64(d)/float64(time.Millisecond))
+}
+
+// filterAdapters keeps the adapters with the given aliases.
+func filterAdapters(adapters []optimize.AdapterDNS, aliases []string) []optimize.AdapterDNS {
+	var kept []optimize.AdapterDNS
+	for _, a := range adapters {
+		for _, alias := range aliases {
+			if strings.EqualFold(a.Alias, alias) {
+				kept = append(kept, a)
+				break
+			}
+		}
+	}
+	return kept
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Drive Optimization ──────────────────────────────────────────────────────
+// `pw optimize --drives` retrims SSDs and defragments hard disks, picking
+// the operation per volume from the media type of its physical disk.
+
+func init() {
+	optimizeCmd.Flags().Bool("drives", false, "TRIM SSDs and defragment hard disks")
+	optimizeCmd.Flags().StringSlice("drive", nil, "With --drives, only optimize these drives (e.g. C:, repeatable)")
+	optimizeCmd.Flags().String("schedule", "", "With --drives, run it daily, weekly or monthly")
+	optimizeCmd.Flags().String("at", "03:00", "With --schedule, local start time (HH:MM)")
+	optimizeCmd.Flags().Bool("unschedule", false, "With --drives, remove the scheduled drive optimization")
+	optimizeCmd.MarkFlagsMutuallyExclusive("schedule", "unschedule")
+}
+
+// runOptimizeDrives handles `pw optimize --drives`.
+func runOptimizeDrives(cmd *cobra.Command) {
+	only, _ := cmd.Flags().GetStringSlice("drive")
+	every, _ := cmd.Flags().GetString("schedule")
+	unschedule, _ := cmd.Flags().GetBool("unschedule")
+
+	drives := make([]string, 0, len(only))
+	for _, d := range only {
+		drives = append(drives, strings.ToUpper(strings.TrimRight(strings.TrimSpace(d), `:\`))+":")
+	}
+
+	if unschedule || every != "" {
+		scheduleOptimizeDrives(cmd, drives, every, unschedule)
+		return
+	}
+
+	vols, err := optimize.ListVolumes()
+	if err != nil {
+		if jsonOutput {
+			output.Fail(cmd.CommandPath(), err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if len(drives) > 0 {
+		vols = filterVolumes(vols, drives)
+	}
+	if jsonOutput {
+		output.JSON(append(make([]optimize.Volume, 0, len(vols)), vols...))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Drive Optimization", 50))
+	fmt.Println()
+
+	if len(vols) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No matching NTFS or ReFS volumes found."))
+		fmt.Println()
+		return
+	}
+
+	for _, v := range vols {
+		label := v.Label
+		if label == "" {
+			label = "Local Disk"
+		}
+		fmt.Printf("  %s %-3s %-18s %-12s %s free of %s  %s\n",
+			ui.IconBullet,
+			ui.BoldStyle().Render(v.Drive),
+			label,
+			v.Media,
+			core.FormatSize(v.Free),
+			core.FormatSize(v.Size),
+			ui.MutedStyle().Render(v.FileSystem),
+		)
+	}
+	fmt.Println()
+
+	requireAdminOrExit("optimize drives")
+
+	var results []optimizeResult
+	for _, v := range vols {
+		results = append(results, runVolumeOptimization(v))
+	}
+	fmt.Println()
+	printOptimizeSummary(results)
+}
+
+// runVolumeOptimization optimizes one volume, showing defrag.exe's progress
+// on the spinner.
+func runVolumeOptimization(v optimize.Volume) optimizeResult {
+	name := fmt.Sprintf("%s %s", v.Action(), v.Drive)
+	if dryRun {
+		return runOptimizeTask(name, nil)
+	}
+
+	spin := ui.NewInlineSpinner()
+	spin.Start(name + "...")
+	err := journalOptimizeTask(name, func() error {
+		return optimize.OptimizeVolume(v, func(percent int) {
+			spin.UpdateMessage(fmt.Sprintf("%s... %d%%", name, percent))
+		})
+	})
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("%s: %s", name, err))
+		return optimizeResult{Name: name, Success: false, Error: err}
+	}
+	spin.Stop(name)
+	return optimizeResult{Name: name, Success: true}
+}
+
+// filterVolumes keeps the volumes on the given drives.
+func filterVolumes(vols []optimize.Volume, drives []string) []optimize.Volume {
+	var kept []optimize.Volume
+	for _, v := range vols {
+		for _, d := range drives {
+			if strings.EqualFold(v.Drive, d) {
+				kept = append(kept, v)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// scheduleOptimizeDrives registers or removes the recurring drive
+// optimization task.
+func scheduleOptimizeDrives(cmd *cobra.Command, drives []string, every string, unschedule bool) {
+	if unschedule {
+		if !core.ScheduledTaskExists(optimize.DrivesTaskName) {
+			fmt.Println(ui.MutedStyle().Render("  No scheduled drive optimization."))
+			return
+		}
+		if dryRun {
+			fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+				"  [DRY RUN] Would remove %s", optimize.DrivesTaskName)))
+			return
+		}
+		if err := core.DeleteScheduledTask(optimize.DrivesTaskName); err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+			"  %s Scheduled drive optimization removed.", ui.IconSuccess)))
+		return
+	}
+
+	at, _ := cmd.Flags().GetString("at")
+	task := optimize.DrivesTask(drives, strings.ToLower(every), at)
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  [DRY RUN] Would schedule %q: pw %s (%s at %s)",
+			task.Name, strings.Join(task.Args, " "), task.Frequency, task.StartTime)))
+		return
+	}
+	if err := core.RequireAdmin("schedule drive optimization"); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if err := core.CreateScheduledTask(task); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+		"  %s Scheduled %s: pw %s", ui.IconSuccess, task.Name, strings.Join(task.Args, " "))))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Runs %s at %s with administrator rights.", task.Frequency, task.StartTime)))
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/status"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeGPUCmd = &cobra.Command{
+	Use:   "gpu",
+	Short: "Show GPU memory and purge driver shader caches",
+	Long: `Show each graphics adapter with its driver version and video memory in
+use, and the size of the DirectX and driver shader caches.
+
+--purge-shaders empties the DirectX cache and the caches of the GPU vendors
+installed in this machine; --vendor picks caches explicitly. Purging helps
+after a driver update or when a game renders corrupted shaders. Drivers
+rebuild the caches on demand, so games may stutter briefly on next launch.
+
+Examples:
+  pw optimize gpu
+  pw optimize gpu --purge-shaders --dry-run
+  pw optimize gpu --purge-shaders --vendor nvidia`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeGPU,
+}
+
+func init() {
+	optimizeGPUCmd.Flags().Bool("purge-shaders", false, "Empty the shader caches")
+	optimizeGPUCmd.Flags().StringSlice("vendor", nil, "With --purge-shaders, only these caches: directx, nvidia, amd, intel (repeatable)")
+	optimizeCmd.AddCommand(optimizeGPUCmd)
+}
+
+func runOptimizeGPU(cmd *cobra.Command, args []string) {
+	purge, _ := cmd.Flags().GetBool("purge-shaders")
+	vendors, _ := cmd.Flags().GetStringSlice("vendor")
+
+	gpus := status.CollectGPUs()
+	caches := optimize.ShaderCaches()
+
+	if !purge {
+		if jsonOutput {
+			output.JSON(struct {
+				GPUs         []status.GPUInfo       `json:"gpus"`
+				ShaderCaches []optimize.ShaderCache `json:"shader_caches"`
+			}{gpus, caches})
+			return
+		}
+		fmt.Println()
+		fmt.Println(ui.SectionHeader("GPU", 50))
+		fmt.Println()
+		printGPUs(gpus)
+		printShaderCaches(caches)
+		fmt.Println(ui.MutedStyle().Render("  Purge with: pw optimize gpu --purge-shaders"))
+		fmt.Println()
+		return
+	}
+
+	// By default purge DirectX plus the vendors of the installed GPUs.
+	wanted := map[string]bool{"directx": true}
+	for _, g := range gpus {
+		if v, err := optimize.ShaderVendor(g.Vendor); err == nil {
+			wanted[v] = true
+		}
+	}
+	if len(vendors) > 0 {
+		wanted = make(map[string]bool, len(vendors))
+		for _, name := range vendors {
+			v, err := optimize.ShaderVendor(name)
+			if err != nil {
+				optimizeFail(cmd, err)
+			}
+			wanted[v] = true
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Shader Caches", 50))
+	fmt.Println()
+
+	var results []optimizeResult
+	var freed int64
+	for _, c := range caches {
+		if !wanted[c.Vendor] {
+			continue
+		}
+		c := c // capture for closure
+		var planned []optimize.Change
+		for _, p := range c.Paths {
+			planned = append(planned, optimize.Change{Kind: "file", Target: p + `\*`, To: "deleted"})
+		}
+		results = append(results, runOptimizeTask(
+			fmt.Sprintf("Purge %s (%s)", c.Description, core.FormatSize(c.Size)),
+			func() error {
+				n, err := optimize.PurgeShaderCache(c)
+				freed += n
+				return err
+			}, planned...))
+	}
+	if len(results) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No matching shader caches found."))
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	if !dryRun {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s freed", core.FormatSize(freed))))
+		fmt.Println()
+	}
+}
+
+// printGPUs lists adapters with their driver and VRAM use.
+func printGPUs(gpus []status.GPUInfo) {
+	if len(gpus) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No graphics adapter found."))
+		fmt.Println()
+		return
+	}
+	for _, g := range gpus {
+		fmt.Printf("  %s %s\n", ui.IconBullet, ui.BoldStyle().Render(g.Name))
+		if g.DriverVersion != "" {
+			fmt.Printf("    %-10s %s\n", "Driver", g.DriverVersion)
+		}
+		switch {
+		case g.VRAMTotal > 0 && g.VRAMUsed > 0:
+			fmt.Printf("    %-10s %s of %s in use (%.0f%%)\n", "VRAM",
+				core.FormatSize(int64(g.VRAMUsed)), core.FormatSize(int64(g.VRAMTotal)),
+				float64(g.VRAMUsed)/float64(g.VRAMTotal)*100)
+		case g.VRAMTotal > 0:
+			fmt.Printf("    %-10s %s\n", "VRAM", core.FormatSize(int64(g.VRAMTotal)))
+		}
+	}
+	fmt.Println()
+}
+
+// printShaderCaches lists the shader caches found on disk.
+func printShaderCaches(caches []optimize.ShaderCache) {
+	fmt.Println(ui.BoldStyle().Render("  Shader caches"))
+	if len(caches) == 0 {
+		fmt.Println(ui.MutedStyle().Render("    None found."))
+	}
+	for _, c := range caches {
+		fmt.Printf("    %-8s %10s  %s\n", c.Vendor, core.FormatSize(c.Size), ui.MutedStyle().Render(c.Description))
+	}
+	fmt.Println()
+}
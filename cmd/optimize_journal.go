@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Change Journal ──────────────────────────────────────────────────────────
+// Every optimize task that runs for real goes through journalOptimizeTask,
+// which appends what it changed to the journal in the config dir.
+// `pw optimize --revert` undoes the journaled changes newest first.
+
+// optimizeJournal is the journal of the current run, opened on first use.
+var optimizeJournal *optimize.Journal
+
+// journalPaused stops tasks from being journaled while a revert runs, so
+// the revert itself is not reverted next time.
+var journalPaused bool
+
+func init() {
+	optimizeCmd.Flags().Bool("revert", false, "Undo the journaled optimize changes, newest first")
+	optimizeCmd.Flags().Bool("yes", false, "With --revert, skip the confirmation prompt")
+}
+
+// journalOptimizeTask runs fn and records it in the change journal, with
+// the declared changes a revert cannot undo. Without a config dir the task
+// still runs, just unjournaled.
+func journalOptimizeTask(name string, fn func() error, changes ...optimize.Change) error {
+	if journalPaused {
+		return fn()
+	}
+	if optimizeJournal == nil {
+		cfg, err := config.Load()
+		if err != nil {
+			return fn()
+		}
+		optimizeJournal = optimize.OpenJournal(cfg.ConfigDir)
+	}
+	return optimizeJournal.Record(name, fn, changes...)
+}
+
+// runOptimizeRevert handles `pw optimize --revert`.
+func runOptimizeRevert(cmd *cobra.Command) {
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	cfg, err := config.Load()
+	if err != nil {
+		optimizeFail(cmd, fmt.Errorf("failed to load config: %w", err))
+	}
+	entries, err := optimize.PendingJournalEntries(cfg.ConfigDir)
+	if err != nil {
+		optimizeFail(cmd, err)
+	}
+	if jsonOutput && dryRun {
+		output.JSON(append(make([]optimize.JournalEntry, 0, len(entries)), entries...))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Revert Optimize Changes", 50))
+	fmt.Println()
+
+	var reversible, manual int
+	for _, e := range entries {
+		if e.Reversible() {
+			reversible++
+		}
+		if len(e.NotRevertible()) > 0 {
+			manual++
+		}
+	}
+	if reversible == 0 && manual == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Nothing to revert since the last revert.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	printJournalEntries(entries)
+
+	if reversible == 0 {
+		printNotRevertedNote(manual)
+		return
+	}
+	if dryRun {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  %d action(s) would be reverted, newest first. Run without --dry-run to apply.", reversible)))
+		fmt.Println()
+		printNotRevertedNote(manual)
+		return
+	}
+	requireAdminOrExit("revert optimize changes")
+	if !skipConfirm {
+		confirmed, confirmErr := ui.Confirm(fmt.Sprintf("  Revert %d action(s)?", reversible))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Revert cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Println()
+
+	journalPaused = true
+	defer func() { journalPaused = false }()
+
+	var results []optimizeResult
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if !e.Reversible() {
+			continue
+		}
+		// Each revert starts from the state the newer reverts left.
+		current, err := optimize.CaptureSnapshot()
+		if err != nil {
+			optimizeFail(cmd, fmt.Errorf("cannot read system state: %w", err))
+		}
+		for _, c := range optimize.JournalRevertChanges(e, current) {
+			c := c // capture for closure
+			results = append(results, runOptimizeTask(
+				fmt.Sprintf("%s: %s → %s", e.Operation, c.Target, c.To), c.Apply))
+		}
+	}
+	fmt.Println()
+
+	failed := false
+	for _, r := range results {
+		if !r.Success {
+			failed = true
+		}
+	}
+	// After a partial failure the entries stay pending, so running
+	// --revert again retries what is left; reverted items diff as clean.
+	if !failed {
+		if err := optimize.MarkJournalReverted(cfg.ConfigDir); err != nil {
+			fmt.Println(ui.WarningStyle().Render(fmt.Sprintf("  %s %v", ui.IconWarning, err)))
+		}
+	}
+	if len(results) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s System already matches the state before these actions.", ui.IconSuccess)))
+		fmt.Println()
+		printNotRevertedNote(manual)
+		return
+	}
+	printOptimizeSummary(results)
+	printNotRevertedNote(manual)
+}
+
+// printNotRevertedNote warns that n actions changed state a revert leaves
+// alone, as marked in the listing.
+func printNotRevertedNote(n int) {
+	if n == 0 {
+		return
+	}
+	fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+		"  %s %d action(s) made changes --revert cannot undo; undo them by hand as noted above.", ui.IconWarning, n)))
+	fmt.Println()
+}
+
+// printJournalEntries lists pending entries newest first with what each
+// changed; entries that only ran commands are shown as not reversible, and
+// changes a revert cannot undo are marked with how to undo them by hand.
+func printJournalEntries(entries []optimize.JournalEntry) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		stamp := ui.MutedStyle().Render(e.Time.Local().Format("2006-01-02 15:04"))
+		if len(e.Changes) == 0 {
+			fmt.Printf("  %s %s %s\n", stamp, e.Operation, ui.MutedStyle().Render("(no tracked changes, not reversible)"))
+			continue
+		}
+		fmt.Printf("  %s %s\n", stamp, ui.BoldStyle().Render(e.Operation))
+		for _, c := range e.Changes {
+			line := "      " + c.String()
+			if c.NoRevert {
+				fmt.Println(ui.WarningStyle().Render(line))
+				continue
+			}
+			fmt.Println(ui.MutedStyle().Render(line))
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeHibernationCmd = &cobra.Command{
+	Use:   "hibernation",
+	Short: "Show or change hibernation (hiberfil.sys)",
+	Long: `Show whether hibernation is enabled and how much space hiberfil.sys takes.
+
+hiberfil.sys is usually 40% or more of installed RAM. Turning hibernation
+off deletes it, but also disables hibernate and Fast Startup.
+
+Examples:
+  pw optimize hibernation          Show hibernation status
+  pw optimize hibernation --off    Disable hibernation and delete hiberfil.sys
+  pw optimize hibernation --on     Re-enable hibernation`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeHibernation,
+}
+
+var optimizePagefileCmd = &cobra.Command{
+	Use:   "pagefile",
+	Short: "Review or resize the pagefile",
+	Long: `Show each pagefile with its allocated size, current and peak usage, and
+whether Windows manages its size.
+
+--size fixes the pagefile size (initial:maximum, e.g. 4GB:8GB). Setting it
+below the peak usage can cause out-of-memory errors. Changes take effect
+after a restart.
+
+Examples:
+  pw optimize pagefile                     Show pagefile usage
+  pw optimize pagefile --size 4GB:8GB      Fix the size on the system drive
+  pw optimize pagefile --size 2GB --drive D:
+  pw optimize pagefile --auto              Let Windows manage the size again`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizePagefile,
+}
+
+func init() {
+	optimizeHibernationCmd.Flags().Bool("off", false, "Disable hibernation and delete hiberfil.sys")
+	optimizeHibernationCmd.Flags().Bool("on", false, "Enable hibernation")
+	optimizeHibernationCmd.MarkFlagsMutuallyExclusive("off", "on")
+
+	optimizePagefileCmd.Flags().String("size", "", "Fixed pagefile size as initial[:maximum] (e.g. 4GB:8GB)")
+	optimizePagefileCmd.Flags().String("drive", "", "Drive of the pagefile to resize (default: system drive)")
+	optimizePagefileCmd.Flags().Bool("auto", false, "Let Windows manage pagefile size")
+	optimizePagefileCmd.MarkFlagsMutuallyExclusive("size", "auto")
+
+	optimizeCmd.AddCommand(optimizeHibernationCmd)
+	optimizeCmd.AddCommand(optimizePagefileCmd)
+}
+
+// requireAdminOrExit stops with the standard elevation hint unless running
+// elevated. Dry runs do not need admin.
+func requireAdminOrExit(operation string) {
+	if dryRun {
+		return
+	}
+	if err := core.RequireAdmin(operation); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+}
+
+// ─── Hibernation ─────────────────────────────────────────────────────────────
+
+func runOptimizeHibernation(cmd *cobra.Command, args []string) {
+	turnOff, _ := cmd.Flags().GetBool("off")
+	turnOn, _ := cmd.Flags().GetBool("on")
+
+	state := optimize.GetHibernation()
+	if jsonOutput {
+		output.JSON(state)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Hibernation", 50))
+	status := ui.MutedStyle().Render("disabled")
+	if state.Enabled {
+		status = ui.WarningStyle().Render("enabled")
+	}
+	fmt.Printf("  %-14s %s\n", "Hibernation:", status)
+	if state.FileSize > 0 {
+		fmt.Printf("  %-14s %s %s\n", "hiberfil.sys:", ui.BoldStyle().Render(core.FormatSize(state.FileSize)),
+			ui.MutedStyle().Render(state.Path))
+	} else {
+		fmt.Printf("  %-14s %s\n", "hiberfil.sys:", ui.MutedStyle().Render("not present"))
+	}
+	fmt.Println()
+
+	switch {
+	case turnOff:
+		if !state.Enabled && state.FileSize == 0 {
+			fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Hibernation is already off.", ui.IconSuccess)))
+			fmt.Println()
+			return
+		}
+		requireAdminOrExit("optimize hibernation")
+		changes := optimize.NotRevertible("pw optimize hibernation --on",
+			optimize.Change{Kind: "hibernation", Target: "hiberfil.sys", From: "on", To: "off"})
+		if dryRun {
+			fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+				"  [DRY RUN] Would disable hibernation and free %s", core.FormatSize(state.FileSize))))
+			fmt.Println(ui.MutedStyle().Render("      " + changes[0].String()))
+			fmt.Println()
+			return
+		}
+		confirmed, err := ui.DangerConfirm(fmt.Sprintf(
+			"Disable hibernation? This deletes hiberfil.sys (%s) and turns off hibernate and Fast Startup.",
+			core.FormatSize(state.FileSize)))
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			fmt.Println()
+			return
+		}
+		result := runOptimizeTask("Disable hibernation", func() error { return optimize.SetHibernation(false) }, changes...)
+		if result.Success {
+			fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+				"  %s Freed %s", ui.IconSuccess, core.FormatSize(state.FileSize))))
+		}
+		fmt.Println()
+
+	case turnOn:
+		if state.Enabled {
+			fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Hibernation is already on.", ui.IconSuccess)))
+			fmt.Println()
+			return
+		}
+		requireAdminOrExit("optimize hibernation")
+		runOptimizeTask("Enable hibernation", func() error { return optimize.SetHibernation(true) },
+			optimize.NotRevertible("pw optimize hibernation --off",
+				optimize.Change{Kind: "hibernation", Target: "hiberfil.sys", From: "off", To: "on"})...)
+		fmt.Println()
+
+	default:
+		if state.FileSize > 0 {
+			fmt.Println(ui.MutedStyle().Render("  Run 'pw optimize hibernation --off' to reclaim this space."))
+			fmt.Println()
+		}
+	}
+}
+
+// ─── Pagefile ────────────────────────────────────────────────────────────────
+
+func runOptimizePagefile(cmd *cobra.Command, args []string) {
+	sizeFlag, _ := cmd.Flags().GetString("size")
+	drive, _ := cmd.Flags().GetString("drive")
+	auto, _ := cmd.Flags().GetBool("auto")
+
+	state, err := optimize.GetPagefile()
+	if err != nil {
+		if jsonOutput {
+			output.Fail(cmd.CommandPath(), err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if jsonOutput {
+		if state.Files == nil {
+			state.Files = []optimize.PagefileInfo{}
+		}
+		output.JSON(state)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Pagefile", 50))
+	printPagefileState(state)
+
+	switch {
+	case sizeFlag != "":
+		initialMB, maximumMB, err := parsePagefileSize(sizeFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Invalid --size: %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		path := pagefilePath(drive)
+		var peakMB int64
+		for _, f := range state.Files {
+			if strings.EqualFold(f.Path, path) {
+				peakMB = f.PeakMB
+			}
+		}
+		if maximumMB < peakMB {
+			fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+				"  %s Maximum %d MB is below the peak usage of %d MB.", ui.IconWarning, maximumMB, peakMB)))
+		}
+
+		requireAdminOrExit("optimize pagefile")
+		changes := optimize.NotRevertible(pagefileUndo(state, path), optimize.Change{
+			Kind: "pagefile", Target: path, From: pagefileSetting(state, path),
+			To: fmt.Sprintf("%d-%d MB", initialMB, maximumMB),
+		})
+		if dryRun {
+			fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+				"  [DRY RUN] Would set %s to %d-%d MB", path, initialMB, maximumMB)))
+			fmt.Println(ui.MutedStyle().Render("      " + changes[0].String()))
+			fmt.Println()
+			return
+		}
+		confirmed, err := ui.DangerConfirm(fmt.Sprintf(
+			"Set %s to a fixed %d-%d MB? Too small a pagefile can crash programs when memory runs low.",
+			path, initialMB, maximumMB))
+		if err != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			fmt.Println()
+			return
+		}
+		result := runOptimizeTask(fmt.Sprintf("Resize %s", path), func() error {
+			return optimize.SetPagefileSize(path, initialMB, maximumMB)
+		}, changes...)
+		if result.Success {
+			fmt.Println(ui.MutedStyle().Render("  Restart Windows to apply the new size."))
+		}
+		fmt.Println()
+
+	case auto:
+		if state.Automatic {
+			fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+				"  %s Windows already manages the pagefile size.", ui.IconSuccess)))
+			fmt.Println()
+			return
+		}
+		requireAdminOrExit("optimize pagefile")
+		result := runOptimizeTask("Enable automatic pagefile size", optimize.SetPagefileAutomatic,
+			optimize.NotRevertible(pagefileUndo(state, ""), optimize.Change{
+				Kind: "pagefile", Target: "all drives", From: "fixed size", To: "system managed",
+			})...)
+		if result.Success && !dryRun {
+			fmt.Println(ui.MutedStyle().Render("  Restart Windows to apply the change."))
+		}
+		fmt.Println()
+	}
+}
+
+// printPagefileState lists each pagefile with its size and usage.
+func printPagefileState(state optimize.PagefileState) {
+	managed := "fixed size"
+	if state.Automatic {
+		managed = "managed by Windows"
+	}
+	fmt.Printf("  %-14s %s\n", "Sizing:", managed)
+	fmt.Println()
+
+	if len(state.Files) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No pagefile in use."))
+		fmt.Println()
+		return
+	}
+	const mb = 1024 * 1024
+	for _, f := range state.Files {
+		limits := "system managed"
+		if f.InitialMB > 0 || f.MaximumMB > 0 {
+			limits = fmt.Sprintf("%d-%d MB", f.InitialMB, f.MaximumMB)
+		}
+		fmt.Printf("  %s %s\n", ui.IconBullet, ui.BoldStyle().Render(f.Path))
+		fmt.Printf("      %-12s %s\n", "Allocated:", core.FormatSize(f.AllocatedMB*mb))
+		fmt.Printf("      %-12s %s (peak %s)\n", "In use:",
+			core.FormatSize(f.CurrentMB*mb), core.FormatSize(f.PeakMB*mb))
+		fmt.Printf("      %-12s %s\n", "Limits:", limits)
+	}
+	fmt.Println()
+}
+
+// parsePagefileSize parses "initial[:maximum]" sizes such as "4GB:8GB" or
+// "4096" (megabytes when no unit is given) into megabytes.
+func parsePagefileSize(s string) (initialMB, maximumMB int64, err error) {
+	parts := strings.SplitN(s, ":", 2)
+	toMB := func(v string) (int64, error) {
+		v = strings.TrimSpace(v)
+		if v != "" && strings.Trim(v, "0123456789") == "" {
+			v += "MB"
+		}
+		n, err := config.ParseSize(v)
+		return n / (1024 * 1024), err
+	}
+	if initialMB, err = toMB(parts[0]); err != nil {
+		return 0, 0, err
+	}
+	maximumMB = initialMB
+	if len(parts) == 2 {
+		if maximumMB, err = toMB(parts[1]); err != nil {
+			return 0, 0, err
+		}
+	}
+	if initialMB < 16 {
+		return 0, 0, fmt.Errorf("initial size must be at least 16 MB")
+	}
+	if maximumMB < initialMB {
+		return 0, 0, fmt.Errorf("maximum size is smaller than the initial size")
+	}
+	return initialMB, maximumMB, nil
+}
+
+// pagefileSetting describes the configured size of the pagefile at path.
+func pagefileSetting(state optimize.PagefileState, path string) string {
+	if state.Automatic {
+		return "system managed"
+	}
+	for _, f := range state.Files {
+		if strings.EqualFold(f.Path, path) && f.InitialMB > 0 {
+			return fmt.Sprintf("%d-%d MB", f.InitialMB, f.MaximumMB)
+		}
+	}
+	return "system managed"
+}
+
+// pagefileUndo returns the commands that restore the current size of the
+// pagefile at path, or of every pagefile when path is "", or "" when there
+// is no fixed size to restore.
+func pagefileUndo(state optimize.PagefileState, path string) string {
+	if state.Automatic {
+		return "pw optimize pagefile --auto"
+	}
+	var cmds []string
+	for _, f := range state.Files {
+		if (path == "" || strings.EqualFold(f.Path, path)) && f.InitialMB > 0 && len(f.Path) >= 2 {
+			cmds = append(cmds, fmt.Sprintf("pw optimize pagefile --drive %s --size %d:%d", f.Path[:2], f.InitialMB, f.MaximumMB))
+		}
+	}
+	return strings.Join(cmds, "; ")
+}
+
+// pagefilePath returns the pagefile path on drive, or on the system drive
+// when drive is empty. "D", "D:" and "D:\" are all accepted.
+func pagefilePath(drive string) string {
+	drive = strings.TrimRight(strings.TrimSpace(drive), `:\`)
+	if drive == "" {
+		drive = strings.TrimRight(os.Getenv("SystemDrive"), `:\`)
+	}
+	if drive == "" {
+		drive = "C"
+	}
+	return strings.ToUpper(drive) + `:\pagefile.sys`
+}
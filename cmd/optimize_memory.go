@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/status"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Memory Optimization ─────────────────────────────────────────────────────
+// `pw optimize --memory` trims the working sets of idle processes and, when
+// elevated, purges the standby list, then shows RAM before and after.
+
+func init() {
+	optimizeCmd.Flags().Bool("memory", false, "Trim idle working sets and purge the standby list")
+}
+
+// runOptimizeMemory handles `pw optimize --memory`.
+func runOptimizeMemory() {
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Memory", 50))
+	fmt.Println()
+
+	before, beforeErr := status.CollectMemory()
+	elevated := core.IsElevated()
+
+	if dryRun {
+		runOptimizeTask("Trim working sets of idle processes", nil)
+		if elevated {
+			runOptimizeTask("Purge standby list", nil)
+		}
+		fmt.Println()
+		if beforeErr == nil {
+			fmt.Printf("  %-12s %s of %s used, %s free\n", "Now",
+				core.FormatSize(int64(before.Used)), core.FormatSize(int64(before.Total)),
+				core.FormatSize(int64(before.Free)))
+			fmt.Println()
+		}
+		return
+	}
+
+	var results []optimizeResult
+	var trim optimize.WorkingSetTrim
+	results = append(results, runOptimizeTask("Trim working sets of idle processes", func() error {
+		var err error
+		trim, err = optimize.TrimIdleWorkingSets()
+		return err
+	}))
+	if elevated {
+		results = append(results, runOptimizeTask("Purge standby list", optimize.PurgeStandbyList))
+	}
+	fmt.Println()
+
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  %d process(es) trimmed, %d busy, %d not accessible", trim.Trimmed, trim.Busy, trim.Skipped)))
+	if !elevated {
+		fmt.Println(ui.MutedStyle().Render(
+			"  Standby list not purged: run elevated to purge it and trim system processes."))
+	}
+	fmt.Println()
+
+	after, afterErr := status.CollectMemory()
+	if beforeErr == nil && afterErr == nil {
+		printMemoryChange(before, after)
+	}
+	printOptimizeSummary(results)
+}
+
+// printMemoryChange shows used, available and free RAM before and after.
+func printMemoryChange(before, after status.MemoryMetrics) {
+	fmt.Printf("  %-12s %s\n", "",
+		ui.MutedStyle().Render(fmt.Sprintf("%12s %12s %12s", "Before", "After", "Change")))
+	rows := []struct {
+		label         string
+		before, after uint64
+	}{
+		{"Used", before.Used, after.Used},
+		{"Available", before.Available, after.Available},
+		{"Free", before.Free, after.Free},
+	}
+	for _, r := range rows {
+		delta := int64(r.after) - int64(r.before)
+		change := core.FormatSize(delta)
+		if delta > 0 {
+			change = "+" + change
+		} else if delta < 0 {
+			change = "-" + core.FormatSize(-delta)
+		}
+		fmt.Printf("  %-12s %12s %12s %12s\n", r.label,
+			core.FormatSize(int64(r.before)), core.FormatSize(int64(r.after)), change)
+	}
+	fmt.Println()
+
+	// Trimmed pages move to the standby list and stop counting as used;
+	// purging the standby list turns them into free pages.
+	reclaimed := int64(before.Used) - int64(after.Used)
+	freed := int64(after.Free) - int64(before.Free)
+	if reclaimed > 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+			"  %s Reclaimed %s of RAM from working sets", ui.IconSuccess, core.FormatSize(reclaimed))))
+	}
+	if freed > 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+			"  %s %s more free RAM", ui.IconSuccess, core.FormatSize(freed))))
+	}
+	if reclaimed > 0 || freed > 0 {
+		fmt.Println()
+	}
+}
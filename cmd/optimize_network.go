@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeNetworkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Reset and tune the network stack",
+	Long: `Reset parts of the Windows network stack, or run them all with --repair:
+
+  --dns          Flush the DNS resolver cache
+  --arp          Flush the ARP (IP-to-MAC) cache
+  --netbios      Purge and reload the NetBIOS name cache
+  --dhcp         Renew every adapter's DHCP lease
+  --winsock      Reset the Winsock catalog (needs a reboot)
+  --autotuning   Set TCP receive window auto-tuning (normal, disabled, ...)
+
+--repair runs DNS, ARP, NetBIOS, DHCP and Winsock in that order. All
+actions require administrator privileges; --dry-run describes each one.
+
+Examples:
+  pw optimize network --repair --dry-run
+  pw optimize network --arp --netbios
+  pw optimize network --autotuning disabled
+  pw optimize network --autotuning normal`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeNetwork,
+}
+
+func init() {
+	optimizeNetworkCmd.Flags().Bool("repair", false, "Run every reset step")
+	optimizeNetworkCmd.Flags().Bool("dns", false, "Flush the DNS cache")
+	optimizeNetworkCmd.Flags().Bool("arp", false, "Flush the ARP cache")
+	optimizeNetworkCmd.Flags().Bool("netbios", false, "Flush the NetBIOS name cache")
+	optimizeNetworkCmd.Flags().Bool("dhcp", false, "Renew DHCP leases")
+	optimizeNetworkCmd.Flags().Bool("winsock", false, "Reset the Winsock catalog")
+	optimizeNetworkCmd.Flags().String("autotuning", "", "Set TCP auto-tuning level (normal, disabled, restricted, highlyrestricted, experimental)")
+	optimizeCmd.AddCommand(optimizeNetworkCmd)
+}
+
+func runOptimizeNetwork(cmd *cobra.Command, args []string) {
+	actions, err := networkActionsFromFlags(cmd)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	if len(actions) == 0 {
+		_ = cmd.Help()
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Network", 50))
+	fmt.Println()
+
+	if dryRun {
+		for _, a := range actions {
+			fmt.Printf("  %s %s\n",
+				ui.WarningStyle().Render(ui.IconArrow),
+				ui.MutedStyle().Render(fmt.Sprintf("[DRY RUN] %s", a.Name)))
+			fmt.Println(ui.MutedStyle().Render("      " + a.Description))
+			for _, c := range a.Changes {
+				fmt.Println(ui.MutedStyle().Render("      " + c.String()))
+			}
+		}
+		fmt.Println()
+		return
+	}
+	requireAdminOrExit("reset the network stack")
+
+	var results []optimizeResult
+	reboot := false
+	for _, a := range actions {
+		r := runOptimizeTask(a.Name, a.Run, a.Changes...)
+		results = append(results, r)
+		if r.Success && a.NeedsReboot {
+			reboot = true
+		}
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	if reboot {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Restart Windows to finish the Winsock reset.", ui.IconWarning)))
+		fmt.Println()
+	}
+}
+
+// networkActionsFromFlags returns the requested actions in repair order,
+// with TCP auto-tuning last.
+func networkActionsFromFlags(cmd *cobra.Command) ([]optimize.NetworkAction, error) {
+	repair, _ := cmd.Flags().GetBool("repair")
+	autotuning, _ := cmd.Flags().GetString("autotuning")
+
+	// Repair actions in order, each also selectable by its own flag.
+	var actions []optimize.NetworkAction
+	for _, a := range optimize.NetworkRepairActions() {
+		on, _ := cmd.Flags().GetBool(a.Key)
+		if repair || on {
+			actions = append(actions, a)
+		}
+	}
+
+	if autotuning != "" {
+		a, err := optimize.TCPAutoTuningAction(autotuning)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Power Plans ─────────────────────────────────────────────────────────────
+// `pw optimize --power` shows the active power plan and the installed ones,
+// switches plans, creates a tuned plan, and toggles USB selective suspend
+// and PCIe link power management in the active plan.
+
+func init() {
+	optimizeCmd.Flags().Bool("power", false, "Show and manage power plans")
+	optimizeCmd.Flags().String("plan", "", "With --power, activate a plan: balanced, high, ultimate, saver, tuned, a name or a GUID")
+	optimizeCmd.Flags().Bool("create-tuned", false, "With --power, create the "+optimize.TunedPlanName+" plan and activate it")
+	optimizeCmd.Flags().String("usb-suspend", "", "With --power, turn USB selective suspend on or off in the active plan")
+	optimizeCmd.Flags().String("pcie-aspm", "", "With --power, set PCIe link power management to off, moderate or maximum")
+	optimizeCmd.MarkFlagsMutuallyExclusive("plan", "create-tuned")
+}
+
+// runOptimizePower handles `pw optimize --power`.
+func runOptimizePower(cmd *cobra.Command) {
+	plan, _ := cmd.Flags().GetString("plan")
+	createTuned, _ := cmd.Flags().GetBool("create-tuned")
+	usbSuspend, _ := cmd.Flags().GetString("usb-suspend")
+	aspm, _ := cmd.Flags().GetString("pcie-aspm")
+
+	var usbEnabled bool
+	if usbSuspend != "" {
+		switch strings.ToLower(usbSuspend) {
+		case "on":
+			usbEnabled = true
+		case "off":
+		default:
+			optimizeFail(cmd, fmt.Errorf("--usb-suspend must be on or off, not %q", usbSuspend))
+		}
+	}
+
+	if plan == "" && !createTuned && usbSuspend == "" && aspm == "" {
+		showPowerStatus(cmd)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Power Plan", 50))
+	fmt.Println()
+
+	// The snapshot records the active plan, so restore switches back.
+	if !dryRun {
+		if cfg, err := config.Load(); err == nil {
+			if path, snapErr := saveOptimizeSnapshot(cfg); snapErr == nil {
+				fmt.Println(ui.MutedStyle().Render(
+					fmt.Sprintf("  Snapshot saved to %s", path)))
+				fmt.Println()
+			}
+		}
+	}
+
+	// Dry runs show the settings as they are now next to the new values.
+	st := &optimize.PowerStatus{}
+	if dryRun {
+		if now, err := optimize.GetPowerStatus(); err == nil {
+			st = now
+		}
+	}
+	planChange := func(to string) optimize.Change {
+		return optimize.Change{Kind: "power", Target: "Active power plan", From: st.Active.Name, To: to}
+	}
+
+	var results []optimizeResult
+	switch {
+	case createTuned:
+		results = append(results, runOptimizeTask("Create and activate "+optimize.TunedPlanName, func() error {
+			p, err := optimize.CreateTunedPlan()
+			if err != nil {
+				return err
+			}
+			return optimize.SetActivePowerPlan(p.GUID)
+		},
+			optimize.Change{Kind: "power", Target: "Plan " + optimize.TunedPlanName, To: "created from High performance"},
+			planChange(optimize.TunedPlanName)))
+	case strings.EqualFold(plan, "tuned"):
+		results = append(results, runOptimizeTask("Activate "+optimize.TunedPlanName, func() error {
+			p, err := optimize.FindPowerPlan(optimize.TunedPlanName)
+			if err != nil {
+				return fmt.Errorf("%w; create it with --create-tuned", err)
+			}
+			return optimize.SetActivePowerPlan(p.GUID)
+		}, planChange(optimize.TunedPlanName)))
+	case plan != "":
+		results = append(results, runOptimizeTask("Activate "+plan, func() error {
+			p, err := optimize.FindPowerPlan(plan)
+			if err != nil {
+				return err
+			}
+			return optimize.SetActivePowerPlan(p.GUID)
+		}, planChange(plan)))
+	}
+	if usbSuspend != "" {
+		results = append(results, runOptimizeTask("USB selective suspend "+strings.ToLower(usbSuspend),
+			func() error { return optimize.SetUSBSelectiveSuspend(usbEnabled) },
+			optimize.Change{
+				Kind: "power", Target: "USB selective suspend (plugged in, on battery)",
+				From: joinPowerValues(st.USBSuspendAC, st.USBSuspendDC), To: strings.ToLower(usbSuspend),
+			}))
+	}
+	if aspm != "" {
+		results = append(results, runOptimizeTask("PCIe link power management "+strings.ToLower(aspm),
+			func() error { return optimize.SetPCIeASPM(aspm) },
+			optimize.Change{
+				Kind: "power", Target: "PCIe link power management (plugged in, on battery)",
+				From: joinPowerValues(st.PCIeASPMAC, st.PCIeASPMDC), To: strings.ToLower(aspm),
+			}))
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	if !dryRun {
+		showPowerStatus(cmd)
+	}
+}
+
+// joinPowerValues formats a plugged-in and on-battery value pair, empty
+// when unknown.
+func joinPowerValues(ac, dc string) string {
+	if ac == "" && dc == "" {
+		return ""
+	}
+	return ac + ", " + dc
+}
+
+// showPowerStatus prints the active plan, its toggleable settings, and the
+// installed plans.
+func showPowerStatus(cmd *cobra.Command) {
+	st, err := optimize.GetPowerStatus()
+	if err != nil {
+		optimizeFail(cmd, err)
+	}
+	if jsonOutput {
+		output.JSON(st)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Power Plan", 50))
+	fmt.Println()
+	fmt.Printf("  %-28s %s\n", "Active plan", ui.BoldStyle().Render(st.Active.Name))
+	fmt.Printf("  %-28s %s\n", "", ui.MutedStyle().Render(st.Active.GUID))
+	fmt.Println()
+	fmt.Printf("  %-28s %-12s %s\n", "", ui.MutedStyle().Render("Plugged in"), ui.MutedStyle().Render("On battery"))
+	fmt.Printf("  %-28s %-12s %s\n", "USB selective suspend", st.USBSuspendAC, st.USBSuspendDC)
+	fmt.Printf("  %-28s %-12s %s\n", "PCIe link power management", st.PCIeASPMAC, st.PCIeASPMDC)
+	fmt.Println()
+
+	fmt.Println(ui.BoldStyle().Render("  Installed plans"))
+	for _, p := range st.Plans {
+		marker := "  "
+		name := p.Name
+		if p.Active {
+			marker = ui.SuccessStyle().Render(ui.IconSuccess + " ")
+			name = ui.BoldStyle().Render(name)
+		}
+		fmt.Printf("    %s%-32s %s\n", marker, name, ui.MutedStyle().Render(p.GUID))
+	}
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render("  Switch with: pw optimize --power --plan <balanced|high|ultimate|tuned|name>"))
+	fmt.Println()
+}
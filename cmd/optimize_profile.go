@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeProfileCmd = &cobra.Command{
+	Use:   "profile [name]",
+	Short: "Apply a curated service startup profile",
+	Long: `Set the startup type of a vetted list of services for a use case. Each
+profile turns off telemetry services and features most PCs never use (Fax,
+Store demo mode, ...) plus:
+
+  gaming             SysMain (Superfetch) off, search indexing on demand
+  developer          Search indexing and Xbox services on demand
+  minimal-telemetry  Telemetry services only
+
+Startup types are changed through the service control manager; running
+services keep running until the next restart. The changes are shown as a
+before/after diff first, and a snapshot is saved so
+'pw optimize restore <snapshot>' reverts them.
+
+Without a name, the profiles and their services are listed.
+
+Examples:
+  pw optimize profile
+  pw optimize profile gaming --dry-run
+  pw optimize profile developer --yes`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runOptimizeProfile,
+}
+
+func init() {
+	optimizeProfileCmd.Flags().Bool("yes", false, "Skip the confirmation prompt")
+	optimizeCmd.AddCommand(optimizeProfileCmd)
+}
+
+func runOptimizeProfile(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		listServiceProfiles()
+		return
+	}
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	profile, err := optimize.FindServiceProfile(args[0])
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Service Profile: "+profile.Name, 50))
+	fmt.Println(ui.MutedStyle().Render("  " + profile.Description))
+	fmt.Println()
+
+	changes := optimize.PlanProfile(profile)
+	if len(changes) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Services already match the %s profile.", ui.IconSuccess, profile.Name)))
+		fmt.Println()
+		return
+	}
+	printProfileDiff(changes)
+
+	if dryRun {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  %d service(s) would change. Run without --dry-run to apply.", len(changes))))
+		fmt.Println()
+		return
+	}
+
+	if !core.IsElevated() {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Changing service startup types requires administrator privileges.", ui.IconWarning)))
+		fmt.Println()
+	}
+
+	if !skipConfirm {
+		confirmed, confirmErr := ui.Confirm(fmt.Sprintf("  Change %d service(s)?", len(changes)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Println()
+
+	// The snapshot is the revert file for this run.
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	snapPath, err := saveOptimizeSnapshot(cfg)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Not applying the profile without a snapshot to revert to: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	var results []optimizeResult
+	for _, c := range changes {
+		c := c // capture for closure
+		results = append(results, runOptimizeTask(
+			fmt.Sprintf("%s → %s", c.Service, c.To),
+			func() error { return optimize.ApplyProfileChange(c) }))
+	}
+	fmt.Println()
+
+	// Show the state after the run, so a change that did not stick is
+	// visible.
+	fmt.Println(ui.BoldStyle().Render("  After"))
+	for _, c := range changes {
+		now, readErr := optimize.ServiceStartType(c.Service)
+		if readErr != nil {
+			now = "unknown"
+		}
+		style := ui.SuccessStyle()
+		if now != c.To {
+			style = ui.ErrorStyle()
+		}
+		fmt.Printf("    %-42s %s\n", c.Service, style.Render(now))
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  Revert with: pw optimize restore %s", filepath.Base(snapPath))))
+	fmt.Println()
+}
+
+// printProfileDiff shows each service's current and new startup type.
+func printProfileDiff(changes []optimize.ProfileChange) {
+	fmt.Println(ui.BoldStyle().Render("  Services"))
+	for _, c := range changes {
+		fmt.Printf("    %s %-40s %s %s %s\n",
+			ui.WarningStyle().Render(ui.IconArrow),
+			c.Service,
+			ui.ErrorStyle().Render(c.From),
+			ui.MutedStyle().Render("→"),
+			ui.SuccessStyle().Render(c.To))
+		fmt.Println(ui.MutedStyle().Render("      " + c.Reason))
+	}
+	fmt.Println()
+}
+
+// listServiceProfiles prints every profile and the settings it applies.
+func listServiceProfiles() {
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Service Profiles", 50))
+	fmt.Println()
+	for _, p := range optimize.ServiceProfiles() {
+		fmt.Printf("  %s  %s\n", ui.BoldStyle().Render(p.Name), ui.MutedStyle().Render(p.Description))
+		for _, s := range p.Services {
+			fmt.Printf("    %s %-42s %s\n", ui.IconBullet, s.Name, ui.MutedStyle().Render(s.StartType))
+		}
+		fmt.Println()
+	}
+	fmt.Println(ui.MutedStyle().Render("  Apply one with: pw optimize profile <name>"))
+	fmt.Println()
+}
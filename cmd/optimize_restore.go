@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the current optimize-related system state",
+	Long: `Record services, tracked registry tweaks, startup entries, and the active
+power plan so they can be restored later with 'pw optimize restore'.
+
+A snapshot is also saved automatically before every 'pw optimize' run.`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeSnapshot,
+}
+
+var optimizeRestoreCmd = &cobra.Command{
+	Use:   "restore [snapshot]",
+	Short: "Restore system state from an optimize snapshot",
+	Long: `Compare a saved snapshot against the current system, show what will be
+reverted, and restore the recorded state after confirmation.
+
+Without an argument the newest snapshot is used.
+
+Registry values are restored only when PureWin tracks them, and startup
+entries only when a journaled optimize action changed them. Startup
+entries added since the snapshot are left alone.
+
+Examples:
+  pw optimize restore --dry-run
+  pw optimize restore --list
+  pw optimize restore optimize-20260101-120000.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runOptimizeRestore,
+}
+
+func init() {
+	optimizeRestoreCmd.Flags().Bool("list", false, "List saved snapshots")
+	optimizeRestoreCmd.Flags().Bool("yes", false, "Skip the confirmation prompt")
+
+	optimizeCmd.AddCommand(optimizeSnapshotCmd)
+	optimizeCmd.AddCommand(optimizeRestoreCmd)
+}
+
+// ─── Snapshot ────────────────────────────────────────────────────────────────
+
+func runOptimizeSnapshot(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	path, err := saveOptimizeSnapshot(cfg)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s Snapshot saved to %s", ui.IconSuccess, path)))
+}
+
+// saveOptimizeSnapshot captures and saves the current state.
+func saveOptimizeSnapshot(cfg *config.Config) (string, error) {
+	snap, err := optimize.CaptureSnapshot()
+	if err != nil {
+		return "", err
+	}
+	return optimize.SaveSnapshot(cfg.ConfigDir, snap)
+}
+
+// ─── Restore ─────────────────────────────────────────────────────────────────
+
+func runOptimizeRestore(cmd *cobra.Command, args []string) {
+	listOnly, _ := cmd.Flags().GetBool("list")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if listOnly {
+		listOptimizeSnapshots(cfg)
+		return
+	}
+
+	path, err := resolveSnapshotPath(cfg, args)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	saved, err := optimize.LoadSnapshot(path)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	spin := ui.NewInlineSpinner()
+	spin.Start("Reading current system state...")
+	current, err := optimize.CaptureSnapshot()
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("Cannot read system state: %v", err))
+		os.Exit(1)
+	}
+	spin.Stop("Read current system state")
+
+	changes := optimize.DiffSnapshot(saved, current, optimize.JournaledStartup(cfg.ConfigDir))
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Restore Snapshot", 50))
+	fmt.Printf("  %s %s\n", ui.MutedStyle().Render("Snapshot:"), filepath.Base(path))
+	fmt.Printf("  %s %s\n", ui.MutedStyle().Render("Taken:   "),
+		saved.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Println()
+
+	if untracked := optimize.UntrackedRegistryValues(saved); len(untracked) > 0 {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s Ignoring %d registry value(s) PureWin does not track:", ui.IconWarning, len(untracked))))
+		for _, v := range untracked {
+			fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("    %s\\%s\\%s", v.Root, v.Path, v.Name)))
+		}
+		fmt.Println()
+	}
+
+	if len(changes) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s System already matches the snapshot.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	printSnapshotDiff(changes)
+
+	if dryRun {
+		fmt.Println(ui.MutedStyle().Render(
+			fmt.Sprintf("  %d change(s) would be reverted. Run without --dry-run to apply.", len(changes))))
+		fmt.Println()
+		return
+	}
+
+	if !core.IsElevated() {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Service and machine-wide changes require administrator privileges.", ui.IconWarning)))
+		fmt.Println()
+	}
+
+	if !skipConfirm {
+		confirmed, confirmErr := ui.Confirm(fmt.Sprintf("  Revert %d change(s)?", len(changes)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Restore cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+	fmt.Println()
+
+	var results []optimizeResult
+	for _, c := range changes {
+		c := c // capture for closure
+		results = append(results, runOptimizeTask(
+			fmt.Sprintf("%s → %s", c.Target, c.To), c.Apply))
+	}
+	fmt.Println()
+	printOptimizeSummary(results)
+}
+
+// resolveSnapshotPath returns the snapshot named in args, looking in the
+// snapshot directory for bare file names, or the newest snapshot.
+func resolveSnapshotPath(cfg *config.Config, args []string) (string, error) {
+	if len(args) == 0 {
+		return optimize.LatestSnapshot(cfg.ConfigDir)
+	}
+	path := args[0]
+	if _, err := os.Stat(path); err != nil && filepath.Base(path) == path {
+		path = filepath.Join(optimize.SnapshotDir(cfg.ConfigDir), path)
+	}
+	return path, nil
+}
+
+// printSnapshotDiff shows each pending change grouped by kind.
+func printSnapshotDiff(changes []optimize.SnapshotChange) {
+	kinds := []struct{ kind, title string }{
+		{"service", "Services"},
+		{"registry", "Registry"},
+		{"startup", "Startup"},
+		{"power", "Power"},
+	}
+	for _, k := range kinds {
+		header := false
+		for _, c := range changes {
+			if c.Kind != k.kind {
+				continue
+			}
+			if !header {
+				fmt.Println(ui.BoldStyle().Render("  " + k.title))
+				header = true
+			}
+			fmt.Printf("    %s %-40s %s %s %s\n",
+				ui.WarningStyle().Render(ui.IconArrow),
+				c.Target,
+				ui.ErrorStyle().Render(c.From),
+				ui.MutedStyle().Render("→"),
+				ui.SuccessStyle().Render(c.To))
+		}
+		if header {
+			fmt.Println()
+		}
+	}
+}
+
+// listOptimizeSnapshots prints the saved snapshots, newest first.
+func listOptimizeSnapshots(cfg *config.Config) {
+	paths, err := optimize.ListSnapshots(cfg.ConfigDir)
+	if err != nil || len(paths) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No optimize snapshots found."))
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Optimize Snapshots", 50))
+	for _, p := range paths {
+		fmt.Printf("  %s %s\n", ui.IconBullet, filepath.Base(p))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage persistent per-application priority and affinity rules",
+	Long: `Store rules such as "run OBS at high priority" or "limit OneDrive to 2 cores".
+
+Rules are applied once to each matching process when it is first seen:
+by 'pw optimize rules watch', by 'pw status' while it runs, or on demand
+with 'pw optimize rules apply'.
+
+Examples:
+  pw optimize rules add obs64 --priority high
+  pw optimize rules add OneDrive --priority below-normal --cores 2
+  pw optimize rules add ffmpeg --cpus 4-7
+  pw optimize rules watch`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeRulesList,
+}
+
+var optimizeRulesAddCmd = &cobra.Command{
+	Use:   "add <process>",
+	Short: "Add or replace the rule for a process",
+	Args:  cobra.ExactArgs(1),
+	Run:   runOptimizeRulesAdd,
+}
+
+var optimizeRulesRemoveCmd = &cobra.Command{
+	Use:   "remove <process>",
+	Short: "Remove the rule for a process",
+	Args:  cobra.ExactArgs(1),
+	Run:   runOptimizeRulesRemove,
+}
+
+var optimizeRulesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply rules to all matching running processes now",
+	Args:  cobra.NoArgs,
+	Run:   runOptimizeRulesApply,
+}
+
+var optimizeRulesWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Apply rules to matching processes as they start",
+	Args:  cobra.NoArgs,
+	Run:   runOptimizeRulesWatch,
+}
+
+func init() {
+	optimizeRulesAddCmd.Flags().String("priority", "", "Priority class: "+strings.Join(optimize.PriorityNames, ", "))
+	optimizeRulesAddCmd.Flags().Int("cores", 0, "Limit the process to this many logical processors")
+	optimizeRulesAddCmd.Flags().String("cpus", "", "Pin the process to these processors (e.g. 0,2,4-7)")
+
+	optimizeRulesWatchCmd.Flags().Int("interval", 2, "Polling interval in seconds")
+
+	optimizeRulesCmd.AddCommand(optimizeRulesAddCmd)
+	optimizeRulesCmd.AddCommand(optimizeRulesRemoveCmd)
+	optimizeRulesCmd.AddCommand(optimizeRulesApplyCmd)
+	optimizeRulesCmd.AddCommand(optimizeRulesWatchCmd)
+	optimizeCmd.AddCommand(optimizeRulesCmd)
+}
+
+// processRulesPath returns the rule file location, exiting on config errors.
+func processRulesPath() string {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return filepath.Join(cfg.ConfigDir, optimize.ProcessRulesFileName)
+}
+
+// mustLoadProcessRules loads the rule file, exiting on parse errors.
+func mustLoadProcessRules(path string) []optimize.ProcessRule {
+	rules, err := optimize.LoadProcessRules(path)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	return rules
+}
+
+// loadProcessRuleEnforcer returns an enforcer for the saved rules, or nil
+// when there are none or they cannot be read.
+func loadProcessRuleEnforcer() *optimize.RuleEnforcer {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	rules, err := optimize.LoadProcessRules(filepath.Join(cfg.ConfigDir, optimize.ProcessRulesFileName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	enforcer := optimize.NewRuleEnforcer(rules)
+	enforcer.DryRun = dryRun
+	return enforcer
+}
+
+func runOptimizeRulesList(cmd *cobra.Command, args []string) {
+	path := processRulesPath()
+	rules := mustLoadProcessRules(path)
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Process Rules", 55))
+	if len(rules) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No rules. Add one with 'pw optimize rules add <process> --priority high'."))
+		fmt.Println()
+		return
+	}
+	for _, r := range rules {
+		fmt.Printf("  %s %-28s %s\n", ui.IconBullet, r.Process, ui.MutedStyle().Render(r.Summary()))
+	}
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render("  Stored in " + path))
+	fmt.Println()
+}
+
+func runOptimizeRulesAdd(cmd *cobra.Command, args []string) {
+	priority, _ := cmd.Flags().GetString("priority")
+	cores, _ := cmd.Flags().GetInt("cores")
+	cpuList, _ := cmd.Flags().GetString("cpus")
+
+	rule := optimize.ProcessRule{
+		Process:  args[0],
+		Priority: strings.ToLower(priority),
+		Cores:    cores,
+	}
+	if cpuList != "" {
+		cpus, err := parseCPUList(cpuList)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Invalid --cpus: %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		rule.CPUs = cpus
+	}
+	if err := rule.Validate(); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	path := processRulesPath()
+	rules := mustLoadProcessRules(path)
+
+	replaced := false
+	for i, r := range rules {
+		if strings.EqualFold(r.Process, rule.Process) {
+			rules[i] = rule
+			replaced = true
+		}
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+
+	if err := optimize.SaveProcessRules(path, rules); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to save rules: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s %s: %s", ui.IconSuccess, rule.Process, rule.Summary())))
+}
+
+func runOptimizeRulesRemove(cmd *cobra.Command, args []string) {
+	path := processRulesPath()
+	rules := mustLoadProcessRules(path)
+
+	kept := rules[:0]
+	for _, r := range rules {
+		if !strings.EqualFold(r.Process, args[0]) {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == len(rules) {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s No rule for %s", ui.IconWarning, args[0])))
+		return
+	}
+
+	if err := optimize.SaveProcessRules(path, kept); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to save rules: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println(ui.SuccessStyle().Render(
+		fmt.Sprintf("  %s Removed rule for %s", ui.IconSuccess, args[0])))
+}
+
+func runOptimizeRulesApply(cmd *cobra.Command, args []string) {
+	rules := mustLoadProcessRules(processRulesPath())
+	if len(rules) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No rules to apply."))
+		return
+	}
+
+	enforcer := optimize.NewRuleEnforcer(rules)
+	enforcer.DryRun = dryRun
+	applied := enforcer.Enforce()
+	if len(applied) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No running process matches a rule."))
+		return
+	}
+	for _, a := range applied {
+		printRuleApplication(a)
+	}
+}
+
+func runOptimizeRulesWatch(cmd *cobra.Command, args []string) {
+	intervalSecs, _ := cmd.Flags().GetInt("interval")
+	if intervalSecs < 1 {
+		intervalSecs = 1
+	}
+
+	rules := mustLoadProcessRules(processRulesPath())
+	if len(rules) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No rules to enforce."))
+		return
+	}
+	enforcer := optimize.NewRuleEnforcer(rules)
+	enforcer.DryRun = dryRun
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("  Watching for %d rule(s) every %ds. Press Ctrl+C to stop.\n", len(rules), intervalSecs)
+	ticker := time.NewTicker(time.Duration(intervalSecs) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		for _, a := range enforcer.Enforce() {
+			printRuleApplication(a)
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println("  Stopped.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// printRuleApplication reports one applied rule.
+func printRuleApplication(a optimize.RuleApplication) {
+	stamp := time.Now().Format("15:04:05")
+	if a.Err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %s %s (%d): %v", ui.IconWarning, stamp, a.Process, a.PID, a.Err)))
+		return
+	}
+	summary := a.Rule.Summary()
+	if dryRun {
+		summary = "[DRY RUN] would set " + summary
+	}
+	fmt.Printf("  %s %s %s (%d) %s %s\n",
+		ui.SuccessStyle().Render(ui.IconSuccess), stamp, a.Process, a.PID,
+		ui.IconArrow, ui.MutedStyle().Render(summary))
+}
+
+// parseCPUList parses processor lists like "0,2,4-7". An affinity mask
+// has 64 bits, so processors above 63 are rejected.
+func parseCPUList(s string) ([]int, error) {
+	var cpus []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, fmt.Errorf("bad processor %q", part)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(hi); err != nil || end < start {
+				return nil, fmt.Errorf("bad range %q", part)
+			}
+		}
+		if end >= 64 {
+			return nil, fmt.Errorf("processor %d out of range (0-63)", end)
+		}
+		for c := start; c <= end; c++ {
+			cpus = append(cpus, c)
+		}
+	}
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("no processors given")
+	}
+	return cpus, nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseCPUList(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"0", []int{0}, false},
+		{"0,2,4-7", []int{0, 2, 4, 5, 6, 7}, false},
+		{" 1 , 3-3 ,", []int{1, 3}, false},
+		{"62-63", []int{62, 63}, false},
+		{"", nil, true},
+		{",", nil, true},
+		{"x", nil, true},
+		{"-1", nil, true},
+		{"5-2", nil, true},
+		{"4-", nil, true},
+		{"64", nil, true},
+		{"60-70", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCPUList(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseCPUList(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseCPUList(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
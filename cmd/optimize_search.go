@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Rebuild and tune the Windows Search index",
+	Long: `Show the Windows Search index, rebuild it from scratch, keep folders out
+of it, or turn indexing off for secondary drives.
+
+Exclusions are written as the "Prevent indexing certain paths" policy and
+take effect when Windows Search restarts, which is done for you. Turning a
+drive off also excludes it, so what was already indexed is dropped. The
+system drive cannot be turned off.
+
+Examples:
+  pw optimize search                              Show index status
+  pw optimize search --rebuild                    Delete and rebuild the index
+  pw optimize search --exclude D:\Builds --exclude C:\src\node_modules
+  pw optimize search --include D:\Builds          Index a folder again
+  pw optimize search --disable-drive E:           Stop indexing drive E:`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeSearch,
+}
+
+func init() {
+	optimizeSearchCmd.Flags().Bool("rebuild", false, "Delete the index and rebuild it from scratch")
+	optimizeSearchCmd.Flags().StringSlice("exclude", nil, "Keep a folder out of the index (repeatable)")
+	optimizeSearchCmd.Flags().StringSlice("include", nil, "Remove a folder exclusion (repeatable)")
+	optimizeSearchCmd.Flags().StringSlice("disable-drive", nil, "Turn off indexing on a secondary drive (e.g. D:, repeatable)")
+	optimizeSearchCmd.Flags().StringSlice("enable-drive", nil, "Turn indexing back on for a drive (repeatable)")
+	optimizeCmd.AddCommand(optimizeSearchCmd)
+}
+
+func runOptimizeSearch(cmd *cobra.Command, args []string) {
+	rebuild, _ := cmd.Flags().GetBool("rebuild")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	include, _ := cmd.Flags().GetStringSlice("include")
+	disable, _ := cmd.Flags().GetStringSlice("disable-drive")
+	enable, _ := cmd.Flags().GetStringSlice("enable-drive")
+
+	if !rebuild && len(exclude)+len(include)+len(disable)+len(enable) == 0 {
+		showSearchStatus(cmd)
+		return
+	}
+
+	exclude = absFolders(cmd, exclude)
+	include = absFolders(cmd, include)
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Search Index", 50))
+	fmt.Println()
+	requireAdminOrExit("change the search index")
+
+	var results []optimizeResult
+	if len(include) > 0 {
+		results = append(results, runOptimizeTask("Index again: "+strings.Join(include, ", "),
+			func() error { return optimize.IncludeInSearch(include) },
+			optimize.SearchExclusionChanges(include, false)...))
+	}
+	if len(exclude) > 0 {
+		results = append(results, runOptimizeTask("Exclude: "+strings.Join(exclude, ", "),
+			func() error { return optimize.ExcludeFromSearch(exclude) },
+			optimize.SearchExclusionChanges(exclude, true)...))
+	}
+	for _, d := range enable {
+		d := d // capture for closure
+		results = append(results, runOptimizeTask("Turn on indexing for "+strings.ToUpper(d),
+			func() error { return optimize.SetDriveIndexing(d, true) },
+			optimize.DriveIndexingChanges(d, true)...))
+	}
+	for _, d := range disable {
+		d := d // capture for closure
+		results = append(results, runOptimizeTask("Turn off indexing for "+strings.ToUpper(d),
+			func() error { return optimize.SetDriveIndexing(d, false) },
+			optimize.DriveIndexingChanges(d, false)...))
+	}
+
+	// A rebuild restarts the indexer anyway; otherwise restart it once so
+	// it rereads the exclusions.
+	switch {
+	case rebuild:
+		results = append(results, runSearchRebuild())
+	case len(results) > 0:
+		results = append(results, runOptimizeTask("Restart Windows Search", optimize.RestartSearchService,
+			optimize.ServiceChange("WSearch", "restarted with its dependents")))
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	if rebuild && !dryRun {
+		fmt.Println(ui.MutedStyle().Render(
+			"  Windows Search is re-indexing in the background; results fill in over the next hours."))
+		fmt.Println()
+	}
+}
+
+// runSearchRebuild resets the index, showing each step on the spinner.
+func runSearchRebuild() optimizeResult {
+	const name = "Rebuild search index"
+	if dryRun {
+		return runOptimizeTask(name, nil, optimize.ResetSearchIndexChanges()...)
+	}
+
+	spin := ui.NewInlineSpinner()
+	spin.Start(name + "...")
+	err := journalOptimizeTask(name, func() error {
+		return optimize.ResetSearchIndex(func(step string) {
+			spin.UpdateMessage(fmt.Sprintf("%s: %s...", name, step))
+		})
+	})
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("%s: %s", name, err))
+		return optimizeResult{Name: name, Success: false, Error: err}
+	}
+	spin.Stop(name)
+	return optimizeResult{Name: name, Success: true}
+}
+
+// absFolders makes folder arguments absolute, failing on ones that are not
+// local paths.
+func absFolders(cmd *cobra.Command, folders []string) []string {
+	out := make([]string, 0, len(folders))
+	for _, f := range folders {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			optimizeFail(cmd, fmt.Errorf("invalid folder %q: %w", f, err))
+		}
+		out = append(out, abs)
+	}
+	return out
+}
+
+// showSearchStatus prints the indexer state, the index size, exclusions
+// and per-drive indexing.
+func showSearchStatus(cmd *cobra.Command) {
+	st, err := optimize.GetSearchIndexStatus()
+	if err != nil {
+		optimizeFail(cmd, err)
+	}
+	if jsonOutput {
+		output.JSON(st)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Search Index", 50))
+	fmt.Println()
+	fmt.Printf("  %-18s %s\n", "Windows Search", st.Service)
+	if st.DataDirectory != "" {
+		fmt.Printf("  %-18s %s\n", "Index location", st.DataDirectory)
+		fmt.Printf("  %-18s %s\n", "Index size", core.FormatSize(st.IndexSize))
+	}
+	fmt.Println()
+
+	fmt.Println(ui.BoldStyle().Render("  Drives"))
+	for _, d := range st.Drives {
+		state := ui.SuccessStyle().Render("indexed")
+		if !d.Indexed {
+			state = ui.MutedStyle().Render("not indexed")
+		}
+		note := ""
+		if d.System {
+			note = ui.MutedStyle().Render(" (system)")
+		}
+		fmt.Printf("    %s %-4s %s%s\n", ui.IconBullet, d.Drive, state, note)
+	}
+	fmt.Println()
+
+	fmt.Println(ui.BoldStyle().Render("  Excluded folders"))
+	if len(st.Excluded) == 0 {
+		fmt.Println(ui.MutedStyle().Render("    None."))
+	}
+	for _, f := range st.Excluded {
+		fmt.Printf("    %s %s\n", ui.IconBullet, f)
+	}
+	fmt.Println()
+	fmt.Println(ui.MutedStyle().Render("  Rebuild with: pw optimize search --rebuild"))
+	fmt.Println()
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var optimizeWinSxSCmd = &cobra.Command{
+	Use:   "winsxs",
+	Short: "Analyze or clean the Windows Update component store",
+	Long: `Show how much of the component store (C:\Windows\WinSxS) is superseded
+updates and temporary data, as reported by DISM /AnalyzeComponentStore.
+
+--clean runs DISM /StartComponentCleanup to remove it. --reset-base also
+removes the older versions kept for uninstalling updates: it frees more
+space, but installed updates can no longer be uninstalled.
+
+Requires administrator privileges. The analysis takes a minute or two; a
+cleanup can take much longer.
+
+Examples:
+  pw optimize winsxs                  Show the reclaimable size
+  pw optimize winsxs --clean          Remove superseded components
+  pw optimize winsxs --reset-base     Also make installed updates permanent`,
+	Args: cobra.NoArgs,
+	Run:  runOptimizeWinSxS,
+}
+
+func init() {
+	optimizeWinSxSCmd.Flags().Bool("clean", false, "Run DISM /StartComponentCleanup")
+	optimizeWinSxSCmd.Flags().Bool("reset-base", false, "Clean with /ResetBase (installed updates can no longer be uninstalled)")
+
+	optimizeCmd.AddCommand(optimizeWinSxSCmd)
+}
+
+func runOptimizeWinSxS(cmd *cobra.Command, args []string) {
+	resetBase, _ := cmd.Flags().GetBool("reset-base")
+	cleanStore, _ := cmd.Flags().GetBool("clean")
+	cleanStore = cleanStore || resetBase
+
+	if jsonOutput {
+		store, err := optimize.AnalyzeComponentStore()
+		if err != nil {
+			output.Fail(cmd.CommandPath(), err)
+		}
+		output.JSON(store)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Component Store", 50))
+	fmt.Println()
+
+	spin := ui.NewInlineSpinner()
+	spin.Start("Analyzing component store (DISM)...")
+	store, err := optimize.AnalyzeComponentStore()
+	if err != nil {
+		spin.StopWithError(err.Error())
+		os.Exit(1)
+	}
+	spin.Stop("Analysis complete")
+	fmt.Println()
+
+	printComponentStore(store)
+
+	if !cleanStore {
+		if store.CleanupRecommended {
+			fmt.Println(ui.MutedStyle().Render("  Run 'pw optimize winsxs --clean' to reclaim this space."))
+			fmt.Println()
+		}
+		return
+	}
+
+	name := "Component store cleanup"
+	if resetBase {
+		name += " with /ResetBase"
+	}
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  [DRY RUN] Would run %s, freeing up to about %s", name, core.FormatSize(store.Reclaimable()))))
+		fmt.Println()
+		return
+	}
+
+	if resetBase {
+		confirmed, confirmErr := ui.DangerConfirm(
+			"Clean with /ResetBase? Installed updates can no longer be uninstalled afterwards.")
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			fmt.Println()
+			return
+		}
+	}
+
+	spin = ui.NewInlineSpinner()
+	spin.Start(name + "...")
+	err = optimize.CleanupComponentStore(resetBase, func(percent int) {
+		spin.UpdateMessage(fmt.Sprintf("%s... %d%%", name, percent))
+	})
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("%s: %s", name, err))
+		fmt.Println()
+		os.Exit(1)
+	}
+	spin.Stop(name)
+	fmt.Println(ui.MutedStyle().Render("  Run 'pw optimize winsxs' again to see the new size."))
+	fmt.Println()
+}
+
+// printComponentStore shows the sizes from a component store analysis.
+func printComponentStore(store optimize.ComponentStore) {
+	row := func(label string, size int64) {
+		fmt.Printf("  %-32s %s\n", label, core.FormatSize(size))
+	}
+	row("Reported size (Explorer):", store.ReportedSize)
+	row("Actual size:", store.ActualSize)
+	row("  Shared with Windows:", store.SharedWithWindows)
+	row("  Backups and disabled features:", store.BackupsAndDisabled)
+	row("  Cache and temporary data:", store.CacheAndTemp)
+	if store.LastCleanup != "" {
+		fmt.Printf("  %-32s %s\n", "Last cleanup:", store.LastCleanup)
+	}
+	fmt.Println()
+
+	estimate := fmt.Sprintf("  Estimated reclaimable: %s (%d reclaimable packages)",
+		core.FormatSize(store.Reclaimable()), store.ReclaimablePackages)
+	if store.CleanupRecommended {
+		fmt.Println(ui.WarningStyle().Render(estimate + " — cleanup recommended"))
+	} else {
+		fmt.Println(ui.MutedStyle().Render(estimate + " — cleanup not needed"))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var privacyCmd = &cobra.Command{
+	Use:   "privacy [setting...]",
+	Short: "Audit and turn off telemetry settings",
+	Long: `Check telemetry-related settings and optionally turn them off:
+
+  diagtrack             DiagTrack and dmwappushservice services
+  telemetry-policy      AllowTelemetry policy at its lowest level
+  ceip                  CEIP and Application Experience scheduled tasks
+  advertising-id        Advertising ID
+  activity-history      Activity history and its upload
+  tailored-experiences  Tips and offers based on diagnostic data
+  feedback              Feedback requests
+
+Without --apply the current state of each setting is shown. --apply picks
+the settings still on in a checklist, or applies the settings named as
+arguments. A snapshot is saved first, so 'pw optimize restore <snapshot>'
+reverts the changes.
+
+Examples:
+  pw privacy
+  pw privacy --apply
+  pw privacy --apply advertising-id activity-history
+  pw privacy --apply --yes --dry-run`,
+	Run: runPrivacy,
+}
+
+func init() {
+	privacyCmd.Flags().Bool("apply", false, "Turn off the selected settings")
+	privacyCmd.Flags().Bool("yes", false, "With --apply, turn off every setting that is still on without asking")
+}
+
+func runPrivacy(cmd *cobra.Command, args []string) {
+	apply, _ := cmd.Flags().GetBool("apply")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	var only map[string]bool
+	if len(args) > 0 {
+		only = make(map[string]bool, len(args))
+		for _, a := range args {
+			s, err := optimize.FindPrivacySetting(a)
+			if err != nil {
+				if jsonOutput {
+					output.Fail(cmd.CommandPath(), err)
+				}
+				fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+				os.Exit(1)
+			}
+			only[s.ID] = true
+		}
+	}
+
+	states := optimize.AuditPrivacy()
+	if jsonOutput && !apply {
+		output.JSON(states)
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Privacy", 50))
+	fmt.Println()
+	printPrivacyAudit(states)
+	if !apply {
+		fmt.Println(ui.MutedStyle().Render("  Turn settings off with: pw privacy --apply"))
+		fmt.Println()
+		return
+	}
+
+	var pending []optimize.PrivacyState
+	for _, st := range states {
+		if st.Present && !st.Hardened && (only == nil || only[st.ID]) {
+			pending = append(pending, st)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Nothing to change.", ui.IconSuccess)))
+		fmt.Println()
+		return
+	}
+
+	if only == nil && !skipConfirm && !dryRun {
+		pending = pickPrivacySettings(pending)
+		if len(pending) == 0 {
+			fmt.Println(ui.MutedStyle().Render("  Nothing selected."))
+			fmt.Println()
+			return
+		}
+	}
+
+	if dryRun {
+		for _, st := range pending {
+			runOptimizeTask("Turn off "+st.Name, nil, st.Changes...)
+		}
+		fmt.Println()
+		return
+	}
+	requireAdminOrExit("change privacy settings")
+
+	// The snapshot is the revert file for this run.
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	snapPath, err := saveOptimizeSnapshot(cfg)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Not changing settings without a snapshot to revert to: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	var results []optimizeResult
+	for _, st := range pending {
+		setting, err := optimize.FindPrivacySetting(st.ID)
+		if err != nil {
+			continue
+		}
+		results = append(results, runOptimizeTask("Turn off "+st.Name,
+			func() error { return optimize.HardenPrivacySetting(setting) }))
+	}
+	fmt.Println()
+
+	printOptimizeSummary(results)
+	fmt.Println(ui.MutedStyle().Render(
+		fmt.Sprintf("  Revert with: pw optimize restore %s", filepath.Base(snapPath))))
+	fmt.Println()
+}
+
+// printPrivacyAudit shows each setting as off, on (with what is still on),
+// or not present.
+func printPrivacyAudit(states []optimize.PrivacyState) {
+	for _, st := range states {
+		var mark, state string
+		switch {
+		case !st.Present:
+			mark, state = ui.MutedStyle().Render(ui.IconBullet), ui.MutedStyle().Render("not present")
+		case st.Hardened:
+			mark, state = ui.SuccessStyle().Render(ui.IconSuccess), ui.SuccessStyle().Render("off")
+		default:
+			mark, state = ui.WarningStyle().Render(ui.IconWarning), ui.WarningStyle().Render("on")
+		}
+		fmt.Printf("  %s %-30s %s\n", mark, st.Name, state)
+		fmt.Println(ui.MutedStyle().Render(fmt.Sprintf("      %s (%s)", st.Description, st.ID)))
+		for _, p := range st.Pending {
+			fmt.Println(ui.MutedStyle().Render("      " + ui.IconArrow + " " + p))
+		}
+	}
+	fmt.Println()
+}
+
+// pickPrivacySettings lets the user choose which settings to turn off.
+func pickPrivacySettings(pending []optimize.PrivacyState) []optimize.PrivacyState {
+	items := make([]ui.SelectorItem, 0, len(pending))
+	for _, st := range pending {
+		items = append(items, ui.SelectorItem{
+			Label:       st.Name,
+			Description: st.Description,
+			Value:       st.ID,
+			Selected:    true,
+		})
+	}
+	picked, err := ui.RunSelector(items, "Select settings to turn off")
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+	chosen := make(map[string]bool, len(picked))
+	for _, p := range picked {
+		chosen[p.Value] = true
+	}
+	var kept []optimize.PrivacyState
+	for _, st := range pending {
+		if chosen[st.ID] {
+			kept = append(kept, st)
+		}
+	}
+	return kept
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/purge"
 	"github.com/cy-infamous/purewin/internal/ui"
 	"github.com/spf13/cobra"
@@ -27,23 +28,29 @@ Examples:
   pw purge                 Scan current directory for build artifacts
   pw purge D:\Projects     Scan a specific directory
   pw purge --all           Scan all configured project directories
+  pw purge --exclude "D:\Projects\keep\**"
+                           Skip artifacts under a folder for this run
   pw purge --paths         Configure project scan directories`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runPurge,
 }
 
 func init() {
-	purgeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without deleting")
 	purgeCmd.Flags().Bool("all", false, "Scan all configured project directories")
 	purgeCmd.Flags().Bool("paths", false, "Configure project scan directories")
 	purgeCmd.Flags().Int("min-age", 7, "Minimum age in days (recent projects are skipped)")
 	purgeCmd.Flags().String("min-size", "", "Minimum artifact size to show (e.g., 50MB)")
+	addNiceFlag(purgeCmd.Flags())
+	addExcludeFlag(purgeCmd.Flags())
 }
 
 func runPurge(cmd *cobra.Command, args []string) {
 	// Load config
 	cfg, err := config.Load()
 	if err != nil {
+		if jsonOutput {
+			output.Fail("purge", err)
+		}
 		fmt.Printf("%s Failed to load config: %v\n", ui.ErrorStyle().Render(ui.IconError), err)
 		os.Exit(1)
 	}
@@ -57,6 +64,8 @@ func runPurge(cmd *cobra.Command, args []string) {
 
 	allFlag, _ := cmd.Flags().GetBool("all")
 
+	defer applyNiceFlag(cmd)()
+
 	// Determine scan paths.
 	var scanPaths []string
 	var scanLabel string
@@ -89,6 +98,17 @@ func runPurge(cmd *cobra.Command, args []string) {
 		scanLabel = cwd
 	}
 
+	// Saved whitelist patterns and --exclude globs protect artifacts.
+	var excluded func(string) bool
+	if wl := applyExcludeFlag(cmd, loadPipelineWhitelist(cfg)); wl != nil {
+		excluded = wl.IsWhitelisted
+	}
+
+	if jsonOutput {
+		runPurgeJSON(scanPaths, excluded)
+		return
+	}
+
 	if len(scanPaths) == 0 {
 		fmt.Println()
 		fmt.Println(ui.MutedStyle().Render("  No scan paths configured. Run 'pw purge --paths' to configure."))
@@ -109,7 +129,7 @@ func runPurge(cmd *cobra.Command, args []string) {
 	spinner.Start("Scanning for project artifacts...")
 
 	// Scan for artifacts
-	artifacts, err := purge.ScanProjects(scanPaths)
+	artifacts, err := purge.ScanProjectsExcluding(scanPaths, excluded)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Scan failed: %v", err))
 		os.Exit(1)
@@ -200,6 +220,38 @@ func runPurge(cmd *cobra.Command, args []string) {
 	}
 }
 
+// purgeReport is the --json form of `pw purge`. It lists the artifacts
+// found; nothing is deleted in JSON mode.
+type purgeReport struct {
+	ScanPaths  []string                `json:"scan_paths"`
+	Artifacts  []purge.ProjectArtifact `json:"artifacts"`
+	TotalSize  int64                   `json:"total_size"`
+	TotalCount int                     `json:"total_count"`
+}
+
+// runPurgeJSON scans scanPaths and writes a purgeReport to stdout.
+func runPurgeJSON(scanPaths []string, excluded func(string) bool) {
+	if len(scanPaths) == 0 {
+		output.Fail("purge", fmt.Errorf("no scan paths configured"))
+	}
+
+	artifacts, err := purge.ScanProjectsExcluding(scanPaths, excluded)
+	if err != nil {
+		output.Fail("purge", err)
+	}
+
+	report := purgeReport{
+		ScanPaths:  scanPaths,
+		Artifacts:  make([]purge.ProjectArtifact, 0, len(artifacts)),
+		TotalCount: len(artifacts),
+	}
+	report.Artifacts = append(report.Artifacts, artifacts...)
+	for _, a := range artifacts {
+		report.TotalSize += a.Size
+	}
+	output.JSON(report)
+}
+
 // getScanPaths returns the list of paths to scan for projects.
 func getScanPaths(cfg *config.Config) []string {
 	// Try to load custom paths first
@@ -284,6 +336,7 @@ func artifactsToSelectorItems(artifacts []purge.ProjectArtifact) []ui.SelectorIt
 				Description: fmt.Sprintf("%s • %s old", artifact.ArtifactPath, ageStr),
 				Value:       artifact.ArtifactPath,
 				Size:        core.FormatSize(artifact.Size),
+				Bytes:       artifact.Size,
 				Selected:    !artifact.IsRecent, // Don't select recent artifacts by default
 				Disabled:    false,
 				Category:    artifact.ArtifactType,
@@ -47,6 +47,12 @@ func runRemove(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println()
 
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render("  [DRY RUN] Nothing was removed."))
+		fmt.Println()
+		return
+	}
+
 	// Danger confirmation
 	confirmed, err := ui.DangerConfirm("This will permanently delete PureWin and all its data")
 	if err != nil {
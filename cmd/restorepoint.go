@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// addRestorePointFlag registers --no-restore-point on a flag set.
+func addRestorePointFlag(fs *pflag.FlagSet) {
+	fs.Bool("no-restore-point", false, "Skip the System Restore point created before risky changes")
+}
+
+// ensureRestorePoint creates a restore point described by what before a
+// risky operation and reports the outcome. It returns false when the
+// operation should not go ahead: creation failed and the user declined to
+// continue without one. Unattended runs continue with a warning. Nothing
+// is created with --no-restore-point, in dry runs or without admin rights.
+// With --json, progress and errors go to stderr and a failure never
+// prompts.
+func ensureRestorePoint(cmd *cobra.Command, what string, unattended bool) bool {
+	if skip, _ := cmd.Flags().GetBool("no-restore-point"); skip || dryRun {
+		return true
+	}
+	if jsonOutput {
+		return ensureRestorePointQuiet(what)
+	}
+	if !core.IsElevated() {
+		fmt.Println(ui.MutedStyle().Render(
+			"  Skipping restore point (requires administrator privileges)."))
+		return true
+	}
+
+	spin := ui.NewInlineSpinner()
+	spin.Start("Creating restore point...")
+	err := core.CreateRestorePoint("PureWin: " + what)
+	switch {
+	case err == nil:
+		spin.Stop("Restore point created")
+		return true
+	case errors.Is(err, core.ErrRestorePointRecent):
+		spin.Stop("Restore point skipped: Windows already created one in the last 24 hours")
+		return true
+	}
+
+	spin.StopWithError(err.Error())
+	fmt.Println(ui.MutedStyle().Render(
+		"  → Turn on System Protection for the system drive, or pass --no-restore-point."))
+	if unattended {
+		return true
+	}
+	confirmed, confirmErr := ui.Confirm("  Continue without a restore point?")
+	return confirmErr == nil && confirmed
+}
+
+// ensureRestorePointQuiet creates the restore point of a --json run,
+// reporting on stderr so stdout stays valid JSON. A failure is a warning.
+func ensureRestorePointQuiet(what string) bool {
+	if !core.IsElevated() {
+		fmt.Fprintln(os.Stderr, "Skipping restore point (requires administrator privileges).")
+		return true
+	}
+	fmt.Fprintln(os.Stderr, "Creating restore point...")
+	err := core.CreateRestorePoint("PureWin: " + what)
+	switch {
+	case err == nil:
+		fmt.Fprintln(os.Stderr, "Restore point created.")
+	case errors.Is(err, core.ErrRestorePointRecent):
+		fmt.Fprintln(os.Stderr, "Restore point skipped: Windows already created one in the last 24 hours.")
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: cannot create restore point: %v\n", err)
+	}
+	return true
+}
@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/internal/shell"
 	"github.com/cy-infamous/purewin/internal/ui"
@@ -14,9 +15,10 @@ import (
 
 var (
 	// Global flags
-	debug    bool
-	dryRun   bool
-	runAdmin bool
+	debug      bool
+	dryRun     bool
+	jsonOutput bool
+	runAdmin   bool
 
 	// Version info populated from main
 	appVersion = "dev"
@@ -53,10 +55,17 @@ func init() {
 	}
 
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Show detailed operation logs")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Preview destructive actions without changing anything")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Write machine-readable JSON to stdout instead of styled output")
 	rootCmd.PersistentFlags().BoolVar(&runAdmin, "admin", false, "Re-launch PureWin with administrator privileges (UAC)")
 
 	// PersistentPreRun: if --admin is set, re-launch elevated and exit.
+	// Otherwise upgrade any data left by an older version first.
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		applyConfigDefaults(cmd)
+		if !runAdmin || core.IsElevated() {
+			runPendingMigrations()
+		}
 		if !runAdmin {
 			return
 		}
@@ -81,16 +90,39 @@ func init() {
 	rootCmd.AddCommand(cleanCmd)
 	rootCmd.AddCommand(uninstallCmd)
 	rootCmd.AddCommand(optimizeCmd)
+	rootCmd.AddCommand(privacyCmd)
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(tuiCmd)
 	rootCmd.AddCommand(purgeCmd)
+	rootCmd.AddCommand(dupesCmd)
+	rootCmd.AddCommand(maintainCmd)
+	rootCmd.AddCommand(scheduleCmd)
 	rootCmd.AddCommand(installerCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(removeCmd)
+	rootCmd.AddCommand(filterCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// applyConfigDefaults applies persistent config settings to global flags
+// that were not given on the command line. The dry-run and JSON defaults
+// are reset on every call because the interactive shell executes many
+// commands in one process.
+func applyConfigDefaults(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("json") {
+		jsonOutput = false
+	}
+	if cmd.Flags().Changed("dry-run") {
+		return
+	}
+	dryRun = false
+	if cfg, err := config.Load(); err == nil && cfg.DryRunMode {
+		dryRun = true
+	}
+}
+
 // runInteractiveShell launches the persistent interactive shell with
 // slash-command autocomplete. The shell runs in a loop: each iteration
 // runs a bubbletea program; when the user invokes a command, the shell
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run cleanups on a schedule",
+	Long: `Register Windows Task Scheduler tasks that run 'pw clean' unattended.
+
+Each task cleans the chosen categories with 'pw clean --yes', so nothing is
+prompted and Windows.old is never removed. Only low-risk targets are
+cleaned unless --max-risk raises the ceiling. AI feature data (--ai) cannot be
+scheduled because it always requires a typed confirmation.
+
+Examples:
+  pw schedule add nightly --user --browser --every daily --at 02:30
+  pw schedule add monthly-dev --dev --every monthly
+  pw schedule list
+  pw schedule remove nightly`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runScheduleList(cmd, args)
+	},
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Schedule a recurring cleanup",
+	Args:  cobra.ExactArgs(1),
+	Run:   runScheduleAdd,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled PureWin tasks",
+	Args:  cobra.NoArgs,
+	Run:   runScheduleList,
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a scheduled cleanup",
+	Args:  cobra.ExactArgs(1),
+	Run:   runScheduleRemove,
+}
+
+func init() {
+	scheduleAddCmd.Flags().Bool("all", false, "Clean all categories")
+	scheduleAddCmd.Flags().Bool("user", false, "Clean user caches")
+	scheduleAddCmd.Flags().Bool("system", false, "Clean system caches (task runs elevated)")
+	scheduleAddCmd.Flags().Bool("browser", false, "Clean browser caches")
+	scheduleAddCmd.Flags().Bool("dev", false, "Clean developer tool caches")
+	scheduleAddCmd.Flags().Bool("apps", false, "Clean desktop app caches")
+	scheduleAddCmd.Flags().String("max-risk", "", "Highest target risk to clean: low, medium or high (default low)")
+	scheduleAddCmd.Flags().String("every", "weekly", "Frequency: daily, weekly or monthly")
+	scheduleAddCmd.Flags().String("at", "03:00", "Local start time (HH:MM)")
+
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+}
+
+// cleanTaskPrefix keeps scheduled cleanups apart from other PureWin tasks.
+const cleanTaskPrefix = "Clean-"
+
+// scheduleNamePattern restricts task names to characters safe in a task path.
+var scheduleNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// cleanTaskName returns the Task Scheduler name for a cleanup schedule,
+// accepting names with or without the prefix.
+func cleanTaskName(name string) string {
+	if strings.HasPrefix(name, cleanTaskPrefix) {
+		return name
+	}
+	return cleanTaskPrefix + name
+}
+
+func runScheduleAdd(cmd *cobra.Command, args []string) {
+	name := args[0]
+	if !scheduleNamePattern.MatchString(name) {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s Invalid name %q (use letters, digits, - and _)", ui.IconError, name)))
+		os.Exit(1)
+	}
+
+	cleanArgs := []string{"clean"}
+	system := false
+	for _, flag := range []string{"all", "user", "system", "browser", "dev", "apps"} {
+		if set, _ := cmd.Flags().GetBool(flag); set {
+			cleanArgs = append(cleanArgs, "--"+flag)
+			system = system || flag == "all" || flag == "system"
+		}
+	}
+	if len(cleanArgs) == 1 {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s Choose at least one category: --all, --user, --system, --browser, --dev or --apps", ui.IconError)))
+		os.Exit(1)
+	}
+	cleanArgs = append(cleanArgs, "--yes")
+	if s, _ := cmd.Flags().GetString("max-risk"); s != "" {
+		maxRisk, err := config.ParseRiskLevel(s)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+			os.Exit(1)
+		}
+		cleanArgs = append(cleanArgs, "--max-risk", maxRisk)
+	}
+
+	if system && !core.IsElevated() {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf(
+			"  %s System cleanups run elevated; register them from an elevated terminal (or use --admin).", ui.IconError)))
+		os.Exit(1)
+	}
+
+	every, _ := cmd.Flags().GetString("every")
+	at, _ := cmd.Flags().GetString("at")
+
+	task := core.ScheduledTask{
+		Name:      cleanTaskName(name),
+		Args:      cleanArgs,
+		Frequency: strings.ToLower(every),
+		StartTime: at,
+		Elevated:  system,
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf(
+			"  [DRY RUN] Would schedule %q: pw %s (%s at %s)",
+			task.Name, strings.Join(task.Args, " "), task.Frequency, task.StartTime)))
+		return
+	}
+
+	if err := core.CreateScheduledTask(task); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf(
+		"  %s Scheduled %s: pw %s", ui.IconSuccess, task.Name, strings.Join(task.Args, " "))))
+	fmt.Println(ui.MutedStyle().Render(fmt.Sprintf(
+		"  Runs %s at %s. Results are written to the PureWin log.", task.Frequency, task.StartTime)))
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) {
+	tasks, err := core.ListScheduledTasks()
+	if err != nil {
+		if jsonOutput {
+			output.Fail("schedule", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		output.JSON(append(make([]core.ScheduledTaskStatus, 0, len(tasks)), tasks...))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SectionHeader("Scheduled Tasks", 50))
+	if len(tasks) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No scheduled tasks. Use 'pw schedule add <name> --user'."))
+		fmt.Println()
+		return
+	}
+
+	for _, t := range tasks {
+		fmt.Printf("  %s %s  %s\n", ui.IconBullet, ui.BoldStyle().Render(t.Name),
+			ui.MutedStyle().Render(t.Status))
+		fmt.Printf("      %s\n", ui.MutedStyle().Render(t.Command))
+		fmt.Printf("      Next: %s   Last: %s (result %s)\n", t.NextRun, t.LastRun, t.LastResult)
+	}
+	fmt.Println()
+}
+
+func runScheduleRemove(cmd *cobra.Command, args []string) {
+	name := cleanTaskName(args[0])
+
+	if !core.ScheduledTaskExists(name) {
+		fmt.Println(ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s No scheduled cleanup named %q.", ui.IconWarning, args[0])))
+		os.Exit(1)
+	}
+
+	if dryRun {
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf("  [DRY RUN] Would remove %s", name)))
+		return
+	}
+
+	if err := core.DeleteScheduledTask(name); err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Removed %s.", ui.IconSuccess, name)))
+}
@@ -1,50 +1,359 @@
 package cmd
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/status"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Monitor system health",
-	Long:  "Real-time dashboard with CPU, memory, disk, network, GPU, and battery metrics.",
-	Run:   runStatus,
+	Long: `Real-time dashboard with CPU, memory, disk, network, GPU, and battery metrics.
+
+The Overview shows the uptime and boot time, whether Windows is waiting for
+a restart (after Windows Update, component servicing or pending file
+renames) and how many critical events the System log recorded in the last
+24 hours. Both lower the health score, and the Overview names everything
+that lowers it.
+
+On laptops the Overview shows the battery's charge history, time remaining
+and health: the capacity it holds at a full charge against its design
+capacity, the wear that amounts to, and its cycle count.
+
+Sustained threshold breaches are recorded to alerts.json in the config
+directory, shown as a banner while ongoing and listed on the Alerts tab,
+where they can be acknowledged. The rules default to CPU above 90% for 5
+minutes, memory above 95% for a minute, swap above 80% for a minute and
+any drive below 10% free; alert_rules.json in the config directory
+replaces them:
+
+  [{"metric": "cpu", "threshold": 90, "for": "5m", "notify": true},
+   {"metric": "disk_free", "threshold": 10, "below": true}]
+
+Metrics are cpu, memory, swap, disk, disk_free, gpu_memory and battery.
+Rules with "notify" — or every rule, with --notify — also show a Windows
+notification, in the dashboard as with --daemon or --etw.
+
+The Processes tab lists every process: c, m, d and n sort it by CPU,
+memory, disk I/O or name, / filters it by name and r shows 10, 25 or 50
+rows. Enter opens the selected process: its command line,
+parent, start time, threads and handles, a breakdown of its memory and its
+disk I/O rate, and o shows its file in Explorer. From the list or the
+details, K ends the process (after confirmation; critical system processes
+are refused), P changes its priority class and A picks the CPUs it may run
+on. Processes of other users and services need an elevated prompt.
+
++ and - change the refresh interval while it runs (--interval sets it at
+start, e.g. --interval 500ms) and p pauses it, freezing the sparklines.
+While the terminal is not focused it refreshes at most every 5 seconds.
+
+The Network tab lists every TCP connection and listener and every UDP
+endpoint with its owning process, like a minimal TCPView: s changes the
+order and / filters by process, address, port or state. Per-connection
+throughput is measured when running as administrator.
+
+The Services tab watches key Windows services — by default those pw optimize
+manages — with their state and start type, flagging automatic services that
+are not running. R restarts the selected service and its dependents (as
+administrator). watched_services.json in the config directory, a JSON array
+of service names, replaces the list:
+
+  ["Dnscache", "Spooler", "W32Time"]
+
+The Disk tab breaks I/O down per volume (throughput, IOPS, queue depth and
+busy time), lists the processes doing the most I/O and shows each drive's
+S.M.A.R.T. health: a pass/fail verdict, temperature, reallocated sectors and
+power-on hours. A drive predicting its failure lowers the health score.
+S.M.A.R.T. data needs an elevated prompt and is often missing for NVMe
+drives.
+
+--record FILE appends every sample to FILE as CSV or, for a .jsonl file,
+JSON Lines; --daemon records without the dashboard until Ctrl+C, to
+metrics.csv in the config directory unless --record is given. The
+dashboard seeds its sparklines from the recording, so a recent history
+survives restarts.`,
+	Run: runStatus,
 }
 
 func init() {
 	statusCmd.Flags().Int("refresh", 1, "Refresh interval in seconds")
-	statusCmd.Flags().Bool("json", false, "Output metrics as JSON")
+	statusCmd.Flags().Duration("interval", 0, "Refresh interval, e.g. 500ms or 5s (overrides --refresh)")
+	statusCmd.Flags().Bool("etw", false, "Run headless and publish derived metrics as ETW events")
+	statusCmd.Flags().String("record", "", "Append every sample to a .csv or .jsonl file")
+	statusCmd.Flags().Bool("daemon", false, "Run headless and only record samples")
+	statusCmd.Flags().Bool("notify", false, "Show a Windows notification for every alert")
+	statusCmd.Flags().String("listen", "", "Run headless and serve Prometheus metrics on this address, e.g. :9182")
 }
 
 func runStatus(cmd *cobra.Command, args []string) {
-	jsonMode, _ := cmd.Flags().GetBool("json")
 	refreshSecs, _ := cmd.Flags().GetInt("refresh")
+	interval := time.Duration(refreshSecs) * time.Second
+	if cmd.Flags().Changed("interval") {
+		interval, _ = cmd.Flags().GetDuration("interval")
+		if interval < status.MinRefreshInterval {
+			fmt.Fprintf(os.Stderr, "Error: --interval must be at least %s\n", status.MinRefreshInterval)
+			os.Exit(1)
+		}
+	}
+	etwMode, _ := cmd.Flags().GetBool("etw")
+	daemon, _ := cmd.Flags().GetBool("daemon")
+	recordPath, _ := cmd.Flags().GetString("record")
+	notifyAll, _ := cmd.Flags().GetBool("notify")
+	listen, _ := cmd.Flags().GetString("listen")
 
-	if jsonMode {
-		// Single-shot: collect once, print JSON, exit.
-		metrics, err := status.CollectMetrics(nil, 0)
+	if daemon && recordPath == "" {
+		cfg, err := config.Load()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		data, _ := json.MarshalIndent(metrics, "", "  ")
-		fmt.Println(string(data))
+		recordPath = filepath.Join(cfg.ConfigDir, status.RecordFileName)
+	}
+	var recorder *status.Recorder
+	if recordPath != "" && !jsonOutput {
+		var err error
+		if recorder, err = status.OpenRecorder(recordPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer recorder.Close()
+	}
+
+	if daemon || listen != "" {
+		runStatusDaemon(interval, recorder, listen, notifyAll)
+		return
+	}
+	if etwMode {
+		runStatusETW(interval, recorder, notifyAll)
+		return
+	}
+
+	if jsonOutput {
+		// Single-shot: collect once, print JSON, exit.
+		metrics, err := status.CollectMetrics(nil, 0)
+		if err != nil {
+			output.Fail("status", err)
+		}
+		output.JSON(metrics)
 		return
 	}
 
 	// Interactive dashboard.
-	interval := time.Duration(refreshSecs) * time.Second
 	model := status.NewStatusModel(interval)
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	model.Alerts = loadAlertTracker(notifyAll)
+	model.WatchedServices = loadWatchedServices()
+	model.Recorder = recorder
+	model.SeedHistory(loadRecordedHistory(recordPath))
+	if enforcer := loadProcessRuleEnforcer(); enforcer != nil {
+		model.OnCollect = func() { enforcer.Enforce() }
+	}
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithReportFocus())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// reclaimableRefresh is how often the ETW exporter re-estimates reclaimable
+// space. The estimate walks cache directories, so it runs far less often
+// than metric collection.
+const reclaimableRefresh = 10 * time.Minute
+
+// runStatusETW collects metrics on the given interval and publishes the
+// derived values to ETW until interrupted, also recording them when
+// recorder is set.
+func runStatusETW(interval time.Duration, recorder *status.Recorder, notifyAll bool) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	publisher, err := status.NewETWPublisher()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer publisher.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Publishing metrics to ETW provider %s %s every %s\n",
+		status.ETWProviderName, status.ETWProviderGUID, interval)
+	fmt.Println("Press Ctrl+C to stop.")
+
+	// Reclaimable space is estimated in the background.
+	var reclaimable atomic.Int64
+	go func() {
+		var wl *whitelist.Whitelist
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			wl, _ = whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+		}
+		ticker := time.NewTicker(reclaimableRefresh)
+		defer ticker.Stop()
+		for {
+			reclaimable.Store(status.EstimateReclaimable(wl))
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	alerts := loadAlertTracker(notifyAll)
+	enforcer := loadProcessRuleEnforcer()
+
+	var prevNet *status.NetworkMetrics
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		metrics, collectErr := status.CollectMetrics(prevNet, interval)
+		if collectErr == nil {
+			prevNet = &metrics.Network
+			alerts.Observe(metrics)
+			enforcer.Enforce()
+			if recErr := recorder.Record(metrics); recErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", recErr)
+			}
+			if pubErr := publisher.Publish(status.Derive(metrics, reclaimable.Load())); pubErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", pubErr)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Stopped.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runStatusDaemon collects metrics on the given interval until interrupted,
+// recording them when recorder is set and serving them to Prometheus when
+// listen is. Alerts and process rules apply as in the dashboard.
+func runStatusDaemon(interval time.Duration, recorder *status.Recorder, listen string, notifyAll bool) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	alerts := loadAlertTracker(notifyAll)
+	enforcer := loadProcessRuleEnforcer()
+
+	var exporter *status.PrometheusHandler
+	if listen != "" {
+		exporter = status.NewPrometheusHandler(alerts)
+		ln, err := net.Listen("tcp", listen)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot listen on %s: %v\n", listen, err)
+			os.Exit(1)
+		}
+		srv := &http.Server{Handler: exporter, ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = srv.Serve(ln) }()
+		defer srv.Close()
+		fmt.Printf("Serving Prometheus metrics on http://%s/metrics\n", ln.Addr())
+	}
+	if recorder != nil {
+		fmt.Printf("Recording metrics to %s every %s\n", recorder.Path(), interval)
+	}
+	fmt.Println("Press Ctrl+C to stop.")
+
+	var prevNet *status.NetworkMetrics
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		metrics, collectErr := status.CollectMetrics(prevNet, interval)
+		if collectErr == nil {
+			prevNet = &metrics.Network
+			alerts.Observe(metrics)
+			enforcer.Enforce()
+			if recErr := recorder.Record(metrics); recErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", recErr)
+			}
+			if exporter != nil {
+				exporter.Update(metrics)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Stopped.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadRecordedHistory reads the tail of the recording at path, or of the
+// daemon's default recording when path is empty. A missing or unreadable
+// recording just starts the sparklines empty.
+func loadRecordedHistory(path string) []status.Sample {
+	if path == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(cfg.ConfigDir, status.RecordFileName)
+	}
+	samples, _ := status.LoadHistory(path, 60)
+	return samples
+}
+
+// loadWatchedServices reads the services listed on the Services tab from
+// watched_services.json in the config directory, falling back to the
+// default watchlist when it cannot be read.
+func loadWatchedServices() []string {
+	cfg, err := config.Load()
+	if err != nil {
+		return status.DefaultWatchedServices()
+	}
+	names, err := status.LoadWatchedServices(filepath.Join(cfg.ConfigDir, status.WatchedServicesFileName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (watching the default services)\n", err)
+		return status.DefaultWatchedServices()
+	}
+	return names
+}
+
+// loadAlertTracker opens the persisted alert history in the config
+// directory with the rules of alert_rules.json. Failures are reported but
+// never fatal; a nil tracker simply disables alerting. notifyAll shows a
+// Windows notification for every alert, not only those of rules with
+// "notify" set.
+func loadAlertTracker(notifyAll bool) *status.AlertTracker {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	tracker, err := status.LoadAlertTracker(filepath.Join(cfg.ConfigDir, status.AlertFileName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (alerts of this session will not be saved; fix or delete the file)\n", err)
+	}
+	rules, err := status.LoadAlertRules(filepath.Join(cfg.ConfigDir, status.AlertRulesFileName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v (using the default alert rules)\n", err)
+	} else {
+		tracker.SetRules(rules)
+	}
+	tracker.SetNotifier(status.NotifyAlert, notifyAll)
+	return tracker
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/tui"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui [path]",
+	Short: "Open status, analyze, clean and uninstall in one app",
+	Long: `Open a single full-screen application that hosts the status monitor, disk
+analyzer, cleaner and uninstaller as switchable panes.
+
+Panes keep running in the background while hidden, and share state: press
+c on a folder in Analyze to scan it for junk in the Clean pane.
+
+The Clean pane applies the same safety gates as pw clean. Only low-risk
+groups start checked, --max-risk hides riskier targets and --older-than
+skips recent files. Privacy-sensitive targets need a second confirmation,
+and a restore point is created before high-risk targets are deleted (admin
+only; --no-restore-point skips it). Online-only cloud files are never
+deleted; --free-cloud frees the local copy of synced items instead.
+
+Keys:
+  F1-F4 / Alt+1-4    Jump to Status, Analyze, Clean, Uninstall
+  Ctrl+← / Ctrl+→    Previous / next pane
+  q / Ctrl+C         Quit
+
+Examples:
+  pw tui              Start on the Status pane
+  pw tui D:\          Start on the Analyze pane scanning D:\
+  pw tui --max-risk low --older-than 30d`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runTUI,
+}
+
+func init() {
+	tuiCmd.Flags().Int("refresh", 1, "Status refresh interval in seconds")
+	tuiCmd.Flags().String("max-risk", config.RiskHigh, "Only list clean targets at or below this risk: low, medium, high")
+	tuiCmd.Flags().String("older-than", "", "Only clean files not modified within this period (e.g. 30d, 2w, 12h)")
+	tuiCmd.Flags().Bool("free-cloud", false, "Free up space on cloud-synced files instead of deleting them")
+	tuiCmd.Flags().Bool("no-restore-point", false, "Skip the restore point before cleaning high-risk targets")
+}
+
+func runTUI(cmd *cobra.Command, args []string) {
+	refreshSecs, _ := cmd.Flags().GetInt("refresh")
+	freeCloud, _ := cmd.Flags().GetBool("free-cloud")
+	noRestorePoint, _ := cmd.Flags().GetBool("no-restore-point")
+
+	rawRisk, _ := cmd.Flags().GetString("max-risk")
+	maxRisk, err := config.ParseRiskLevel(rawRisk)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+	rawAge, _ := cmd.Flags().GetString("older-than")
+	minAge, err := config.ParseAge(rawAge)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(
+			fmt.Sprintf("  %s Failed to load config: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	wl, wlErr := whitelist.Load(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+	if wlErr != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Could not load whitelist: %v", ui.IconWarning, wlErr)))
+		wl = nil
+	}
+
+	opts := tui.Options{
+		RefreshInterval: time.Duration(refreshSecs) * time.Second,
+		Whitelist:       wl,
+		Protect:         loadProtectionList(),
+		Alerts:          loadAlertTracker(false),
+		WatchedServices: loadWatchedServices(),
+		IsAdmin:         core.IsElevated(),
+		DryRun:          dryRun,
+		MaxRisk:         maxRisk,
+		MinAge:          minAge,
+		FreeCloud:       freeCloud,
+		NoRestorePoint:  noRestorePoint,
+	}
+	if len(args) > 0 {
+		opts.AnalyzePath = args[0]
+	}
+	if enforcer := loadProcessRuleEnforcer(); enforcer != nil {
+		opts.OnCollect = func() { enforcer.Enforce() }
+	}
+
+	p := tea.NewProgram(tui.NewAppModel(opts), tea.WithAltScreen(), tea.WithReportFocus())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
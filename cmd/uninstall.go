@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/output"
 	"github.com/cy-infamous/purewin/internal/ui"
 	"github.com/cy-infamous/purewin/internal/uninstall"
 )
@@ -22,17 +29,130 @@ Use --all to show all installed applications regardless of location.
 Examples:
   pw uninstall              Show apps installed on the current drive
   pw uninstall D:\Programs  Show apps installed under a specific path
-  pw uninstall --all        Show all installed applications`,
+  pw uninstall --all        Show all installed applications
+  pw uninstall --all --measure
+                            Sort by the real size of each install folder
+  pw uninstall --all --publisher adobe
+                            Everything from one publisher
+  pw uninstall --all --installed-before 2022 --min-size 1GB
+                            Old apps over 1 GB
+  pw uninstall --search slack --footprint
+                            Show what Slack occupies before removing it
+  pw uninstall --history --since 7d
+                            What was uninstalled in the last week
+  pw uninstall --stale      Remove ghost entries for apps deleted by hand
+  pw uninstall --bloatware  Remove preinstalled trials, OEM apps and sponsored games
+  pw uninstall --export inventory.csv
+                            Export all installed apps for an asset inventory
+  pw uninstall --id "7-Zip 23.01 (x64)|23.01"
+                            Uninstall one app by Id without prompting
+  pw uninstall --from-file apps.txt --json
+                            Uninstall a list of apps, reporting each as JSON
+
+Apps are picked in a finder: type to fuzzy-search names and publishers
+(matched letters are highlighted), tab to tick apps, ctrl+s to sort by
+relevance, name, size or install date, and Enter to continue. The app
+under the cursor is described below the list. --search starts the finder
+with that query; with --quiet, a search naming exactly one app uninstalls
+it directly.
+
+After an uninstall, PureWin looks for what the uninstaller left behind:
+the install folder, data folders named after the app in AppData and
+ProgramData, shortcuts to missing programs, registry keys, and services
+and scheduled tasks that still run a program from the install folder (they
+fail on every boot). Pick what to remove from a checklist; registry keys
+are backed up to backups\arp in the config directory first, services are
+removed with sc delete and tasks with schtasks /delete. Data and settings
+are not preselected, nor are services or tasks whose program still exists.
+
+Sizes come from the registry, where installers often leave them out or
+get them wrong. --measure walks each install folder (in parallel) and shows
+the real size, with the registry's figure alongside, sorted largest first.
+
+--footprint shows what each selected app occupies before you confirm: the
+install folder, data folders in AppData and ProgramData, its registry
+keys, and the services and scheduled tasks that run programs from the
+install folder. With --json, each app's footprint is included.
+
+--publisher, --installed-before, --installed-after and --min-size narrow
+the list. Dates are YYYY, YYYY-MM or YYYY-MM-DD and exclude the period
+given; apps with no recorded install date are left out when a date is
+set. --min-size compares the measured size with --measure, otherwise the
+registry's.
+
+Apps matching the protection list (antivirus, VPN clients, management
+agents, PureWin itself) are shown locked and are never uninstalled. Edit
+protected_apps.txt in the PureWin config directory to change the rules.
+
+Each uninstaller's output is shown as it runs. Ctrl+C kills the running
+uninstaller (and any processes it started) and skips the remaining apps;
+--timeout does the same for an uninstaller that runs too long (default 2m).
+
+--export writes every installed app (name, version, publisher, size,
+install date and location) to a file for asset inventories. The format
+follows the extension (.json, .csv, .md) or --format json|csv|markdown;
+"--export -" writes to stdout. --show-all includes system components.
+
+--msi-log passes /L*V to msiexec so each Windows Installer uninstall
+writes a verbose log to logs\msi in the config directory, one file per app.
+When an uninstall fails, its log path is shown with the error. Common
+Windows Installer exit codes (1603, 1618, 1625 and so on) are explained
+with what to do next.
+
+--id and --from-file uninstall without any prompt, for scripts and fleet
+tools. An Id is "Name|Version" as listed in the "id" field of
+'pw uninstall --all --json'; the version may be left out when only one is
+installed. Silent uninstall commands are preferred and no leftovers are
+removed. Each app gets a result (also as JSON with --json), and the exit
+status is 1 if any app was not uninstalled.
+
+When run as administrator, a System Restore point is created before a
+batch uninstall or --stale cleanup starts; --no-restore-point skips it.
+
+--bloatware flags installed apps found in a built-in catalog of OEM
+utilities and trials, sponsored Store games and apps, and consumer Store
+apps bundled with Windows. OEM utilities and trials are preselected; the
+rest must be picked. Silent uninstall commands are used where the app
+provides one.
+
+Every uninstall is recorded in uninstall_history.jsonl in the config
+directory: the app's name, version and publisher, when it was removed, the
+uninstaller's exit code and the leftovers removed afterwards. --history
+lists it, newest first (filter with --search and --since), with the
+publisher's download page or Store link when the app registered one.
+
+--stale lists "ghost" Apps & Features entries whose install folder or
+uninstaller no longer exists. Entries missing only one of the two are
+listed too, unselected: with the folder still there, removing the key does
+not remove those files, and with the uninstaller still there it may yet
+clean up after the app. Each selected key is exported to a .reg file under
+backups\arp in the config directory before it is deleted.`,
 	Args: cobra.MaximumNArgs(1),
 	Run:  runUninstall,
 }
 
 func init() {
-	uninstallCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview without uninstalling")
 	uninstallCmd.Flags().Bool("all", false, "Show all installed apps regardless of location")
 	uninstallCmd.Flags().Bool("quiet", false, "Prefer silent uninstall commands")
 	uninstallCmd.Flags().Bool("show-all", false, "Show system components too")
-	uninstallCmd.Flags().String("search", "", "Search for apps by name")
+	uninstallCmd.Flags().String("search", "", "Search for apps by name (the finder's initial query)")
+	uninstallCmd.Flags().String("publisher", "", "Only show apps whose publisher contains this text")
+	uninstallCmd.Flags().String("installed-before", "", "Only show apps installed before this date (YYYY, YYYY-MM or YYYY-MM-DD)")
+	uninstallCmd.Flags().String("installed-after", "", "Only show apps installed after this date (YYYY, YYYY-MM or YYYY-MM-DD)")
+	uninstallCmd.Flags().String("min-size", "", "Only show apps at least this large (e.g. 500MB, 1GB)")
+	uninstallCmd.Flags().Bool("measure", false, "Measure each install folder and sort by actual size")
+	uninstallCmd.Flags().Bool("footprint", false, "Show each app's data, registry keys, services and tasks before uninstalling")
+	uninstallCmd.Flags().Bool("history", false, "List past uninstalls with exit codes, leftovers removed and download links")
+	uninstallCmd.Flags().String("since", "", "With --history, only show uninstalls within this period (e.g. 7d, 2w)")
+	uninstallCmd.Flags().Bool("stale", false, "Clean up uninstall entries for apps deleted by hand")
+	uninstallCmd.Flags().Bool("msi-log", false, "Write a verbose log of each msiexec uninstall to logs\\msi in the config directory")
+	uninstallCmd.Flags().Duration("timeout", uninstall.DefaultUninstallTimeout, "Kill an uninstaller that runs longer than this (e.g. 10m)")
+	uninstallCmd.Flags().StringArray("id", nil, `Uninstall the app with this Id ("Name|Version") without prompting (repeatable)`)
+	uninstallCmd.Flags().String("from-file", "", "Uninstall the app Ids listed in this file, one per line, without prompting")
+	uninstallCmd.Flags().String("export", "", "Write an inventory of all installed apps to this file (- for stdout)")
+	uninstallCmd.Flags().String("format", "", "Inventory format: json, csv or markdown (default: from the --export extension)")
+	addRestorePointFlag(uninstallCmd.Flags())
+	uninstallCmd.Flags().Bool("bloatware", false, "Find and remove known bloatware (OEM trials, sponsored apps)")
 }
 
 func runUninstall(cmd *cobra.Command, args []string) {
@@ -40,6 +160,60 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	allFlag, _ := cmd.Flags().GetBool("all")
 	showAll, _ := cmd.Flags().GetBool("show-all")
 	search, _ := cmd.Flags().GetString("search")
+	measure, _ := cmd.Flags().GetBool("measure")
+	footprint, _ := cmd.Flags().GetBool("footprint")
+	filter := appFilterFromFlags(cmd)
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	if timeout <= 0 {
+		err := fmt.Errorf("--timeout must be positive, got %s", timeout)
+		if jsonOutput {
+			output.Fail("uninstall", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if history, _ := cmd.Flags().GetBool("history"); history {
+		runUninstallHistory(cmd, search)
+		return
+	}
+
+	if stale, _ := cmd.Flags().GetBool("stale"); stale {
+		if jsonOutput {
+			entries, err := uninstall.FindStaleEntries()
+			if err != nil {
+				output.Fail("uninstall", err)
+			}
+			output.JSON(staleReport{Entries: append(make([]uninstall.StaleEntry, 0, len(entries)), entries...)})
+			return
+		}
+		runStaleCleanup(cmd)
+		return
+	}
+
+	if exportPath, _ := cmd.Flags().GetString("export"); exportPath != "" {
+		format, _ := cmd.Flags().GetString("format")
+		runInventoryExport(exportPath, format, showAll)
+		return
+	}
+
+	ids, idErr := uninstallIDsFromFlags(cmd)
+	if idErr != nil {
+		if jsonOutput {
+			output.Fail("uninstall", idErr)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, idErr)))
+		os.Exit(1)
+	}
+	if len(ids) > 0 {
+		runUninstallByID(cmd, ids, uninstall.RunOptions{Quiet: true, Timeout: timeout, MSILogDir: msiLogDir(cmd)})
+		return
+	}
+
+	if bloat, _ := cmd.Flags().GetBool("bloatware"); bloat {
+		runBloatware(cmd, uninstall.RunOptions{Timeout: timeout, MSILogDir: msiLogDir(cmd)})
+		return
+	}
 
 	// Determine filter path.
 	var filterPath string
@@ -48,6 +222,9 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	} else if !allFlag {
 		cwd, cwdErr := os.Getwd()
 		if cwdErr != nil {
+			if jsonOutput {
+				output.Fail("uninstall", cwdErr)
+			}
 			fmt.Println(ui.ErrorStyle().Render(
 				fmt.Sprintf("  %s Cannot determine current directory: %v", ui.IconError, cwdErr)))
 			os.Exit(1)
@@ -55,6 +232,11 @@ func runUninstall(cmd *cobra.Command, args []string) {
 		filterPath = cwd
 	}
 
+	if jsonOutput {
+		runUninstallJSON(filterPath, search, filter, showAll, measure, footprint)
+		return
+	}
+
 	// Scan installed apps from the registry.
 	fmt.Println()
 	spin := ui.NewInlineSpinner()
@@ -74,26 +256,39 @@ func runUninstall(cmd *cobra.Command, args []string) {
 		spin.Stop(fmt.Sprintf("Found %d installed applications", len(apps)))
 	}
 
-	// Apply search filter if specified.
-	if search != "" {
-		apps = filterAppsByName(apps, search)
+	if measure {
+		measureAppSizes(apps)
+	}
+
+	if !filter.IsZero() {
+		apps = filter.Apply(apps)
 		if len(apps) == 0 {
-			fmt.Println(ui.WarningStyle().Render(
-				fmt.Sprintf("  No applications matching %q found.", search)))
+			fmt.Println(ui.WarningStyle().Render("  No applications match the filters."))
 			return
 		}
 		fmt.Println(ui.InfoStyle().Render(
-			fmt.Sprintf("  %d application(s) matching %q", len(apps), search)))
+			fmt.Sprintf("  %d application(s) match the filters", len(apps))))
 	}
 
-	// Quick single-app uninstall if --quiet + --search yields exactly one result.
-	if quiet && search != "" && len(apps) == 1 {
-		runSingleUninstall(apps[0], dryRun, quiet)
-		return
+	protect := loadProtectionList()
+
+	// Quick single-app uninstall if --quiet + --search names exactly one app.
+	if quiet && search != "" {
+		if matches := filterAppsByName(apps, search); len(matches) == 1 {
+			runSingleUninstall(matches[0], dryRun, footprint, protect,
+				uninstall.RunOptions{Quiet: quiet, Timeout: timeout, MSILogDir: msiLogDir(cmd)})
+			return
+		}
 	}
 
-	// Batch uninstall flow with selector.
-	if err := uninstall.RunBatchUninstall(apps, dryRun); err != nil {
+	// Batch uninstall flow with the finder; --search is its initial query.
+	opts := uninstall.BatchOptions{
+		DryRun: dryRun, Protect: protect, BackupDir: registryBackupDir(), Timeout: timeout,
+		MSILogDir: msiLogDir(cmd), HistoryDir: uninstallHistoryDir(), Footprint: footprint,
+		Query:     search,
+		BeforeRun: func() bool { return ensureRestorePoint(cmd, "before pw uninstall", false) },
+	}
+	if err := uninstall.RunBatchUninstall(apps, opts); err != nil {
 		fmt.Fprintf(os.Stderr, "\n%s %s\n",
 			ui.ErrorStyle().Render(ui.IconError),
 			ui.ErrorStyle().Render(err.Error()))
@@ -101,6 +296,201 @@ func runUninstall(cmd *cobra.Command, args []string) {
 	}
 }
 
+// uninstallReport is the --json form of `pw uninstall`. It lists the apps
+// that would be offered for removal; nothing is uninstalled in JSON mode.
+type uninstallReport struct {
+	Path       string               `json:"path,omitempty"` // empty for --all
+	Search     string               `json:"search,omitempty"`
+	Apps       []uninstallReportApp `json:"apps"`
+	TotalSize  int64                `json:"total_size"`
+	TotalCount int                  `json:"total_count"`
+}
+
+// uninstallReportApp is an installed app plus the protection rule that
+// locks it, if any.
+type uninstallReportApp struct {
+	ID string `json:"id"` // for --id
+	uninstall.InstalledApp
+	ProtectedBy string               `json:"protected_by,omitempty"`
+	Footprint   *uninstall.Footprint `json:"footprint,omitempty"` // with --footprint
+}
+
+// runInventoryExport writes every installed app to path ("-" for stdout)
+// in the given format, or the one implied by the extension.
+func runInventoryExport(path, format string, showAll bool) {
+	fail := func(err error) {
+		if jsonOutput {
+			output.Fail("uninstall", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	if path == "-" && format == "" {
+		format = uninstall.InventoryJSON
+	}
+	format, err := uninstall.InventoryFormat(format, path)
+	if err != nil {
+		fail(err)
+	}
+
+	apps, err := uninstall.GetInstalledApps(showAll)
+	if err != nil {
+		fail(fmt.Errorf("failed to read registry: %w", err))
+	}
+	inv := uninstall.NewInventory(apps, time.Now())
+
+	if path == "-" {
+		if err := inv.Write(os.Stdout, format); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		fail(fmt.Errorf("cannot create %s: %w", path, err))
+	}
+	writeErr := inv.Write(f, format)
+	if closeErr := f.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		fail(fmt.Errorf("cannot write %s: %w", path, writeErr))
+	}
+
+	if jsonOutput {
+		output.JSON(inventoryExportReport{Path: path, Format: format, Count: inv.Count, TotalSize: inv.TotalSize})
+		return
+	}
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle().Render(fmt.Sprintf("  %s Exported %d applications (%s) to %s",
+		ui.IconSuccess, inv.Count, core.FormatSize(inv.TotalSize), path)))
+	fmt.Println()
+}
+
+// inventoryExportReport is the --json summary of `pw uninstall --export`.
+type inventoryExportReport struct {
+	Path      string `json:"path"`
+	Format    string `json:"format"`
+	Count     int    `json:"count"`
+	TotalSize int64  `json:"total_size"`
+}
+
+// bloatwareReport is the --json form of `pw uninstall --bloatware`.
+type bloatwareReport struct {
+	Matches   []uninstall.BloatwareMatch `json:"matches"`
+	TotalSize int64                      `json:"total_size"`
+}
+
+// staleReport is the --json form of `pw uninstall --stale`.
+type staleReport struct {
+	Entries []uninstall.StaleEntry `json:"entries"`
+}
+
+// runUninstallJSON lists apps like runUninstall and writes an
+// uninstallReport to stdout.
+func runUninstallJSON(filterPath, search string, filter uninstall.AppFilter, showAll, measure, footprint bool) {
+	apps, err := uninstall.GetInstalledApps(showAll)
+	if err != nil {
+		output.Fail("uninstall", err)
+	}
+	if filterPath != "" {
+		apps = uninstall.FilterByPath(apps, filterPath)
+	}
+	if search != "" {
+		apps = filterAppsByName(apps, search)
+	}
+	if measure {
+		uninstall.MeasureSizes(apps, nil)
+		uninstall.SortByActualSize(apps)
+	}
+	apps = filter.Apply(apps)
+
+	protect := uninstall.DefaultProtectionList()
+	if cfg, cfgErr := config.Load(); cfgErr == nil {
+		if p, pErr := uninstall.LoadProtectionList(filepath.Join(cfg.ConfigDir, uninstall.ProtectedFileName)); pErr == nil {
+			protect = p
+		}
+	}
+
+	report := uninstallReport{
+		Path:       filterPath,
+		Search:     search,
+		Apps:       make([]uninstallReportApp, 0, len(apps)),
+		TotalCount: len(apps),
+	}
+	for _, app := range apps {
+		rule, _ := protect.Match(app)
+		entry := uninstallReportApp{ID: app.ID(), InstalledApp: app, ProtectedBy: rule}
+		if footprint {
+			fp := uninstall.ScanFootprint(app)
+			entry.Footprint = &fp
+		}
+		report.Apps = append(report.Apps, entry)
+		report.TotalSize += app.DisplaySize()
+	}
+	output.JSON(report)
+}
+
+// measureAppSizes measures the install folders of apps behind a spinner
+// and sorts them by actual size.
+func measureAppSizes(apps []uninstall.InstalledApp) {
+	spin := ui.NewInlineSpinner()
+	spin.Start("Measuring install folders...")
+	uninstall.MeasureSizes(apps, func(done, total int) {
+		spin.UpdateMessage(fmt.Sprintf("Measuring install folders... %d/%d", done, total))
+	})
+	uninstall.SortByActualSize(apps)
+
+	var measured, estimated int64
+	for _, app := range apps {
+		if app.ActualSize > 0 {
+			measured += app.ActualSize
+			estimated += app.EstimatedSize
+		}
+	}
+	spin.Stop(fmt.Sprintf("Measured %s on disk (registry estimates: %s)",
+		core.FormatSize(measured), core.FormatSize(estimated)))
+}
+
+// appFilterFromFlags reads --publisher, --installed-before,
+// --installed-after and --min-size, exiting on invalid values.
+func appFilterFromFlags(cmd *cobra.Command) uninstall.AppFilter {
+	fail := func(err error) {
+		if jsonOutput {
+			output.Fail("uninstall", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	var f uninstall.AppFilter
+	f.Publisher, _ = cmd.Flags().GetString("publisher")
+	if s, _ := cmd.Flags().GetString("installed-before"); s != "" {
+		t, err := uninstall.ParseDateBound(s, false)
+		if err != nil {
+			fail(fmt.Errorf("--installed-before: %w", err))
+		}
+		f.InstalledBefore = t
+	}
+	if s, _ := cmd.Flags().GetString("installed-after"); s != "" {
+		t, err := uninstall.ParseDateBound(s, true)
+		if err != nil {
+			fail(fmt.Errorf("--installed-after: %w", err))
+		}
+		f.InstalledAfter = t
+	}
+	if s, _ := cmd.Flags().GetString("min-size"); s != "" {
+		size, err := config.ParseSize(s)
+		if err != nil {
+			fail(fmt.Errorf("--min-size: %w", err))
+		}
+		f.MinSize = size
+	}
+	return f
+}
+
 // filterAppsByName returns apps whose Name contains the search term
 // (case-insensitive).
 func filterAppsByName(apps []uninstall.InstalledApp, search string) []uninstall.InstalledApp {
@@ -114,8 +504,37 @@ func filterAppsByName(apps []uninstall.InstalledApp, search string) []uninstall.
 	return filtered
 }
 
+// loadProtectionList loads the user's protected-app rules, falling back to
+// the built-in defaults if the file cannot be read.
+func loadProtectionList() *uninstall.ProtectionList {
+	cfg, err := config.Load()
+	if err != nil {
+		return uninstall.DefaultProtectionList()
+	}
+	protect, err := uninstall.LoadProtectionList(filepath.Join(cfg.ConfigDir, uninstall.ProtectedFileName))
+	if err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Could not load protection list, using defaults: %v", ui.IconWarning, err)))
+		return uninstall.DefaultProtectionList()
+	}
+	return protect
+}
+
 // runSingleUninstall handles uninstalling a single app directly.
-func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) {
+func runSingleUninstall(app uninstall.InstalledApp, dryRun, footprint bool, protect *uninstall.ProtectionList, run uninstall.RunOptions) {
+	if err := protect.Check(app); err != nil {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Refusing to uninstall: %s", ui.IconWarning, err)))
+		fmt.Println(ui.MutedStyle().Render(
+			"  → Edit " + uninstall.ProtectedFileName + " in the PureWin config directory to change this."))
+		return
+	}
+
+	if footprint {
+		fmt.Println()
+		uninstall.PrintFootprint(app, uninstall.ScanFootprint(app))
+	}
+
 	if dryRun {
 		fmt.Printf("\n  DRY RUN: Would uninstall %s\n", app.Name)
 		return
@@ -127,12 +546,183 @@ func runSingleUninstall(app uninstall.InstalledApp, dryRun bool, quiet bool) {
 		return
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Println(ui.MutedStyle().Render("  Press Ctrl+C to cancel."))
+	uninstErr := uninstall.UninstallWithSpinner(ctx, app, run)
+	entry := uninstall.NewHistoryEntry(app, uninstErr, time.Now())
+	if uninstErr != nil {
+		recordUninstall(entry)
+		os.Exit(1)
+	}
+
+	cleaned, err := uninstall.CleanLeftovers([]uninstall.InstalledApp{app}, registryBackupDir())
+	entry.Leftovers = cleaned[app.Name]
+	recordUninstall(entry)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+}
+
+// registryBackupDir is where registry keys are exported before they are
+// deleted.
+func registryBackupDir() string {
+	if cfg, err := config.Load(); err == nil {
+		return filepath.Join(cfg.ConfigDir, "backups", "arp")
+	}
+	return filepath.Join(os.TempDir(), "purewin", "arp")
+}
+
+// runBloatware flags installed apps from the bloatware catalog and offers
+// them for batch removal.
+func runBloatware(cmd *cobra.Command, run uninstall.RunOptions) {
+	if jsonOutput {
+		matches, err := uninstall.FindBloatware()
+		if err != nil {
+			output.Fail("uninstall", err)
+		}
+		report := bloatwareReport{Matches: append(make([]uninstall.BloatwareMatch, 0, len(matches)), matches...)}
+		for _, m := range matches {
+			report.TotalSize += m.App.EstimatedSize
+		}
+		output.JSON(report)
+		return
+	}
+
+	fmt.Println()
+	spin := ui.NewInlineSpinner()
+	spin.Start("Checking installed apps for bloatware...")
+
+	matches, err := uninstall.FindBloatware()
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("Failed to read registry: %s", err))
+		os.Exit(1)
+	}
+	var total int64
+	for _, m := range matches {
+		total += m.App.EstimatedSize
+	}
+	spin.Stop(fmt.Sprintf("Found %d bloatware apps (%s)", len(matches), core.FormatSize(total)))
+
+	opts := uninstall.BatchOptions{
+		DryRun: dryRun, Protect: loadProtectionList(), BackupDir: registryBackupDir(), Timeout: run.Timeout,
+		MSILogDir: run.MSILogDir, HistoryDir: uninstallHistoryDir(),
+		BeforeRun: func() bool { return ensureRestorePoint(cmd, "before pw uninstall --bloatware", false) },
+	}
+	if err := uninstall.RunBloatwareUninstall(matches, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "\n%s %s\n",
+			ui.ErrorStyle().Render(ui.IconError),
+			ui.ErrorStyle().Render(err.Error()))
+		os.Exit(1)
+	}
+}
+
+// runStaleCleanup finds uninstall keys left behind by manually deleted apps,
+// lets the user pick which to remove, and deletes them after backing each
+// one up to a .reg file.
+func runStaleCleanup(cmd *cobra.Command) {
+	fmt.Println()
 	spin := ui.NewInlineSpinner()
-	spin.Start(fmt.Sprintf("Uninstalling %s...", app.Name))
+	spin.Start("Checking uninstall entries...")
+
+	entries, err := uninstall.FindStaleEntries()
+	if err != nil {
+		spin.StopWithError(fmt.Sprintf("Failed to read registry: %s", err))
+		os.Exit(1)
+	}
+	spin.Stop(fmt.Sprintf("Found %d stale entries", len(entries)))
+
+	if len(entries) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Apps & Features has no stale entries.", ui.IconSuccess)))
+		return
+	}
+
+	items := make([]ui.SelectorItem, len(entries))
+	for i, e := range entries {
+		items[i] = ui.SelectorItem{
+			Label:       e.App.Name,
+			Description: e.Reason,
+			Value:       e.App.RegistryKey,
+			Selected:    !e.Broken,
+		}
+	}
 
-	if uninstErr := uninstall.UninstallApp(app, quiet); uninstErr != nil {
-		spin.StopWithError(fmt.Sprintf("Failed: %s", uninstErr))
+	selected, err := ui.RunSelector(items, "Select stale entries to remove")
+	if err != nil {
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Selector error: %v", ui.IconError, err)))
 		os.Exit(1)
 	}
-	spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
+	if len(selected) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No entries selected."))
+		return
+	}
+
+	chosen := make(map[string]bool, len(selected))
+	for _, item := range selected {
+		chosen[item.Value] = true
+	}
+
+	backupDir := registryBackupDir()
+
+	if !dryRun {
+		confirmed, confirmErr := ui.Confirm(fmt.Sprintf(
+			"Remove %d uninstall entries? Each key is backed up first.", len(chosen)))
+		if confirmErr != nil || !confirmed {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			return
+		}
+		if !ensureRestorePoint(cmd, "before pw uninstall --stale", false) {
+			fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+			return
+		}
+	}
+
+	fmt.Println()
+	var removed, failed int
+	for _, e := range entries {
+		if !chosen[e.App.RegistryKey] {
+			continue
+		}
+		backup, rmErr := uninstall.RemoveStaleEntry(e, backupDir, dryRun)
+		switch {
+		case rmErr != nil:
+			failed++
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %s: %v", ui.IconError, e.App.Name, rmErr)))
+		case dryRun:
+			fmt.Println(ui.InfoStyle().Render(
+				fmt.Sprintf("  [DRY RUN] Would remove %s (backup: %s)", e.App.Name, backup)))
+		default:
+			removed++
+			fmt.Printf("  %s %s %s\n", ui.SuccessStyle().Render(ui.IconSuccess), e.App.Name,
+				ui.MutedStyle().Render("→ "+backup))
+		}
+	}
+
+	fmt.Println()
+	if removed > 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Removed %d entries. Backups: %s", ui.IconSuccess, removed, backupDir)))
+		fmt.Println(ui.MutedStyle().Render(
+			"  → Double-click a .reg file to restore its entry."))
+	}
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %d entries could not be removed (system-wide keys need admin)", ui.IconWarning, failed)))
+	}
+}
+
+// msiLogDir returns where --msi-log writes msiexec logs, or "" when the
+// flag is not set.
+func msiLogDir(cmd *cobra.Command) string {
+	if on, _ := cmd.Flags().GetBool("msi-log"); !on {
+		return ""
+	}
+	if cfg, err := config.Load(); err == nil {
+		return filepath.Join(filepath.Dir(cfg.LogFile), "msi")
+	}
+	return filepath.Join(os.TempDir(), "purewin", "msi")
 }
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/internal/uninstall"
+)
+
+// ─── Uninstall History ───────────────────────────────────────────────────────
+
+// uninstallHistoryReport is the --json form of `pw uninstall --history`.
+type uninstallHistoryReport struct {
+	Entries []uninstall.HistoryEntry `json:"entries"`
+}
+
+// uninstallHistoryDir is where the uninstall history is kept, or "" when
+// the config directory cannot be determined.
+func uninstallHistoryDir() string {
+	if cfg, err := config.Load(); err == nil {
+		return cfg.ConfigDir
+	}
+	return ""
+}
+
+// recordUninstall appends e to the uninstall history. Failures are ignored:
+// a missing record must never fail the uninstall itself.
+func recordUninstall(e uninstall.HistoryEntry) {
+	if dir := uninstallHistoryDir(); dir != "" {
+		_ = uninstall.RecordUninstall(dir, e)
+	}
+}
+
+// runUninstallHistory lists past uninstalls, newest first, optionally
+// limited by --since and a name search.
+func runUninstallHistory(cmd *cobra.Command, search string) {
+	fail := func(err error) {
+		if jsonOutput {
+			output.Fail("uninstall", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+
+	raw, _ := cmd.Flags().GetString("since")
+	age, err := config.ParseAge(raw)
+	if err != nil {
+		fail(err)
+	}
+	var since time.Time
+	if age > 0 {
+		since = time.Now().Add(-age)
+	}
+
+	dir := uninstallHistoryDir()
+	if dir == "" {
+		fail(fmt.Errorf("cannot locate the config directory"))
+	}
+	entries, err := uninstall.LoadHistory(dir)
+	if err != nil {
+		fail(fmt.Errorf("cannot read uninstall history: %w", err))
+	}
+	entries = uninstall.FilterHistory(entries, since, search)
+
+	if jsonOutput {
+		output.JSON(uninstallHistoryReport{Entries: append(make([]uninstall.HistoryEntry, 0, len(entries)), entries...)})
+		return
+	}
+
+	fmt.Println()
+	if len(entries) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  No uninstalls recorded."))
+		return
+	}
+	fmt.Println(ui.HeaderStyle().Render(fmt.Sprintf("  %d uninstall(s)", len(entries))))
+	fmt.Println()
+
+	for _, e := range entries {
+		name := e.Name
+		if e.Version != "" {
+			name += " " + e.Version
+		}
+		when := e.Timestamp.Local().Format("2006-01-02 15:04")
+
+		switch e.Status {
+		case uninstall.HistoryUninstalled:
+			fmt.Printf("  %s %s  %s\n", ui.SuccessStyle().Render(ui.IconSuccess), ui.MutedStyle().Render(when), name)
+		case uninstall.HistoryCancelled:
+			fmt.Printf("  %s %s  %s %s\n", ui.WarningStyle().Render(ui.IconWarning), ui.MutedStyle().Render(when), name,
+				ui.WarningStyle().Render("(cancelled)"))
+		default:
+			fmt.Printf("  %s %s  %s %s\n", ui.ErrorStyle().Render(ui.IconError), ui.MutedStyle().Render(when), name,
+				ui.ErrorStyle().Render(fmt.Sprintf("(failed, exit code %d)", e.ExitCode)))
+		}
+
+		var details []string
+		if e.Publisher != "" {
+			details = append(details, e.Publisher)
+		}
+		if n := len(e.Leftovers); n > 0 {
+			details = append(details, fmt.Sprintf("%d leftovers removed", n))
+		}
+		if len(details) > 0 {
+			fmt.Println(ui.MutedStyle().Render("      " + strings.Join(details, " • ")))
+		}
+		if e.DownloadURL != "" {
+			fmt.Println(ui.MutedStyle().Render("      Reinstall: " + e.DownloadURL))
+		}
+	}
+	fmt.Println()
+}
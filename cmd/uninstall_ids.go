@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cy-infamous/purewin/internal/output"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/internal/uninstall"
+)
+
+// ─── Non-Interactive Uninstall ───────────────────────────────────────────────
+// --id and --from-file uninstall a fixed list of apps without prompts, for
+// CI and fleet scripts. Every Id gets a result; the exit status is 1 when
+// any of them was not uninstalled.
+
+// Outcomes of a non-interactive uninstall.
+const (
+	idStatusUninstalled = "uninstalled"
+	idStatusDryRun      = "dry_run"
+	idStatusNotFound    = "not_found"
+	idStatusProtected   = "protected"
+	idStatusFailed      = "failed"
+	idStatusCancelled   = "cancelled"
+)
+
+// idUninstallResult is the outcome for one requested Id.
+type idUninstallResult struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// idUninstallReport is the --json form of `pw uninstall --id/--from-file`.
+type idUninstallReport struct {
+	Results   []idUninstallResult `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+// uninstallIDsFromFlags collects the Ids given with --id and --from-file.
+func uninstallIDsFromFlags(cmd *cobra.Command) ([]string, error) {
+	ids, _ := cmd.Flags().GetStringArray("id")
+	if path, _ := cmd.Flags().GetString("from-file"); path != "" {
+		fromFile, err := uninstall.ReadIDFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(fromFile) == 0 {
+			return nil, fmt.Errorf("%s lists no apps", path)
+		}
+		ids = append(ids, fromFile...)
+	}
+	return ids, nil
+}
+
+// runUninstallByID uninstalls the apps named by ids without prompting,
+// preferring silent uninstall commands, and exits 1 if any failed.
+func runUninstallByID(cmd *cobra.Command, ids []string, run uninstall.RunOptions) {
+	apps, err := uninstall.GetInstalledApps(true)
+	if err != nil {
+		if jsonOutput {
+			output.Fail("uninstall", err)
+		}
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to read registry: %v", ui.IconError, err)))
+		os.Exit(1)
+	}
+	protect := loadProtectionList()
+
+	if !jsonOutput {
+		fmt.Println()
+	}
+	if !ensureRestorePoint(cmd, "before pw uninstall --id", true) {
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report := idUninstallReport{Results: make([]idUninstallResult, 0, len(ids))}
+	for _, id := range ids {
+		res := uninstallOneByID(ctx, apps, id, protect, run)
+		if res.Status == idStatusUninstalled || res.Status == idStatusDryRun {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, res)
+		if !jsonOutput {
+			printIDResult(res)
+		}
+	}
+
+	if jsonOutput {
+		output.JSON(report)
+	} else {
+		fmt.Println()
+		fmt.Println(ui.Divider(40))
+		fmt.Printf("  %d uninstalled, %d failed\n", report.Succeeded, report.Failed)
+		fmt.Println()
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// uninstallOneByID resolves and uninstalls a single Id.
+func uninstallOneByID(ctx context.Context, apps []uninstall.InstalledApp, id string,
+	protect *uninstall.ProtectionList, run uninstall.RunOptions) idUninstallResult {
+	res := idUninstallResult{ID: id}
+	if ctx.Err() != nil {
+		res.Status = idStatusCancelled
+		return res
+	}
+
+	app, err := uninstall.FindByID(apps, id)
+	if err != nil {
+		res.Status = idStatusNotFound
+		res.Error = err.Error()
+		return res
+	}
+	res.ID, res.Name = app.ID(), app.Name
+
+	if err := protect.Check(app); err != nil {
+		res.Status = idStatusProtected
+		res.Error = err.Error()
+		return res
+	}
+	if dryRun {
+		res.Status = idStatusDryRun
+		return res
+	}
+
+	err = uninstall.UninstallAppContext(ctx, app, run)
+	recordUninstall(uninstall.NewHistoryEntry(app, err, time.Now()))
+	switch {
+	case errors.Is(err, uninstall.ErrCancelled):
+		res.Status = idStatusCancelled
+		res.Error = err.Error()
+	case err != nil:
+		res.Status = idStatusFailed
+		res.Error = err.Error()
+	default:
+		res.Status = idStatusUninstalled
+	}
+	return res
+}
+
+// printIDResult prints one line for a non-interactive uninstall result.
+func printIDResult(res idUninstallResult) {
+	name := res.Name
+	if name == "" {
+		name = res.ID
+	}
+	switch res.Status {
+	case idStatusUninstalled:
+		fmt.Printf("  %s %s\n", ui.SuccessStyle().Render(ui.IconSuccess), name)
+	case idStatusDryRun:
+		fmt.Println(ui.InfoStyle().Render(fmt.Sprintf("  [DRY RUN] Would uninstall %s", name)))
+	default:
+		fmt.Println(ui.ErrorStyle().Render(fmt.Sprintf("  %s %s: %s", ui.IconError, name, res.Error)))
+	}
+}
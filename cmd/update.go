@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/ui"
@@ -43,11 +44,12 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	spinner := ui.NewInlineSpinner()
 	spinner.Start("Checking for updates...")
 
-	latestVersion, downloadURL, err := update.CheckForUpdate(appVersion)
+	release, err := update.CheckForUpdateFull(appVersion)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Update check failed: %v", err))
 		os.Exit(1)
 	}
+	latestVersion := strings.TrimPrefix(release.TagName, "v")
 
 	spinner.Stop("Update check complete")
 
@@ -85,29 +87,30 @@ func runUpdate(cmd *cobra.Command, args []string) {
 	// Download update
 	fmt.Println()
 	spinner = ui.NewInlineSpinner()
-	spinner.Start("Downloading update...")
+	spinner.Start("Downloading and verifying update...")
 
-	tempPath, err := update.DownloadUpdate(downloadURL)
+	// Checks size, SHA256 checksum, and Authenticode signer.
+	verified, err := update.DownloadAndVerifyUpdate(release)
 	if err != nil {
 		spinner.StopWithError(fmt.Sprintf("Download failed: %v", err))
 		os.Exit(1)
 	}
 
-	spinner.Stop("Download complete")
+	spinner.Stop("Download verified")
 
 	// Apply update
 	spinner = ui.NewInlineSpinner()
 	spinner.Start("Installing update...")
 
-	if err := update.ApplyUpdate(tempPath); err != nil {
+	if err := update.ApplyUpdate(verified); err != nil {
 		spinner.StopWithError(fmt.Sprintf("Installation failed: %v", err))
 		// Clean up temp file
-		_ = os.Remove(tempPath)
+		_ = os.Remove(verified.Path)
 		os.Exit(1)
 	}
 
 	// Clean up temp file
-	_ = os.Remove(tempPath)
+	_ = os.Remove(verified.Path)
 
 	spinner.Stop("Update installed successfully")
 
@@ -11,6 +11,10 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show installed version",
 	Run: func(cmd *cobra.Command, args []string) {
+		if show, _ := cmd.Flags().GetBool("migrations"); show {
+			printMigrationState()
+			return
+		}
 		fmt.Printf("PureWin version %s\n", appVersion)
 		fmt.Printf("Commit: %s\n", appCommit)
 		fmt.Printf("Built: %s\n", appDate)
@@ -18,3 +22,7 @@ var versionCmd = &cobra.Command{
 		fmt.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
 	},
 }
+
+func init() {
+	versionCmd.Flags().Bool("migrations", false, "Show which post-update data migrations have run")
+}
@@ -0,0 +1,191 @@
+package analyze
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Archive Contents ────────────────────────────────────────────────────────
+// Pressing a on an archive lists what is inside it without extracting it,
+// so a large download can be judged before it is deleted. Zip files are
+// read directly, with the packed size of every entry. 7z, ISO, RAR, CAB and
+// tar files are listed with the tar.exe that ships with Windows (libarchive),
+// which reports unpacked sizes only; their ratio compares the unpacked total
+// with the size of the archive.
+
+// archiveListTimeout bounds a tar.exe listing of a large or slow archive.
+const archiveListTimeout = 60 * time.Second
+
+// archiveFormats maps the extensions that can be listed to their format.
+var archiveFormats = map[string]string{
+	".zip": "zip",
+	".7z":  "7z",
+	".iso": "iso",
+	".rar": "rar",
+	".cab": "cab",
+	".tar": "tar",
+	".tgz": "tar",
+}
+
+// ArchiveEntry is one file or folder inside an archive.
+type ArchiveEntry struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`   // unpacked
+	Packed int64  `json:"packed"` // -1 when the format does not say
+	IsDir  bool   `json:"is_dir"`
+}
+
+// ArchiveListing is the table of contents of an archive.
+type ArchiveListing struct {
+	Path     string         `json:"path"`
+	Format   string         `json:"format"`
+	Size     int64          `json:"size"` // of the archive file
+	Files    int            `json:"files"`
+	Dirs     int            `json:"dirs"`
+	Unpacked int64          `json:"unpacked"`
+	Entries  []ArchiveEntry `json:"entries"` // largest first
+}
+
+// Ratio returns the archive size as a fraction of its unpacked contents,
+// or 0 when it is empty.
+func (l *ArchiveListing) Ratio() float64 {
+	if l.Unpacked == 0 {
+		return 0
+	}
+	return float64(l.Size) / float64(l.Unpacked)
+}
+
+// IsArchive reports whether name has an extension ListArchive can read.
+func IsArchive(name string) bool {
+	_, ok := archiveFormats[strings.ToLower(filepath.Ext(name))]
+	return ok
+}
+
+// ListArchive reads the table of contents of the archive at path.
+func ListArchive(path string) (*ArchiveListing, error) {
+	format, ok := archiveFormats[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, fmt.Errorf("%s is not a supported archive", filepath.Base(path))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ArchiveEntry
+	if format == "zip" {
+		entries, err = listZip(path)
+	} else {
+		entries, err = listWithTar(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot list %s: %w", filepath.Base(path), err)
+	}
+
+	l := &ArchiveListing{Path: path, Format: format, Size: info.Size(), Entries: entries}
+	for _, e := range entries {
+		if e.IsDir {
+			l.Dirs++
+			continue
+		}
+		l.Files++
+		l.Unpacked += e.Size
+	}
+	sort.SliceStable(l.Entries, func(i, j int) bool { return l.Entries[i].Size > l.Entries[j].Size })
+	return l, nil
+}
+
+// listZip reads the central directory of a zip file.
+func listZip(path string) ([]ArchiveEntry, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	entries := make([]ArchiveEntry, 0, len(r.File))
+	for _, f := range r.File {
+		entries = append(entries, ArchiveEntry{
+			Name:   f.Name,
+			Size:   int64(f.UncompressedSize64),
+			Packed: int64(f.CompressedSize64),
+			IsDir:  f.FileInfo().IsDir(),
+		})
+	}
+	return entries, nil
+}
+
+// listWithTar lists an archive with tar.exe. Its verbose listing reads
+//
+//	-rw-rw-rw-  0 0      0     1048576 Mar 12  2024 setup/data.bin
+//
+// with the size in the fifth field and the name from the ninth on.
+func listWithTar(path string) ([]ArchiveEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), archiveListTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "tar.exe", "-tvf", path)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s", msg)
+		}
+		return nil, err
+	}
+
+	var entries []ArchiveEntry
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 9 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, ArchiveEntry{
+			Name:   strings.Join(fields[8:], " "),
+			Size:   size,
+			Packed: -1,
+			IsDir:  strings.HasPrefix(fields[0], "d"),
+		})
+	}
+	return entries, sc.Err()
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// archiveListedMsg carries the contents of an inspected archive.
+type archiveListedMsg struct {
+	path    string
+	listing *ArchiveListing
+	err     error
+}
+
+func inspectArchive(path string) tea.Cmd {
+	return func() tea.Msg {
+		listing, err := ListArchive(path)
+		return archiveListedMsg{path: path, listing: listing, err: err}
+	}
+}
+
+// archiveViewHeight is the number of entries shown below the archive
+// summary.
+func (m AnalyzeModel) archiveViewHeight() int {
+	return max(m.viewportHeight()-2, 1)
+}
@@ -11,6 +11,10 @@ import (
 const (
 	cacheFileName = "analyze_cache.json"
 	cacheTTL      = 5 * time.Minute
+
+	// scanCacheSubdir holds scan caches below the config directory. Older
+	// versions wrote them directly into the config directory.
+	scanCacheSubdir = `cache\analyze`
 )
 
 // cacheEntry wraps a scan result with metadata for validation.
@@ -18,6 +22,7 @@ type cacheEntry struct {
 	Timestamp time.Time `json:"timestamp"`
 	RootPath  string    `json:"root_path"`
 	Root      *DirEntry `json:"root"`
+	Journal   *usnState `json:"journal,omitempty"` // change journal position at scan time
 }
 
 // cacheDir returns the %APPDATA%\purewin directory, creating it if needed.
@@ -34,9 +39,20 @@ func cacheDir() (string, error) {
 	return dir, os.MkdirAll(dir, 0o755)
 }
 
+// scanCacheDir returns the directory holding scan caches, creating it if
+// needed.
+func scanCacheDir() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, scanCacheSubdir)
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
 // cachePath generates a cache file path keyed by the scan root.
 func cachePath(rootPath string) string {
-	dir, err := cacheDir()
+	dir, err := scanCacheDir()
 	if err != nil {
 		return ""
 	}
@@ -60,6 +76,7 @@ func SaveCache(root *DirEntry, rootPath string) error {
 		Timestamp: time.Now(),
 		RootPath:  rootPath,
 		Root:      root,
+		Journal:   root.journal,
 	}
 
 	data, err := json.Marshal(entry)
@@ -73,6 +90,32 @@ func SaveCache(root *DirEntry, rootPath string) error {
 // LoadCache loads cached scan results if they exist and haven't expired.
 // Returns os.ErrNotExist if no valid cache is found.
 func LoadCache(rootPath string) (*DirEntry, error) {
+	entry, err := loadCacheEntry(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate: cache must not be expired.
+	if time.Since(entry.Timestamp) > cacheTTL {
+		return nil, os.ErrNotExist
+	}
+	return entry.Root, nil
+}
+
+// LoadStaleCache loads cached scan results whatever their age, to be
+// brought up to date with Scanner.Refresh. Returns os.ErrNotExist if no
+// cache is found.
+func LoadStaleCache(rootPath string) (*DirEntry, error) {
+	entry, err := loadCacheEntry(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Root, nil
+}
+
+// loadCacheEntry reads the cache file for rootPath and restores the tree's
+// parent pointers, journal position and scan time.
+func loadCacheEntry(rootPath string) (*cacheEntry, error) {
 	path := cachePath(rootPath)
 	if path == "" {
 		return nil, os.ErrNotExist
@@ -89,19 +132,16 @@ func LoadCache(rootPath string) (*DirEntry, error) {
 	}
 
 	// Validate: root path must match.
-	if entry.RootPath != rootPath {
-		return nil, os.ErrNotExist
-	}
-
-	// Validate: cache must not be expired.
-	if time.Since(entry.Timestamp) > cacheTTL {
+	if entry.RootPath != rootPath || entry.Root == nil {
 		return nil, os.ErrNotExist
 	}
 
 	// Rebuild parent pointers (not serialized to avoid circular refs).
 	rebuildParents(entry.Root, nil)
+	entry.Root.journal = entry.Journal
+	entry.Root.scannedAt = entry.Timestamp
 
-	return entry.Root, nil
+	return &entry, nil
 }
 
 // rebuildParents restores Parent pointers after deserialization.
@@ -114,3 +154,30 @@ func rebuildParents(entry *DirEntry, parent *DirEntry) {
 		rebuildParents(child, entry)
 	}
 }
+
+// MigrateCacheLayout moves scan caches written by older versions from the
+// config directory root into the scan cache directory. It returns the
+// number of files moved and is a no-op once nothing is left to move.
+func MigrateCacheLayout() (int, error) {
+	oldDir, err := cacheDir()
+	if err != nil {
+		return 0, err
+	}
+	legacy, err := filepath.Glob(filepath.Join(oldDir, "*_"+cacheFileName))
+	if err != nil || len(legacy) == 0 {
+		return 0, err
+	}
+	newDir, err := scanCacheDir()
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, path := range legacy {
+		if err := os.Rename(path, filepath.Join(newDir, filepath.Base(path))); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
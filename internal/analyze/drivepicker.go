@@ -0,0 +1,175 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Drive Picker ────────────────────────────────────────────────────────────
+
+// drivesLoadedMsg carries the result of ListDrives.
+type drivesLoadedMsg struct {
+	drives []Drive
+	err    error
+}
+
+// spinnerTickMsg advances the loading spinner.
+type spinnerTickMsg struct{}
+
+func spinnerTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg { return spinnerTickMsg{} })
+}
+
+// DrivesModel lists the mounted volumes and lets the user pick one to
+// analyze.
+type DrivesModel struct {
+	drives   []Drive
+	err      error
+	loading  bool
+	cursor   int
+	selected string
+	width    int
+	frame    int
+}
+
+// NewDrivesModel creates a picker that reads the drive list on start.
+func NewDrivesModel() DrivesModel {
+	return DrivesModel{loading: true, width: 80}
+}
+
+// Selected returns the root of the chosen drive, or "" if the user quit.
+func (m DrivesModel) Selected() string {
+	return m.selected
+}
+
+// Init starts reading the drive list.
+func (m DrivesModel) Init() tea.Cmd {
+	return tea.Batch(func() tea.Msg {
+		drives, err := ListDrives()
+		return drivesLoadedMsg{drives: drives, err: err}
+	}, spinnerTick())
+}
+
+// Update handles navigation and selection.
+func (m DrivesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case drivesLoadedMsg:
+		m.loading = false
+		m.drives, m.err = msg.drives, msg.err
+		return m, nil
+
+	case spinnerTickMsg:
+		if !m.loading {
+			return m, nil
+		}
+		m.frame = (m.frame + 1) % len(ui.SpinnerFrames)
+		return m, spinnerTick()
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.drives)-1 {
+				m.cursor++
+			}
+		case "enter", "right", "l":
+			if m.cursor < len(m.drives) && m.drives[m.cursor].Ready {
+				m.selected = m.drives[m.cursor].Root
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the drive list.
+func (m DrivesModel) View() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(ui.ColorCoral).
+		Render("  " + ui.IconDiamond + " Drives"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading:
+		b.WriteString(ui.MutedStyle().Render(fmt.Sprintf("  %s Reading drives…", ui.SpinnerFrames[m.frame])))
+		b.WriteString("\n")
+		return b.String()
+	case m.err != nil:
+		b.WriteString(ui.ErrorStyle().Render("  Cannot list drives: " + m.err.Error()))
+		b.WriteString("\n")
+		return b.String()
+	case len(m.drives) == 0:
+		b.WriteString(ui.MutedStyle().Render("  No drives found."))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	barWidth := 24
+	if m.width > 110 {
+		barWidth = 34
+	}
+	for i, d := range m.drives {
+		b.WriteString(renderDrive(d, barWidth, i == m.cursor))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(ui.HintBarStyle().Render("  ↑↓ Navigate │ Enter Analyze │ Q Quit"))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderDrive renders one drive as two lines: usage bar and details.
+func renderDrive(d Drive, barWidth int, selected bool) string {
+	cursor := "  "
+	nameStyle := lipgloss.NewStyle().Foreground(clrDir).Bold(true)
+	if selected {
+		cursor = " " + lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+		nameStyle = nameStyle.Foreground(clrCursor)
+	}
+
+	name := d.Root
+	if d.Label != "" {
+		name += " " + d.Label
+	}
+	if len(name) > 24 {
+		name = name[:23] + "…"
+	}
+
+	if !d.Ready {
+		return fmt.Sprintf("%s %s  %s\n", cursor, nameStyle.Render(fmt.Sprintf("%-24s", name)),
+			ui.MutedStyle().Render(d.Type+", not ready"))
+	}
+
+	pct := d.UsedPercent()
+	usage := fmt.Sprintf("%5.1f%%  %s free of %s", pct, ui.FormatSize(d.Free), ui.FormatSize(d.Size))
+	if pct >= 90 {
+		usage = ui.WarningStyle().Render(usage)
+	}
+	line := fmt.Sprintf("%s %s  %s  %s", cursor, nameStyle.Render(fmt.Sprintf("%-24s", name)),
+		ui.GradientBar(pct, barWidth), usage)
+
+	details := []string{d.Type, d.FileSystem}
+	if d.Media != "" {
+		details = append(details, d.Media)
+	}
+	if d.BitLocker != "" {
+		details = append(details, "BitLocker "+d.BitLocker)
+	}
+	return line + "\n" + ui.MutedStyle().Render("     "+strings.Join(details, " · "))
+}
@@ -0,0 +1,150 @@
+package analyze
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Drives ──────────────────────────────────────────────────────────────────
+// `pw analyze --drives` starts from an overview of every mounted volume, so
+// the drive worth scanning can be picked instead of typed. Capacity, file
+// system and drive type come straight from the volume APIs; the media type
+// of the physical disk and BitLocker status need Storage and Shell queries,
+// run through one PowerShell call that is skipped if it fails or hangs.
+
+// driveDetailsTimeout bounds the PowerShell media and BitLocker query.
+const driveDetailsTimeout = 15 * time.Second
+
+// Drive is a mounted volume with a drive letter.
+type Drive struct {
+	Root       string `json:"root"` // e.g. `C:\`
+	Label      string `json:"label"`
+	FileSystem string `json:"file_system"`
+	Type       string `json:"type"`                // "Fixed", "Removable", "Network", "CD-ROM" or "RAM disk"
+	Media      string `json:"media,omitempty"`     // "SSD" or "HDD" when known
+	BitLocker  string `json:"bitlocker,omitempty"` // "On", "Off", "Locked", ... when known
+	Size       int64  `json:"size"`
+	Free       int64  `json:"free"`
+	Ready      bool   `json:"ready"` // false for empty card readers and disconnected shares
+}
+
+// Used returns the bytes in use on the drive.
+func (d Drive) Used() int64 {
+	return d.Size - d.Free
+}
+
+// UsedPercent returns how full the drive is, 0–100.
+func (d Drive) UsedPercent() float64 {
+	if d.Size <= 0 {
+		return 0
+	}
+	return float64(d.Used()) / float64(d.Size) * 100
+}
+
+// driveTypeNames names the GetDriveType results worth listing.
+var driveTypeNames = map[uint32]string{
+	windows.DRIVE_FIXED:     "Fixed",
+	windows.DRIVE_REMOVABLE: "Removable",
+	windows.DRIVE_REMOTE:    "Network",
+	windows.DRIVE_CDROM:     "CD-ROM",
+	windows.DRIVE_RAMDISK:   "RAM disk",
+}
+
+// ListDrives returns every lettered volume, in drive letter order.
+func ListDrives() ([]Drive, error) {
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return nil, err
+	}
+
+	var drives []Drive
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		root := string(rune('A'+i)) + `:\`
+		rootp, _ := windows.UTF16PtrFromString(root)
+		typ, ok := driveTypeNames[windows.GetDriveType(rootp)]
+		if !ok {
+			continue
+		}
+		d := Drive{Root: root, Type: typ}
+
+		label := make([]uint16, windows.MAX_PATH+1)
+		fs := make([]uint16, windows.MAX_PATH+1)
+		if windows.GetVolumeInformation(rootp, &label[0], uint32(len(label)), nil, nil, nil,
+			&fs[0], uint32(len(fs))) == nil {
+			d.Label = windows.UTF16ToString(label)
+			d.FileSystem = windows.UTF16ToString(fs)
+			d.Ready = true
+		}
+		var free, total, totalFree uint64
+		if windows.GetDiskFreeSpaceEx(rootp, &free, &total, &totalFree) == nil {
+			d.Size, d.Free = int64(total), int64(totalFree)
+		}
+		drives = append(drives, d)
+	}
+
+	addDriveDetails(drives)
+	return drives, nil
+}
+
+// driveDetailsQuery reports, per drive letter, the media type of the disk
+// holding it and the Shell's BitLocker protection value, as a JSON array.
+const driveDetailsQuery = `$disks = @{}
+Get-PhysicalDisk -ErrorAction SilentlyContinue | ForEach-Object { $disks[[string]$_.DeviceId] = [string]$_.MediaType }
+$shell = New-Object -ComObject Shell.Application
+$out = @(Get-Partition -ErrorAction SilentlyContinue | Where-Object { $_.DriveLetter } | ForEach-Object {
+  $bl = $shell.NameSpace(17).ParseName("$($_.DriveLetter):").ExtendedProperty('System.Volume.BitLockerProtection')
+  [pscustomobject]@{ Drive = "$($_.DriveLetter):"; Media = [string]$disks[[string]$_.DiskNumber]; BitLocker = [int]$bl }
+})
+ConvertTo-Json -InputObject $out -Compress`
+
+// bitLockerStates maps System.Volume.BitLockerProtection values to a
+// status. 0 means the volume cannot be encrypted and is left blank.
+var bitLockerStates = map[int]string{
+	1: "On",
+	2: "Off",
+	3: "Encrypting",
+	4: "Decrypting",
+	5: "Suspended",
+	6: "Locked",
+	8: "Waiting",
+}
+
+// addDriveDetails fills in media type and BitLocker status where the
+// query can tell them.
+func addDriveDetails(drives []Drive) {
+	ctx, cancel := context.WithTimeout(context.Background(), driveDetailsTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-NonInteractive", "-Command", driveDetailsQuery).Output()
+	if err != nil {
+		return
+	}
+	var details []struct {
+		Drive     string
+		Media     string
+		BitLocker int
+	}
+	if json.Unmarshal(bytes.TrimSpace(out), &details) != nil {
+		return
+	}
+	for _, det := range details {
+		for i := range drives {
+			if !strings.EqualFold(strings.TrimSuffix(drives[i].Root, `\`), det.Drive) {
+				continue
+			}
+			if det.Media == "SSD" || det.Media == "HDD" {
+				drives[i].Media = det.Media
+			}
+			drives[i].BitLocker = bitLockerStates[det.BitLocker]
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package analyze
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── Export ──────────────────────────────────────────────────────────────────
+// `pw analyze --export` writes a scan to a file so it can be archived,
+// diffed or opened elsewhere: a native JSON tree, a flat CSV listing of
+// every file and folder, or the ncdu export format (ncdu -f reads it).
+
+// Export formats, chosen with --export-format or from the file extension.
+const (
+	ExportJSON = "json"
+	ExportCSV  = "csv"
+	ExportNcdu = "ncdu"
+)
+
+// ExportMeta describes the scan an export was made from.
+type ExportMeta struct {
+	Tool      string    `json:"tool"`
+	Version   string    `json:"version"`
+	ScannedAt time.Time `json:"scanned_at"`
+	Root      string    `json:"root"`
+	Excluded  []string  `json:"excluded"`
+}
+
+// exportDocument is the native JSON export: metadata and the full tree.
+type exportDocument struct {
+	ExportMeta
+	Tree *DirEntry `json:"tree"`
+}
+
+// ExportFormatFor returns the export format implied by a file name:
+// ".csv" is CSV, ".ncdu" is ncdu and anything else is native JSON.
+func ExportFormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ExportCSV
+	case ".ncdu":
+		return ExportNcdu
+	}
+	return ExportJSON
+}
+
+// WriteExport writes root to w in the given format.
+func WriteExport(w io.Writer, root *DirEntry, meta ExportMeta, format string) error {
+	if meta.Excluded == nil {
+		meta.Excluded = []string{}
+	}
+	switch format {
+	case ExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exportDocument{ExportMeta: meta, Tree: root})
+	case ExportCSV:
+		return writeExportCSV(w, root, meta)
+	case ExportNcdu:
+		return writeExportNcdu(w, root, meta)
+	}
+	return fmt.Errorf("unknown export format %q (want json, csv or ncdu)", format)
+}
+
+// writeExportCSV lists every node under root, one row each, after comment
+// lines carrying the metadata.
+func writeExportCSV(w io.Writer, root *DirEntry, meta ExportMeta) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s %s export of %s\n", meta.Tool, meta.Version, meta.Root)
+	fmt.Fprintf(bw, "# scanned_at: %s\n", meta.ScannedAt.Format(time.RFC3339))
+	fmt.Fprintf(bw, "# excluded: %s\n", strings.Join(meta.Excluded, ";"))
+
+	cw := csv.NewWriter(bw)
+	if err := cw.Write([]string{"path", "type", "size", "disk_size", "mod_time", "depth", "link"}); err != nil {
+		return err
+	}
+	var walk func(e *DirEntry, depth int) error
+	walk = func(e *DirEntry, depth int) error {
+		kind := "file"
+		if e.IsDir {
+			kind = "dir"
+		}
+		row := []string{e.Path, kind, strconv.FormatInt(e.Size, 10), strconv.FormatInt(e.DiskSize, 10),
+			e.ModTime.Format(time.RFC3339), strconv.Itoa(depth), e.Link}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+		for _, c := range e.Children {
+			if err := walk(c, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root, 0); err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// ncduInfo is one entry of an ncdu export. Folders carry only their name;
+// ncdu adds up their contents itself.
+type ncduInfo struct {
+	Name  string `json:"name"`
+	Asize int64  `json:"asize,omitempty"`
+	Dsize int64  `json:"dsize,omitempty"`
+	Mtime int64  `json:"mtime,omitempty"`
+}
+
+// writeExportNcdu writes the ncdu JSON export format (major version 1,
+// minor 2): [1, 2, {header}, [{root}, file, [{dir}, ...], ...]].
+func writeExportNcdu(w io.Writer, root *DirEntry, meta ExportMeta) error {
+	bw := bufio.NewWriter(w)
+	header, err := json.Marshal(map[string]any{
+		"progname":  meta.Tool,
+		"progver":   meta.Version,
+		"timestamp": meta.ScannedAt.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(bw, "[1,2,%s,\n", header)
+
+	var write func(e *DirEntry, name string) error
+	write = func(e *DirEntry, name string) error {
+		info := ncduInfo{Name: name}
+		if !e.ModTime.IsZero() {
+			info.Mtime = e.ModTime.Unix()
+		}
+		if !e.IsDir {
+			info.Asize, info.Dsize = e.Size, e.DiskSize
+			if e.DiskSize == 0 {
+				info.Dsize = e.Size // scanned before disk sizes were recorded
+			}
+		}
+		data, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		if !e.IsDir {
+			_, err = bw.Write(data)
+			return err
+		}
+		bw.WriteByte('[')
+		bw.Write(data)
+		for _, c := range e.Children {
+			bw.WriteString(",\n")
+			if err := write(c, c.Name); err != nil {
+				return err
+			}
+		}
+		_, err = bw.WriteString("]")
+		return err
+	}
+	// The root entry is named with its full path, as ncdu itself does.
+	if err := write(root, root.Path); err != nil {
+		return err
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
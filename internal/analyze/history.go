@@ -0,0 +1,287 @@
+package analyze
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+const (
+	historyDirName   = "history"
+	scheduleFileName = "analyze_schedule.json"
+
+	// historyMaxChildren caps the per-sample breakdown to the largest
+	// entries so history files stay small on wide directories.
+	historyMaxChildren = 200
+)
+
+// ─── Scan History ────────────────────────────────────────────────────────────
+
+// HistorySample is one recorded scan of a root: its total size and the
+// sizes of its immediate children.
+type HistorySample struct {
+	Timestamp time.Time        `json:"timestamp"`
+	RootPath  string           `json:"root_path"`
+	Size      int64            `json:"size"`
+	Children  map[string]int64 `json:"children"`
+}
+
+// historyPath returns the JSON-lines history file for a root. The name is
+// the sanitized path, cut to 80 characters, plus a hash of the full path so
+// long paths sharing a prefix get files of their own. A file kept under the
+// older unhashed name is moved over on first use.
+func historyPath(rootPath string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, historyDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	key := strings.ToLower(rootPath)
+	safe := strings.NewReplacer(`\`, "_", `/`, "_", `:`, "").Replace(key)
+	if len(safe) > 80 {
+		safe = safe[:80]
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	path := filepath.Join(dir, fmt.Sprintf("%s-%08x.jsonl", safe, h.Sum32()))
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		_ = os.Rename(filepath.Join(dir, safe+".jsonl"), path)
+	}
+	return path, nil
+}
+
+// RecordHistory appends a sample for the scanned root to its history file.
+func RecordHistory(root *DirEntry) error {
+	path, err := historyPath(root.Path)
+	if err != nil {
+		return err
+	}
+
+	children := root.Children
+	if len(children) > historyMaxChildren {
+		children = children[:historyMaxChildren] // Already sorted by size.
+	}
+	sample := HistorySample{
+		Timestamp: time.Now(),
+		RootPath:  root.Path,
+		Size:      root.Size,
+		Children:  make(map[string]int64, len(children)),
+	}
+	for _, c := range children {
+		sample.Children[c.Name] = c.Size
+	}
+
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// HasHistory reports whether any samples exist for the root.
+func HasHistory(rootPath string) bool {
+	path, err := historyPath(rootPath)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// LoadHistory reads all samples for a root, oldest first. Malformed lines,
+// and samples of other roots left in a file from before names were hashed,
+// are skipped.
+func LoadHistory(rootPath string) ([]HistorySample, error) {
+	path, err := historyPath(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var samples []HistorySample
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var s HistorySample
+		if json.Unmarshal(sc.Bytes(), &s) != nil {
+			continue
+		}
+		if s.RootPath != "" && !strings.EqualFold(filepath.Clean(s.RootPath), filepath.Clean(rootPath)) {
+			continue
+		}
+		samples = append(samples, s)
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return samples, sc.Err()
+}
+
+// ─── Trends ──────────────────────────────────────────────────────────────────
+
+// WeeklyPoint is the last recorded size within a calendar week.
+type WeeklyPoint struct {
+	WeekStart time.Time
+	Size      int64
+}
+
+// Grower is a child directory whose size changed over the trend window.
+type Grower struct {
+	Name    string
+	First   int64
+	Last    int64
+	Delta   int64
+	PerWeek int64
+}
+
+// Trend summarizes growth of a root over a time window.
+type Trend struct {
+	RootPath string
+	Weeks    []WeeklyPoint
+	Growers  []Grower
+	First    HistorySample
+	Last     HistorySample
+}
+
+// ComputeTrend buckets samples newer than since into weeks and ranks the
+// children by absolute growth between the first and last sample.
+func ComputeTrend(samples []HistorySample, since time.Time) Trend {
+	var window []HistorySample
+	for _, s := range samples {
+		if !s.Timestamp.Before(since) {
+			window = append(window, s)
+		}
+	}
+	if len(window) == 0 {
+		return Trend{}
+	}
+
+	t := Trend{
+		RootPath: window[0].RootPath,
+		First:    window[0],
+		Last:     window[len(window)-1],
+	}
+
+	for _, s := range window {
+		week := weekStart(s.Timestamp)
+		if n := len(t.Weeks); n > 0 && t.Weeks[n-1].WeekStart.Equal(week) {
+			t.Weeks[n-1].Size = s.Size
+			continue
+		}
+		t.Weeks = append(t.Weeks, WeeklyPoint{WeekStart: week, Size: s.Size})
+	}
+
+	weeks := t.Last.Timestamp.Sub(t.First.Timestamp).Hours() / (24 * 7)
+	names := make(map[string]bool)
+	for name := range t.First.Children {
+		names[name] = true
+	}
+	for name := range t.Last.Children {
+		names[name] = true
+	}
+	for name := range names {
+		g := Grower{
+			Name:  name,
+			First: t.First.Children[name],
+			Last:  t.Last.Children[name],
+		}
+		g.Delta = g.Last - g.First
+		if g.Delta == 0 {
+			continue
+		}
+		if weeks >= 1 {
+			g.PerWeek = int64(float64(g.Delta) / weeks)
+		}
+		t.Growers = append(t.Growers, g)
+	}
+	sort.Slice(t.Growers, func(i, j int) bool {
+		return t.Growers[i].Delta > t.Growers[j].Delta
+	})
+	return t
+}
+
+// weekStart returns midnight on the Monday of t's week.
+func weekStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// ─── Schedule ────────────────────────────────────────────────────────────────
+
+// ScheduleTaskName is the Task Scheduler task that records trend samples.
+const ScheduleTaskName = "AnalyzeScan"
+
+// Schedule lists the roots scanned by the background analyze task.
+type Schedule struct {
+	Roots     []string `json:"roots"`
+	Frequency string   `json:"frequency"`
+	StartTime string   `json:"start_time"`
+}
+
+// Task returns the Task Scheduler definition that runs this schedule.
+func (s *Schedule) Task() core.ScheduledTask {
+	return core.ScheduledTask{
+		Name:      ScheduleTaskName,
+		Args:      []string{"analyze", "record"},
+		Frequency: s.Frequency,
+		StartTime: s.StartTime,
+	}
+}
+
+// LoadSchedule reads the saved schedule. A missing file yields an empty one.
+func LoadSchedule() (*Schedule, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, scheduleFileName))
+	if os.IsNotExist(err) {
+		return &Schedule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := &Schedule{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SaveSchedule persists the schedule.
+func SaveSchedule(s *Schedule) error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, scheduleFileName), data, 0o644)
+}
@@ -0,0 +1,149 @@
+package analyze
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+)
+
+// ─── Junk Highlighting ───────────────────────────────────────────────────────
+// The analyzer reuses the `pw clean <path>` classification (temp files,
+// build output, caches, ...) so junk can be spotted while browsing and
+// handed to the clean selection flow without a second scan.
+
+// classifyFunc returns the junk category and label of one node.
+type classifyFunc func(e *DirEntry) (category, label string, ok bool)
+
+// MarkJunk classifies every node below root and records how much junk lies
+// under each directory. A matched directory counts as junk in full; its
+// children are not classified separately, mirroring ScanPath. The root
+// itself is never marked.
+func MarkJunk(root *DirEntry) {
+	markJunk(root, func(e *DirEntry) (string, string, bool) {
+		return clean.ClassifyJunk(e.Path, e.IsDir)
+	})
+}
+
+// markJunk is MarkJunk with the classification supplied by classify.
+func markJunk(root *DirEntry, classify classifyFunc) {
+	if root == nil {
+		return
+	}
+	root.JunkCategory, root.JunkLabel = "", ""
+	root.JunkSize = markJunkChildren(root, classify)
+}
+
+// markJunkChildren classifies e's children and returns their junk total.
+func markJunkChildren(e *DirEntry, classify classifyFunc) int64 {
+	var total int64
+	for _, c := range e.Children {
+		c.JunkCategory, c.JunkLabel, c.JunkSize = "", "", 0
+		if cat, label, ok := classify(c); ok {
+			c.JunkCategory, c.JunkLabel, c.JunkSize = cat, label, c.Size
+		} else if c.IsDir {
+			c.JunkSize = markJunkChildren(c, classify)
+		}
+		total += c.JunkSize
+	}
+	return total
+}
+
+// ─── Background Classification ───────────────────────────────────────────────
+// Classifying a large tree takes a while, so the analyzer does it in a
+// tea.Cmd. The command only sees paths listed up front; the nodes
+// themselves are updated on the UI goroutine when junkMarkedMsg arrives,
+// so browsing and deleting meanwhile is safe.
+
+// junkMark is the classification of one node.
+type junkMark struct {
+	category, label string
+}
+
+// junkMarkedMsg carries the junk found under root by markJunkCmd.
+type junkMarkedMsg struct {
+	root  *DirEntry
+	marks map[*DirEntry]junkMark
+}
+
+// junkCandidate is a node queued for classification. end is the index just
+// past its subtree, so a matched folder's contents can be skipped.
+type junkCandidate struct {
+	entry *DirEntry
+	path  string
+	isDir bool
+	end   int
+}
+
+// markJunkCmd classifies the tree under root in the background.
+func markJunkCmd(root *DirEntry) tea.Cmd {
+	if root == nil {
+		return nil
+	}
+	nodes := junkCandidates(root)
+	return func() tea.Msg {
+		marks := make(map[*DirEntry]junkMark)
+		for i := 0; i < len(nodes); i++ {
+			n := nodes[i]
+			if cat, label, ok := clean.ClassifyJunk(n.path, n.isDir); ok {
+				marks[n.entry] = junkMark{cat, label}
+				i = n.end - 1
+			}
+		}
+		return junkMarkedMsg{root: root, marks: marks}
+	}
+}
+
+// junkCandidates lists every node below root in tree order.
+func junkCandidates(root *DirEntry) []junkCandidate {
+	var nodes []junkCandidate
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		for _, c := range e.Children {
+			i := len(nodes)
+			nodes = append(nodes, junkCandidate{entry: c, path: c.Path, isDir: c.IsDir})
+			if c.IsDir {
+				walk(c)
+			}
+			nodes[i].end = len(nodes)
+		}
+	}
+	walk(root)
+	return nodes
+}
+
+// applyJunk records the classification in msg on the tree. Nodes removed
+// since it was computed are simply no longer reached.
+func applyJunk(msg junkMarkedMsg) {
+	markJunk(msg.root, func(e *DirEntry) (string, string, bool) {
+		mark, ok := msg.marks[e]
+		return mark.category, mark.label, ok
+	})
+}
+
+// IsJunk reports whether the entry itself matched a junk category.
+func (e *DirEntry) IsJunk() bool {
+	return e.JunkCategory != ""
+}
+
+// JunkItems returns every junk node at or under e as clean items, ready for
+// the clean selection flow.
+func JunkItems(e *DirEntry) []clean.CleanItem {
+	if e == nil {
+		return nil
+	}
+	if e.IsJunk() {
+		return []clean.CleanItem{{
+			Path:        e.Path,
+			Size:        e.Size,
+			Category:    e.JunkCategory,
+			Description: e.JunkLabel,
+		}}
+	}
+	var items []clean.CleanItem
+	for _, c := range e.Children {
+		if c.JunkSize > 0 || c.IsJunk() {
+			items = append(items, JunkItems(c)...)
+		}
+	}
+	return items
+}
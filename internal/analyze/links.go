@@ -0,0 +1,165 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Links and On-Disk Size ──────────────────────────────────────────────────
+// A folder's size should be what deleting it would free. Junctions and
+// symbolic links point at data counted elsewhere (or at a parent, which
+// would loop forever), so they are listed but never followed and count as
+// nothing. A file with several hard links is one file under several names:
+// the first name the scan meets counts, the others show as links of size
+// zero. Alongside the apparent size, each node carries its size on disk —
+// whole clusters, or what is actually allocated for NTFS-compressed and
+// sparse files — since the two can differ a lot.
+
+// Kinds of link, in DirEntry.Link.
+const (
+	LinkJunction = "junction"
+	LinkSymlink  = "symlink"
+	LinkHardlink = "hard link"
+)
+
+const (
+	ioReparseTagMountPoint = 0xA0000003
+	ioReparseTagSymlink    = 0xA000000C
+	invalidFileSize        = 0xFFFFFFFF
+
+	// hardLinkMinSize is the smallest file checked for other hard links.
+	// The check opens the file, and below this size the few bytes a
+	// duplicate adds are not worth doubling the scan time.
+	hardLinkMinSize = 64 << 10
+)
+
+var (
+	procGetDiskFreeSpace       = modKernel32.NewProc("GetDiskFreeSpaceW")
+	procGetCompressedFileSizeW = modKernel32.NewProc("GetCompressedFileSizeW")
+)
+
+// fileKey identifies a file on a volume, whichever name it is opened by.
+type fileKey struct {
+	volume uint32
+	index  uint64
+}
+
+// fileAttributes returns the Windows attributes recorded in info.
+func fileAttributes(info os.FileInfo) uint32 {
+	if d, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return d.FileAttributes
+	}
+	return 0
+}
+
+// linkKind returns LinkJunction or LinkSymlink for a reparse point that
+// redirects elsewhere, or "" for other reparse points (cloud placeholders,
+// deduplicated files), which hold data of their own.
+func linkKind(path string) string {
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return ""
+	}
+	var fd windows.Win32finddata
+	h, err := windows.FindFirstFile(p, &fd)
+	if err != nil {
+		return LinkJunction // unreadable: do not risk following it
+	}
+	windows.FindClose(h)
+	switch fd.Reserved0 { // the reparse tag
+	case ioReparseTagMountPoint:
+		return LinkJunction
+	case ioReparseTagSymlink:
+		return LinkSymlink
+	}
+	return ""
+}
+
+// hardLinkKey returns the identity of a file that has more than one name.
+func hardLinkKey(path string) (fileKey, bool) {
+	p, err := windows.UTF16PtrFromString(longPath(path))
+	if err != nil {
+		return fileKey{}, false
+	}
+	h, err := windows.CreateFile(p, 0,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, windows.FILE_FLAG_OPEN_REPARSE_POINT, 0)
+	if err != nil {
+		return fileKey{}, false
+	}
+	defer windows.CloseHandle(h)
+	var fi windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(h, &fi); err != nil || fi.NumberOfLinks < 2 {
+		return fileKey{}, false
+	}
+	return fileKey{fi.VolumeSerialNumber, uint64(fi.FileIndexHigh)<<32 | uint64(fi.FileIndexLow)}, true
+}
+
+// firstLink records a hard-linked file and reports whether this is the
+// first of its names the scan has seen.
+func (s *Scanner) firstLink(key fileKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.links == nil {
+		s.links = map[fileKey]bool{}
+	}
+	if s.links[key] {
+		return false
+	}
+	s.links[key] = true
+	return true
+}
+
+// diskSize returns the space a file of the given size and attributes takes
+// on disk.
+func (s *Scanner) diskSize(path string, size int64, attrs uint32) int64 {
+	if attrs&(windows.FILE_ATTRIBUTE_COMPRESSED|windows.FILE_ATTRIBUTE_SPARSE_FILE) != 0 {
+		if n, ok := compressedFileSize(path); ok {
+			return n
+		}
+	}
+	if s.clusterSize <= 0 {
+		return size
+	}
+	return (size + s.clusterSize - 1) / s.clusterSize * s.clusterSize
+}
+
+// compressedFileSize returns the bytes allocated to a compressed or sparse
+// file.
+func compressedFileSize(path string) (int64, bool) {
+	p, err := windows.UTF16PtrFromString(longPath(path))
+	if err != nil {
+		return 0, false
+	}
+	var high uint32
+	low, _, callErr := procGetCompressedFileSizeW.Call(uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&high)))
+	if uint32(low) == invalidFileSize && callErr != windows.ERROR_SUCCESS {
+		return 0, false
+	}
+	return int64(high)<<32 | int64(uint32(low)), true
+}
+
+// volumeClusterSize returns the allocation unit of the volume holding path,
+// or 0 when it cannot be read.
+func volumeClusterSize(path string) int64 {
+	vol := filepath.VolumeName(path)
+	if vol == "" {
+		return 0
+	}
+	p, err := windows.UTF16PtrFromString(vol + `\`)
+	if err != nil {
+		return 0
+	}
+	var sectorsPerCluster, bytesPerSector, freeClusters, totalClusters uint32
+	r, _, _ := procGetDiskFreeSpace.Call(uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(&sectorsPerCluster)), uintptr(unsafe.Pointer(&bytesPerSector)),
+		uintptr(unsafe.Pointer(&freeClusters)), uintptr(unsafe.Pointer(&totalClusters)))
+	if r == 0 {
+		return 0
+	}
+	return int64(sectorsPerCluster) * int64(bytesPerSector)
+}
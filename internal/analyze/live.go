@@ -0,0 +1,182 @@
+package analyze
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Live Scan ───────────────────────────────────────────────────────────────
+// The analyzer can open before its scan is done. Top-level folders appear
+// as the scanner completes them and can be browsed straight away, while the
+// header shows the running totals and time estimate. Deleting, moving and
+// the junk hand-off wait for the scan to finish; the finished tree then
+// replaces the partial one.
+
+// liveScanInterval is how often the partial tree and progress redraw.
+const liveScanInterval = 200 * time.Millisecond
+
+type liveScanTickMsg struct{}
+
+// liveScanDoneMsg carries the finished scan.
+type liveScanDoneMsg struct {
+	root *DirEntry
+	err  error
+}
+
+// liveFeed collects completed branches from scanning goroutines until the
+// model picks them up on its next tick.
+type liveFeed struct {
+	mu      sync.Mutex
+	pending []*DirEntry
+}
+
+func (f *liveFeed) push(e *DirEntry) {
+	f.mu.Lock()
+	f.pending = append(f.pending, e)
+	f.mu.Unlock()
+}
+
+func (f *liveFeed) take() []*DirEntry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := f.pending
+	f.pending = nil
+	return out
+}
+
+// NewScanningAnalyzeModel creates an analyzer that scans path with scanner
+// when started, showing completed folders as they arrive. The finished
+// scan is cached, as a scan run before opening the analyzer would be.
+func NewScanningAnalyzeModel(path string, scanner *Scanner) AnalyzeModel {
+	clean := filepath.Clean(path)
+	m := NewAnalyzeModel(&DirEntry{Path: clean, Name: filepath.Base(clean), IsDir: true})
+	m.scanner = scanner
+	m.scanPath = path
+	m.feed = &liveFeed{}
+	scanner.OnBranch(m.feed.push)
+	return m
+}
+
+// Scanning reports whether the analyzer is still waiting for its scan.
+func (m AnalyzeModel) Scanning() bool {
+	return m.scanner != nil
+}
+
+// startLiveScan runs the scan in the background.
+func (m AnalyzeModel) startLiveScan() tea.Cmd {
+	scanner, path := m.scanner, m.scanPath
+	scan := func() tea.Msg {
+		root, err := scanner.Scan(path)
+		if err == nil {
+			_ = SaveCache(root, path)
+			if HasHistory(root.Path) {
+				_ = RecordHistory(root)
+			}
+		}
+		return liveScanDoneMsg{root: root, err: err}
+	}
+	return tea.Batch(scan, liveScanTick())
+}
+
+func liveScanTick() tea.Cmd {
+	return tea.Tick(liveScanInterval, func(time.Time) tea.Msg { return liveScanTickMsg{} })
+}
+
+// addBranches adds the folders completed since the last tick to the
+// partial tree.
+func (m *AnalyzeModel) addBranches() {
+	branches := m.feed.take()
+	if len(branches) == 0 {
+		return
+	}
+	for _, b := range branches {
+		m.root.Children = append(m.root.Children, b)
+		m.root.Size += b.Size
+		m.root.DiskSize += b.DiskSize
+	}
+	sort.SliceStable(m.root.Children, func(i, j int) bool {
+		return m.root.Children[i].Size > m.root.Children[j].Size
+	})
+	m.runSearch()
+}
+
+// finishLiveScan swaps the partial tree for the finished one. Branches
+// already shown are the same nodes in both, so browsing carries on where
+// it was. It returns the command that marks junk in the finished tree.
+func (m *AnalyzeModel) finishLiveScan(msg liveScanDoneMsg) tea.Cmd {
+	m.scanner, m.feed = nil, nil
+	if msg.err != nil {
+		m.err = msg.err
+		return nil
+	}
+	partial := m.root
+	m.root = msg.root
+	if m.current == partial {
+		m.current = m.root
+	}
+	for i, bc := range m.breadcrumb {
+		if bc == partial {
+			m.breadcrumb[i] = m.root
+		}
+	}
+	m.top, m.types, m.stale = nil, nil, nil
+	m.runSearch()
+	if items := m.visibleItems(); m.cursor >= len(items) {
+		m.cursor = max(len(items)-1, 0)
+	}
+	m.ensureVisible()
+	return markJunkCmd(m.root)
+}
+
+// locked reports whether changes to the tree are disabled: for previews,
+// and until a live scan finishes.
+func (m AnalyzeModel) locked() bool {
+	return m.ReadOnly || m.scanner != nil
+}
+
+// renderScanProgress renders the header line of a running scan.
+func (m AnalyzeModel) renderScanProgress() string {
+	p := m.scanner.Progress()
+	parts := []string{
+		fmt.Sprintf("%s Scanning %s", ui.SpinnerFrames[m.frame], m.root.Path),
+		ui.FormatSize(p.Bytes) + " so far",
+		fmt.Sprintf("%d files", p.Files),
+		fmt.Sprintf("%.0f files/s", p.Rate),
+	}
+	switch {
+	case p.ETA > 0:
+		parts = append(parts, "about "+formatETA(p.ETA)+" left")
+	case p.Expected > 0 && p.Bytes >= p.Expected:
+		parts = append(parts, "almost done")
+	}
+	return "  " + strings.Join(parts, " · ")
+}
+
+// formatETA renders a time estimate: "45s", "3m20s", "1h05m".
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// FormatScanProgress renders a running scan's progress on one line, for
+// spinners outside the analyzer.
+func FormatScanProgress(p ScanProgress) string {
+	s := fmt.Sprintf("%d entries · %s · %.0f files/s", p.Entries, ui.FormatSize(p.Bytes), p.Rate)
+	if p.ETA > 0 {
+		s += " · about " + formatETA(p.ETA) + " left"
+	}
+	return s
+}
@@ -0,0 +1,96 @@
+package analyze
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Owner and Last Access ───────────────────────────────────────────────────
+// Pressing i shows who owns each entry and when it was last opened; O keeps
+// only entries whose owner matches. Both are read when an entry is first
+// shown rather than during the scan, which would slow it down for every
+// file on the drive: the rows on screen, or all entries of the list while
+// filtering. Windows stops updating last-access times on some volumes
+// (NtfsDisableLastAccessUpdate), in which case they show the creation time
+// or the last time the setting allowed an update.
+
+// EntryMeta is the metadata read on demand for one entry.
+type EntryMeta struct {
+	Owner    string    // DOMAIN\user, or "" when unreadable
+	Accessed time.Time // last access; zero when unreadable
+}
+
+// metaLoadedMsg carries metadata read in the background.
+type metaLoadedMsg struct {
+	metas map[*DirEntry]EntryMeta
+}
+
+// ReadEntryMeta reads the owner and last-access time of path.
+func ReadEntryMeta(path string) EntryMeta {
+	var meta EntryMeta
+	if !filepath.IsAbs(path) {
+		return meta // synthetic preview node
+	}
+	meta.Owner = FileOwner(path)
+	if info, err := os.Lstat(path); err == nil {
+		if d, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+			meta.Accessed = time.Unix(0, d.LastAccessTime.Nanoseconds())
+		}
+	}
+	return meta
+}
+
+// loadMeta reads the metadata of entries in the background.
+func loadMeta(entries []*DirEntry) tea.Cmd {
+	return func() tea.Msg {
+		metas := make(map[*DirEntry]EntryMeta, len(entries))
+		for _, e := range entries {
+			metas[e] = ReadEntryMeta(e.Path)
+		}
+		return metaLoadedMsg{metas: metas}
+	}
+}
+
+// fetchMeta starts reading the metadata the view is about to need: every
+// entry of the list while filtering by owner, otherwise the rows on screen
+// when the columns are shown. Entries already read or being read are
+// skipped.
+func (m AnalyzeModel) fetchMeta() tea.Cmd {
+	if m.quitting || (!m.showMeta && m.ownerFilter == "") {
+		return nil
+	}
+	var want []*DirEntry
+	if m.ownerFilter != "" {
+		want = m.listItems()
+	} else {
+		items := m.visibleItems()
+		if m.offset < len(items) {
+			want = items[m.offset:min(m.offset+m.viewportHeight(), len(items))]
+		}
+	}
+
+	var missing []*DirEntry
+	for _, e := range want {
+		if _, ok := m.meta[e]; ok || m.metaPending[e] {
+			continue
+		}
+		m.metaPending[e] = true
+		missing = append(missing, e)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return loadMeta(missing)
+}
+
+// ownerMatches reports whether e's owner contains the owner filter, ignoring
+// case. Entries not read yet do not match until they are.
+func (m AnalyzeModel) ownerMatches(e *DirEntry) bool {
+	meta, ok := m.meta[e]
+	return ok && strings.Contains(strings.ToLower(meta.Owner), strings.ToLower(m.ownerFilter))
+}
@@ -1,61 +1,177 @@
 package analyze
 
 import (
+	"fmt"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/clean"
 	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
 )
 
 // ─── Messages ────────────────────────────────────────────────────────────────
 
 type deleteResultMsg struct {
-	path  string
-	freed int64
-	err   error
+	path   string
+	freed  int64
+	dryRun bool
+	err    error
 }
 
-func deleteEntry(entry *DirEntry) tea.Cmd {
+func deleteEntry(entry *DirEntry, dryRun bool, isWhitelisted func(string) bool) tea.Cmd {
 	return func() tea.Msg {
-		freed, err := core.SafeDelete(entry.Path, false)
-		return deleteResultMsg{path: entry.Path, freed: freed, err: err}
+		freed, err := core.SafeDeleteWithWhitelist(entry.Path, dryRun, isWhitelisted)
+		return deleteResultMsg{path: entry.Path, freed: freed, dryRun: dryRun, err: err}
 	}
 }
 
+type moveResultMsg struct {
+	path   string
+	dest   string
+	moved  int64
+	dryRun bool
+	err    error
+}
+
+func moveEntry(entry *DirEntry, dest string, dryRun bool, isWhitelisted func(string) bool) tea.Cmd {
+	return func() tea.Msg {
+		moved, err := core.SafeMove(entry.Path, dest, dryRun, isWhitelisted)
+		return moveResultMsg{path: entry.Path, dest: dest, moved: moved, dryRun: dryRun, err: err}
+	}
+}
+
+// ─── Tab enumeration ─────────────────────────────────────────────────────────
+
+// Tab identifies one of the analyzer's views.
+type Tab int
+
+const (
+	TabTree Tab = iota
+	TabTopFiles
+	TabTypes
+	TabStale
+)
+
+// TabNames is the display label for each tab.
+var TabNames = []string{"Tree", "Top files", "Types", "Stale"}
+
 // ─── Model ───────────────────────────────────────────────────────────────────
 
 // AnalyzeModel is the bubbletea Model for the disk analyzer TUI.
 type AnalyzeModel struct {
+	// DryRun reports what a delete would free instead of deleting.
+	DryRun bool
+
+	// ReadOnly disables delete and the junk hand-off, for previews.
+	ReadOnly bool
+
+	// Title replaces "Disk Analyzer" in the header when set.
+	Title string
+
+	// TopN is the length of the Top files list; 0 means DefaultTopFiles.
+	TopN int
+
+	// StaleAge is the Stale tab's threshold; 0 means DefaultStaleAge.
+	StaleAge time.Duration
+
+	// IsWhitelisted, when set, protects matching paths from delete and move.
+	IsWhitelisted func(string) bool
+
 	root          *DirEntry
 	current       *DirEntry   // directory being displayed
 	cursor        int         // selected item index
 	breadcrumb    []*DirEntry // navigation history stack
 	width         int
 	height        int
-	offset        int  // viewport scroll offset
-	largeOnly     bool // filter: show only >100MB
-	confirmDelete bool // two-key delete: Backspace then Enter
+	offset        int                     // viewport scroll offset
+	largeOnly     bool                    // filter: show only >100MB
+	treemap       bool                    // view mode: treemap instead of the list
+	tab           Tab                     // active view
+	top           []TopFile               // largest files, built when the tab is first shown
+	types         *TypeBreakdown          // sizes by file type, built when the tab is first shown
+	stale         []StaleDir              // folders untouched for StaleAge, built when the tab is first shown
+	confirmDelete bool                    // two-key delete: Backspace then Enter
+	moving        bool                    // typing the destination of a move
+	moveInput     textinput.Model         // move destination prompt
+	showMeta      bool                    // owner and last-access columns shown
+	meta          map[*DirEntry]EntryMeta // owner and last access, read on demand
+	metaPending   map[*DirEntry]bool      // metadata being read
+	ownerFilter   string                  // keep only entries whose owner contains this
+	filtering     bool                    // typing the owner filter
+	ownerInput    textinput.Model         // owner filter prompt
+	searching     bool                    // typing a search
+	searchInput   textinput.Model         // search prompt
+	search        *Search                 // active search; nil shows everything
+	matches       []*DirEntry             // entries matching the search, in tree order
+	matchIdx      int                     // match last jumped to
+	hits          map[*DirEntry]bool      // matches (true) and the folders leading to them (false)
+	archive       *ArchiveListing         // contents of the archive being inspected
+	archiveLoad   string                  // archive being listed
+	archiveOffset int                     // scroll offset of the archive contents
+	junkRequest   []clean.CleanItem       // junk handed to the clean selection flow on quit
 	quitting      bool
+	notice        string // one-off status line, e.g. a dry-run result
 	err           error
+
+	// Live scan (see live.go): set until the scan finishes.
+	scanner  *Scanner
+	feed     *liveFeed
+	scanPath string // as given, the key of the scan cache
+	frame    int    // spinner frame
 }
 
 // NewAnalyzeModel creates an AnalyzeModel rooted at the given scan result.
+// Junk nodes are marked for highlighting in the background once Init runs.
 func NewAnalyzeModel(root *DirEntry) AnalyzeModel {
 	return AnalyzeModel{
-		root:    root,
-		current: root,
-		width:   80,
-		height:  24,
+		root:        root,
+		current:     root,
+		width:       80,
+		height:      24,
+		moveInput:   newPrompt(`D:\Archive`, 512),
+		meta:        map[*DirEntry]EntryMeta{},
+		metaPending: map[*DirEntry]bool{},
+		ownerInput:  newPrompt(`CONTOSO\jdoe`, 256),
+		searchInput: newPrompt(`*.vhdx >10GB`, 256),
 	}
 }
 
+// newPrompt returns a one-line text prompt for the footer.
+func newPrompt(placeholder string, limit int) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Prompt = ""
+	ti.CharLimit = limit
+	return ti
+}
+
 func (m AnalyzeModel) Init() tea.Cmd {
-	return nil
+	if m.scanner != nil {
+		return m.startLiveScan()
+	}
+	if m.ReadOnly {
+		return nil
+	}
+	return markJunkCmd(m.root)
 }
 
 func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	m = updated.(AnalyzeModel)
+	// Read the owner and last access of entries that just came into view.
+	if fetch := m.fetchMeta(); fetch != nil {
+		cmd = tea.Batch(cmd, fetch)
+	}
+	return m, cmd
+}
+
+func (m AnalyzeModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
@@ -63,34 +179,210 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case liveScanTickMsg:
+		if m.scanner == nil {
+			return m, nil
+		}
+		m.frame = (m.frame + 1) % len(ui.SpinnerFrames)
+		m.addBranches()
+		return m, liveScanTick()
+
+	case liveScanDoneMsg:
+		return m, m.finishLiveScan(msg)
+
+	case junkMarkedMsg:
+		// Ignore a classification of a tree that has since been replaced.
+		if msg.root == m.root {
+			applyJunk(msg)
+		}
+		return m, nil
+
+	case metaLoadedMsg:
+		for e, meta := range msg.metas {
+			m.meta[e] = meta
+			delete(m.metaPending, e)
+		}
+		if items := m.visibleItems(); m.cursor >= len(items) {
+			m.cursor = max(len(items)-1, 0)
+		}
+		return m, nil
+
+	case archiveListedMsg:
+		if msg.path != m.archiveLoad {
+			return m, nil // closed while listing
+		}
+		m.archiveLoad = ""
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.archive = msg.listing
+		m.archiveOffset = 0
+		return m, nil
+
 	case tea.KeyMsg:
+		m.notice = ""
+
+		// Inspecting an archive: scroll its contents until closed.
+		if m.archive != nil || m.archiveLoad != "" {
+			switch msg.String() {
+			case "q", "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc", "a", "left", "h":
+				m.archive, m.archiveLoad = nil, ""
+			case "up", "k":
+				if m.archiveOffset > 0 {
+					m.archiveOffset--
+				}
+			case "down", "j":
+				if m.archive != nil && m.archiveOffset < len(m.archive.Entries)-m.archiveViewHeight() {
+					m.archiveOffset++
+				}
+			}
+			return m, nil
+		}
+
 		// If awaiting delete confirmation, only Enter confirms.
 		if m.confirmDelete {
 			if msg.String() == "enter" {
 				m.confirmDelete = false
 				items := m.visibleItems()
 				if m.cursor >= 0 && m.cursor < len(items) {
-					return m, deleteEntry(items[m.cursor])
+					return m, deleteEntry(items[m.cursor], m.DryRun, m.IsWhitelisted)
 				}
 			}
 			m.confirmDelete = false
 			return m, nil
 		}
 
+		// Typing a move destination: Enter moves, Esc cancels.
+		if m.moving {
+			switch msg.String() {
+			case "enter":
+				dest := strings.Trim(strings.TrimSpace(m.moveInput.Value()), `"`)
+				if dest == "" {
+					return m, nil
+				}
+				m.moving = false
+				m.moveInput.Blur()
+				if sel := m.Selected(); sel != nil {
+					return m, moveEntry(sel, dest, m.DryRun, m.IsWhitelisted)
+				}
+				return m, nil
+			case "esc":
+				m.moving = false
+				m.moveInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.moveInput, cmd = m.moveInput.Update(msg)
+			return m, cmd
+		}
+
+		// Typing an owner filter: Enter applies it, an empty one clears it.
+		if m.filtering {
+			switch msg.String() {
+			case "enter":
+				m.filtering = false
+				m.ownerInput.Blur()
+				m.ownerFilter = strings.TrimSpace(m.ownerInput.Value())
+				m.cursor = 0
+				m.offset = 0
+				return m, nil
+			case "esc":
+				m.filtering = false
+				m.ownerInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.ownerInput, cmd = m.ownerInput.Update(msg)
+			return m, cmd
+		}
+
+		// Typing a search: Enter runs it and jumps to the first match.
+		if m.searching {
+			switch msg.String() {
+			case "enter":
+				m.searching = false
+				m.searchInput.Blur()
+				query := strings.TrimSpace(m.searchInput.Value())
+				if query == "" {
+					m.clearSearch()
+					return m, nil
+				}
+				search, err := ParseSearch(query)
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.err = nil
+				m.search = &search
+				m.matchIdx = 0
+				m.runSearch()
+				if len(m.matches) == 0 {
+					m.notice = "No matches for " + query
+					m.clearSearch()
+					return m, nil
+				}
+				m.jumpToMatch(0)
+				return m, nil
+			case "esc":
+				m.searching = false
+				m.searchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			return m, cmd
+		}
+
 		switch msg.String() {
-		case "q", "esc", "ctrl+c":
+		case "esc":
+			// Esc leaves a search before it leaves the analyzer.
+			if m.search != nil {
+				m.clearSearch()
+				return m, nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "q", "ctrl+c":
 			m.quitting = true
 			return m, tea.Quit
 
+		case "/":
+			m.searching = true
+			if m.search != nil {
+				m.searchInput.SetValue(m.search.Query)
+			}
+			m.searchInput.CursorEnd()
+			m.searchInput.Focus()
+			return m, textinput.Blink
+
+		case "n":
+			m.jumpToMatch(m.matchIdx + 1)
+
+		case "N":
+			m.jumpToMatch(m.matchIdx - 1)
+
 		case "up", "k":
-			if m.cursor > 0 {
+			if m.tab == TabTypes {
+				if m.offset > 0 {
+					m.offset--
+				}
+			} else if m.cursor > 0 {
 				m.cursor--
 				m.ensureVisible()
 			}
 
 		case "down", "j":
 			items := m.visibleItems()
-			if m.cursor < len(items)-1 {
+			if m.tab == TabTypes {
+				if m.offset < m.typesLineCount()-m.viewportHeight() {
+					m.offset++
+				}
+			} else if m.cursor < len(items)-1 {
 				m.cursor++
 				m.ensureVisible()
 			}
@@ -117,24 +409,103 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "left", "h":
 			// Go up to parent directory.
-			if len(m.breadcrumb) > 0 {
+			if m.tab == TabTree && len(m.breadcrumb) > 0 {
 				m.current = m.breadcrumb[len(m.breadcrumb)-1]
 				m.breadcrumb = m.breadcrumb[:len(m.breadcrumb)-1]
 				m.cursor = 0
 				m.offset = 0
 			}
 
+		case "m":
+			// Move the selection to another folder, typically on another drive.
+			if !m.locked() && m.Selected() != nil {
+				m.moving = true
+				m.moveInput.Focus()
+				return m, textinput.Blink
+			}
+
+		case "y":
+			// Copy the selected path to the clipboard.
+			if sel := m.Selected(); sel != nil && filepath.IsAbs(sel.Path) {
+				if err := core.CopyToClipboard(sel.Path); err != nil {
+					m.err = err
+				} else {
+					m.notice = "Copied " + sel.Path
+				}
+			}
+
 		case "backspace":
 			// First key of two-key delete confirmation.
 			items := m.visibleItems()
-			if m.cursor >= 0 && m.cursor < len(items) {
+			if !m.locked() && m.cursor >= 0 && m.cursor < len(items) {
 				m.confirmDelete = true
 			}
 
+		case "tab":
+			m.tab = (m.tab + 1) % Tab(len(TabNames))
+			m.cursor = 0
+			m.offset = 0
+			if m.tab == TabTopFiles && m.top == nil {
+				n := m.TopN
+				if n <= 0 {
+					n = DefaultTopFiles
+				}
+				m.top = TopFiles(m.root, n)
+			}
+			if m.tab == TabTypes && m.types == nil {
+				b := BreakdownTypes(m.root)
+				m.types = &b
+			}
+			if m.tab == TabStale && m.stale == nil {
+				m.stale = StaleDirs(m.root, m.staleAge(), time.Now())
+			}
+
+		case "t":
+			m.treemap = !m.treemap
+			m.offset = 0
+			m.ensureVisible()
+
 		case "L":
 			m.largeOnly = !m.largeOnly
 			m.cursor = 0
 			m.offset = 0
+
+		case "a":
+			// List the contents of the selected archive.
+			if sel := m.Selected(); sel != nil && !sel.IsDir && IsArchive(sel.Name) && filepath.IsAbs(sel.Path) {
+				m.err = nil
+				m.archiveLoad = sel.Path
+				return m, inspectArchive(sel.Path)
+			}
+
+		case "i":
+			// Owner and last-access columns, read as rows come into view.
+			m.showMeta = !m.showMeta
+
+		case "O":
+			// Filter by owner.
+			if m.tab != TabTypes {
+				m.filtering = true
+				m.ownerInput.SetValue(m.ownerFilter)
+				m.ownerInput.CursorEnd()
+				m.ownerInput.Focus()
+				return m, textinput.Blink
+			}
+
+		case "J":
+			// Hand all junk under the current directory to the clean
+			// selection flow.
+			if m.locked() {
+				return m, nil
+			}
+			items := JunkItems(m.current)
+			if len(items) == 0 {
+				m.err = fmt.Errorf("no junk found under %s", m.current.Path)
+				return m, nil
+			}
+			m.junkRequest = items
+			m.quitting = true
+			return m, tea.Quit
 		}
 
 		return m, nil
@@ -142,8 +513,23 @@ func (m AnalyzeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case deleteResultMsg:
 		if msg.err != nil {
 			m.err = msg.err
+		} else if msg.dryRun {
+			m.notice = fmt.Sprintf("[DRY RUN] Would free %s from %s", core.FormatSize(msg.freed), msg.path)
+		} else {
+			m.dropEntry(msg.path)
+			m.runSearch()
+		}
+		return m, nil
+
+	case moveResultMsg:
+		if msg.err != nil {
+			m.err = msg.err
+		} else if msg.dryRun {
+			m.notice = fmt.Sprintf("[DRY RUN] Would move %s (%s) to %s", msg.path, core.FormatSize(msg.moved), msg.dest)
 		} else {
-			m.removeEntry(msg.path)
+			m.dropEntry(msg.path)
+			m.runSearch()
+			m.notice = fmt.Sprintf("Moved %s (%s) to %s", filepath.Base(msg.path), core.FormatSize(msg.moved), msg.dest)
 		}
 		return m, nil
 	}
@@ -156,6 +542,32 @@ func (m AnalyzeModel) View() string {
 	return m.renderView()
 }
 
+// Selected returns the highlighted entry, or nil when the list is empty.
+func (m AnalyzeModel) Selected() *DirEntry {
+	items := m.visibleItems()
+	if m.cursor >= 0 && m.cursor < len(items) {
+		return items[m.cursor]
+	}
+	return nil
+}
+
+// JunkRequest returns the junk items the user asked to clean with J, or nil
+// when the analyzer exited normally.
+func (m AnalyzeModel) JunkRequest() []clean.CleanItem {
+	return m.junkRequest
+}
+
+// CapturingInput reports whether the analyzer is editing text, in which
+// case a host must pass printable keys through.
+func (m AnalyzeModel) CapturingInput() bool {
+	return m.moving || m.filtering || m.searching
+}
+
+// Current returns the directory being displayed.
+func (m AnalyzeModel) Current() *DirEntry {
+	return m.current
+}
+
 // ─── Helpers ─────────────────────────────────────────────────────────────────
 
 func (m *AnalyzeModel) ensureVisible() {
@@ -169,47 +581,74 @@ func (m *AnalyzeModel) ensureVisible() {
 }
 
 func (m *AnalyzeModel) viewportHeight() int {
-	h := m.height - 8 // header (4) + footer (3) + padding
+	h := m.height - 9 // header (5) + footer (3) + padding
+	if m.tab == TabStale {
+		h-- // summary line
+	}
 	if h < 1 {
 		h = 1
 	}
 	return h
 }
 
-// visibleItems returns the children of the current directory, optionally
-// filtered to only entries ≥100 MiB.
+// visibleItems returns the active list, optionally filtered to only
+// entries ≥100 MiB, to entries of the owner filter and to search matches
+// and the folders leading to them.
 func (m AnalyzeModel) visibleItems() []*DirEntry {
-	if m.current == nil {
-		return nil
-	}
-	if !m.largeOnly {
-		return m.current.Children
+	items := m.listItems()
+	if !m.largeOnly && m.ownerFilter == "" && m.search == nil {
+		return items
 	}
 	const threshold int64 = 100 * 1024 * 1024 // 100 MiB
 	var out []*DirEntry
-	for _, c := range m.current.Children {
-		if c.Size >= threshold {
-			out = append(out, c)
+	for _, c := range items {
+		if m.largeOnly && c.Size < threshold {
+			continue
+		}
+		if m.ownerFilter != "" && !m.ownerMatches(c) {
+			continue
 		}
+		if _, hit := m.hits[c]; m.search != nil && !hit {
+			continue
+		}
+		out = append(out, c)
 	}
 	return out
 }
 
-// removeEntry deletes an entry from the current Children slice and
-// recalculates the parent size.
+// listItems returns the children of the current directory, the largest
+// files on the Top files tab or the stale folders on the Stale tab.
+func (m AnalyzeModel) listItems() []*DirEntry {
+	if m.current == nil {
+		return nil
+	}
+	items := m.current.Children
+	switch m.tab {
+	case TabTypes:
+		return nil
+	case TabTopFiles:
+		items = make([]*DirEntry, 0, len(m.top))
+		for _, f := range m.top {
+			items = append(items, f.Entry)
+		}
+	case TabStale:
+		items = make([]*DirEntry, 0, len(m.stale))
+		for _, d := range m.stale {
+			items = append(items, d.Entry)
+		}
+	}
+	return items
+}
+
+// removeEntry deletes an entry from the current directory and subtracts
+// its size and junk from every ancestor.
 func (m *AnalyzeModel) removeEntry(path string) {
 	if m.current == nil {
 		return
 	}
-	for i, c := range m.current.Children {
+	for _, c := range m.current.Children {
 		if c.Path == path {
-			m.current.Children = append(m.current.Children[:i], m.current.Children[i+1:]...)
-			// Recalculate current directory size.
-			var total int64
-			for _, child := range m.current.Children {
-				total += child.Size
-			}
-			m.current.Size = total
+			c.Detach()
 			if m.cursor >= len(m.current.Children) && m.cursor > 0 {
 				m.cursor--
 			}
@@ -218,10 +657,76 @@ func (m *AnalyzeModel) removeEntry(path string) {
 	}
 }
 
-// openInExplorer opens the parent folder of a path with the item selected.
+// typesLineCount is the number of lines on the Types tab: a heading per
+// section, a blank line between them, and a row per category and extension.
+func (m AnalyzeModel) typesLineCount() int {
+	if m.types == nil {
+		return 0
+	}
+	return len(m.types.Categories) + len(m.types.Extensions) + 3
+}
+
+// dropEntry takes a deleted or moved item out of the view it was acted on
+// from.
+func (m *AnalyzeModel) dropEntry(path string) {
+	switch m.tab {
+	case TabTopFiles:
+		m.removeTopFile(path)
+		m.types, m.stale = nil, nil
+		return
+	case TabStale:
+		m.removeStaleDir(path)
+		m.top, m.types = nil, nil
+		return
+	}
+	m.removeEntry(path)
+	m.top, m.types, m.stale = nil, nil, nil // rebuilt when their tab is shown again
+}
+
+// removeStaleDir drops a deleted or moved folder from the Stale list and
+// from the tree, updating the sizes of its parents.
+func (m *AnalyzeModel) removeStaleDir(path string) {
+	for i, d := range m.stale {
+		if d.Path != path {
+			continue
+		}
+		d.Entry.Detach()
+		m.stale = append(m.stale[:i], m.stale[i+1:]...)
+		if items := m.visibleItems(); m.cursor >= len(items) && m.cursor > 0 {
+			m.cursor--
+		}
+		return
+	}
+}
+
+// staleAge returns the Stale tab's threshold.
+func (m AnalyzeModel) staleAge() time.Duration {
+	if m.StaleAge > 0 {
+		return m.StaleAge
+	}
+	return DefaultStaleAge
+}
+
+// removeTopFile drops a deleted file from the Top files list and from the
+// tree, updating the sizes of its folders.
+func (m *AnalyzeModel) removeTopFile(path string) {
+	for i, f := range m.top {
+		if f.Path != path {
+			continue
+		}
+		f.Entry.Detach()
+		m.top = append(m.top[:i], m.top[i+1:]...)
+		if items := m.visibleItems(); m.cursor >= len(items) && m.cursor > 0 {
+			m.cursor--
+		}
+		return
+	}
+}
+
+// openInExplorer opens the folder containing path with the item selected.
+// Synthetic nodes without a filesystem path are ignored.
 func openInExplorer(path string) {
-	if runtime.GOOS == "windows" {
-		dir := filepath.Dir(path)
-		_ = exec.Command("explorer", "/select,", dir).Start()
+	if runtime.GOOS == "windows" && filepath.IsAbs(path) {
+		_ = exec.Command("explorer", "/select,", path).Start()
 	}
 }
@@ -0,0 +1,79 @@
+package analyze
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+)
+
+// ─── Clean Preview ───────────────────────────────────────────────────────────
+// `pw clean --dry-run` reuses the analyzer to show exactly what would be
+// deleted: one top-level node per target, holding its items, with
+// directory items expanded to their real contents.
+
+// PreviewGroup is one clean target and the items it would delete.
+type PreviewGroup struct {
+	Name  string
+	Items []clean.CleanItem
+}
+
+// BuildPreviewTree builds a browsable tree from clean groups. Directory
+// items are scanned so their contents can be drilled into; items that can
+// no longer be read keep their scanned size. The root and group nodes are
+// synthetic and have no filesystem path.
+func BuildPreviewTree(title string, groups []PreviewGroup) *DirEntry {
+	scanner := NewScanner(8, nil)
+	root := &DirEntry{Name: title, IsDir: true, Scanned: true}
+
+	for _, g := range groups {
+		if len(g.Items) == 0 {
+			continue
+		}
+		group := &DirEntry{Name: g.Name, IsDir: true, Parent: root, Scanned: true}
+		for _, item := range g.Items {
+			node, err := scanner.Scan(item.Path)
+			if err != nil {
+				node = &DirEntry{
+					Path:    item.Path,
+					Name:    filepath.Base(item.Path),
+					Size:    item.Size,
+					ModTime: item.ModTime,
+					Scanned: true,
+				}
+			}
+			node.Name = item.Path
+			node.Parent = group
+			group.Children = append(group.Children, node)
+			group.Size += node.Size
+		}
+		sortBySize(group.Children)
+		root.Children = append(root.Children, group)
+		root.Size += group.Size
+	}
+	sortBySize(root.Children)
+	return root
+}
+
+// NewPreviewModel returns a read-only analyzer over a preview tree: delete
+// and junk hand-off are disabled and junk is not highlighted, since every
+// entry is already slated for deletion.
+func NewPreviewModel(root *DirEntry, title string) AnalyzeModel {
+	return AnalyzeModel{
+		ReadOnly:    true,
+		Title:       title,
+		root:        root,
+		current:     root,
+		width:       80,
+		height:      24,
+		meta:        map[*DirEntry]EntryMeta{},
+		metaPending: map[*DirEntry]bool{},
+		ownerInput:  newPrompt(`CONTOSO\jdoe`, 256),
+		searchInput: newPrompt(`*.vhdx >10GB`, 256),
+	}
+}
+
+// sortBySize orders entries largest first.
+func sortBySize(entries []*DirEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Size > entries[j].Size })
+}
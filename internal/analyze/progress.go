@@ -0,0 +1,110 @@
+package analyze
+
+import (
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Scan Progress ───────────────────────────────────────────────────────────
+// A long scan reports how far it has got — files and bytes counted, the
+// rate, and an estimate of the time left — and hands over each top-level
+// folder as soon as it is complete, so the analyzer can be browsed while
+// the rest of the drive is still being read. The estimate compares the
+// bytes counted with what the scan is expected to find: the used space of
+// a whole drive, or the size of an earlier scan of the same folder. Without
+// either there is no estimate.
+
+// ScanProgress is a snapshot of a running scan.
+type ScanProgress struct {
+	Entries  int64         // files and folders seen
+	Files    int64         // files counted
+	Bytes    int64         // bytes in the files counted
+	Elapsed  time.Duration // since the scan started
+	Rate     float64       // files per second
+	Expected int64         // bytes the scan should find; 0 when unknown
+	ETA      time.Duration // time left; 0 when unknown
+}
+
+// Progress returns how far the running scan has got.
+func (s *Scanner) Progress() ScanProgress {
+	p := ScanProgress{
+		Entries:  s.scannedCount.Load(),
+		Files:    s.filesCounted.Load(),
+		Bytes:    s.bytesCounted.Load(),
+		Expected: s.expected,
+	}
+	if started := s.started.Load(); started != 0 {
+		p.Elapsed = time.Since(time.Unix(0, started))
+	}
+	if secs := p.Elapsed.Seconds(); secs > 0 {
+		p.Rate = float64(p.Files) / secs
+		// Wait for a few seconds of data before guessing.
+		if p.Expected > p.Bytes && p.Bytes > 0 && secs >= 2 {
+			bytesPerSec := float64(p.Bytes) / secs
+			p.ETA = time.Duration(float64(p.Expected-p.Bytes) / bytesPerSec * float64(time.Second))
+		}
+	}
+	return p
+}
+
+// ExpectScanSize sets the bytes the next scan is expected to find, for its
+// time estimate.
+func (s *Scanner) ExpectScanSize(n int64) {
+	s.expected = n
+}
+
+// ExpectedScanSize guesses how many bytes a scan of path will count: the
+// used space of a drive root, or the size recorded by an earlier scan.
+// It returns 0 when there is nothing to go on.
+func ExpectedScanSize(path string) int64 {
+	path = filepath.Clean(path)
+	if isVolumeRoot(path) {
+		p, err := windows.UTF16PtrFromString(path)
+		if err != nil {
+			return 0
+		}
+		var free, total, totalFree uint64
+		if windows.GetDiskFreeSpaceEx(p, &free, &total, &totalFree) == nil {
+			return int64(total - totalFree)
+		}
+		return 0
+	}
+	if entry, err := loadCacheEntry(path); err == nil {
+		return entry.Root.Size
+	}
+	return 0
+}
+
+// OnBranch registers fn to receive each file and folder directly under the
+// scan root as soon as it is complete, with its size already summed. fn is
+// called from scanning goroutines and must not change the entry. Whole
+// drives read from the MFT arrive all at once when the scan ends instead.
+func (s *Scanner) OnBranch(fn func(*DirEntry)) {
+	s.onBranch = fn
+}
+
+// publishBranch hands a completed child of the scan root to the OnBranch
+// callback. The subtree is sized here, and left alone by the final size
+// pass, so the receiver can read it while the scan goes on.
+func (s *Scanner) publishBranch(e *DirEntry) {
+	if s.onBranch == nil || e.Parent == nil || e.Parent != s.liveRoot {
+		return
+	}
+	s.calculateSizes(e)
+	s.mu.Lock()
+	if s.published == nil {
+		s.published = map[*DirEntry]bool{}
+	}
+	s.published[e] = true
+	s.mu.Unlock()
+	s.onBranch(e)
+}
+
+// isPublished reports whether e was handed to the OnBranch callback.
+func (s *Scanner) isPublished(e *DirEntry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.published[e]
+}
@@ -0,0 +1,169 @@
+package analyze
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Incremental Refresh ─────────────────────────────────────────────────────
+// An expired scan cache is brought up to date instead of rescanned: only
+// folders whose listing changed are read again, and every subfolder that is
+// still there keeps its cached subtree. Changed folders come from the NTFS
+// change journal when it can be read (see usn.go), otherwise from comparing
+// folder modification times. The fallback catches files added, removed or
+// renamed, but not a file growing in place.
+
+// Ways Refresh finds changed folders, reported in RefreshResult.
+const (
+	RefreshJournal = "change journal"
+	RefreshModTime = "modification times"
+)
+
+// RefreshResult describes an incremental refresh.
+type RefreshResult struct {
+	Method  string // RefreshJournal or RefreshModTime
+	Changed int    // folders read again
+}
+
+// Refresh updates a tree loaded from an older scan in place, reading again
+// only the folders that changed since, and recomputes sizes.
+func (s *Scanner) Refresh(root *DirEntry) (RefreshResult, error) {
+	if root == nil || !root.IsDir {
+		return RefreshResult{}, errors.New("nothing to refresh")
+	}
+	if _, err := os.Stat(longPath(root.Path)); err != nil {
+		return RefreshResult{}, err
+	}
+
+	var res RefreshResult
+	s.clusterSize = volumeClusterSize(root.Path)
+	root.scannedAt = time.Now()
+	var dirs []*DirEntry
+	if root.journal != nil {
+		if paths, next, err := changedDirsUSN(root.journal); err == nil {
+			res.Method = RefreshJournal
+			dirs = findDirs(root, paths)
+			root.journal = next
+		}
+	}
+	if res.Method == "" {
+		res.Method = RefreshModTime
+		// Record the journal position first so the next refresh can use it.
+		root.journal = currentUSNState(root.Path)
+		dirs = s.changedDirsModTime(root, nil)
+	}
+
+	for _, d := range dirs {
+		s.relistDir(d)
+	}
+	s.calculateSizes(root)
+	res.Changed = len(dirs)
+	return res, nil
+}
+
+// changedDirsModTime appends every folder at or under e whose modification
+// time differs from the cached one.
+func (s *Scanner) changedDirsModTime(e *DirEntry, out []*DirEntry) []*DirEntry {
+	s.scannedCount.Add(1)
+	info, err := os.Lstat(longPath(e.Path))
+	if err != nil {
+		return out // gone: the parent's listing changed too
+	}
+	if !info.ModTime().Equal(e.ModTime) {
+		out = append(out, e)
+	}
+	for _, c := range e.Children {
+		if c.IsDir {
+			out = s.changedDirsModTime(c, out)
+		}
+	}
+	return out
+}
+
+// findDirs maps folder paths to their nodes under root. Paths outside root
+// or not in the tree are skipped: new folders are picked up when their
+// parent is read again.
+func findDirs(root *DirEntry, paths []string) []*DirEntry {
+	prefix := strings.ToLower(strings.TrimSuffix(root.Path, `\`)) + `\`
+	seen := map[*DirEntry]bool{}
+	var out []*DirEntry
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		var node *DirEntry
+		switch lower := strings.ToLower(p); {
+		case lower == prefix || lower+`\` == prefix:
+			node = root
+		case strings.HasPrefix(lower, prefix):
+			node = root
+			for _, name := range strings.Split(p[len(prefix):], `\`) {
+				node = childDir(node, name)
+				if node == nil {
+					break
+				}
+			}
+		}
+		if node != nil && !seen[node] {
+			seen[node] = true
+			out = append(out, node)
+		}
+	}
+	return out
+}
+
+// childDir returns e's subfolder called name, matched case-insensitively.
+func childDir(e *DirEntry, name string) *DirEntry {
+	for _, c := range e.Children {
+		if c.IsDir && strings.EqualFold(c.Name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// relistDir reads a folder again. Files are replaced; subfolders that still
+// exist keep their cached subtree and new ones are scanned in full.
+func (s *Scanner) relistDir(d *DirEntry) {
+	entries, err := os.ReadDir(longPath(d.Path))
+	if err != nil {
+		s.addWarning("cannot read " + d.Path + ": " + err.Error())
+		return
+	}
+	if info, err := os.Lstat(longPath(d.Path)); err == nil {
+		d.ModTime = info.ModTime()
+	}
+
+	old := make(map[string]*DirEntry, len(d.Children))
+	for _, c := range d.Children {
+		if c.IsDir {
+			old[strings.ToLower(c.Name)] = c
+		}
+	}
+
+	var wg sync.WaitGroup
+	children := make([]*DirEntry, 0, len(entries))
+	for _, e := range entries {
+		child := s.newChild(d, e)
+		if child == nil {
+			continue
+		}
+		if child.IsDir {
+			if prev, ok := old[strings.ToLower(child.Name)]; ok {
+				children = append(children, prev)
+				continue
+			}
+			wg.Add(1)
+			go func(dir *DirEntry) {
+				defer wg.Done()
+				s.scanDir(dir)
+				dir.Scanned = true
+			}(child)
+		}
+		children = append(children, child)
+	}
+	wg.Wait()
+	d.Children = children
+}
@@ -7,8 +7,9 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"syscall"
 	"time"
+
+	"golang.org/x/sys/windows"
 )
 
 // DirEntry represents a file or directory in the scan tree.
@@ -21,6 +22,30 @@ type DirEntry struct {
 	Parent   *DirEntry   `json:"-"`
 	ModTime  time.Time   `json:"mod_time"`
 	Scanned  bool        `json:"scanned"`
+
+	// DiskSize is the space taken on disk, summed for folders like Size.
+	DiskSize int64 `json:"disk_size,omitempty"`
+	// Link marks junctions, symbolic links and further names of a
+	// hard-linked file: listed, but never followed and counted as nothing.
+	Link string `json:"link,omitempty"`
+
+	// Junk classification, filled in by MarkJunk rather than cached.
+	JunkCategory string `json:"-"` // clean path-scan category when this node is junk
+	JunkLabel    string `json:"-"`
+	JunkSize     int64  `json:"-"` // total junk at or under this node
+
+	// journal is the change journal position when a scan root was scanned,
+	// kept in the cache for Refresh.
+	journal *usnState
+
+	// scannedAt is when a scan root was last scanned or refreshed.
+	scannedAt time.Time
+}
+
+// ScannedAt returns when the scan rooted at e was taken or last refreshed,
+// or the zero time for nodes below a root.
+func (e *DirEntry) ScannedAt() time.Time {
+	return e.scannedAt
 }
 
 // IsOld returns true if the entry hasn't been modified in 6+ months.
@@ -36,6 +61,25 @@ func (e *DirEntry) Percentage(parentSize int64) float64 {
 	return float64(e.Size) / float64(parentSize) * 100
 }
 
+// Trim returns a copy of the tree limited to depth levels below e, keeping
+// only children of at least minSize bytes. Depth 0 keeps e alone. Parent
+// links are not set in the copy; it is meant for export.
+func (e *DirEntry) Trim(depth int, minSize int64) *DirEntry {
+	out := *e
+	out.Parent = nil
+	out.Children = nil
+	if depth <= 0 {
+		return &out
+	}
+	for _, child := range e.Children {
+		if child.Size < minSize {
+			continue
+		}
+		out.Children = append(out.Children, child.Trim(depth-1, minSize))
+	}
+	return &out
+}
+
 // Scanner performs parallel recursive directory scanning.
 type Scanner struct {
 	sem          chan struct{}
@@ -43,6 +87,17 @@ type Scanner struct {
 	mu           sync.Mutex
 	warnings     []string
 	scannedCount atomic.Int64
+	filesCounted atomic.Int64
+	bytesCounted atomic.Int64
+	started      atomic.Int64 // UnixNano when the scan started
+	expected     int64        // bytes the scan should find, for the time estimate
+
+	liveRoot  *DirEntry          // root of the running scan, whose children are published
+	onBranch  func(*DirEntry)    // receives completed children of liveRoot
+	published map[*DirEntry]bool // children already sized and handed over, under mu
+
+	clusterSize int64            // allocation unit of the scanned volume
+	links       map[fileKey]bool // hard-linked files already counted, under mu
 }
 
 // NewScanner creates a scanner with bounded concurrency.
@@ -81,21 +136,6 @@ func (s *Scanner) addWarning(msg string) {
 	}
 }
 
-// isReparsePoint returns true if the path is a Windows junction or symlink
-// (FILE_ATTRIBUTE_REPARSE_POINT). Must be checked to avoid infinite recursion.
-func isReparsePoint(path string) bool {
-	pathp, err := syscall.UTF16PtrFromString(path)
-	if err != nil {
-		return false
-	}
-	attrs, err := syscall.GetFileAttributes(pathp)
-	if err != nil {
-		return false
-	}
-	const fileAttributeReparsePoint = 0x0400
-	return attrs&fileAttributeReparsePoint != 0
-}
-
 // longPath adds the \\?\ prefix for paths exceeding MAX_PATH on Windows.
 func longPath(path string) string {
 	if len(path) >= 260 && !strings.HasPrefix(path, `\\?\`) {
@@ -119,6 +159,8 @@ func (s *Scanner) Scan(rootPath string) (*DirEntry, error) {
 		IsDir:   info.IsDir(),
 		ModTime: info.ModTime(),
 	}
+	s.started.Store(time.Now().UnixNano())
+	s.liveRoot = root
 
 	if !info.IsDir() {
 		root.Size = info.Size()
@@ -126,6 +168,23 @@ func (s *Scanner) Scan(rootPath string) (*DirEntry, error) {
 		return root, nil
 	}
 
+	s.clusterSize = volumeClusterSize(rootPath)
+
+	// Take the journal position before reading anything, so a later refresh
+	// also sees changes made while this scan ran.
+	root.journal = currentUSNState(rootPath)
+	root.scannedAt = time.Now()
+
+	// A whole NTFS drive is read from the MFT when elevated; otherwise, or
+	// if that fails, folders are listed one by one.
+	if isVolumeRoot(rootPath) {
+		if err := s.scanMFT(root); err == nil {
+			s.calculateSizes(root)
+			root.Scanned = true
+			return root, nil
+		}
+		root.Children = nil
+	}
 	s.scanDir(root)
 	s.calculateSizes(root)
 	root.Scanned = true
@@ -152,45 +211,21 @@ func (s *Scanner) scanDir(entry *DirEntry) {
 	var mu sync.Mutex
 
 	for _, e := range entries {
-		childPath := filepath.Join(entry.Path, e.Name())
-		s.scannedCount.Add(1)
-
-		// Skip excluded directories.
-		if e.IsDir() && s.exclude[strings.ToLower(e.Name())] {
+		child := s.newChild(entry, e)
+		if child == nil {
 			continue
 		}
 
-		// NEVER follow junction points / reparse points — infinite recursion risk.
-		if e.IsDir() && isReparsePoint(childPath) {
-			s.addWarning("skipping junction/reparse: " + childPath)
-			continue
-		}
-
-		info, err := e.Info()
-		if err != nil {
-			// Permission denied or other error — skip, don't fail.
-			s.addWarning("cannot stat " + childPath + ": " + err.Error())
-			continue
-		}
-
-		child := &DirEntry{
-			Path:    childPath,
-			Name:    e.Name(),
-			IsDir:   e.IsDir(),
-			Parent:  entry,
-			ModTime: info.ModTime(),
-		}
-
-		if !e.IsDir() {
-			child.Size = info.Size()
-			child.Scanned = true
-		} else {
+		if child.IsDir {
 			wg.Add(1)
 			go func(dir *DirEntry) {
 				defer wg.Done()
 				s.scanDir(dir)
 				dir.Scanned = true
+				s.publishBranch(dir)
 			}(child)
+		} else {
+			s.publishBranch(child)
 		}
 
 		mu.Lock()
@@ -201,19 +236,79 @@ func (s *Scanner) scanDir(entry *DirEntry) {
 	wg.Wait()
 }
 
+// newChild builds the node for one directory entry of parent, or returns nil
+// when the entry is excluded, a reparse point, or cannot be read. Files get
+// their size; directories are left for the caller to scan.
+func (s *Scanner) newChild(parent *DirEntry, e os.DirEntry) *DirEntry {
+	childPath := filepath.Join(parent.Path, e.Name())
+	s.scannedCount.Add(1)
+
+	// Skip excluded directories.
+	if e.IsDir() && s.exclude[strings.ToLower(e.Name())] {
+		return nil
+	}
+
+	info, err := e.Info()
+	if err != nil {
+		// Permission denied or other error — skip, don't fail.
+		s.addWarning("cannot stat " + childPath + ": " + err.Error())
+		return nil
+	}
+
+	child := &DirEntry{
+		Path:    childPath,
+		Name:    e.Name(),
+		IsDir:   e.IsDir(),
+		Parent:  parent,
+		ModTime: info.ModTime(),
+	}
+	attrs := fileAttributes(info)
+
+	// NEVER follow junctions or symbolic links — infinite recursion risk.
+	if attrs&windows.FILE_ATTRIBUTE_REPARSE_POINT != 0 {
+		if kind := linkKind(childPath); kind != "" {
+			child.IsDir = attrs&windows.FILE_ATTRIBUTE_DIRECTORY != 0
+			child.Link = kind
+			child.Scanned = true
+			return child
+		}
+	}
+
+	if !child.IsDir {
+		child.Size = info.Size()
+		child.Scanned = true
+		if child.Size >= hardLinkMinSize {
+			if key, ok := hardLinkKey(childPath); ok && !s.firstLink(key) {
+				child.Size = 0
+				child.Link = LinkHardlink
+				return child
+			}
+		}
+		child.DiskSize = s.diskSize(childPath, child.Size, attrs)
+		s.filesCounted.Add(1)
+		s.bytesCounted.Add(child.Size)
+	}
+	return child
+}
+
 // calculateSizes walks the tree bottom-up, summing sizes from children,
 // then sorts each level by size descending.
 func (s *Scanner) calculateSizes(entry *DirEntry) {
 	if !entry.IsDir {
 		return
 	}
+	if entry.Parent != nil && entry.Parent == s.liveRoot && s.isPublished(entry) {
+		return // sized when published, and possibly being read
+	}
 
-	var total int64
+	var total, disk int64
 	for _, child := range entry.Children {
 		s.calculateSizes(child)
 		total += child.Size
+		disk += child.DiskSize
 	}
 	entry.Size = total
+	entry.DiskSize = disk
 
 	// Sort children by size descending after all sizes are known.
 	sort.Slice(entry.Children, func(i, j int) bool {
@@ -0,0 +1,187 @@
+package analyze
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+// ─── Search ──────────────────────────────────────────────────────────────────
+// Pressing / searches the finished scan instead of walking the disk again.
+// A query is a name — a glob such as "*.vhdx" or plain text matched
+// anywhere in the name — and optionally a minimum size written ">10GB" or
+// "over 10GB". The views then show only matches and the folders leading to
+// them, and n / N jump from one match to the next.
+
+// Search is a parsed search query.
+type Search struct {
+	Query   string // as typed
+	Pattern string // name glob or text, lower case; "" matches any name
+	MinSize int64  // 0 when no size was given
+}
+
+// ParseSearch parses a query such as `*.vhdx >10GB`, `node_modules` or
+// `over 1GB`.
+func ParseSearch(query string) (Search, error) {
+	s := Search{Query: strings.TrimSpace(query)}
+	var name []string
+	fields := strings.Fields(s.Query)
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		size := ""
+		switch {
+		case strings.HasPrefix(f, ">"):
+			size = strings.TrimLeft(f, ">=")
+			if size == "" && i+1 < len(fields) {
+				i++
+				size = fields[i]
+			}
+		case strings.EqualFold(f, "over") && i+1 < len(fields):
+			i++
+			size = fields[i]
+		default:
+			name = append(name, f)
+			continue
+		}
+		n, err := config.ParseSize(size)
+		if err != nil {
+			return Search{}, fmt.Errorf("invalid size %q in search: %w", size, err)
+		}
+		s.MinSize = n
+	}
+	s.Pattern = strings.ToLower(strings.Join(name, " "))
+	if s.Pattern == "" && s.MinSize == 0 {
+		return Search{}, fmt.Errorf("empty search")
+	}
+	if isGlob(s.Pattern) {
+		if _, err := filepath.Match(s.Pattern, ""); err != nil {
+			return Search{}, fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+	}
+	return s, nil
+}
+
+// Match reports whether e matches the query. A query with only a size
+// matches files, since every folder above a large file is large too.
+func (s Search) Match(e *DirEntry) bool {
+	if e.Size < s.MinSize {
+		return false
+	}
+	if s.Pattern == "" {
+		return !e.IsDir
+	}
+	name := strings.ToLower(e.Name)
+	if isGlob(s.Pattern) {
+		ok, _ := filepath.Match(s.Pattern, name)
+		return ok
+	}
+	return strings.Contains(name, s.Pattern)
+}
+
+// FindMatches returns the entries under root matching s, in tree order,
+// largest child first.
+func FindMatches(root *DirEntry, s Search) []*DirEntry {
+	if root == nil {
+		return nil
+	}
+	var out []*DirEntry
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		for _, c := range e.Children {
+			if s.Match(c) {
+				out = append(out, c)
+			}
+			walk(c)
+		}
+	}
+	walk(root)
+	return out
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// runSearch finds the matches of the active search and marks them and the
+// folders leading to them as shown.
+func (m *AnalyzeModel) runSearch() {
+	if m.search == nil {
+		m.matches, m.hits = nil, nil
+		return
+	}
+	m.matches = FindMatches(m.root, *m.search)
+	m.hits = make(map[*DirEntry]bool, len(m.matches)*2)
+	for _, e := range m.matches {
+		m.hits[e] = true
+		for p := e.Parent; p != nil && p != m.root; p = p.Parent {
+			if _, ok := m.hits[p]; ok {
+				break
+			}
+			m.hits[p] = false
+		}
+	}
+	if m.matchIdx >= len(m.matches) {
+		m.matchIdx = 0
+	}
+}
+
+// clearSearch shows every entry again.
+func (m *AnalyzeModel) clearSearch() {
+	m.search = nil
+	m.matchIdx = 0
+	m.runSearch()
+	if items := m.visibleItems(); m.cursor >= len(items) {
+		m.cursor = max(len(items)-1, 0)
+	}
+	m.ensureVisible()
+}
+
+// jumpToMatch opens the folder holding match i on the Tree tab and puts
+// the cursor on it.
+func (m *AnalyzeModel) jumpToMatch(i int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIdx = (i%len(m.matches) + len(m.matches)) % len(m.matches)
+	target := m.matches[m.matchIdx]
+
+	var chain []*DirEntry // root … target's parent
+	for p := target.Parent; p != nil; p = p.Parent {
+		chain = append([]*DirEntry{p}, chain...)
+		if p == m.root {
+			break
+		}
+	}
+	if len(chain) == 0 || chain[0].Parent != nil || chain[0].Path != m.root.Path {
+		return // no longer in the tree
+	}
+	// During a live scan the branches hang off the scanner's root, which
+	// the partial tree stands in for.
+	chain[0] = m.root
+	m.tab = TabTree
+	m.current = chain[len(chain)-1]
+	m.breadcrumb = chain[:len(chain)-1]
+	m.cursor, m.offset = 0, 0
+	for j, e := range m.visibleItems() {
+		if e == target {
+			m.cursor = j
+			break
+		}
+	}
+	m.ensureVisible()
+}
+
+// SearchActive reports whether a search is narrowing the views.
+func (m AnalyzeModel) SearchActive() bool {
+	return m.search != nil
+}
+
+// isMatch reports whether e is a match of the active search, not just a
+// folder leading to one.
+func (m AnalyzeModel) isMatch(e *DirEntry) bool {
+	return m.hits[e]
+}
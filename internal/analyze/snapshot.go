@@ -0,0 +1,220 @@
+package analyze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ─── Snapshots ───────────────────────────────────────────────────────────────
+// `pw analyze --snapshot <name>` keeps a scan under a name so that a later
+// `pw analyze --compare <name>` can show what grew or shrank since. Only
+// folders are kept, which keeps a whole-drive snapshot to a few megabytes;
+// comparisons are made folder by folder. A snapshot is stored in the native
+// export format, so --compare also accepts a file written by --export.
+
+const snapshotDirName = "snapshots"
+
+// snapshotDir returns the directory holding named snapshots, creating it if
+// needed.
+func snapshotDir() (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, snapshotDirName)
+	return dir, os.MkdirAll(dir, 0o755)
+}
+
+// validSnapshotName reports whether name is usable as a snapshot file name.
+func validSnapshotName(name string) bool {
+	if name == "" || len(name) > 64 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.", r)) {
+			return false
+		}
+	}
+	return name != "." && name != ".."
+}
+
+// SaveSnapshot stores the folders of root under name, replacing any
+// snapshot of that name.
+func SaveSnapshot(name string, root *DirEntry, meta ExportMeta) error {
+	if !validSnapshotName(name) {
+		return fmt.Errorf("invalid snapshot name %q (use letters, digits, '-', '_' and '.')", name)
+	}
+	dir, err := snapshotDir()
+	if err != nil {
+		return err
+	}
+	if meta.Excluded == nil {
+		meta.Excluded = []string{}
+	}
+	data, err := json.Marshal(exportDocument{ExportMeta: meta, Tree: foldersOnly(root)})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), data, 0o644)
+}
+
+// LoadSnapshot reads a named snapshot, or a JSON file written by --export
+// when ref is a path to one.
+func LoadSnapshot(ref string) (*DirEntry, ExportMeta, error) {
+	path := ref
+	if validSnapshotName(ref) {
+		dir, err := snapshotDir()
+		if err != nil {
+			return nil, ExportMeta{}, err
+		}
+		// A saved snapshot wins over a file of the same name.
+		if p := filepath.Join(dir, ref+".json"); fileExists(p) || !fileExists(ref) {
+			path = p
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ExportMeta{}, fmt.Errorf("no snapshot named %q", ref)
+	}
+	if err != nil {
+		return nil, ExportMeta{}, err
+	}
+	var doc exportDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ExportMeta{}, fmt.Errorf("%s is not a snapshot: %w", path, err)
+	}
+	if doc.Tree == nil {
+		return nil, ExportMeta{}, fmt.Errorf("%s is not a snapshot: no tree", path)
+	}
+	rebuildParents(doc.Tree, nil)
+	return doc.Tree, doc.ExportMeta, nil
+}
+
+// ListSnapshots returns the names of the saved snapshots, sorted.
+func ListSnapshots() ([]string, error) {
+	dir, err := snapshotDir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(f), ".json"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// foldersOnly returns a copy of the tree without files.
+func foldersOnly(e *DirEntry) *DirEntry {
+	out := *e
+	out.Parent = nil
+	out.Children = nil
+	for _, c := range e.Children {
+		if c.IsDir {
+			out.Children = append(out.Children, foldersOnly(c))
+		}
+	}
+	return &out
+}
+
+// ─── Comparison ──────────────────────────────────────────────────────────────
+
+// SizeDelta is how one folder changed between a snapshot and a scan.
+// Children are ordered by growth, largest first.
+type SizeDelta struct {
+	Name     string       `json:"name"`
+	Path     string       `json:"path"`
+	Old      int64        `json:"old"`
+	New      int64        `json:"new"`
+	Delta    int64        `json:"delta"`
+	Added    bool         `json:"added,omitempty"`   // not in the snapshot
+	Removed  bool         `json:"removed,omitempty"` // gone since the snapshot
+	Children []*SizeDelta `json:"children,omitempty"`
+}
+
+// CompareTrees matches the folders of an older and a newer tree by name and
+// returns the size change of each.
+func CompareTrees(old, cur *DirEntry) *SizeDelta {
+	d := &SizeDelta{}
+	switch {
+	case cur != nil:
+		d.Name, d.Path, d.New = cur.Name, cur.Path, cur.Size
+	case old != nil:
+		d.Name, d.Path = old.Name, old.Path
+	}
+	if old != nil {
+		d.Old = old.Size
+	}
+	d.Added, d.Removed = old == nil, cur == nil
+	d.Delta = d.New - d.Old
+
+	oldDirs := map[string]*DirEntry{}
+	if old != nil {
+		for _, c := range old.Children {
+			if c.IsDir {
+				oldDirs[strings.ToLower(c.Name)] = c
+			}
+		}
+	}
+	if cur != nil {
+		for _, c := range cur.Children {
+			if !c.IsDir {
+				continue
+			}
+			key := strings.ToLower(c.Name)
+			d.Children = append(d.Children, CompareTrees(oldDirs[key], c))
+			delete(oldDirs, key)
+		}
+	}
+	for _, c := range oldDirs {
+		d.Children = append(d.Children, CompareTrees(c, nil))
+	}
+	sort.Slice(d.Children, func(i, j int) bool {
+		if d.Children[i].Delta != d.Children[j].Delta {
+			return d.Children[i].Delta > d.Children[j].Delta
+		}
+		return d.Children[i].Name < d.Children[j].Name
+	})
+	return d
+}
+
+// BiggestGrowth returns up to n folders where growth happened: folders that
+// grew without one subfolder accounting for most of it, largest first.
+// Reporting every ancestor of a growing folder would only repeat it.
+func BiggestGrowth(d *SizeDelta, n int) []*SizeDelta {
+	var out []*SizeDelta
+	var walk func(d *SizeDelta)
+	walk = func(d *SizeDelta) {
+		if d.Delta <= 0 {
+			return
+		}
+		if len(d.Children) > 0 && d.Children[0].Delta*2 > d.Delta {
+			for _, c := range d.Children {
+				walk(c)
+			}
+			return
+		}
+		out = append(out, d)
+	}
+	walk(d)
+	sort.Slice(out, func(i, j int) bool { return out[i].Delta > out[j].Delta })
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package analyze
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ─── Stale Folders ───────────────────────────────────────────────────────────
+// The analyzer's "Stale" tab and `pw analyze --stale` list folders in which
+// nothing has been modified for a long time — forgotten downloads, finished
+// projects, abandoned virtual machines. A folder is stale when its newest
+// file is older than the threshold; only the outermost stale folder of a
+// branch is listed, so the sizes add up to what could be reclaimed.
+
+// DefaultStaleAge is the threshold when none is configured.
+const DefaultStaleAge = 365 * 24 * time.Hour
+
+// StaleDir is a folder whose newest file is older than the threshold.
+type StaleDir struct {
+	Entry  *DirEntry `json:"-"`
+	Path   string    `json:"path"`
+	Size   int64     `json:"size"`
+	Newest time.Time `json:"newest"` // modification time of its newest file
+}
+
+// StaleDirs returns the outermost folders under root whose newest file was
+// modified before now-age, largest first. Empty folders are left out.
+func StaleDirs(root *DirEntry, age time.Duration, now time.Time) []StaleDir {
+	if root == nil {
+		return nil
+	}
+	cutoff := now.Add(-age)
+	newest := map[*DirEntry]time.Time{}
+	newestFile(root, newest)
+
+	var out []StaleDir
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		for _, c := range e.Children {
+			if !c.IsDir || c.Link != "" {
+				continue
+			}
+			t := newest[c]
+			if c.Size > 0 && !t.IsZero() && t.Before(cutoff) {
+				out = append(out, StaleDir{Entry: c, Path: c.Path, Size: c.Size, Newest: t})
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(root)
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Size != out[j].Size {
+			return out[i].Size > out[j].Size
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
+
+// newestFile records in newest the latest file modification time under
+// each folder at or below e, and returns e's.
+func newestFile(e *DirEntry, newest map[*DirEntry]time.Time) time.Time {
+	if !e.IsDir {
+		return e.ModTime
+	}
+	var t time.Time
+	for _, c := range e.Children {
+		if c.Link != "" {
+			continue
+		}
+		if ct := newestFile(c, newest); ct.After(t) {
+			t = ct
+		}
+	}
+	newest[e] = t
+	return t
+}
+
+// FormatStaleAge renders a threshold as whole years ("1y") or days ("90d").
+func FormatStaleAge(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days >= 365 && days%365 == 0 {
+		return fmt.Sprintf("%dy", days/365)
+	}
+	return fmt.Sprintf("%dd", days)
+}
+
+// StaleTotal returns the combined size of the stale folders.
+func StaleTotal(dirs []StaleDir) int64 {
+	var total int64
+	for _, d := range dirs {
+		total += d.Size
+	}
+	return total
+}
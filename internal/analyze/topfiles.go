@@ -0,0 +1,156 @@
+package analyze
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Largest Files ───────────────────────────────────────────────────────────
+// `pw analyze --top N` and the analyzer's "Top files" tab flatten the scan
+// tree into the N largest files at any depth, so a stray ISO five levels
+// down is as visible as a large folder at the top.
+
+// DefaultTopFiles is the number of files listed when no count is given.
+const DefaultTopFiles = 50
+
+// TopFile is one entry of the largest-files list.
+type TopFile struct {
+	Entry   *DirEntry `json:"-"`
+	Path    string    `json:"path"`
+	Size    int64     `json:"size"`
+	Ext     string    `json:"ext"`
+	ModTime time.Time `json:"mod_time"`
+	Owner   string    `json:"owner,omitempty"`
+}
+
+// LargestFiles returns the n largest files under root, largest first.
+func LargestFiles(root *DirEntry, n int) []*DirEntry {
+	if root == nil || n <= 0 {
+		return nil
+	}
+	var top []*DirEntry
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		if !e.IsDir {
+			if len(top) == n && e.Size <= top[n-1].Size {
+				return
+			}
+			i := sort.Search(len(top), func(i int) bool { return top[i].Size < e.Size })
+			if len(top) < n {
+				top = append(top, nil)
+			}
+			copy(top[i+1:], top[i:])
+			top[i] = e
+			return
+		}
+		for _, c := range e.Children {
+			walk(c)
+		}
+	}
+	walk(root)
+	return top
+}
+
+// TopFiles returns the n largest files under root with their extension and
+// owner filled in.
+func TopFiles(root *DirEntry, n int) []TopFile {
+	entries := LargestFiles(root, n)
+	out := make([]TopFile, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, TopFile{
+			Entry:   e,
+			Path:    e.Path,
+			Size:    e.Size,
+			Ext:     strings.ToLower(filepath.Ext(e.Name)),
+			ModTime: e.ModTime,
+			Owner:   FileOwner(e.Path),
+		})
+	}
+	return out
+}
+
+var (
+	ownerMu    sync.Mutex
+	ownerNames = map[string]string{} // SID string → DOMAIN\user
+)
+
+// FileOwner returns the owner of path as DOMAIN\user, the SID when the
+// account cannot be resolved, or "" when the security descriptor cannot be
+// read.
+func FileOwner(path string) string {
+	sd, err := windows.GetNamedSecurityInfo(longPath(path), windows.SE_FILE_OBJECT, windows.OWNER_SECURITY_INFORMATION)
+	if err != nil {
+		return ""
+	}
+	sid, _, err := sd.Owner()
+	if err != nil || sid == nil {
+		return ""
+	}
+	key := sid.String()
+
+	ownerMu.Lock()
+	defer ownerMu.Unlock()
+	if name, ok := ownerNames[key]; ok {
+		return name
+	}
+	name := key
+	if account, domain, _, err := sid.LookupAccount(""); err == nil {
+		name = account
+		if domain != "" {
+			name = domain + `\` + account
+		}
+	}
+	ownerNames[key] = name
+	return name
+}
+
+// Detach removes e from its parent and subtracts its size, and its junk,
+// from every ancestor.
+func (e *DirEntry) Detach() {
+	parent := e.Parent
+	if parent == nil {
+		return
+	}
+	for i, c := range parent.Children {
+		if c == e {
+			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
+			break
+		}
+	}
+	junk := e.JunkSize
+	for p := parent; p != nil; p = p.Parent {
+		p.Size -= e.Size
+		if p.IsJunk() {
+			// A junk folder counts in full, unclassified contents included.
+			junk = p.JunkSize - p.Size
+			p.JunkSize = p.Size
+		} else {
+			p.JunkSize -= junk
+		}
+	}
+	e.Parent = nil
+}
+
+// FormatAge renders the time since t compactly: "today", "12d", "5mo", "3y".
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	days := int(time.Since(t).Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days < 60:
+		return fmt.Sprintf("%dd", days)
+	case days < 730:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
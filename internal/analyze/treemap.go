@@ -0,0 +1,247 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Treemap ─────────────────────────────────────────────────────────────────
+// The treemap view (toggled with t) draws the current directory's children
+// as rectangles whose area is proportional to their size, WinDirStat-style.
+// Rectangles are laid out by recursive bisection: the size-ordered items are
+// split where the two halves are closest in total size, and the rectangle is
+// cut across its longer side in that proportion. Items too small to get a
+// single cell are left out.
+
+// treemapPalette colors directories in turn; files share a dimmer color so
+// folders stand out.
+var treemapPalette = []lipgloss.AdaptiveColor{
+	ui.ColorCoral,
+	ui.ColorBlue,
+	ui.ColorTeal,
+	ui.ColorViolet,
+	ui.ColorHazy,
+	ui.ColorAccent,
+}
+
+// treemapRect is one item's cell rectangle.
+type treemapRect struct {
+	x, y, w, h int
+	idx        int // index into the laid-out items
+}
+
+// layoutTreemap places items (sorted by size, largest first) in a w×h cell
+// grid. Zero-size items are skipped.
+func layoutTreemap(items []*DirEntry, w, h int) []treemapRect {
+	var idx []int
+	for i, e := range items {
+		if e.Size > 0 {
+			idx = append(idx, i)
+		}
+	}
+	var out []treemapRect
+	bisectTreemap(items, idx, 0, 0, w, h, &out)
+	return out
+}
+
+func bisectTreemap(items []*DirEntry, idx []int, x, y, w, h int, out *[]treemapRect) {
+	if len(idx) == 0 || w < 1 || h < 1 {
+		return
+	}
+	if len(idx) == 1 {
+		*out = append(*out, treemapRect{x: x, y: y, w: w, h: h, idx: idx[0]})
+		return
+	}
+
+	var total int64
+	for _, i := range idx {
+		total += items[i].Size
+	}
+
+	// Split where the first half comes closest to half the total.
+	k, acc := 1, items[idx[0]].Size
+	for k < len(idx)-1 && acc+items[idx[k]].Size/2 <= total/2 {
+		acc += items[idx[k]].Size
+		k++
+	}
+	frac := float64(acc) / float64(total)
+
+	// Terminal cells are about twice as tall as wide, so a rectangle is
+	// wider than it is tall once w reaches 2h.
+	if w >= 2*h {
+		cut := int(float64(w)*frac + 0.5)
+		if cut < 1 {
+			cut = 1
+		}
+		if cut >= w {
+			cut = w - 1
+		}
+		bisectTreemap(items, idx[:k], x, y, cut, h, out)
+		bisectTreemap(items, idx[k:], x+cut, y, w-cut, h, out)
+		return
+	}
+
+	cut := int(float64(h)*frac + 0.5)
+	if cut < 1 {
+		cut = 1
+	}
+	if cut >= h {
+		cut = h - 1
+	}
+	if cut < 1 { // a single cell: keep the larger half
+		bisectTreemap(items, idx[:k], x, y, w, h, out)
+		return
+	}
+	bisectTreemap(items, idx[:k], x, y, w, cut, out)
+	bisectTreemap(items, idx[k:], x, y+cut, w, h-cut, out)
+}
+
+// treemapCell is one character of the rendered map.
+type treemapCell struct {
+	ch    rune
+	owner int // item index, or -1 for unused space
+	label bool
+}
+
+// renderTreemap draws the visible items as a treemap with a detail line for
+// the selected one.
+func (m AnalyzeModel) renderTreemap(w int) string {
+	items := m.visibleItems()
+	if len(items) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render("  (empty directory)")
+	}
+
+	mapW := w - 4
+	mapH := m.viewportHeight() - 1 // leave a row for the detail line
+	if mapH < 2 {
+		mapH = 2
+	}
+
+	grid := make([][]treemapCell, mapH)
+	for y := range grid {
+		grid[y] = make([]treemapCell, mapW)
+		for x := range grid[y] {
+			grid[y][x] = treemapCell{ch: ' ', owner: -1}
+		}
+	}
+
+	rects := layoutTreemap(items, mapW, mapH)
+	for _, r := range rects {
+		for y := r.y; y < r.y+r.h; y++ {
+			for x := r.x; x < r.x+r.w; x++ {
+				// Half blocks on the right and bottom edges leave a thin
+				// gap between neighbouring rectangles.
+				right := x == r.x+r.w-1 && r.w > 1
+				bottom := y == r.y+r.h-1 && r.h > 1
+				ch := '█'
+				switch {
+				case right && bottom:
+					ch = '▘'
+				case right:
+					ch = '▌'
+				case bottom:
+					ch = '▀'
+				}
+				grid[y][x] = treemapCell{ch: ch, owner: r.idx}
+			}
+		}
+		writeTreemapLabel(grid, r, items[r.idx])
+	}
+
+	var lines []string
+	for _, row := range grid {
+		lines = append(lines, "  "+m.renderTreemapRow(row, items))
+	}
+
+	// Detail line for the selection.
+	if m.cursor >= 0 && m.cursor < len(items) {
+		e := items[m.cursor]
+		detail := fmt.Sprintf("  %s %s  %s  %.1f%%", ui.IconBlock, e.Name,
+			ui.FormatSize(e.Size), e.Percentage(m.current.Size))
+		if shown := len(rects); shown < len(items) {
+			detail += fmt.Sprintf("   (%d smaller items not drawn)", len(items)-shown)
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(detail))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeTreemapLabel puts the item's name, and its size when there is room,
+// on the first rows of its rectangle.
+func writeTreemapLabel(grid [][]treemapCell, r treemapRect, e *DirEntry) {
+	room := r.w - 1 // keep the right edge
+	if room < 3 {
+		return
+	}
+	texts := []string{e.Name, ui.FormatSize(e.Size)}
+	for row, text := range texts {
+		if row >= r.h-1 && !(row == 0 && r.h == 1) {
+			break
+		}
+		runes := []rune(text)
+		if len(runes) > room {
+			runes = append(runes[:room-1], '…')
+		}
+		for i, ch := range runes {
+			grid[r.y+row][r.x+i] = treemapCell{ch: ch, owner: r.idx, label: true}
+		}
+	}
+}
+
+// renderTreemapRow styles a grid row, one style per run of cells with the
+// same owner and kind.
+func (m AnalyzeModel) renderTreemapRow(row []treemapCell, items []*DirEntry) string {
+	var s strings.Builder
+	for start := 0; start < len(row); {
+		end := start + 1
+		for end < len(row) && row[end].owner == row[start].owner && row[end].label == row[start].label {
+			end++
+		}
+		var run strings.Builder
+		for _, c := range row[start:end] {
+			run.WriteRune(c.ch)
+		}
+
+		cell := row[start]
+		if cell.owner < 0 {
+			s.WriteString(run.String())
+		} else {
+			color := m.treemapColor(cell.owner, items[cell.owner])
+			style := lipgloss.NewStyle().Foreground(color)
+			if cell.label {
+				style = lipgloss.NewStyle().
+					Foreground(ui.ColorSurfaceDark).
+					Background(color).
+					Bold(items[cell.owner].IsDir)
+			}
+			s.WriteString(style.Render(run.String()))
+		}
+		start = end
+	}
+	return s.String()
+}
+
+// treemapColor picks an item's color: the cursor color when selected, the
+// junk badge color for junk, a palette color for directories and a dim
+// color for files.
+func (m AnalyzeModel) treemapColor(idx int, e *DirEntry) lipgloss.AdaptiveColor {
+	switch {
+	case idx == m.cursor:
+		return clrCursor
+	case e.IsJunk():
+		if c, ok := junkBadgeColors[e.JunkCategory]; ok {
+			return c
+		}
+		return ui.ColorWarning
+	case e.IsDir:
+		return treemapPalette[idx%len(treemapPalette)]
+	default:
+		return ui.ColorTextDim
+	}
+}
@@ -0,0 +1,123 @@
+package analyze
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ─── File Types ──────────────────────────────────────────────────────────────
+// The analyzer's "Types" tab and `pw analyze --types` total the scanned files
+// by extension and by broad category, answering what kind of data fills a
+// drive rather than where it is.
+
+// fileCategories maps lower-case extensions to a category. Anything else
+// counts as "other".
+var fileCategories = map[string]string{}
+
+func init() {
+	for category, exts := range map[string][]string{
+		"video":       {".mp4", ".mkv", ".avi", ".mov", ".wmv", ".webm", ".m4v", ".flv", ".mpg", ".mpeg", ".m2ts", ".vob", ".3gp"},
+		"images":      {".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tif", ".tiff", ".webp", ".heic", ".raw", ".cr2", ".nef", ".arw", ".dng", ".psd", ".svg", ".ico"},
+		"audio":       {".mp3", ".flac", ".wav", ".aac", ".ogg", ".m4a", ".wma", ".opus", ".aiff"},
+		"archives":    {".zip", ".7z", ".rar", ".tar", ".gz", ".tgz", ".bz2", ".xz", ".zst", ".cab", ".iso", ".img", ".vhd", ".vhdx", ".wim", ".esd"},
+		"executables": {".exe", ".dll", ".msi", ".msix", ".msp", ".appx", ".appxbundle", ".sys", ".com", ".scr", ".ocx"},
+		"code": {".go", ".c", ".h", ".cc", ".cpp", ".hpp", ".cs", ".java", ".kt", ".py", ".js", ".mjs", ".ts", ".tsx", ".jsx",
+			".rs", ".rb", ".php", ".swift", ".lua", ".ps1", ".psm1", ".bat", ".cmd", ".sh", ".sql", ".html", ".css", ".scss",
+			".json", ".yaml", ".yml", ".toml", ".xml", ".pdb", ".obj", ".o", ".lib", ".a", ".class", ".jar", ".pyc", ".wasm"},
+		"documents": {".pdf", ".doc", ".docx", ".xls", ".xlsx", ".ppt", ".pptx", ".odt", ".ods", ".odp", ".rtf", ".txt",
+			".md", ".csv", ".epub", ".one", ".pst", ".ost"},
+	} {
+		for _, ext := range exts {
+			fileCategories[ext] = category
+		}
+	}
+}
+
+// FileCategory returns the category of a file extension such as ".mp4".
+func FileCategory(ext string) string {
+	if c, ok := fileCategories[strings.ToLower(ext)]; ok {
+		return c
+	}
+	return "other"
+}
+
+// TypeStat totals the files of one extension or category.
+type TypeStat struct {
+	Name     string `json:"name"`               // ".mp4", "(none)" or a category
+	Category string `json:"category,omitempty"` // set for extensions
+	Size     int64  `json:"size"`
+	Files    int    `json:"files"`
+}
+
+// TypeBreakdown is the size of the files under a directory by category and
+// by extension, each largest first.
+type TypeBreakdown struct {
+	Total      int64      `json:"total"`
+	Categories []TypeStat `json:"categories"`
+	Extensions []TypeStat `json:"extensions"`
+}
+
+// BreakdownTypes totals every file under root by extension and category.
+func BreakdownTypes(root *DirEntry) TypeBreakdown {
+	exts := map[string]*TypeStat{}
+	cats := map[string]*TypeStat{}
+
+	var walk func(e *DirEntry)
+	walk = func(e *DirEntry) {
+		if e.IsDir {
+			for _, c := range e.Children {
+				walk(c)
+			}
+			return
+		}
+		if e.Link != "" {
+			return // counted under another name, or not data at all
+		}
+		ext := strings.ToLower(filepath.Ext(e.Name))
+		if ext == "" {
+			ext = "(none)"
+		}
+		st, ok := exts[ext]
+		if !ok {
+			st = &TypeStat{Name: ext, Category: FileCategory(ext)}
+			exts[ext] = st
+		}
+		st.Size += e.Size
+		st.Files++
+
+		cat, ok := cats[st.Category]
+		if !ok {
+			cat = &TypeStat{Name: st.Category}
+			cats[st.Category] = cat
+		}
+		cat.Size += e.Size
+		cat.Files++
+	}
+
+	var b TypeBreakdown
+	if root == nil {
+		return b
+	}
+	walk(root)
+	for _, c := range cats {
+		b.Categories = append(b.Categories, *c)
+		b.Total += c.Size
+	}
+	for _, e := range exts {
+		b.Extensions = append(b.Extensions, *e)
+	}
+	sortTypeStats(b.Categories)
+	sortTypeStats(b.Extensions)
+	return b
+}
+
+// sortTypeStats orders stats largest first, by name on ties.
+func sortTypeStats(stats []TypeStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Size != stats[j].Size {
+			return stats[i].Size > stats[j].Size
+		}
+		return stats[i].Name < stats[j].Name
+	})
+}
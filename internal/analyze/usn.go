@@ -0,0 +1,226 @@
+package analyze
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── NTFS Change Journal ─────────────────────────────────────────────────────
+// Every NTFS volume keeps an update sequence number (USN) journal: a log of
+// each file created, deleted, renamed or written, addressed by file
+// reference number. A scan records the journal's position; a refresh reads
+// the records written since and maps each changed file's parent folder back
+// to a path, so only those folders need to be listed again. Reading the
+// journal needs administrator rights; without them Refresh falls back to
+// folder modification times.
+
+const (
+	fsctlQueryUSNJournal = 0x000900F4
+	fsctlReadUSNJournal  = 0x000900BB
+	volumeNameDOS        = 0x0 // GetFinalPathNameByHandle: drive letter paths
+
+	// usnReadBufferSize is the buffer for one FSCTL_READ_USN_JOURNAL call.
+	usnReadBufferSize = 64 * 1024
+
+	// usnMaxChangedDirs caps the folders a journal refresh will resolve;
+	// beyond it walking modification times is no slower.
+	usnMaxChangedDirs = 100000
+)
+
+var (
+	modKernel32      = windows.NewLazySystemDLL("kernel32.dll")
+	procOpenFileByID = modKernel32.NewProc("OpenFileById")
+)
+
+// usnState is a volume's change journal position, stored with a scan.
+type usnState struct {
+	Volume    string `json:"volume"` // "C:"
+	JournalID uint64 `json:"journal_id"`
+	NextUSN   int64  `json:"next_usn"`
+}
+
+// usnJournalData mirrors USN_JOURNAL_DATA_V0.
+type usnJournalData struct {
+	UsnJournalID    uint64
+	FirstUsn        int64
+	NextUsn         int64
+	LowestValidUsn  int64
+	MaxUsn          int64
+	MaximumSize     uint64
+	AllocationDelta uint64
+}
+
+// readUSNJournalData mirrors READ_USN_JOURNAL_DATA_V0.
+type readUSNJournalData struct {
+	StartUsn          int64
+	ReasonMask        uint32
+	ReturnOnlyOnClose uint32
+	Timeout           uint64
+	BytesToWaitFor    uint64
+	UsnJournalID      uint64
+}
+
+// fileIDDescriptor mirrors FILE_ID_DESCRIPTOR with a 64-bit FileIdType id.
+type fileIDDescriptor struct {
+	Size   uint32
+	Type   uint32
+	FileID uint64
+	_      [8]byte // rest of the FILE_ID_128 union member
+}
+
+// volumeOf returns the drive letter volume ("C:") of a local path, or "".
+func volumeOf(path string) string {
+	vol := filepath.VolumeName(path)
+	if len(vol) != 2 || vol[1] != ':' {
+		return "" // UNC paths and the like have no journal we can read
+	}
+	return strings.ToUpper(vol)
+}
+
+// openVolume opens a drive letter volume for journal queries.
+func openVolume(vol string) (windows.Handle, error) {
+	name, err := windows.UTF16PtrFromString(`\\.\` + vol)
+	if err != nil {
+		return windows.InvalidHandle, err
+	}
+	return windows.CreateFile(name, windows.GENERIC_READ,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil, windows.OPEN_EXISTING, 0, 0)
+}
+
+// queryUSNJournal returns the journal state of an open volume.
+func queryUSNJournal(h windows.Handle) (usnJournalData, error) {
+	var data usnJournalData
+	var n uint32
+	err := windows.DeviceIoControl(h, fsctlQueryUSNJournal, nil, 0,
+		(*byte)(unsafe.Pointer(&data)), uint32(unsafe.Sizeof(data)), &n, nil)
+	return data, err
+}
+
+// currentUSNState returns the journal position of the volume holding path,
+// or nil when it cannot be read.
+func currentUSNState(path string) *usnState {
+	vol := volumeOf(path)
+	if vol == "" {
+		return nil
+	}
+	h, err := openVolume(vol)
+	if err != nil {
+		return nil
+	}
+	defer windows.CloseHandle(h)
+	data, err := queryUSNJournal(h)
+	if err != nil {
+		return nil
+	}
+	return &usnState{Volume: vol, JournalID: data.UsnJournalID, NextUSN: data.NextUsn}
+}
+
+// changedDirsUSN returns the folders holding a file that changed since
+// state, and the journal's new position. It fails when the journal was
+// recreated or has wrapped past state, in which case the caller must fall
+// back to another way of finding changes.
+func changedDirsUSN(state *usnState) ([]string, *usnState, error) {
+	h, err := openVolume(state.Volume)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer windows.CloseHandle(h)
+
+	cur, err := queryUSNJournal(h)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cur.UsnJournalID != state.JournalID {
+		return nil, nil, errors.New("change journal was recreated since the last scan")
+	}
+	if state.NextUSN < cur.FirstUsn {
+		return nil, nil, errors.New("change journal has wrapped since the last scan")
+	}
+
+	parents := map[uint64]bool{}
+	read := readUSNJournalData{
+		StartUsn:     state.NextUSN,
+		ReasonMask:   0xFFFFFFFF,
+		UsnJournalID: cur.UsnJournalID,
+	}
+	buf := make([]byte, usnReadBufferSize)
+	for read.StartUsn < cur.NextUsn {
+		var n uint32
+		err := windows.DeviceIoControl(h, fsctlReadUSNJournal,
+			(*byte)(unsafe.Pointer(&read)), uint32(unsafe.Sizeof(read)),
+			&buf[0], uint32(len(buf)), &n, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading change journal: %w", err)
+		}
+		if n <= 8 {
+			break
+		}
+		read.StartUsn = int64(binary.LittleEndian.Uint64(buf))
+
+		// USN_RECORD_V2: length, version, file and parent reference numbers.
+		for off := uint32(8); off+24 <= n; {
+			length := binary.LittleEndian.Uint32(buf[off:])
+			if length == 0 {
+				break
+			}
+			if major := binary.LittleEndian.Uint16(buf[off+4:]); major != 2 {
+				return nil, nil, fmt.Errorf("unsupported change journal record version %d", major)
+			}
+			parents[binary.LittleEndian.Uint64(buf[off+16:])] = true
+			off += length
+		}
+		if len(parents) > usnMaxChangedDirs {
+			return nil, nil, errors.New("too many changes since the last scan")
+		}
+	}
+
+	dirs := make([]string, 0, len(parents))
+	pathBuf := make([]uint16, windows.MAX_LONG_PATH)
+	for frn := range parents {
+		// Folders deleted since are gone; their parent is in the set too.
+		if path, err := pathByFileID(h, frn, pathBuf); err == nil {
+			dirs = append(dirs, path)
+		}
+	}
+	next := &usnState{Volume: state.Volume, JournalID: cur.UsnJournalID, NextUSN: cur.NextUsn}
+	return dirs, next, nil
+}
+
+// pathByFileID resolves a file reference number on the volume to a path,
+// using buf for the result.
+func pathByFileID(volume windows.Handle, frn uint64, buf []uint16) (string, error) {
+	if err := procOpenFileByID.Find(); err != nil {
+		return "", err
+	}
+	desc := fileIDDescriptor{Type: 0, FileID: frn} // FileIdType
+	desc.Size = uint32(unsafe.Sizeof(desc))
+	r, _, callErr := procOpenFileByID.Call(
+		uintptr(volume),
+		uintptr(unsafe.Pointer(&desc)),
+		uintptr(windows.FILE_READ_ATTRIBUTES),
+		uintptr(windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE),
+		0,
+		uintptr(windows.FILE_FLAG_BACKUP_SEMANTICS),
+	)
+	h := windows.Handle(r)
+	if h == windows.InvalidHandle {
+		return "", callErr
+	}
+	defer windows.CloseHandle(h)
+
+	n, err := windows.GetFinalPathNameByHandle(h, &buf[0], uint32(len(buf)), volumeNameDOS)
+	if err != nil {
+		return "", err
+	}
+	if int(n) > len(buf) {
+		return "", errors.New("path too long")
+	}
+	return strings.TrimPrefix(windows.UTF16ToString(buf[:n]), `\\?\`), nil
+}
@@ -2,7 +2,9 @@ package analyze
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cy-infamous/purewin/internal/ui"
@@ -21,6 +23,16 @@ var (
 	clrCursor = ui.ColorPrimary
 )
 
+// junkBadgeColors gives each clean path-scan category its own badge color.
+var junkBadgeColors = map[string]lipgloss.AdaptiveColor{
+	"temp":    ui.ColorWarning,
+	"logs":    ui.ColorBlue,
+	"cache":   ui.ColorTeal,
+	"build":   ui.ColorViolet,
+	"os_junk": ui.ColorHazy,
+	"debug":   ui.ColorError,
+}
+
 // ─── Top-level view ──────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderView() string {
@@ -35,7 +47,20 @@ func (m AnalyzeModel) renderView() string {
 	var s strings.Builder
 	s.WriteString(m.renderHeader(w))
 	s.WriteString("\n")
-	s.WriteString(m.renderBody(w))
+	switch {
+	case m.archive != nil || m.archiveLoad != "":
+		s.WriteString(m.renderArchive(w))
+	case m.tab == TabTopFiles:
+		s.WriteString(m.renderTopFiles(w))
+	case m.tab == TabTypes:
+		s.WriteString(m.renderTypes(w))
+	case m.tab == TabStale:
+		s.WriteString(m.renderStale(w))
+	case m.treemap:
+		s.WriteString(m.renderTreemap(w))
+	default:
+		s.WriteString(m.renderBody(w))
+	}
 	s.WriteString("\n")
 	s.WriteString(m.renderFooter(w))
 	return s.String()
@@ -44,15 +69,29 @@ func (m AnalyzeModel) renderView() string {
 // ─── Header ──────────────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderHeader(w int) string {
+	heading := "Disk Analyzer"
+	if m.Title != "" {
+		heading = m.Title
+	}
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(ui.ColorCoral).
-		Render("  " + ui.IconDiamond + " Disk Analyzer")
+		Render("  " + ui.IconDiamond + " " + heading)
 
 	sizeStr := ui.FormatSize(m.current.Size)
+	if m.current.DiskSize > 0 && m.current.DiskSize != m.current.Size {
+		sizeStr += fmt.Sprintf("  (%s on disk)", ui.FormatSize(m.current.DiskSize))
+	}
+	location := m.current.Path
+	if location == "" {
+		location = m.current.Name // synthetic preview node
+	}
 	pathLine := lipgloss.NewStyle().
 		Foreground(ui.ColorTextDim).
-		Render(fmt.Sprintf("  %s    %s", m.current.Path, sizeStr))
+		Render(fmt.Sprintf("  %s    %s", location, sizeStr))
+	if m.scanner != nil {
+		pathLine = lipgloss.NewStyle().Foreground(ui.ColorTextDim).Render(m.renderScanProgress())
+	}
 
 	// Breadcrumb trail.
 	var crumbs []string
@@ -64,7 +103,7 @@ func (m AnalyzeModel) renderHeader(w int) string {
 		Foreground(ui.ColorMuted).
 		Render("  " + strings.Join(crumbs, " "+ui.IconChevron+" "))
 
-	inner := lipgloss.JoinVertical(lipgloss.Left, title, pathLine, bcStr)
+	inner := lipgloss.JoinVertical(lipgloss.Left, title, pathLine, bcStr, m.renderTabs())
 
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -73,6 +112,24 @@ func (m AnalyzeModel) renderHeader(w int) string {
 		Render(inner)
 }
 
+// renderTabs renders the tab strip, the active tab highlighted.
+func (m AnalyzeModel) renderTabs() string {
+	var tabs []string
+	for i, name := range TabNames {
+		if Tab(i) == m.tab {
+			tabs = append(tabs, lipgloss.NewStyle().
+				Foreground(ui.ColorCoral).
+				Bold(true).
+				Render(ui.IconDot+" "+name))
+		} else {
+			tabs = append(tabs, lipgloss.NewStyle().
+				Foreground(ui.ColorMuted).
+				Render("  "+name))
+		}
+	}
+	return "  " + strings.Join(tabs, "   ")
+}
+
 // ─── Body (file list) ────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderBody(w int) string {
@@ -144,7 +201,7 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 	if len(name) > maxName {
 		name = name[:maxName-1] + "…"
 	}
-	nameStr := lipgloss.NewStyle().Foreground(nameColor).Bold(entry.IsDir).Render(name)
+	nameStr := lipgloss.NewStyle().Foreground(nameColor).Bold(entry.IsDir).Underline(m.isMatch(entry)).Render(name)
 
 	// ── Metadata columns ─────────────────────────────────────
 	numStr := lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%3d.", num))
@@ -159,6 +216,15 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 	// ── Assemble ─────────────────────────────────────────────
 	line := fmt.Sprintf("  %s %s  %s  %s %s  %s  %s",
 		numStr, bar, pctStr, icon, nameStr, sizeStr, age)
+	if m.showMeta {
+		line += "  " + m.metaColumns(entry, true)
+	}
+	if note := sizeNote(entry); note != "" {
+		line += "  " + note
+	}
+	if junk := junkBadge(entry); junk != "" {
+		line += "  " + junk
+	}
 
 	if selected {
 		cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
@@ -174,6 +240,310 @@ func (m AnalyzeModel) renderEntry(num int, entry *DirEntry, parentSize int64, ba
 	return line
 }
 
+// ─── Top files ───────────────────────────────────────────────────────────────
+
+func (m AnalyzeModel) renderTopFiles(w int) string {
+	items := m.visibleItems()
+	if len(items) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render("  (no files)")
+	}
+
+	// Extension, age and owner by entry; the list follows visibleItems.
+	byEntry := make(map[*DirEntry]TopFile, len(m.top))
+	for _, f := range m.top {
+		byEntry[f.Entry] = f
+	}
+
+	vh := m.viewportHeight()
+	pathWidth := w - 60
+	if pathWidth < 20 {
+		pathWidth = 20
+	}
+
+	var lines []string
+	for i := m.offset; i < len(items) && i < m.offset+vh; i++ {
+		f := byEntry[items[i]]
+		numStr := lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%3d.", i+1))
+		sizeColor := clrFile
+		if f.Size >= 100*(1<<20) {
+			sizeColor = clrLarge
+		}
+		sizeStr := lipgloss.NewStyle().Foreground(sizeColor).Render(fmt.Sprintf("%10s", ui.FormatSize(f.Size)))
+		ageColor := ui.ColorTextDim
+		if items[i].IsOld() {
+			ageColor = ui.ColorWarning
+		}
+		ageStr := lipgloss.NewStyle().Foreground(ageColor).Render(fmt.Sprintf("%-6s", FormatAge(f.ModTime)))
+		meta := lipgloss.NewStyle().Foreground(ui.ColorTextDim).
+			Render(fmt.Sprintf("%-7.7s", f.Ext))
+		owner := lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%-20.20s", f.Owner))
+
+		line := fmt.Sprintf("  %s %s  %s %s  %s  %s", numStr, sizeStr, meta, ageStr, owner, truncateLeft(f.Path, pathWidth))
+		if m.showMeta {
+			// The owner is already a column here.
+			line = fmt.Sprintf("  %s %s  %s %s  %s  %s  %s", numStr, sizeStr, meta, ageStr, owner,
+				m.metaColumns(items[i], false), truncateLeft(f.Path, pathWidth-12))
+		}
+		if i == m.cursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+			if m.confirmDelete {
+				line += lipgloss.NewStyle().
+					Foreground(ui.ColorError).
+					Bold(true).
+					Render("  " + ui.IconWarning + " Press Enter to delete")
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	if len(items) > vh {
+		pct := float64(m.offset) / float64(len(items)-vh) * 100
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render(fmt.Sprintf("  ── %d/%d files  (%.0f%%) ──", min(m.offset+vh, len(items)), len(items), pct)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─── Stale ───────────────────────────────────────────────────────────────────
+
+func (m AnalyzeModel) renderStale(w int) string {
+	summary := lipgloss.NewStyle().Foreground(ui.ColorTextDim).
+		Render(fmt.Sprintf("  %d folders untouched for %s or more %s %s reclaimable",
+			len(m.stale), FormatStaleAge(m.staleAge()), ui.IconArrow, ui.FormatSize(StaleTotal(m.stale))))
+	items := m.visibleItems()
+	if len(items) == 0 {
+		return summary + "\n" + lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render("  (no stale folders)")
+	}
+
+	newest := make(map[*DirEntry]time.Time, len(m.stale))
+	for _, d := range m.stale {
+		newest[d.Entry] = d.Newest
+	}
+
+	vh := m.viewportHeight()
+	pathWidth := w - 32
+	if pathWidth < 20 {
+		pathWidth = 20
+	}
+
+	lines := []string{summary}
+	for i := m.offset; i < len(items) && i < m.offset+vh; i++ {
+		e := items[i]
+		numStr := lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%3d.", i+1))
+		sizeColor := clrFile
+		if e.Size >= 1<<30 {
+			sizeColor = clrLarge
+		}
+		sizeStr := lipgloss.NewStyle().Foreground(sizeColor).Render(fmt.Sprintf("%10s", ui.FormatSize(e.Size)))
+		ageStr := lipgloss.NewStyle().Foreground(ui.ColorWarning).Render(fmt.Sprintf("%-6s", FormatAge(newest[e])))
+		pathStr := lipgloss.NewStyle().Foreground(clrDir).Render(truncateLeft(e.Path, pathWidth))
+
+		line := fmt.Sprintf("  %s %s  %s  %s", numStr, sizeStr, ageStr, pathStr)
+		if m.showMeta {
+			line = fmt.Sprintf("  %s %s  %s  %s  %s", numStr, sizeStr, ageStr, m.metaColumns(e, true),
+				lipgloss.NewStyle().Foreground(clrDir).Render(truncateLeft(e.Path, pathWidth-34)))
+		}
+		if i == m.cursor {
+			cursor := lipgloss.NewStyle().Foreground(clrCursor).Bold(true).Render(ui.IconBlock)
+			line = " " + cursor + line[2:]
+			if m.confirmDelete {
+				line += lipgloss.NewStyle().
+					Foreground(ui.ColorError).
+					Bold(true).
+					Render("  " + ui.IconWarning + " Press Enter to delete")
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	if len(items) > vh {
+		pct := float64(m.offset) / float64(len(items)-vh) * 100
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render(fmt.Sprintf("  ── %d/%d folders  (%.0f%%) ──", min(m.offset+vh, len(items)), len(items), pct)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─── Archive contents ────────────────────────────────────────────────────────
+
+func (m AnalyzeModel) renderArchive(w int) string {
+	heading := lipgloss.NewStyle().Foreground(clrDir).Bold(true)
+	if m.archive == nil {
+		return heading.Render("  "+filepath.Base(m.archiveLoad)) + "\n" +
+			lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true).
+				Render("  Reading contents…")
+	}
+
+	l := m.archive
+	summary := fmt.Sprintf("  %s  %d files, %d folders  %s archive, %s unpacked",
+		l.Format, l.Files, l.Dirs, ui.FormatSize(l.Size), ui.FormatSize(l.Unpacked))
+	if l.Unpacked > 0 {
+		summary += fmt.Sprintf("  (packed to %.0f%%)", l.Ratio()*100)
+	}
+	lines := []string{
+		heading.Render("  " + filepath.Base(l.Path)),
+		lipgloss.NewStyle().Foreground(ui.ColorTextDim).Render(summary),
+	}
+	if len(l.Entries) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true).Render("  (empty archive)"))
+		return strings.Join(lines, "\n")
+	}
+
+	vh := m.archiveViewHeight()
+	nameWidth := max(w-32, 20)
+	for i := m.archiveOffset; i < len(l.Entries) && i < m.archiveOffset+vh; i++ {
+		e := l.Entries[i]
+		packed := ""
+		if e.Packed >= 0 && !e.IsDir {
+			packed = ui.FormatSize(e.Packed)
+		}
+		nameStyle := lipgloss.NewStyle().Foreground(clrFile)
+		if e.IsDir {
+			nameStyle = lipgloss.NewStyle().Foreground(clrDir)
+		}
+		lines = append(lines, fmt.Sprintf("  %10s  %s  %s",
+			ui.FormatSize(e.Size),
+			lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%10s", packed)),
+			nameStyle.Render(truncateLeft(e.Name, nameWidth))))
+	}
+	if len(l.Entries) > vh {
+		pct := float64(m.archiveOffset) / float64(len(l.Entries)-vh) * 100
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render(fmt.Sprintf("  ── %d/%d entries  (%.0f%%) ──", min(m.archiveOffset+vh, len(l.Entries)), len(l.Entries), pct)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ─── Types ───────────────────────────────────────────────────────────────────
+
+func (m AnalyzeModel) renderTypes(w int) string {
+	if m.types == nil || m.types.Total == 0 {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render("  (no files)")
+	}
+
+	barWidth := 20
+	if w > 110 {
+		barWidth = 30
+	} else if w > 90 {
+		barWidth = 25
+	}
+	total := m.types.Total
+	heading := lipgloss.NewStyle().Foreground(clrDir).Bold(true)
+	row := func(st TypeStat, note string) string {
+		pct := float64(st.Size) / float64(total) * 100
+		return fmt.Sprintf("  %-12.12s %s  %5.1f%%  %10s  %s",
+			st.Name, ui.GradientBar(pct, barWidth), pct, ui.FormatSize(st.Size),
+			lipgloss.NewStyle().Foreground(clrDim).Render(fmt.Sprintf("%d files%s", st.Files, note)))
+	}
+
+	lines := []string{heading.Render("  By category")}
+	for _, st := range m.types.Categories {
+		lines = append(lines, row(st, ""))
+	}
+	lines = append(lines, "", heading.Render("  By extension"))
+	for _, st := range m.types.Extensions {
+		lines = append(lines, row(st, "  "+st.Category))
+	}
+
+	vh := m.viewportHeight()
+	end := min(m.offset+vh, len(lines))
+	out := lines[m.offset:end]
+	if len(lines) > vh {
+		pct := float64(m.offset) / float64(len(lines)-vh) * 100
+		out = append(out, lipgloss.NewStyle().
+			Foreground(ui.ColorMuted).
+			Italic(true).
+			Render(fmt.Sprintf("  ── %d/%d lines  (%.0f%%) ──", end, len(lines), pct)))
+	}
+	return strings.Join(out, "\n")
+}
+
+// truncateLeft shortens s to width runes, keeping the end, which for a path
+// is the part that tells files apart.
+func truncateLeft(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return "…" + string(r[len(r)-width+1:])
+}
+
+// metaColumns renders the last-access column, preceded by the owner when
+// withOwner is set, or placeholders while they are being read.
+func (m AnalyzeModel) metaColumns(e *DirEntry, withOwner bool) string {
+	style := lipgloss.NewStyle().Foreground(clrDim)
+	meta, ok := m.meta[e]
+	accessed := "…"
+	if ok {
+		accessed = FormatAge(meta.Accessed)
+	}
+	cols := style.Render(fmt.Sprintf("%-10s", "acc "+accessed))
+	if withOwner {
+		owner := "…"
+		if ok {
+			owner = meta.Owner
+		}
+		cols = style.Render(fmt.Sprintf("%-20.20s", owner)) + "  " + cols
+	}
+	return cols
+}
+
+// sizeNote marks links, which count as nothing, and entries whose size on
+// disk differs from their apparent size by more than a tenth (compressed,
+// sparse, or many small files).
+func sizeNote(entry *DirEntry) string {
+	style := lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true)
+	switch entry.Link {
+	case LinkJunction, LinkSymlink:
+		return style.Render(ui.IconArrow + " " + entry.Link + ", not followed")
+	case LinkHardlink:
+		return style.Render(ui.IconArrow + " hard link, counted once")
+	}
+	if d := entry.DiskSize - entry.Size; entry.DiskSize > 0 && (d*10 > entry.Size || -d*10 > entry.Size) {
+		return style.Render(ui.FormatSize(entry.DiskSize) + " on disk")
+	}
+	return ""
+}
+
+// junkBadge renders the category tag for a junk node, or the junk total
+// beneath a directory that contains some.
+func junkBadge(entry *DirEntry) string {
+	if entry.IsJunk() {
+		color, ok := junkBadgeColors[entry.JunkCategory]
+		if !ok {
+			color = ui.ColorWarning
+		}
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorSurfaceDark).
+			Background(color).
+			Bold(true).
+			Padding(0, 1).
+			Render(entry.JunkLabel)
+	}
+	if entry.IsDir && entry.JunkSize > 0 {
+		return lipgloss.NewStyle().
+			Foreground(ui.ColorWarning).
+			Render(ui.FormatSize(entry.JunkSize) + " junk")
+	}
+	return ""
+}
+
 // ─── Footer ──────────────────────────────────────────────────────────────────
 
 func (m AnalyzeModel) renderFooter(w int) string {
@@ -187,22 +557,115 @@ func (m AnalyzeModel) renderFooter(w int) string {
 				Render("  "+ui.IconError+" "+m.err.Error()))
 	}
 
+	// Status notice.
+	if m.notice != "" {
+		parts = append(parts,
+			lipgloss.NewStyle().
+				Foreground(ui.ColorInfo).
+				Render("  "+m.notice))
+	}
+
 	// Filter indicator.
 	if m.largeOnly {
 		parts = append(parts,
 			"  "+ui.TagWarningStyle().Render(" >100 MiB filter "))
 	}
 
+	if m.search != nil {
+		at := min(m.matchIdx+1, len(m.matches))
+		parts = append(parts,
+			lipgloss.NewStyle().
+				Foreground(ui.ColorInfo).
+				Render(fmt.Sprintf("  %s %s  match %d/%d  n next %s N previous %s Esc clear",
+					ui.IconArrow, m.search.Query, at, len(m.matches), ui.IconPipe, ui.IconPipe)))
+	}
+
+	if m.ownerFilter != "" {
+		parts = append(parts,
+			"  "+ui.TagWarningStyle().Render(" owner: "+m.ownerFilter+" "))
+	}
+
+	// Junk total for the current directory.
+	if m.current.JunkSize > 0 {
+		parts = append(parts,
+			lipgloss.NewStyle().
+				Foreground(ui.ColorWarning).
+				Render(fmt.Sprintf("  %s %s of junk here %s press J to review and clean",
+					ui.IconWarning, ui.FormatSize(m.current.JunkSize), ui.IconArrow)))
+	}
+
+	// Move destination prompt.
+	if m.moving {
+		name := ""
+		if sel := m.Selected(); sel != nil {
+			name = sel.Name
+		}
+		parts = append(parts,
+			"  "+ui.BoldStyle().Render("Move "+name+" to: ")+m.moveInput.View(),
+			ui.HintBarStyle().Render("  Enter move  "+ui.IconPipe+"  Esc cancel"))
+		return strings.Join(parts, "\n")
+	}
+
+	// Search prompt.
+	if m.searching {
+		parts = append(parts,
+			"  "+ui.BoldStyle().Render("Search: ")+m.searchInput.View(),
+			ui.HintBarStyle().Render("  Enter search (name glob or text, >SIZE)  "+ui.IconPipe+"  Esc cancel"))
+		return strings.Join(parts, "\n")
+	}
+
+	// Owner filter prompt.
+	if m.filtering {
+		parts = append(parts,
+			"  "+ui.BoldStyle().Render("Show entries owned by: ")+m.ownerInput.View(),
+			ui.HintBarStyle().Render("  Enter filter (empty clears)  "+ui.IconPipe+"  Esc cancel"))
+		return strings.Join(parts, "\n")
+	}
+
 	// Keybindings.
+	if m.archive != nil || m.archiveLoad != "" {
+		parts = append(parts, ui.HintBarStyle().Render("  ↑↓ scroll "+ui.IconPipe+" Esc close "+ui.IconPipe+" q quit"))
+		return strings.Join(parts, "\n")
+	}
 	hints := []string{
 		"↑↓ nav",
 		"→ drill",
 		"← back",
-		"Enter open",
+		"Enter show",
 		"⌫ delete",
+		"m move",
+		"y copy path",
+		"/ search",
 		"L large",
+		"t treemap",
+		"i owner",
+		"O filter owner",
+		"Tab views",
+		"J clean junk",
 		"q quit",
 	}
+	if m.ReadOnly {
+		hints = []string{"↑↓ nav", "→ drill", "← back", "Enter show", "y copy path", "/ search", "L large", "t treemap", "i owner", "O filter owner", "Tab views", "q quit"}
+	}
+	switch m.tab {
+	case TabTopFiles, TabStale:
+		hints = []string{"↑↓ nav", "Enter show", "⌫ delete", "m move", "y copy path", "/ search", "L large", "i owner", "O filter owner", "Tab views", "q quit"}
+		if m.ReadOnly {
+			hints = []string{"↑↓ nav", "Enter show", "y copy path", "/ search", "L large", "i owner", "O filter owner", "Tab views", "q quit"}
+		}
+	case TabTypes:
+		hints = []string{"↑↓ scroll", "Tab views", "q quit"}
+	}
+	if sel := m.Selected(); sel != nil && !sel.IsDir && IsArchive(sel.Name) {
+		hints = append([]string{"a contents"}, hints...)
+	}
+	if m.treemap {
+		for i, h := range hints {
+			if h == "t treemap" {
+				hints[i] = "t list"
+			}
+		}
+	}
 	hintStr := strings.Join(hints, " "+ui.IconPipe+" ")
 	parts = append(parts, ui.HintBarStyle().Render("  "+hintStr))
 
@@ -2,147 +2,108 @@ package clean
 
 import (
 	"os"
-	"path/filepath"
 	"strings"
+	"unsafe"
 
+	"golang.org/x/sys/windows"
+
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
 )
 
-// ─── Browser Definitions ─────────────────────────────────────────────────────
-
-// browserDef describes a Chromium-based browser's cache locations.
-type browserDef struct {
-	name    string   // Human-readable browser name.
-	base    string   // Base "User Data" directory.
-	subdirs []string // Cache subdirectories within each profile.
-}
-
 // ─── Browser Cache Scanning ──────────────────────────────────────────────────
 
-// ScanBrowserCaches auto-detects installed browsers and scans their cache
-// directories across ALL profiles (Default, Profile 1, Profile 2, …).
+// ScanBrowserCaches discovers installed browsers (Chromium-based and Gecko)
+// and scans the cache directories of ALL their profiles, labelling items per
+// profile (see config.DiscoveredBrowser.CacheDescription). Browsers that are
+// currently running are skipped because their caches are locked and in use;
+// see RunningBrowsers.
 //
 // Only cache directories are touched — bookmarks, passwords, cookies,
 // history, extensions, and settings are NEVER included.
 func ScanBrowserCaches(wl *whitelist.Whitelist) []CleanItem {
-	local := os.Getenv("LOCALAPPDATA")
-
-	browsers := []browserDef{
-		{
-			name: "Chrome",
-			base: filepath.Join(local, "Google", "Chrome", "User Data"),
-			subdirs: []string{
-				"Cache",
-				"Code Cache",
-				"GPUCache",
-				filepath.Join("Service Worker", "CacheStorage"),
-			},
-		},
-		{
-			name: "Edge",
-			base: filepath.Join(local, "Microsoft", "Edge", "User Data"),
-			subdirs: []string{
-				"Cache",
-				"Code Cache",
-				"GPUCache",
-				filepath.Join("Service Worker", "CacheStorage"),
-			},
-		},
-		{
-			name: "Brave",
-			base: filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data"),
-			subdirs: []string{
-				"Cache",
-				"Code Cache",
-				"GPUCache",
-			},
-		},
-	}
+	running := runningProcessNames()
 
 	var items []CleanItem
-
-	// Scan Chromium-based browsers.
-	for _, b := range browsers {
-		if _, err := os.Stat(b.base); err != nil {
-			continue // Browser not installed.
+	for _, b := range config.DiscoverBrowsers() {
+		if browserRunning(b.Def, running) {
+			continue
 		}
 
-		profiles := discoverChromiumProfiles(b.base)
-		for _, profile := range profiles {
-			for _, subdir := range b.subdirs {
-				cacheDir := filepath.Join(profile, subdir)
+		// Each profile gets its own description so results break down
+		// per profile.
+		for _, p := range b.Profiles {
+			desc := b.CacheDescription(p)
+			for _, cacheDir := range b.ProfileCachePaths(p) {
 				if _, err := os.Stat(cacheDir); err != nil {
 					continue
 				}
-				desc := b.name + " cache"
-				dirItems := scanDirectory(cacheDir, "browser", desc, wl)
+				dirItems := scanDirectory(cacheDir, "browser", desc, wl, nil)
+				setRisk(dirItems, config.RiskLow)
 				items = append(items, dirItems...)
 			}
 		}
 	}
 
-	// Firefox uses a different profile structure.
-	firefoxItems := scanFirefoxCaches(local, wl)
-	items = append(items, firefoxItems...)
-
 	return items
 }
 
-// ─── Profile Discovery ───────────────────────────────────────────────────────
-
-// discoverChromiumProfiles returns all profile directories within a
-// Chromium-based browser's User Data directory. Profiles are named
-// "Default", "Profile 1", "Profile 2", etc.
-func discoverChromiumProfiles(userDataDir string) []string {
-	entries, err := os.ReadDir(userDataDir)
-	if err != nil {
-		return nil
-	}
+// RunningBrowsers returns the names of installed browsers that are currently
+// running and will therefore be skipped by ScanBrowserCaches.
+func RunningBrowsers() []string {
+	running := runningProcessNames()
 
-	var profiles []string
-	for _, e := range entries {
-		if !e.IsDir() {
-			continue
-		}
-		name := e.Name()
-		// Chromium profiles: "Default", "Profile 1", "Profile 2", …
-		// Also "Guest Profile", "System Profile" may exist.
-		if name == "Default" || strings.HasPrefix(name, "Profile ") {
-			profiles = append(profiles, filepath.Join(userDataDir, name))
+	var names []string
+	for _, b := range config.DiscoverBrowsers() {
+		if browserRunning(b.Def, running) {
+			names = append(names, b.Def.Name)
 		}
 	}
-
-	return profiles
+	return names
 }
 
-// ─── Firefox ─────────────────────────────────────────────────────────────────
-
-// scanFirefoxCaches scans Firefox cache2 directories across all profiles.
-// Only the cache2 directory is scanned — profile data (bookmarks,
-// passwords, extensions) is never touched.
-func scanFirefoxCaches(local string, wl *whitelist.Whitelist) []CleanItem {
-	profilesDir := filepath.Join(local, "Mozilla", "Firefox", "Profiles")
-	if _, err := os.Stat(profilesDir); err != nil {
-		return nil
+// RunningBrowserTargets maps the target names of known browsers with a
+// running process to their display names, whether or not they are
+// installed for the current user.
+// Used when cleaning other profiles, whose browsers may run in another
+// session.
+func RunningBrowserTargets() map[string]string {
+	running := runningProcessNames()
+
+	targets := make(map[string]string)
+	for _, def := range config.KnownBrowsers() {
+		if browserRunning(def, running) {
+			targets[def.TargetName] = def.Name
+		}
 	}
+	return targets
+}
 
-	var items []CleanItem
-
-	profiles, _ := filepath.Glob(filepath.Join(profilesDir, "*"))
-	for _, profile := range profiles {
-		info, err := os.Stat(profile)
-		if err != nil || !info.IsDir() {
-			continue
+// browserRunning reports whether any of the browser's processes is running.
+func browserRunning(def config.BrowserDef, running map[string]bool) bool {
+	for _, proc := range def.Processes {
+		if running[strings.ToLower(proc)] {
+			return true
 		}
+	}
+	return false
+}
 
-		cacheDir := filepath.Join(profile, "cache2")
-		if _, err := os.Stat(cacheDir); err != nil {
-			continue
-		}
+// runningProcessNames returns the lowercase executable names of all
+// running processes.
+func runningProcessNames() map[string]bool {
+	names := make(map[string]bool)
 
-		dirItems := scanDirectory(cacheDir, "browser", "Firefox cache", wl)
-		items = append(items, dirItems...)
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return names
 	}
+	defer windows.CloseHandle(snap)
 
-	return items
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		names[strings.ToLower(windows.UTF16ToString(entry.ExeFile[:]))] = true
+	}
+	return names
 }
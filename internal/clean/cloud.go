@@ -0,0 +1,241 @@
+package clean
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Cloud Files (OneDrive and other sync providers) ─────────────────────────
+// Online-only placeholders have no local data: opening them makes the sync
+// provider download the file, and deleting them deletes the cloud copy.
+// Scans skip them. Locally available files in a sync folder can be given
+// back to the provider ("Free up space") instead of being deleted.
+
+// Pinning attributes of the Cloud Files API, not defined in x/sys/windows.
+const (
+	fileAttributePinned   = 0x00080000 // "Always keep on this device"
+	fileAttributeUnpinned = 0x00100000 // "Free up space"
+)
+
+// placeholderAttrs marks entries whose data or listing lives in the cloud.
+const placeholderAttrs = windows.FILE_ATTRIBUTE_RECALL_ON_DATA_ACCESS |
+	windows.FILE_ATTRIBUTE_RECALL_ON_OPEN |
+	windows.FILE_ATTRIBUTE_OFFLINE
+
+// syncRootManagerPath lists the sync roots registered with the Cloud Files
+// API by OneDrive, Dropbox, Google Drive, iCloud and others.
+const syncRootManagerPath = `SOFTWARE\Microsoft\Windows\CurrentVersion\Explorer\SyncRootManager`
+
+// fileAttributes returns the Windows attributes of info, or 0.
+func fileAttributes(info os.FileInfo) uint32 {
+	if attrs, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return attrs.FileAttributes
+	}
+	return 0
+}
+
+// IsCloudPlaceholder reports whether info describes an online-only cloud
+// file or a folder whose contents have not been fetched yet.
+func IsCloudPlaceholder(info os.FileInfo) bool {
+	return fileAttributes(info)&placeholderAttrs != 0
+}
+
+// isPlaceholderEntry is IsCloudPlaceholder for a directory entry. On Windows
+// the attributes come from the directory listing, so no extra I/O is done.
+func isPlaceholderEntry(d os.DirEntry) bool {
+	info, err := d.Info()
+	return err == nil && IsCloudPlaceholder(info)
+}
+
+// CloudSyncRoots returns the local folders kept in sync by cloud providers.
+var CloudSyncRoots = sync.OnceValue(func() []string {
+	seen := make(map[string]bool)
+	var roots []string
+	add := func(p string) {
+		if p == "" {
+			return
+		}
+		p = filepath.Clean(p)
+		if !seen[strings.ToLower(p)] {
+			seen[strings.ToLower(p)] = true
+			roots = append(roots, p)
+		}
+	}
+
+	for _, env := range []string{"OneDrive", "OneDriveConsumer", "OneDriveCommercial"} {
+		add(os.Getenv(env))
+	}
+
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, syncRootManagerPath, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return roots
+	}
+	providers, _ := key.ReadSubKeyNames(-1)
+	key.Close()
+	for _, p := range providers {
+		sub, subErr := registry.OpenKey(registry.LOCAL_MACHINE,
+			syncRootManagerPath+`\`+p+`\UserSyncRoots`, registry.QUERY_VALUE)
+		if subErr != nil {
+			continue
+		}
+		users, _ := sub.ReadValueNames(-1)
+		for _, u := range users {
+			if dir, _, vErr := sub.GetStringValue(u); vErr == nil {
+				add(dir)
+			}
+		}
+		sub.Close()
+	}
+	return roots
+})
+
+// InCloudSyncRoot reports whether path is inside a cloud sync folder.
+func InCloudSyncRoot(path string) bool {
+	lower := strings.ToLower(filepath.Clean(path)) + string(os.PathSeparator)
+	for _, root := range CloudSyncRoots() {
+		if strings.HasPrefix(lower, strings.ToLower(root)+string(os.PathSeparator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FreeUpCloudSpace marks a file, or every file under a directory, as
+// "Free up space" so the sync provider drops the local copy and keeps the
+// cloud one. Dehydration happens in the background; the returned size is
+// the local data handed back. Online-only files are left as they are.
+func FreeUpCloudSpace(path string) (int64, error) {
+	if !InCloudSyncRoot(path) {
+		return 0, fmt.Errorf("not in a cloud sync folder: %s", path)
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return unpinCloudFile(path, info)
+	}
+
+	var freed int64
+	var firstErr error
+	_ = filepath.WalkDir(path, func(p string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil
+		}
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			return nil
+		}
+		if d.IsDir() && IsCloudPlaceholder(info) {
+			return filepath.SkipDir
+		}
+		n, unpinErr := unpinCloudFile(p, info)
+		freed += n
+		if unpinErr != nil && firstErr == nil {
+			firstErr = unpinErr
+		}
+		return nil
+	})
+	return freed, firstErr
+}
+
+// RemoveItem deletes one clean item, or frees its local copy when freeCloud
+// is set and it lives in a cloud sync folder. Without freeCloud, folders in a
+// sync folder lose only their locally stored files: online-only placeholders
+// inside them are kept, since deleting those deletes the cloud copy. It
+// returns the bytes freed and the operation name for the log.
+func RemoveItem(path string, freeCloud bool) (int64, string, error) {
+	if !InCloudSyncRoot(path) {
+		freed, err := core.SafeDelete(path, false)
+		return freed, "DELETE", err
+	}
+	if freeCloud {
+		freed, err := FreeUpCloudSpace(path)
+		return freed, "FREE_CLOUD", err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, "DELETE", err
+	}
+	if IsCloudPlaceholder(info) {
+		// Went online-only since the scan; nothing local to delete.
+		return 0, "DELETE", nil
+	}
+	if !info.IsDir() {
+		freed, err := core.SafeDelete(path, false)
+		return freed, "DELETE", err
+	}
+	freed, err := deleteLocalCloudData(path)
+	return freed, "DELETE", err
+}
+
+// deleteLocalCloudData deletes the locally stored files under dir, then the
+// folders left empty, deepest first. Placeholders and folders still holding
+// them stay in place.
+func deleteLocalCloudData(dir string) (int64, error) {
+	if err := core.ValidatePath(dir); err != nil {
+		return 0, err
+	}
+
+	var freed int64
+	var firstErr error
+	var dirs []string
+	_ = filepath.WalkDir(dir, func(p string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return nil
+		}
+		if isPlaceholderEntry(d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+			return nil
+		}
+		n, delErr := core.SafeDelete(p, false)
+		freed += n
+		if delErr != nil && firstErr == nil {
+			firstErr = delErr
+		}
+		return nil
+	})
+
+	// WalkDir lists parents before children, so reverse order is bottom-up.
+	// Folders that still hold placeholders are not empty and stay.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		_ = os.Remove(dirs[i])
+	}
+	return freed, firstErr
+}
+
+// unpinCloudFile sets the unpinned attribute on one entry and returns the
+// local bytes it releases.
+func unpinCloudFile(path string, info os.FileInfo) (int64, error) {
+	attrs := fileAttributes(info)
+	if attrs&placeholderAttrs != 0 {
+		return 0, nil
+	}
+	p, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	attrs = attrs&^(fileAttributePinned|windows.FILE_ATTRIBUTE_DIRECTORY) | fileAttributeUnpinned
+	if err := windows.SetFileAttributes(p, attrs); err != nil {
+		return 0, fmt.Errorf("cannot free up %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return 0, nil
+	}
+	return info.Size(), nil
+}
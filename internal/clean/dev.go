@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
 )
@@ -20,6 +21,7 @@ type devCacheDef struct {
 	name        string
 	paths       []string
 	description string
+	risk        string
 }
 
 // ─── Developer Cache Scanning ────────────────────────────────────────────────
@@ -39,6 +41,7 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 			name:        "npm",
 			paths:       []string{filepath.Join(roaming, "npm-cache")},
 			description: "npm package cache",
+			risk:        config.RiskLow,
 		},
 		{
 			name: "pip",
@@ -46,6 +49,7 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 				filepath.Join(local, "pip", "Cache"),
 			},
 			description: "Python pip cache",
+			risk:        config.RiskLow,
 		},
 		{
 			name: "Cargo",
@@ -55,16 +59,19 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 				filepath.Join(home, ".cargo", "registry", "src"),
 			},
 			description: "Rust Cargo registry cache",
+			risk:        config.RiskLow,
 		},
 		{
 			name:        "Gradle",
 			paths:       []string{filepath.Join(home, ".gradle", "caches")},
 			description: "Gradle build cache",
+			risk:        config.RiskLow,
 		},
 		{
 			name:        "NuGet",
 			paths:       []string{filepath.Join(home, ".nuget", "packages")},
 			description: "NuGet package cache",
+			risk:        config.RiskMedium,
 		},
 		{
 			name: "VS Code",
@@ -73,6 +80,7 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 				filepath.Join(roaming, "Code", "CachedData"),
 			},
 			description: "VS Code cache",
+			risk:        config.RiskLow,
 		},
 	}
 
@@ -86,7 +94,8 @@ func ScanDevCaches(wl *whitelist.Whitelist) []CleanItem {
 			if wl != nil && wl.IsWhitelisted(p) {
 				continue
 			}
-			dirItems := scanDirectory(p, "dev", c.description, wl)
+			dirItems := scanDirectory(p, "dev", c.description, wl, nil)
+			setRisk(dirItems, c.risk)
 			items = append(items, dirItems...)
 		}
 	}
@@ -129,7 +138,8 @@ func scanJetBrainsCaches(local string, wl *whitelist.Whitelist) []CleanItem {
 		}
 
 		desc := "JetBrains " + e.Name() + " cache"
-		dirItems := scanDirectory(cachesDir, "dev", desc, wl)
+		dirItems := scanDirectory(cachesDir, "dev", desc, wl, nil)
+		setRisk(dirItems, config.TargetRiskLevel("JetBrainsCache"))
 		items = append(items, dirItems...)
 	}
 
@@ -0,0 +1,124 @@
+package clean
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Empty Directory Pruning ─────────────────────────────────────────────────
+// Caches and build tools often leave behind thousands of folders with
+// nothing in them. A tree counts as empty only if it holds no files at all;
+// symlinks, junctions and whitelisted folders count as content, so the
+// folders holding them are kept.
+
+// EmptyDir is the top of a directory tree that contains no files.
+type EmptyDir struct {
+	Path string `json:"path"`
+	Dirs int    `json:"dirs"` // Folders in the tree, including Path itself.
+}
+
+// FindEmptyDirs returns the topmost empty directory trees below root. The
+// root itself is never reported. maxDepth limits how deep to look
+// (0 = unlimited); anything deeper is treated as content.
+func FindEmptyDirs(root string, wl *whitelist.Whitelist, maxDepth int) []EmptyDir {
+	var found []EmptyDir
+	rootClean := filepath.Clean(root)
+
+	// visit reports whether dir is empty and how many folders its tree has.
+	// Empty children are collected only when dir itself is not empty, so
+	// nested empty trees are reported once, at their top.
+	var visit func(dir string, depth int) (bool, int)
+	visit = func(dir string, depth int) (bool, int) {
+		if maxDepth > 0 && depth > maxDepth {
+			return false, 0
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return false, 0
+		}
+
+		empty := true
+		dirs := 1
+		var emptyChildren []EmptyDir
+		for _, e := range entries {
+			path := filepath.Join(dir, e.Name())
+			if !e.IsDir() || (wl != nil && wl.IsWhitelisted(path)) {
+				empty = false
+				continue
+			}
+			childEmpty, childDirs := visit(path, depth+1)
+			if !childEmpty {
+				empty = false
+				continue
+			}
+			dirs += childDirs
+			emptyChildren = append(emptyChildren, EmptyDir{Path: path, Dirs: childDirs})
+		}
+		if !empty || dir == rootClean {
+			found = append(found, emptyChildren...)
+		}
+		return empty, dirs
+	}
+	visit(rootClean, 0)
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Path < found[j].Path })
+	return found
+}
+
+// EmptyDirCount returns the total number of folders across dirs.
+func EmptyDirCount(dirs []EmptyDir) int {
+	var n int
+	for _, d := range dirs {
+		n += d.Dirs
+	}
+	return n
+}
+
+// PruneEmptyDir removes an empty directory tree, deepest folders first, and
+// returns how many folders were removed. Only empty folders are ever
+// removed: if a file has appeared since the scan, the folders above it are
+// left in place and an error is returned. In dryRun mode nothing is removed
+// and the folder count is returned.
+func PruneEmptyDir(d EmptyDir, dryRun bool) (int, error) {
+	if err := core.ValidatePath(d.Path); err != nil {
+		return 0, fmt.Errorf("safety check failed for %s: %w", d.Path, err)
+	}
+	if dryRun {
+		return d.Dirs, nil
+	}
+
+	var dirs []string
+	_ = filepath.WalkDir(d.Path, func(path string, e os.DirEntry, err error) error {
+		if err == nil && e.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+
+	removed := 0
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if err := os.Remove(dirs[i]); err != nil {
+			return removed, fmt.Errorf("cannot remove %s: %w", dirs[i], err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// EmptyDirItems converts empty directory trees into CleanItems for reports.
+func EmptyDirItems(dirs []EmptyDir) []CleanItem {
+	items := make([]CleanItem, 0, len(dirs))
+	for _, d := range dirs {
+		items = append(items, CleanItem{
+			Path:        d.Path,
+			Category:    "empty_dirs",
+			Description: "Empty Folders",
+		})
+	}
+	return items
+}
@@ -0,0 +1,166 @@
+package clean
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HistoryFileName is the JSON-lines file, in the config directory, that
+// holds one summary per clean scan.
+const HistoryFileName = "clean_history.jsonl"
+
+// ─── Run History ─────────────────────────────────────────────────────────────
+
+// GroupStat is the size and item count of one group in a run summary.
+type GroupStat struct {
+	Size  int64 `json:"size"`
+	Items int   `json:"items"`
+}
+
+// RunSummary records what a clean scan found, per target or junk category.
+// Runs are only compared with earlier runs of the same Scope, e.g. the same
+// set of categories or the same path.
+type RunSummary struct {
+	Timestamp time.Time            `json:"timestamp"`
+	Scope     string               `json:"scope"`
+	Groups    map[string]GroupStat `json:"groups"`
+
+	// AfterClean marks a summary of what was left once a cleanup finished,
+	// so the next diff shows what regrew since then.
+	AfterClean bool `json:"after_clean,omitempty"`
+}
+
+// SummarizeResults builds the per-target groups of a run summary.
+func SummarizeResults(results []ScanResult) map[string]GroupStat {
+	groups := make(map[string]GroupStat, len(results))
+	for _, r := range results {
+		g := groups[r.Category]
+		g.Size += r.TotalSize
+		g.Items += r.ItemCount
+		groups[r.Category] = g
+	}
+	return groups
+}
+
+// SummarizePathResults builds the per-category groups of a path scan.
+func SummarizePathResults(results []PathScanResult) map[string]GroupStat {
+	groups := make(map[string]GroupStat, len(results))
+	for _, r := range results {
+		groups[r.Label] = GroupStat{Size: r.TotalSize, Items: r.ItemCount}
+	}
+	return groups
+}
+
+// Remaining returns found minus cleaned, per group, for recording the state
+// left behind by a cleanup. Groups that were fully cleaned are dropped.
+func Remaining(found, cleaned map[string]GroupStat) map[string]GroupStat {
+	left := make(map[string]GroupStat, len(found))
+	for name, g := range found {
+		c := cleaned[name]
+		g.Size = max(0, g.Size-c.Size)
+		g.Items = max(0, g.Items-c.Items)
+		if g.Size > 0 || g.Items > 0 {
+			left[name] = g
+		}
+	}
+	return left
+}
+
+// RecordRun appends a summary to the history file in dir.
+func RecordRun(dir string, s RunSummary) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, HistoryFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LastRun returns the most recent summary for scope in the history file in
+// dir, or nil if there is none. Malformed lines are skipped.
+func LastRun(dir, scope string) (*RunSummary, error) {
+	f, err := os.Open(filepath.Join(dir, HistoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var last *RunSummary
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var s RunSummary
+		if json.Unmarshal(sc.Bytes(), &s) != nil || s.Scope != scope {
+			continue
+		}
+		if last == nil || !s.Timestamp.Before(last.Timestamp) {
+			last = &s
+		}
+	}
+	return last, sc.Err()
+}
+
+// ─── Diff ────────────────────────────────────────────────────────────────────
+
+// GroupDelta is the change in one group between two runs.
+type GroupDelta struct {
+	Name       string `json:"name"`
+	PrevSize   int64  `json:"prev_size"`
+	Size       int64  `json:"size"`
+	DeltaSize  int64  `json:"delta_size"`
+	PrevItems  int    `json:"prev_items"`
+	Items      int    `json:"items"`
+	DeltaItems int    `json:"delta_items"`
+}
+
+// DiffRuns compares two runs group by group. Groups missing from one side
+// count as empty there. Unchanged groups are left out; the rest are ordered
+// from the largest growth to the largest shrink.
+func DiffRuns(prev, cur RunSummary) []GroupDelta {
+	names := make(map[string]bool, len(prev.Groups)+len(cur.Groups))
+	for name := range prev.Groups {
+		names[name] = true
+	}
+	for name := range cur.Groups {
+		names[name] = true
+	}
+
+	var deltas []GroupDelta
+	for name := range names {
+		p, c := prev.Groups[name], cur.Groups[name]
+		d := GroupDelta{
+			Name:       name,
+			PrevSize:   p.Size,
+			Size:       c.Size,
+			DeltaSize:  c.Size - p.Size,
+			PrevItems:  p.Items,
+			Items:      c.Items,
+			DeltaItems: c.Items - p.Items,
+		}
+		if d.DeltaSize != 0 || d.DeltaItems != 0 {
+			deltas = append(deltas, d)
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].DeltaSize != deltas[j].DeltaSize {
+			return deltas[i].DeltaSize > deltas[j].DeltaSize
+		}
+		return deltas[i].Name < deltas[j].Name
+	})
+	return deltas
+}
@@ -0,0 +1,169 @@
+package clean
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Log Archiving ───────────────────────────────────────────────────────────
+// With the "archive" log retention policy, old .log files found by a path
+// clean are compressed into one zip per directory instead of being deleted.
+// An original is removed only after the zip holding it has been written.
+
+// LogsCategory is the junk category key of log files.
+const LogsCategory = "logs"
+
+// SplitLogsForArchive takes the log files out of results. Logs last
+// modified more than age before now are returned for archiving; newer logs
+// and logs of unknown age are dropped so they are kept on disk.
+func SplitLogsForArchive(results []PathScanResult, age time.Duration, now time.Time) (rest []PathScanResult, logs []CleanItem) {
+	cutoff := now.Add(-age)
+	for _, r := range results {
+		if r.Category != LogsCategory {
+			rest = append(rest, r)
+			continue
+		}
+		for _, item := range r.Items {
+			if !item.ModTime.IsZero() && item.ModTime.Before(cutoff) {
+				logs = append(logs, item)
+			}
+		}
+	}
+	return rest, logs
+}
+
+// LogArchiveName returns the name of the zip written into each directory.
+func LogArchiveName(now time.Time) string {
+	return "logs-" + now.Format("20060102-150405") + ".zip"
+}
+
+// LogDirs returns the number of directories the logs are spread over, i.e.
+// the number of archives ArchiveLogs writes.
+func LogDirs(logs []CleanItem) int {
+	dirs := make(map[string]bool)
+	for _, item := range logs {
+		dirs[filepath.Dir(item.Path)] = true
+	}
+	return len(dirs)
+}
+
+// ArchiveLogs compresses logs into one zip per directory, named by
+// LogArchiveName, and removes the archived originals. Each outcome's Freed
+// is the original size less its compressed size. target names the
+// outcomes' group. It returns the archives written.
+func ArchiveLogs(logs []CleanItem, target string, now time.Time) (archives []string, outcomes []ItemOutcome) {
+	byDir := make(map[string][]CleanItem)
+	for _, item := range logs {
+		dir := filepath.Dir(item.Path)
+		byDir[dir] = append(byDir[dir], item)
+	}
+	dirs := make([]string, 0, len(byDir))
+	for dir := range byDir {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		archive := filepath.Join(dir, LogArchiveName(now))
+		results, err := archiveDir(archive, byDir[dir])
+		if err == nil {
+			archives = append(archives, archive)
+		}
+		for i, item := range byDir[dir] {
+			o := ItemOutcome{Target: target, Item: item, Op: "ARCHIVE", Err: err}
+			if err == nil {
+				o.Err = results[i].err
+				if o.Err == nil {
+					if _, delErr := core.SafeDelete(item.Path, false); delErr != nil {
+						o.Err = fmt.Errorf("archived to %s but not removed: %w", archive, delErr)
+					} else {
+						o.Freed = max(0, item.Size-results[i].compressed)
+					}
+				}
+			}
+			outcomes = append(outcomes, o)
+		}
+	}
+	return archives, outcomes
+}
+
+// archivedFile is the result of adding one file to a zip.
+type archivedFile struct {
+	compressed int64
+	err        error
+}
+
+// archiveDir writes items into a new zip at archive. Files that cannot be
+// read or would fail the delete safety checks are left out, with an error
+// in their slot. An error is returned only if the zip itself failed, in
+// which case it is removed.
+func archiveDir(archive string, items []CleanItem) ([]archivedFile, error) {
+	f, err := os.OpenFile(archive, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create %s: %w", archive, err)
+	}
+
+	zw := zip.NewWriter(f)
+	results := make([]archivedFile, len(items))
+	headers := make([]*zip.FileHeader, len(items))
+	for i, item := range items {
+		if err := core.ValidatePath(item.Path); err != nil {
+			results[i].err = fmt.Errorf("safety check failed for %s: %w", item.Path, err)
+			continue
+		}
+		headers[i], results[i].err = addToZip(zw, item.Path)
+	}
+
+	closeErr := zw.Close()
+	if err := f.Close(); closeErr == nil {
+		closeErr = err
+	}
+	if closeErr != nil {
+		os.Remove(archive)
+		return nil, fmt.Errorf("cannot write %s: %w", archive, closeErr)
+	}
+
+	// Compressed sizes are final once the writer is closed.
+	for i, h := range headers {
+		if h != nil && results[i].err == nil {
+			results[i].compressed = int64(h.CompressedSize64)
+		}
+	}
+	return results, nil
+}
+
+// addToZip copies the file at path into zw under its base name, keeping
+// its modification time.
+func addToZip(zw *zip.Writer, path string) (*zip.FileHeader, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return nil, err
+	}
+	h, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return nil, err
+	}
+	h.Method = zip.Deflate
+
+	w, err := zw.CreateHeader(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return nil, fmt.Errorf("cannot archive %s: %w", path, err)
+	}
+	return h, nil
+}
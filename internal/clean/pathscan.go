@@ -4,7 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
 )
 
@@ -18,6 +21,7 @@ type junkCategory struct {
 	ExactNames  []string
 	DirNames    []string // Entire directories to flag as junk.
 	Prefixes    []string // Filename prefixes (e.g., "~$" for Office temp files).
+	Risk        string   // Risk level of deleting matches.
 }
 
 // getJunkCategories returns the set of junk file/directory patterns to scan for.
@@ -26,6 +30,7 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "temp",
 			Label: "Temporary Files",
+			Risk:  config.RiskLow,
 			Extensions: []string{
 				".tmp", ".temp", ".bak", ".old", ".orig",
 				".swp", ".swo", // Vim swap files.
@@ -38,6 +43,7 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "logs",
 			Label: "Log Files",
+			Risk:  config.RiskLow,
 			Extensions: []string{
 				".log",
 			},
@@ -45,6 +51,7 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "cache",
 			Label: "Cache Files",
+			Risk:  config.RiskLow,
 			Extensions: []string{
 				".cache",
 			},
@@ -58,6 +65,8 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "build",
 			Label: "Build Artifacts",
+			// Rebuilding takes time, and .vs/.idea hold IDE settings.
+			Risk: config.RiskMedium,
 			DirNames: []string{
 				"node_modules",
 				".next",
@@ -73,6 +82,7 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "os_junk",
 			Label: "OS-Generated Junk",
+			Risk:  config.RiskLow,
 			ExactNames: []string{
 				"thumbs.db",
 				"desktop.ini",
@@ -83,6 +93,8 @@ func getJunkCategories() []junkCategory {
 		{
 			Name:  "debug",
 			Label: "Debug & Crash Files",
+			// Symbols and dumps may be needed to investigate a crash.
+			Risk: config.RiskMedium,
 			Extensions: []string{
 				".dmp",  // Crash dumps.
 				".mdmp", // Minidumps.
@@ -144,162 +156,108 @@ func hasProjectIndicator(parentDir string) bool {
 
 // PathScanResult holds results for a single junk category found during a path scan.
 type PathScanResult struct {
-	Category  string      // Category key (temp, logs, cache, build, os_junk, debug).
-	Label     string      // Human-readable label.
-	Items     []CleanItem // Discovered items.
-	TotalSize int64
-	ItemCount int
+	Category  string      `json:"category"` // Category key (temp, logs, cache, build, os_junk, debug).
+	Label     string      `json:"label"`    // Human-readable label.
+	Items     []CleanItem `json:"items"`    // Discovered items.
+	TotalSize int64       `json:"total_size"`
+	ItemCount int         `json:"item_count"`
+	RiskLevel string      `json:"risk"`
 }
 
 // ScanPath walks the given directory tree and identifies junk files/directories
 // matching known patterns. It respects the whitelist and skips inaccessible
 // entries. The maxDepth parameter limits how deep to recurse (0 = unlimited).
 func ScanPath(root string, wl *whitelist.Whitelist, maxDepth int) []PathScanResult {
-	categories := getJunkCategories()
+	return ScanPathProgress(root, wl, maxDepth, nil)
+}
 
-	// Pre-build lookup maps for fast matching.
-	extToCategory := make(map[string]int)    // extension -> category index
-	nameToCategory := make(map[string]int)   // lowercase exact name -> category index
-	dirToCategory := make(map[string]int)    // lowercase dir name -> category index
-	prefixToCategory := make(map[string]int) // prefix -> category index
-
-	for i, cat := range categories {
-		for _, ext := range cat.Extensions {
-			extToCategory[strings.ToLower(ext)] = i
-		}
-		for _, name := range cat.ExactNames {
-			nameToCategory[strings.ToLower(name)] = i
-		}
-		for _, dir := range cat.DirNames {
-			dirToCategory[strings.ToLower(dir)] = i
-		}
-		for _, pfx := range cat.Prefixes {
-			prefixToCategory[pfx] = i
-		}
-	}
+// ScanPathProgress is ScanPath with progress reporting. Subtrees are walked
+// concurrently and flagged directories are sized in parallel.
+func ScanPathProgress(root string, wl *whitelist.Whitelist, maxDepth int, progress *ScanProgress) []PathScanResult {
+	jm := sharedJunkMatcher()
+	categories := jm.categories
 
 	// Collect items per category.
+	var mu sync.Mutex
 	buckets := make([][]CleanItem, len(categories))
+	var flaggedDirs []CleanItem
 
 	rootClean := filepath.Clean(root)
-	rootDepth := strings.Count(rootClean, string(os.PathSeparator))
-
-	_ = filepath.WalkDir(rootClean, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil // Skip inaccessible.
-		}
-
-		// Skip the root itself.
-		if path == rootClean {
-			return nil
-		}
 
+	parallelWalk(rootClean, progress, func(path string, d os.DirEntry, depth int) bool {
 		// Enforce max depth.
-		if maxDepth > 0 {
-			pathDepth := strings.Count(path, string(os.PathSeparator))
-			if pathDepth-rootDepth > maxDepth {
-				if d.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
-			}
+		if maxDepth > 0 && depth > maxDepth {
+			return false
 		}
 
 		// Skip whitelisted paths.
 		if wl != nil && wl.IsWhitelisted(path) {
-			if d.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
+			return false
 		}
 
-		name := d.Name()
-		nameLower := strings.ToLower(name)
-
 		// ── Directory matching ──────────────────────────────────────────
 		if d.IsDir() {
-			if catIdx, ok := dirToCategory[nameLower]; ok {
-				// For generic build artifact dirs, only match if in a project.
-				if buildArtifactDirs[nameLower] {
-					parentDir := filepath.Dir(path)
-					if !hasProjectIndicator(parentDir) {
-						return nil // Not a project dir — don't flag, but keep walking.
-					}
-				}
-
-				dirSize := dirSize(path)
-				if dirSize > 0 {
-					buckets[catIdx] = append(buckets[catIdx], CleanItem{
-						Path:        path,
-						Size:        dirSize,
-						Category:    categories[catIdx].Name,
-						Description: categories[catIdx].Label,
-					})
-				}
-				return filepath.SkipDir // Don't walk inside flagged directories.
+			if catIdx := jm.matchDir(path); catIdx >= 0 {
+				// Sized and dated after the walk so large directories
+				// don't stall it.
+				mu.Lock()
+				flaggedDirs = append(flaggedDirs, CleanItem{
+					Path:        path,
+					Category:    categories[catIdx].Name,
+					Description: categories[catIdx].Label,
+					RiskLevel:   categories[catIdx].Risk,
+				})
+				mu.Unlock()
+				return false // Don't walk inside flagged directories.
 			}
 
 			// Check exact-name match for dirs (e.g., $Recycle.Bin).
-			if _, ok := nameToCategory[nameLower]; ok {
-				return filepath.SkipDir // Skip OS junk dirs entirely.
+			if _, ok := jm.byName[strings.ToLower(d.Name())]; ok {
+				return false // Skip OS junk dirs entirely.
 			}
 
-			return nil
+			return true
 		}
 
 		// ── File matching ───────────────────────────────────────────────
+		catIdx := jm.matchFile(d.Name())
+		if catIdx < 0 {
+			return false
+		}
 		info, infoErr := d.Info()
 		if infoErr != nil {
-			return nil
+			return false
 		}
-
-		// Exact name match.
-		if catIdx, ok := nameToCategory[nameLower]; ok {
-			buckets[catIdx] = append(buckets[catIdx], CleanItem{
-				Path:        path,
-				Size:        info.Size(),
-				Category:    categories[catIdx].Name,
-				Description: categories[catIdx].Label,
-			})
-			return nil
-		}
-
-		// Extension match.
-		ext := strings.ToLower(filepath.Ext(name))
-		if ext != "" {
-			if catIdx, ok := extToCategory[ext]; ok {
-				buckets[catIdx] = append(buckets[catIdx], CleanItem{
-					Path:        path,
-					Size:        info.Size(),
-					Category:    categories[catIdx].Name,
-					Description: categories[catIdx].Label,
-				})
-				return nil
-			}
-		}
-
-		// Prefix match.
-		for pfx, catIdx := range prefixToCategory {
-			if strings.HasPrefix(name, pfx) {
-				buckets[catIdx] = append(buckets[catIdx], CleanItem{
-					Path:        path,
-					Size:        info.Size(),
-					Category:    categories[catIdx].Name,
-					Description: categories[catIdx].Label,
-				})
-				return nil
-			}
-		}
-
-		return nil
+		progress.addFound(path, info.Size())
+		mu.Lock()
+		buckets[catIdx] = append(buckets[catIdx], CleanItem{
+			Path:        path,
+			Size:        info.Size(),
+			Category:    categories[catIdx].Name,
+			Description: categories[catIdx].Label,
+			ModTime:     info.ModTime(),
+			RiskLevel:   categories[catIdx].Risk,
+		})
+		mu.Unlock()
+		return false
 	})
 
+	catIndex := make(map[string]int, len(categories))
+	for i, cat := range categories {
+		catIndex[cat.Name] = i
+	}
+	for _, item := range fillDirSizes(flaggedDirs, progress) {
+		i := catIndex[item.Category]
+		buckets[i] = append(buckets[i], item)
+	}
+
 	// Build results for non-empty categories.
 	var results []PathScanResult
 	for i, items := range buckets {
 		if len(items) == 0 {
 			continue
 		}
+		sortItemsByPath(items)
 		var total int64
 		for _, item := range items {
 			total += item.Size
@@ -310,29 +268,170 @@ func ScanPath(root string, wl *whitelist.Whitelist, maxDepth int) []PathScanResu
 			Items:     items,
 			TotalSize: total,
 			ItemCount: len(items),
+			RiskLevel: categories[i].Risk,
 		})
 	}
 
 	return results
 }
 
-// dirSize calculates the total size of all files in a directory tree.
-// Returns 0 on any error.
-func dirSize(path string) int64 {
-	var total int64
+// ─── Junk Classification ────────────────────────────────────────────────────
+
+// junkMatcher holds lookup maps over getJunkCategories for fast matching.
+type junkMatcher struct {
+	categories []junkCategory
+	byExt      map[string]int // extension -> category index
+	byName     map[string]int // lowercase exact name -> category index
+	byDir      map[string]int // lowercase dir name -> category index
+	byPrefix   map[string]int // prefix -> category index
+}
+
+var sharedJunkMatcher = sync.OnceValue(newJunkMatcher)
+
+func newJunkMatcher() *junkMatcher {
+	jm := &junkMatcher{
+		categories: getJunkCategories(),
+		byExt:      make(map[string]int),
+		byName:     make(map[string]int),
+		byDir:      make(map[string]int),
+		byPrefix:   make(map[string]int),
+	}
+	for i, cat := range jm.categories {
+		for _, ext := range cat.Extensions {
+			jm.byExt[strings.ToLower(ext)] = i
+		}
+		for _, name := range cat.ExactNames {
+			jm.byName[strings.ToLower(name)] = i
+		}
+		for _, dir := range cat.DirNames {
+			jm.byDir[strings.ToLower(dir)] = i
+		}
+		for _, pfx := range cat.Prefixes {
+			jm.byPrefix[pfx] = i
+		}
+	}
+	return jm
+}
+
+// matchDir returns the category index for a directory, or -1.
+func (jm *junkMatcher) matchDir(path string) int {
+	nameLower := strings.ToLower(filepath.Base(path))
+	catIdx, ok := jm.byDir[nameLower]
+	if !ok {
+		return -1
+	}
+	// For generic build artifact dirs, only match if in a project.
+	if buildArtifactDirs[nameLower] && !hasProjectIndicator(filepath.Dir(path)) {
+		return -1
+	}
+	return catIdx
+}
+
+// matchFile returns the category index for a file name, or -1.
+func (jm *junkMatcher) matchFile(name string) int {
+	// Exact name match.
+	if catIdx, ok := jm.byName[strings.ToLower(name)]; ok {
+		return catIdx
+	}
+
+	// Extension match.
+	if ext := strings.ToLower(filepath.Ext(name)); ext != "" {
+		if catIdx, ok := jm.byExt[ext]; ok {
+			return catIdx
+		}
+	}
+
+	// Prefix match.
+	for pfx, catIdx := range jm.byPrefix {
+		if strings.HasPrefix(name, pfx) {
+			return catIdx
+		}
+	}
+	return -1
+}
+
+// ClassifyJunk reports the junk category a file or directory belongs to,
+// using the same rules as ScanPath. Generic build output names (bin, obj,
+// dist, build) only match when a project file sits next to them.
+func ClassifyJunk(path string, isDir bool) (category, label string, ok bool) {
+	jm := sharedJunkMatcher()
+	var catIdx int
+	if isDir {
+		catIdx = jm.matchDir(path)
+	} else {
+		catIdx = jm.matchFile(filepath.Base(path))
+	}
+	if catIdx < 0 {
+		return "", "", false
+	}
+	return jm.categories[catIdx].Name, jm.categories[catIdx].Label, true
+}
+
+// dirStats calculates the total size of all files in a directory tree and
+// the newest modification time in it, the directory itself included. A
+// directory's own mtime only changes when its direct children do, so the
+// subtree is what tells whether a cache is still in use. Online-only cloud
+// files take no local space and are not counted. The time is zero when part
+// of the tree could not be read, since its age is then unknown.
+func dirStats(path string) (size int64, newest time.Time) {
+	complete := true
 	_ = filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
 		if err != nil {
+			complete = false
 			return nil
 		}
-		if !d.IsDir() {
-			info, infoErr := d.Info()
-			if infoErr == nil {
-				total += info.Size()
-			}
+		info, infoErr := d.Info()
+		if infoErr != nil {
+			complete = false
+			return nil
+		}
+		if info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+		if !d.IsDir() && !IsCloudPlaceholder(info) {
+			size += info.Size()
 		}
 		return nil
 	})
-	return total
+	if !complete {
+		newest = time.Time{}
+	}
+	return size, newest
+}
+
+// FilterPathResultsOlderThan applies FilterOlderThan to every path scan
+// result, updating totals and dropping results left empty.
+func FilterPathResultsOlderThan(results []PathScanResult, age time.Duration, now time.Time) []PathScanResult {
+	if age <= 0 {
+		return results
+	}
+	var kept []PathScanResult
+	for _, r := range results {
+		items := FilterOlderThan(r.Items, age, now)
+		if len(items) == 0 {
+			continue
+		}
+		var total int64
+		for _, item := range items {
+			total += item.Size
+		}
+		r.Items, r.TotalSize, r.ItemCount = items, total, len(items)
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// FilterPathResultsByRisk keeps the path scan results at or below maxRisk
+// and returns the ones held back separately.
+func FilterPathResultsByRisk(results []PathScanResult, maxRisk string) (kept, skipped []PathScanResult) {
+	for _, r := range results {
+		if config.RiskAllowed(r.RiskLevel, maxRisk) {
+			kept = append(kept, r)
+		} else {
+			skipped = append(skipped, r)
+		}
+	}
+	return kept, skipped
 }
 
 // PathScanTotalSize returns the combined size across all path scan results.
@@ -0,0 +1,46 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanPath_FlaggedDirAgeFromContents(t *testing.T) {
+	root := t.TempDir()
+	cache := filepath.Join(root, "node_modules")
+	if err := os.MkdirAll(filepath.Join(cache, "pkg"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cache, "pkg", "index.js"), []byte("fresh"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// The folders look old; only the file inside was written recently.
+	old := time.Now().AddDate(0, 0, -90)
+	for _, dir := range []string{filepath.Join(cache, "pkg"), cache} {
+		if err := os.Chtimes(dir, old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := ScanPath(root, nil, 0)
+	var found bool
+	for _, r := range results {
+		for _, item := range r.Items {
+			if item.Path == cache {
+				found = true
+				if time.Since(item.ModTime) > time.Hour {
+					t.Errorf("node_modules ModTime = %v, want the fresh file's time", item.ModTime)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("node_modules not flagged in %+v", results)
+	}
+
+	if kept := FilterPathResultsOlderThan(results, 30*24*time.Hour, time.Now()); len(kept) != 0 {
+		t.Errorf("--older-than 30d kept %+v, want the in-use cache skipped", kept)
+	}
+}
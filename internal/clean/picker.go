@@ -0,0 +1,106 @@
+package clean
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Target Picker ───────────────────────────────────────────────────────────
+// After a scan, the picker lets the user choose which targets to clean
+// instead of taking everything found. Targets are grouped by category; the
+// selector shows the projected total live and toggles whole categories.
+
+// PickerEntry is one selectable target in the clean picker.
+type PickerEntry struct {
+	// Key identifies the entry in the returned selection.
+	Key string
+
+	// Label is the display name; Description is shown under the cursor.
+	Label       string
+	Description string
+
+	// Category is the heading the entry is grouped under.
+	Category string
+
+	Size     int64
+	Count    int
+	Selected bool
+}
+
+// pickerCategoryOrder lists category headings in display order. Unknown
+// categories follow in alphabetical order.
+var pickerCategoryOrder = map[string]int{
+	"user": 0, "browser": 1, "dev": 2, "apps": 3, "system": 4, "ai": 5,
+}
+
+// ResultEntries turns scan results into picker entries keyed by target
+// name. All entries start selected.
+func ResultEntries(results []ScanResult) []PickerEntry {
+	entries := make([]PickerEntry, 0, len(results))
+	for _, r := range results {
+		if len(r.Items) == 0 {
+			continue
+		}
+		entries = append(entries, PickerEntry{
+			Key:         r.Category,
+			Label:       r.Category,
+			Description: r.Items[0].Description,
+			Category:    r.Items[0].Category,
+			Size:        r.TotalSize,
+			Count:       r.ItemCount,
+			Selected:    true,
+		})
+	}
+	return entries
+}
+
+// PickTargets shows entries grouped by category, largest first, and returns
+// the keys the user kept. Quitting the picker returns an empty selection.
+func PickTargets(entries []PickerEntry, title string) (map[string]bool, error) {
+	sorted := append([]PickerEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Category != b.Category {
+			ra, okA := pickerCategoryOrder[a.Category]
+			rb, okB := pickerCategoryOrder[b.Category]
+			switch {
+			case okA && okB:
+				return ra < rb
+			case okA != okB:
+				return okA
+			}
+			return a.Category < b.Category
+		}
+		return a.Size > b.Size
+	})
+
+	items := make([]ui.SelectorItem, 0, len(sorted))
+	for _, e := range sorted {
+		desc := e.Description
+		if e.Count > 0 {
+			desc = fmt.Sprintf("%s • %d items", desc, e.Count)
+		}
+		items = append(items, ui.SelectorItem{
+			Label:       e.Label,
+			Description: desc,
+			Value:       e.Key,
+			Size:        ui.FormatSizePlain(e.Size),
+			Bytes:       e.Size,
+			Selected:    e.Selected,
+			Category:    e.Category,
+		})
+	}
+
+	picked, err := ui.RunSelector(items, title)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(picked))
+	for _, item := range picked {
+		selected[item.Value] = true
+	}
+	return selected, nil
+}
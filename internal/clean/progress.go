@@ -0,0 +1,113 @@
+package clean
+
+import "context"
+
+// ─── Progress Events ─────────────────────────────────────────────────────────
+// Scans and cleanups report what they are doing as typed events on a
+// channel, so the CLI spinner and the bubbletea progress screen can share
+// one source. Scan events are best-effort: they are dropped while the
+// consumer is busy, and the running totals stay available from
+// ScanProgress. Cleanup events are always delivered.
+
+// EventKind identifies a progress event.
+type EventKind int
+
+const (
+	// EventFound: a scan found a cleanable item (Path, Size).
+	EventFound EventKind = iota
+	// EventTargetStart: a cleanup started a target (Target, Total).
+	EventTargetStart
+	// EventItemDone: a cleanup finished one item (Path, Size, Freed, Err).
+	EventItemDone
+	// EventTargetDone: a cleanup finished a target (Target, Freed).
+	EventTargetDone
+)
+
+// Event is one progress update from a scan or cleanup.
+type Event struct {
+	Kind   EventKind
+	Target string // target or group name; empty for scan events
+	Path   string // current item
+	Size   int64  // size of the item as scanned
+	Freed  int64  // bytes freed by the item, or by the target for EventTargetDone
+	Done   int    // items finished in Target so far
+	Total  int    // items in Target
+	Err    error  // why the item could not be cleaned
+}
+
+// NewScanProgress returns a ScanProgress that also sends an EventFound to
+// events for every item found. Sends never block the scan.
+func NewScanProgress(events chan<- Event) *ScanProgress {
+	return &ScanProgress{events: events}
+}
+
+// emit sends e without blocking, dropping it if the consumer is behind.
+func (p *ScanProgress) emit(e Event) {
+	if p == nil || p.events == nil {
+		return
+	}
+	select {
+	case p.events <- e:
+	default:
+	}
+}
+
+// ─── Cleanup Runner ──────────────────────────────────────────────────────────
+
+// RemoveFunc removes one item, returning the bytes freed and the operation
+// name to log (e.g. "DELETE").
+type RemoveFunc func(path string) (freed int64, op string, err error)
+
+// ItemOutcome is the result of cleaning one item.
+type ItemOutcome struct {
+	Target string
+	Item   CleanItem
+	Freed  int64
+	Op     string
+	Err    error
+}
+
+// CleanResults removes every item of results with remove, in order, and
+// reports progress on events, which it closes when done. events may be nil.
+// Cancelling ctx stops the cleanup between items; the outcomes of the items
+// already handled are returned.
+func CleanResults(ctx context.Context, results []ScanResult, remove RemoveFunc, events chan<- Event) []ItemOutcome {
+	if events != nil {
+		defer close(events)
+	}
+	send := func(e Event) {
+		if events != nil {
+			events <- e
+		}
+	}
+
+	var outcomes []ItemOutcome
+	for _, r := range results {
+		if ctx.Err() != nil {
+			break
+		}
+		total := len(r.Items)
+		send(Event{Kind: EventTargetStart, Target: r.Category, Total: total})
+
+		var targetFreed int64
+		for i, item := range r.Items {
+			if ctx.Err() != nil {
+				break
+			}
+			freed, op, err := remove(item.Path)
+			if err != nil {
+				freed = 0
+			}
+			targetFreed += freed
+			outcomes = append(outcomes, ItemOutcome{
+				Target: r.Category, Item: item, Freed: freed, Op: op, Err: err,
+			})
+			send(Event{
+				Kind: EventItemDone, Target: r.Category, Path: item.Path, Size: item.Size,
+				Freed: freed, Done: i + 1, Total: total, Err: err,
+			})
+		}
+		send(Event{Kind: EventTargetDone, Target: r.Category, Freed: targetFreed, Total: total})
+	}
+	return outcomes
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
@@ -15,31 +16,41 @@ import (
 // CleanItem represents a single file or directory eligible for cleanup.
 type CleanItem struct {
 	// Path is the absolute filesystem path.
-	Path string
+	Path string `json:"path"`
 
 	// Size is the size in bytes.
-	Size int64
+	Size int64 `json:"size"`
 
 	// Category is the high-level grouping (user, browser, dev, system).
-	Category string
+	Category string `json:"category"`
 
 	// Description is a human-readable label for the parent target.
-	Description string
+	Description string `json:"description,omitempty"`
+
+	// ModTime is the last modification time. It is zero when unknown.
+	ModTime time.Time `json:"mod_time,omitzero"`
+
+	// RiskLevel is the risk of the target the item came from (low, medium,
+	// high). Empty means unknown, which is gated as high.
+	RiskLevel string `json:"risk,omitempty"`
 }
 
 // ScanResult holds the aggregated scan output for a single clean target.
 type ScanResult struct {
 	// Category is the target name (e.g. "ChromeCache", "NpmCache").
-	Category string
+	Category string `json:"category"`
 
 	// Items is the list of discovered cleanable files/directories.
-	Items []CleanItem
+	Items []CleanItem `json:"items"`
 
 	// TotalSize is the sum of all item sizes in bytes.
-	TotalSize int64
+	TotalSize int64 `json:"total_size"`
 
 	// ItemCount is the number of items discovered.
-	ItemCount int
+	ItemCount int `json:"item_count"`
+
+	// RiskLevel is the highest risk level among the items.
+	RiskLevel string `json:"risk,omitempty"`
 }
 
 // ─── Parallel Scan Engine ────────────────────────────────────────────────────
@@ -48,6 +59,11 @@ type ScanResult struct {
 // target that has cleanable items. Targets requiring admin privileges are
 // skipped when isAdmin is false. Whitelisted paths are excluded.
 func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool) []ScanResult {
+	return ScanAllProgress(targets, wl, isAdmin, nil)
+}
+
+// ScanAllProgress is ScanAll with progress reporting.
+func ScanAllProgress(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool, progress *ScanProgress) []ScanResult {
 	var (
 		mu      sync.Mutex
 		wg      sync.WaitGroup
@@ -65,21 +81,20 @@ func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool
 			continue
 		}
 
-		wg.Add(1)
-		go func(target config.CleanTarget) {
-			defer wg.Done()
-
-			items := scanTarget(target, wl)
+		// Targets share the scan slots with their subtrees, so a long
+		// target list does not multiply the concurrent directory reads.
+		goOrInline(&wg, func() {
+			items := scanTarget(t, wl, progress)
 			if len(items) == 0 {
 				return
 			}
 
-			result := ItemsToResult(target.Name, items)
+			result := ItemsToResult(t.Name, items)
 
 			mu.Lock()
 			results = append(results, result)
 			mu.Unlock()
-		}(t)
+		})
 	}
 
 	wg.Wait()
@@ -96,7 +111,7 @@ func ScanAll(targets []config.CleanTarget, wl *whitelist.Whitelist, isAdmin bool
 
 // scanTarget scans a single CleanTarget by resolving environment variables
 // and glob patterns in its paths.
-func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem {
+func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist, progress *ScanProgress) []CleanItem {
 	var items []CleanItem
 
 	for _, rawPath := range target.Paths {
@@ -123,54 +138,68 @@ func scanTarget(target config.CleanTarget, wl *whitelist.Whitelist) []CleanItem
 				continue // Path doesn't exist or is inaccessible.
 			}
 
+			if IsCloudPlaceholder(info) {
+				progress.addCloudSkipped()
+				continue
+			}
+
 			if info.IsDir() {
-				dirItems := scanDirectory(path, target.Category, target.Description, wl)
+				dirItems := scanDirectory(path, target.Category, target.Description, wl, progress)
 				items = append(items, dirItems...)
 			} else {
+				progress.addFound(path, info.Size())
 				items = append(items, CleanItem{
 					Path:        path,
 					Size:        info.Size(),
 					Category:    target.Category,
 					Description: target.Description,
+					ModTime:     info.ModTime(),
 				})
 			}
 		}
 	}
 
-	return items
+	setRisk(items, target.RiskLevel)
+	return FilterOlderThan(items, target.MinAge, time.Now())
 }
 
 // scanDirectory walks a directory tree collecting all files as CleanItems.
+// Subtrees are walked concurrently; items are returned sorted by path.
 // Whitelisted and inaccessible entries are silently skipped.
-func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) []CleanItem {
-	var items []CleanItem
+func scanDirectory(dir, category, description string, wl *whitelist.Whitelist, progress *ScanProgress) []CleanItem {
+	var (
+		mu    sync.Mutex
+		items []CleanItem
+	)
 
-	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
-		if err != nil {
-			return nil // Skip inaccessible entries.
-		}
+	parallelWalk(dir, progress, func(path string, d os.DirEntry, _ int) bool {
 		if d.IsDir() {
-			return nil
+			return true
 		}
 
 		if wl != nil && wl.IsWhitelisted(path) {
-			return nil
+			return false
 		}
 
 		info, infoErr := d.Info()
 		if infoErr != nil {
-			return nil
+			return false
 		}
 
+		progress.addFound(path, info.Size())
+		mu.Lock()
 		items = append(items, CleanItem{
 			Path:        path,
 			Size:        info.Size(),
 			Category:    category,
 			Description: description,
+			ModTime:     info.ModTime(),
 		})
-		return nil
+		mu.Unlock()
+		return false
 	})
 
+	sortItemsByPath(items)
 	return items
 }
 
@@ -180,15 +209,84 @@ func scanDirectory(dir, category, description string, wl *whitelist.Whitelist) [
 // the given name and pre-calculated totals.
 func ItemsToResult(name string, items []CleanItem) ScanResult {
 	var totalSize int64
+	risk := config.RiskLow
 	for _, item := range items {
 		totalSize += item.Size
+		risk = config.HigherRisk(risk, item.RiskLevel)
 	}
 	return ScanResult{
 		Category:  name,
 		Items:     items,
 		TotalSize: totalSize,
 		ItemCount: len(items),
+		RiskLevel: risk,
+	}
+}
+
+// setRisk stamps a risk level on items.
+func setRisk(items []CleanItem, level string) {
+	for i := range items {
+		items[i].RiskLevel = level
+	}
+}
+
+// FilterByRisk splits items into those at or below maxRisk and those above.
+func FilterByRisk(items []CleanItem, maxRisk string) (kept, skipped []CleanItem) {
+	for _, item := range items {
+		if config.RiskAllowed(item.RiskLevel, maxRisk) {
+			kept = append(kept, item)
+		} else {
+			skipped = append(skipped, item)
+		}
+	}
+	return kept, skipped
+}
+
+// FilterResultsByRisk applies FilterByRisk to every result. It returns the
+// results left to clean and, per target, the items held back.
+func FilterResultsByRisk(results []ScanResult, maxRisk string) (kept, skipped []ScanResult) {
+	for _, r := range results {
+		ok, over := FilterByRisk(r.Items, maxRisk)
+		if len(ok) > 0 {
+			kept = append(kept, ItemsToResult(r.Category, ok))
+		}
+		if len(over) > 0 {
+			skipped = append(skipped, ItemsToResult(r.Category, over))
+		}
+	}
+	return kept, skipped
+}
+
+// FilterOlderThan keeps the items last modified at least age before now.
+// Items with an unknown modification time are dropped, since they may be
+// recent. A zero age keeps everything.
+func FilterOlderThan(items []CleanItem, age time.Duration, now time.Time) []CleanItem {
+	if age <= 0 {
+		return items
+	}
+	cutoff := now.Add(-age)
+	kept := make([]CleanItem, 0, len(items))
+	for _, item := range items {
+		if !item.ModTime.IsZero() && !item.ModTime.After(cutoff) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// FilterResultsOlderThan applies FilterOlderThan to every result, updating
+// totals and dropping results left empty.
+func FilterResultsOlderThan(results []ScanResult, age time.Duration, now time.Time) []ScanResult {
+	if age <= 0 {
+		return results
+	}
+	var kept []ScanResult
+	for _, r := range results {
+		if items := FilterOlderThan(r.Items, age, now); len(items) > 0 {
+			kept = append(kept, ItemsToResult(r.Category, items))
+		}
 	}
+	return kept
 }
 
 // GroupByCategory aggregates scan results by the high-level category of
@@ -0,0 +1,56 @@
+package clean
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+func TestFilterResultsByRisk(t *testing.T) {
+	results := []ScanResult{
+		ItemsToResult("Temp", []CleanItem{
+			{Path: `C:\Temp\a.tmp`, Size: 1, RiskLevel: config.RiskLow},
+			{Path: `C:\Temp\b.tmp`, Size: 2, RiskLevel: config.RiskMedium},
+		}),
+		ItemsToResult("WindowsOld", []CleanItem{
+			{Path: `C:\Windows.old`, Size: 100, RiskLevel: config.RiskHigh},
+		}),
+	}
+
+	kept, skipped := FilterResultsByRisk(results, config.RiskLow)
+	if len(kept) != 1 || kept[0].Category != "Temp" || kept[0].ItemCount != 1 || kept[0].TotalSize != 1 {
+		t.Errorf("kept = %+v, want only the low-risk Temp item", kept)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("len(skipped) = %d, want 2 (one per target)", len(skipped))
+	}
+	if skipped[0].Category != "Temp" || skipped[0].TotalSize != 2 || skipped[0].RiskLevel != config.RiskMedium {
+		t.Errorf("skipped[0] = %+v, want the medium-risk Temp item", skipped[0])
+	}
+	if skipped[1].Category != "WindowsOld" || skipped[1].RiskLevel != config.RiskHigh {
+		t.Errorf("skipped[1] = %+v, want WindowsOld", skipped[1])
+	}
+
+	kept, skipped = FilterResultsByRisk(results, config.RiskHigh)
+	if len(kept) != 2 || len(skipped) != 0 {
+		t.Errorf("high ceiling kept %d and skipped %d results, want 2 and 0", len(kept), len(skipped))
+	}
+}
+
+func TestFilterOlderThan(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	items := []CleanItem{
+		{Path: `C:\Temp\old.tmp`, ModTime: now.AddDate(0, 0, -60)},
+		{Path: `C:\Temp\new.tmp`, ModTime: now.AddDate(0, 0, -1)},
+		{Path: `C:\Temp\unknown.tmp`},
+	}
+
+	kept := FilterOlderThan(items, 30*24*time.Hour, now)
+	if len(kept) != 1 || kept[0].Path != `C:\Temp\old.tmp` {
+		t.Errorf("FilterOlderThan(30d) kept %+v, want only old.tmp", kept)
+	}
+	if kept := FilterOlderThan(items, 0, now); len(kept) != len(items) {
+		t.Errorf("FilterOlderThan(0) kept %d items, want all %d", len(kept), len(items))
+	}
+}
@@ -1,21 +1,21 @@
 package clean
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"strings"
+	"sync"
 	"time"
 
+	"github.com/cy-infamous/purewin/internal/config"
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/internal/ui"
 	"github.com/cy-infamous/purewin/pkg/whitelist"
 )
 
 const (
-	// serviceCommandTimeout is the maximum time to wait for net stop/start.
+	// serviceCommandTimeout is the maximum time to wait for a service to stop
+	// or start.
 	serviceCommandTimeout = 60 * time.Second
 )
 
@@ -98,7 +98,9 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 		},
 	}
 
-	var items []CleanItem
+	// Scan every path concurrently, keeping results in target order.
+	type job struct{ path, description string }
+	var jobs []job
 	for _, t := range targets {
 		for _, p := range t.paths {
 			if _, err := os.Stat(p); err != nil {
@@ -107,11 +109,23 @@ func ScanSystemCaches(wl *whitelist.Whitelist) []CleanItem {
 			if wl != nil && wl.IsWhitelisted(p) {
 				continue
 			}
-			dirItems := scanDirectory(p, "system", t.description, wl)
-			items = append(items, dirItems...)
+			jobs = append(jobs, job{p, t.description})
 		}
 	}
 
+	found := make([][]CleanItem, len(jobs))
+	var wg sync.WaitGroup
+	for i, j := range jobs {
+		goOrInline(&wg, func() {
+			found[i] = scanDirectory(j.path, "system", j.description, wl, nil)
+		})
+	}
+	wg.Wait()
+
+	var items []CleanItem
+	for _, f := range found {
+		items = append(items, f...)
+	}
 	return items
 }
 
@@ -135,16 +149,18 @@ func ScanMemoryDumps() []CleanItem {
 			Size:        info.Size(),
 			Category:    "system",
 			Description: "Kernel memory dump",
+			ModTime:     info.ModTime(),
 		})
 	}
 
 	// Minidumps.
 	minidumpDir := filepath.Join(sr, "Minidump")
 	if _, err := os.Stat(minidumpDir); err == nil {
-		dirItems := scanDirectory(minidumpDir, "system", "Minidump crash files", nil)
+		dirItems := scanDirectory(minidumpDir, "system", "Minidump crash files", nil, nil)
 		items = append(items, dirItems...)
 	}
 
+	setRisk(items, config.TargetRiskLevel("MemoryDumps"))
 	return items
 }
 
@@ -193,8 +209,9 @@ func CleanWindowsUpdate(dryRun bool) (int64, error) {
 		return size, nil
 	}
 
-	// Stop Windows Update service.
-	if err := runServiceCommand("stop", "wuauserv"); err != nil {
+	// Stop Windows Update service and anything depending on it.
+	dependents, err := core.StopService("wuauserv", serviceCommandTimeout)
+	if err != nil {
 		return 0, fmt.Errorf("failed to stop wuauserv: %w", err)
 	}
 
@@ -202,7 +219,7 @@ func CleanWindowsUpdate(dryRun bool) (int64, error) {
 	freed, _, cleanErr := core.SafeCleanDir(downloadDir, "*", false)
 
 	// Always restart the service, even if cleaning failed.
-	if restartErr := runServiceCommand("start", "wuauserv"); restartErr != nil {
+	if restartErr := startServices(append([]string{"wuauserv"}, dependents...)); restartErr != nil {
 		if cleanErr != nil {
 			return 0, fmt.Errorf("clean failed: %w; also failed to restart wuauserv: %v", cleanErr, restartErr)
 		}
@@ -217,15 +234,13 @@ func CleanWindowsUpdate(dryRun bool) (int64, error) {
 	return freed, nil
 }
 
-// runServiceCommand executes `net <action> <service>` with a timeout.
-func runServiceCommand(action, service string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), serviceCommandTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "net", action, service)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("net %s %s: %w\n%s", action, service, err, strings.TrimSpace(string(output)))
+// startServices starts each service in order, stopping at the first
+// failure.
+func startServices(names []string) error {
+	for _, name := range names {
+		if err := core.StartService(name, serviceCommandTimeout); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -306,9 +321,10 @@ func ScanWERUserReports(wl *whitelist.Whitelist) []CleanItem {
 		if wl != nil && wl.IsWhitelisted(p) {
 			continue
 		}
-		dirItems := scanDirectory(p, "system", "Windows Error Reports (user)", wl)
+		dirItems := scanDirectory(p, "system", "Windows Error Reports (user)", wl, nil)
 		items = append(items, dirItems...)
 	}
 
+	setRisk(items, config.TargetRiskLevel("WERReports"))
 	return items
 }
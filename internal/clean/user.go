@@ -5,14 +5,15 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"syscall"
 	"unsafe"
+
+	"golang.org/x/sys/windows"
 )
 
 // ─── Shell32 Syscalls ────────────────────────────────────────────────────────
 
 var (
-	modShell32          = syscall.NewLazyDLL("shell32.dll")
+	modShell32          = windows.NewLazySystemDLL("shell32.dll")
 	procEmptyRecycleBin = modShell32.NewProc("SHEmptyRecycleBinW")
 	procQueryRecycleBin = modShell32.NewProc("SHQueryRecycleBinW")
 )
@@ -60,7 +61,7 @@ func ScanUserCaches() []CleanItem {
 		if err != nil || !info.IsDir() {
 			continue
 		}
-		dirItems := scanDirectory(dir, "user", "User temporary files", nil)
+		dirItems := scanDirectory(dir, "user", "User temporary files", nil, nil)
 		items = append(items, dirItems...)
 	}
 
@@ -90,6 +91,7 @@ func ScanThumbnailCache() []CleanItem {
 			Size:        info.Size(),
 			Category:    "user",
 			Description: "Thumbnail cache",
+			ModTime:     info.ModTime(),
 		})
 	}
 
@@ -98,32 +100,105 @@ func ScanThumbnailCache() []CleanItem {
 
 // ─── Recycle Bin ──────────────────────────────────────────────────────────────
 
+// RecycleBinDrive is the Recycle Bin content of a single drive.
+type RecycleBinDrive struct {
+	Drive string `json:"drive"`
+	Size  int64  `json:"size"`
+	Items int64  `json:"items"`
+}
+
 // ScanRecycleBin calculates the total size of items in the Windows Recycle
 // Bin across all drives using the SHQueryRecycleBinW Shell API.
 func ScanRecycleBin() (int64, error) {
+	size, _, err := queryRecycleBin("")
+	return size, err
+}
+
+// ScanRecycleBinDrives returns the Recycle Bin size and item count of every
+// fixed and removable drive, skipping drives whose bin is empty.
+func ScanRecycleBinDrives() []RecycleBinDrive {
+	var drives []RecycleBinDrive
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return nil
+	}
+
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		drive := string(rune('A'+i)) + ":"
+		root, _ := windows.UTF16PtrFromString(drive + `\`)
+		switch windows.GetDriveType(root) {
+		case windows.DRIVE_FIXED, windows.DRIVE_REMOVABLE:
+		default:
+			continue
+		}
+
+		size, count, err := queryRecycleBin(drive)
+		if err != nil || count == 0 {
+			continue
+		}
+		drives = append(drives, RecycleBinDrive{Drive: drive, Size: size, Items: count})
+	}
+	return drives
+}
+
+// queryRecycleBin returns the size and item count of the Recycle Bin on
+// drive (e.g. "C:"), or across all drives when drive is empty.
+func queryRecycleBin(drive string) (int64, int64, error) {
 	var info shQueryRBInfo
 	info.cbSize = uint32(unsafe.Sizeof(info))
 
+	root, err := recycleBinRoot(drive)
+	if err != nil {
+		return 0, 0, err
+	}
+
 	ret, _, _ := procQueryRecycleBin.Call(
-		0, // NULL = query all drives
+		uintptr(unsafe.Pointer(root)),
 		uintptr(unsafe.Pointer(&info)),
 	)
 	if ret != 0 {
-		return 0, fmt.Errorf("SHQueryRecycleBinW failed: HRESULT 0x%08x", uint32(ret))
+		return 0, 0, fmt.Errorf("SHQueryRecycleBinW failed: HRESULT 0x%08x", uint32(ret))
 	}
 
-	return info.i64Size, nil
+	return info.i64Size, info.i64NumItems, nil
+}
+
+// recycleBinRoot converts a drive such as "C:" to the root path pointer the
+// Shell API expects. An empty drive yields nil, meaning all drives.
+func recycleBinRoot(drive string) (*uint16, error) {
+	if drive == "" {
+		return nil, nil
+	}
+	drive = strings.TrimRight(drive, `\/`)
+	if len(drive) != 2 || drive[1] != ':' {
+		return nil, fmt.Errorf("invalid drive %q", drive)
+	}
+	return windows.UTF16PtrFromString(strings.ToUpper(drive) + `\`)
 }
 
 // EmptyRecycleBin empties the Windows Recycle Bin on all drives via the
 // SHEmptyRecycleBinW Shell API. In dryRun mode, no action is taken.
 func EmptyRecycleBin(dryRun bool) error {
+	return EmptyRecycleBinDrive("", dryRun)
+}
+
+// EmptyRecycleBinDrive empties the Recycle Bin on a single drive such as
+// "C:", or on all drives when drive is empty. In dryRun mode, no action is
+// taken.
+func EmptyRecycleBinDrive(drive string, dryRun bool) error {
+	root, err := recycleBinRoot(drive)
+	if err != nil {
+		return err
+	}
 	if dryRun {
 		return nil
 	}
 
 	flags := uintptr(sherbNoConfirmation | sherbNoProgressUI | sherbNoSound)
-	ret, _, _ := procEmptyRecycleBin.Call(0, 0, flags)
+	ret, _, _ := procEmptyRecycleBin.Call(0, uintptr(unsafe.Pointer(root)), flags)
 
 	hr := uint32(ret)
 	// S_OK (0) = success, E_UNEXPECTED (0x8000FFFF) = bin already empty.
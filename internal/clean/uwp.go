@@ -0,0 +1,142 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Store (UWP) App Caches ──────────────────────────────────────────────────
+// Every packaged app keeps its data under %LOCALAPPDATA%\Packages\<family>.
+// Its web cache (AC\INetCache), temp folder (AC\Temp) and LocalCache are
+// disposable; LocalState, RoamingState and Settings are never touched.
+
+// uwpRepositoryPath is the per-user AppX package repository. Each subkey is
+// a PackageFullName (Name_Version_Arch_ResourceId_PublisherId).
+const uwpRepositoryPath = `Software\Classes\Local Settings\Software\Microsoft\Windows\CurrentVersion\AppModel\Repository\Packages`
+
+// uwpTempDirs are cleaned for every installed package.
+var uwpTempDirs = []string{
+	filepath.Join("AC", "INetCache"),
+	filepath.Join("AC", "Temp"),
+}
+
+// uwpLocalCacheSkip lists lowercase package names whose LocalCache is not
+// cleaned wholesale: it holds sign-in state, message stores or a browser
+// profile, and the apps and browser scanners clean their caches already.
+var uwpLocalCacheSkip = map[string]bool{
+	"msteams":                  true,
+	"5319275a.whatsappdesktop": true,
+	"91750d7e.slack":           true,
+	"spotifyab.spotifymusic":   true,
+	"thebrowsercompany.arc":    true,
+}
+
+// uwpSkipPackages lists lowercase package names left out entirely because
+// their data belongs to the opt-in ai category.
+var uwpSkipPackages = map[string]bool{
+	"microsoft.copilot":                     true,
+	"microsoft.windows.ai.copilot.provider": true,
+	"microsoftwindows.client.coreai":        true,
+}
+
+// UWPPackage is an installed Store app package for the current user.
+type UWPPackage struct {
+	Family      string // Name_PublisherId, the folder name under Packages
+	Name        string
+	DisplayName string
+	System      bool // inbox app under C:\Windows\SystemApps
+}
+
+// InstalledUWPPackages lists the packaged apps registered for the current
+// user, one per package family, from the AppX repository in the registry.
+func InstalledUWPPackages() []UWPPackage {
+	key, err := registry.OpenKey(registry.CURRENT_USER, uwpRepositoryPath, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	names, err := key.ReadSubKeyNames(-1)
+	key.Close()
+	if err != nil {
+		return nil
+	}
+
+	byFamily := make(map[string]UWPPackage)
+	for _, full := range names {
+		parts := strings.Split(full, "_")
+		if len(parts) != 5 {
+			continue
+		}
+		pkg := UWPPackage{Family: parts[0] + "_" + parts[4], Name: parts[0]}
+		if _, seen := byFamily[strings.ToLower(pkg.Family)]; seen {
+			continue
+		}
+
+		if sub, subErr := registry.OpenKey(registry.CURRENT_USER, uwpRepositoryPath+`\`+full, registry.QUERY_VALUE); subErr == nil {
+			pkg.DisplayName, _, _ = sub.GetStringValue("DisplayName")
+			root, _, _ := sub.GetStringValue("PackageRootFolder")
+			pkg.System = strings.Contains(strings.ToLower(root), `\windows\systemapps\`)
+			sub.Close()
+		}
+		// Many display names are indirect "@{...ms-resource:...}" strings;
+		// fall back to the readable part of the package name.
+		if pkg.DisplayName == "" || strings.HasPrefix(pkg.DisplayName, "@") || strings.HasPrefix(pkg.DisplayName, "ms-resource:") {
+			pkg.DisplayName = pkg.Name[strings.LastIndex(pkg.Name, ".")+1:]
+		}
+		byFamily[strings.ToLower(pkg.Family)] = pkg
+	}
+
+	pkgs := make([]UWPPackage, 0, len(byFamily))
+	for _, p := range byFamily {
+		pkgs = append(pkgs, p)
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Family < pkgs[j].Family })
+	return pkgs
+}
+
+// UWPDescriptionPrefix starts the description of every Store app item, so
+// results can be grouped per app.
+const UWPDescriptionPrefix = "Store app: "
+
+// ScanUWPCaches scans the cache folders of every installed Store app. Items
+// are labelled per app, e.g. "Store app: WindowsCalculator". LocalCache is
+// skipped for inbox system apps and for apps covered by other scanners;
+// AI packages are left to the ai category.
+func ScanUWPCaches(wl *whitelist.Whitelist) []CleanItem {
+	packagesDir := filepath.Join(os.Getenv("LOCALAPPDATA"), "Packages")
+
+	var items []CleanItem
+	for _, pkg := range InstalledUWPPackages() {
+		if uwpSkipPackages[strings.ToLower(pkg.Name)] {
+			continue
+		}
+		base := filepath.Join(packagesDir, pkg.Family)
+		dirs := append([]string(nil), uwpTempDirs...)
+		if !pkg.System && !uwpLocalCacheSkip[strings.ToLower(pkg.Name)] {
+			dirs = append(dirs, "LocalCache")
+		}
+
+		desc := UWPDescriptionPrefix + pkg.DisplayName
+		for _, d := range dirs {
+			dir := filepath.Join(base, d)
+			if _, err := os.Stat(dir); err != nil {
+				continue
+			}
+			dirItems := scanDirectory(dir, config.CategoryApps, desc, wl, nil)
+			// LocalCache may hold app state that is slow to rebuild.
+			if d == "LocalCache" {
+				setRisk(dirItems, config.RiskMedium)
+			} else {
+				setRisk(dirItems, config.RiskLow)
+			}
+			items = append(items, dirItems...)
+		}
+	}
+	return items
+}
@@ -0,0 +1,171 @@
+package clean
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ─── Concurrent Walker ───────────────────────────────────────────────────────
+// Scans fan out across targets and subtrees: work is handed to a new
+// goroutine while one of a fixed number of slots is free and done inline
+// otherwise. The slots are shared by every scan in the process, so the
+// goroutines and ReadDir calls in flight stay bounded by scanWorkers() no
+// matter how many targets a scan has. Slots are never waited for, so nested
+// fan-outs cannot deadlock.
+
+// scanWorkers returns the number of concurrent directory reads used by scans.
+func scanWorkers() int {
+	return max(4, runtime.NumCPU())
+}
+
+// scanSlots holds one token per helper goroutine. The goroutine that starts
+// a scan is one of the workers, so it needs no slot.
+var scanSlots = make(chan struct{}, scanWorkers()-1)
+
+// goOrInline runs fn on a new goroutine tracked by wg when a scan slot is
+// free, and on the calling goroutine otherwise.
+func goOrInline(wg *sync.WaitGroup, fn func()) {
+	select {
+	case scanSlots <- struct{}{}:
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-scanSlots }()
+			fn()
+		}()
+	default:
+		fn()
+	}
+}
+
+// ScanProgress counts work done by a running scan. It is safe to read from
+// another goroutine, e.g. to update a spinner. A nil *ScanProgress is valid
+// and records nothing.
+type ScanProgress struct {
+	entries      atomic.Int64
+	found        atomic.Int64
+	foundSize    atomic.Int64
+	cloudSkipped atomic.Int64
+
+	events chan<- Event // see NewScanProgress
+}
+
+// Entries returns the number of files and directories visited so far.
+func (p *ScanProgress) Entries() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.entries.Load()
+}
+
+// Found returns the number of cleanable items found so far.
+func (p *ScanProgress) Found() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.found.Load()
+}
+
+// FoundSize returns the combined size of the items found so far. Flagged
+// directories are counted once their size has been computed.
+func (p *ScanProgress) FoundSize() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.foundSize.Load()
+}
+
+// CloudSkipped returns the number of online-only cloud placeholders the
+// scan left alone.
+func (p *ScanProgress) CloudSkipped() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.cloudSkipped.Load()
+}
+
+func (p *ScanProgress) addEntry() {
+	if p != nil {
+		p.entries.Add(1)
+	}
+}
+
+func (p *ScanProgress) addCloudSkipped() {
+	if p != nil {
+		p.cloudSkipped.Add(1)
+	}
+}
+
+func (p *ScanProgress) addFound(path string, size int64) {
+	if p != nil {
+		p.found.Add(1)
+		p.foundSize.Add(size)
+		p.emit(Event{Kind: EventFound, Path: path, Size: size})
+	}
+}
+
+// walkVisitFunc is called for every entry below the walk root, with the
+// entry's depth (1 for the root's direct children). It returns false to
+// skip a directory's contents. It is called concurrently.
+type walkVisitFunc func(path string, d os.DirEntry, depth int) bool
+
+// parallelWalk walks the tree under root, handing subtrees to the shared
+// scan slots. Unreadable directories and online-only cloud placeholders are
+// skipped. Like filepath.WalkDir, it does not follow symlinks or junctions.
+func parallelWalk(root string, progress *ScanProgress, visit walkVisitFunc) {
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, e := range entries {
+			path := filepath.Join(dir, e.Name())
+			progress.addEntry()
+			if isPlaceholderEntry(e) {
+				progress.addCloudSkipped()
+				continue
+			}
+			if !visit(path, e, depth) || !e.IsDir() {
+				continue
+			}
+			goOrInline(&wg, func() { walk(path, depth+1) })
+		}
+		wg.Wait()
+	}
+
+	walk(root, 1)
+}
+
+// fillDirSizes computes the size and newest modification time of each
+// directory item in parallel on the shared scan slots, and drops items that
+// turn out to be empty.
+func fillDirSizes(items []CleanItem, progress *ScanProgress) []CleanItem {
+	var wg sync.WaitGroup
+	for i := range items {
+		goOrInline(&wg, func() {
+			items[i].Size, items[i].ModTime = dirStats(items[i].Path)
+			progress.addFound(items[i].Path, items[i].Size)
+		})
+	}
+	wg.Wait()
+
+	kept := items[:0]
+	for _, item := range items {
+		if item.Size > 0 {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// sortItemsByPath orders items by path so concurrent scans give stable output.
+func sortItemsByPath(items []CleanItem) {
+	sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
+}
@@ -0,0 +1,63 @@
+package config
+
+import "path/filepath"
+
+// ─── AI Feature Data ─────────────────────────────────────────────────────────
+// Windows AI features (Recall, Copilot, the semantic index) keep large local
+// stores that capture what the user has seen and typed. They are grouped in
+// their own "ai" category, which is never part of --all: the user has to ask
+// for them explicitly, and each target carries a privacy note that is shown
+// before anything is deleted.
+
+// CategoryAI is the clean category for AI feature data stores.
+const CategoryAI = "ai"
+
+// aiTargets returns the clean targets for AI feature data.
+func aiTargets() []CleanTarget {
+	local := localAppData()
+	packages := filepath.Join(local, "Packages")
+
+	return []CleanTarget{
+		{
+			Name: "RecallSnapshots",
+			Paths: []string{
+				filepath.Join(local, "CoreAIPlatform*", "UKP", "*", "ImageStore"),
+				filepath.Join(local, "CoreAIPlatform*", "UKP", "*", "ukg.db*"),
+			},
+			Description:   "Windows Recall screenshots and activity database",
+			RequiresAdmin: false,
+			Category:      CategoryAI,
+			RiskLevel:     "high",
+			PrivacyNote: "Recall snapshots record everything shown on screen, including " +
+				"passwords and private messages. Deleting them permanently erases your Recall timeline.",
+		},
+		{
+			Name: "SemanticIndex",
+			Paths: []string{
+				filepath.Join(local, "CoreAIPlatform*", "SemanticIndex"),
+				filepath.Join(local, "CoreAIPlatform*", "ModelCache"),
+			},
+			Description:   "AI semantic search index and on-device model cache",
+			RequiresAdmin: false,
+			Category:      CategoryAI,
+			RiskLevel:     "medium",
+			PrivacyNote: "The semantic index holds text extracted from your files and screen. " +
+				"Windows rebuilds it in the background, which costs CPU and battery for a while.",
+		},
+		{
+			Name: "CopilotCache",
+			Paths: []string{
+				filepath.Join(packages, "Microsoft.Copilot_*", "LocalCache"),
+				filepath.Join(packages, "Microsoft.Copilot_*", "AC", "INetCache"),
+				filepath.Join(packages, "Microsoft.Windows.Ai.Copilot.Provider_*", "LocalCache"),
+				filepath.Join(packages, "MicrosoftWindows.Client.CoreAI_*", "LocalCache"),
+			},
+			Description:   "Copilot app web cache and local conversation data",
+			RequiresAdmin: false,
+			Category:      CategoryAI,
+			RiskLevel:     "medium",
+			PrivacyNote: "Copilot caches may contain recent prompts and responses. " +
+				"Cleaning signs you out of the Copilot app; chat history stored online is unaffected.",
+		},
+	}
+}
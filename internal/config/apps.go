@@ -0,0 +1,101 @@
+package config
+
+import "path/filepath"
+
+// ─── Desktop App Caches ──────────────────────────────────────────────────────
+// Electron and WebView2 apps (Teams, Discord, Slack, ...) each embed a
+// browser engine with its own HTTP, code and GPU caches, plus log folders.
+// They live in the "apps" category, which is part of --all. Only cache and
+// log folders are listed; settings, sign-in state and message stores are
+// left alone.
+
+// CategoryApps is the clean category for desktop app caches.
+const CategoryApps = "apps"
+
+// electronCacheDirs returns the cache folders an Electron or WebView2 app
+// keeps under root, followed by any extra folders relative to root.
+func electronCacheDirs(root string, extra ...string) []string {
+	dirs := []string{
+		filepath.Join(root, "Cache"),
+		filepath.Join(root, "Code Cache"),
+		filepath.Join(root, "GPUCache"),
+		filepath.Join(root, "Service Worker", "CacheStorage"),
+	}
+	for _, e := range extra {
+		dirs = append(dirs, filepath.Join(root, e))
+	}
+	return dirs
+}
+
+// appTargets returns the clean targets for desktop app caches.
+func appTargets() []CleanTarget {
+	local := localAppData()
+	roaming := appData()
+	packages := filepath.Join(local, "Packages")
+
+	teamsNew := filepath.Join(packages, "MSTeams_*", "LocalCache", "Microsoft", "MSTeams")
+	whatsAppNew := filepath.Join(packages, "5319275A.WhatsAppDesktop_*", "LocalCache")
+
+	var teams, discord, slack, spotify, whatsApp []string
+	teams = append(teams, electronCacheDirs(filepath.Join(roaming, "Microsoft", "Teams"), "logs")...)
+	teams = append(teams, electronCacheDirs(filepath.Join(teamsNew, "EBWebView", "Default"))...)
+	teams = append(teams, filepath.Join(teamsNew, "Logs"))
+
+	discord = electronCacheDirs(filepath.Join(roaming, "discord"), "logs")
+
+	slack = append(slack, electronCacheDirs(filepath.Join(roaming, "Slack"), "logs")...)
+	slack = append(slack, electronCacheDirs(filepath.Join(packages, "91750D7E.Slack_*", "LocalCache", "Roaming", "Slack"), "logs")...)
+
+	spotify = []string{
+		filepath.Join(local, "Spotify", "Data"),
+		filepath.Join(local, "Spotify", "Browser", "Cache"),
+		filepath.Join(local, "Spotify", "Browser", "GPUCache"),
+		filepath.Join(packages, "SpotifyAB.SpotifyMusic_*", "LocalCache", "Spotify", "Data"),
+	}
+
+	whatsApp = append(whatsApp, electronCacheDirs(filepath.Join(roaming, "WhatsApp"), "logs")...)
+	whatsApp = append(whatsApp, electronCacheDirs(filepath.Join(whatsAppNew, "EBWebView", "Default"))...)
+
+	return []CleanTarget{
+		{
+			Name:          "TeamsCache",
+			Paths:         teams,
+			Description:   "Microsoft Teams cache and logs",
+			RequiresAdmin: false,
+			Category:      CategoryApps,
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "DiscordCache",
+			Paths:         discord,
+			Description:   "Discord cache and logs",
+			RequiresAdmin: false,
+			Category:      CategoryApps,
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "SlackCache",
+			Paths:         slack,
+			Description:   "Slack cache and logs",
+			RequiresAdmin: false,
+			Category:      CategoryApps,
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "SpotifyCache",
+			Paths:         spotify,
+			Description:   "Spotify streaming and browser cache",
+			RequiresAdmin: false,
+			Category:      CategoryApps,
+			RiskLevel:     "low",
+		},
+		{
+			Name:          "WhatsAppCache",
+			Paths:         whatsApp,
+			Description:   "WhatsApp Desktop cache and logs",
+			RequiresAdmin: false,
+			Category:      CategoryApps,
+			RiskLevel:     "low",
+		},
+	}
+}
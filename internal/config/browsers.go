@@ -0,0 +1,493 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ─── Browser Definitions ─────────────────────────────────────────────────────
+
+// Browser engines with distinct profile layouts.
+const (
+	EngineChromium = "chromium"
+	EngineGecko    = "gecko"
+)
+
+// BrowserDef describes where a browser keeps its profiles and caches.
+type BrowserDef struct {
+	// Name is the human-readable browser name (e.g. "Chrome").
+	Name string
+
+	// TargetName is the CleanTarget name for this browser's caches.
+	TargetName string
+
+	// Engine is EngineChromium or EngineGecko.
+	Engine string
+
+	// DataDir is the Chromium "User Data" directory, or the Gecko roaming
+	// directory holding profiles.ini. May contain glob patterns.
+	DataDir string
+
+	// CacheDir is where caches live when they are kept apart from profile
+	// data: the Gecko local directory, or Opera's local profile directory.
+	CacheDir string
+
+	// SingleProfile marks browsers whose DataDir is itself the profile.
+	SingleProfile bool
+
+	// Processes are the executable names that indicate the browser is running.
+	Processes []string
+
+	// CacheSubdirs are the cache directories within each profile.
+	CacheSubdirs []string
+
+	// Description is the CleanTarget description.
+	Description string
+
+	// Always keeps the target in GetCleanTargets even when not installed.
+	Always bool
+}
+
+// BrowserProfile is a single discovered browser profile.
+type BrowserProfile struct {
+	// Dir is the profile folder name (e.g. "Profile 1", "abcd.default").
+	Dir string
+
+	// Name is the user-visible profile name, falling back to Dir.
+	Name string
+
+	// CacheRoot is the directory containing the profile's CacheSubdirs.
+	CacheRoot string
+}
+
+// DiscoveredBrowser pairs an installed browser with its profiles.
+type DiscoveredBrowser struct {
+	Def      BrowserDef
+	Profiles []BrowserProfile
+}
+
+// CachePaths returns every cache directory across all discovered profiles.
+func (b DiscoveredBrowser) CachePaths() []string {
+	var paths []string
+	for _, p := range b.Profiles {
+		paths = append(paths, b.ProfileCachePaths(p)...)
+	}
+	return paths
+}
+
+// ProfileCachePaths returns the cache directories of a single profile.
+func (b DiscoveredBrowser) ProfileCachePaths(p BrowserProfile) []string {
+	paths := make([]string, 0, len(b.Def.CacheSubdirs))
+	for _, sub := range b.Def.CacheSubdirs {
+		paths = append(paths, filepath.Join(p.CacheRoot, sub))
+	}
+	return paths
+}
+
+// CacheDescription labels the cache of profile p, e.g. "Chrome cache (Work)".
+// The profile is only named when the browser has more than one, and its
+// folder is added when two profiles share a display name.
+func (b DiscoveredBrowser) CacheDescription(p BrowserProfile) string {
+	desc := b.Def.Name + " cache"
+	if len(b.Profiles) < 2 {
+		return desc
+	}
+	label := p.Name
+	for _, other := range b.Profiles {
+		if other.Dir != p.Dir && other.Name == p.Name {
+			label = p.Name + ", " + p.Dir
+			break
+		}
+	}
+	return desc + " (" + label + ")"
+}
+
+var (
+	chromiumCacheSubdirs = []string{
+		"Cache",
+		"Code Cache",
+		"GPUCache",
+		filepath.Join("Service Worker", "CacheStorage"),
+	}
+	geckoCacheSubdirs = []string{"cache2", "startupCache", "thumbnails"}
+)
+
+// KnownBrowsers returns the browsers PureWin knows how to discover.
+func KnownBrowsers() []BrowserDef {
+	local := localAppData()
+	roaming := appData()
+
+	return []BrowserDef{
+		{
+			Name:         "Chrome",
+			TargetName:   "ChromeCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "Google", "Chrome", "User Data"),
+			Processes:    []string{"chrome.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Google Chrome browser cache",
+			Always:       true,
+		},
+		{
+			Name:         "Edge",
+			TargetName:   "EdgeCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "Microsoft", "Edge", "User Data"),
+			Processes:    []string{"msedge.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Microsoft Edge browser cache",
+			Always:       true,
+		},
+		{
+			Name:         "Brave",
+			TargetName:   "BraveCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data"),
+			Processes:    []string{"brave.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Brave browser cache",
+			Always:       true,
+		},
+		{
+			Name:         "Vivaldi",
+			TargetName:   "VivaldiCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "Vivaldi", "User Data"),
+			Processes:    []string{"vivaldi.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Vivaldi browser cache",
+		},
+		{
+			Name:          "Opera",
+			TargetName:    "OperaCache",
+			Engine:        EngineChromium,
+			DataDir:       filepath.Join(roaming, "Opera Software", "Opera Stable"),
+			CacheDir:      filepath.Join(local, "Opera Software", "Opera Stable"),
+			SingleProfile: true,
+			Processes:     []string{"opera.exe"},
+			CacheSubdirs:  chromiumCacheSubdirs,
+			Description:   "Opera browser cache",
+		},
+		{
+			Name:          "Opera GX",
+			TargetName:    "OperaGXCache",
+			Engine:        EngineChromium,
+			DataDir:       filepath.Join(roaming, "Opera Software", "Opera GX Stable"),
+			CacheDir:      filepath.Join(local, "Opera Software", "Opera GX Stable"),
+			SingleProfile: true,
+			Processes:     []string{"opera.exe"},
+			CacheSubdirs:  chromiumCacheSubdirs,
+			Description:   "Opera GX browser cache",
+		},
+		{
+			Name:         "Arc",
+			TargetName:   "ArcCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "Packages", "TheBrowserCompany.Arc_*", "LocalCache", "Local", "Arc", "User Data"),
+			Processes:    []string{"arc.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Arc browser cache",
+		},
+		{
+			Name:         "Chromium",
+			TargetName:   "ChromiumCache",
+			Engine:       EngineChromium,
+			DataDir:      filepath.Join(local, "Chromium", "User Data"),
+			Processes:    []string{"chrome.exe"},
+			CacheSubdirs: chromiumCacheSubdirs,
+			Description:  "Chromium browser cache",
+		},
+		{
+			Name:         "Firefox",
+			TargetName:   "FirefoxCache",
+			Engine:       EngineGecko,
+			DataDir:      filepath.Join(roaming, "Mozilla", "Firefox"),
+			CacheDir:     filepath.Join(local, "Mozilla", "Firefox"),
+			Processes:    []string{"firefox.exe"},
+			CacheSubdirs: geckoCacheSubdirs,
+			Description:  "Mozilla Firefox browser cache (cache2 within profiles)",
+			Always:       true,
+		},
+		{
+			Name:         "LibreWolf",
+			TargetName:   "LibreWolfCache",
+			Engine:       EngineGecko,
+			DataDir:      filepath.Join(roaming, "librewolf"),
+			CacheDir:     filepath.Join(local, "librewolf"),
+			Processes:    []string{"librewolf.exe"},
+			CacheSubdirs: geckoCacheSubdirs,
+			Description:  "LibreWolf browser cache",
+		},
+		{
+			Name:         "Waterfox",
+			TargetName:   "WaterfoxCache",
+			Engine:       EngineGecko,
+			DataDir:      filepath.Join(roaming, "Waterfox"),
+			CacheDir:     filepath.Join(local, "Waterfox"),
+			Processes:    []string{"waterfox.exe"},
+			CacheSubdirs: geckoCacheSubdirs,
+			Description:  "Waterfox browser cache",
+		},
+	}
+}
+
+// ─── Discovery ───────────────────────────────────────────────────────────────
+
+// DiscoverBrowsers returns every installed browser with at least one profile.
+func DiscoverBrowsers() []DiscoveredBrowser {
+	var found []DiscoveredBrowser
+	for _, def := range KnownBrowsers() {
+		if profiles := DiscoverProfiles(def); len(profiles) > 0 {
+			found = append(found, DiscoveredBrowser{Def: def, Profiles: profiles})
+		}
+	}
+	return found
+}
+
+// DiscoverProfiles lists the profiles of a single browser. Glob patterns in
+// DataDir (used for Store-packaged browsers) are expanded.
+func DiscoverProfiles(def BrowserDef) []BrowserProfile {
+	dataDirs, _ := filepath.Glob(def.DataDir)
+	if len(dataDirs) == 0 {
+		dataDirs = []string{def.DataDir}
+	}
+
+	var profiles []BrowserProfile
+	for _, dataDir := range dataDirs {
+		switch {
+		case def.SingleProfile:
+			if !isDir(dataDir) {
+				continue
+			}
+			root := dataDir
+			if def.CacheDir != "" {
+				root = def.CacheDir
+			}
+			profiles = append(profiles, BrowserProfile{Dir: filepath.Base(dataDir), Name: def.Name, CacheRoot: root})
+		case def.Engine == EngineGecko:
+			profiles = append(profiles, discoverGeckoProfiles(dataDir, def.CacheDir)...)
+		default:
+			profiles = append(profiles, discoverChromiumProfiles(dataDir)...)
+		}
+	}
+	return profiles
+}
+
+// discoverChromiumProfiles finds profile folders in a "User Data" directory.
+// Names come from the Local State profile cache when available.
+func discoverChromiumProfiles(userDataDir string) []BrowserProfile {
+	entries, err := os.ReadDir(userDataDir)
+	if err != nil {
+		return nil
+	}
+
+	names := readChromiumProfileNames(filepath.Join(userDataDir, "Local State"))
+
+	var profiles []BrowserProfile
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := e.Name()
+		_, known := names[dir]
+		// "System Profile" holds no user browsing data.
+		if !known && dir != "Default" && dir != "Guest Profile" && !strings.HasPrefix(dir, "Profile ") {
+			continue
+		}
+		name := names[dir]
+		if name == "" {
+			name = dir
+		}
+		profiles = append(profiles, BrowserProfile{
+			Dir:       dir,
+			Name:      name,
+			CacheRoot: filepath.Join(userDataDir, dir),
+		})
+	}
+	return profiles
+}
+
+// readChromiumProfileNames maps profile folder names to display names from
+// the "profile.info_cache" section of Local State.
+func readChromiumProfileNames(localStatePath string) map[string]string {
+	names := make(map[string]string)
+	data, err := os.ReadFile(localStatePath)
+	if err != nil {
+		return names
+	}
+
+	var state struct {
+		Profile struct {
+			InfoCache map[string]struct {
+				Name string `json:"name"`
+			} `json:"info_cache"`
+		} `json:"profile"`
+	}
+	if json.Unmarshal(data, &state) != nil {
+		return names
+	}
+	for dir, info := range state.Profile.InfoCache {
+		names[dir] = info.Name
+	}
+	return names
+}
+
+// discoverGeckoProfiles reads profiles.ini from the roaming directory and
+// maps each relative profile to its cache folder under localDir. Without a
+// profiles.ini, every folder under localDir\Profiles is used.
+func discoverGeckoProfiles(roamingDir, localDir string) []BrowserProfile {
+	f, err := os.Open(filepath.Join(roamingDir, "profiles.ini"))
+	if err != nil {
+		var profiles []BrowserProfile
+		matches, _ := filepath.Glob(filepath.Join(localDir, "Profiles", "*"))
+		for _, m := range matches {
+			if isDir(m) {
+				profiles = append(profiles, BrowserProfile{Dir: filepath.Base(m), Name: filepath.Base(m), CacheRoot: m})
+			}
+		}
+		return profiles
+	}
+	defer f.Close()
+
+	var profiles []BrowserProfile
+	for _, p := range ParseProfilesINI(f) {
+		rel := filepath.FromSlash(p.Path)
+		root := rel
+		if p.IsRelative {
+			root = filepath.Join(localDir, rel)
+		}
+		name := p.Name
+		if name == "" {
+			name = filepath.Base(rel)
+		}
+		profiles = append(profiles, BrowserProfile{Dir: filepath.Base(rel), Name: name, CacheRoot: root})
+	}
+	return profiles
+}
+
+// GeckoProfileEntry is one [ProfileN] section of a profiles.ini file.
+type GeckoProfileEntry struct {
+	Name       string
+	Path       string
+	IsRelative bool
+}
+
+// ParseProfilesINI extracts the [ProfileN] sections of a Gecko profiles.ini,
+// ordered by section name. Sections without a Path are dropped.
+func ParseProfilesINI(r io.Reader) []GeckoProfileEntry {
+	sections := make(map[string]*GeckoProfileEntry)
+	var current *GeckoProfileEntry
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := line[1 : len(line)-1]
+			current = nil
+			if strings.HasPrefix(strings.ToLower(section), "profile") {
+				current = &GeckoProfileEntry{}
+				sections[section] = current
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "Name":
+			current.Name = strings.TrimSpace(value)
+		case "Path":
+			current.Path = strings.TrimSpace(value)
+		case "IsRelative":
+			current.IsRelative = strings.TrimSpace(value) == "1"
+		}
+	}
+
+	keys := make([]string, 0, len(sections))
+	for k := range sections {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var entries []GeckoProfileEntry
+	for _, k := range keys {
+		if e := sections[k]; e.Path != "" {
+			entries = append(entries, *e)
+		}
+	}
+	return entries
+}
+
+// ─── Clean Targets ───────────────────────────────────────────────────────────
+
+// browserTargets returns one CleanTarget per browser. Profiles are
+// discovered once per run, since GetCleanTargets is called often; callers
+// get their own copy of the list.
+func browserTargets() []CleanTarget {
+	return slices.Clone(discoverBrowserTargets())
+}
+
+// discoverBrowserTargets builds the browser targets from discovered
+// profiles. Browsers marked Always fall back to their default profile
+// location so the target list stays stable when they are not installed.
+var discoverBrowserTargets = sync.OnceValue(func() []CleanTarget {
+	var targets []CleanTarget
+	for _, def := range KnownBrowsers() {
+		b := DiscoveredBrowser{Def: def, Profiles: DiscoverProfiles(def)}
+		paths := b.CachePaths()
+		if len(paths) == 0 {
+			if !def.Always {
+				continue
+			}
+			paths = defaultBrowserCachePaths(def)
+		}
+		targets = append(targets, browserTarget(def, paths))
+	}
+	return targets
+})
+
+// browserTarget returns the CleanTarget for a browser's cache paths.
+func browserTarget(def BrowserDef, paths []string) CleanTarget {
+	return CleanTarget{
+		Name:          def.TargetName,
+		Paths:         paths,
+		Description:   def.Description,
+		RequiresAdmin: false,
+		Category:      "browser",
+		RiskLevel:     "low",
+	}
+}
+
+// defaultBrowserCachePaths returns the cache paths of a browser's default
+// profile, used when no profile could be discovered.
+func defaultBrowserCachePaths(def BrowserDef) []string {
+	root := filepath.Join(def.DataDir, "Default")
+	if def.Engine == EngineGecko {
+		root = filepath.Join(def.CacheDir, "Profiles", "*")
+	}
+	paths := make([]string, 0, len(def.CacheSubdirs))
+	for _, sub := range def.CacheSubdirs {
+		paths = append(paths, filepath.Join(root, sub))
+	}
+	return paths
+}
+
+// isDir reports whether path exists and is a directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProfilesINI(t *testing.T) {
+	ini := `[General]
+StartWithLastProfile=1
+
+[Profile1]
+Name=work
+IsRelative=1
+Path=Profiles/abcd1234.work
+
+[Profile0]
+Name=default-release
+IsRelative=1
+Path=Profiles/wxyz9876.default-release
+Default=1
+
+[Profile2]
+Name=external
+IsRelative=0
+Path=D:\FirefoxProfiles\ext
+
+[Profile3]
+Name=broken
+
+[Install308046B0AF4A39CB]
+Default=Profiles/wxyz9876.default-release
+`
+	got := ParseProfilesINI(strings.NewReader(ini))
+	if len(got) != 3 {
+		t.Fatalf("got %d profiles, want 3: %+v", len(got), got)
+	}
+	if got[0].Name != "default-release" || !got[0].IsRelative {
+		t.Errorf("profile 0 = %+v", got[0])
+	}
+	if got[1].Path != "Profiles/abcd1234.work" {
+		t.Errorf("profile 1 path = %q", got[1].Path)
+	}
+	if got[2].IsRelative || got[2].Path != `D:\FirefoxProfiles\ext` {
+		t.Errorf("profile 2 = %+v", got[2])
+	}
+}
+
+func TestKnownBrowsers_UniqueTargetNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, b := range KnownBrowsers() {
+		if seen[b.TargetName] {
+			t.Errorf("duplicate browser target name %q", b.TargetName)
+		}
+		seen[b.TargetName] = true
+		if len(b.CacheSubdirs) == 0 {
+			t.Errorf("browser %q has no cache subdirectories", b.Name)
+		}
+	}
+}
+
+func TestDiscoveredBrowser_CacheDescription(t *testing.T) {
+	def := BrowserDef{Name: "Chrome"}
+
+	single := DiscoveredBrowser{Def: def, Profiles: []BrowserProfile{{Dir: "Default", Name: "Person 1"}}}
+	if got := single.CacheDescription(single.Profiles[0]); got != "Chrome cache" {
+		t.Errorf("single profile = %q, want %q", got, "Chrome cache")
+	}
+
+	multi := DiscoveredBrowser{Def: def, Profiles: []BrowserProfile{
+		{Dir: "Default", Name: "Work"},
+		{Dir: "Profile 1", Name: "Home"},
+		{Dir: "Profile 2", Name: "Home"},
+	}}
+	want := []string{"Chrome cache (Work)", "Chrome cache (Home, Profile 1)", "Chrome cache (Home, Profile 2)"}
+	for i, p := range multi.Profiles {
+		if got := multi.CacheDescription(p); got != want[i] {
+			t.Errorf("profile %s = %q, want %q", p.Dir, got, want[i])
+		}
+	}
+}
@@ -15,8 +15,9 @@ const (
 	// ConfigFileName is the configuration file name.
 	ConfigFileName = "config.json"
 
-	// DefaultVersion is the config schema version.
-	DefaultVersion = "1"
+	// DefaultVersion is the config schema version. Older files are
+	// upgraded by the post-update migrations in internal/migrate.
+	DefaultVersion = "2"
 )
 
 // Config holds the application configuration.
@@ -39,6 +40,9 @@ type Config struct {
 	// DryRunMode enables dry-run globally (no actual deletions).
 	DryRunMode bool `json:"dry_run_mode"`
 
+	// LogRetention decides whether path cleans delete or archive old logs.
+	LogRetention LogRetention `json:"log_retention"`
+
 	mu sync.RWMutex
 }
 
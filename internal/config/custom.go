@@ -0,0 +1,208 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ─── User-Defined Targets ────────────────────────────────────────────────────
+// Users can add their own clean targets in targets.json in the config
+// directory. Each entry names one or more paths (environment variables and
+// glob patterns allowed), a category that decides which clean flags pick
+// it up, and a risk level. Entries that could reach a never-delete path are
+// rejected when the file is loaded.
+
+// CustomTargetsFileName is the file holding user-defined clean targets.
+const CustomTargetsFileName = "targets.json"
+
+// customTargetsFile is the on-disk form of targets.json.
+type customTargetsFile struct {
+	Targets []customTarget `json:"targets"`
+}
+
+// customTarget is one entry of targets.json.
+type customTarget struct {
+	Name          string   `json:"name"`
+	Paths         []string `json:"paths"`
+	Description   string   `json:"description,omitempty"`
+	Category      string   `json:"category,omitempty"`
+	Risk          string   `json:"risk,omitempty"`
+	RequiresAdmin bool     `json:"requires_admin,omitempty"`
+	PrivacyNote   string   `json:"privacy_note,omitempty"`
+	MinAge        string   `json:"min_age,omitempty"`
+}
+
+// customCategories are the categories a user-defined target may join.
+var customCategories = map[string]bool{
+	"user": true, "system": true, "browser": true, "dev": true, CategoryApps: true, CategoryAI: true,
+}
+
+// CustomTargetsTemplate is written when targets.json is first created.
+const CustomTargetsTemplate = `{
+  "targets": [
+    {
+      "name": "ExampleAppCache",
+      "paths": ["%LOCALAPPDATA%\\ExampleApp\\Cache"],
+      "description": "Example app cache (edit or remove this entry)",
+      "category": "user",
+      "risk": "low",
+      "min_age": "7d"
+    }
+  ]
+}
+`
+
+// GetCustomTargets returns the valid user-defined targets from the default
+// config directory. Invalid entries and read errors are ignored; use
+// LoadCustomTargets to report them.
+func GetCustomTargets() []CleanTarget {
+	dir, err := defaultConfigDir()
+	if err != nil {
+		return nil
+	}
+	targets, _, _ := LoadCustomTargets(dir)
+	return targets
+}
+
+// LoadCustomTargets reads targets.json from configDir. It returns the valid
+// targets and one error per rejected entry. A missing file yields no
+// targets and no error.
+func LoadCustomTargets(configDir string) ([]CleanTarget, []error, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, CustomTargetsFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	return parseCustomTargets(data, GetNeverDeletePaths())
+}
+
+// parseCustomTargets decodes and validates targets.json content.
+func parseCustomTargets(data []byte, neverDelete []string) ([]CleanTarget, []error, error) {
+	var file customTargetsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", CustomTargetsFileName, err)
+	}
+
+	taken := make(map[string]bool)
+	for _, t := range GetCleanTargets() {
+		taken[strings.ToLower(t.Name)] = true
+	}
+
+	var targets []CleanTarget
+	var problems []error
+	for i, ct := range file.Targets {
+		t, err := ct.validate(neverDelete)
+		if err == nil && taken[strings.ToLower(t.Name)] {
+			err = fmt.Errorf("name %q is already used", t.Name)
+		}
+		if err != nil {
+			label := ct.Name
+			if label == "" {
+				label = fmt.Sprintf("#%d", i+1)
+			}
+			problems = append(problems, fmt.Errorf("target %s: %w", label, err))
+			continue
+		}
+		taken[strings.ToLower(t.Name)] = true
+		targets = append(targets, t)
+	}
+	return targets, problems, nil
+}
+
+// validate converts an entry to a CleanTarget, applying defaults and
+// rejecting unsafe paths.
+func (ct customTarget) validate(neverDelete []string) (CleanTarget, error) {
+	t := CleanTarget{
+		Name:          strings.TrimSpace(ct.Name),
+		Description:   strings.TrimSpace(ct.Description),
+		Category:      strings.ToLower(strings.TrimSpace(ct.Category)),
+		RiskLevel:     strings.ToLower(strings.TrimSpace(ct.Risk)),
+		RequiresAdmin: ct.RequiresAdmin,
+		PrivacyNote:   strings.TrimSpace(ct.PrivacyNote),
+	}
+	if t.Name == "" {
+		return t, fmt.Errorf("name is required")
+	}
+	if t.Description == "" {
+		t.Description = t.Name
+	}
+	if t.Category == "" {
+		t.Category = "user"
+	}
+	if !customCategories[t.Category] {
+		return t, fmt.Errorf("unknown category %q (use user, system, browser, dev, apps or ai)", ct.Category)
+	}
+	switch t.RiskLevel {
+	case "":
+		t.RiskLevel = "medium"
+	case "low", "medium", "high":
+	default:
+		return t, fmt.Errorf("unknown risk %q (use low, medium or high)", ct.Risk)
+	}
+	minAge, err := ParseAge(ct.MinAge)
+	if err != nil {
+		return t, err
+	}
+	t.MinAge = minAge
+	if len(ct.Paths) == 0 {
+		return t, fmt.Errorf("at least one path is required")
+	}
+
+	for _, raw := range ct.Paths {
+		p := expand(strings.TrimSpace(raw))
+		if !isAbsWindowsPath(p) {
+			return t, fmt.Errorf("path %q is not absolute", raw)
+		}
+		if protected, ok := reachesProtectedPath(p, neverDelete); ok {
+			return t, fmt.Errorf("path %q would include protected path %s", raw, protected)
+		}
+		t.Paths = append(t.Paths, p)
+	}
+	return t, nil
+}
+
+// reachesProtectedPath reports whether pattern matches a never-delete path
+// or one of its ancestors, which would put the protected path inside the
+// target. Matching is case-insensitive and separator-agnostic.
+func reachesProtectedPath(pattern string, neverDelete []string) (string, bool) {
+	pat := normalizeForMatch(pattern)
+	for _, protected := range neverDelete {
+		for q := normalizeForMatch(protected); ; q = path.Dir(q) {
+			if ok, _ := path.Match(pat, q); ok || pat == q {
+				return protected, true
+			}
+			if parent := path.Dir(q); parent == q || parent == "." {
+				break
+			}
+		}
+	}
+	return "", false
+}
+
+// isAbsWindowsPath reports whether p is a drive-rooted or UNC path.
+func isAbsWindowsPath(p string) bool {
+	if strings.HasPrefix(p, `\\`) {
+		return true
+	}
+	if len(p) < 3 || p[1] != ':' || (p[2] != '\\' && p[2] != '/') {
+		return false
+	}
+	c := p[0] | 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// normalizeForMatch lowercases p, uses forward slashes, and drops any
+// trailing separator so that a drive root compares as "c:".
+func normalizeForMatch(p string) string {
+	p = strings.ToLower(strings.ReplaceAll(p, `\`, "/"))
+	if trimmed := strings.TrimRight(p, "/"); trimmed != "" {
+		p = trimmed
+	}
+	return p
+}
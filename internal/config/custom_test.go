@@ -0,0 +1,60 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCustomTargets(t *testing.T) {
+	neverDelete := []string{`C:\Windows`, `C:\Windows\System32`, `C:\Users`, `C:\ProgramData`}
+
+	data := []byte(`{"targets": [
+		{"name": "UnityCache", "paths": ["C:\\Users\\me\\AppData\\Local\\Unity\\cache"], "category": "dev", "risk": "low"},
+		{"name": "Defaults", "paths": ["D:\\scratch\\*.tmp"]},
+		{"name": "WholeDrive", "paths": ["C:\\"]},
+		{"name": "WinGlob", "paths": ["C:\\Win*"]},
+		{"name": "UsersRoot", "paths": ["c:\\users"]},
+		{"name": "Relative", "paths": ["cache"]},
+		{"name": "BadRisk", "paths": ["D:\\x"], "risk": "extreme"},
+		{"name": "BadCategory", "paths": ["D:\\x"], "category": "misc"},
+		{"name": "NoPaths"},
+		{"name": "UserTemp", "paths": ["D:\\x"]},
+		{"name": "unitycache", "paths": ["D:\\y"]}
+	]}`)
+
+	targets, problems, err := parseCustomTargets(data, neverDelete)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(targets) != 2 {
+		t.Fatalf("got %d valid targets, want 2: %+v", len(targets), targets)
+	}
+	if targets[0].Category != "dev" || targets[0].RiskLevel != "low" {
+		t.Errorf("UnityCache = %+v", targets[0])
+	}
+	if d := targets[1]; d.Category != "user" || d.RiskLevel != "medium" || d.Description != "Defaults" {
+		t.Errorf("Defaults = %+v", d)
+	}
+
+	if len(problems) != 9 {
+		t.Fatalf("got %d problems, want 9: %v", len(problems), problems)
+	}
+	for _, name := range []string{"WholeDrive", "WinGlob", "UsersRoot"} {
+		found := false
+		for _, p := range problems {
+			if strings.Contains(p.Error(), name) && strings.Contains(p.Error(), "protected") {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %s to be rejected as protected; problems: %v", name, problems)
+		}
+	}
+}
+
+func TestParseCustomTargets_InvalidJSON(t *testing.T) {
+	if _, _, err := parseCustomTargets([]byte(`{"targets": [`), nil); err == nil {
+		t.Error("expected an error for malformed JSON")
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/cy-infamous/purewin/internal/envutil"
 )
@@ -10,22 +11,30 @@ import (
 // CleanTarget represents a category of files that can be cleaned.
 type CleanTarget struct {
 	// Name is the unique identifier for this target.
-	Name string
+	Name string `json:"name"`
 
 	// Paths is the list of filesystem paths to clean.
-	Paths []string
+	Paths []string `json:"paths"`
 
 	// Description is a human-readable description.
-	Description string
+	Description string `json:"description"`
 
 	// RequiresAdmin indicates whether elevated privileges are needed.
-	RequiresAdmin bool
+	RequiresAdmin bool `json:"requires_admin,omitempty"`
 
-	// Category groups related targets (e.g., "user", "system", "browser", "dev").
-	Category string
+	// Category groups related targets (e.g., "user", "system", "browser", "dev", "apps").
+	Category string `json:"category"`
 
 	// RiskLevel is one of "low", "medium", "high".
-	RiskLevel string
+	RiskLevel string `json:"risk"`
+
+	// PrivacyNote explains what sensitive data the target holds. Targets
+	// with a note require explicit confirmation before cleaning.
+	PrivacyNote string `json:"privacy_note,omitempty"`
+
+	// MinAge is the default minimum age of files to clean. Newer files are
+	// skipped because they may still be in use. Zero cleans everything.
+	MinAge time.Duration `json:"min_age,omitempty"`
 }
 
 // expand resolves environment variables in a path, supporting both
@@ -44,6 +53,18 @@ func localAppData() string {
 	return os.Getenv("LOCALAPPDATA")
 }
 
+// localLow returns the LocalLow app data directory used by low-integrity
+// processes.
+func localLow() string {
+	return filepath.Join(userProfile(), "AppData", "LocalLow")
+}
+
+// defenderData returns the Microsoft Defender data directory under
+// ProgramData.
+func defenderData() string {
+	return filepath.Join(programData(), "Microsoft", "Windows Defender")
+}
+
 // appData returns the roaming app data directory.
 func appData() string {
 	return os.Getenv("APPDATA")
@@ -83,7 +104,7 @@ func GetCleanTargets() []CleanTarget {
 	local := localAppData()
 	roaming := appData()
 
-	return []CleanTarget{
+	targets := []CleanTarget{
 		// ── User Temp ───────────────────────────────────────────
 		{
 			Name:          "UserTemp",
@@ -92,6 +113,7 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: false,
 			Category:      "user",
 			RiskLevel:     "low",
+			MinAge:        24 * time.Hour,
 		},
 
 		// ── System Temp ─────────────────────────────────────────
@@ -102,60 +124,14 @@ func GetCleanTargets() []CleanTarget {
 			RequiresAdmin: true,
 			Category:      "system",
 			RiskLevel:     "low",
+			MinAge:        24 * time.Hour,
 		},
+	}
 
-		// ── Browser Caches ──────────────────────────────────────
-		{
-			Name: "ChromeCache",
-			Paths: []string{
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Google", "Chrome", "User Data", "Default", "Service Worker", "CacheStorage"),
-			},
-			Description:   "Google Chrome browser cache",
-			RequiresAdmin: false,
-			Category:      "browser",
-			RiskLevel:     "low",
-		},
-		{
-			Name: "EdgeCache",
-			Paths: []string{
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "GPUCache"),
-				filepath.Join(local, "Microsoft", "Edge", "User Data", "Default", "Service Worker", "CacheStorage"),
-			},
-			Description:   "Microsoft Edge browser cache",
-			RequiresAdmin: false,
-			Category:      "browser",
-			RiskLevel:     "low",
-		},
-		{
-			Name: "FirefoxCache",
-			Paths: []string{
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "cache2"),
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "startupCache"),
-				filepath.Join(local, "Mozilla", "Firefox", "Profiles", "*", "thumbnails"),
-			},
-			Description:   "Mozilla Firefox browser cache (cache2 within profiles)",
-			RequiresAdmin: false,
-			Category:      "browser",
-			RiskLevel:     "low",
-		},
-		{
-			Name: "BraveCache",
-			Paths: []string{
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "Code Cache"),
-				filepath.Join(local, "BraveSoftware", "Brave-Browser", "User Data", "Default", "GPUCache"),
-			},
-			Description:   "Brave browser cache",
-			RequiresAdmin: false,
-			Category:      "browser",
-			RiskLevel:     "low",
-		},
+	// ── Browser Caches (discovered per profile) ─────────────────
+	targets = append(targets, browserTargets()...)
 
+	targets = append(targets, []CleanTarget{
 		// ── Developer Caches ────────────────────────────────────
 		{
 			Name:          "NpmCache",
@@ -285,6 +261,22 @@ func GetCleanTargets() []CleanTarget {
 			Category:      "system",
 			RiskLevel:     "low",
 		},
+		{
+			// Scan history and support logs only. Definition Updates and
+			// Platform are never-delete paths and are not listed here.
+			Name: "DefenderLogs",
+			Paths: []string{
+				filepath.Join(defenderData(), "Scans", "History", "Service"),
+				filepath.Join(defenderData(), "Scans", "History", "Results"),
+				filepath.Join(defenderData(), "Scans", "History", "CacheManager"),
+				filepath.Join(defenderData(), "Support"),
+				filepath.Join(defenderData(), "Network Inspection System", "Support"),
+			},
+			Description:   "Microsoft Defender scan history, cache manager and support logs",
+			RequiresAdmin: true,
+			Category:      "system",
+			RiskLevel:     "medium",
+		},
 		{
 			Name:          "DeliveryOptimization",
 			Paths:         []string{filepath.Join(systemRoot(), "SoftwareDistribution", "DeliveryOptimization")},
@@ -314,6 +306,53 @@ func GetCleanTargets() []CleanTarget {
 			RiskLevel:     "low",
 		},
 
+		// ── GPU Shader Caches ───────────────────────────────────
+		// Rebuilt by the driver on demand; games may stutter briefly
+		// while shaders recompile after a clean.
+		{
+			Name:          "DirectXShaderCache",
+			Paths:         []string{filepath.Join(local, "D3DSCache")},
+			Description:   "DirectX shader cache",
+			RequiresAdmin: false,
+			Category:      "user",
+			RiskLevel:     "low",
+		},
+		{
+			Name: "NvidiaShaderCache",
+			Paths: []string{
+				filepath.Join(local, "NVIDIA", "DXCache"),
+				filepath.Join(local, "NVIDIA", "GLCache"),
+				filepath.Join(local, "NVIDIA", "OptixCache"),
+			},
+			Description:   "NVIDIA DirectX, OpenGL and OptiX shader caches",
+			RequiresAdmin: false,
+			Category:      "user",
+			RiskLevel:     "low",
+		},
+		{
+			Name: "AMDShaderCache",
+			Paths: []string{
+				filepath.Join(local, "AMD", "DxCache"),
+				filepath.Join(local, "AMD", "DxcCache"),
+				filepath.Join(local, "AMD", "GLCache"),
+				filepath.Join(local, "AMD", "VkCache"),
+			},
+			Description:   "AMD DirectX, OpenGL and Vulkan shader caches",
+			RequiresAdmin: false,
+			Category:      "user",
+			RiskLevel:     "low",
+		},
+		{
+			Name: "IntelShaderCache",
+			Paths: []string{
+				filepath.Join(localLow(), "Intel", "ShaderCache"),
+			},
+			Description:   "Intel graphics shader cache",
+			RequiresAdmin: false,
+			Category:      "user",
+			RiskLevel:     "low",
+		},
+
 		// ── Memory Dumps ────────────────────────────────────────
 		{
 			Name: "MemoryDumps",
@@ -346,7 +385,16 @@ func GetCleanTargets() []CleanTarget {
 			Category:      "user",
 			RiskLevel:     "medium",
 		},
-	}
+	}...)
+
+	// ── Desktop App Caches ──────────────────────────────────────
+	targets = append(targets, appTargets()...)
+
+	// ── Prefetch (opt-in only) ──────────────────────────────────
+	targets = append(targets, prefetchTargets()...)
+
+	// ── AI Feature Data (opt-in only) ───────────────────────────
+	return append(targets, aiTargets()...)
 }
 
 // GetTargetsByCategory returns clean targets filtered by category.
@@ -386,6 +434,8 @@ func GetNeverDeletePaths() []string {
 		filepath.Join(sd, "Users"),
 		filepath.Join(sd, "Recovery"),
 		pd, // e.g. C:\ProgramData
+		filepath.Join(defenderData(), "Definition Updates"),
+		filepath.Join(defenderData(), "Platform"),
 		filepath.Join(sd, "pagefile.sys"),
 		filepath.Join(sd, "swapfile.sys"),
 		filepath.Join(sd, "hiberfil.sys"),
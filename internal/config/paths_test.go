@@ -119,3 +119,39 @@ func TestGetTargetsByCategory(t *testing.T) {
 		}
 	}
 }
+
+func TestGetCleanTargets_AITargetsCarryPrivacyNotes(t *testing.T) {
+	found := 0
+	for _, target := range GetTargetsByCategory(CategoryAI) {
+		found++
+		if target.PrivacyNote == "" {
+			t.Errorf("AI target %q has no PrivacyNote", target.Name)
+		}
+		if target.RiskLevel == "low" {
+			t.Errorf("AI target %q must not be low risk", target.Name)
+		}
+	}
+	if found == 0 {
+		t.Error("expected at least one AI target")
+	}
+}
+
+func TestGetCleanTargets_PrefetchTargetsAreGuarded(t *testing.T) {
+	targets := GetTargetsByCategory(CategoryPrefetch)
+	if len(targets) == 0 {
+		t.Fatal("expected prefetch targets")
+	}
+	for _, target := range targets {
+		if !target.RequiresAdmin {
+			t.Errorf("prefetch target %q must require admin", target.Name)
+		}
+		if target.MinAge < PrefetchMinAge {
+			t.Errorf("prefetch target %q has MinAge %v, want at least %v", target.Name, target.MinAge, PrefetchMinAge)
+		}
+		for _, p := range target.Paths {
+			if !strings.ContainsRune(filepath.Base(p), '*') {
+				t.Errorf("prefetch target %q path %q must match files, not a directory", target.Name, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ─── Prefetch (opt-in only) ──────────────────────────────────────────────────
+// Windows records which files each program loads at start-up in
+// C:\Windows\Prefetch and replays them to speed up the next launch; ReadyBoot
+// does the same for boot. Deleting the traces frees little space and makes
+// the next boot and first launches slower until Windows relearns them, so
+// the "prefetch" category is never part of --all and only old traces are
+// offered. The Prefetch directory is in GetNeverDeletePaths, which guards
+// everything below it; IsPrefetchTrace carves out the trace files directly
+// inside it and ReadyBoot, so the directories themselves and anything
+// else in them stay protected.
+
+// CategoryPrefetch is the clean category for prefetch and ReadyBoot traces.
+const CategoryPrefetch = "prefetch"
+
+// PrefetchMinAge is the default minimum age of prefetch traces to clean:
+// traces of programs not started for a month no longer help.
+const PrefetchMinAge = 30 * 24 * time.Hour
+
+// PrefetchTradeoff explains what cleaning prefetch data costs.
+const PrefetchTradeoff = "Prefetch traces make boot and app launches faster. Windows recreates them, " +
+	"but the next boot and the first start of each program will be slower until it does."
+
+// prefetchTargets returns the clean targets for prefetch data.
+func prefetchTargets() []CleanTarget {
+	prefetch := filepath.Join(systemRoot(), "Prefetch")
+
+	return []CleanTarget{
+		{
+			Name:          "Prefetch",
+			Paths:         []string{filepath.Join(prefetch, "*.pf")},
+			Description:   "Prefetch traces of programs not started for 30 days",
+			RequiresAdmin: true,
+			Category:      CategoryPrefetch,
+			RiskLevel:     "medium",
+			MinAge:        PrefetchMinAge,
+		},
+		{
+			Name:          "ReadyBootLogs",
+			Paths:         []string{filepath.Join(prefetch, "ReadyBoot", "*.etl")},
+			Description:   "ReadyBoot boot trace logs",
+			RequiresAdmin: true,
+			Category:      CategoryPrefetch,
+			RiskLevel:     "medium",
+			MinAge:        PrefetchMinAge,
+		},
+	}
+}
+
+// IsPrefetchTrace reports whether path is a trace file the prefetch targets
+// clean: a .pf file directly inside the Prefetch directory or an .etl file
+// directly inside Prefetch\ReadyBoot.
+func IsPrefetchTrace(path string) bool {
+	prefetch := filepath.Join(systemRoot(), "Prefetch")
+	path = filepath.Clean(path)
+	dir, ext := filepath.Dir(path), strings.ToLower(filepath.Ext(path))
+	switch {
+	case strings.EqualFold(dir, prefetch):
+		return ext == ".pf"
+	case strings.EqualFold(dir, filepath.Join(prefetch, "ReadyBoot")):
+		return ext == ".etl"
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ─── All User Profiles ───────────────────────────────────────────────────────
+// Admins cleaning shared or lab machines can apply the per-user targets to
+// every profile under C:\Users. The targets are the current user's, with
+// paths moved from this profile into the other one; browsers are discovered
+// separately in each profile since users install different ones.
+
+// UserProfile is a local user profile folder.
+type UserProfile struct {
+	Name string `json:"name"` // folder name, usually the account name
+	Dir  string `json:"dir"`
+}
+
+// skippedProfiles are folders under C:\Users that are not user profiles.
+var skippedProfiles = map[string]bool{
+	"public": true, "default": true, "default user": true, "all users": true,
+	"defaultuser0": true, "wdagutilityaccount": true,
+}
+
+// ListUserProfiles returns the user profiles under <SystemDrive>\Users,
+// sorted by name. Junctions and folders without AppData\Local are skipped.
+func ListUserProfiles() []UserProfile {
+	usersDir := filepath.Join(systemDrive(), "Users")
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		return nil
+	}
+
+	var profiles []UserProfile
+	for _, e := range entries {
+		if !e.IsDir() || e.Type()&os.ModeSymlink != 0 || skippedProfiles[strings.ToLower(e.Name())] {
+			continue
+		}
+		dir := filepath.Join(usersDir, e.Name())
+		if !isDir(filepath.Join(dir, "AppData", "Local")) {
+			continue
+		}
+		profiles = append(profiles, UserProfile{Name: e.Name(), Dir: dir})
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return strings.ToLower(profiles[i].Name) < strings.ToLower(profiles[j].Name)
+	})
+	return profiles
+}
+
+// ProfileTargets returns the per-user targets for the profile in dir: the
+// "user" targets, the user-level WER report queues and the browser caches
+// of the browsers found in that profile. Paths outside the current user's
+// profile are machine-wide and left out.
+func ProfileTargets(dir string) []CleanTarget {
+	var targets []CleanTarget
+	for _, t := range GetCleanTargets() {
+		if t.Category != "user" && t.Name != "WERReports" {
+			continue
+		}
+		if t, ok := rebaseTarget(t, dir); ok {
+			targets = append(targets, t)
+		}
+	}
+
+	for _, def := range KnownBrowsers() {
+		def.DataDir, _ = rebaseProfilePath(def.DataDir, dir)
+		def.CacheDir, _ = rebaseProfilePath(def.CacheDir, dir)
+		b := DiscoveredBrowser{Def: def, Profiles: DiscoverProfiles(def)}
+		if paths := b.CachePaths(); len(paths) > 0 {
+			targets = append(targets, browserTarget(def, paths))
+		}
+	}
+	return targets
+}
+
+// rebaseTarget moves t's paths into the profile at dir, dropping paths
+// outside the current profile and duplicates. It reports false when no
+// path is left.
+func rebaseTarget(t CleanTarget, dir string) (CleanTarget, bool) {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, p := range t.Paths {
+		rebased, ok := rebaseProfilePath(p, dir)
+		if !ok || seen[strings.ToLower(rebased)] {
+			continue
+		}
+		seen[strings.ToLower(rebased)] = true
+		paths = append(paths, rebased)
+	}
+	t.Paths = paths
+	return t, len(paths) > 0
+}
+
+// rebaseProfilePath rewrites path from the current user's profile into the
+// profile at dir. It reports false, returning path unchanged, when path is
+// not inside the current profile.
+func rebaseProfilePath(path, dir string) (string, bool) {
+	home := filepath.Clean(userProfile())
+	if home == "." || path == "" {
+		return path, false
+	}
+	clean := filepath.Clean(path)
+	if strings.EqualFold(clean, home) {
+		return dir, true
+	}
+	prefix := home + string(filepath.Separator)
+	if len(clean) <= len(prefix) || !strings.EqualFold(clean[:len(prefix)], prefix) {
+		return path, false
+	}
+	return filepath.Join(dir, clean[len(prefix):]), true
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRebaseProfilePath(t *testing.T) {
+	home := filepath.Join(string(filepath.Separator)+"Users", "alice")
+	other := filepath.Join(string(filepath.Separator)+"Users", "bob")
+	t.Setenv("USERPROFILE", home)
+
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{filepath.Join(home, "AppData", "Local", "Temp"), filepath.Join(other, "AppData", "Local", "Temp"), true},
+		{home, other, true},
+		{filepath.Join(string(filepath.Separator)+"Users", "alice2", "Temp"), filepath.Join(string(filepath.Separator)+"Users", "alice2", "Temp"), false},
+		{filepath.Join(string(filepath.Separator)+"ProgramData", "WER"), filepath.Join(string(filepath.Separator)+"ProgramData", "WER"), false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := rebaseProfilePath(tt.in, other)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("rebaseProfilePath(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRebaseTargetDropsMachinePaths(t *testing.T) {
+	home := filepath.Join(string(filepath.Separator)+"Users", "alice")
+	other := filepath.Join(string(filepath.Separator)+"Users", "bob")
+	t.Setenv("USERPROFILE", home)
+
+	target := CleanTarget{Name: "WERReports", Paths: []string{
+		filepath.Join(home, "AppData", "Local", "WER"),
+		filepath.Join(home, "AppData", "Local", "WER"),
+		filepath.Join(string(filepath.Separator)+"ProgramData", "WER"),
+	}}
+	got, ok := rebaseTarget(target, other)
+	if !ok || len(got.Paths) != 1 || got.Paths[0] != filepath.Join(other, "AppData", "Local", "WER") {
+		t.Errorf("rebaseTarget paths = %v, %v; want only the rebased profile path", got.Paths, ok)
+	}
+
+	machineOnly := CleanTarget{Paths: []string{filepath.Join(string(filepath.Separator)+"Windows", "Temp")}}
+	if _, ok := rebaseTarget(machineOnly, other); ok {
+		t.Error("rebaseTarget kept a target with only machine-wide paths")
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ─── Log Retention ───────────────────────────────────────────────────────────
+// By default `pw clean <path>` deletes the .log files it finds. Users who
+// need old logs for troubleshooting can set "log_retention" in config.json
+// to archive them instead:
+//
+//	"log_retention": {"mode": "archive", "archive_after": "7d"}
+//
+// Logs older than archive_after are compressed into one zip per directory
+// and the originals removed; newer logs are left alone.
+
+// Log retention modes.
+const (
+	LogRetentionDelete  = "delete"
+	LogRetentionArchive = "archive"
+)
+
+// DefaultLogArchiveAfter is the age at which logs are archived when
+// archive_after is not set.
+const DefaultLogArchiveAfter = 7 * 24 * time.Hour
+
+// LogRetention is the retention policy for the "logs" junk category.
+type LogRetention struct {
+	// Mode is LogRetentionDelete (the default) or LogRetentionArchive.
+	Mode string `json:"mode,omitempty"`
+
+	// ArchiveAfter is the minimum age of logs to archive, e.g. "14d".
+	ArchiveAfter string `json:"archive_after,omitempty"`
+}
+
+// Archive reports whether logs are archived rather than deleted.
+func (r LogRetention) Archive() bool {
+	return strings.EqualFold(strings.TrimSpace(r.Mode), LogRetentionArchive)
+}
+
+// Age returns the minimum age of logs to archive.
+func (r LogRetention) Age() (time.Duration, error) {
+	if strings.TrimSpace(r.ArchiveAfter) == "" {
+		return DefaultLogArchiveAfter, nil
+	}
+	return ParseAge(r.ArchiveAfter)
+}
+
+// Validate reports a mode or age that cannot be used.
+func (r LogRetention) Validate() error {
+	switch strings.ToLower(strings.TrimSpace(r.Mode)) {
+	case "", LogRetentionDelete, LogRetentionArchive:
+	default:
+		return fmt.Errorf("invalid log_retention mode %q (use %s or %s)",
+			r.Mode, LogRetentionDelete, LogRetentionArchive)
+	}
+	if _, err := r.Age(); err != nil {
+		return fmt.Errorf("invalid log_retention archive_after: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogRetention(t *testing.T) {
+	var def LogRetention
+	if def.Archive() {
+		t.Error("zero LogRetention archives, want delete")
+	}
+	if age, err := def.Age(); err != nil || age != DefaultLogArchiveAfter {
+		t.Errorf("zero LogRetention Age() = %v, %v; want %v", age, err, DefaultLogArchiveAfter)
+	}
+
+	r := LogRetention{Mode: " Archive ", ArchiveAfter: "14d"}
+	if !r.Archive() {
+		t.Error("Archive() = false for mode archive")
+	}
+	if age, err := r.Age(); err != nil || age != 14*24*time.Hour {
+		t.Errorf("Age() = %v, %v; want 336h", age, err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	for _, bad := range []LogRetention{{Mode: "compress"}, {Mode: "archive", ArchiveAfter: "soon"}} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) succeeded, want error", bad)
+		}
+	}
+}
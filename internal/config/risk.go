@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─── Risk Levels ─────────────────────────────────────────────────────────────
+// Every clean target carries a risk level. Unattended runs only touch
+// targets at or below a ceiling; anything with an unknown level counts as
+// high so it is never cleaned by accident.
+
+// Risk levels, lowest first.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// riskRank orders risk levels. Unknown levels rank as high.
+func riskRank(level string) int {
+	switch level {
+	case RiskLow:
+		return 0
+	case RiskMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ParseRiskLevel validates a risk level given on the command line.
+func ParseRiskLevel(s string) (string, error) {
+	level := strings.ToLower(strings.TrimSpace(s))
+	switch level {
+	case RiskLow, RiskMedium, RiskHigh:
+		return level, nil
+	}
+	return "", fmt.Errorf("unknown risk level %q (use low, medium or high)", s)
+}
+
+// RiskAllowed reports whether a target at level may be cleaned under the
+// ceiling maxRisk.
+func RiskAllowed(level, maxRisk string) bool {
+	return riskRank(level) <= riskRank(maxRisk)
+}
+
+// HigherRisk returns the riskier of two levels.
+func HigherRisk(a, b string) string {
+	if riskRank(b) > riskRank(a) {
+		return b
+	}
+	return a
+}
+
+// TargetRiskLevel returns the risk level of the built-in target with the
+// given name, or "" if there is none.
+func TargetRiskLevel(name string) string {
+	for _, t := range GetCleanTargets() {
+		if t.Name == name {
+			return t.RiskLevel
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+func TestRiskAllowed(t *testing.T) {
+	tests := []struct {
+		maxRisk string
+		allowed map[string]bool
+	}{
+		{RiskLow, map[string]bool{RiskLow: true, RiskMedium: false, RiskHigh: false, "": false, "extreme": false}},
+		{RiskMedium, map[string]bool{RiskLow: true, RiskMedium: true, RiskHigh: false, "": false, "extreme": false}},
+		{RiskHigh, map[string]bool{RiskLow: true, RiskMedium: true, RiskHigh: true, "": true, "extreme": true}},
+	}
+	for _, tt := range tests {
+		for level, want := range tt.allowed {
+			if got := RiskAllowed(level, tt.maxRisk); got != want {
+				t.Errorf("RiskAllowed(%q, %q) = %v, want %v", level, tt.maxRisk, got, want)
+			}
+		}
+	}
+}
+
+func TestParseRiskLevel(t *testing.T) {
+	for in, want := range map[string]string{"low": RiskLow, " Medium ": RiskMedium, "HIGH": RiskHigh} {
+		got, err := ParseRiskLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseRiskLevel(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "none", "critical"} {
+		if _, err := ParseRiskLevel(in); err == nil {
+			t.Errorf("ParseRiskLevel(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestHigherRisk(t *testing.T) {
+	tests := []struct{ a, b, want string }{
+		{RiskLow, RiskLow, RiskLow},
+		{RiskLow, RiskMedium, RiskMedium},
+		{RiskHigh, RiskMedium, RiskHigh},
+		{RiskLow, "", ""},
+	}
+	for _, tt := range tests {
+		if got := HigherRisk(tt.a, tt.b); got != tt.want {
+			t.Errorf("HigherRisk(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTargetRiskLevel(t *testing.T) {
+	if got := TargetRiskLevel("WindowsOld"); got != RiskHigh {
+		t.Errorf("TargetRiskLevel(WindowsOld) = %q, want %q", got, RiskHigh)
+	}
+	if got := TargetRiskLevel("NoSuchTarget"); got != "" {
+		t.Errorf("TargetRiskLevel(NoSuchTarget) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"100B", 100},
+		{"4K", 4 << 10},
+		{"10MB", 10 << 20},
+		{"1.5GB", 3 << 29},
+		{" 2t ", 2 << 40},
+	}
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if err != nil {
+			t.Errorf("ParseSize(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "GB", "10XB", "1..5MB"} {
+		if _, err := ParseSize(bad); err == nil {
+			t.Errorf("ParseSize(%q) expected an error", bad)
+		}
+	}
+}
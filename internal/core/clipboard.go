@@ -0,0 +1,69 @@
+package core
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Clipboard ───────────────────────────────────────────────────────────────
+// Text goes on the clipboard as CF_UNICODETEXT, so paths with non-ASCII
+// names survive (clip.exe converts through the console code page).
+
+var (
+	modUser32            = windows.NewLazySystemDLL("user32.dll")
+	procOpenClipboard    = modUser32.NewProc("OpenClipboard")
+	procCloseClipboard   = modUser32.NewProc("CloseClipboard")
+	procEmptyClipboard   = modUser32.NewProc("EmptyClipboard")
+	procSetClipboardData = modUser32.NewProc("SetClipboardData")
+
+	modKernel32      = windows.NewLazySystemDLL("kernel32.dll")
+	procGlobalAlloc  = modKernel32.NewProc("GlobalAlloc")
+	procGlobalFree   = modKernel32.NewProc("GlobalFree")
+	procGlobalLock   = modKernel32.NewProc("GlobalLock")
+	procGlobalUnlock = modKernel32.NewProc("GlobalUnlock")
+	procMoveMemory   = modKernel32.NewProc("RtlMoveMemory")
+)
+
+const (
+	cfUnicodeText = 13
+	gmemMoveable  = 0x0002
+)
+
+// CopyToClipboard replaces the clipboard contents with text.
+func CopyToClipboard(text string) error {
+	data, err := windows.UTF16FromString(text)
+	if err != nil {
+		return err
+	}
+
+	if r, _, callErr := procOpenClipboard.Call(0); r == 0 {
+		return fmt.Errorf("cannot open clipboard: %w", callErr)
+	}
+	defer procCloseClipboard.Call()
+
+	if r, _, callErr := procEmptyClipboard.Call(); r == 0 {
+		return fmt.Errorf("cannot empty clipboard: %w", callErr)
+	}
+
+	size := uintptr(len(data)) * unsafe.Sizeof(data[0])
+	mem, _, callErr := procGlobalAlloc.Call(gmemMoveable, size)
+	if mem == 0 {
+		return fmt.Errorf("cannot allocate clipboard memory: %w", callErr)
+	}
+	ptr, _, callErr := procGlobalLock.Call(mem)
+	if ptr == 0 {
+		procGlobalFree.Call(mem)
+		return fmt.Errorf("cannot lock clipboard memory: %w", callErr)
+	}
+	procMoveMemory.Call(ptr, uintptr(unsafe.Pointer(&data[0])), size)
+	procGlobalUnlock.Call(mem)
+
+	// On success the clipboard owns the memory.
+	if r, _, callErr := procSetClipboardData.Call(cfUnicodeText, mem); r == 0 {
+		procGlobalFree.Call(mem)
+		return fmt.Errorf("cannot set clipboard data: %w", callErr)
+	}
+	return nil
+}
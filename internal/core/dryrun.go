@@ -12,9 +12,9 @@ import (
 
 // DryRunItem represents a single file or directory that would be deleted.
 type DryRunItem struct {
-	Path     string
-	Size     int64
-	Category string
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Category string `json:"category"`
 }
 
 // DryRunContext tracks what WOULD be deleted during a dry-run.
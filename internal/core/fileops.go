@@ -3,8 +3,11 @@ package core
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"golang.org/x/sys/windows"
@@ -105,9 +108,16 @@ func SafeDelete(path string, dryRun bool) (int64, error) {
 			time.Sleep(backoff)
 		}
 
-		if info.IsDir() {
+		l := currentLimiter()
+		switch {
+		case info.IsDir() && l != nil:
+			lastErr = removeAllThrottled(path, l)
+		case info.IsDir():
 			lastErr = os.RemoveAll(path)
-		} else {
+		default:
+			if l != nil {
+				l.wait()
+			}
 			lastErr = os.Remove(path)
 		}
 
@@ -148,6 +158,142 @@ func SafeDeleteWithWhitelist(path string, dryRun bool, isWhitelisted func(string
 	return SafeDelete(path, dryRun)
 }
 
+// SafeMove moves a file or directory into destDir, keeping its name, after
+// the same whitelist and safety checks as SafeDeleteWithWhitelist. Moves
+// within a volume are a rename; across volumes the item is copied and the
+// source deleted only once the copy is complete, so a failed move leaves
+// the source untouched. A copy cannot carry junctions and symbolic links
+// over, so a folder containing one is never moved across volumes. In
+// dryRun mode it only reports the size. Returns
+// the number of bytes moved (or that would be moved).
+func SafeMove(path, destDir string, dryRun bool, isWhitelisted func(string) bool) (int64, error) {
+	if isWhitelisted != nil && isWhitelisted(path) {
+		return 0, fmt.Errorf("path is whitelisted and will be skipped: %s", path)
+	}
+	if err := ValidatePath(path); err != nil {
+		return 0, fmt.Errorf("safety check failed for %s: %w", path, err)
+	}
+
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, fmt.Errorf("cannot stat %s: %w", path, err)
+	}
+	if isReparsePoint(fs.FileInfoToDirEntry(info)) {
+		return 0, fmt.Errorf("refusing to move symlink or junction %s", path)
+	}
+	size := info.Size()
+	if info.IsDir() {
+		size, _ = GetDirSize(path)
+	}
+
+	destInfo, err := os.Stat(destDir)
+	if err != nil {
+		return 0, fmt.Errorf("cannot use destination %s: %w", destDir, err)
+	}
+	if !destInfo.IsDir() {
+		return 0, fmt.Errorf("destination is not a folder: %s", destDir)
+	}
+	dest := filepath.Join(destDir, filepath.Base(path))
+	if _, err := os.Lstat(dest); err == nil {
+		return 0, fmt.Errorf("%s already exists", dest)
+	}
+	if rel, err := filepath.Rel(path, dest); err == nil && !strings.HasPrefix(rel, "..") {
+		return 0, fmt.Errorf("cannot move %s into itself", path)
+	}
+
+	if dryRun {
+		return size, nil
+	}
+
+	err = os.Rename(path, dest)
+	if err == nil {
+		return size, nil
+	}
+	var errno windows.Errno
+	if !errors.As(err, &errno) || errno != windows.ERROR_NOT_SAME_DEVICE {
+		return 0, fmt.Errorf("cannot move %s: %w", path, err)
+	}
+
+	// Different volume: copy, then delete the source.
+	if link := findReparsePoint(path); link != "" {
+		return 0, fmt.Errorf("refusing to move %s to another volume: the link %s inside it would be lost", path, link)
+	}
+	if err := copyTree(path, dest); err != nil {
+		_ = os.RemoveAll(dest)
+		return 0, fmt.Errorf("cannot copy %s to %s: %w", path, dest, err)
+	}
+	if _, err := SafeDelete(path, false); err != nil {
+		return size, fmt.Errorf("copied to %s but could not remove the original: %w", dest, err)
+	}
+	return size, nil
+}
+
+// findReparsePoint returns the first junction, symbolic link or other
+// reparse point below root, or "" when there is none. Links are not
+// followed.
+func findReparsePoint(root string) string {
+	var found string
+	_ = filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil || p == root {
+			return nil
+		}
+		if isReparsePoint(d) {
+			found = p
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
+// copyTree copies a file or directory tree to dest, keeping modification
+// times. It fails on a symlink or junction inside the tree rather than
+// drop it; SafeMove checks for them before copying.
+func copyTree(src, dest string) error {
+	return filepath.WalkDir(src, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case isReparsePoint(d):
+			return fmt.Errorf("cannot copy link %s", p)
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		}
+		if err := copyFile(p, target, info); err != nil {
+			return err
+		}
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+}
+
+// copyFile copies one regular file's contents.
+func copyFile(src, dest string, info os.FileInfo) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
 // SafeCleanDir removes files matching a glob pattern within a directory.
 // Returns total bytes freed and number of files deleted.
 func SafeCleanDir(dir string, pattern string, dryRun bool) (int64, int, error) {
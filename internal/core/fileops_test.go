@@ -1,10 +1,14 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"golang.org/x/sys/windows"
 )
 
 // unprotectedTempDir creates a temporary directory that passes IsSafePath.
@@ -152,6 +156,108 @@ func TestSafeDeleteWithWhitelist_SkipsWhitelisted(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// SafeMove tests
+// ---------------------------------------------------------------------------
+
+func TestSafeDelete_PrefetchTracesOnly(t *testing.T) {
+	// Point SystemRoot at a scratch directory so its Prefetch folder is
+	// protected like the real one without touching the real one.
+	root := unprotectedTempDir(t)
+	t.Setenv("SystemRoot", root)
+	prefetch := filepath.Join(root, "Prefetch")
+	readyBoot := filepath.Join(prefetch, "ReadyBoot")
+	nested := filepath.Join(prefetch, "Sub")
+	for _, dir := range []string{readyBoot, nested} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write := func(path string) string {
+		t.Helper()
+		if err := os.WriteFile(path, []byte("trace"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for _, p := range []string{
+		write(filepath.Join(prefetch, "APP.EXE-12345678.pf")),
+		write(filepath.Join(readyBoot, "ReadyBoot.etl")),
+	} {
+		if _, err := SafeDelete(p, false); err != nil {
+			t.Errorf("SafeDelete(%q) should delete a prefetch trace: %v", p, err)
+		}
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Errorf("%q should have been deleted", p)
+		}
+	}
+
+	for _, p := range []string{
+		prefetch,
+		readyBoot,
+		write(filepath.Join(prefetch, "Layout.ini")),
+		write(filepath.Join(prefetch, "trace.etl")),
+		write(filepath.Join(nested, "APP.EXE-12345678.pf")),
+	} {
+		if _, err := SafeDelete(p, false); err == nil {
+			t.Errorf("SafeDelete(%q) must stay protected", p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("%q should still exist: %v", p, err)
+		}
+	}
+}
+
+func TestSafeMove_MovesIntoFolder(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	src := filepath.Join(dir, "moveme.tmp")
+	if err := os.WriteFile(src, []byte("move me"), 0o644); err != nil {
+		t.Fatalf("cannot create test file: %v", err)
+	}
+	dest := filepath.Join(dir, "dest")
+	if err := os.Mkdir(dest, 0o755); err != nil {
+		t.Fatalf("cannot create destination: %v", err)
+	}
+
+	size, err := SafeMove(src, dest, false, nil)
+	if err != nil {
+		t.Fatalf("SafeMove should move valid file, got: %v", err)
+	}
+	if size != int64(len("move me")) {
+		t.Errorf("expected size %d, got %d", len("move me"), size)
+	}
+	if _, statErr := os.Stat(src); !os.IsNotExist(statErr) {
+		t.Error("source still exists after SafeMove")
+	}
+	if _, statErr := os.Stat(filepath.Join(dest, "moveme.tmp")); statErr != nil {
+		t.Errorf("moved file missing at destination: %v", statErr)
+	}
+}
+
+func TestSafeMove_DryRunAndWhitelist(t *testing.T) {
+	dir := unprotectedTempDir(t)
+	src := filepath.Join(dir, "stay.tmp")
+	if err := os.WriteFile(src, []byte("stay"), 0o644); err != nil {
+		t.Fatalf("cannot create test file: %v", err)
+	}
+
+	if _, err := SafeMove(src, dir+`\missing`, false, nil); err == nil {
+		t.Error("SafeMove should reject a missing destination")
+	}
+	if _, err := SafeMove(src, os.TempDir(), true, nil); err != nil {
+		t.Errorf("SafeMove(dryRun=true) returned error: %v", err)
+	}
+	_, err := SafeMove(src, os.TempDir(), false, func(string) bool { return true })
+	if err == nil || !strings.Contains(err.Error(), "whitelisted") {
+		t.Errorf("SafeMove should skip whitelisted path, got: %v", err)
+	}
+	if _, statErr := os.Stat(src); statErr != nil {
+		t.Fatal("file was moved during dry run or despite whitelist — SAFETY VIOLATION")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // FormatSize tests
 // ---------------------------------------------------------------------------
@@ -178,3 +284,26 @@ func TestFormatSize(t *testing.T) {
 		}
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Locked-file tests
+// ---------------------------------------------------------------------------
+
+func TestIsLockedError(t *testing.T) {
+	locked := fmt.Errorf("failed to delete: %w",
+		&os.PathError{Op: "remove", Path: `D:\x\a.log`, Err: windows.ERROR_SHARING_VIOLATION})
+	if !IsLockedError(locked) {
+		t.Error("sharing violation should count as locked")
+	}
+	if got := LockedPath(locked); got != `D:\x\a.log` {
+		t.Errorf("LockedPath = %q, want %q", got, `D:\x\a.log`)
+	}
+
+	denied := &os.PathError{Op: "remove", Path: `D:\x`, Err: windows.ERROR_ACCESS_DENIED}
+	if IsLockedError(denied) {
+		t.Error("access denied should not count as locked")
+	}
+	if got := LockedPath(errors.New("plain")); got != "" {
+		t.Errorf("LockedPath of a plain error = %q, want empty", got)
+	}
+}
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Locked Files ────────────────────────────────────────────────────────────
+// Files held open by a running process cannot be deleted. SafeDelete gives
+// up on them after its retries; callers can then ask the Restart Manager
+// which processes hold the lock, or queue the path for deletion at the next
+// reboot with MoveFileEx(MOVEFILE_DELAY_UNTIL_REBOOT).
+
+var (
+	modRstrtmgr             = windows.NewLazySystemDLL("rstrtmgr.dll")
+	procRmStartSession      = modRstrtmgr.NewProc("RmStartSession")
+	procRmRegisterResources = modRstrtmgr.NewProc("RmRegisterResources")
+	procRmGetList           = modRstrtmgr.NewProc("RmGetList")
+	procRmEndSession        = modRstrtmgr.NewProc("RmEndSession")
+)
+
+const (
+	cchRmSessionKey = 32
+	cchRmMaxAppName = 255
+	cchRmMaxSvcName = 63
+)
+
+// rmUniqueProcess mirrors RM_UNIQUE_PROCESS.
+type rmUniqueProcess struct {
+	ProcessID        uint32
+	ProcessStartTime windows.Filetime
+}
+
+// rmProcessInfo mirrors RM_PROCESS_INFO.
+type rmProcessInfo struct {
+	Process          rmUniqueProcess
+	AppName          [cchRmMaxAppName + 1]uint16
+	ServiceShortName [cchRmMaxSvcName + 1]uint16
+	ApplicationType  uint32
+	AppStatus        uint32
+	TSSessionID      uint32
+	Restartable      int32
+}
+
+// LockingProcess is a process holding one of the queried files open.
+type LockingProcess struct {
+	PID     uint32 `json:"pid"`
+	Name    string `json:"name"`
+	Service string `json:"service,omitempty"`
+}
+
+// IsLockedError reports whether err was caused by a file being in use by
+// another process.
+func IsLockedError(err error) bool {
+	return isRetryableError(err)
+}
+
+// LockedPath returns the file named in a locked-file error. Deleting a
+// directory fails on the first locked file inside it, so this may be a
+// descendant of the path passed to SafeDelete. It returns "" when err does
+// not name a file.
+func LockedPath(err error) string {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Path
+	}
+	return ""
+}
+
+// FindLockingProcesses asks the Restart Manager which processes hold any of
+// files open.
+func FindLockingProcesses(files []string) ([]LockingProcess, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	if err := procRmStartSession.Find(); err != nil {
+		return nil, fmt.Errorf("restart manager unavailable: %w", err)
+	}
+
+	var session uint32
+	var key [cchRmSessionKey + 1]uint16
+	if ret, _, _ := procRmStartSession.Call(
+		uintptr(unsafe.Pointer(&session)), 0, uintptr(unsafe.Pointer(&key[0])),
+	); ret != 0 {
+		return nil, fmt.Errorf("RmStartSession failed: %w", windows.Errno(ret))
+	}
+	defer procRmEndSession.Call(uintptr(session))
+
+	names := make([]*uint16, 0, len(files))
+	for _, f := range files {
+		p, err := windows.UTF16PtrFromString(f)
+		if err != nil {
+			continue
+		}
+		names = append(names, p)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if ret, _, _ := procRmRegisterResources.Call(
+		uintptr(session),
+		uintptr(len(names)), uintptr(unsafe.Pointer(&names[0])),
+		0, 0, 0, 0,
+	); ret != 0 {
+		return nil, fmt.Errorf("RmRegisterResources failed: %w", windows.Errno(ret))
+	}
+
+	var infos []rmProcessInfo
+	for attempt := 0; attempt < 3; attempt++ {
+		var needed, reasons uint32
+		count := uint32(len(infos))
+		var buf *rmProcessInfo
+		if count > 0 {
+			buf = &infos[0]
+		}
+		ret, _, _ := procRmGetList.Call(
+			uintptr(session),
+			uintptr(unsafe.Pointer(&needed)),
+			uintptr(unsafe.Pointer(&count)),
+			uintptr(unsafe.Pointer(buf)),
+			uintptr(unsafe.Pointer(&reasons)),
+		)
+		switch windows.Errno(ret) {
+		case 0:
+			return lockingProcesses(infos[:count]), nil
+		case windows.ERROR_MORE_DATA:
+			infos = make([]rmProcessInfo, needed)
+		default:
+			return nil, fmt.Errorf("RmGetList failed: %w", windows.Errno(ret))
+		}
+	}
+	return nil, fmt.Errorf("RmGetList failed: process list kept changing")
+}
+
+// lockingProcesses converts Restart Manager records, dropping duplicates.
+func lockingProcesses(infos []rmProcessInfo) []LockingProcess {
+	seen := make(map[uint32]bool)
+	var procs []LockingProcess
+	for _, info := range infos {
+		pid := info.Process.ProcessID
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+		procs = append(procs, LockingProcess{
+			PID:     pid,
+			Name:    windows.UTF16ToString(info.AppName[:]),
+			Service: windows.UTF16ToString(info.ServiceShortName[:]),
+		})
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].PID < procs[j].PID })
+	return procs
+}
+
+// ScheduleDeleteOnReboot queues path for deletion when Windows next starts.
+// For a directory, whatever can be deleted now is deleted; only the files
+// that still fail are queued, then the directories left behind, deepest
+// first, since only empty directories can be removed at boot. Every queued
+// path passes ValidatePath, and whitelisted paths (and the directories
+// holding them) are left alone. It returns the number of entries queued.
+// Requires administrator privileges.
+func ScheduleDeleteOnReboot(path string, isWhitelisted func(string) bool) (int, error) {
+	if err := ValidatePath(path); err != nil {
+		return 0, fmt.Errorf("safety check failed for %s: %w", path, err)
+	}
+	if isWhitelisted != nil && isWhitelisted(path) {
+		return 0, fmt.Errorf("path is whitelisted and will be skipped: %s", path)
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, fmt.Errorf("cannot stat %s: %w", path, err)
+	}
+
+	paths := []string{path}
+	if info.IsDir() {
+		paths = rebootDeletePaths(path, isWhitelisted)
+	}
+
+	queued := 0
+	for _, p := range paths {
+		from, err := windows.UTF16PtrFromString(p)
+		if err != nil {
+			continue
+		}
+		if err := windows.MoveFileEx(from, nil, windows.MOVEFILE_DELAY_UNTIL_REBOOT); err != nil {
+			return queued, fmt.Errorf("cannot schedule %s for deletion at reboot: %w", p, err)
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// rebootDeletePaths retries every entry under dir and returns the files that
+// still cannot be deleted, followed by the directories that will be empty
+// once they are gone, deepest first. Paths that fail ValidatePath or are
+// whitelisted are kept, and so are the directories above them.
+func rebootDeletePaths(dir string, isWhitelisted func(string) bool) []string {
+	var files, dirs, kept []string
+	_ = filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			kept = append(kept, p)
+			return nil
+		}
+		if ValidatePath(p) != nil || (isWhitelisted != nil && isWhitelisted(p)) {
+			kept = append(kept, p)
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			dirs = append(dirs, p)
+			return nil
+		}
+		if os.Remove(p) != nil {
+			files = append(files, p)
+		}
+		return nil
+	})
+
+	// Longer paths are deeper; handle children before their parents. A
+	// directory holding a kept path never becomes empty, and one that is
+	// empty already is removed now.
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+	for _, d := range dirs {
+		if !holdsAny(d, kept) && os.Remove(d) != nil {
+			files = append(files, d)
+		}
+	}
+	return files
+}
+
+// holdsAny reports whether any of paths is inside dir.
+func holdsAny(dir string, paths []string) bool {
+	prefix := strings.ToLower(dir) + string(os.PathSeparator)
+	for _, p := range paths {
+		if strings.HasPrefix(strings.ToLower(p), prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// restorePointTimeout bounds Checkpoint-Computer; creating a restore point
+// snapshots the system volume and can take a few minutes.
+const restorePointTimeout = 5 * time.Minute
+
+// ErrRestorePointRecent is returned when Windows skipped the restore point
+// because one was already created in the last 24 hours (the default
+// SystemRestorePointCreationFrequency). That earlier point still covers
+// the operation.
+var ErrRestorePointRecent = errors.New("a restore point was already created in the last 24 hours")
+
+// CreateRestorePoint creates a System Restore point with the given
+// description through Checkpoint-Computer (SRSetRestorePoint). It requires
+// administrator privileges and System Protection enabled on the system
+// drive.
+func CreateRestorePoint(description string) error {
+	if err := RequireAdmin("create restore point"); err != nil {
+		return err
+	}
+
+	// Single quotes are doubled to keep the description a literal.
+	desc := strings.ReplaceAll(description, "'", "''")
+	script := "$ErrorActionPreference = 'Stop'; " +
+		"Checkpoint-Computer -Description '" + desc + "' -RestorePointType MODIFY_SETTINGS " +
+		"-WarningVariable w -WarningAction SilentlyContinue; " +
+		"if ($w) { Write-Output ('WARNING: ' + $w[0]) }"
+
+	ctx, cancel := context.WithTimeout(context.Background(), restorePointTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	text := strings.TrimSpace(string(out))
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("creating a restore point timed out after %s", restorePointTimeout)
+		}
+		if text == "" {
+			text = err.Error()
+		}
+		return fmt.Errorf("create restore point: %s", firstLine(text))
+	}
+
+	if warning, ok := strings.CutPrefix(text, "WARNING: "); ok {
+		if strings.Contains(strings.ToLower(warning), "1440 minutes") ||
+			strings.Contains(strings.ToLower(warning), "past") {
+			return ErrRestorePointRecent
+		}
+		return fmt.Errorf("create restore point: %s", firstLine(warning))
+	}
+	return nil
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
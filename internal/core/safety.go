@@ -12,9 +12,15 @@ import (
 )
 
 // IsSafePath returns true if the given path is NOT in the NEVER_DELETE list.
-// Paths are compared case-insensitively after cleaning.
+// Paths are compared case-insensitively after cleaning. Prefetch trace files
+// are the only exception (see config.IsPrefetchTrace).
 func IsSafePath(path string) bool {
 	cleaned := filepath.Clean(path)
+	// Prefetch traces are the one kind of file cleaned inside a protected
+	// directory.
+	if config.IsPrefetchTrace(cleaned) {
+		return true
+	}
 	for _, protected := range config.GetNeverDeletePaths() {
 		if strings.EqualFold(cleaned, filepath.Clean(protected)) {
 			return false
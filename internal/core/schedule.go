@@ -0,0 +1,235 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// scheduleTimeout bounds each schtasks invocation.
+const scheduleTimeout = 30 * time.Second
+
+// ScheduleFolder is the Task Scheduler folder holding PureWin tasks.
+const ScheduleFolder = `\PureWin\`
+
+// scheduleTimePattern validates an HH:MM start time.
+var scheduleTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// ScheduledTask describes a recurring PureWin invocation registered with
+// the Windows Task Scheduler.
+type ScheduledTask struct {
+	// Name is the task name inside ScheduleFolder.
+	Name string
+
+	// Args are the pw arguments to run (e.g. "analyze", "record").
+	Args []string
+
+	// Frequency is "daily", "weekly" or "monthly".
+	Frequency string
+
+	// StartTime is the HH:MM local start time.
+	StartTime string
+
+	// Elevated runs the task with highest privileges. Registering such a
+	// task requires an elevated process.
+	Elevated bool
+}
+
+// CreateScheduledTask registers (or replaces) a task that runs the current
+// executable with the task's arguments. The task runs as the current user.
+func CreateScheduledTask(t ScheduledTask) error {
+	var sc string
+	switch strings.ToLower(t.Frequency) {
+	case "daily":
+		sc = "DAILY"
+	case "weekly":
+		sc = "WEEKLY"
+	case "monthly":
+		sc = "MONTHLY"
+	default:
+		return fmt.Errorf("unsupported frequency %q (use daily, weekly or monthly)", t.Frequency)
+	}
+	if !scheduleTimePattern.MatchString(t.StartTime) {
+		return fmt.Errorf("invalid start time %q (use HH:MM)", t.StartTime)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot determine executable path: %w", err)
+	}
+
+	parts := []string{escapeWindowsArg(exe)}
+	for _, arg := range t.Args {
+		parts = append(parts, escapeWindowsArg(arg))
+	}
+
+	args := []string{"/Create", "/F",
+		"/TN", ScheduleFolder + t.Name,
+		"/TR", strings.Join(parts, " "),
+		"/SC", sc,
+		"/ST", t.StartTime}
+	if sc == "MONTHLY" {
+		args = append(args, "/D", "1")
+	}
+	if t.Elevated {
+		args = append(args, "/RL", "HIGHEST")
+	}
+	return runSchtasks(args...)
+}
+
+// DeleteScheduledTask removes a PureWin task.
+func DeleteScheduledTask(name string) error {
+	return runSchtasks("/Delete", "/F", "/TN", ScheduleFolder+name)
+}
+
+// DeleteTaskByPath removes any task, named by its path below the root as
+// returned by ListAllScheduledTasks.
+func DeleteTaskByPath(name string) error {
+	return runSchtasks("/Delete", "/F", "/TN", `\`+name)
+}
+
+// ScheduledTaskExists reports whether a PureWin task is registered.
+func ScheduledTaskExists(name string) bool {
+	return runSchtasks("/Query", "/TN", ScheduleFolder+name) == nil
+}
+
+// taskSettingsBlock and taskEnabledFlag find the Enabled flag of a task's
+// Settings in `schtasks /Query /XML` output. Element names are not
+// localized; triggers carry their own Enabled flags, so only the Settings
+// block is searched.
+var (
+	taskSettingsBlock = regexp.MustCompile(`(?s)<Settings>(.*?)</Settings>`)
+	taskEnabledFlag   = regexp.MustCompile(`<Enabled>\s*(true|false)\s*</Enabled>`)
+)
+
+// ScheduledTaskEnabled reports whether any task, named by its path below
+// the root (e.g. `Microsoft\Windows\Autochk\Proxy`), is enabled. A task
+// whose settings omit the flag is enabled.
+func ScheduledTaskEnabled(name string) (bool, error) {
+	output, err := schtasksOutput("/Query", "/TN", `\`+name, "/XML")
+	if err != nil {
+		return false, err
+	}
+	settings := taskSettingsBlock.FindSubmatch(output)
+	if settings == nil {
+		return true, nil
+	}
+	m := taskEnabledFlag.FindSubmatch(settings[1])
+	return m == nil || string(m[1]) == "true", nil
+}
+
+// SetScheduledTaskEnabled enables or disables any task, named by its path
+// below the root.
+func SetScheduledTaskEnabled(name string, enabled bool) error {
+	flag := "/DISABLE"
+	if enabled {
+		flag = "/ENABLE"
+	}
+	return runSchtasks("/Change", "/TN", `\`+name, flag)
+}
+
+// ScheduledTaskStatus is a registered PureWin task as reported by schtasks.
+type ScheduledTaskStatus struct {
+	// Name is the task name inside ScheduleFolder.
+	Name string `json:"name"`
+
+	// Command is the full command line the task runs.
+	Command    string `json:"command"`
+	NextRun    string `json:"next_run"`
+	LastRun    string `json:"last_run"`
+	LastResult string `json:"last_result"`
+	Status     string `json:"status"`
+}
+
+// ListScheduledTasks returns every task in ScheduleFolder.
+func ListScheduledTasks() ([]ScheduledTaskStatus, error) {
+	output, err := schtasksOutput("/Query", "/FO", "CSV", "/V", "/NH")
+	if err != nil {
+		return nil, err
+	}
+	return parseSchtasksCSV(output)
+}
+
+// ListAllScheduledTasks returns every task registered on the machine, named
+// by its path below the root (e.g. `Vendor\UpdateTask`). Tasks in folders the
+// current user cannot read are missing unless run as administrator.
+func ListAllScheduledTasks() ([]ScheduledTaskStatus, error) {
+	output, err := schtasksOutput("/Query", "/FO", "CSV", "/V", "/NH")
+	if err != nil {
+		return nil, err
+	}
+	return parseSchtasksRows(output, `\`)
+}
+
+// parseSchtasksCSV extracts PureWin tasks from verbose, headerless
+// `schtasks /Query /FO CSV` output.
+func parseSchtasksCSV(data []byte) ([]ScheduledTaskStatus, error) {
+	return parseSchtasksRows(data, ScheduleFolder)
+}
+
+// parseSchtasksRows extracts the tasks under folder from verbose,
+// headerless `schtasks /Query /FO CSV` output; names are relative to
+// folder. Columns are read by position because the header names are
+// localized. Tasks with several triggers appear once per trigger; only the
+// first row is kept.
+func parseSchtasksRows(data []byte, folder string) ([]ScheduledTaskStatus, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schtasks output: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var tasks []ScheduledTaskStatus
+	for _, rec := range records {
+		// HostName, TaskName, Next Run Time, Status, Logon Mode,
+		// Last Run Time, Last Result, Author, Task To Run, ...
+		if len(rec) < 9 || !strings.HasPrefix(rec[1], folder) {
+			continue
+		}
+		name := strings.TrimPrefix(rec[1], folder)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tasks = append(tasks, ScheduledTaskStatus{
+			Name:       name,
+			NextRun:    rec[2],
+			Status:     rec[3],
+			LastRun:    rec[5],
+			LastResult: rec[6],
+			Command:    rec[8],
+		})
+	}
+	return tasks, nil
+}
+
+// runSchtasks runs schtasks.exe and folds its output into any error.
+func runSchtasks(args ...string) error {
+	_, err := schtasksOutput(args...)
+	return err
+}
+
+// schtasksOutput runs schtasks.exe and returns its output.
+func schtasksOutput(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), scheduleTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "schtasks", args...).CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(output))
+		if msg == "" {
+			return nil, fmt.Errorf("schtasks failed: %w", err)
+		}
+		return nil, fmt.Errorf("schtasks failed: %s", msg)
+	}
+	return output, nil
+}
@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+func TestParseSchtasksCSV(t *testing.T) {
+	data := []byte(`"PC","\Microsoft\Windows\Defrag\ScheduledDefrag","N/A","Ready","Interactive/Background","1/1/2026 3:00:00 AM","0","Microsoft","%windir%\system32\defrag.exe -c"
+"PC","\PureWin\Clean-nightly","10/17/2026 3:00:00 AM","Ready","Interactive only","10/16/2026 3:00:00 AM","0","PC\me","""C:\Tools\pw.exe"" clean --user --yes"
+"PC","\PureWin\Clean-nightly","10/17/2026 3:00:00 AM","Ready","Interactive only","10/16/2026 3:00:00 AM","0","PC\me","""C:\Tools\pw.exe"" clean --user --yes"
+"PC","\PureWin\AnalyzeScan","N/A","Disabled","Interactive only","N/A","267011","PC\me","""C:\Tools\pw.exe"" analyze record"
+`)
+
+	tasks, err := parseSchtasksCSV(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("got %d tasks, want 2: %+v", len(tasks), tasks)
+	}
+	if tasks[0].Name != "Clean-nightly" || tasks[0].Command != `"C:\Tools\pw.exe" clean --user --yes` {
+		t.Errorf("first task = %+v", tasks[0])
+	}
+	if tasks[1].Name != "AnalyzeScan" || tasks[1].Status != "Disabled" || tasks[1].LastResult != "267011" {
+		t.Errorf("second task = %+v", tasks[1])
+	}
+}
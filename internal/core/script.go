@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ScriptAction is a cleanup step that is not a plain path deletion (e.g.
+// emptying the Recycle Bin). Its Command is emitted verbatim.
+type ScriptAction struct {
+	Description string
+	Size        int64
+	Command     string // A single PowerShell statement.
+}
+
+// psQuote returns s as a single-quoted PowerShell string literal. PowerShell
+// treats the typographic quotes ‘ ’ ‚ ‛ like ', so they are doubled too.
+func psQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('\'')
+	for _, r := range s {
+		switch r {
+		case '\'', '‘', '’', '‚', '‛':
+			b.WriteRune(r)
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// commentSafe escapes the control and other non-printable characters in s
+// Go-style (\n, \x1b, \u2028), so a file name cannot end a # comment
+// and smuggle a statement into the script.
+func commentSafe(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsPrint(r) {
+			b.WriteRune(r)
+			continue
+		}
+		q := strconv.QuoteRune(r)
+		b.WriteString(q[1 : len(q)-1])
+	}
+	return b.String()
+}
+
+// headerSafe is commentSafe for the <# #> block at the top of the script,
+// where "#>" would also end the comment.
+func headerSafe(s string) string {
+	return strings.ReplaceAll(commentSafe(s), "#>", "# >")
+}
+
+// ExportScript writes a PowerShell script that performs the tracked
+// deletions instead of running them, so administrators can review and
+// execute the change through their own tooling. Every path is re-validated
+// against the NEVER_DELETE rules; rejected paths are emitted as comments.
+// The script supports -WhatIf and -Confirm via SupportsShouldProcess.
+// Remove-Item -Recurse follows junctions and symbolic links on Windows
+// PowerShell 5.1, so the script skips a path that is a link or, for a
+// folder, contains one, checked when the script runs.
+func (d *DryRunContext) ExportScript(path, command string, actions []ScriptAction) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("cannot create script directory %s: %w", dir, err)
+	}
+
+	host, _ := os.Hostname()
+	total := d.TotalSizeUnlocked()
+	for _, a := range actions {
+		total += a.Size
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<#\n")
+	sb.WriteString("  PureWin removal script\n")
+	sb.WriteString(fmt.Sprintf("  Generated: %s by pw %s\n", time.Now().Format("2006-01-02 15:04:05"), headerSafe(command)))
+	sb.WriteString(fmt.Sprintf("  Host:      %s (user %s)\n", headerSafe(host), headerSafe(os.Getenv("USERNAME"))))
+	sb.WriteString(fmt.Sprintf("  Items:     %d paths, %d actions, %s\n", len(d.Items), len(actions), FormatSize(total)))
+	sb.WriteString("\n")
+	sb.WriteString("  Review every line before running. Preview with -WhatIf.\n")
+	sb.WriteString("#>\n")
+	sb.WriteString("[CmdletBinding(SupportsShouldProcess = $true)]\n")
+	sb.WriteString("param()\n\n")
+	sb.WriteString("$ErrorActionPreference = 'Continue'\n")
+	sb.WriteString("$script:freed = [long]0\n")
+	sb.WriteString("$script:failed = 0\n\n")
+	sb.WriteString("function Remove-PureWinItem {\n")
+	sb.WriteString("    [CmdletBinding(SupportsShouldProcess = $true)]\n")
+	sb.WriteString("    param([string]$Path, [long]$Size)\n")
+	sb.WriteString("    $item = Get-Item -LiteralPath $Path -Force -ErrorAction SilentlyContinue\n")
+	sb.WriteString("    if (-not $item) { return }\n")
+	sb.WriteString("    if ($item.Attributes -band [IO.FileAttributes]::ReparsePoint) {\n")
+	sb.WriteString("        Write-Warning \"Skipped ${Path}: it is a link\"\n")
+	sb.WriteString("        return\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    if ($item.PSIsContainer -and (Get-ChildItem -LiteralPath $Path -Recurse -Force -Attributes ReparsePoint -ErrorAction SilentlyContinue | Select-Object -First 1)) {\n")
+	sb.WriteString("        Write-Warning \"Skipped ${Path}: it contains links\"\n")
+	sb.WriteString("        return\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("    if ($PSCmdlet.ShouldProcess($Path, 'Remove')) {\n")
+	sb.WriteString("        try {\n")
+	sb.WriteString("            Remove-Item -LiteralPath $Path -Recurse -Force -ErrorAction Stop\n")
+	sb.WriteString("            $script:freed += $Size\n")
+	sb.WriteString("        } catch {\n")
+	sb.WriteString("            $script:failed++\n")
+	sb.WriteString("            Write-Warning \"Failed to remove ${Path}: $($_.Exception.Message)\"\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n\n")
+
+	summary := d.categorySummary()
+	cats := make([]string, 0, len(summary))
+	for cat := range summary {
+		cats = append(cats, cat)
+	}
+	sort.Strings(cats)
+
+	grouped := make(map[string][]DryRunItem)
+	for _, item := range d.Items {
+		grouped[item.Category] = append(grouped[item.Category], item)
+	}
+
+	for _, cat := range cats {
+		entry := summary[cat]
+		sb.WriteString(fmt.Sprintf("# ── %s — %d items, %s\n",
+			commentSafe(strings.ToUpper(cat)), entry.count, FormatSize(entry.size)))
+		for _, item := range grouped[cat] {
+			if err := ValidatePath(item.Path); err != nil {
+				sb.WriteString(fmt.Sprintf("# SKIPPED (%s): %s\n", commentSafe(err.Error()), commentSafe(item.Path)))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("Remove-PureWinItem -Path %s -Size %d  # %s\n",
+				psQuote(item.Path), item.Size, FormatSize(item.Size)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(actions) > 0 {
+		sb.WriteString("# ── ACTIONS\n")
+		for _, a := range actions {
+			sb.WriteString(fmt.Sprintf("if ($PSCmdlet.ShouldProcess(%s, 'Run')) {\n", psQuote(a.Description)))
+			sb.WriteString(fmt.Sprintf("    %s\n", a.Command))
+			sb.WriteString(fmt.Sprintf("    $script:freed += %d\n", a.Size))
+			sb.WriteString("}\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("$freedMB = [math]::Round($script:freed / 1MB, 2)\n")
+	sb.WriteString("Write-Host \"Freed $freedMB MB; $script:failed item(s) failed.\"\n")
+
+	// UTF-8 BOM so Windows PowerShell 5.1 reads non-ASCII paths correctly.
+	data := append([]byte{0xEF, 0xBB, 0xBF}, []byte(sb.String())...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("cannot write script %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPsQuote(t *testing.T) {
+	cases := map[string]string{
+		`C:\Temp\a.tmp`:    `'C:\Temp\a.tmp'`,
+		`C:\it's\here`:     `'C:\it''s\here'`,
+		"C:\\curly\u2019s": "'C:\\curly\u2019\u2019s'",
+		`C:\$env:TEMP\x`:   `'C:\$env:TEMP\x'`,
+	}
+	for in, want := range cases {
+		if got := psQuote(in); got != want {
+			t.Errorf("psQuote(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExportScript_SkipsProtectedPaths(t *testing.T) {
+	drc := NewDryRunContext()
+	drc.Add(`C:\Windows\System32\kernel32.dll`, 100, "system")
+
+	out := filepath.Join(t.TempDir(), "cleanup.ps1")
+	if err := drc.ExportScript(out, "clean", nil); err != nil {
+		t.Fatalf("ExportScript() error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("cannot read script: %v", err)
+	}
+	script := string(data)
+	if strings.Contains(script, "Remove-PureWinItem -Path 'C:\\Windows") {
+		t.Error("protected path must not be emitted as a removal")
+	}
+	if !strings.Contains(script, "# SKIPPED") {
+		t.Error("protected path should be listed as skipped")
+	}
+}
+
+func TestExportScript_SkipsLinksBeforeRemoving(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "cleanup.ps1")
+	if err := NewDryRunContext().ExportScript(out, "clean", nil); err != nil {
+		t.Fatalf("ExportScript() error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("cannot read script: %v", err)
+	}
+	script := string(data)
+	guard := strings.Index(script, "-band [IO.FileAttributes]::ReparsePoint")
+	nested := strings.Index(script, "-Attributes ReparsePoint")
+	remove := strings.Index(script, "Remove-Item")
+	if guard < 0 || nested < 0 {
+		t.Fatal("script must check for links before removing")
+	}
+	if remove < guard || remove < nested {
+		t.Error("link checks must come before Remove-Item")
+	}
+}
+
+func TestExportScript_EscapesNewlinesInSkippedPaths(t *testing.T) {
+	drc := NewDryRunContext()
+	drc.Add("C:\\Temp\\a\r\nRemove-Item C:\\Users -Recurse\n.tmp", 100, "temp")
+
+	out := filepath.Join(t.TempDir(), "cleanup.ps1")
+	if err := drc.ExportScript(out, "clean", nil); err != nil {
+		t.Fatalf("ExportScript() error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("cannot read script: %v", err)
+	}
+	lines := strings.FieldsFunc(string(data), func(r rune) bool { return r == '\r' || r == '\n' })
+	for _, line := range lines {
+		if strings.Contains(line, "Remove-Item C:") && !strings.HasPrefix(line, "# SKIPPED") {
+			t.Errorf("file name escaped its comment: %q", line)
+		}
+	}
+	if !strings.Contains(string(data), `a\r\nRemove-Item C:\Users -Recurse\n.tmp`) {
+		t.Error("skipped path should be listed with its control characters escaped")
+	}
+}
+
+func TestExportScript_EscapesCommandInHeader(t *testing.T) {
+	drc := NewDryRunContext()
+	drc.Add(`C:\Temp\a.tmp`, 100, "temp")
+
+	out := filepath.Join(t.TempDir(), "cleanup.ps1")
+	command := "clean C:\\x#>\nRemove-Item C:\\Users -Recurse\n<#"
+	if err := drc.ExportScript(out, command, nil); err != nil {
+		t.Fatalf("ExportScript() error: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("cannot read script: %v", err)
+	}
+	script := string(data)
+	header := script[:strings.Index(script, "#>\n")]
+	if !strings.Contains(header, `clean C:\x# >\nRemove-Item C:\Users -Recurse\n<#`) {
+		t.Errorf("command should stay inside the header, escaped; header = %q", header)
+	}
+	if strings.Contains(script, "\nRemove-Item C:\\Users") {
+		t.Error("command escaped the header comment")
+	}
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// ─── Service Control ─────────────────────────────────────────────────────────
+// Services are started and stopped through the service control manager
+// rather than net.exe or sc.exe: states are structured instead of parsed
+// from localized output, waits are bounded, and dependent services are
+// stopped first and brought back afterwards.
+
+// DefaultServiceTimeout bounds how long a service may take to start or stop.
+const DefaultServiceTimeout = 30 * time.Second
+
+// servicePollInterval is how often a pending service's state is checked.
+const servicePollInterval = 250 * time.Millisecond
+
+// serviceStateNames are the names ServiceState reports.
+var serviceStateNames = map[svc.State]string{
+	svc.Stopped:         "STOPPED",
+	svc.StartPending:    "START_PENDING",
+	svc.StopPending:     "STOP_PENDING",
+	svc.Running:         "RUNNING",
+	svc.ContinuePending: "CONTINUE_PENDING",
+	svc.PausePending:    "PAUSE_PENDING",
+	svc.Paused:          "PAUSED",
+}
+
+// OpenService opens a service with the requested access rights. The SCM
+// itself is opened with connect-only rights so read-only queries work
+// without elevation. The caller must Close the returned service.
+func OpenService(name string, access uint32) (*mgr.Service, error) {
+	scm, err := windows.OpenSCManager(nil, nil, windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to service manager: %w", err)
+	}
+	defer windows.CloseServiceHandle(scm)
+
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	h, err := windows.OpenService(scm, namePtr, access)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open service %s: %w", name, err)
+	}
+	return &mgr.Service{Name: name, Handle: h}, nil
+}
+
+// ServiceState returns the service's current state, e.g. "RUNNING".
+func ServiceState(name string) (string, error) {
+	s, err := OpenService(name, windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		return "", err
+	}
+	defer s.Close()
+
+	status, err := s.Query()
+	if err != nil {
+		return "", fmt.Errorf("cannot query %s: %w", name, err)
+	}
+	if state, ok := serviceStateNames[status.State]; ok {
+		return state, nil
+	}
+	return "UNKNOWN", nil
+}
+
+// StopService stops a service and every running service that depends on
+// it, dependents first, waiting up to timeout for each. It returns the
+// dependents it stopped, in the order they should be started again. A
+// service that is already stopped is not an error.
+func StopService(name string, timeout time.Duration) ([]string, error) {
+	s, err := OpenService(name, windows.SERVICE_QUERY_STATUS|windows.SERVICE_STOP|windows.SERVICE_ENUMERATE_DEPENDENTS)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	// Dependents come back in reverse start order, which is stop order.
+	dependents, err := s.ListDependentServices(svc.Active)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list services depending on %s: %w", name, err)
+	}
+	var stopped []string
+	for _, dep := range dependents {
+		if err := stopOne(dep, timeout); err != nil {
+			return reversed(stopped), fmt.Errorf("cannot stop dependent service %s: %w", dep, err)
+		}
+		stopped = append(stopped, dep)
+	}
+
+	if err := stopOpened(s, timeout); err != nil {
+		return reversed(stopped), err
+	}
+	return reversed(stopped), nil
+}
+
+// StartService starts a service and waits up to timeout for it to run. A
+// service that is already running is not an error.
+func StartService(name string, timeout time.Duration) error {
+	s, err := OpenService(name, windows.SERVICE_QUERY_STATUS|windows.SERVICE_START)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if err := s.Start(); err != nil && !errors.Is(err, windows.ERROR_SERVICE_ALREADY_RUNNING) {
+		return fmt.Errorf("cannot start %s: %w", name, err)
+	}
+	return waitForState(s, svc.Running, timeout)
+}
+
+// RestartService stops a service with its dependents and starts them all
+// again. Services that restart on their own (DNS Client, DHCP Client) are
+// fine: starting a running service succeeds.
+func RestartService(name string, timeout time.Duration) error {
+	dependents, err := StopService(name, timeout)
+	if err != nil {
+		return err
+	}
+	if err := StartService(name, timeout); err != nil {
+		return err
+	}
+	for _, dep := range dependents {
+		if err := StartService(dep, timeout); err != nil {
+			return fmt.Errorf("restarted %s but not its dependent %s: %w", name, dep, err)
+		}
+	}
+	return nil
+}
+
+// DeleteService stops a service if it is running and marks it for
+// deletion. Windows removes it once the last handle to it closes.
+func DeleteService(name string, timeout time.Duration) error {
+	s, err := OpenService(name, windows.SERVICE_QUERY_STATUS|windows.SERVICE_STOP|windows.DELETE)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	// A service that will not stop can still be deleted.
+	_ = stopOpened(s, timeout)
+	if err := s.Delete(); err != nil && !errors.Is(err, windows.ERROR_SERVICE_MARKED_FOR_DELETE) {
+		return fmt.Errorf("cannot delete service %s: %w", name, err)
+	}
+	return nil
+}
+
+// stopOne opens and stops a single service.
+func stopOne(name string, timeout time.Duration) error {
+	s, err := OpenService(name, windows.SERVICE_QUERY_STATUS|windows.SERVICE_STOP)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+	return stopOpened(s, timeout)
+}
+
+// stopOpened sends a stop control unless the service is already stopped
+// and waits for it to stop.
+func stopOpened(s *mgr.Service, timeout time.Duration) error {
+	status, err := s.Query()
+	if err != nil {
+		return fmt.Errorf("cannot query %s: %w", s.Name, err)
+	}
+	if status.State == svc.Stopped {
+		return nil
+	}
+	if status.State != svc.StopPending {
+		if _, err := s.Control(svc.Stop); err != nil && !errors.Is(err, windows.ERROR_SERVICE_NOT_ACTIVE) {
+			return fmt.Errorf("cannot stop %s: %w", s.Name, err)
+		}
+	}
+	return waitForState(s, svc.Stopped, timeout)
+}
+
+// waitForState polls until the service reaches target or timeout passes.
+func waitForState(s *mgr.Service, target svc.State, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		status, err := s.Query()
+		if err != nil {
+			return fmt.Errorf("cannot query %s: %w", s.Name, err)
+		}
+		if status.State == target {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s to reach %s",
+				timeout, s.Name, serviceStateNames[target])
+		}
+		time.Sleep(servicePollInterval)
+	}
+}
+
+// reversed returns s in reverse order.
+func reversed(s []string) []string {
+	out := make([]string, len(s))
+	for i, v := range s {
+		out[len(s)-1-i] = v
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// ─── Simulation Report ───────────────────────────────────────────────────────
+// A SimulationReport aggregates what several destructive modules (clean,
+// purge, installers, optimize, ...) would do, or did, in one run, with
+// per-module totals. It is what `pw maintain --dry-run --json` prints.
+
+// SimulationModule is one module's share of a SimulationReport.
+type SimulationModule struct {
+	// Module names the command the entries come from, e.g. "clean".
+	Module string `json:"module"`
+
+	// Items are the files and directories the module deletes.
+	Items []DryRunItem `json:"items,omitempty"`
+
+	// Changes are non-file actions, e.g. "Restart Windows Search".
+	Changes []string `json:"changes,omitempty"`
+
+	// Size is the bytes freed (or that would be freed).
+	Size int64 `json:"size"`
+
+	// Count is the number of items plus changes.
+	Count int `json:"count"`
+
+	// Failed counts entries that could not be applied (real runs only).
+	Failed int `json:"failed,omitempty"`
+
+	// Skipped explains why the module did not run, e.g. missing admin rights.
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// SimulationReport is the aggregated result across modules.
+type SimulationReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	DryRun      bool               `json:"dry_run"`
+	Modules     []SimulationModule `json:"modules"`
+	TotalSize   int64              `json:"total_size"`
+	TotalCount  int                `json:"total_count"`
+	TotalFailed int                `json:"total_failed,omitempty"`
+}
+
+// NewSimulationReport creates an empty report.
+func NewSimulationReport(dryRun bool) *SimulationReport {
+	return &SimulationReport{
+		GeneratedAt: time.Now(),
+		DryRun:      dryRun,
+		Modules:     make([]SimulationModule, 0),
+	}
+}
+
+// Add appends a module, filling in its Size and Count from Items and
+// Changes when they are unset, and updates the report totals.
+func (r *SimulationReport) Add(m SimulationModule) {
+	if m.Size == 0 {
+		for _, item := range m.Items {
+			m.Size += item.Size
+		}
+	}
+	if m.Count == 0 {
+		m.Count = len(m.Items) + len(m.Changes)
+	}
+	r.Modules = append(r.Modules, m)
+	r.TotalSize += m.Size
+	r.TotalCount += m.Count
+	r.TotalFailed += m.Failed
+}
+
+// Module returns the named module, or nil when it is not in the report.
+func (r *SimulationReport) Module(name string) *SimulationModule {
+	for i := range r.Modules {
+		if r.Modules[i].Module == name {
+			return &r.Modules[i]
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *SimulationReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Module converts the recorded items into a report module.
+func (d *DryRunContext) Module(name string) SimulationModule {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	items := make([]DryRunItem, len(d.Items))
+	copy(items, d.Items)
+	return SimulationModule{Module: name, Items: items}
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSimulationReport_Totals(t *testing.T) {
+	drc := NewDryRunContext()
+	drc.Add(`C:\Temp\a.tmp`, 100, "user")
+	drc.Add(`C:\Temp\b.tmp`, 50, "user")
+
+	r := NewSimulationReport(true)
+	r.Add(drc.Module("clean"))
+	r.Add(SimulationModule{Module: "optimize", Changes: []string{"Flush DNS cache"}})
+	r.Add(SimulationModule{Module: "purge", Skipped: "no project directories"})
+
+	if r.TotalSize != 150 || r.TotalCount != 3 {
+		t.Errorf("totals = %d bytes / %d entries, want 150 / 3", r.TotalSize, r.TotalCount)
+	}
+	if m := r.Module("clean"); m == nil || m.Size != 150 || m.Count != 2 {
+		t.Errorf("clean module = %+v", m)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded SimulationReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("report JSON does not round-trip: %v", err)
+	}
+	if len(decoded.Modules) != 3 || decoded.Modules[0].Items[0].Path != `C:\Temp\a.tmp` {
+		t.Errorf("decoded modules = %+v", decoded.Modules)
+	}
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── I/O Niceness ────────────────────────────────────────────────────────────
+// Large deletions can saturate the disk and make the machine unusable.
+// Niceness lowers the process to background I/O priority and/or caps the
+// number of filesystem delete operations per second. It applies to every
+// SafeDelete call made after ApplyNiceness.
+
+// NiceBackground is the --nice value selecting background I/O priority.
+const NiceBackground = "background"
+
+// Niceness configures deletion throttling.
+type Niceness struct {
+	// Background lowers the process to background mode (low I/O and
+	// memory priority) via PROCESS_MODE_BACKGROUND_BEGIN.
+	Background bool
+
+	// OpsPerSec caps individual file and directory removals per second.
+	// Zero means unlimited.
+	OpsPerSec int
+}
+
+// Enabled reports whether any throttling is requested.
+func (n Niceness) Enabled() bool {
+	return n.Background || n.OpsPerSec > 0
+}
+
+// String describes the niceness for status output.
+func (n Niceness) String() string {
+	var parts []string
+	if n.Background {
+		parts = append(parts, "background I/O")
+	}
+	if n.OpsPerSec > 0 {
+		parts = append(parts, fmt.Sprintf("%d ops/sec", n.OpsPerSec))
+	}
+	if len(parts) == 0 {
+		return "off"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseNiceness parses a --nice value. Accepted forms are "background",
+// a number of operations per second ("200"), or both separated by a comma
+// ("background,200"). An empty string disables throttling.
+func ParseNiceness(s string) (Niceness, error) {
+	var n Niceness
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		switch {
+		case part == "":
+			continue
+		case part == NiceBackground || part == "bg":
+			n.Background = true
+		default:
+			ops, err := strconv.Atoi(strings.TrimSuffix(part, "/s"))
+			if err != nil || ops <= 0 {
+				return Niceness{}, fmt.Errorf("invalid niceness %q (use %q or operations per second)", part, NiceBackground)
+			}
+			n.OpsPerSec = ops
+		}
+	}
+	return n, nil
+}
+
+// deleteLimiter spaces out delete operations when an ops/sec cap is set.
+type deleteLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next operation is allowed.
+func (l *deleteLimiter) wait() {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+var (
+	throttleMu sync.RWMutex
+	limiter    *deleteLimiter
+)
+
+// currentLimiter returns the active delete limiter, or nil.
+func currentLimiter() *deleteLimiter {
+	throttleMu.RLock()
+	defer throttleMu.RUnlock()
+	return limiter
+}
+
+// ApplyNiceness enables the requested throttling for the rest of the
+// process. The returned function restores normal priority; it is safe to
+// call even when nothing was applied.
+func ApplyNiceness(n Niceness) (restore func(), err error) {
+	throttleMu.Lock()
+	if n.OpsPerSec > 0 {
+		limiter = &deleteLimiter{interval: time.Second / time.Duration(n.OpsPerSec)}
+	} else {
+		limiter = nil
+	}
+	throttleMu.Unlock()
+
+	background := false
+	if n.Background {
+		if bgErr := windows.SetPriorityClass(windows.CurrentProcess(), windows.PROCESS_MODE_BACKGROUND_BEGIN); bgErr != nil {
+			err = fmt.Errorf("cannot enter background I/O mode: %w", bgErr)
+		} else {
+			background = true
+		}
+	}
+
+	restore = func() {
+		throttleMu.Lock()
+		limiter = nil
+		throttleMu.Unlock()
+		if background {
+			_ = windows.SetPriorityClass(windows.CurrentProcess(), windows.PROCESS_MODE_BACKGROUND_END)
+		}
+	}
+	return restore, err
+}
+
+// removeAllThrottled removes a directory tree one entry at a time, waiting
+// on the limiter before each removal. Children are removed before their
+// parent directory.
+func removeAllThrottled(path string, l *deleteLimiter) error {
+	var dirs []string
+	var firstErr error
+
+	walkErr := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Never descend through junctions or directory symlinks; remove
+		// the link itself so the target is left untouched.
+		if d.IsDir() && !isReparsePoint(d) {
+			dirs = append(dirs, p)
+			return nil
+		}
+		l.wait()
+		if rmErr := os.Remove(p); rmErr != nil && !os.IsNotExist(rmErr) && firstErr == nil {
+			firstErr = rmErr
+		}
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if walkErr != nil && firstErr == nil {
+		firstErr = walkErr
+	}
+
+	// Deepest directories last in walk order, so remove in reverse.
+	for i := len(dirs) - 1; i >= 0; i-- {
+		l.wait()
+		if rmErr := os.Remove(dirs[i]); rmErr != nil && !os.IsNotExist(rmErr) && firstErr == nil {
+			firstErr = rmErr
+		}
+	}
+	return firstErr
+}
+
+// isReparsePoint reports whether a directory entry is a junction, symlink
+// or other reparse point.
+func isReparsePoint(d fs.DirEntry) bool {
+	if d.Type()&(fs.ModeSymlink|fs.ModeIrregular) != 0 {
+		return true
+	}
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+	if attrs, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		return attrs.FileAttributes&windows.FILE_ATTRIBUTE_REPARSE_POINT != 0
+	}
+	return false
+}
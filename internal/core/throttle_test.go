@@ -0,0 +1,32 @@
+package core
+
+import "testing"
+
+func TestParseNiceness(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Niceness
+	}{
+		{"", Niceness{}},
+		{"background", Niceness{Background: true}},
+		{"BG", Niceness{Background: true}},
+		{"200", Niceness{OpsPerSec: 200}},
+		{"background, 50/s", Niceness{Background: true, OpsPerSec: 50}},
+	}
+	for _, c := range cases {
+		got, err := ParseNiceness(c.in)
+		if err != nil {
+			t.Errorf("ParseNiceness(%q) error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseNiceness(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+
+	for _, bad := range []string{"fast", "0", "-5"} {
+		if _, err := ParseNiceness(bad); err == nil {
+			t.Errorf("ParseNiceness(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package dupes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// Action is what to do with a redundant copy.
+type Action int
+
+const (
+	// ActionDelete removes the copy.
+	ActionDelete Action = iota
+	// ActionLink replaces the copy with a hard link to the keeper, so both
+	// names remain but the data is stored once.
+	ActionLink
+)
+
+// String returns the log operation name for the action.
+func (a Action) String() string {
+	if a == ActionLink {
+		return "DEDUPE_LINK"
+	}
+	return "DEDUPE_DELETE"
+}
+
+// Resolve removes or hard-links dup in favour of keep and returns the bytes
+// freed (or that would be freed in dryRun mode). Before touching dup it
+// checks that keep still exists and is not already the same file, and
+// re-hashes both so a copy rewritten since the scan (even at the same size)
+// is left alone. The last copy of the data is never removed.
+func Resolve(keep, dup string, size int64, hash string, action Action, dryRun bool) (int64, error) {
+	keepInfo, err := os.Stat(keep)
+	if err != nil {
+		return 0, fmt.Errorf("keeper %s is gone: %w", keep, err)
+	}
+	dupInfo, err := os.Lstat(dup)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if keepInfo.Size() != size || dupInfo.Size() != size {
+		return 0, fmt.Errorf("%s changed since the scan", dup)
+	}
+	if os.SameFile(keepInfo, dupInfo) {
+		return 0, nil
+	}
+	for _, path := range []string{keep, dup} {
+		if sum, err := hashFile(path, -1); err != nil || sum != hash {
+			return 0, fmt.Errorf("%s changed since the scan", path)
+		}
+	}
+	if err := core.ValidatePath(dup); err != nil {
+		return 0, fmt.Errorf("safety check failed for %s: %w", dup, err)
+	}
+
+	if action == ActionDelete {
+		return core.SafeDelete(dup, dryRun)
+	}
+	if dryRun {
+		return size, nil
+	}
+	if err := replaceWithLink(keep, dup); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// replaceWithLink creates a hard link to keep next to dup and then renames
+// it over dup, so dup is never missing if the link cannot be made (for
+// example when the two are on different volumes).
+func replaceWithLink(keep, dup string) error {
+	tmp := filepath.Join(filepath.Dir(dup), "."+filepath.Base(dup)+".pwlink")
+	_ = os.Remove(tmp)
+	if err := os.Link(keep, tmp); err != nil {
+		return fmt.Errorf("cannot hard-link %s: %w", dup, err)
+	}
+	if err := os.Rename(tmp, dup); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("cannot replace %s: %w", dup, err)
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+package dupes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// partialSize is how much of each file is hashed in the first pass. Files
+// that differ usually differ in their first block, so only the survivors
+// of this pass are read in full.
+const partialSize = 64 * 1024
+
+// File is one copy within a duplicate group.
+type File struct {
+	Path    string    `json:"path"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Group is a set of files with identical content.
+type Group struct {
+	Size  int64  `json:"size"`  // Size of each copy in bytes
+	Hash  string `json:"hash"`  // Hex SHA-256 of the content
+	Files []File `json:"files"` // Oldest first; Files[0] is the suggested keeper
+}
+
+// Wasted returns the bytes taken up by every copy except the keeper.
+func (g Group) Wasted() int64 {
+	if len(g.Files) < 2 {
+		return 0
+	}
+	return g.Size * int64(len(g.Files)-1)
+}
+
+// Options controls a duplicate search.
+type Options struct {
+	// MinSize skips files smaller than this many bytes. Empty files are
+	// always skipped.
+	MinSize int64
+
+	// Workers bounds how many files are hashed at once. Zero picks a
+	// default based on the CPU count.
+	Workers int
+
+	// Progress, if non-nil, is updated as the search runs.
+	Progress *Progress
+}
+
+// Progress counts work done by a running search. It is safe to read from
+// another goroutine, e.g. to update a spinner. A nil *Progress is valid and
+// records nothing.
+type Progress struct {
+	files  atomic.Int64
+	hashed atomic.Int64
+}
+
+// Files returns the number of candidate files seen so far.
+func (p *Progress) Files() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.files.Load()
+}
+
+// Hashed returns the number of hash passes completed so far.
+func (p *Progress) Hashed() int64 {
+	if p == nil {
+		return 0
+	}
+	return p.hashed.Load()
+}
+
+func (p *Progress) addFile() {
+	if p != nil {
+		p.files.Add(1)
+	}
+}
+
+func (p *Progress) addHashed() {
+	if p != nil {
+		p.hashed.Add(1)
+	}
+}
+
+// candidate is a file under consideration along with its stat info, which
+// is kept so hard links to the same data can be recognised.
+type candidate struct {
+	File
+	size int64
+	info fs.FileInfo
+}
+
+// Find walks roots and returns groups of files with identical content,
+// largest waste first. Files are bucketed by size, then compared by a hash
+// of their first 64 KB, and only then hashed in full. Hard links to the
+// same data count as one copy. Unreadable files and directories are
+// skipped; an error is returned only when a root itself cannot be read.
+func Find(roots []string, opts Options) ([]Group, error) {
+	minSize := max(1, opts.MinSize)
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = max(4, runtime.NumCPU())
+	}
+
+	bySize := make(map[int64][]candidate)
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		if _, err := os.Stat(root); err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", root, err)
+		}
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			key := strings.ToLower(path)
+			if seen[key] {
+				return nil
+			}
+			seen[key] = true
+
+			info, err := d.Info()
+			if err != nil || info.Size() < minSize {
+				return nil
+			}
+			opts.Progress.addFile()
+			bySize[info.Size()] = append(bySize[info.Size()], candidate{
+				File: File{Path: path, ModTime: info.ModTime()},
+				size: info.Size(),
+				info: info,
+			})
+			return nil
+		})
+	}
+
+	var groups []Group
+	for size, files := range bySize {
+		files = dropHardLinks(files)
+		if len(files) < 2 {
+			continue
+		}
+
+		// First pass: hash the leading block. For small files this is the
+		// whole content, so the second pass is skipped.
+		for _, partial := range splitByHash(files, partialSize, workers, opts.Progress) {
+			if size <= partialSize {
+				groups = append(groups, newGroup(size, partial.hash, partial.files))
+				continue
+			}
+			for _, full := range splitByHash(partial.files, -1, workers, opts.Progress) {
+				groups = append(groups, newGroup(size, full.hash, full.files))
+			}
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if wi, wj := groups[i].Wasted(), groups[j].Wasted(); wi != wj {
+			return wi > wj
+		}
+		return groups[i].Files[0].Path < groups[j].Files[0].Path
+	})
+	return groups, nil
+}
+
+// dropHardLinks keeps one entry per underlying file, so names that already
+// share their data are not reported as duplicates of each other.
+func dropHardLinks(files []candidate) []candidate {
+	kept := files[:0]
+	for _, f := range files {
+		linked := false
+		for _, k := range kept {
+			if os.SameFile(f.info, k.info) {
+				linked = true
+				break
+			}
+		}
+		if !linked {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// hashSet is a run of files that share a hash.
+type hashSet struct {
+	hash  string
+	files []candidate
+}
+
+// splitByHash hashes files in parallel, reading at most limit bytes of
+// each (all of it when limit is negative), and returns the sets of two or
+// more files that hashed alike. Files that cannot be read are dropped.
+func splitByHash(files []candidate, limit int64, workers int, progress *Progress) []hashSet {
+	hashes := make([]string, len(files))
+	sem := make(chan struct{}, max(1, workers))
+	var wg sync.WaitGroup
+	for i := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			hashes[i], _ = hashFile(files[i].Path, limit)
+			<-sem
+			progress.addHashed()
+		}()
+	}
+	wg.Wait()
+
+	byHash := make(map[string][]candidate)
+	var order []string
+	for i, h := range hashes {
+		if h == "" {
+			continue
+		}
+		if _, ok := byHash[h]; !ok {
+			order = append(order, h)
+		}
+		byHash[h] = append(byHash[h], files[i])
+	}
+
+	var sets []hashSet
+	for _, h := range order {
+		if len(byHash[h]) > 1 {
+			sets = append(sets, hashSet{hash: h, files: byHash[h]})
+		}
+	}
+	return sets
+}
+
+// hashFile returns the hex SHA-256 of the first limit bytes of path, or of
+// the whole file when limit is negative.
+func hashFile(path string, limit int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if limit >= 0 {
+		r = io.LimitReader(f, limit)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// newGroup builds a Group with the oldest copy first, as that is usually
+// the original.
+func newGroup(size int64, hash string, files []candidate) Group {
+	g := Group{Size: size, Hash: hash, Files: make([]File, 0, len(files))}
+	for _, f := range files {
+		g.Files = append(g.Files, f.File)
+	}
+	sort.Slice(g.Files, func(i, j int) bool {
+		a, b := g.Files[i], g.Files[j]
+		if !a.ModTime.Equal(b.ModTime) {
+			return a.ModTime.Before(b.ModTime)
+		}
+		return a.Path < b.Path
+	})
+	return g
+}
+
+// Summary returns the number of redundant copies across groups and the
+// bytes they take up.
+func Summary(groups []Group) (copies int, wasted int64) {
+	for _, g := range groups {
+		copies += len(g.Files) - 1
+		wasted += g.Wasted()
+	}
+	return copies, wasted
+}
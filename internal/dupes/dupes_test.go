@@ -0,0 +1,125 @@
+package dupes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind_GroupsIdenticalContent(t *testing.T) {
+	dir := t.TempDir()
+	big := bytes.Repeat([]byte("a"), partialSize*2)
+	// Same size and same first block as big, but differs at the end.
+	bigTail := append(bytes.Repeat([]byte("a"), partialSize*2-1), 'b')
+
+	writeFile(t, filepath.Join(dir, "one.txt"), []byte("hello"))
+	writeFile(t, filepath.Join(dir, "sub", "two.txt"), []byte("hello"))
+	writeFile(t, filepath.Join(dir, "other.txt"), []byte("world"))
+	writeFile(t, filepath.Join(dir, "big1.bin"), big)
+	writeFile(t, filepath.Join(dir, "sub", "big2.bin"), big)
+	writeFile(t, filepath.Join(dir, "big3.bin"), bigTail)
+	writeFile(t, filepath.Join(dir, "empty1"), nil)
+	writeFile(t, filepath.Join(dir, "empty2"), nil)
+
+	groups, err := Find([]string{dir}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2: %+v", len(groups), groups)
+	}
+	if groups[0].Size != int64(len(big)) || len(groups[0].Files) != 2 {
+		t.Errorf("largest group = %+v, want the two big files", groups[0])
+	}
+	if groups[1].Size != 5 || len(groups[1].Files) != 2 {
+		t.Errorf("second group = %+v, want the two hello files", groups[1])
+	}
+
+	copies, wasted := Summary(groups)
+	if copies != 2 || wasted != int64(len(big))+5 {
+		t.Errorf("Summary = %d, %d", copies, wasted)
+	}
+}
+
+func TestFind_MinSize(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a"), []byte("tiny"))
+	writeFile(t, filepath.Join(dir, "b"), []byte("tiny"))
+
+	groups, err := Find([]string{dir}, Options{MinSize: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("got %d groups, want none below MinSize", len(groups))
+	}
+}
+
+func TestFind_IgnoresHardLinks(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a")
+	writeFile(t, a, []byte("shared data"))
+	if err := os.Link(a, filepath.Join(dir, "b")); err != nil {
+		t.Skipf("hard links not supported: %v", err)
+	}
+
+	groups, err := Find([]string{dir}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("hard links reported as duplicates: %+v", groups)
+	}
+}
+
+func TestFind_OverlappingRoots(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "sub", "only.txt"), []byte("single copy"))
+
+	groups, err := Find([]string{dir, filepath.Join(dir, "sub")}, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("a file seen twice was reported as a duplicate: %+v", groups)
+	}
+}
+
+func TestFind_MissingRoot(t *testing.T) {
+	if _, err := Find([]string{filepath.Join(t.TempDir(), "nope")}, Options{}); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+}
+
+func TestResolve_RewrittenSinceScan(t *testing.T) {
+	dir := t.TempDir()
+	keep := filepath.Join(dir, "keep.txt")
+	dup := filepath.Join(dir, "dup.txt")
+	writeFile(t, keep, []byte("hello"))
+	writeFile(t, dup, []byte("hello"))
+
+	groups, err := Find([]string{dir}, Options{})
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("Find() = %+v, %v; want one group", groups, err)
+	}
+	// Same size, different content: the keeper is no longer a copy of dup.
+	writeFile(t, keep, []byte("HELLO"))
+
+	if _, err := Resolve(keep, dup, groups[0].Size, groups[0].Hash, ActionDelete, false); err == nil {
+		t.Error("Resolve should refuse a keeper rewritten since the scan")
+	}
+	if _, err := os.Stat(dup); err != nil {
+		t.Errorf("dup must survive: %v", err)
+	}
+}
@@ -13,12 +13,12 @@ import (
 
 // InstallerFile represents a detected installer or archive file.
 type InstallerFile struct {
-	Path      string    // Full path to the file
-	Name      string    // File name only
-	Size      int64     // Size in bytes
-	Extension string    // File extension (.exe, .msi, etc.)
-	Source    string    // Source location (Downloads, Desktop, etc.)
-	ModTime   time.Time // Last modification time
+	Path      string    `json:"path"`      // Full path to the file
+	Name      string    `json:"name"`      // File name only
+	Size      int64     `json:"size"`      // Size in bytes
+	Extension string    `json:"extension"` // File extension (.exe, .msi, etc.)
+	Source    string    `json:"source"`    // Source location (Downloads, Desktop, etc.)
+	ModTime   time.Time `json:"mod_time"`  // Last modification time
 }
 
 // scanLocation represents a directory to scan for installer files.
@@ -0,0 +1,231 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+// ─── Post-Update Migrations ──────────────────────────────────────────────────
+// Versioned, run-once upgrades for data written by older PureWin versions:
+// config files, whitelist format, cache layouts and scheduled task
+// definitions. The applied schema version is tracked in StateFileName so
+// each step runs exactly once per installation, and the config directory's
+// files are backed up before the first pending step runs.
+
+// StateFileName records migration progress inside the config directory.
+const StateFileName = "migrations.json"
+
+const (
+	// backupDirName holds pre-migration copies inside the config directory.
+	backupDirName = "backups"
+
+	// maxBackupFileSize skips large files, which are caches rather than
+	// settings, when backing up.
+	maxBackupFileSize = 4 << 20
+)
+
+// Migration upgrades on-disk data from Version-1 to Version.
+type Migration struct {
+	// Version is the schema version this step produces. Versions start at 1
+	// and must be contiguous.
+	Version int
+
+	// Name is a short identifier shown in reports.
+	Name string
+
+	// Apply performs the upgrade and returns a one-line note for the user,
+	// or "" when there was nothing to change. It must be safe to re-run
+	// after a partial failure.
+	Apply func(cfg *config.Config) (string, error)
+}
+
+// AppliedMigration is one completed step in the state file.
+type AppliedMigration struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+	Note      string    `json:"note,omitempty"`
+}
+
+// State is the persisted migration progress.
+type State struct {
+	// SchemaVersion is the highest migration applied.
+	SchemaVersion int `json:"schema_version"`
+
+	// AppVersion is the PureWin version that last ran the migrations.
+	AppVersion string `json:"app_version"`
+
+	Applied []AppliedMigration `json:"applied,omitempty"`
+}
+
+// Report describes one Run.
+type Report struct {
+	// Applied lists the steps that ran this time.
+	Applied []AppliedMigration
+
+	// BackupDir is where pre-migration copies were written, if any.
+	BackupDir string
+
+	// FromVersion is the app version recorded before this run.
+	FromVersion string
+}
+
+// LatestVersion is the schema version produced by the newest migration.
+func LatestVersion() int {
+	return len(migrations)
+}
+
+// Run applies every pending migration for the given config. It is cheap
+// when nothing is pending: one small file read. A failing step stops the
+// run; earlier steps stay recorded so the next run resumes after them.
+func Run(cfg *config.Config, appVersion string) (*Report, error) {
+	return run(cfg, appVersion, migrations)
+}
+
+func run(cfg *config.Config, appVersion string, steps []Migration) (*Report, error) {
+	statePath := filepath.Join(cfg.ConfigDir, StateFileName)
+	state, err := LoadState(cfg.ConfigDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{FromVersion: state.AppVersion}
+	if state.SchemaVersion >= len(steps) {
+		if state.AppVersion != appVersion {
+			state.AppVersion = appVersion
+			err = saveState(statePath, state)
+		}
+		return report, err
+	}
+
+	report.BackupDir, err = backupConfigDir(cfg.ConfigDir, state.SchemaVersion)
+	if err != nil {
+		return report, fmt.Errorf("cannot back up config before migrating: %w", err)
+	}
+
+	for _, step := range steps[state.SchemaVersion:] {
+		note, applyErr := step.Apply(cfg)
+		if applyErr != nil {
+			if saveErr := saveState(statePath, state); saveErr != nil {
+				return report, saveErr
+			}
+			return report, fmt.Errorf("migration %d (%s) failed: %w", step.Version, step.Name, applyErr)
+		}
+		applied := AppliedMigration{
+			Version:   step.Version,
+			Name:      step.Name,
+			AppliedAt: time.Now(),
+			Note:      note,
+		}
+		state.Applied = append(state.Applied, applied)
+		state.SchemaVersion = step.Version
+		report.Applied = append(report.Applied, applied)
+	}
+
+	state.AppVersion = appVersion
+	return report, saveState(statePath, state)
+}
+
+// LoadState reads the migration state. A missing file is schema version 0.
+func LoadState(configDir string) (*State, error) {
+	path := filepath.Join(configDir, StateFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveState writes the state atomically.
+func saveState(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// backupConfigDir copies the small regular files at the top of the config
+// directory (config, whitelist, schedules; not caches or logs) into a
+// timestamped folder under backups. It returns "" when there was nothing
+// to copy, as on a fresh install.
+func backupConfigDir(configDir string, fromSchema int) (string, error) {
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var files []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || name == StateFileName || strings.HasSuffix(name, ".log") ||
+			strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+		if info, infoErr := e.Info(); infoErr != nil || info.Size() > maxBackupFileSize {
+			continue
+		}
+		files = append(files, name)
+	}
+	if len(files) == 0 {
+		return "", nil
+	}
+
+	dir := filepath.Join(configDir, backupDirName,
+		fmt.Sprintf("schema%d-%s", fromSchema, time.Now().Format("20060102-150405")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	for _, name := range files {
+		if err := copyFile(filepath.Join(configDir, name), filepath.Join(dir, name)); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
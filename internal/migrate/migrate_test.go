@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+func TestRun_AppliesPendingStepsOnce(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "whitelist.txt"), []byte("C:\\a\\b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{ConfigDir: dir}
+
+	calls := 0
+	steps := []Migration{
+		{Version: 1, Name: "one", Apply: func(*config.Config) (string, error) { calls++; return "did one", nil }},
+		{Version: 2, Name: "two", Apply: func(*config.Config) (string, error) { calls++; return "", nil }},
+	}
+
+	report, err := run(cfg, "2.1.0", steps)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(report.Applied) != 2 || calls != 2 {
+		t.Fatalf("expected 2 steps applied, got %d (calls %d)", len(report.Applied), calls)
+	}
+	if report.BackupDir == "" {
+		t.Error("expected a backup of the existing config files")
+	} else if _, err := os.Stat(filepath.Join(report.BackupDir, "whitelist.txt")); err != nil {
+		t.Errorf("whitelist.txt not backed up: %v", err)
+	}
+
+	report, err = run(cfg, "2.1.0", steps)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if len(report.Applied) != 0 || calls != 2 {
+		t.Errorf("steps re-ran: applied %d, calls %d", len(report.Applied), calls)
+	}
+}
+
+func TestRun_FailureResumesAtFailedStep(t *testing.T) {
+	cfg := &config.Config{ConfigDir: t.TempDir()}
+
+	fail := true
+	var ran []int
+	steps := []Migration{
+		{Version: 1, Name: "one", Apply: func(*config.Config) (string, error) { ran = append(ran, 1); return "", nil }},
+		{Version: 2, Name: "two", Apply: func(*config.Config) (string, error) {
+			ran = append(ran, 2)
+			if fail {
+				return "", errors.New("locked")
+			}
+			return "", nil
+		}},
+	}
+
+	if _, err := run(cfg, "2.1.0", steps); err == nil {
+		t.Fatal("expected the failing step to surface an error")
+	}
+	state, err := LoadState(cfg.ConfigDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.SchemaVersion != 1 {
+		t.Fatalf("SchemaVersion = %d, want 1 after partial run", state.SchemaVersion)
+	}
+
+	fail = false
+	if _, err := run(cfg, "2.1.0", steps); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if want := []int{1, 2, 2}; len(ran) != len(want) || ran[2] != 2 {
+		t.Errorf("ran = %v, want %v", ran, want)
+	}
+}
@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cy-infamous/purewin/internal/analyze"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// migrations is the ordered upgrade chain. Append new steps at the end with
+// the next Version; never reorder or remove shipped steps.
+var migrations = []Migration{
+	{Version: 1, Name: "config-schema", Apply: migrateConfigSchema},
+	{Version: 2, Name: "whitelist-format", Apply: migrateWhitelistFormat},
+	{Version: 3, Name: "analyze-cache-layout", Apply: migrateAnalyzeCache},
+	{Version: 4, Name: "scheduled-tasks", Apply: migrateScheduledTasks},
+}
+
+// migrateConfigSchema stamps config.json with the current schema version,
+// persisting the defaults Load fills in for fields older files lack.
+func migrateConfigSchema(cfg *config.Config) (string, error) {
+	if cfg.Version == config.DefaultVersion {
+		return "", nil
+	}
+	from := cfg.Version
+	cfg.Version = config.DefaultVersion
+	if err := cfg.Save(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("config.json upgraded from schema %s to %s", from, config.DefaultVersion), nil
+}
+
+// migrateWhitelistFormat normalizes separators and removes duplicate
+// patterns, keeping the user's comments.
+func migrateWhitelistFormat(cfg *config.Config) (string, error) {
+	changed, err := whitelist.NormalizeFile(filepath.Join(cfg.ConfigDir, "whitelist.txt"))
+	if err != nil || !changed {
+		return "", err
+	}
+	return "whitelist.txt normalized", nil
+}
+
+// migrateAnalyzeCache moves scan caches into their own directory.
+func migrateAnalyzeCache(cfg *config.Config) (string, error) {
+	moved, err := analyze.MigrateCacheLayout()
+	if err != nil || moved == 0 {
+		return "", err
+	}
+	return fmt.Sprintf("moved %d analyzer cache file(s)", moved), nil
+}
+
+// migrateScheduledTasks re-registers the background analyze task from its
+// saved schedule, so the task runs the current executable with the current
+// arguments.
+func migrateScheduledTasks(cfg *config.Config) (string, error) {
+	sched, err := analyze.LoadSchedule()
+	if err != nil {
+		return "", err
+	}
+	if len(sched.Roots) == 0 || !core.ScheduledTaskExists(analyze.ScheduleTaskName) {
+		return "", nil
+	}
+	if err := core.CreateScheduledTask(sched.Task()); err != nil {
+		return "", err
+	}
+	return "re-registered the scheduled analyze task", nil
+}
@@ -0,0 +1,179 @@
+package optimize
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ─── Boot Performance ────────────────────────────────────────────────────────
+// `pw optimize --boot-report` reads the Diagnostics-Performance event log.
+// Windows writes event 100 after each boot with its duration, and events
+// 101-110 for applications, drivers, services and devices that slowed a
+// boot down. Reading the log requires administrator rights.
+
+// bootEventLog is the channel Windows records boot performance in.
+const bootEventLog = "Microsoft-Windows-Diagnostics-Performance/Operational"
+
+// bootOffenderKinds names what slowed a boot down, by event ID.
+var bootOffenderKinds = map[int]string{
+	101: "application",
+	102: "driver",
+	103: "service",
+	104: "system",
+	105: "foreground",
+	106: "background",
+	107: "application",
+	108: "prefetch",
+	109: "device",
+	110: "session",
+}
+
+// BootRecord is one measured boot. Durations are in milliseconds.
+type BootRecord struct {
+	Time       time.Time `json:"time"`
+	BootMs     int64     `json:"boot_ms"`      // Power on to a usable desktop, post-boot included.
+	MainPathMs int64     `json:"main_path_ms"` // Power on to the desktop.
+	PostBootMs int64     `json:"post_boot_ms"` // Desktop to the system settling down.
+	Degraded   bool      `json:"degraded"`     // Windows flagged the boot as slower than usual.
+}
+
+// BootOffender is something that repeatedly delayed boots.
+type BootOffender struct {
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Occurrences   int    `json:"occurrences"`
+	TotalDelayMs  int64  `json:"total_delay_ms"`
+	LatestDelayMs int64  `json:"latest_delay_ms"`
+}
+
+// BootReport summarizes recent boots, newest first.
+type BootReport struct {
+	Boots     []BootRecord   `json:"boots"`
+	Offenders []BootOffender `json:"offenders"`
+}
+
+// AverageMs returns the mean boot duration of boots[from:to].
+func (r BootReport) AverageMs(from, to int) int64 {
+	to = min(to, len(r.Boots))
+	if from >= to {
+		return 0
+	}
+	var sum int64
+	for _, b := range r.Boots[from:to] {
+		sum += b.BootMs
+	}
+	return sum / int64(to-from)
+}
+
+// bootEvent is one event as emitted by bootEventQuery.
+type bootEvent struct {
+	ID   int               `json:"Id"`
+	Time time.Time         `json:"Time"`
+	Data map[string]string `json:"Data"`
+}
+
+// bootEventQuery lists boot performance events, newest first, with their
+// event data as a name-to-value map. %d is the number of events to read.
+const bootEventQuery = `$events = @(Get-WinEvent -FilterHashtable @{
+  LogName = '` + bootEventLog + `'; Id = 100..110
+} -MaxEvents %d -ErrorAction Stop | ForEach-Object {
+  $data = @{}
+  foreach ($d in ([xml]$_.ToXml()).Event.EventData.Data) { $data[[string]$d.Name] = [string]$d.'#text' }
+  [pscustomobject]@{ Id = $_.Id; Time = $_.TimeCreated.ToUniversalTime().ToString('o'); Data = $data }
+})
+ConvertTo-Json -InputObject $events -Depth 3 -Compress`
+
+// GetBootReport reads up to the last boots measured boots and the delays
+// recorded alongside them. Offenders are sorted by the total delay they
+// caused, largest first.
+func GetBootReport(boots int) (BootReport, error) {
+	if boots < 1 {
+		boots = 1
+	}
+	// Each boot logs its own event plus usually a handful of offenders.
+	output, err := runPowerShell(fmt.Sprintf(bootEventQuery, boots*20))
+	if err != nil {
+		if strings.Contains(err.Error(), "No events were found") {
+			return BootReport{}, nil
+		}
+		return BootReport{}, fmt.Errorf("cannot read %s: %w", bootEventLog, err)
+	}
+
+	var events []bootEvent
+	if err := json.Unmarshal(bytes.TrimSpace(output), &events); err != nil {
+		return BootReport{}, fmt.Errorf("cannot parse boot events: %w", err)
+	}
+	return buildBootReport(events, boots), nil
+}
+
+// buildBootReport keeps the newest boots and aggregates the offenders
+// logged since the oldest of them.
+func buildBootReport(events []bootEvent, boots int) BootReport {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.After(events[j].Time) })
+
+	var report BootReport
+	var oldest time.Time
+	for _, e := range events {
+		if e.ID != 100 || len(report.Boots) == boots {
+			continue
+		}
+		report.Boots = append(report.Boots, BootRecord{
+			Time:       e.Time,
+			BootMs:     eventInt(e, "BootTime"),
+			MainPathMs: eventInt(e, "MainPathBootTime"),
+			PostBootMs: eventInt(e, "BootPostBootTime"),
+			Degraded:   strings.EqualFold(e.Data["BootIsDegradation"], "true"),
+		})
+		oldest = e.Time
+	}
+
+	byName := make(map[string]*BootOffender)
+	for _, e := range events {
+		kind, ok := bootOffenderKinds[e.ID]
+		if !ok || e.Time.Before(oldest) {
+			continue
+		}
+		name := bootOffenderName(e)
+		key := kind + "\x00" + strings.ToLower(name)
+		o := byName[key]
+		if o == nil {
+			// Events are newest first, so the first one seen is the latest.
+			o = &BootOffender{Kind: kind, Name: name, LatestDelayMs: eventInt(e, "DegradationTime")}
+			byName[key] = o
+		}
+		o.Occurrences++
+		o.TotalDelayMs += eventInt(e, "DegradationTime")
+	}
+	for _, o := range byName {
+		report.Offenders = append(report.Offenders, *o)
+	}
+	sort.Slice(report.Offenders, func(i, j int) bool {
+		if report.Offenders[i].TotalDelayMs != report.Offenders[j].TotalDelayMs {
+			return report.Offenders[i].TotalDelayMs > report.Offenders[j].TotalDelayMs
+		}
+		return report.Offenders[i].Name < report.Offenders[j].Name
+	})
+	return report
+}
+
+// bootOffenderName prefers the friendly name of an offender and falls back
+// to its file or service name.
+func bootOffenderName(e bootEvent) string {
+	for _, field := range []string{"FriendlyName", "Name", "FileName"} {
+		if v := strings.TrimSpace(e.Data[field]); v != "" {
+			return v
+		}
+	}
+	return fmt.Sprintf("event %d", e.ID)
+}
+
+// eventInt parses a numeric event data field, 0 when missing.
+func eventInt(e bootEvent, field string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(e.Data[field]), 10, 64)
+	return n
+}
@@ -0,0 +1,175 @@
+package optimize
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Component Store (WinSxS) ────────────────────────────────────────────────
+// DISM reports how much of WinSxS is superseded updates and temporary data,
+// and removes it with /StartComponentCleanup. /ResetBase also removes the
+// superseded versions kept for uninstalling updates, which frees more space
+// but makes every installed update permanent.
+
+// dismTimeout bounds a component store cleanup. /ResetBase on a system that
+// has not been cleaned for a long time can take an hour.
+const dismTimeout = 2 * time.Hour
+
+// ComponentStore is the result of DISM /AnalyzeComponentStore.
+type ComponentStore struct {
+	ReportedSize        int64  `json:"reported_size"` // size Explorer shows for WinSxS
+	ActualSize          int64  `json:"actual_size"`   // size without hard-linked Windows files
+	SharedWithWindows   int64  `json:"shared_with_windows"`
+	BackupsAndDisabled  int64  `json:"backups_and_disabled"`
+	CacheAndTemp        int64  `json:"cache_and_temp"`
+	LastCleanup         string `json:"last_cleanup,omitempty"`
+	ReclaimablePackages int    `json:"reclaimable_packages"`
+	CleanupRecommended  bool   `json:"cleanup_recommended"`
+}
+
+// Reclaimable estimates what a cleanup frees: superseded packages and
+// temporary data. The real figure depends on which packages DISM can drop.
+func (c ComponentStore) Reclaimable() int64 {
+	return c.BackupsAndDisabled + c.CacheAndTemp
+}
+
+// AnalyzeComponentStore runs DISM /AnalyzeComponentStore. It takes a minute
+// or two.
+func AnalyzeComponentStore() (ComponentStore, error) {
+	if err := core.RequireAdmin("analyze the component store"); err != nil {
+		return ComponentStore{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "DISM.exe",
+		"/Online", "/Cleanup-Image", "/AnalyzeComponentStore", "/English")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return ComponentStore{}, fmt.Errorf("DISM analysis failed: %s: %w",
+			truncateOutput(output, 300), err)
+	}
+	return parseComponentStore(string(output)), nil
+}
+
+// parseComponentStore reads the "Name : Value" lines of an English
+// /AnalyzeComponentStore report.
+func parseComponentStore(report string) ComponentStore {
+	var c ComponentStore
+	for _, line := range strings.Split(report, "\n") {
+		name, value, ok := strings.Cut(line, " : ")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		switch name {
+		case "Windows Explorer Reported Size of Component Store":
+			c.ReportedSize = parseDISMSize(value)
+		case "Actual Size of Component Store":
+			c.ActualSize = parseDISMSize(value)
+		case "Shared with Windows":
+			c.SharedWithWindows = parseDISMSize(value)
+		case "Backups and Disabled Features":
+			c.BackupsAndDisabled = parseDISMSize(value)
+		case "Cache and Temporary Data":
+			c.CacheAndTemp = parseDISMSize(value)
+		case "Date of Last Cleanup":
+			c.LastCleanup = value
+		case "Number of Reclaimable Packages":
+			c.ReclaimablePackages, _ = strconv.Atoi(value)
+		case "Component Store Cleanup Recommended":
+			c.CleanupRecommended = strings.EqualFold(value, "Yes")
+		}
+	}
+	return c
+}
+
+// parseDISMSize converts sizes such as "8.51 GB" or "40.20 MB" (binary
+// units) to bytes. Unparsable values yield 0.
+func parseDISMSize(s string) int64 {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0
+	}
+	n, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	units := map[string]float64{
+		"BYTES": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40,
+	}
+	unit, ok := units[strings.ToUpper(fields[1])]
+	if !ok {
+		return 0
+	}
+	return int64(math.Round(n * unit))
+}
+
+// dismProgressPattern matches DISM's progress bar, e.g.
+// "[==========                 20.0%                          ]".
+var dismProgressPattern = regexp.MustCompile(`(\d{1,3})(?:\.\d+)?%`)
+
+// CleanupComponentStore runs DISM /StartComponentCleanup, with /ResetBase
+// when resetBase is set. progress, if non-nil, receives the percentage
+// DISM reports as it runs.
+func CleanupComponentStore(resetBase bool, progress func(percent int)) error {
+	if err := core.RequireAdmin("DISM cleanup"); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dismTimeout)
+	defer cancel()
+
+	args := []string{"/Online", "/Cleanup-Image", "/StartComponentCleanup", "/English"}
+	if resetBase {
+		args = append(args, "/ResetBase")
+	}
+	cmd := exec.CommandContext(ctx, "DISM.exe", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("cannot start DISM.exe: %w", err)
+	}
+
+	// DISM redraws its progress bar with carriage returns.
+	var tail []string
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(splitLinesOrCR)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if m := dismProgressPattern.FindStringSubmatch(line); m != nil && progress != nil {
+				if pct, convErr := strconv.Atoi(m[1]); convErr == nil {
+					progress(min(pct, 100))
+				}
+			}
+			continue
+		}
+		tail = append(tail, line)
+		if len(tail) > 5 {
+			tail = tail[1:]
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("DISM cleanup failed: %s: %w",
+			truncateOutput([]byte(strings.Join(tail, " ")), 300), err)
+	}
+	return nil
+}
@@ -0,0 +1,384 @@
+package optimize
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── DNS Resolvers ───────────────────────────────────────────────────────────
+// Resolvers are benchmarked with plain UDP A queries sent straight to each
+// server, so the Windows DNS cache does not hide their latency. Changing an
+// adapter's resolvers records its previous servers in a backup file first;
+// RevertAdapterDNS puts them back.
+
+// DNSBackupFileName is the file under the config dir holding the adapter
+// DNS settings from before the first change.
+const DNSBackupFileName = "dns_backup.json"
+
+// dnsQueryTimeout bounds one benchmark query.
+const dnsQueryTimeout = 2 * time.Second
+
+// dnsBenchmarkDomains are resolved against every server; popular names are
+// likely cached by the resolver, so the benchmark measures its latency
+// rather than the authoritative servers'.
+var dnsBenchmarkDomains = []string{
+	"www.microsoft.com", "www.google.com", "github.com", "www.wikipedia.org", "www.amazon.com",
+}
+
+// Resolver is a DNS service with its IPv4 servers.
+type Resolver struct {
+	Name    string   `json:"name"`
+	Servers []string `json:"servers"`
+}
+
+// publicResolvers are the well-known resolvers offered by `--set`.
+var publicResolvers = []Resolver{
+	{Name: "cloudflare", Servers: []string{"1.1.1.1", "1.0.0.1"}},
+	{Name: "google", Servers: []string{"8.8.8.8", "8.8.4.4"}},
+	{Name: "quad9", Servers: []string{"9.9.9.9", "149.112.112.112"}},
+}
+
+// PublicResolvers returns the well-known resolvers.
+func PublicResolvers() []Resolver {
+	return publicResolvers
+}
+
+// FindResolver returns the public resolver with the given name.
+func FindResolver(name string) (Resolver, error) {
+	var names []string
+	for _, r := range publicResolvers {
+		if strings.EqualFold(r.Name, name) {
+			return r, nil
+		}
+		names = append(names, r.Name)
+	}
+	return Resolver{}, fmt.Errorf("unknown resolver %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// FlushDNS clears the DNS resolver cache.
+func FlushDNS() error {
+	if err := core.RequireAdmin("flush DNS"); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), serviceTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "ipconfig", "/flushdns")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to flush DNS: %s: %w",
+			strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// ─── Benchmark ───────────────────────────────────────────────────────────────
+
+// DNSBenchmark is the latency of one resolver's primary server.
+type DNSBenchmark struct {
+	Resolver string        `json:"resolver"`
+	Server   string        `json:"server"`
+	Median   time.Duration `json:"median_ns"`
+	Min      time.Duration `json:"min_ns"`
+	Max      time.Duration `json:"max_ns"`
+	Answered int           `json:"answered"`
+	Queries  int           `json:"queries"`
+}
+
+// Reliable reports whether every query got an answer.
+func (b DNSBenchmark) Reliable() bool {
+	return b.Queries > 0 && b.Answered == b.Queries
+}
+
+// BenchmarkResolvers queries every resolver's primary server rounds times
+// per benchmark domain and returns the results, fastest first. Resolvers
+// that answered nothing sort last.
+func BenchmarkResolvers(resolvers []Resolver, rounds int) []DNSBenchmark {
+	var results []DNSBenchmark
+	for _, r := range resolvers {
+		if len(r.Servers) == 0 {
+			continue
+		}
+		results = append(results, benchmarkServer(r.Name, r.Servers[0], rounds))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if (a.Answered == 0) != (b.Answered == 0) {
+			return a.Answered > 0
+		}
+		return a.Median < b.Median
+	})
+	return results
+}
+
+func benchmarkServer(name, server string, rounds int) DNSBenchmark {
+	b := DNSBenchmark{Resolver: name, Server: server}
+	var times []time.Duration
+	for i := 0; i < rounds; i++ {
+		for _, domain := range dnsBenchmarkDomains {
+			b.Queries++
+			d, err := queryDNS(server, domain)
+			if err != nil {
+				continue
+			}
+			b.Answered++
+			times = append(times, d)
+		}
+	}
+	if len(times) == 0 {
+		return b
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	b.Min, b.Max, b.Median = times[0], times[len(times)-1], times[len(times)/2]
+	return b
+}
+
+// queryDNS sends one A query for domain to server over UDP and returns how
+// long the answer took.
+func queryDNS(server, domain string) (time.Duration, error) {
+	id := uint16(rand.UintN(1 << 16))
+	msg, err := buildDNSQuery(id, domain)
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(server, "53"), dnsQueryTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	start := time.Now()
+	if err := conn.SetDeadline(start.Add(dnsQueryTimeout)); err != nil {
+		return 0, err
+	}
+	if _, err := conn.Write(msg); err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 512)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+		// Ignore stray datagrams; a response has our ID and the QR bit.
+		if n >= 12 && binary.BigEndian.Uint16(buf) == id && buf[2]&0x80 != 0 {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// buildDNSQuery encodes a recursive A query.
+func buildDNSQuery(id uint16, domain string) ([]byte, error) {
+	msg := make([]byte, 12, 12+len(domain)+6)
+	binary.BigEndian.PutUint16(msg[0:], id)
+	msg[2] = 0x01                          // RD: recursion desired.
+	binary.BigEndian.PutUint16(msg[4:], 1) // QDCOUNT
+	for _, label := range strings.Split(strings.TrimSuffix(domain, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return nil, fmt.Errorf("invalid domain %q", domain)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0)          // Root label.
+	msg = append(msg, 0, 1, 0, 1) // QTYPE A, QCLASS IN.
+	return msg, nil
+}
+
+// ─── Adapters ────────────────────────────────────────────────────────────────
+
+// AdapterDNS is the IPv4 DNS configuration of a connected adapter. Static
+// is false when the servers come from DHCP.
+type AdapterDNS struct {
+	Alias   string   `json:"alias"`
+	Index   int      `json:"index"`
+	Servers []string `json:"servers"`
+	Static  bool     `json:"static"`
+}
+
+// adapterDNSQuery lists connected adapters with their IPv4 DNS servers.
+// Servers set by hand are stored in the interface's NameServer value;
+// DHCP-provided ones are not.
+const adapterDNSQuery = `$ErrorActionPreference = 'Stop'
+@(Get-NetAdapter | Where-Object { $_.Status -eq 'Up' } | ForEach-Object {
+  $a = $_
+  $dns = (Get-DnsClientServerAddress -InterfaceIndex $a.ifIndex -AddressFamily IPv4 -ErrorAction SilentlyContinue).ServerAddresses
+  $key = "HKLM:\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\Interfaces\$($a.InterfaceGuid)"
+  $ns = (Get-ItemProperty -Path $key -Name NameServer -ErrorAction SilentlyContinue).NameServer
+  [pscustomobject]@{ Alias = $a.Name; Index = [int]$a.ifIndex; Servers = @($dns); Static = [bool]$ns }
+}) | ConvertTo-Json -Depth 3 -Compress`
+
+// ListAdapterDNS returns the DNS servers of every connected adapter.
+func ListAdapterDNS() ([]AdapterDNS, error) {
+	output, err := runPowerShell(adapterDNSQuery)
+	if err != nil {
+		return nil, fmt.Errorf("adapter query failed: %w", err)
+	}
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil, nil
+	}
+	// ConvertTo-Json unwraps single-element arrays.
+	if !strings.HasPrefix(text, "[") {
+		text = "[" + text + "]"
+	}
+	var adapters []AdapterDNS
+	if err := json.Unmarshal([]byte(text), &adapters); err != nil {
+		return nil, fmt.Errorf("cannot parse adapter query: %w", err)
+	}
+	return adapters, nil
+}
+
+// CurrentResolver returns the servers the adapters use now, as a resolver
+// named "current", for benchmarking alongside the public ones.
+func CurrentResolver(adapters []AdapterDNS) Resolver {
+	r := Resolver{Name: "current"}
+	seen := make(map[string]bool)
+	for _, a := range adapters {
+		for _, s := range a.Servers {
+			if !seen[s] {
+				seen[s] = true
+				r.Servers = append(r.Servers, s)
+			}
+		}
+	}
+	return r
+}
+
+// SetAdapterDNS points an adapter at the given servers. The adapter's
+// current settings are added to the backup file in configDir first, unless
+// an earlier change already recorded them.
+func SetAdapterDNS(configDir string, adapter AdapterDNS, servers []string) error {
+	if err := core.RequireAdmin("change DNS servers"); err != nil {
+		return err
+	}
+	quoted, err := quoteServers(servers)
+	if err != nil {
+		return err
+	}
+	if err := backupAdapterDNS(configDir, adapter); err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+Set-DnsClientServerAddress -InterfaceIndex %d -ServerAddresses @(%s)`, adapter.Index, quoted)
+	if _, err := runPowerShell(script); err != nil {
+		return fmt.Errorf("cannot set DNS servers on %s: %w", adapter.Alias, err)
+	}
+	return nil
+}
+
+// RevertAdapterDNS restores every adapter recorded in the backup file and
+// removes it. Adapters that used DHCP go back to DHCP-provided servers.
+func RevertAdapterDNS(configDir string) ([]AdapterDNS, error) {
+	if err := core.RequireAdmin("change DNS servers"); err != nil {
+		return nil, err
+	}
+	saved, err := LoadDNSBackup(configDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(saved) == 0 {
+		return nil, fmt.Errorf("no DNS changes to revert")
+	}
+
+	var errs []error
+	for _, a := range saved {
+		script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+Set-DnsClientServerAddress -InterfaceIndex %d -ResetServerAddresses`, a.Index)
+		if a.Static && len(a.Servers) > 0 {
+			quoted, err := quoteServers(a.Servers)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			script = fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+Set-DnsClientServerAddress -InterfaceIndex %d -ServerAddresses @(%s)`, a.Index, quoted)
+		}
+		if _, err := runPowerShell(script); err != nil {
+			errs = append(errs, fmt.Errorf("cannot restore %s: %w", a.Alias, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return saved, err
+	}
+	if err := os.Remove(filepath.Join(configDir, DNSBackupFileName)); err != nil && !os.IsNotExist(err) {
+		return saved, err
+	}
+	return saved, nil
+}
+
+// LoadDNSBackup returns the adapter settings recorded before the first
+// change, or nil when nothing was changed.
+func LoadDNSBackup(configDir string) ([]AdapterDNS, error) {
+	data, err := os.ReadFile(filepath.Join(configDir, DNSBackupFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read DNS backup: %w", err)
+	}
+	var saved []AdapterDNS
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("cannot parse DNS backup: %w", err)
+	}
+	return saved, nil
+}
+
+// backupAdapterDNS records an adapter's settings unless already recorded,
+// so repeated changes keep the original state.
+func backupAdapterDNS(configDir string, adapter AdapterDNS) error {
+	saved, err := LoadDNSBackup(configDir)
+	if err != nil {
+		return err
+	}
+	for _, a := range saved {
+		if a.Index == adapter.Index {
+			return nil
+		}
+	}
+	saved = append(saved, adapter)
+
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		return fmt.Errorf("cannot create config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(configDir, DNSBackupFileName), data, 0o644); err != nil {
+		return fmt.Errorf("cannot write DNS backup: %w", err)
+	}
+	return nil
+}
+
+// quoteServers validates IP addresses and formats them as a PowerShell
+// array body.
+func quoteServers(servers []string) (string, error) {
+	if len(servers) == 0 {
+		return "", fmt.Errorf("no DNS servers given")
+	}
+	quoted := make([]string, 0, len(servers))
+	for _, s := range servers {
+		if net.ParseIP(s) == nil {
+			return "", fmt.Errorf("invalid DNS server address %q", s)
+		}
+		quoted = append(quoted, "'"+s+"'")
+	}
+	return strings.Join(quoted, ","), nil
+}
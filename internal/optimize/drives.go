@@ -0,0 +1,189 @@
+package optimize
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// driveOptimizeTimeout bounds one volume. Defragmenting a large, badly
+// fragmented hard disk can take hours; a retrim takes seconds to minutes.
+const driveOptimizeTimeout = 6 * time.Hour
+
+// ─── Volumes ─────────────────────────────────────────────────────────────────
+
+// Media types reported for a volume's physical disk.
+const (
+	MediaSSD     = "SSD"
+	MediaHDD     = "HDD"
+	MediaUnknown = "Unspecified"
+)
+
+// Volume is a fixed, lettered NTFS or ReFS volume that can be optimized.
+type Volume struct {
+	Drive      string `json:"drive"` // e.g. "C:"
+	Label      string `json:"label"`
+	FileSystem string `json:"file_system"`
+	Media      string `json:"media"` // MediaSSD, MediaHDD or MediaUnknown
+	Size       int64  `json:"size"`
+	Free       int64  `json:"free"`
+}
+
+// Action describes what optimizing the volume does: a retrim for SSDs, a
+// defragmentation for hard disks, and Windows' own choice otherwise.
+func (v Volume) Action() string {
+	switch v.Media {
+	case MediaSSD:
+		return "TRIM"
+	case MediaHDD:
+		return "Defragment"
+	}
+	return "Optimize"
+}
+
+// volumeQuery lists optimizable volumes with the media type of the disk
+// they live on, as a JSON array.
+const volumeQuery = `$disks = @{}
+Get-PhysicalDisk | ForEach-Object { $disks[[string]$_.DeviceId] = [string]$_.MediaType }
+$vols = @(Get-Volume | Where-Object {
+  $_.DriveLetter -and $_.DriveType -eq 'Fixed' -and $_.FileSystemType -in 'NTFS','ReFS'
+} | Sort-Object DriveLetter | ForEach-Object {
+  $p = Get-Partition -DriveLetter $_.DriveLetter -ErrorAction SilentlyContinue | Select-Object -First 1
+  $media = 'Unspecified'
+  if ($p -and $disks.ContainsKey([string]$p.DiskNumber)) { $media = $disks[[string]$p.DiskNumber] }
+  [pscustomobject]@{
+    Drive = "$($_.DriveLetter):"; Label = [string]$_.FileSystemLabel
+    FileSystem = [string]$_.FileSystemType; Media = $media
+    Size = [int64]$_.Size; Free = [int64]$_.SizeRemaining
+  }
+})
+ConvertTo-Json -InputObject $vols -Compress`
+
+// ListVolumes returns the fixed NTFS and ReFS volumes with a drive letter,
+// with the media type of each detected from its physical disk.
+func ListVolumes() ([]Volume, error) {
+	output, err := runPowerShell(volumeQuery)
+	if err != nil {
+		return nil, fmt.Errorf("volume query failed: %w", err)
+	}
+	var vols []Volume
+	if err := json.Unmarshal(bytes.TrimSpace(output), &vols); err != nil {
+		return nil, fmt.Errorf("cannot parse volume query: %w", err)
+	}
+	for i := range vols {
+		if vols[i].Media != MediaSSD && vols[i].Media != MediaHDD {
+			vols[i].Media = MediaUnknown
+		}
+	}
+	return vols, nil
+}
+
+// ─── Optimization ────────────────────────────────────────────────────────────
+
+// driveLetterPattern limits drives to a single letter and colon.
+var driveLetterPattern = regexp.MustCompile(`^[A-Za-z]:$`)
+
+// defragProgressPattern matches progress lines such as
+// "Retrim:  45% complete..." printed by defrag.exe /U.
+var defragProgressPattern = regexp.MustCompile(`(\d{1,3})%\s+complete`)
+
+// OptimizeVolume retrims an SSD, defragments a hard disk, or lets
+// defrag.exe pick for unknown media. progress, if non-nil, receives the
+// percentage reported by defrag.exe as it runs.
+func OptimizeVolume(v Volume, progress func(percent int)) error {
+	if err := core.RequireAdmin("optimize drives"); err != nil {
+		return err
+	}
+	if !driveLetterPattern.MatchString(v.Drive) {
+		return fmt.Errorf("invalid drive %q", v.Drive)
+	}
+
+	mode := "/O" // proper optimization for the media type
+	switch v.Media {
+	case MediaSSD:
+		mode = "/L" // retrim
+	case MediaHDD:
+		mode = "/D" // traditional defragmentation
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), driveOptimizeTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "defrag.exe", v.Drive, mode, "/U", "/V")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("cannot start defrag.exe: %w", err)
+	}
+
+	// defrag.exe redraws its progress line with carriage returns.
+	var tail []string
+	scanner := bufio.NewScanner(stdout)
+	scanner.Split(splitLinesOrCR)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if m := defragProgressPattern.FindStringSubmatch(line); m != nil {
+			if pct, convErr := strconv.Atoi(m[1]); convErr == nil && progress != nil {
+				progress(min(pct, 100))
+			}
+			continue
+		}
+		tail = append(tail, line)
+		if len(tail) > 5 {
+			tail = tail[1:]
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("defrag %s %s failed: %s: %w",
+			v.Drive, mode, truncateOutput([]byte(strings.Join(tail, " ")), 300), err)
+	}
+	return nil
+}
+
+// splitLinesOrCR is a bufio.SplitFunc that ends tokens at \r or \n.
+func splitLinesOrCR(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// ─── Scheduling ──────────────────────────────────────────────────────────────
+
+// DrivesTaskName is the Task Scheduler name of the drive optimization task.
+const DrivesTaskName = "OptimizeDrives"
+
+// DrivesTask returns the scheduled task that runs `pw optimize --drives`
+// for the given drives (all when empty). It runs elevated, as defrag.exe
+// requires.
+func DrivesTask(drives []string, frequency, startTime string) core.ScheduledTask {
+	args := []string{"optimize", "--drives"}
+	for _, d := range drives {
+		args = append(args, "--drive", d)
+	}
+	return core.ScheduledTask{
+		Name:      DrivesTaskName,
+		Args:      args,
+		Frequency: frequency,
+		StartTime: startTime,
+		Elevated:  true,
+	}
+}
@@ -0,0 +1,87 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── GPU Shader Caches ───────────────────────────────────────────────────────
+// `pw optimize gpu --purge-shaders` empties the driver shader caches. The
+// caches are the same ones `pw clean` knows about; purging them is useful
+// after a driver update or when a game shows corrupted shaders. Drivers
+// rebuild them on demand, so the next launch of a game may stutter briefly.
+
+// shaderCacheTargets maps shader cache clean targets to their vendor.
+var shaderCacheTargets = map[string]string{
+	"DirectXShaderCache": "directx",
+	"NvidiaShaderCache":  "nvidia",
+	"AMDShaderCache":     "amd",
+	"IntelShaderCache":   "intel",
+}
+
+// ShaderCache is one vendor's shader cache folders.
+type ShaderCache struct {
+	Vendor      string   `json:"vendor"` // directx, nvidia, amd or intel
+	Description string   `json:"description"`
+	Paths       []string `json:"paths"`
+	Size        int64    `json:"size"`
+}
+
+// ShaderCaches returns each vendor's shader caches that exist on disk with
+// their current size.
+func ShaderCaches() []ShaderCache {
+	var caches []ShaderCache
+	for _, t := range config.GetCleanTargets() {
+		vendor, ok := shaderCacheTargets[t.Name]
+		if !ok {
+			continue
+		}
+		c := ShaderCache{Vendor: vendor, Description: t.Description}
+		for _, p := range t.Paths {
+			if _, err := os.Stat(p); err != nil {
+				continue
+			}
+			c.Paths = append(c.Paths, p)
+			size, _ := core.GetDirSize(p)
+			c.Size += size
+		}
+		if len(c.Paths) == 0 {
+			continue
+		}
+		caches = append(caches, c)
+	}
+	return caches
+}
+
+// ShaderVendor normalizes a vendor name as reported for a GPU ("NVIDIA")
+// or typed by the user ("nvidia") to a shader cache vendor.
+func ShaderVendor(name string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(name))
+	for _, known := range shaderCacheTargets {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown shader cache vendor %q (want directx, nvidia, amd or intel)", name)
+}
+
+// PurgeShaderCache deletes the contents of a vendor's shader cache folders
+// and returns the bytes freed. Files the driver has open are skipped; the
+// folders themselves are kept.
+func PurgeShaderCache(c ShaderCache) (int64, error) {
+	var freed int64
+	var errs []error
+	for _, p := range c.Paths {
+		n, _, err := core.SafeCleanDir(p, "*", false)
+		freed += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return freed, errors.Join(errs...)
+}
@@ -0,0 +1,329 @@
+package optimize
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ─── Change Journal ──────────────────────────────────────────────────────────
+// Every optimize action that is actually applied is appended to a journal
+// as one JSON line. An entry holds the tracked state the action changed
+// (services, registry tweaks, scheduled tasks, startup entries, the power
+// plan) as it was before the action ran, found by capturing the state
+// around it. `pw optimize --revert` replays the entries newest first and
+// then appends a revert marker, so the next revert starts after it.
+// DNS servers, TCP auto-tuning, the Winsock catalog, hibernation, the
+// pagefile and search exclusions are not part of that state: their actions
+// declare their changes NotRevertible, and the journal keeps them so a
+// revert can name what it leaves alone and how to undo it by hand.
+
+// JournalFileName is the journal file under the config dir.
+const JournalFileName = "optimize_journal.jsonl"
+
+// journalRevertMarker is the Operation of the marker written by a revert.
+const journalRevertMarker = "revert"
+
+// Change is one planned or applied modification.
+type Change struct {
+	Kind   string `json:"kind"` // e.g. "service", "registry", "task", "power", "command"
+	Target string `json:"target"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+
+	// NoRevert is set on changes `pw optimize --revert` cannot undo; Undo
+	// then says how to undo it by hand, if it can be.
+	NoRevert bool   `json:"no_revert,omitempty"`
+	Undo     string `json:"undo,omitempty"`
+}
+
+// String formats the change for dry runs and journal listings.
+func (c Change) String() string {
+	var s string
+	switch {
+	case c.From != "" && c.To != "":
+		s = fmt.Sprintf("%s %s: %s → %s", c.Kind, c.Target, c.From, c.To)
+	case c.To != "":
+		s = fmt.Sprintf("%s %s: %s", c.Kind, c.Target, c.To)
+	default:
+		s = fmt.Sprintf("%s %s", c.Kind, c.Target)
+	}
+	switch {
+	case c.NoRevert && c.Undo != "":
+		s += fmt.Sprintf(" (not revertible; undo with %s)", c.Undo)
+	case c.NoRevert:
+		s += " (not revertible)"
+	}
+	return s
+}
+
+// NotRevertible marks changes `pw optimize --revert` cannot undo, with the
+// command that undoes them by hand, or "" when none does.
+func NotRevertible(undo string, changes ...Change) []Change {
+	out := make([]Change, len(changes))
+	for i, c := range changes {
+		c.NoRevert, c.Undo = true, undo
+		out[i] = c
+	}
+	return out
+}
+
+// CommandChange describes running a command that changes no tracked state.
+func CommandChange(command string) Change {
+	return Change{Kind: "command", Target: command}
+}
+
+// ServiceChange describes a service changing to the given state.
+func ServiceChange(name, to string) Change {
+	return Change{Kind: "service", Target: name, To: to}
+}
+
+// JournalEntry is one applied optimize action.
+type JournalEntry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Error     string    `json:"error,omitempty"`
+	Changes   []Change  `json:"changes,omitempty"`
+
+	// Before holds only the tracked items the action changed, as they
+	// were before it ran.
+	Before *Snapshot `json:"before,omitempty"`
+}
+
+// Reversible reports whether the entry changed any tracked state.
+func (e JournalEntry) Reversible() bool {
+	return e.Before != nil
+}
+
+// NotRevertible returns the changes of the entry a revert leaves alone.
+func (e JournalEntry) NotRevertible() []Change {
+	var out []Change
+	for _, c := range e.Changes {
+		if c.NoRevert {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Journal records applied actions for one run. It reuses the state
+// captured after each action as the state before the next.
+type Journal struct {
+	path string
+	last *Snapshot
+}
+
+// OpenJournal returns the journal under the config dir.
+func OpenJournal(configDir string) *Journal {
+	return &Journal{path: filepath.Join(configDir, JournalFileName)}
+}
+
+// Record runs an action and appends what it changed to the journal. Of the
+// changes a successful action declares, the NotRevertible ones are
+// journaled as they are, since no captured state covers them. The action's own error is
+// returned; failing to capture state or write the journal never fails the
+// action.
+func (j *Journal) Record(operation string, run func() error, declared ...Change) error {
+	before := j.last
+	if before == nil {
+		before, _ = CaptureSnapshot()
+	}
+
+	runErr := run()
+
+	after, _ := CaptureSnapshot()
+	j.last = after
+
+	entry := JournalEntry{Time: time.Now(), Operation: operation}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+	if before != nil && after != nil {
+		if changed := changedState(before, after); changed != nil {
+			entry.Before = changed
+			for _, c := range diffJournalState(changed, after) {
+				// The diff reverts; the journal shows the forward change.
+				entry.Changes = append(entry.Changes, Change{Kind: c.Kind, Target: c.Target, From: c.To, To: c.From})
+			}
+		}
+	}
+	for _, c := range declared {
+		if c.NoRevert && runErr == nil {
+			entry.Changes = append(entry.Changes, c)
+		}
+	}
+	_ = j.append(entry)
+	return runErr
+}
+
+// append writes one entry as a JSON line.
+func (j *Journal) append(entry JournalEntry) error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open journal %s: %w", j.path, err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// PendingJournalEntries returns the entries recorded since the last
+// revert, oldest first.
+func PendingJournalEntries(configDir string) ([]JournalEntry, error) {
+	return readJournal(configDir, true)
+}
+
+// JournaledStartup returns the IDs of the startup entries any journaled
+// optimize action changed, reverted or not. These are the only entries
+// DiffSnapshot restores. An unreadable journal owns nothing.
+func JournaledStartup(configDir string) map[string]bool {
+	owned := make(map[string]bool)
+	entries, _ := readJournal(configDir, false)
+	for _, e := range entries {
+		if e.Before == nil {
+			continue
+		}
+		for _, s := range e.Before.Startup {
+			owned[startupID(s)] = true
+		}
+	}
+	return owned
+}
+
+// readJournal returns the journal entries oldest first, without revert
+// markers. With sinceRevert only the entries after the last marker are
+// returned.
+func readJournal(configDir string, sinceRevert bool) ([]JournalEntry, error) {
+	path := filepath.Join(configDir, JournalFileName)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read journal %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			continue // A torn last line from an interrupted write.
+		}
+		if e.Operation == journalRevertMarker {
+			if sinceRevert {
+				entries = entries[:0]
+			}
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read journal %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// JournalRevertChanges returns what reverting an entry would change on
+// the current system. Items since changed back by hand yield nothing.
+func JournalRevertChanges(entry JournalEntry, current *Snapshot) []SnapshotChange {
+	if entry.Before == nil || current == nil {
+		return nil
+	}
+	return diffJournalState(entry.Before, current)
+}
+
+// MarkJournalReverted appends the revert marker, so entries up to now are
+// not reverted again.
+func MarkJournalReverted(configDir string) error {
+	return OpenJournal(configDir).append(JournalEntry{Time: time.Now(), Operation: journalRevertMarker})
+}
+
+// changedState returns the items of before that differ in after, or nil
+// when nothing tracked changed.
+func changedState(before, after *Snapshot) *Snapshot {
+	changed := &Snapshot{Version: SnapshotVersion, CreatedAt: before.CreatedAt, Host: before.Host}
+	found := false
+
+	services := make(map[string]ServiceState, len(after.Services))
+	for _, s := range after.Services {
+		services[strings.ToLower(s.Name)] = s
+	}
+	for _, s := range before.Services {
+		if a, ok := services[strings.ToLower(s.Name)]; ok && a != s {
+			changed.Services = append(changed.Services, s)
+			found = true
+		}
+	}
+
+	values := make(map[string]RegistryValue, len(after.Registry))
+	for _, v := range after.Registry {
+		values[registryValueID(v)] = v
+	}
+	for _, v := range before.Registry {
+		if a := values[registryValueID(v)]; a.Exists != v.Exists || a.Value != v.Value {
+			changed.Registry = append(changed.Registry, v)
+			found = true
+		}
+	}
+
+	tasks := make(map[string]TaskState, len(after.Tasks))
+	for _, t := range after.Tasks {
+		tasks[strings.ToLower(t.Path)] = t
+	}
+	for _, t := range before.Tasks {
+		if a, ok := tasks[strings.ToLower(t.Path)]; ok && a.Enabled != t.Enabled {
+			changed.Tasks = append(changed.Tasks, t)
+			found = true
+		}
+	}
+
+	startup := make(map[string]StartupItemState, len(after.Startup))
+	for _, s := range after.Startup {
+		startup[startupID(s)] = s
+	}
+	for _, s := range before.Startup {
+		if a, ok := startup[startupID(s)]; !ok || a.Enabled != s.Enabled {
+			changed.Startup = append(changed.Startup, s)
+			found = true
+		}
+	}
+
+	if before.PowerPlan != "" && !strings.EqualFold(before.PowerPlan, after.PowerPlan) {
+		changed.PowerPlan = before.PowerPlan
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+	return changed
+}
+
+// diffJournalState diffs a partial snapshot against the current state.
+// The startup entries it holds are ones the journaled action changed.
+func diffJournalState(partial, current *Snapshot) []SnapshotChange {
+	owned := make(map[string]bool, len(partial.Startup))
+	for _, s := range partial.Startup {
+		owned[startupID(s)] = true
+	}
+	return DiffSnapshot(partial, current, owned)
+}
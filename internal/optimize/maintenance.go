@@ -21,21 +21,7 @@ const (
 
 // RunDISMCleanup runs the DISM component cleanup to free disk space.
 func RunDISMCleanup() error {
-	if err := core.RequireAdmin("DISM cleanup"); err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), maintenanceTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "DISM.exe",
-		"/Online", "/Cleanup-Image", "/StartComponentCleanup")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("DISM cleanup failed: %s: %w",
-			truncateOutput(output, 300), err)
-	}
-	return nil
+	return CleanupComponentStore(false, nil)
 }
 
 // RunSFCCheck runs the System File Checker in verify-only mode.
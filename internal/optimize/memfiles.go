@@ -0,0 +1,187 @@
+package optimize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// memFileTimeout bounds powercfg and WMI queries.
+const memFileTimeout = 60 * time.Second
+
+// ─── Hibernation ─────────────────────────────────────────────────────────────
+
+// HibernationState describes hibernation and the size of hiberfil.sys.
+type HibernationState struct {
+	Enabled  bool   `json:"enabled"`
+	Path     string `json:"path"`
+	FileSize int64  `json:"file_size"` // 0 when the file does not exist
+}
+
+// GetHibernation reports whether hibernation is enabled and how much disk
+// space hiberfil.sys currently takes.
+func GetHibernation() HibernationState {
+	state := HibernationState{Path: filepath.Join(systemDrive(), "hiberfil.sys")}
+
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE,
+		`SYSTEM\CurrentControlSet\Control\Power`, registry.QUERY_VALUE)
+	if err == nil {
+		if v, _, vErr := key.GetIntegerValue("HibernateEnabled"); vErr == nil {
+			state.Enabled = v != 0
+		}
+		key.Close()
+	}
+
+	// hiberfil.sys is held open by the kernel; os.Stat falls back to the
+	// directory entry, which still carries the size.
+	if info, err := os.Stat(state.Path); err == nil {
+		state.FileSize = info.Size()
+	}
+	return state
+}
+
+// SetHibernation turns hibernation (and Fast Startup, which depends on it)
+// on or off with powercfg. Turning it off deletes hiberfil.sys.
+func SetHibernation(enabled bool) error {
+	if err := core.RequireAdmin("optimize hibernation"); err != nil {
+		return err
+	}
+
+	arg := "off"
+	if enabled {
+		arg = "on"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), memFileTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, "powercfg", "/hibernate", arg).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("powercfg /hibernate %s failed: %s: %w", arg, truncateOutput(output, 300), err)
+	}
+	return nil
+}
+
+// ─── Pagefile ────────────────────────────────────────────────────────────────
+
+// PagefileInfo describes one pagefile: its configured limits and its use.
+// Sizes are in megabytes, as reported by WMI.
+type PagefileInfo struct {
+	Path        string `json:"path"`
+	AllocatedMB int64  `json:"allocated_mb"`
+	CurrentMB   int64  `json:"current_usage_mb"`
+	PeakMB      int64  `json:"peak_usage_mb"`
+	InitialMB   int64  `json:"initial_mb"` // 0 when system managed
+	MaximumMB   int64  `json:"maximum_mb"` // 0 when system managed
+}
+
+// PagefileState is the pagefile configuration of the machine.
+type PagefileState struct {
+	// Automatic is true when Windows manages pagefile sizes on all drives.
+	Automatic bool           `json:"automatic"`
+	Files     []PagefileInfo `json:"files"`
+}
+
+// pagefileQuery gathers the pagefile state from WMI as a single JSON object.
+const pagefileQuery = `$cs = Get-CimInstance Win32_ComputerSystem
+$set = @(Get-CimInstance Win32_PageFileSetting)
+$use = @(Get-CimInstance Win32_PageFileUsage | ForEach-Object {
+  $n = $_.Name
+  $s = $set | Where-Object { $_.Name -eq $n } | Select-Object -First 1
+  [pscustomobject]@{
+    Path = $n; AllocatedMB = [int64]$_.AllocatedBaseSize
+    CurrentMB = [int64]$_.CurrentUsage; PeakMB = [int64]$_.PeakUsage
+    InitialMB = [int64]$s.InitialSize; MaximumMB = [int64]$s.MaximumSize
+  }
+})
+[pscustomobject]@{ Automatic = [bool]$cs.AutomaticManagedPagefile; Files = $use } | ConvertTo-Json -Depth 3 -Compress`
+
+// GetPagefile queries WMI for the pagefile configuration and usage.
+func GetPagefile() (PagefileState, error) {
+	var state PagefileState
+	output, err := runPowerShell(pagefileQuery)
+	if err != nil {
+		return state, fmt.Errorf("pagefile query failed: %w", err)
+	}
+	if err := json.Unmarshal(output, &state); err != nil {
+		return state, fmt.Errorf("cannot parse pagefile query: %w", err)
+	}
+	return state, nil
+}
+
+// pagefilePathPattern limits pagefile paths to <drive>:\pagefile.sys so they
+// are safe to embed in a PowerShell command.
+var pagefilePathPattern = regexp.MustCompile(`(?i)^[a-z]:\\pagefile\.sys$`)
+
+// SetPagefileSize switches off automatic management and gives the pagefile
+// at path a fixed initial and maximum size in megabytes. Windows applies
+// the change at the next restart.
+func SetPagefileSize(path string, initialMB, maximumMB int64) error {
+	if err := core.RequireAdmin("optimize pagefile"); err != nil {
+		return err
+	}
+	if !pagefilePathPattern.MatchString(path) {
+		return fmt.Errorf("invalid pagefile path %q (expected e.g. C:\\pagefile.sys)", path)
+	}
+	if initialMB < 16 || maximumMB < initialMB {
+		return fmt.Errorf("invalid pagefile size %d-%d MB", initialMB, maximumMB)
+	}
+
+	script := fmt.Sprintf(`$ErrorActionPreference = 'Stop'
+Get-CimInstance Win32_ComputerSystem | Set-CimInstance -Property @{ AutomaticManagedPagefile = $false }
+$s = Get-CimInstance Win32_PageFileSetting | Where-Object { $_.Name -eq '%[1]s' }
+if ($s) { $s | Set-CimInstance -Property @{ InitialSize = [uint32]%[2]d; MaximumSize = [uint32]%[3]d } }
+else { New-CimInstance -ClassName Win32_PageFileSetting -Property @{ Name = '%[1]s'; InitialSize = [uint32]%[2]d; MaximumSize = [uint32]%[3]d } | Out-Null }`,
+		path, initialMB, maximumMB)
+	if _, err := runPowerShell(script); err != nil {
+		return fmt.Errorf("cannot resize pagefile: %w", err)
+	}
+	return nil
+}
+
+// SetPagefileAutomatic hands pagefile sizing back to Windows. Windows applies
+// the change at the next restart.
+func SetPagefileAutomatic() error {
+	if err := core.RequireAdmin("optimize pagefile"); err != nil {
+		return err
+	}
+	script := `$ErrorActionPreference = 'Stop'
+Get-CimInstance Win32_ComputerSystem | Set-CimInstance -Property @{ AutomaticManagedPagefile = $true }`
+	if _, err := runPowerShell(script); err != nil {
+		return fmt.Errorf("cannot enable automatic pagefile: %w", err)
+	}
+	return nil
+}
+
+// runPowerShell runs a script with a timeout and returns its stdout.
+func runPowerShell(script string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), memFileTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-NonInteractive", "-Command", script)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("%s: %w", truncateOutput(exitErr.Stderr, 300), err)
+		}
+		return nil, err
+	}
+	return output, nil
+}
+
+// systemDrive returns the system drive root, e.g. C:\.
+func systemDrive() string {
+	if d := os.Getenv("SystemDrive"); d != "" {
+		return d + `\`
+	}
+	return `C:\`
+}
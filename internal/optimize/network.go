@@ -0,0 +1,148 @@
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// networkTimeout bounds netsh, arp, nbtstat and ipconfig calls. Renewing
+// leases can wait on a slow DHCP server.
+const networkTimeout = 90 * time.Second
+
+// ─── Network Actions ─────────────────────────────────────────────────────────
+
+// NetworkAction is one network reset or tuning step.
+type NetworkAction struct {
+	Key         string // Short name, e.g. "arp".
+	Name        string
+	Description string // Shown in dry runs.
+	NeedsReboot bool
+	Run         func() error
+
+	// Changes lists what the action changes that `pw optimize --revert`
+	// cannot undo; cache flushes and lease renewals change nothing lasting.
+	Changes []Change
+}
+
+// tcpAutoTuningLevels are the levels netsh accepts for autotuninglevel.
+var tcpAutoTuningLevels = []string{"normal", "disabled", "restricted", "highlyrestricted", "experimental"}
+
+// NetworkRepairActions returns the steps of the combined network repair,
+// least disruptive first. Winsock reset comes last since it needs a reboot.
+func NetworkRepairActions() []NetworkAction {
+	return []NetworkAction{
+		{
+			Key:         "dns",
+			Name:        "Flush DNS cache",
+			Description: "Clear cached name lookups (ipconfig /flushdns)",
+			Run:         FlushDNS,
+		},
+		ARPCacheAction(),
+		NetBIOSCacheAction(),
+		DHCPRenewAction(),
+		WinsockResetAction(),
+	}
+}
+
+// WinsockResetAction resets the Winsock catalog, removing broken layered
+// service providers left by VPNs, proxies and security software.
+func WinsockResetAction() NetworkAction {
+	return NetworkAction{
+		Key:         "winsock",
+		Name:        "Reset Winsock catalog",
+		Description: "Restore the default Winsock catalog (netsh winsock reset); removes third-party LSPs",
+		NeedsReboot: true,
+		Run: func() error {
+			return runNetworkCommand("reset Winsock", "netsh", "winsock", "reset")
+		},
+		// The removed providers come back only by reinstalling their software.
+		Changes: NotRevertible("", CommandChange("netsh winsock reset")),
+	}
+}
+
+// ARPCacheAction clears the IP-to-MAC address cache.
+func ARPCacheAction() NetworkAction {
+	return NetworkAction{
+		Key:         "arp",
+		Name:        "Flush ARP cache",
+		Description: "Clear the IP-to-MAC address cache (netsh interface ip delete arpcache)",
+		Run: func() error {
+			return runNetworkCommand("flush ARP cache", "netsh", "interface", "ip", "delete", "arpcache")
+		},
+	}
+}
+
+// NetBIOSCacheAction purges and reloads the NetBIOS name cache.
+func NetBIOSCacheAction() NetworkAction {
+	return NetworkAction{
+		Key:         "netbios",
+		Name:        "Flush NetBIOS cache",
+		Description: "Purge and reload the NetBIOS name cache (nbtstat -R)",
+		Run: func() error {
+			return runNetworkCommand("flush NetBIOS cache", "nbtstat", "-R")
+		},
+	}
+}
+
+// DHCPRenewAction renews the DHCP lease of every adapter. Adapters keep
+// their address while renewing, so connections are not dropped.
+func DHCPRenewAction() NetworkAction {
+	return NetworkAction{
+		Key:         "dhcp",
+		Name:        "Renew DHCP leases",
+		Description: "Ask the DHCP server to renew every adapter's lease (ipconfig /renew)",
+		Run: func() error {
+			return runNetworkCommand("renew DHCP leases", "ipconfig", "/renew")
+		},
+	}
+}
+
+// TCPAutoTuningAction sets the TCP receive window auto-tuning level.
+// "disabled" can help with routers that mishandle window scaling;
+// "normal" is the Windows default.
+func TCPAutoTuningAction(level string) (NetworkAction, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	valid := false
+	for _, l := range tcpAutoTuningLevels {
+		if l == level {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return NetworkAction{}, fmt.Errorf("unknown TCP auto-tuning level %q (want %s)",
+			level, strings.Join(tcpAutoTuningLevels, ", "))
+	}
+	return NetworkAction{
+		Key:         "autotuning",
+		Name:        "Set TCP auto-tuning to " + level,
+		Description: "Change the TCP receive window auto-tuning level (netsh int tcp set global autotuninglevel=" + level + ")",
+		Run: func() error {
+			return runNetworkCommand("set TCP auto-tuning", "netsh", "int", "tcp", "set", "global", "autotuninglevel="+level)
+		},
+		Changes: NotRevertible("pw optimize network --autotuning normal (the Windows default)",
+			Change{Kind: "tcp", Target: "autotuninglevel", To: level}),
+	}, nil
+}
+
+// runNetworkCommand checks for admin rights and runs a networking tool
+// with a timeout.
+func runNetworkCommand(operation, name string, args ...string) error {
+	if err := core.RequireAdmin(operation); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), networkTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to %s: %s: %w", operation, truncateOutput(output, 300), err)
+	}
+	return nil
+}
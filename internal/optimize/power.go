@@ -0,0 +1,439 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Power Plans ─────────────────────────────────────────────────────────────
+// Power schemes are read and written through powrprof.dll rather than by
+// parsing powercfg output, whose labels are localized. Settings are
+// addressed by their documented subgroup and setting GUIDs.
+
+var (
+	modPowrprof                = windows.NewLazySystemDLL("powrprof.dll")
+	procPowerGetActiveScheme   = modPowrprof.NewProc("PowerGetActiveScheme")
+	procPowerSetActiveScheme   = modPowrprof.NewProc("PowerSetActiveScheme")
+	procPowerEnumerate         = modPowrprof.NewProc("PowerEnumerate")
+	procPowerReadFriendlyName  = modPowrprof.NewProc("PowerReadFriendlyName")
+	procPowerWriteFriendlyName = modPowrprof.NewProc("PowerWriteFriendlyName")
+	procPowerDuplicateScheme   = modPowrprof.NewProc("PowerDuplicateScheme")
+	procPowerReadACValueIndex  = modPowrprof.NewProc("PowerReadACValueIndex")
+	procPowerReadDCValueIndex  = modPowrprof.NewProc("PowerReadDCValueIndex")
+	procPowerWriteACValueIndex = modPowrprof.NewProc("PowerWriteACValueIndex")
+	procPowerWriteDCValueIndex = modPowrprof.NewProc("PowerWriteDCValueIndex")
+)
+
+// accessScheme is POWER_DATA_ACCESSOR ACCESS_SCHEME for PowerEnumerate.
+const accessScheme = 16
+
+// Built-in scheme GUIDs. Ultimate Performance is hidden on most editions
+// until it is duplicated.
+const (
+	PlanPowerSaver      = "a1841308-3541-4fab-bc81-f71556f20b4a"
+	PlanBalanced        = "381b4222-f694-41f0-9685-f6fe8e8a3a62"
+	PlanHighPerformance = "8c5e7fda-e8bf-4a96-9a85-a6e23a8c635c"
+	PlanUltimate        = "e9a42b02-d5df-448d-aa00-03f14749eb61"
+)
+
+// TunedPlanName is the name of the plan CreateTunedPlan creates.
+const TunedPlanName = "PureWin Tuned"
+
+// planAliases map the short names accepted by FindPowerPlan to built-in
+// scheme GUIDs.
+var planAliases = map[string]string{
+	"saver":            PlanPowerSaver,
+	"power-saver":      PlanPowerSaver,
+	"balanced":         PlanBalanced,
+	"high":             PlanHighPerformance,
+	"high-performance": PlanHighPerformance,
+	"ultimate":         PlanUltimate,
+}
+
+// powerSetting identifies one setting inside a scheme.
+type powerSetting struct {
+	subgroup string
+	setting  string
+}
+
+var (
+	// settingUSBSuspend is USB selective suspend: 0 disabled, 1 enabled.
+	settingUSBSuspend = powerSetting{"2a737441-1930-4402-8d77-b2bebba308a3", "48e6b7a6-50f5-4782-a5d4-53bb8f07e226"}
+	// settingPCIeASPM is PCI Express link state power management:
+	// 0 off, 1 moderate, 2 maximum savings.
+	settingPCIeASPM = powerSetting{"501a4d13-42af-4429-9fd1-a8218c268e20", "ee12f906-d277-404b-b6da-e5fa1a576df5"}
+	// settingDiskIdle is "turn off hard disk after", in seconds; 0 never.
+	settingDiskIdle = powerSetting{"0012ee47-9041-4b5d-9b77-535fba8b1442", "6738e2c4-e8a5-4a42-b16a-e040e769756e"}
+)
+
+// aspmNames are the PCIe ASPM values by index.
+var aspmNames = []string{"off", "moderate", "maximum"}
+
+// PowerPlan is an installed power scheme.
+type PowerPlan struct {
+	GUID   string `json:"guid"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// PowerStatus is the active plan, every installed plan, and the settings
+// `pw optimize --power` can toggle, for plugged-in (AC) and battery (DC).
+type PowerStatus struct {
+	Active       PowerPlan   `json:"active"`
+	Plans        []PowerPlan `json:"plans"`
+	USBSuspendAC string      `json:"usb_selective_suspend_ac"`
+	USBSuspendDC string      `json:"usb_selective_suspend_dc"`
+	PCIeASPMAC   string      `json:"pcie_aspm_ac"`
+	PCIeASPMDC   string      `json:"pcie_aspm_dc"`
+}
+
+// ─── Public API ──────────────────────────────────────────────────────────────
+
+// GetPowerStatus reads the active plan, the installed plans and the
+// toggleable settings of the active plan.
+func GetPowerStatus() (*PowerStatus, error) {
+	active, err := activeScheme()
+	if err != nil {
+		return nil, err
+	}
+	plans, err := ListPowerPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	st := &PowerStatus{Plans: plans}
+	for _, p := range plans {
+		if p.Active {
+			st.Active = p
+		}
+	}
+	if st.Active.GUID == "" {
+		st.Active = PowerPlan{GUID: guidString(active), Name: schemeName(active), Active: true}
+	}
+
+	st.USBSuspendAC, st.USBSuspendDC = readSettingPair(active, settingUSBSuspend, func(v uint32) string {
+		if v == 0 {
+			return "disabled"
+		}
+		return "enabled"
+	})
+	st.PCIeASPMAC, st.PCIeASPMDC = readSettingPair(active, settingPCIeASPM, func(v uint32) string {
+		if int(v) < len(aspmNames) {
+			return aspmNames[v]
+		}
+		return fmt.Sprintf("%d", v)
+	})
+	return st, nil
+}
+
+// ListPowerPlans returns the installed power schemes.
+func ListPowerPlans() ([]PowerPlan, error) {
+	if err := procPowerEnumerate.Find(); err != nil {
+		return nil, fmt.Errorf("power management API unavailable: %w", err)
+	}
+	active, err := activeScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []PowerPlan
+	for i := uint32(0); ; i++ {
+		var g windows.GUID
+		size := uint32(unsafe.Sizeof(g))
+		ret, _, _ := procPowerEnumerate.Call(0, 0, 0, accessScheme, uintptr(i),
+			uintptr(unsafe.Pointer(&g)), uintptr(unsafe.Pointer(&size)))
+		if windows.Errno(ret) == windows.ERROR_NO_MORE_ITEMS {
+			break
+		}
+		if ret != 0 {
+			return nil, fmt.Errorf("PowerEnumerate failed: %w", windows.Errno(ret))
+		}
+		plans = append(plans, PowerPlan{
+			GUID:   guidString(g),
+			Name:   schemeName(g),
+			Active: g == active,
+		})
+	}
+	return plans, nil
+}
+
+// FindPowerPlan resolves a plan by alias (balanced, high, ultimate,
+// saver), GUID, or name. Ultimate Performance is unlocked by duplicating
+// its hidden template the first time it is asked for.
+func FindPowerPlan(name string) (PowerPlan, error) {
+	plans, err := ListPowerPlans()
+	if err != nil {
+		return PowerPlan{}, err
+	}
+
+	guid := strings.ToLower(strings.Trim(strings.TrimSpace(name), "{}"))
+	if alias, ok := planAliases[guid]; ok {
+		guid = alias
+	}
+	for _, p := range plans {
+		if p.GUID == guid || strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+	}
+
+	if guid == PlanUltimate {
+		// A duplicated Ultimate plan has a new GUID but the template's name.
+		tmpl, err := parseGUID(PlanUltimate)
+		if err != nil {
+			return PowerPlan{}, err
+		}
+		tmplName := schemeName(tmpl)
+		for _, p := range plans {
+			if tmplName != "" && strings.EqualFold(p.Name, tmplName) {
+				return p, nil
+			}
+		}
+		if err := core.RequireAdmin("unlock the Ultimate Performance plan"); err != nil {
+			return PowerPlan{}, err
+		}
+		dup, err := duplicateScheme(tmpl)
+		if err != nil {
+			return PowerPlan{}, fmt.Errorf("cannot unlock Ultimate Performance: %w", err)
+		}
+		return PowerPlan{GUID: guidString(dup), Name: schemeName(dup)}, nil
+	}
+
+	return PowerPlan{}, fmt.Errorf("no power plan named %q (use a GUID, a plan name, or balanced, high, ultimate, saver)", name)
+}
+
+// SetActivePowerPlan makes the plan with the given GUID active.
+func SetActivePowerPlan(guid string) error {
+	g, err := parseGUID(guid)
+	if err != nil {
+		return err
+	}
+	if err := procPowerSetActiveScheme.Find(); err != nil {
+		return fmt.Errorf("power management API unavailable: %w", err)
+	}
+	if ret, _, _ := procPowerSetActiveScheme.Call(0, uintptr(unsafe.Pointer(&g))); ret != 0 {
+		return fmt.Errorf("cannot activate power plan %s: %w", guid, windows.Errno(ret))
+	}
+	return nil
+}
+
+// CreateTunedPlan creates (or updates) the "PureWin Tuned" plan: High
+// performance with USB selective suspend, PCIe link power management and
+// disk spin-down turned off while plugged in. Battery settings are left
+// as High performance sets them. It returns the plan; it is not activated.
+func CreateTunedPlan() (PowerPlan, error) {
+	if err := core.RequireAdmin("create a power plan"); err != nil {
+		return PowerPlan{}, err
+	}
+
+	plans, err := ListPowerPlans()
+	if err != nil {
+		return PowerPlan{}, err
+	}
+	var scheme windows.GUID
+	found := false
+	for _, p := range plans {
+		if p.Name == TunedPlanName {
+			if scheme, err = parseGUID(p.GUID); err != nil {
+				return PowerPlan{}, err
+			}
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		// Modern Standby machines only ship Balanced; fall back to it.
+		base, _ := parseGUID(PlanHighPerformance)
+		scheme, err = duplicateScheme(base)
+		if err != nil {
+			base, _ = parseGUID(PlanBalanced)
+			if scheme, err = duplicateScheme(base); err != nil {
+				return PowerPlan{}, fmt.Errorf("cannot create power plan: %w", err)
+			}
+		}
+		if err := writeSchemeName(scheme, TunedPlanName); err != nil {
+			return PowerPlan{}, err
+		}
+	}
+
+	for _, s := range []powerSetting{settingUSBSuspend, settingPCIeASPM, settingDiskIdle} {
+		if err := writeSetting(procPowerWriteACValueIndex, scheme, s, 0); err != nil {
+			return PowerPlan{}, err
+		}
+	}
+	return PowerPlan{GUID: guidString(scheme), Name: TunedPlanName}, nil
+}
+
+// SetUSBSelectiveSuspend turns USB selective suspend on or off in the
+// active plan, plugged in and on battery.
+func SetUSBSelectiveSuspend(enabled bool) error {
+	var v uint32
+	if enabled {
+		v = 1
+	}
+	return writeActiveSetting(settingUSBSuspend, v)
+}
+
+// SetPCIeASPM sets PCI Express link state power management in the active
+// plan to off, moderate or maximum, plugged in and on battery.
+func SetPCIeASPM(level string) error {
+	for i, name := range aspmNames {
+		if strings.EqualFold(level, name) {
+			return writeActiveSetting(settingPCIeASPM, uint32(i))
+		}
+	}
+	return fmt.Errorf("unknown PCIe ASPM level %q (want off, moderate or maximum)", level)
+}
+
+// ─── powrprof Helpers ────────────────────────────────────────────────────────
+
+// activeScheme returns the GUID of the active power scheme.
+func activeScheme() (windows.GUID, error) {
+	if err := procPowerGetActiveScheme.Find(); err != nil {
+		return windows.GUID{}, fmt.Errorf("power management API unavailable: %w", err)
+	}
+	var p *windows.GUID
+	if ret, _, _ := procPowerGetActiveScheme.Call(0, uintptr(unsafe.Pointer(&p))); ret != 0 {
+		return windows.GUID{}, fmt.Errorf("PowerGetActiveScheme failed: %w", windows.Errno(ret))
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(p)))
+	return *p, nil
+}
+
+// schemeName returns a scheme's friendly name, or "" if it can't be read.
+func schemeName(g windows.GUID) string {
+	size := uint32(512)
+	for attempt := 0; attempt < 2; attempt++ {
+		buf := make([]uint16, size/2)
+		ret, _, _ := procPowerReadFriendlyName.Call(0, uintptr(unsafe.Pointer(&g)), 0, 0,
+			uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+		switch windows.Errno(ret) {
+		case 0:
+			return windows.UTF16ToString(buf)
+		case windows.ERROR_MORE_DATA:
+			continue
+		}
+		return ""
+	}
+	return ""
+}
+
+// writeSchemeName sets a scheme's friendly name.
+func writeSchemeName(g windows.GUID, name string) error {
+	buf, err := windows.UTF16FromString(name)
+	if err != nil {
+		return err
+	}
+	ret, _, _ := procPowerWriteFriendlyName.Call(0, uintptr(unsafe.Pointer(&g)), 0, 0,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)*2))
+	if ret != 0 {
+		return fmt.Errorf("cannot name power plan: %w", windows.Errno(ret))
+	}
+	return nil
+}
+
+// duplicateScheme copies a scheme and returns the new scheme's GUID.
+func duplicateScheme(src windows.GUID) (windows.GUID, error) {
+	var p *windows.GUID
+	ret, _, _ := procPowerDuplicateScheme.Call(0, uintptr(unsafe.Pointer(&src)), uintptr(unsafe.Pointer(&p)))
+	if ret != 0 {
+		return windows.GUID{}, fmt.Errorf("PowerDuplicateScheme failed: %w", windows.Errno(ret))
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(p)))
+	return *p, nil
+}
+
+// readSettingPair reads a setting's AC and DC values and formats them.
+// Settings the machine does not have read as "n/a".
+func readSettingPair(scheme windows.GUID, s powerSetting, format func(uint32) string) (string, string) {
+	read := func(proc *windows.LazyProc) string {
+		v, err := readSetting(proc, scheme, s)
+		if err != nil {
+			return "n/a"
+		}
+		return format(v)
+	}
+	return read(procPowerReadACValueIndex), read(procPowerReadDCValueIndex)
+}
+
+// readSetting reads one value index with PowerReadACValueIndex or
+// PowerReadDCValueIndex.
+func readSetting(proc *windows.LazyProc, scheme windows.GUID, s powerSetting) (uint32, error) {
+	sub, err := parseGUID(s.subgroup)
+	if err != nil {
+		return 0, err
+	}
+	setting, err := parseGUID(s.setting)
+	if err != nil {
+		return 0, err
+	}
+	var v uint32
+	ret, _, _ := proc.Call(0, uintptr(unsafe.Pointer(&scheme)), uintptr(unsafe.Pointer(&sub)),
+		uintptr(unsafe.Pointer(&setting)), uintptr(unsafe.Pointer(&v)))
+	if ret != 0 {
+		return 0, windows.Errno(ret)
+	}
+	return v, nil
+}
+
+// writeSetting writes one value index with PowerWriteACValueIndex or
+// PowerWriteDCValueIndex.
+func writeSetting(proc *windows.LazyProc, scheme windows.GUID, s powerSetting, v uint32) error {
+	sub, err := parseGUID(s.subgroup)
+	if err != nil {
+		return err
+	}
+	setting, err := parseGUID(s.setting)
+	if err != nil {
+		return err
+	}
+	ret, _, _ := proc.Call(0, uintptr(unsafe.Pointer(&scheme)), uintptr(unsafe.Pointer(&sub)),
+		uintptr(unsafe.Pointer(&setting)), uintptr(v))
+	if ret != 0 {
+		return fmt.Errorf("cannot write power setting %s: %w", s.setting, windows.Errno(ret))
+	}
+	return nil
+}
+
+// writeActiveSetting writes a setting for AC and DC in the active scheme
+// and re-applies the scheme so the change takes effect immediately.
+func writeActiveSetting(s powerSetting, v uint32) error {
+	if err := core.RequireAdmin("change power settings"); err != nil {
+		return err
+	}
+	active, err := activeScheme()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, proc := range []*windows.LazyProc{procPowerWriteACValueIndex, procPowerWriteDCValueIndex} {
+		if err := writeSetting(proc, active, s, v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return SetActivePowerPlan(guidString(active))
+}
+
+// parseGUID parses a GUID with or without braces.
+func parseGUID(s string) (windows.GUID, error) {
+	g, err := windows.GUIDFromString("{" + strings.Trim(s, "{}") + "}")
+	if err != nil {
+		return windows.GUID{}, fmt.Errorf("invalid GUID %q", s)
+	}
+	return g, nil
+}
+
+// guidString formats a GUID the way powercfg prints it: lower-case,
+// without braces.
+func guidString(g windows.GUID) string {
+	return strings.ToLower(strings.Trim(g.String(), "{}"))
+}
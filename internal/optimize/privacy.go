@@ -0,0 +1,253 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Privacy Settings ────────────────────────────────────────────────────────
+// Telemetry-related settings audited and hardened by `pw privacy`. Each
+// setting groups the services, scheduled tasks and registry values that
+// together turn one feature off. Every part is captured in optimize
+// snapshots, so 'pw optimize restore' reverts a hardening run.
+
+// PrivacySetting is one telemetry feature and how to turn it off.
+type PrivacySetting struct {
+	ID          string
+	Name        string
+	Description string
+
+	services []string        // Disabled and stopped.
+	tasks    []string        // Disabled; paths below the task root.
+	values   []RegistryValue // Set to Value.
+}
+
+// privacySettings are the settings `pw privacy` manages.
+var privacySettings = []PrivacySetting{
+	{
+		ID:          "diagtrack",
+		Name:        "Telemetry services",
+		Description: "Connected User Experiences and Telemetry (DiagTrack) and WAP push routing",
+		services:    []string{"DiagTrack", "dmwappushservice"},
+	},
+	{
+		ID:          "telemetry-policy",
+		Name:        "Diagnostic data level",
+		Description: "AllowTelemetry policy at its lowest level (Security on Enterprise, Required elsewhere)",
+		values: []RegistryValue{
+			{Root: "HKLM", Path: `SOFTWARE\Policies\Microsoft\Windows\DataCollection`, Name: "AllowTelemetry", Value: 0},
+		},
+	},
+	{
+		ID:          "ceip",
+		Name:        "CEIP and compatibility tasks",
+		Description: "Customer Experience Improvement Program and Application Experience data collection",
+		tasks: []string{
+			`Microsoft\Windows\Customer Experience Improvement Program\Consolidator`,
+			`Microsoft\Windows\Customer Experience Improvement Program\UsbCeip`,
+			`Microsoft\Windows\Application Experience\Microsoft Compatibility Appraiser`,
+			`Microsoft\Windows\Application Experience\ProgramDataUpdater`,
+			`Microsoft\Windows\DiskDiagnostic\Microsoft-Windows-DiskDiagnosticDataCollector`,
+		},
+	},
+	{
+		ID:          "advertising-id",
+		Name:        "Advertising ID",
+		Description: "Per-user ID apps use for personalized ads",
+		values: []RegistryValue{
+			{Root: "HKCU", Path: `Software\Microsoft\Windows\CurrentVersion\AdvertisingInfo`, Name: "Enabled", Value: 0},
+			{Root: "HKLM", Path: `SOFTWARE\Policies\Microsoft\Windows\AdvertisingInfo`, Name: "DisabledByGroupPolicy", Value: 1},
+		},
+	},
+	{
+		ID:          "activity-history",
+		Name:        "Activity history",
+		Description: "Timeline of apps, files and sites, and its upload to Microsoft",
+		values: []RegistryValue{
+			{Root: "HKLM", Path: `SOFTWARE\Policies\Microsoft\Windows\System`, Name: "EnableActivityFeed", Value: 0},
+			{Root: "HKLM", Path: `SOFTWARE\Policies\Microsoft\Windows\System`, Name: "PublishUserActivities", Value: 0},
+			{Root: "HKLM", Path: `SOFTWARE\Policies\Microsoft\Windows\System`, Name: "UploadUserActivities", Value: 0},
+		},
+	},
+	{
+		ID:          "tailored-experiences",
+		Name:        "Tailored experiences",
+		Description: "Tips and offers based on diagnostic data",
+		values: []RegistryValue{
+			{Root: "HKCU", Path: `Software\Microsoft\Windows\CurrentVersion\Privacy`, Name: "TailoredExperiencesWithDiagnosticDataEnabled", Value: 0},
+		},
+	},
+	{
+		ID:          "feedback",
+		Name:        "Feedback requests",
+		Description: "Windows asking for feedback",
+		values: []RegistryValue{
+			{Root: "HKCU", Path: `Software\Microsoft\Siuf\Rules`, Name: "NumberOfSIUFInPeriod", Value: 0},
+		},
+	},
+}
+
+// PrivacySettings returns the settings `pw privacy` manages.
+func PrivacySettings() []PrivacySetting {
+	return privacySettings
+}
+
+// FindPrivacySetting returns the setting with the given ID.
+func FindPrivacySetting(id string) (PrivacySetting, error) {
+	var ids []string
+	for _, s := range privacySettings {
+		if strings.EqualFold(s.ID, id) {
+			return s, nil
+		}
+		ids = append(ids, s.ID)
+	}
+	return PrivacySetting{}, fmt.Errorf("unknown privacy setting %q (want %s)", id, strings.Join(ids, ", "))
+}
+
+// privacyServiceNames, privacyTaskPaths and privacyRegistryValues list
+// every part of every setting, so snapshots capture what hardening may
+// change.
+func privacyServiceNames() []string {
+	var names []string
+	for _, s := range privacySettings {
+		names = append(names, s.services...)
+	}
+	return names
+}
+
+func privacyTaskPaths() []string {
+	var paths []string
+	for _, s := range privacySettings {
+		paths = append(paths, s.tasks...)
+	}
+	return paths
+}
+
+func privacyRegistryValues() []RegistryValue {
+	var values []RegistryValue
+	for _, s := range privacySettings {
+		for _, v := range s.values {
+			v.Value = 0 // Snapshot entries carry the captured value.
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ─── Audit ───────────────────────────────────────────────────────────────────
+
+// PrivacyState is the audited state of one privacy setting.
+type PrivacyState struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Hardened    bool     `json:"hardened"`
+	Present     bool     `json:"present"` // False when none of its parts exist.
+	Pending     []string `json:"pending,omitempty"`
+
+	// Changes are what hardening the setting would change, for dry runs.
+	Changes []Change `json:"-"`
+}
+
+// AuditPrivacy reports which privacy settings are already turned off and,
+// for the rest, which parts are still on. Parts that do not exist on this
+// machine (a service or task removed by the edition) are ignored.
+func AuditPrivacy() []PrivacyState {
+	var states []PrivacyState
+	for _, s := range privacySettings {
+		states = append(states, auditPrivacySetting(s))
+	}
+	return states
+}
+
+func auditPrivacySetting(s PrivacySetting) PrivacyState {
+	st := PrivacyState{ID: s.ID, Name: s.Name, Description: s.Description}
+
+	for _, name := range s.services {
+		svc, err := readServiceState(name)
+		if err != nil {
+			continue // Not installed.
+		}
+		st.Present = true
+		if svc.StartType != "disabled" || svc.Running {
+			st.Pending = append(st.Pending, fmt.Sprintf("service %s is %s", name, describeStartType(svc)))
+			st.Changes = append(st.Changes, Change{
+				Kind: "service", Target: name,
+				From: describeStartType(svc) + ", " + describeRunning(svc.Running), To: "disabled, stopped",
+			})
+		}
+	}
+	for _, path := range s.tasks {
+		enabled, err := core.ScheduledTaskEnabled(path)
+		if err != nil {
+			continue // Not registered.
+		}
+		st.Present = true
+		if enabled {
+			st.Pending = append(st.Pending, "task "+taskBaseName(path)+" is enabled")
+			st.Changes = append(st.Changes, Change{Kind: "task", Target: path, From: "enabled", To: "disabled"})
+		}
+	}
+	for _, want := range s.values {
+		st.Present = true
+		have := readRegistryValue(RegistryValue{Root: want.Root, Path: want.Path, Name: want.Name})
+		if !have.Exists || have.Value != want.Value {
+			st.Pending = append(st.Pending, fmt.Sprintf("%s is %s", want.Name, describeRegistryValue(have)))
+			st.Changes = append(st.Changes, Change{
+				Kind: "registry", Target: want.Root + `\` + want.Path + `\` + want.Name,
+				From: describeRegistryValue(have), To: describeRegistryValue(want),
+			})
+		}
+	}
+
+	st.Hardened = st.Present && len(st.Pending) == 0
+	return st
+}
+
+// taskBaseName returns the last element of a task path.
+func taskBaseName(path string) string {
+	return path[strings.LastIndex(path, `\`)+1:]
+}
+
+// ─── Harden ──────────────────────────────────────────────────────────────────
+
+// HardenPrivacySetting turns every part of a setting off. It keeps going
+// past failures and returns them joined.
+func HardenPrivacySetting(s PrivacySetting) error {
+	if err := core.RequireAdmin("change privacy settings"); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range s.services {
+		if _, err := readServiceState(name); err != nil {
+			continue
+		}
+		if err := setServiceStartType(ServiceState{Name: name, StartType: "disabled"}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := setServiceRunning(name, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, path := range s.tasks {
+		if _, err := core.ScheduledTaskEnabled(path); err != nil {
+			continue
+		}
+		if err := core.SetScheduledTaskEnabled(path, false); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", taskBaseName(path), err))
+		}
+	}
+	for _, v := range s.values {
+		v.Exists = true
+		if err := setRegistryValue(v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,377 @@
+package optimize
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Process Rules ───────────────────────────────────────────────────────────
+// Persistent per-application priority and CPU affinity rules, e.g. "run OBS
+// at High priority" or "keep OneDrive on 2 cores". Rules are stored as JSON
+// in the config directory and applied by a RuleEnforcer each time a matching
+// process is seen for the first time.
+
+// ProcessRulesFileName is the rule file inside the config directory.
+const ProcessRulesFileName = "process_rules.json"
+
+var (
+	modKernel32                = syscall.NewLazyDLL("kernel32.dll")
+	procGetProcessAffinityMask = modKernel32.NewProc("GetProcessAffinityMask")
+	procSetProcessAffinityMask = modKernel32.NewProc("SetProcessAffinityMask")
+	priorityClassByName        = map[string]uint32{
+		"idle":         windows.IDLE_PRIORITY_CLASS,
+		"below-normal": windows.BELOW_NORMAL_PRIORITY_CLASS,
+		"normal":       windows.NORMAL_PRIORITY_CLASS,
+		"above-normal": windows.ABOVE_NORMAL_PRIORITY_CLASS,
+		"high":         windows.HIGH_PRIORITY_CLASS,
+	}
+)
+
+// PriorityNames lists the accepted priority names, lowest first. Realtime is
+// deliberately absent: it can starve the input and disk stacks.
+var PriorityNames = []string{"idle", "below-normal", "normal", "above-normal", "high"}
+
+// ProcessRule is a persistent priority/affinity setting for one executable.
+type ProcessRule struct {
+	// Process is the executable name, e.g. "obs64.exe". Wildcards (* ?) are
+	// allowed; matching is case-insensitive.
+	Process string `json:"process"`
+
+	// Priority is one of PriorityNames, or empty to leave it unchanged.
+	Priority string `json:"priority,omitempty"`
+
+	// Cores limits the process to this many logical processors (the
+	// highest-numbered ones, away from the interrupt-heavy CPU 0). Zero
+	// leaves affinity unchanged unless CPUs is set.
+	Cores int `json:"cores,omitempty"`
+
+	// CPUs pins the process to these logical processor indexes. Takes
+	// precedence over Cores.
+	CPUs []int `json:"cpus,omitempty"`
+}
+
+// Validate checks that the rule names a process and changes something.
+func (r ProcessRule) Validate() error {
+	if strings.TrimSpace(r.Process) == "" {
+		return fmt.Errorf("rule has no process name")
+	}
+	if _, err := filepath.Match(strings.ToLower(r.Process), ""); err != nil {
+		return fmt.Errorf("invalid process pattern %q: %w", r.Process, err)
+	}
+	if r.Priority != "" {
+		if _, ok := priorityClassByName[r.Priority]; !ok {
+			return fmt.Errorf("unknown priority %q (use %s)", r.Priority, strings.Join(PriorityNames, ", "))
+		}
+	}
+	if r.Cores < 0 {
+		return fmt.Errorf("cores must be positive")
+	}
+	for _, cpu := range r.CPUs {
+		if cpu < 0 || cpu >= 64 {
+			return fmt.Errorf("cpu index %d out of range", cpu)
+		}
+	}
+	if r.Priority == "" && r.Cores == 0 && len(r.CPUs) == 0 {
+		return fmt.Errorf("rule for %s sets neither priority nor affinity", r.Process)
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to an executable name.
+func (r ProcessRule) Matches(exe string) bool {
+	pattern := strings.ToLower(r.Process)
+	name := strings.ToLower(exe)
+	if !strings.ContainsAny(pattern, "*?.") {
+		pattern += ".exe"
+	}
+	ok, _ := filepath.Match(pattern, name)
+	return ok
+}
+
+// Summary describes what the rule does, e.g. "high priority, 2 cores".
+func (r ProcessRule) Summary() string {
+	var parts []string
+	if r.Priority != "" {
+		parts = append(parts, r.Priority+" priority")
+	}
+	switch {
+	case len(r.CPUs) > 0:
+		cpus := make([]string, len(r.CPUs))
+		for i, c := range r.CPUs {
+			cpus[i] = fmt.Sprint(c)
+		}
+		parts = append(parts, "CPUs "+strings.Join(cpus, ","))
+	case r.Cores == 1:
+		parts = append(parts, "1 core")
+	case r.Cores > 1:
+		parts = append(parts, fmt.Sprintf("%d cores", r.Cores))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ─── Persistence ─────────────────────────────────────────────────────────────
+
+// LoadProcessRules reads the rule file. A missing file yields no rules.
+func LoadProcessRules(path string) ([]ProcessRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read process rules %s: %w", path, err)
+	}
+	var rules []ProcessRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse process rules %s: %w", path, err)
+	}
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return rules, nil
+}
+
+// SaveProcessRules writes the rule file, sorted by process name.
+func SaveProcessRules(path string, rules []ProcessRule) error {
+	sort.Slice(rules, func(i, j int) bool {
+		return strings.ToLower(rules[i].Process) < strings.ToLower(rules[j].Process)
+	})
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// ─── Enforcement ─────────────────────────────────────────────────────────────
+
+// RuleApplication records one rule applied to one process.
+type RuleApplication struct {
+	PID     uint32
+	Process string
+	Rule    ProcessRule
+	Err     error
+}
+
+// RuleEnforcer applies rules to processes the first time they are seen, so a
+// user who later changes a process's priority by hand is not overridden on
+// every poll. It is safe for concurrent use.
+type RuleEnforcer struct {
+	// DryRun reports matching processes without changing them.
+	DryRun bool
+
+	mu    sync.Mutex
+	rules []ProcessRule
+	seen  map[uint32]string // PID → exe name, pruned when processes exit
+}
+
+// NewRuleEnforcer creates an enforcer for rules.
+func NewRuleEnforcer(rules []ProcessRule) *RuleEnforcer {
+	return &RuleEnforcer{rules: rules, seen: make(map[uint32]string)}
+}
+
+// Enforce scans running processes and applies matching rules to any that
+// started since the previous call. Errors are reported per application.
+func (e *RuleEnforcer) Enforce() []RuleApplication {
+	if e == nil || len(e.rules) == 0 {
+		return nil
+	}
+	procs, err := listProcesses()
+	if err != nil {
+		return []RuleApplication{{Err: err}}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	alive := make(map[uint32]bool, len(procs))
+	var applied []RuleApplication
+	for _, p := range procs {
+		alive[p.pid] = true
+		// A recycled PID with a different image counts as a new process.
+		if name, ok := e.seen[p.pid]; ok && name == p.name {
+			continue
+		}
+		e.seen[p.pid] = p.name
+
+		for _, rule := range e.rules {
+			if rule.Matches(p.name) {
+				app := RuleApplication{PID: p.pid, Process: p.name, Rule: rule}
+				if !e.DryRun {
+					app.Err = applyProcessRule(p.pid, rule)
+				}
+				applied = append(applied, app)
+				break
+			}
+		}
+	}
+
+	for pid := range e.seen {
+		if !alive[pid] {
+			delete(e.seen, pid)
+		}
+	}
+	return applied
+}
+
+// processEntry is a running process from the toolhelp snapshot.
+type processEntry struct {
+	pid  uint32
+	name string
+}
+
+// listProcesses enumerates running processes.
+func listProcesses() ([]processEntry, error) {
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot snapshot processes: %w", err)
+	}
+	defer windows.CloseHandle(snap)
+
+	var procs []processEntry
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	for err = windows.Process32First(snap, &entry); err == nil; err = windows.Process32Next(snap, &entry) {
+		procs = append(procs, processEntry{
+			pid:  entry.ProcessID,
+			name: windows.UTF16ToString(entry.ExeFile[:]),
+		})
+	}
+	return procs, nil
+}
+
+// applyProcessRule sets the priority class and affinity of one process.
+func applyProcessRule(pid uint32, rule ProcessRule) error {
+	h, err := windows.OpenProcess(windows.PROCESS_SET_INFORMATION|windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return fmt.Errorf("cannot open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h)
+
+	if rule.Priority != "" {
+		if err := windows.SetPriorityClass(h, priorityClassByName[rule.Priority]); err != nil {
+			return fmt.Errorf("cannot set priority: %w", err)
+		}
+	}
+
+	if rule.Cores == 0 && len(rule.CPUs) == 0 {
+		return nil
+	}
+	var processMask, systemMask uintptr
+	ret, _, callErr := procGetProcessAffinityMask.Call(uintptr(h),
+		uintptr(unsafe.Pointer(&processMask)), uintptr(unsafe.Pointer(&systemMask)))
+	if ret == 0 {
+		return fmt.Errorf("cannot read affinity: %w", callErr)
+	}
+	mask := affinityMask(rule, uint64(systemMask))
+	if mask == 0 {
+		return fmt.Errorf("rule selects no available CPUs")
+	}
+	ret, _, callErr = procSetProcessAffinityMask.Call(uintptr(h), uintptr(mask))
+	if ret == 0 {
+		return fmt.Errorf("cannot set affinity: %w", callErr)
+	}
+	return nil
+}
+
+// affinityMask computes the mask for rule within the system's available
+// processors. Cores picks the highest-numbered available processors.
+func affinityMask(rule ProcessRule, system uint64) uint64 {
+	if len(rule.CPUs) > 0 {
+		var mask uint64
+		for _, cpu := range rule.CPUs {
+			mask |= 1 << uint(cpu)
+		}
+		return mask & system
+	}
+	if rule.Cores >= bits.OnesCount64(system) {
+		return system
+	}
+	var mask uint64
+	for i := 63; i >= 0 && bits.OnesCount64(mask) < rule.Cores; i-- {
+		if system&(1<<uint(i)) != 0 {
+			mask |= 1 << uint(i)
+		}
+	}
+	return mask
+}
+
+// SetProcessPriority sets the priority class of one running process, as a
+// one-off change rather than a rule. priority is one of PriorityNames.
+func SetProcessPriority(pid uint32, priority string) error {
+	class, ok := priorityClassByName[priority]
+	if !ok {
+		return fmt.Errorf("unknown priority %q (use %s)", priority, strings.Join(PriorityNames, ", "))
+	}
+	h, err := windows.OpenProcess(windows.PROCESS_SET_INFORMATION, false, pid)
+	if err != nil {
+		return fmt.Errorf("cannot open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h)
+	if err := windows.SetPriorityClass(h, class); err != nil {
+		return fmt.Errorf("cannot set priority: %w", err)
+	}
+	return nil
+}
+
+// PriorityName returns the name of a priority class: one of PriorityNames,
+// "realtime", or "" when unknown.
+func PriorityName(class uint32) string {
+	if class == windows.REALTIME_PRIORITY_CLASS {
+		return "realtime"
+	}
+	for name, c := range priorityClassByName {
+		if c == class {
+			return name
+		}
+	}
+	return ""
+}
+
+// ProcessAffinity returns the CPU affinity mask of one running process and
+// the mask of processors available to it.
+func ProcessAffinity(pid uint32) (process, system uint64, err error) {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h)
+	var processMask, systemMask uintptr
+	ret, _, callErr := procGetProcessAffinityMask.Call(uintptr(h),
+		uintptr(unsafe.Pointer(&processMask)), uintptr(unsafe.Pointer(&systemMask)))
+	if ret == 0 {
+		return 0, 0, fmt.Errorf("cannot read affinity: %w", callErr)
+	}
+	return uint64(processMask), uint64(systemMask), nil
+}
+
+// SetProcessAffinity limits one running process to the processors in mask.
+func SetProcessAffinity(pid uint32, mask uint64) error {
+	if mask == 0 {
+		return fmt.Errorf("select at least one CPU")
+	}
+	h, err := windows.OpenProcess(windows.PROCESS_SET_INFORMATION, false, pid)
+	if err != nil {
+		return fmt.Errorf("cannot open process %d: %w", pid, err)
+	}
+	defer windows.CloseHandle(h)
+	ret, _, callErr := procSetProcessAffinityMask.Call(uintptr(h), uintptr(mask))
+	if ret == 0 {
+		return fmt.Errorf("cannot set affinity: %w", callErr)
+	}
+	return nil
+}
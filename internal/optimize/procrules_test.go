@@ -0,0 +1,55 @@
+package optimize
+
+import "testing"
+
+func TestAffinityMask(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   ProcessRule
+		system uint64
+		want   uint64
+	}{
+		{"cpus pinned", ProcessRule{CPUs: []int{0, 2}}, 0xFF, 0x05},
+		{"cpus outside system dropped", ProcessRule{CPUs: []int{1, 9}}, 0xFF, 0x02},
+		{"cpus all outside system", ProcessRule{CPUs: []int{8, 9}}, 0xFF, 0},
+		{"cpus win over cores", ProcessRule{CPUs: []int{3}, Cores: 4}, 0xFF, 0x08},
+		{"highest cores first", ProcessRule{Cores: 2}, 0xFF, 0xC0},
+		{"cores skip missing cpus", ProcessRule{Cores: 2}, 0x0B, 0x0A},
+		{"cores equal to system", ProcessRule{Cores: 8}, 0xFF, 0xFF},
+		{"cores beyond system", ProcessRule{Cores: 16}, 0x0F, 0x0F},
+		{"top of 64-bit mask", ProcessRule{Cores: 1}, ^uint64(0), 1 << 63},
+	}
+	for _, tt := range tests {
+		if got := affinityMask(tt.rule, tt.system); got != tt.want {
+			t.Errorf("%s: affinityMask(%+v, %#x) = %#x, want %#x", tt.name, tt.rule, tt.system, got, tt.want)
+		}
+	}
+}
+
+func TestProcessRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		exe     string
+		want    bool
+	}{
+		{"obs64", "obs64.exe", true},
+		{"obs64", "OBS64.EXE", true},
+		{"OBS64.exe", "obs64.exe", true},
+		{"obs64", "obs64", false},
+		{"obs64", "obs64-helper.exe", false},
+		{"obs*", "obs64.exe", true},
+		{"obs*", "obs32.exe", true},
+		{"obs*", "notobs.exe", false},
+		{"*.exe", "anything.exe", true},
+		{"*", "onedrive.exe", true},
+		{"obs??.exe", "obs64.exe", true},
+		{"obs?.exe", "obs64.exe", false},
+		{"chrome.exe", "chrome.exe.bak", false},
+	}
+	for _, tt := range tests {
+		r := ProcessRule{Process: tt.pattern}
+		if got := r.Matches(tt.exe); got != tt.want {
+			t.Errorf("ProcessRule{%q}.Matches(%q) = %v, want %v", tt.pattern, tt.exe, got, tt.want)
+		}
+	}
+}
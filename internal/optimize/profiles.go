@@ -0,0 +1,159 @@
+package optimize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ─── Service Profiles ────────────────────────────────────────────────────────
+// Curated startup-type settings for a vetted list of services. Every entry
+// is safe to turn off on a typical PC for the stated use; nothing that
+// networking, updates, audio, printing or sign-in depends on is listed.
+// A snapshot is saved before a profile is applied, so 'pw optimize
+// restore' reverts it.
+
+// ServiceSetting is the startup type a profile gives one service.
+type ServiceSetting struct {
+	Name      string `json:"name"`
+	StartType string `json:"start_type"` // auto, manual or disabled
+	Reason    string `json:"reason"`
+}
+
+// ServiceProfile is a named set of service settings.
+type ServiceProfile struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Services    []ServiceSetting `json:"services"`
+}
+
+// telemetryServices turn off diagnostic data collection; shared by every
+// profile.
+var telemetryServices = []ServiceSetting{
+	{"DiagTrack", "disabled", "Connected User Experiences and Telemetry"},
+	{"dmwappushservice", "disabled", "Routes WAP push messages for telemetry"},
+	{"diagnosticshub.standardcollector.service", "manual", "Diagnostics Hub collector; starts when a profiler needs it"},
+	{"WerSvc", "manual", "Error reporting; starts when an app crashes"},
+}
+
+// unusedServices are features most PCs never use.
+var unusedServices = []ServiceSetting{
+	{"Fax", "disabled", "Fax"},
+	{"RetailDemo", "disabled", "Store demo mode"},
+	{"MapsBroker", "manual", "Offline maps updates"},
+	{"WMPNetworkSvc", "disabled", "Windows Media Player library sharing"},
+}
+
+// serviceProfiles are the built-in profiles.
+var serviceProfiles = []ServiceProfile{
+	{
+		Name:        "gaming",
+		Description: "Fewer background services competing with games for disk and CPU",
+		Services: concatSettings(telemetryServices, unusedServices, []ServiceSetting{
+			{"SysMain", "disabled", "Superfetch preloading causes disk activity during play"},
+			{"WSearch", "manual", "Search indexing in the background"},
+			{"TabletInputService", "manual", "Touch keyboard and handwriting; starts on demand"},
+		}),
+	},
+	{
+		Name:        "developer",
+		Description: "Less indexing and scanning of large source trees and build output",
+		Services: concatSettings(telemetryServices, unusedServices, []ServiceSetting{
+			{"WSearch", "manual", "Indexing churns on source trees and build output"},
+			{"XblAuthManager", "manual", "Xbox Live sign-in; starts on demand"},
+			{"XblGameSave", "manual", "Xbox cloud saves; starts on demand"},
+			{"XboxNetApiSvc", "manual", "Xbox Live networking; starts on demand"},
+		}),
+	},
+	{
+		Name:        "minimal-telemetry",
+		Description: "Turn off diagnostic data collection and nothing else",
+		Services:    telemetryServices,
+	},
+}
+
+// concatSettings joins setting lists into a new slice.
+func concatSettings(lists ...[]ServiceSetting) []ServiceSetting {
+	var out []ServiceSetting
+	for _, l := range lists {
+		out = append(out, l...)
+	}
+	return out
+}
+
+// ServiceProfiles returns the built-in service profiles.
+func ServiceProfiles() []ServiceProfile {
+	return serviceProfiles
+}
+
+// FindServiceProfile returns the profile with the given name.
+func FindServiceProfile(name string) (ServiceProfile, error) {
+	var names []string
+	for _, p := range serviceProfiles {
+		if strings.EqualFold(p.Name, name) {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return ServiceProfile{}, fmt.Errorf("unknown profile %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// profileServiceNames returns every service any profile touches, so
+// snapshots capture what a profile may change.
+func profileServiceNames() []string {
+	var names []string
+	for _, p := range serviceProfiles {
+		for _, s := range p.Services {
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
+
+// ─── Plan and Apply ──────────────────────────────────────────────────────────
+
+// ProfileChange is one service whose startup type a profile changes.
+type ProfileChange struct {
+	Service string `json:"service"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Reason  string `json:"reason"`
+}
+
+// PlanProfile compares a profile with the current services and returns the
+// changes it would make. Services that are not installed or already set
+// are left out.
+func PlanProfile(p ServiceProfile) []ProfileChange {
+	var changes []ProfileChange
+	for _, s := range p.Services {
+		st, err := readServiceState(s.Name)
+		if err != nil {
+			continue // not installed
+		}
+		if st.StartType == s.StartType && !st.Delayed {
+			continue
+		}
+		changes = append(changes, ProfileChange{
+			Service: s.Name,
+			From:    describeStartType(st),
+			To:      s.StartType,
+			Reason:  s.Reason,
+		})
+	}
+	return changes
+}
+
+// ApplyProfileChange sets the service's startup type through the service
+// control manager. Running services keep running until the next restart.
+func ApplyProfileChange(c ProfileChange) error {
+	return setServiceStartType(ServiceState{Name: c.Service, StartType: c.To})
+}
+
+// ServiceStartType returns a service's current startup type as shown in a
+// profile diff.
+func ServiceStartType(name string) (string, error) {
+	st, err := readServiceState(name)
+	if err != nil {
+		return "", err
+	}
+	return describeStartType(st), nil
+}
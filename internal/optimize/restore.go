@@ -0,0 +1,356 @@
+package optimize
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// serviceStateTimeout bounds how long restore waits for a service to reach
+// its target run state.
+const serviceStateTimeout = 30 * time.Second
+
+// ─── Diff ────────────────────────────────────────────────────────────────────
+
+// SnapshotChange is a single difference between a snapshot and the current
+// machine state, together with the action that reverts it.
+type SnapshotChange struct {
+	Kind   string // "service", "registry", "startup", "power", "task"
+	Target string // Human-readable item name.
+	From   string // Current state.
+	To     string // State recorded in the snapshot.
+
+	apply func() error
+}
+
+// Apply reverts the change to the snapshot state.
+func (c SnapshotChange) Apply() error {
+	if c.apply == nil {
+		return fmt.Errorf("no restore action for %s", c.Target)
+	}
+	return c.apply()
+}
+
+// DiffSnapshot compares a saved snapshot against the current state and
+// returns the changes needed to restore it. Startup entries are restored
+// only when owned holds their ID (see JournaledStartup): a snapshot file is
+// user-writable, and re-creating any Run entry it lists would run whatever
+// command it names at logon.
+func DiffSnapshot(saved, current *Snapshot, owned map[string]bool) []SnapshotChange {
+	var changes []SnapshotChange
+	changes = append(changes, diffServices(saved.Services, current.Services)...)
+	changes = append(changes, diffRegistry(saved.Registry, current.Registry)...)
+	changes = append(changes, diffStartup(saved.Startup, current.Startup, owned)...)
+	changes = append(changes, diffTasks(saved.Tasks, current.Tasks)...)
+
+	if saved.PowerPlan != "" && !strings.EqualFold(saved.PowerPlan, current.PowerPlan) {
+		guid := saved.PowerPlan
+		changes = append(changes, SnapshotChange{
+			Kind:   "power",
+			Target: "Active power plan",
+			From:   current.PowerPlan,
+			To:     guid,
+			apply:  func() error { return setActivePowerPlan(guid) },
+		})
+	}
+	return changes
+}
+
+func diffServices(saved, current []ServiceState) []SnapshotChange {
+	now := make(map[string]ServiceState, len(current))
+	for _, s := range current {
+		now[strings.ToLower(s.Name)] = s
+	}
+
+	var changes []SnapshotChange
+	for _, want := range saved {
+		have, ok := now[strings.ToLower(want.Name)]
+		if !ok {
+			continue // Service no longer installed.
+		}
+		if have.StartType != want.StartType || have.Delayed != want.Delayed {
+			target := want
+			changes = append(changes, SnapshotChange{
+				Kind:   "service",
+				Target: want.Name + " startup",
+				From:   describeStartType(have),
+				To:     describeStartType(want),
+				apply:  func() error { return setServiceStartType(target) },
+			})
+		}
+		if have.Running != want.Running {
+			target := want
+			changes = append(changes, SnapshotChange{
+				Kind:   "service",
+				Target: want.Name,
+				From:   describeRunning(have.Running),
+				To:     describeRunning(want.Running),
+				apply:  func() error { return setServiceRunning(target.Name, target.Running) },
+			})
+		}
+	}
+	return changes
+}
+
+// diffRegistry only restores the values PureWin tracks; anything else in a
+// hand-edited or corrupted snapshot is left out (see UntrackedRegistryValues).
+func diffRegistry(saved, current []RegistryValue) []SnapshotChange {
+	now := make(map[string]RegistryValue, len(current))
+	for _, v := range current {
+		now[registryValueID(v)] = v
+	}
+	tracked := trackedRegistryIDs()
+
+	var changes []SnapshotChange
+	for _, want := range saved {
+		if !tracked[registryValueID(want)] {
+			continue
+		}
+		have := now[registryValueID(want)]
+		if have.Exists == want.Exists && (!want.Exists || have.Value == want.Value) {
+			continue
+		}
+		target := want
+		changes = append(changes, SnapshotChange{
+			Kind:   "registry",
+			Target: want.Root + `\` + want.Path + `\` + want.Name,
+			From:   describeRegistryValue(have),
+			To:     describeRegistryValue(want),
+			apply:  func() error { return setRegistryValue(target) },
+		})
+	}
+	return changes
+}
+
+// UntrackedRegistryValues returns the registry values in a snapshot that
+// PureWin does not track. Restore skips them, since writing arbitrary values
+// from a snapshot file would let anyone who can edit it change the registry.
+func UntrackedRegistryValues(saved *Snapshot) []RegistryValue {
+	tracked := trackedRegistryIDs()
+	var untracked []RegistryValue
+	for _, v := range saved.Registry {
+		if !tracked[registryValueID(v)] {
+			untracked = append(untracked, v)
+		}
+	}
+	return untracked
+}
+
+// trackedRegistryIDs returns the IDs of every value snapshots capture.
+func trackedRegistryIDs() map[string]bool {
+	values := snapshotRegistryValues()
+	ids := make(map[string]bool, len(values))
+	for _, v := range values {
+		ids[registryValueID(v)] = true
+	}
+	return ids
+}
+
+// diffStartup restores the owned entries to their snapshot state. Entries
+// added since the snapshot are left alone: disabling them would undo the
+// user's installs, not PureWin's changes.
+func diffStartup(saved, current []StartupItemState, owned map[string]bool) []SnapshotChange {
+	now := make(map[string]StartupItemState, len(current))
+	for _, s := range current {
+		now[startupID(s)] = s
+	}
+
+	var changes []SnapshotChange
+	for _, want := range saved {
+		id := startupID(want)
+		if !owned[id] {
+			continue
+		}
+		have, ok := now[id]
+		switch {
+		case !ok:
+			target := want
+			changes = append(changes, SnapshotChange{
+				Kind:   "startup",
+				Target: want.Name,
+				From:   "missing",
+				To:     "restored (" + describeEnabled(want.Enabled) + "): " + want.Command,
+				apply:  func() error { return restoreStartupEntry(target) },
+			})
+		case have.Enabled != want.Enabled:
+			target := want
+			changes = append(changes, SnapshotChange{
+				Kind:   "startup",
+				Target: want.Name,
+				From:   describeEnabled(have.Enabled),
+				To:     describeEnabled(want.Enabled),
+				apply:  func() error { return toggleStartupState(target, target.Enabled) },
+			})
+		}
+	}
+	return changes
+}
+
+func diffTasks(saved, current []TaskState) []SnapshotChange {
+	now := make(map[string]TaskState, len(current))
+	for _, t := range current {
+		now[strings.ToLower(t.Path)] = t
+	}
+
+	var changes []SnapshotChange
+	for _, want := range saved {
+		have, ok := now[strings.ToLower(want.Path)]
+		if !ok || have.Enabled == want.Enabled {
+			continue // Task removed, or unchanged.
+		}
+		target := want
+		changes = append(changes, SnapshotChange{
+			Kind:   "task",
+			Target: taskBaseName(want.Path),
+			From:   describeEnabled(have.Enabled),
+			To:     describeEnabled(want.Enabled),
+			apply:  func() error { return core.SetScheduledTaskEnabled(target.Path, target.Enabled) },
+		})
+	}
+	return changes
+}
+
+// ─── Descriptions ────────────────────────────────────────────────────────────
+
+func describeStartType(s ServiceState) string {
+	if s.StartType == "auto" && s.Delayed {
+		return "auto (delayed)"
+	}
+	return s.StartType
+}
+
+func describeRunning(running bool) string {
+	if running {
+		return "running"
+	}
+	return "stopped"
+}
+
+func describeEnabled(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func describeRegistryValue(v RegistryValue) string {
+	if !v.Exists {
+		return "(not set)"
+	}
+	return fmt.Sprintf("%d", v.Value)
+}
+
+func registryValueID(v RegistryValue) string {
+	return strings.ToLower(v.Root + `\` + v.Path + `\` + v.Name)
+}
+
+func startupID(s StartupItemState) string {
+	return strings.ToLower(s.Location + `\` + s.Name)
+}
+
+// ─── Restore Actions ─────────────────────────────────────────────────────────
+
+// setServiceStartType restores a service's start type and delayed flag.
+func setServiceStartType(want ServiceState) error {
+	startType, ok := startTypeFromName(want.StartType)
+	if !ok {
+		return fmt.Errorf("unknown start type %q for %s", want.StartType, want.Name)
+	}
+
+	s, err := openService(want.Name, windows.SERVICE_QUERY_CONFIG|windows.SERVICE_CHANGE_CONFIG)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	cfg, err := s.Config()
+	if err != nil {
+		return fmt.Errorf("cannot read config for %s: %w", want.Name, err)
+	}
+	cfg.StartType = startType
+	cfg.DelayedAutoStart = want.Delayed
+	if err := s.UpdateConfig(cfg); err != nil {
+		return fmt.Errorf("cannot update %s: %w", want.Name, err)
+	}
+	return nil
+}
+
+// setServiceRunning starts or stops a service and waits for the
+// transition. Stopping also stops the services that depend on it.
+func setServiceRunning(name string, running bool) error {
+	if running {
+		return core.StartService(name, serviceStateTimeout)
+	}
+	_, err := core.StopService(name, serviceStateTimeout)
+	return err
+}
+
+// setRegistryValue writes or deletes a tracked DWORD tweak.
+func setRegistryValue(want RegistryValue) error {
+	root, err := registryRoot(want.Root)
+	if err != nil {
+		return err
+	}
+
+	if !want.Exists {
+		key, err := registry.OpenKey(root, want.Path, registry.SET_VALUE)
+		if err != nil {
+			return nil // Key gone means the value is gone too.
+		}
+		defer key.Close()
+		if err := key.DeleteValue(want.Name); err != nil && err != registry.ErrNotExist {
+			return fmt.Errorf("cannot delete %s: %w", want.Name, err)
+		}
+		return nil
+	}
+
+	key, _, err := registry.CreateKey(root, want.Path, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("cannot open %s\\%s: %w", want.Root, want.Path, err)
+	}
+	defer key.Close()
+	if err := key.SetDWordValue(want.Name, uint32(want.Value)); err != nil {
+		return fmt.Errorf("cannot set %s: %w", want.Name, err)
+	}
+	return nil
+}
+
+// toggleStartupState enables or disables a snapshot startup entry.
+func toggleStartupState(s StartupItemState, enable bool) error {
+	return ToggleStartupItem(StartupItem{
+		Name:     s.Name,
+		Command:  s.Command,
+		Location: s.Location,
+		Source:   "Registry",
+	}, enable)
+}
+
+// restoreStartupEntry re-creates a removed Run value and sets its state.
+func restoreStartupEntry(s StartupItemState) error {
+	for _, src := range startupSources {
+		if src.label != s.Location {
+			continue
+		}
+		key, _, err := registry.CreateKey(src.root, src.path, registry.SET_VALUE)
+		if err != nil {
+			return fmt.Errorf("cannot open Run key: %w", err)
+		}
+		err = key.SetStringValue(s.Name, s.Command)
+		key.Close()
+		if err != nil {
+			return fmt.Errorf("cannot restore %s: %w", s.Name, err)
+		}
+		return toggleStartupState(s, s.Enabled)
+	}
+	return fmt.Errorf("startup item location %q not recognized", s.Location)
+}
+
+// setActivePowerPlan activates the power scheme with the given GUID.
+func setActivePowerPlan(guid string) error {
+	return SetActivePowerPlan(guid)
+}
@@ -0,0 +1,53 @@
+package optimize
+
+import "testing"
+
+func TestDiffRegistry(t *testing.T) {
+	menu := trackedRegistryValues[0]
+	dvr := trackedRegistryValues[2]
+	priority := trackedRegistryValues[3]
+
+	saved := []RegistryValue{
+		{Root: menu.Root, Path: menu.Path, Name: menu.Name, Exists: true, Value: 400},
+		{Root: dvr.Root, Path: dvr.Path, Name: dvr.Name, Exists: true, Value: 1},
+		{Root: priority.Root, Path: priority.Path, Name: priority.Name, Exists: true, Value: 2},
+		{Root: "HKLM", Path: `SOFTWARE\Microsoft\Windows\CurrentVersion\Run`, Name: "evil", Exists: true, Value: 1},
+	}
+	current := []RegistryValue{
+		{Root: menu.Root, Path: menu.Path, Name: menu.Name, Exists: true, Value: 0}, // Changed.
+		{Root: dvr.Root, Path: dvr.Path, Name: dvr.Name, Exists: true, Value: 1},    // Unchanged.
+		// priority is missing now.
+	}
+
+	changes := diffRegistry(saved, current)
+	if len(changes) != 2 {
+		t.Fatalf("diffRegistry returned %d changes, want 2: %+v", len(changes), changes)
+	}
+	if c := changes[0]; c.Target != menu.Root+`\`+menu.Path+`\`+menu.Name || c.From != "0" || c.To != "400" {
+		t.Errorf("changed value = %+v, want %s 0 → 400", c, menu.Name)
+	}
+	if c := changes[1]; c.Target != priority.Root+`\`+priority.Path+`\`+priority.Name || c.From != "(not set)" || c.To != "2" {
+		t.Errorf("missing value = %+v, want %s (not set) → 2", c, priority.Name)
+	}
+
+	untracked := UntrackedRegistryValues(&Snapshot{Registry: saved})
+	if len(untracked) != 1 || untracked[0].Name != "evil" {
+		t.Errorf("UntrackedRegistryValues = %+v, want only the untracked Run value", untracked)
+	}
+}
+
+func TestDiffStartup(t *testing.T) {
+	ours := StartupItemState{Name: "Ours", Command: `C:\Apps\ours.exe`, Location: "HKCU Run", Enabled: true}
+	foreign := StartupItemState{Name: "Foreign", Command: `C:\evil.exe`, Location: "HKLM Run", Enabled: true}
+	added := StartupItemState{Name: "Added", Command: `C:\Apps\new.exe`, Location: "HKCU Run", Enabled: true}
+	owned := map[string]bool{startupID(ours): true}
+
+	// Both snapshot entries are gone; one was installed since.
+	changes := diffStartup([]StartupItemState{ours, foreign}, []StartupItemState{added}, owned)
+	if len(changes) != 1 {
+		t.Fatalf("diffStartup returned %d changes, want 1: %+v", len(changes), changes)
+	}
+	if c := changes[0]; c.Target != "Ours" || c.To != `restored (enabled): C:\Apps\ours.exe` {
+		t.Errorf("change = %+v, want Ours restored with its command shown", c)
+	}
+}
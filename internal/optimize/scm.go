@@ -0,0 +1,35 @@
+package optimize
+
+import (
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Service Control Manager ─────────────────────────────────────────────────
+
+// serviceStartTypeNames maps SCM start types to the names used in snapshots.
+var serviceStartTypeNames = map[uint32]string{
+	windows.SERVICE_BOOT_START:   "boot",
+	windows.SERVICE_SYSTEM_START: "system",
+	windows.SERVICE_AUTO_START:   "auto",
+	windows.SERVICE_DEMAND_START: "manual",
+	windows.SERVICE_DISABLED:     "disabled",
+}
+
+// startTypeFromName is the inverse of serviceStartTypeNames.
+func startTypeFromName(name string) (uint32, bool) {
+	for k, v := range serviceStartTypeNames {
+		if v == name {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// openService opens a service with the requested access rights. The
+// caller must Close the returned service.
+func openService(name string, access uint32) (*mgr.Service, error) {
+	return core.OpenService(name, access)
+}
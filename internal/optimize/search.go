@@ -0,0 +1,374 @@
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Windows Search Index ────────────────────────────────────────────────────
+// `pw optimize search` rebuilds the Windows Search index, keeps folders out
+// of it, and turns indexing off for secondary drives. Exclusions use the
+// "Prevent indexing certain paths" policy, which the indexer reads when it
+// starts, so changes are followed by a WSearch restart.
+
+const (
+	// searchServiceName is the Windows Search indexer service.
+	searchServiceName = "WSearch"
+
+	// searchTimeout bounds stopping and starting WSearch, which can take a
+	// while to flush a large index.
+	searchTimeout = 2 * time.Minute
+
+	searchKeyPath       = `SOFTWARE\Microsoft\Windows Search`
+	searchExclusionPath = `SOFTWARE\Policies\Microsoft\Windows\Windows Search\PreventIndexingCertainPaths`
+
+	// searchRulePrefix and searchRuleSuffix wrap a folder into a policy rule
+	// covering the folder and everything below it.
+	searchRulePrefix = "file:///"
+	searchRuleSuffix = `\*`
+)
+
+// SearchIndexStatus describes the Windows Search index.
+type SearchIndexStatus struct {
+	Service       string          `json:"service"` // e.g. "RUNNING", or "NOT INSTALLED"
+	DataDirectory string          `json:"data_directory"`
+	IndexSize     int64           `json:"index_size"`
+	Excluded      []string        `json:"excluded"`
+	Drives        []DriveIndexing `json:"drives"`
+}
+
+// DriveIndexing is whether a fixed drive allows its contents to be indexed.
+type DriveIndexing struct {
+	Drive   string `json:"drive"` // e.g. "D:"
+	System  bool   `json:"system"`
+	Indexed bool   `json:"indexed"`
+}
+
+// GetSearchIndexStatus reports the indexer's state, where the index lives
+// and how large it is, the excluded folders, and per-drive indexing.
+func GetSearchIndexStatus() (SearchIndexStatus, error) {
+	st := SearchIndexStatus{Service: "NOT INSTALLED"}
+	if state, err := core.ServiceState(searchServiceName); err == nil {
+		st.Service = state
+	}
+
+	if key, err := registry.OpenKey(registry.LOCAL_MACHINE, searchKeyPath, registry.QUERY_VALUE); err == nil {
+		if dir, _, vErr := key.GetStringValue("DataDirectory"); vErr == nil {
+			if expanded, xErr := registry.ExpandString(dir); xErr == nil {
+				dir = expanded
+			}
+			st.DataDirectory = filepath.Clean(dir)
+		}
+		key.Close()
+	}
+	if st.DataDirectory != "" {
+		st.IndexSize, _ = core.GetDirSize(st.DataDirectory)
+	}
+
+	excluded, err := ListSearchExclusions()
+	if err != nil {
+		return st, err
+	}
+	st.Excluded = excluded
+
+	drives, err := listDriveIndexing()
+	if err != nil {
+		return st, err
+	}
+	st.Drives = drives
+	return st, nil
+}
+
+// ─── Rebuild ─────────────────────────────────────────────────────────────────
+
+// ResetSearchIndex deletes the Windows Search index and has the indexer
+// build it again from scratch. WSearch is stopped with its dependents, the
+// index is flagged as not set up, and the services are started again; the
+// indexer then recreates the catalog and re-crawls in the background.
+// progress, if non-nil, receives a description of each step.
+func ResetSearchIndex(progress func(step string)) error {
+	if err := core.RequireAdmin("rebuild the search index"); err != nil {
+		return err
+	}
+	report := func(step string) {
+		if progress != nil {
+			progress(step)
+		}
+	}
+
+	report("Stopping Windows Search")
+	dependents, err := core.StopService(searchServiceName, searchTimeout)
+	if err != nil {
+		return err
+	}
+
+	report("Marking the index for rebuild")
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, searchKeyPath, registry.SET_VALUE)
+	if err == nil {
+		err = key.SetDWordValue("SetupCompletedSuccessfully", 0)
+		key.Close()
+	}
+	if err != nil {
+		// Leave the indexer running with its old index.
+		_ = startSearchServices(dependents)
+		return fmt.Errorf("cannot mark the search index for rebuild: %w", err)
+	}
+
+	report("Starting Windows Search")
+	return startSearchServices(dependents)
+}
+
+// ResetSearchIndexChanges describes what ResetSearchIndex changes.
+func ResetSearchIndexChanges() []Change {
+	return []Change{
+		ServiceChange(searchServiceName, "stopped with its dependents"),
+		{Kind: "registry", Target: `HKLM\` + searchKeyPath + `\SetupCompletedSuccessfully`, To: "0"},
+		ServiceChange(searchServiceName, "started with its dependents"),
+	}
+}
+
+// startSearchServices starts WSearch and then the dependents it stopped.
+func startSearchServices(dependents []string) error {
+	if err := core.StartService(searchServiceName, searchTimeout); err != nil {
+		return err
+	}
+	for _, dep := range dependents {
+		if err := core.StartService(dep, searchTimeout); err != nil {
+			return fmt.Errorf("started %s but not its dependent %s: %w", searchServiceName, dep, err)
+		}
+	}
+	return nil
+}
+
+// ─── Exclusions ──────────────────────────────────────────────────────────────
+
+// ListSearchExclusions returns the folders kept out of the index by policy,
+// sorted.
+func ListSearchExclusions() ([]string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, searchExclusionPath, registry.QUERY_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot read search exclusions: %w", err)
+	}
+	defer key.Close()
+
+	names, err := key.ReadValueNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read search exclusions: %w", err)
+	}
+	var folders []string
+	for _, name := range names {
+		rule, _, err := key.GetStringValue(name)
+		if err != nil {
+			continue
+		}
+		if folder, ok := searchRuleFolder(rule); ok {
+			folders = append(folders, folder)
+		}
+	}
+	sort.Strings(folders)
+	return folders, nil
+}
+
+// ExcludeFromSearch keeps folders, and everything below them, out of the
+// index. The indexer picks the change up when WSearch restarts.
+func ExcludeFromSearch(folders []string) error {
+	if err := core.RequireAdmin("change search exclusions"); err != nil {
+		return err
+	}
+	key, _, err := registry.CreateKey(registry.LOCAL_MACHINE, searchExclusionPath, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("cannot open search exclusions: %w", err)
+	}
+	defer key.Close()
+
+	for _, folder := range folders {
+		rule, err := searchRule(folder)
+		if err != nil {
+			return err
+		}
+		if err := key.SetStringValue(rule, rule); err != nil {
+			return fmt.Errorf("cannot exclude %s: %w", folder, err)
+		}
+	}
+	return nil
+}
+
+// IncludeInSearch removes folder exclusions added by ExcludeFromSearch.
+// Folders that are not excluded are ignored.
+func IncludeInSearch(folders []string) error {
+	if err := core.RequireAdmin("change search exclusions"); err != nil {
+		return err
+	}
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, searchExclusionPath, registry.SET_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("cannot open search exclusions: %w", err)
+	}
+	defer key.Close()
+
+	for _, folder := range folders {
+		rule, err := searchRule(folder)
+		if err != nil {
+			return err
+		}
+		if err := key.DeleteValue(rule); err != nil && !errors.Is(err, registry.ErrNotExist) {
+			return fmt.Errorf("cannot include %s: %w", folder, err)
+		}
+	}
+	return nil
+}
+
+// SearchExclusionChanges describes what ExcludeFromSearch (excluded) or
+// IncludeInSearch changes for the given folders. The journal does not
+// track exclusions, so the changes are NotRevertible; the opposite flag
+// undoes each.
+func SearchExclusionChanges(folders []string, excluded bool) []Change {
+	var changes []Change
+	for _, folder := range folders {
+		rule, err := searchRule(folder)
+		if err != nil {
+			continue
+		}
+		c := Change{Kind: "registry", Target: `HKLM\` + searchExclusionPath + `\` + rule, To: "(not set)"}
+		undo := fmt.Sprintf("pw optimize search --exclude %q", folder)
+		if excluded {
+			c.To = rule
+			undo = fmt.Sprintf("pw optimize search --include %q", folder)
+		}
+		changes = append(changes, NotRevertible(undo, c)...)
+	}
+	return changes
+}
+
+// RestartSearchService restarts WSearch so it rereads its exclusions.
+func RestartSearchService() error {
+	if err := core.RequireAdmin("restart Windows Search"); err != nil {
+		return err
+	}
+	return core.RestartService(searchServiceName, searchTimeout)
+}
+
+// searchRule turns an absolute folder into a policy rule, e.g. D:\Builds
+// into file:///D:\Builds\*.
+func searchRule(folder string) (string, error) {
+	if !filepath.IsAbs(folder) || strings.HasPrefix(folder, `\\`) {
+		return "", fmt.Errorf("%q is not a local absolute path", folder)
+	}
+	folder = strings.TrimRight(filepath.Clean(folder), `\`)
+	return searchRulePrefix + folder + searchRuleSuffix, nil
+}
+
+// searchRuleFolder is the inverse of searchRule. Rules written by other
+// tools in other shapes are reported as-is.
+func searchRuleFolder(rule string) (string, bool) {
+	if rule == "" {
+		return "", false
+	}
+	folder := strings.TrimPrefix(rule, searchRulePrefix)
+	folder = strings.TrimSuffix(folder, searchRuleSuffix)
+	if len(folder) == 2 && folder[1] == ':' {
+		folder += `\`
+	}
+	return folder, true
+}
+
+// ─── Drive Indexing ──────────────────────────────────────────────────────────
+
+// listDriveIndexing reports the content-indexing attribute of each fixed
+// drive's root, the setting behind "Allow files on this drive to have
+// contents indexed".
+func listDriveIndexing() ([]DriveIndexing, error) {
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list drives: %w", err)
+	}
+	system := strings.ToUpper(strings.TrimRight(systemDrive(), `\`))
+
+	var drives []DriveIndexing
+	for i := 0; i < 26; i++ {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		drive := string(rune('A'+i)) + ":"
+		root, _ := windows.UTF16PtrFromString(drive + `\`)
+		if windows.GetDriveType(root) != windows.DRIVE_FIXED {
+			continue
+		}
+		attrs, err := windows.GetFileAttributes(root)
+		if err != nil {
+			continue // Not ready or not accessible.
+		}
+		drives = append(drives, DriveIndexing{
+			Drive:   drive,
+			System:  drive == system,
+			Indexed: attrs&windows.FILE_ATTRIBUTE_NOT_CONTENT_INDEXED == 0,
+		})
+	}
+	return drives, nil
+}
+
+// DriveIndexingChanges describes what SetDriveIndexing changes.
+func DriveIndexingChanges(drive string, enabled bool) []Change {
+	drive = strings.ToUpper(strings.TrimRight(drive, `\`))
+	attr := Change{Kind: "attribute", Target: drive + `\ NOT_CONTENT_INDEXED`, To: "set"}
+	undo := "pw optimize search --enable-drive " + drive
+	if enabled {
+		attr.To = "cleared"
+		undo = "pw optimize search --disable-drive " + drive
+	}
+	return NotRevertible(undo, append([]Change{attr}, SearchExclusionChanges([]string{drive + `\`}, !enabled)...)...)
+}
+
+// SetDriveIndexing turns indexing of a secondary drive on or off. Turning
+// it off clears the drive root's content-indexed attribute, as Explorer's
+// drive properties do, and excludes the whole drive so content already
+// indexed is dropped. The system drive is refused: excluding it would
+// empty the Start menu and Settings search.
+func SetDriveIndexing(drive string, enabled bool) error {
+	if err := core.RequireAdmin("change drive indexing"); err != nil {
+		return err
+	}
+	drive = strings.ToUpper(strings.TrimRight(drive, `\`))
+	if !driveLetterPattern.MatchString(drive) {
+		return fmt.Errorf("invalid drive %q", drive)
+	}
+	if !enabled && drive == strings.ToUpper(strings.TrimRight(systemDrive(), `\`)) {
+		return fmt.Errorf("refusing to turn off indexing on the system drive %s", drive)
+	}
+
+	root, err := windows.UTF16PtrFromString(drive + `\`)
+	if err != nil {
+		return err
+	}
+	attrs, err := windows.GetFileAttributes(root)
+	if err != nil {
+		return fmt.Errorf("cannot read %s: %w", drive, err)
+	}
+	if enabled {
+		attrs &^= windows.FILE_ATTRIBUTE_NOT_CONTENT_INDEXED
+	} else {
+		attrs |= windows.FILE_ATTRIBUTE_NOT_CONTENT_INDEXED
+	}
+	if err := windows.SetFileAttributes(root, attrs); err != nil {
+		return fmt.Errorf("cannot change indexing on %s: %w", drive, err)
+	}
+
+	if enabled {
+		return IncludeInSearch([]string{drive + `\`})
+	}
+	return ExcludeFromSearch([]string{drive + `\`})
+}
@@ -1,10 +1,6 @@
 package optimize
 
 import (
-	"context"
-	"fmt"
-	"os/exec"
-	"strings"
 	"time"
 
 	"github.com/cy-infamous/purewin/internal/core"
@@ -33,84 +29,19 @@ func GetManagedServices() []ManagedService {
 
 // ─── Public API ──────────────────────────────────────────────────────────────
 
-// FlushDNS clears the DNS resolver cache.
-func FlushDNS() error {
-	if err := core.RequireAdmin("flush DNS"); err != nil {
-		return err
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), serviceTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "ipconfig", "/flushdns")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to flush DNS: %s: %w",
-			strings.TrimSpace(string(output)), err)
-	}
-	return nil
-}
-
-// RestartService stops and then starts a Windows service by name.
-// Services that auto-restart (DNS Client, DHCP Client, etc.) are handled
-// gracefully — "already started" after a stop attempt is treated as success.
+// RestartService stops a Windows service, together with any running
+// services that depend on it, and starts them again. Services that
+// auto-restart (DNS Client, DHCP Client, etc.) are handled gracefully —
+// starting a service that is already running succeeds.
 func RestartService(name string) error {
 	if err := core.RequireAdmin("restart service"); err != nil {
 		return err
 	}
-
-	// Stop the service (ignore error — service may not be running).
-	stopCtx, stopCancel := context.WithTimeout(context.Background(), serviceTimeout)
-	defer stopCancel()
-
-	stopCmd := exec.CommandContext(stopCtx, "net", "stop", name)
-	_, _ = stopCmd.CombinedOutput()
-
-	// Brief pause to let the service fully stop before restarting.
-	time.Sleep(1 * time.Second)
-
-	// Start the service.
-	startCtx, startCancel := context.WithTimeout(context.Background(), serviceTimeout)
-	defer startCancel()
-
-	startCmd := exec.CommandContext(startCtx, "net", "start", name)
-	output, err := startCmd.CombinedOutput()
-	if err != nil {
-		outStr := strings.TrimSpace(string(output))
-
-		// "The requested service has already been started" means the service
-		// auto-restarted after the stop — this is the desired outcome.
-		if strings.Contains(strings.ToLower(outStr), "already been started") {
-			return nil
-		}
-
-		return fmt.Errorf("failed to start service %s: %s: %w", name, outStr, err)
-	}
-	return nil
+	return core.RestartService(name, serviceTimeout)
 }
 
-// GetServiceStatus queries the current status of a Windows service.
+// GetServiceStatus queries the current status of a Windows service, e.g.
+// "RUNNING" or "STOPPED".
 func GetServiceStatus(name string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), serviceTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "sc", "query", name)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "", fmt.Errorf("failed to query service %s: %w", name, err)
-	}
-
-	// Parse STATE line from sc query output.
-	// Format: "        STATE              : 4  RUNNING"
-	for _, line := range strings.Split(string(output), "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "STATE") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1]), nil
-			}
-		}
-	}
-
-	return "UNKNOWN", nil
+	return core.ServiceState(name)
 }
@@ -0,0 +1,286 @@
+package optimize
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// SnapshotVersion is the on-disk snapshot schema version.
+const SnapshotVersion = 1
+
+// snapshotDirName is the directory under the config dir holding snapshots.
+const snapshotDirName = "snapshots"
+
+// ─── Snapshot Data ───────────────────────────────────────────────────────────
+
+// Snapshot records the optimize-relevant state of the machine so it can be
+// restored later with `pw optimize restore`.
+type Snapshot struct {
+	Version   int                `json:"version"`
+	CreatedAt time.Time          `json:"created_at"`
+	Host      string             `json:"host"`
+	Services  []ServiceState     `json:"services"`
+	Registry  []RegistryValue    `json:"registry"`
+	Startup   []StartupItemState `json:"startup"`
+	PowerPlan string             `json:"power_plan"` // Active scheme GUID.
+	Tasks     []TaskState        `json:"tasks,omitempty"`
+}
+
+// ServiceState is the start type and run state of a single service.
+type ServiceState struct {
+	Name      string `json:"name"`
+	StartType string `json:"start_type"` // auto, manual, disabled, ...
+	Delayed   bool   `json:"delayed_auto_start"`
+	Running   bool   `json:"running"`
+}
+
+// RegistryValue is a tracked DWORD tweak. Exists is false when the value
+// was absent, in which case restore deletes it.
+type RegistryValue struct {
+	Root   string `json:"root"` // HKLM or HKCU
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+	Exists bool   `json:"exists"`
+	Value  uint64 `json:"value"`
+}
+
+// StartupItemState is a registry Run entry and its StartupApproved state.
+type StartupItemState struct {
+	Name     string `json:"name"`
+	Command  string `json:"command"`
+	Location string `json:"location"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// TaskState is whether a scheduled task, named by its path below the task
+// root, is enabled.
+type TaskState struct {
+	Path    string `json:"path"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ─── Tracked State ───────────────────────────────────────────────────────────
+
+// snapshotServices returns the services whose configuration is captured.
+func snapshotServices() []string {
+	names := []string{"SysMain", "DiagTrack"}
+	for _, svc := range GetManagedServices() {
+		names = append(names, svc.Name)
+	}
+	names = append(names, profileServiceNames()...)
+	names = append(names, privacyServiceNames()...)
+
+	seen := make(map[string]bool, len(names))
+	unique := names[:0]
+	for _, n := range names {
+		if key := strings.ToLower(n); !seen[key] {
+			seen[key] = true
+			unique = append(unique, n)
+		}
+	}
+	return unique
+}
+
+// trackedRegistryValues are the DWORD tweaks captured in snapshots.
+var trackedRegistryValues = []RegistryValue{
+	{Root: "HKCU", Path: `Control Panel\Desktop`, Name: "MenuShowDelay"},
+	{Root: "HKCU", Path: `Software\Microsoft\Windows\CurrentVersion\Explorer\Serialize`, Name: "StartupDelayInMSec"},
+	{Root: "HKCU", Path: `System\GameConfigStore`, Name: "GameDVR_Enabled"},
+	{Root: "HKLM", Path: `SYSTEM\CurrentControlSet\Control\PriorityControl`, Name: "Win32PrioritySeparation"},
+	{Root: "HKLM", Path: `SYSTEM\CurrentControlSet\Control\Power\PowerThrottling`, Name: "PowerThrottlingOff"},
+}
+
+// snapshotRegistryValues returns every DWORD captured in snapshots.
+func snapshotRegistryValues() []RegistryValue {
+	values := append([]RegistryValue(nil), trackedRegistryValues...)
+	return append(values, privacyRegistryValues()...)
+}
+
+// registryRoot maps a root name to its registry key.
+func registryRoot(name string) (registry.Key, error) {
+	switch strings.ToUpper(name) {
+	case "HKLM":
+		return registry.LOCAL_MACHINE, nil
+	case "HKCU":
+		return registry.CURRENT_USER, nil
+	}
+	return 0, fmt.Errorf("unsupported registry root %q", name)
+}
+
+// ─── Capture ─────────────────────────────────────────────────────────────────
+
+// CaptureSnapshot reads the current services, registry tweaks, startup
+// entries, active power plan, and privacy-related scheduled tasks. Items that can't be read are skipped.
+func CaptureSnapshot() (*Snapshot, error) {
+	snap := &Snapshot{
+		Version:   SnapshotVersion,
+		CreatedAt: time.Now(),
+	}
+	snap.Host, _ = os.Hostname()
+
+	for _, name := range snapshotServices() {
+		if st, err := readServiceState(name); err == nil {
+			snap.Services = append(snap.Services, st)
+		}
+	}
+
+	for _, rv := range snapshotRegistryValues() {
+		snap.Registry = append(snap.Registry, readRegistryValue(rv))
+	}
+
+	for _, path := range privacyTaskPaths() {
+		if enabled, err := core.ScheduledTaskEnabled(path); err == nil {
+			snap.Tasks = append(snap.Tasks, TaskState{Path: path, Enabled: enabled})
+		}
+	}
+
+	items, _ := GetStartupItems()
+	for _, item := range items {
+		snap.Startup = append(snap.Startup, StartupItemState{
+			Name:     item.Name,
+			Command:  item.Command,
+			Location: item.Location,
+			Enabled:  item.Enabled,
+		})
+	}
+
+	plan, err := activePowerPlan()
+	if err == nil {
+		snap.PowerPlan = plan
+	}
+
+	return snap, nil
+}
+
+// readServiceState queries a service's configuration and status.
+func readServiceState(name string) (ServiceState, error) {
+	s, err := openService(name, windows.SERVICE_QUERY_CONFIG|windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		return ServiceState{}, err
+	}
+	defer s.Close()
+
+	cfg, err := s.Config()
+	if err != nil {
+		return ServiceState{}, fmt.Errorf("cannot read config for %s: %w", name, err)
+	}
+	status, err := s.Query()
+	if err != nil {
+		return ServiceState{}, fmt.Errorf("cannot query %s: %w", name, err)
+	}
+
+	return ServiceState{
+		Name:      name,
+		StartType: serviceStartTypeNames[cfg.StartType],
+		Delayed:   cfg.DelayedAutoStart,
+		Running:   status.State == svc.Running,
+	}, nil
+}
+
+// readRegistryValue fills in the current value of a tracked tweak.
+func readRegistryValue(rv RegistryValue) RegistryValue {
+	root, err := registryRoot(rv.Root)
+	if err != nil {
+		return rv
+	}
+	key, err := registry.OpenKey(root, rv.Path, registry.QUERY_VALUE)
+	if err != nil {
+		return rv
+	}
+	defer key.Close()
+
+	val, _, err := key.GetIntegerValue(rv.Name)
+	if err != nil {
+		return rv
+	}
+	rv.Exists = true
+	rv.Value = val
+	return rv
+}
+
+// activePowerPlan returns the GUID of the active power scheme.
+func activePowerPlan() (string, error) {
+	g, err := activeScheme()
+	if err != nil {
+		return "", err
+	}
+	return guidString(g), nil
+}
+
+// ─── Persistence ─────────────────────────────────────────────────────────────
+
+// SnapshotDir returns the directory where snapshots are stored.
+func SnapshotDir(configDir string) string {
+	return filepath.Join(configDir, snapshotDirName)
+}
+
+// SaveSnapshot writes the snapshot as JSON under the config dir and returns
+// the file path.
+func SaveSnapshot(configDir string, snap *Snapshot) (string, error) {
+	dir := SnapshotDir(configDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("cannot create snapshot directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	path := filepath.Join(dir, "optimize-"+snap.CreatedAt.Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("cannot write snapshot %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadSnapshot reads a snapshot file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snapshot %s: %w", path, err)
+	}
+	snap := &Snapshot{}
+	if err := json.Unmarshal(data, snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	if snap.Version > SnapshotVersion {
+		return nil, fmt.Errorf("snapshot version %d is newer than supported version %d",
+			snap.Version, SnapshotVersion)
+	}
+	return snap, nil
+}
+
+// ListSnapshots returns saved snapshot paths, newest first.
+func ListSnapshots(configDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(SnapshotDir(configDir), "optimize-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	// Timestamped names sort chronologically.
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+	return matches, nil
+}
+
+// LatestSnapshot returns the path of the newest snapshot.
+func LatestSnapshot(configDir string) (string, error) {
+	paths, err := ListSnapshots(configDir)
+	if err != nil {
+		return "", err
+	}
+	if len(paths) == 0 {
+		return "", fmt.Errorf("no optimize snapshots found in %s", SnapshotDir(configDir))
+	}
+	return paths[0], nil
+}
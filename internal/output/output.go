@@ -0,0 +1,44 @@
+// Package output writes the machine-readable form of command results. When
+// the global --json flag is set, commands build a plain struct describing
+// what they found or did and hand it to JSON instead of printing styled
+// text. Only the JSON document is written to stdout, so the output can be
+// piped straight into other tools.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrorDocument is written in place of a command's result when it fails.
+type ErrorDocument struct {
+	Command string `json:"command"`
+	Error   string `json:"error"`
+}
+
+// Write encodes v as indented JSON followed by a newline.
+func Write(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return nil
+}
+
+// JSON writes v to stdout. Encoding failures are reported on stderr and
+// exit with status 1.
+func JSON(v any) {
+	if err := Write(os.Stdout, v); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Fail writes an ErrorDocument for command to stdout and exits with status 1.
+func Fail(command string, err error) {
+	_ = Write(os.Stdout, ErrorDocument{Command: command, Error: err.Error()})
+	os.Exit(1)
+}
@@ -0,0 +1,39 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWrite_Indented(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, map[string]int{"count": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "{\n  \"count\": 2\n}\n"; got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestWrite_ErrorDocument(t *testing.T) {
+	var buf bytes.Buffer
+	doc := ErrorDocument{Command: "purge", Error: errors.New("no scan paths").Error()}
+	if err := Write(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["command"] != "purge" || decoded["error"] != "no scan paths" {
+		t.Errorf("decoded = %v", decoded)
+	}
+}
+
+func TestWrite_Unsupported(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, make(chan int)); err == nil {
+		t.Error("expected an error for a value JSON cannot encode")
+	}
+}
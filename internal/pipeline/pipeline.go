@@ -0,0 +1,216 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cy-infamous/purewin/internal/config"
+)
+
+// SchemaVersion is the version of the JSON item list format. Readers reject
+// documents with a newer version than they understand.
+const SchemaVersion = 1
+
+// ─── Data Structures ─────────────────────────────────────────────────────────
+
+// Item is a single filesystem entry exchanged between pipeline stages.
+type Item struct {
+	// Path is the absolute filesystem path.
+	Path string `json:"path"`
+
+	// Size is the size in bytes.
+	Size int64 `json:"size"`
+
+	// Category is the high-level grouping (user, browser, dev, system, ...).
+	Category string `json:"category,omitempty"`
+
+	// Description is a human-readable label for the item's origin.
+	Description string `json:"description,omitempty"`
+
+	// Risk is the risk level of the item's clean target (low, medium, high).
+	Risk string `json:"risk,omitempty"`
+}
+
+// Document is the JSON envelope written to stdout by producers
+// (e.g. `pw clean scan --json`) and read from stdin by consumers
+// (e.g. `pw filter`, `pw clean apply --from -`).
+type Document struct {
+	// Version is the schema version (see SchemaVersion).
+	Version int `json:"version"`
+
+	// Source names the command that produced the list (e.g. "clean").
+	Source string `json:"source,omitempty"`
+
+	// Items is the list of entries to act on.
+	Items []Item `json:"items"`
+}
+
+// NewDocument creates an empty document tagged with the given source.
+func NewDocument(source string) *Document {
+	return &Document{
+		Version: SchemaVersion,
+		Source:  source,
+		Items:   make([]Item, 0),
+	}
+}
+
+// TotalSize returns the combined size of all items.
+func (d *Document) TotalSize() int64 {
+	var total int64
+	for _, item := range d.Items {
+		total += item.Size
+	}
+	return total
+}
+
+// LimitRisk drops the items above maxRisk and returns how many were held
+// back and their size. Items without a risk level count as high.
+func (d *Document) LimitRisk(maxRisk string) (heldItems int, heldSize int64) {
+	kept := d.Items[:0]
+	for _, item := range d.Items {
+		if config.RiskAllowed(item.Risk, maxRisk) {
+			kept = append(kept, item)
+		} else {
+			heldItems++
+			heldSize += item.Size
+		}
+	}
+	d.Items = kept
+	return heldItems, heldSize
+}
+
+// ─── Encoding ────────────────────────────────────────────────────────────────
+
+// Write encodes the document as indented JSON followed by a newline.
+func Write(w io.Writer, doc *Document) error {
+	if doc.Items == nil {
+		doc.Items = make([]Item, 0)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode item list: %w", err)
+	}
+	return nil
+}
+
+// Read decodes a document from r. A bare JSON array of items is accepted
+// as well, so hand-written lists don't need the envelope.
+func Read(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item list: %w", err)
+	}
+
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" {
+		return nil, fmt.Errorf("item list is empty")
+	}
+
+	doc := &Document{}
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &doc.Items); err != nil {
+			return nil, fmt.Errorf("failed to parse item list: %w", err)
+		}
+		doc.Version = SchemaVersion
+	} else {
+		if err := json.Unmarshal([]byte(trimmed), doc); err != nil {
+			return nil, fmt.Errorf("failed to parse item list: %w", err)
+		}
+		if doc.Version == 0 {
+			doc.Version = SchemaVersion
+		}
+	}
+
+	if doc.Version > SchemaVersion {
+		return nil, fmt.Errorf("item list version %d is newer than supported version %d",
+			doc.Version, SchemaVersion)
+	}
+
+	for i, item := range doc.Items {
+		if strings.TrimSpace(item.Path) == "" {
+			return nil, fmt.Errorf("item %d has no path", i)
+		}
+	}
+
+	return doc, nil
+}
+
+// Open returns a reader for the named input. The name "-" selects stdin.
+func Open(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open item list %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// ─── Filtering ───────────────────────────────────────────────────────────────
+
+// Filter selects a subset of items. Zero-valued fields are ignored.
+type Filter struct {
+	// MinSize drops items smaller than this many bytes.
+	MinSize int64
+
+	// MaxSize drops items larger than this many bytes.
+	MaxSize int64
+
+	// Categories keeps only items in one of these categories (case-insensitive).
+	Categories []string
+
+	// Exclude drops items whose path or base name matches any glob pattern.
+	Exclude []string
+}
+
+// Apply returns the items that pass the filter, preserving order.
+func (f Filter) Apply(items []Item) []Item {
+	cats := make(map[string]bool, len(f.Categories))
+	for _, c := range f.Categories {
+		cats[strings.ToLower(c)] = true
+	}
+
+	out := make([]Item, 0, len(items))
+	for _, item := range items {
+		if f.MinSize > 0 && item.Size < f.MinSize {
+			continue
+		}
+		if f.MaxSize > 0 && item.Size > f.MaxSize {
+			continue
+		}
+		if len(cats) > 0 && !cats[strings.ToLower(item.Category)] {
+			continue
+		}
+		if f.excluded(item.Path) {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// excluded reports whether path matches any exclude pattern. Matching is
+// case-insensitive to mirror Windows filesystem semantics.
+func (f Filter) excluded(path string) bool {
+	lowerPath := strings.ToLower(path)
+	lowerBase := lowerPath
+	if i := strings.LastIndexAny(lowerPath, `\/`); i >= 0 {
+		lowerBase = lowerPath[i+1:]
+	}
+	for _, pattern := range f.Exclude {
+		p := strings.ToLower(pattern)
+		if ok, _ := filepath.Match(p, lowerBase); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, lowerPath); ok {
+			return true
+		}
+	}
+	return false
+}
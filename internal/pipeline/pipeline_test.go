@@ -0,0 +1,119 @@
+package pipeline
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	doc := NewDocument("clean")
+	doc.Items = append(doc.Items,
+		Item{Path: `C:\Temp\a.tmp`, Size: 100, Category: "user"},
+		Item{Path: `C:\Temp\b.log`, Size: 200, Category: "user"},
+	)
+
+	var buf bytes.Buffer
+	if err := Write(&buf, doc); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if got.Source != "clean" {
+		t.Errorf("Source = %q, want %q", got.Source, "clean")
+	}
+	if len(got.Items) != 2 {
+		t.Fatalf("len(Items) = %d, want 2", len(got.Items))
+	}
+	if got.TotalSize() != 300 {
+		t.Errorf("TotalSize() = %d, want 300", got.TotalSize())
+	}
+}
+
+func TestRead_BareArray(t *testing.T) {
+	doc, err := Read(strings.NewReader(`[{"path":"C:\\x","size":5}]`))
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if len(doc.Items) != 1 || doc.Items[0].Size != 5 {
+		t.Errorf("unexpected items: %+v", doc.Items)
+	}
+	if doc.Version != SchemaVersion {
+		t.Errorf("Version = %d, want %d", doc.Version, SchemaVersion)
+	}
+}
+
+func TestRead_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"empty":        "   ",
+		"garbage":      "not json",
+		"future":       `{"version": 99, "items": []}`,
+		"missing path": `{"version": 1, "items": [{"size": 1}]}`,
+	}
+	for name, input := range cases {
+		if _, err := Read(strings.NewReader(input)); err == nil {
+			t.Errorf("%s: Read() should fail", name)
+		}
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	items := []Item{
+		{Path: `C:\a\small.tmp`, Size: 10, Category: "user"},
+		{Path: `C:\a\big.tmp`, Size: 1000, Category: "user"},
+		{Path: `C:\b\huge.log`, Size: 5000, Category: "dev"},
+		{Path: `C:\b\keep.LOG`, Size: 5000, Category: "DEV"},
+	}
+
+	f := Filter{MinSize: 100}
+	if got := f.Apply(items); len(got) != 3 {
+		t.Errorf("MinSize: got %d items, want 3", len(got))
+	}
+
+	f = Filter{MaxSize: 1000}
+	if got := f.Apply(items); len(got) != 2 {
+		t.Errorf("MaxSize: got %d items, want 2", len(got))
+	}
+
+	f = Filter{Categories: []string{"dev"}}
+	if got := f.Apply(items); len(got) != 2 {
+		t.Errorf("Categories: got %d items, want 2", len(got))
+	}
+
+	f = Filter{Exclude: []string{"keep.*"}}
+	got := f.Apply(items)
+	if len(got) != 3 {
+		t.Errorf("Exclude: got %d items, want 3", len(got))
+	}
+	for _, item := range got {
+		if strings.Contains(item.Path, "keep") {
+			t.Errorf("Exclude: %s should have been dropped", item.Path)
+		}
+	}
+}
+
+func TestDocument_LimitRisk(t *testing.T) {
+	doc := NewDocument("clean")
+	doc.Items = append(doc.Items,
+		Item{Path: `C:\Temp\low.tmp`, Size: 1, Risk: "low"},
+		Item{Path: `C:\Temp\medium.tmp`, Size: 10, Risk: "medium"},
+		Item{Path: `C:\Temp\high.tmp`, Size: 100, Risk: "high"},
+		Item{Path: `C:\Temp\unknown.tmp`, Size: 1000},
+	)
+
+	held, size := doc.LimitRisk("medium")
+	if held != 2 || size != 1100 {
+		t.Errorf("LimitRisk(medium) held %d items (%d bytes), want 2 (1100)", held, size)
+	}
+	if len(doc.Items) != 2 || doc.Items[0].Risk != "low" || doc.Items[1].Risk != "medium" {
+		t.Errorf("LimitRisk(medium) kept %+v, want the low and medium items", doc.Items)
+	}
+
+	held, _ = doc.LimitRisk("low")
+	if held != 1 || len(doc.Items) != 1 {
+		t.Errorf("LimitRisk(low) held %d and kept %d items, want 1 and 1", held, len(doc.Items))
+	}
+}
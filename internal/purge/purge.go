@@ -13,12 +13,12 @@ import (
 
 // ProjectArtifact represents a build artifact found in a project directory.
 type ProjectArtifact struct {
-	ProjectPath  string    // Path to the project root
-	ArtifactPath string    // Full path to the artifact (node_modules, target, etc.)
-	ArtifactType string    // Type of artifact (node_modules, target, dist, etc.)
-	Size         int64     // Size in bytes
-	ModTime      time.Time // Last modification time
-	IsRecent     bool      // True if modified within 7 days
+	ProjectPath  string    `json:"project_path"`  // Path to the project root
+	ArtifactPath string    `json:"artifact_path"` // Full path to the artifact (node_modules, target, etc.)
+	ArtifactType string    `json:"artifact_type"` // Type of artifact (node_modules, target, dist, etc.)
+	Size         int64     `json:"size"`          // Size in bytes
+	ModTime      time.Time `json:"mod_time"`      // Last modification time
+	IsRecent     bool      `json:"is_recent"`     // True if modified within 7 days
 }
 
 // artifactDefinition describes how to detect and identify artifacts.
@@ -62,6 +62,12 @@ var artifactDirNames = func() map[string]bool {
 // ScanProjects walks the given paths and identifies project artifacts.
 // It will scan up to 3 levels deep and NOT recurse into artifact directories.
 func ScanProjects(paths []string) ([]ProjectArtifact, error) {
+	return ScanProjectsExcluding(paths, nil)
+}
+
+// ScanProjectsExcluding is ScanProjects, skipping artifacts and directories
+// for which excluded returns true. excluded may be nil.
+func ScanProjectsExcluding(paths []string, excluded func(path string) bool) ([]ProjectArtifact, error) {
 	var artifacts []ProjectArtifact
 	seenProjects := make(map[string]bool)
 
@@ -71,7 +77,7 @@ func ScanProjects(paths []string) ([]ProjectArtifact, error) {
 			continue // Skip non-existent paths
 		}
 
-		err := scanDirectory(basePath, basePath, 0, 3, seenProjects, &artifacts)
+		err := scanDirectory(basePath, basePath, 0, 3, seenProjects, excluded, &artifacts)
 		if err != nil {
 			// Non-fatal: log but continue scanning other paths
 			continue
@@ -92,7 +98,7 @@ func ScanProjects(paths []string) ([]ProjectArtifact, error) {
 // scanDirectory recursively scans a directory for project artifacts.
 // depth starts at 0 and increases with each level.
 // maxDepth limits how deep we search (typically 3).
-func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjects map[string]bool, artifacts *[]ProjectArtifact) error {
+func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjects map[string]bool, excluded func(string) bool, artifacts *[]ProjectArtifact) error {
 	if depth > maxDepth {
 		return nil
 	}
@@ -123,6 +129,9 @@ func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjec
 		}
 
 		artifactPath := filepath.Join(currentPath, name)
+		if excluded != nil && excluded(artifactPath) {
+			continue
+		}
 
 		// Find the matching definition
 		var def *artifactDefinition
@@ -197,7 +206,10 @@ func scanDirectory(basePath, currentPath string, depth, maxDepth int, seenProjec
 		}
 
 		subPath := filepath.Join(currentPath, name)
-		_ = scanDirectory(basePath, subPath, depth+1, maxDepth, seenProjects, artifacts)
+		if excluded != nil && excluded(subPath) {
+			continue
+		}
+		_ = scanDirectory(basePath, subPath, depth+1, maxDepth, seenProjects, excluded, artifacts)
 	}
 
 	return nil
@@ -0,0 +1,179 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Markdown ────────────────────────────────────────────────────────────────
+
+// writeMarkdown renders r as a Markdown document.
+func writeMarkdown(r *Report, sb *strings.Builder) error {
+	fmt.Fprintf(sb, "# PureWin Cleanup Report\n\n")
+	if r.DryRun {
+		sb.WriteString("> **Dry run** — nothing was deleted. Sizes show what would be freed.\n\n")
+	}
+
+	sb.WriteString("| | |\n|---|---|\n")
+	for _, row := range r.overview() {
+		fmt.Fprintf(sb, "| %s | %s |\n", row[0], mdEscape(row[1]))
+	}
+	sb.WriteString("\n")
+
+	groups := r.Groups()
+	if len(groups) > 0 {
+		sb.WriteString("## Categories\n\n")
+		sb.WriteString("| Category | Freed | Cleaned | Failed | Skipped |\n|---|---:|---:|---:|---:|\n")
+		for _, g := range groups {
+			fmt.Fprintf(sb, "| %s | %s | %d | %d | %d |\n",
+				mdEscape(g.Name), core.FormatSize(g.Freed), g.Cleaned, g.Failed, g.Skipped)
+		}
+		sb.WriteString("\n")
+	}
+
+	cleanedTitle := "Cleaned Items"
+	cleaned := r.WithStatus(StatusCleaned)
+	if r.DryRun {
+		cleanedTitle = "Planned Items"
+		cleaned = r.WithStatus(StatusPlanned)
+	}
+	writeMarkdownEntries(sb, cleanedTitle, cleaned, false)
+	writeMarkdownEntries(sb, "Errors", r.WithStatus(StatusFailed), true)
+	writeMarkdownEntries(sb, "Skipped Items", r.WithStatus(StatusSkipped), true)
+	return nil
+}
+
+// writeMarkdownEntries writes a table of entries under title, if any.
+func writeMarkdownEntries(sb *strings.Builder, title string, entries []Entry, detail bool) {
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "## %s (%d)\n\n", title, len(entries))
+	if detail {
+		sb.WriteString("| Category | Path | Reason |\n|---|---|---|\n")
+	} else {
+		sb.WriteString("| Category | Path | Size |\n|---|---|---:|\n")
+	}
+	for _, e := range entries {
+		last := core.FormatSize(e.Size)
+		if detail {
+			last = mdEscape(e.Detail)
+		}
+		fmt.Fprintf(sb, "| %s | `%s` | %s |\n", mdEscape(e.Group), strings.ReplaceAll(e.Path, "`", "'"), last)
+	}
+	sb.WriteString("\n")
+}
+
+// mdEscape keeps s from breaking a Markdown table row.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// ─── HTML ────────────────────────────────────────────────────────────────────
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"size": core.FormatSize,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>PureWin Cleanup Report</title>
+<style>
+body { font-family: "Segoe UI", sans-serif; margin: 2em; color: #222; }
+h1 { margin-bottom: 0.2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+th { background: #f2f2f2; }
+td.num { text-align: right; }
+code { font-family: Consolas, monospace; }
+.dry { background: #fff4ce; padding: 0.6em 1em; border-left: 4px solid #e0a800; }
+.failed { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>PureWin Cleanup Report</h1>
+{{if .Report.DryRun}}<p class="dry"><strong>Dry run</strong> — nothing was deleted. Sizes show what would be freed.</p>{{end}}
+<table>
+{{range .Overview}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{if .Groups}}<h2>Categories</h2>
+<table>
+<tr><th>Category</th><th>Freed</th><th>Cleaned</th><th>Failed</th><th>Skipped</th></tr>
+{{range .Groups}}<tr><td>{{.Name}}</td><td class="num">{{size .Freed}}</td><td class="num">{{.Cleaned}}</td><td class="num">{{.Failed}}</td><td class="num">{{.Skipped}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Cleaned}}<h2>{{.CleanedTitle}} ({{len .Cleaned}})</h2>
+<table>
+<tr><th>Category</th><th>Path</th><th>Size</th></tr>
+{{range .Cleaned}}<tr><td>{{.Group}}</td><td><code>{{.Path}}</code></td><td class="num">{{size .Size}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Failed}}<h2 class="failed">Errors ({{len .Failed}})</h2>
+<table>
+<tr><th>Category</th><th>Path</th><th>Reason</th></tr>
+{{range .Failed}}<tr><td>{{.Group}}</td><td><code>{{.Path}}</code></td><td>{{.Detail}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Skipped}}<h2>Skipped Items ({{len .Skipped}})</h2>
+<table>
+<tr><th>Category</th><th>Path</th><th>Reason</th></tr>
+{{range .Skipped}}<tr><td>{{.Group}}</td><td><code>{{.Path}}</code></td><td>{{.Detail}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// writeHTML renders r as a standalone HTML page.
+func writeHTML(r *Report, sb *strings.Builder) error {
+	data := struct {
+		Report       *Report
+		Overview     [][2]string
+		Groups       []GroupSummary
+		CleanedTitle string
+		Cleaned      []Entry
+		Failed       []Entry
+		Skipped      []Entry
+	}{
+		Report:       r,
+		Overview:     r.overview(),
+		Groups:       r.Groups(),
+		CleanedTitle: "Cleaned Items",
+		Cleaned:      r.WithStatus(StatusCleaned),
+		Failed:       r.WithStatus(StatusFailed),
+		Skipped:      r.WithStatus(StatusSkipped),
+	}
+	if r.DryRun {
+		data.CleanedTitle = "Planned Items"
+		data.Cleaned = r.WithStatus(StatusPlanned)
+	}
+	if err := htmlTemplate.Execute(sb, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// ─── Shared ──────────────────────────────────────────────────────────────────
+
+// overview returns the label/value rows shown at the top of every report.
+func (r *Report) overview() [][2]string {
+	freedLabel := "Freed"
+	if r.DryRun {
+		freedLabel = "Would free"
+	}
+	rows := [][2]string{{"Command", "pw " + r.Command}}
+	if r.Target != "" {
+		rows = append(rows, [2]string{"Target", r.Target})
+	}
+	rows = append(rows,
+		[2]string{"Host", r.Host},
+		[2]string{"User", r.User},
+		[2]string{"Started", r.StartedAt.Format("2006-01-02 15:04:05")},
+		[2]string{"Duration", r.Duration().Round(time.Millisecond).String()},
+		[2]string{freedLabel, core.FormatSize(r.Freed())},
+		[2]string{"Errors", fmt.Sprint(r.Count(StatusFailed))},
+		[2]string{"Skipped", fmt.Sprint(r.Count(StatusSkipped))},
+	)
+	return rows
+}
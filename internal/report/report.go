@@ -0,0 +1,208 @@
+// Package report records what a cleanup run did and renders it as an HTML
+// or Markdown document for audit trails. Commands create a Report before
+// deleting anything, record every item as it is cleaned, fails or is
+// skipped, and save it once the run is over.
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of a single report entry.
+type Status string
+
+const (
+	StatusCleaned Status = "cleaned"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+	StatusPlanned Status = "planned" // Dry run: would have been cleaned.
+)
+
+// Entry is one item handled by the run.
+type Entry struct {
+	Group  string
+	Path   string
+	Size   int64
+	Status Status
+	Detail string // Error message or skip reason.
+}
+
+// GroupSummary totals the entries of one group.
+type GroupSummary struct {
+	Name    string
+	Freed   int64
+	Cleaned int
+	Failed  int
+	Skipped int
+}
+
+// Report describes one cleanup run. A nil *Report is valid and records
+// nothing, so callers need not check whether reporting was requested.
+type Report struct {
+	Command    string
+	Target     string
+	Host       string
+	User       string
+	DryRun     bool
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Entries    []Entry
+}
+
+// New starts a report for command, stamped with the current time, host and
+// user.
+func New(command string) *Report {
+	host, _ := os.Hostname()
+	return &Report{
+		Command:   command,
+		Host:      host,
+		User:      os.Getenv("USERNAME"),
+		StartedAt: time.Now(),
+	}
+}
+
+// Cleaned records a removed item and the bytes it freed.
+func (r *Report) Cleaned(group, path string, size int64) {
+	r.add(Entry{Group: group, Path: path, Size: size, Status: StatusCleaned})
+}
+
+// Planned records an item a dry run would have removed.
+func (r *Report) Planned(group, path string, size int64) {
+	r.add(Entry{Group: group, Path: path, Size: size, Status: StatusPlanned})
+}
+
+// Failed records an item that could not be removed.
+func (r *Report) Failed(group, path string, size int64, err error) {
+	detail := ""
+	if err != nil {
+		detail = err.Error()
+	}
+	r.add(Entry{Group: group, Path: path, Size: size, Status: StatusFailed, Detail: detail})
+}
+
+// Skipped records an item that was deliberately left alone.
+func (r *Report) Skipped(group, path, reason string) {
+	r.add(Entry{Group: group, Path: path, Status: StatusSkipped, Detail: reason})
+}
+
+func (r *Report) add(e Entry) {
+	if r != nil {
+		r.Entries = append(r.Entries, e)
+	}
+}
+
+// Finish stamps the end time of the run.
+func (r *Report) Finish() {
+	if r != nil {
+		r.FinishedAt = time.Now()
+	}
+}
+
+// Duration returns how long the run took, or zero if it has not finished.
+func (r *Report) Duration() time.Duration {
+	if r.FinishedAt.IsZero() {
+		return 0
+	}
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Freed returns the bytes freed by cleaned entries, or the bytes a dry run
+// would have freed.
+func (r *Report) Freed() int64 {
+	var total int64
+	for _, e := range r.Entries {
+		if e.Status == StatusCleaned || e.Status == StatusPlanned {
+			total += e.Size
+		}
+	}
+	return total
+}
+
+// Count returns the number of entries with the given status.
+func (r *Report) Count(s Status) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Status == s {
+			n++
+		}
+	}
+	return n
+}
+
+// WithStatus returns the entries with the given status, in recorded order.
+func (r *Report) WithStatus(s Status) []Entry {
+	var out []Entry
+	for _, e := range r.Entries {
+		if e.Status == s {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Groups summarizes the entries per group, largest freed first.
+func (r *Report) Groups() []GroupSummary {
+	index := make(map[string]int)
+	var groups []GroupSummary
+	for _, e := range r.Entries {
+		i, ok := index[e.Group]
+		if !ok {
+			i = len(groups)
+			index[e.Group] = i
+			groups = append(groups, GroupSummary{Name: e.Group})
+		}
+		g := &groups[i]
+		switch e.Status {
+		case StatusCleaned, StatusPlanned:
+			g.Freed += e.Size
+			g.Cleaned++
+		case StatusFailed:
+			g.Failed++
+		case StatusSkipped:
+			g.Skipped++
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Freed > groups[j].Freed })
+	return groups
+}
+
+// Save writes the report to path. The format follows the extension:
+// .html/.htm for HTML, .md/.markdown for Markdown.
+func (r *Report) Save(path string) error {
+	if err := CheckPath(path); err != nil {
+		return err
+	}
+	write := writeMarkdown
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".html" || ext == ".htm" {
+		write = writeHTML
+	}
+
+	var sb strings.Builder
+	if err := write(r, &sb); err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("cannot create report directory %s: %w", dir, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// CheckPath reports whether path has a supported report extension, so a
+// bad --report value can be rejected before any work is done.
+func CheckPath(path string) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm", ".md", ".markdown":
+		return nil
+	}
+	return fmt.Errorf("unsupported report format %q (use .html or .md)", filepath.Ext(path))
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sampleReport() *Report {
+	r := &Report{Command: "clean --all", Host: "pc", User: "alice"}
+	r.Cleaned("UserTemp", `C:\Temp\a.tmp`, 300)
+	r.Cleaned("NpmCache", `C:\npm\b`, 1000)
+	r.Failed("UserTemp", `C:\Temp\locked.tmp`, 50, errors.New("access denied"))
+	r.Skipped("Browsers", "Chrome", "browser is running")
+	r.Finish()
+	return r
+}
+
+func TestReport_Totals(t *testing.T) {
+	r := sampleReport()
+
+	if got := r.Freed(); got != 1300 {
+		t.Errorf("Freed() = %d, want 1300", got)
+	}
+	if got := r.Count(StatusFailed); got != 1 {
+		t.Errorf("Count(failed) = %d, want 1", got)
+	}
+
+	groups := r.Groups()
+	if len(groups) != 3 {
+		t.Fatalf("got %d groups, want 3", len(groups))
+	}
+	if groups[0].Name != "NpmCache" || groups[1].Name != "UserTemp" {
+		t.Errorf("groups not sorted by freed size: %+v", groups)
+	}
+	if g := groups[1]; g.Cleaned != 1 || g.Failed != 1 || g.Freed != 300 {
+		t.Errorf("UserTemp summary = %+v", g)
+	}
+}
+
+func TestReport_NilIsNoop(t *testing.T) {
+	var r *Report
+	r.Cleaned("x", "y", 1)
+	r.Skipped("x", "y", "z")
+	r.Finish()
+}
+
+func TestReport_Save(t *testing.T) {
+	dir := t.TempDir()
+	r := sampleReport()
+
+	md := filepath.Join(dir, "out.md")
+	if err := r.Save(md); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(md)
+	for _, want := range []string{"# PureWin Cleanup Report", "## Errors (1)", "access denied", "browser is running", `C:\npm\b`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("markdown report missing %q", want)
+		}
+	}
+
+	html := filepath.Join(dir, "out.html")
+	r.Skipped("Browsers", "<script>", "x")
+	if err := r.Save(html); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(html)
+	if !strings.Contains(string(data), "<h2>Categories</h2>") || strings.Contains(string(data), "<script>") {
+		t.Error("HTML report missing sections or not escaped")
+	}
+
+	if err := r.Save(filepath.Join(dir, "out.txt")); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
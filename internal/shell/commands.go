@@ -34,14 +34,14 @@ func AllCommands() []CmdDef {
 		{
 			Name:        "clean",
 			Description: "Deep clean system caches and temp files",
-			Usage:       "/clean [--dry-run] [--all|--user|--browser|--dev|--system]",
+			Usage:       "/clean [--dry-run] [--all|--user|--browser|--dev|--apps|--system|--ai]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
 		{
 			Name:        "uninstall",
 			Description: "Remove installed applications",
-			Usage:       "/uninstall [--search name] [--quiet]",
+			Usage:       "/uninstall [--search name] [--quiet] [--stale]",
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
@@ -52,6 +52,13 @@ func AllCommands() []CmdDef {
 			Mode:        ExecCobra,
 			AdminHint:   true,
 		},
+		{
+			Name:        "privacy",
+			Description: "Audit and turn off telemetry settings",
+			Usage:       "/privacy [--apply] [setting...]",
+			Mode:        ExecCobra,
+			AdminHint:   true,
+		},
 		{
 			Name:        "analyze",
 			Description: "Explore disk space usage",
@@ -64,18 +71,44 @@ func AllCommands() []CmdDef {
 			Usage:       "/status [--json]",
 			Mode:        ExecCobra,
 		},
+		{
+			Name:        "tui",
+			Description: "Status, analyze, clean and uninstall in one app",
+			Usage:       "/tui [path]",
+			Mode:        ExecCobra,
+		},
 		{
 			Name:        "purge",
 			Description: "Clean project build artifacts",
 			Usage:       "/purge [--dry-run] [--min-age days] [--min-size bytes]",
 			Mode:        ExecCobra,
 		},
+		{
+			Name:        "dupes",
+			Description: "Find duplicate files",
+			Usage:       "/dupes [path...] [--dry-run] [--min-size size] [--link]",
+			Mode:        ExecCobra,
+		},
 		{
 			Name:        "installer",
 			Description: "Find and remove old installer files",
 			Usage:       "/installer [--dry-run] [--min-age days]",
 			Mode:        ExecCobra,
 		},
+		{
+			Name:        "maintain",
+			Description: "Run clean, purge, installer, remnants and optimize together",
+			Usage:       "/maintain [--dry-run] [--json] [--only modules] [--skip modules]",
+			Mode:        ExecCobra,
+			AdminHint:   true,
+		},
+		{
+			Name:        "schedule",
+			Description: "Run cleanups on a schedule",
+			Usage:       "/schedule [add <name> --user --every daily|list|remove <name>]",
+			Mode:        ExecCobra,
+			AdminHint:   true,
+		},
 		{
 			Name:        "update",
 			Description: "Check for PureWin updates",
@@ -0,0 +1,128 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── External Commands ───────────────────────────────────────────────────────
+// Input starting with "!" runs through cmd.exe in the background. Output is
+// captured and appended to the scrollback when the command finishes, so the
+// shell never hands over the terminal for quick queries like !ipconfig.
+
+const (
+	// externalPrefix marks input as an external command.
+	externalPrefix = "!"
+
+	// externalTimeout bounds how long an external command may run.
+	externalTimeout = 2 * time.Minute
+
+	// externalWaitDelay bounds how long output is still collected after the
+	// command is killed, in case a process it started holds the pipe open.
+	externalWaitDelay = 2 * time.Second
+
+	// maxExternalLines caps captured output so a chatty command can't
+	// flush the rest of the scrollback.
+	maxExternalLines = 2000
+)
+
+// externalDoneMsg carries the result of a finished external command.
+type externalDoneMsg struct {
+	output   []byte
+	err      error
+	elapsed  time.Duration
+	timedOut bool
+	canceled bool
+}
+
+// startExternal launches line via cmd.exe and returns a cancel function and
+// the tea.Cmd that waits for it. The command line is passed verbatim with
+// /S so cmd.exe strips only the outer quotes and pipes, redirects and
+// quoting behave as they would at a normal prompt. Cancelling kills the
+// whole process tree, not only cmd.exe.
+func startExternal(line string) (context.CancelFunc, tea.Cmd) {
+	ctx, cancel := context.WithTimeout(context.Background(), externalTimeout)
+
+	run := func() tea.Msg {
+		defer cancel()
+		start := time.Now()
+
+		cmd := exec.CommandContext(ctx, "cmd.exe")
+		cmd.SysProcAttr = &syscall.SysProcAttr{
+			CmdLine:    `cmd.exe /D /S /C "` + line + `"`,
+			HideWindow: true,
+		}
+		cmd.Cancel = func() error {
+			_ = exec.Command("taskkill.exe", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+			return cmd.Process.Kill()
+		}
+		cmd.WaitDelay = externalWaitDelay
+		output, err := cmd.CombinedOutput()
+
+		return externalDoneMsg{
+			output:   output,
+			err:      err,
+			elapsed:  time.Since(start),
+			timedOut: errors.Is(ctx.Err(), context.DeadlineExceeded),
+			canceled: errors.Is(ctx.Err(), context.Canceled),
+		}
+	}
+	return cancel, run
+}
+
+// handleExternalDone appends captured output and the exit status to the
+// scrollback.
+func (m *ShellModel) handleExternalDone(msg externalDoneMsg) {
+	m.running = ""
+	m.cancelRunning = nil
+
+	lines := splitOutputLines(msg.output)
+	truncated := 0
+	if len(lines) > maxExternalLines {
+		truncated = len(lines) - maxExternalLines
+		lines = lines[len(lines)-maxExternalLines:]
+	}
+	if truncated > 0 {
+		m.AppendOutput(fmt.Sprintf("  … %d earlier lines omitted", truncated))
+	}
+	for _, l := range lines {
+		m.AppendOutput("  " + l)
+	}
+
+	switch {
+	case msg.timedOut:
+		m.AppendOutput(fmt.Sprintf("  Command timed out after %s.", externalTimeout))
+	case msg.canceled:
+		m.AppendOutput("  Command cancelled.")
+	case msg.err != nil:
+		var exitErr *exec.ExitError
+		if errors.As(msg.err, &exitErr) {
+			m.AppendOutput(fmt.Sprintf("  Exited with code %d (%s).",
+				exitErr.ExitCode(), msg.elapsed.Round(time.Millisecond)))
+		} else {
+			m.AppendOutput("  Command failed: " + msg.err.Error())
+		}
+	}
+	m.AppendOutput("")
+}
+
+// splitOutputLines normalizes CRLF output into lines, expanding tabs and
+// dropping trailing blank lines.
+func splitOutputLines(output []byte) []string {
+	text := strings.ReplaceAll(string(output), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = strings.ReplaceAll(text, "\t", "    ")
+	text = strings.TrimRight(text, "\n ")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
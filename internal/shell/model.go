@@ -1,6 +1,7 @@
 package shell
 
 import (
+	"context"
 	"os"
 	"strings"
 
@@ -36,6 +37,10 @@ type ShellModel struct {
 	ExecCmd  string   // cobra command name (e.g., "clean")
 	ExecArgs []string // additional args (e.g., ["--dry-run"])
 
+	// External command in flight ("!ipconfig"); empty when idle.
+	running       string
+	cancelRunning context.CancelFunc
+
 	// State
 	Quitting  bool
 	Width     int
@@ -86,6 +91,10 @@ func (m ShellModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+
+	case externalDoneMsg:
+		m.handleExternalDone(msg)
+		return m, nil
 	}
 
 	// Pass to text input for cursor blink etc.
@@ -98,6 +107,14 @@ func (m ShellModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m ShellModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	// ── Ctrl+C cancels a running external command before quitting ──
+	if key == "ctrl+c" && m.running != "" {
+		if m.cancelRunning != nil {
+			m.cancelRunning()
+		}
+		return m, nil
+	}
+
 	// ── Global quit ──
 	if key == "ctrl+c" {
 		m.Quitting = true
@@ -232,6 +249,10 @@ func (m ShellModel) executeInput() (tea.Model, tea.Cmd) {
 	if raw == "" {
 		return m, nil
 	}
+	if m.running != "" {
+		m.AppendOutput("  Still running: " + m.running + " (ctrl+c to cancel)")
+		return m, nil
+	}
 
 	// Add to history (dedup consecutive, cap at 500).
 	if len(m.CmdHistory) == 0 || m.CmdHistory[len(m.CmdHistory)-1] != raw {
@@ -245,9 +266,23 @@ func (m ShellModel) executeInput() (tea.Model, tea.Cmd) {
 	// Record in output.
 	m.AppendOutput("pw \u276f " + raw)
 
+	// External command: run through cmd.exe and capture the output.
+	if strings.HasPrefix(raw, externalPrefix) {
+		line := strings.TrimSpace(strings.TrimPrefix(raw, externalPrefix))
+		m.textInput.SetValue("")
+		if line == "" {
+			m.AppendOutput("  Usage: !<command>  (e.g. !ipconfig /all)")
+			return m, nil
+		}
+		m.running = line
+		var run tea.Cmd
+		m.cancelRunning, run = startExternal(line)
+		return m, run
+	}
+
 	// Parse slash command.
 	if !strings.HasPrefix(raw, "/") {
-		m.AppendOutput("  Unknown input. Type / for available commands.")
+		m.AppendOutput("  Unknown input. Type / for commands or ! to run a system command.")
 		m.textInput.SetValue("")
 		return m, nil
 	}
@@ -325,6 +360,7 @@ func (m *ShellModel) showHelp() {
 		}
 		m.AppendOutput("    /" + padRight(cmd.Name, 12) + cmd.Description + admin)
 	}
+	m.AppendOutput("    !" + padRight("<command>", 12) + "Run a system command (e.g. !ipconfig /all)")
 	m.AppendOutput("")
 	m.AppendOutput("  Type / to see autocomplete suggestions.")
 	m.AppendOutput("")
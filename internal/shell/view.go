@@ -105,9 +105,11 @@ var cmdIcons = map[string]string{
 	"clean":     ui.IconTrash,
 	"uninstall": ui.IconFolder,
 	"optimize":  ui.IconArrow,
+	"privacy":   ui.IconDot,
 	"analyze":   ui.IconDiamond,
 	"status":    ui.IconDot,
 	"purge":     ui.IconTrash,
+	"dupes":     ui.IconDiamond,
 	"installer": ui.IconFolder,
 	"update":    ui.IconReload,
 	"version":   ui.IconDiamond,
@@ -375,6 +377,7 @@ func (m ShellModel) renderWelcomeTips(w int) string {
 		{"/", "see all commands"},
 		{"/clean --dry-run", "preview cleanup"},
 		{"/status", "live system monitor"},
+		{"!ipconfig", "run a system command"},
 	}
 
 	var lines []string
@@ -632,9 +635,19 @@ func (m ShellModel) renderStatusBar(_ int) string {
 		parts = append(parts, statusAdmin.Render(ui.IconDot+" admin"))
 	}
 
+	// Running external command.
+	if m.running != "" {
+		running := m.running
+		if r := []rune(running); len(r) > 30 {
+			running = string(r[:29]) + "…"
+		}
+		parts = append(parts, statusAdmin.Render(ui.IconDot+" running "+running))
+	}
+
 	// Key hints.
 	hints := []struct{ key, desc string }{
 		{"/", "commands"},
+		{"!", "system"},
 		{"↑↓", "history"},
 		{"pgup/dn", "scroll"},
 		{"ctrl+c", "quit"},
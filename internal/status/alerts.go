@@ -0,0 +1,539 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AlertFileName is the alert history file inside the config directory.
+const AlertFileName = "alerts.json"
+
+// maxAlertHistory caps the number of alerts kept on disk.
+const maxAlertHistory = 200
+
+// ─── Rules ───────────────────────────────────────────────────────────────────
+// Rules come from alert_rules.json in the config directory when it exists,
+// otherwise DefaultAlertRules applies:
+//
+//	[
+//	  {"metric": "cpu", "threshold": 90, "for": "5m", "notify": true},
+//	  {"metric": "disk_free", "threshold": 10, "below": true},
+//	  {"metric": "memory", "threshold": 95, "for": "1m"}
+//	]
+//
+// A rule fires once its metric has been past the threshold for the whole
+// "for" duration (immediately when omitted) and ends when it returns.
+
+// AlertRulesFileName is the rule file inside the config directory.
+const AlertRulesFileName = "alert_rules.json"
+
+// alertMetric reads one alertable value from a sample, with a detail such
+// as the drive it was measured on.
+type alertMetric struct {
+	label string
+	value func(m *SystemMetrics) (float64, string, bool) // false: not available
+}
+
+// alertMetrics are the metrics rules can watch, all in percent.
+var alertMetrics = map[string]alertMetric{
+	"cpu": {"CPU usage", func(m *SystemMetrics) (float64, string, bool) {
+		return m.CPU.TotalPercent, "", true
+	}},
+	"memory": {"Memory usage", func(m *SystemMetrics) (float64, string, bool) {
+		return m.Memory.UsedPercent, "", true
+	}},
+	"swap": {"Swap usage", func(m *SystemMetrics) (float64, string, bool) {
+		return m.Memory.SwapPercent, "", m.Memory.SwapTotal > 0
+	}},
+	"disk": {"Disk usage", func(m *SystemMetrics) (float64, string, bool) {
+		var peak float64
+		var path string
+		for _, p := range m.Disk.Partitions {
+			if p.UsedPercent > peak {
+				peak, path = p.UsedPercent, p.Path
+			}
+		}
+		return peak, path, len(m.Disk.Partitions) > 0
+	}},
+	"disk_free": {"Disk free", func(m *SystemMetrics) (float64, string, bool) {
+		low := 100.0
+		var path string
+		for _, p := range m.Disk.Partitions {
+			if free := 100 - p.UsedPercent; free < low {
+				low, path = free, p.Path
+			}
+		}
+		return low, path, len(m.Disk.Partitions) > 0
+	}},
+	"gpu_memory": {"GPU memory", func(m *SystemMetrics) (float64, string, bool) {
+		if m.GPU.VRAMTotal == 0 || m.GPU.VRAMUsed == 0 {
+			return 0, "", false
+		}
+		return float64(m.GPU.VRAMUsed) / float64(m.GPU.VRAMTotal) * 100, m.GPU.Name, true
+	}},
+	"battery": {"Battery charge", func(m *SystemMetrics) (float64, string, bool) {
+		return float64(m.Battery.Charge), "", m.Battery.HasBattery && !m.Battery.IsCharging
+	}},
+}
+
+// AlertRule fires when a metric stays above Threshold — or below it, for
+// Below rules — for the For duration.
+type AlertRule struct {
+	Metric    string  `json:"metric"`
+	Label     string  `json:"label,omitempty"`
+	Threshold float64 `json:"threshold"`
+	Below     bool    `json:"below,omitempty"`
+	For       string  `json:"for,omitempty"`    // e.g. "5m"; empty fires at once
+	Notify    bool    `json:"notify,omitempty"` // show a Windows notification
+
+	sustain time.Duration
+	metric  alertMetric
+}
+
+// DefaultAlertRules returns the built-in threshold rules.
+func DefaultAlertRules() []AlertRule {
+	rules := []AlertRule{
+		{Metric: "cpu", Threshold: 90, For: "5m"},
+		{Metric: "memory", Threshold: 95, For: "1m"},
+		{Metric: "swap", Threshold: 80, For: "1m"},
+		{Metric: "disk_free", Threshold: 10, Below: true},
+	}
+	for i := range rules {
+		_ = rules[i].prepare()
+	}
+	return rules
+}
+
+// prepare validates the rule and resolves its metric and duration.
+func (r *AlertRule) prepare() error {
+	metric, ok := alertMetrics[r.Metric]
+	if !ok {
+		names := make([]string, 0, len(alertMetrics))
+		for name := range alertMetrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown alert metric %q (use %s)", r.Metric, strings.Join(names, ", "))
+	}
+	if r.Threshold < 0 || r.Threshold > 100 {
+		return fmt.Errorf("alert threshold %v for %s is not a percentage", r.Threshold, r.Metric)
+	}
+	r.sustain = 0
+	if r.For != "" {
+		d, err := time.ParseDuration(r.For)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid duration %q for %s alert (use e.g. 30s or 5m)", r.For, r.Metric)
+		}
+		r.sustain = d
+	}
+	if r.Label == "" {
+		r.Label = metric.label
+	}
+	r.metric = metric
+	return nil
+}
+
+// breached reports whether v is past the rule's threshold.
+func (r AlertRule) breached(v float64) bool {
+	if r.Below {
+		return v < r.Threshold
+	}
+	return v > r.Threshold
+}
+
+// worse reports whether v is further past the threshold than peak.
+func (r AlertRule) worse(v, peak float64) bool {
+	if r.Below {
+		return v < peak
+	}
+	return v > peak
+}
+
+// LoadAlertRules reads the rule file. A missing file yields the default
+// rules.
+func LoadAlertRules(path string) ([]AlertRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultAlertRules(), nil
+		}
+		return nil, fmt.Errorf("cannot read alert rules %s: %w", path, err)
+	}
+	var rules []AlertRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse alert rules %s: %w", path, err)
+	}
+	seen := make(map[string]bool)
+	for i := range rules {
+		if err := rules[i].prepare(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		key := rules[i].key()
+		if seen[key] {
+			return nil, fmt.Errorf("%s: more than one %s rule", path, key)
+		}
+		seen[key] = true
+	}
+	return rules, nil
+}
+
+// key identifies the rule among the active alerts: its metric and
+// direction, so one metric can have both an upper and a lower bound.
+func (r AlertRule) key() string {
+	if r.Below {
+		return r.Metric + "<"
+	}
+	return r.Metric
+}
+
+// ─── Alerts ──────────────────────────────────────────────────────────────────
+
+// Alert is a single threshold breach.
+type Alert struct {
+	ID           int64     `json:"id"`
+	Metric       string    `json:"metric"`
+	Label        string    `json:"label"`
+	Detail       string    `json:"detail,omitempty"`
+	Threshold    float64   `json:"threshold"`
+	Below        bool      `json:"below,omitempty"` // Peak is the lowest value
+	Peak         float64   `json:"peak"`
+	StartedAt    time.Time `json:"started_at"`
+	EndedAt      time.Time `json:"ended_at,omitempty"`
+	Acknowledged bool      `json:"acknowledged"`
+}
+
+// Active reports whether the breach is still ongoing.
+func (a Alert) Active() bool {
+	return a.EndedAt.IsZero()
+}
+
+// String summarizes the alert on one line.
+func (a Alert) String() string {
+	s := fmt.Sprintf("%s peaked at %.1f%% (threshold %.0f%%)", a.Label, a.Peak, a.Threshold)
+	if a.Below {
+		s = fmt.Sprintf("%s fell to %.1f%% (threshold %.0f%%)", a.Label, a.Peak, a.Threshold)
+	}
+	if a.Detail != "" {
+		s += " on " + a.Detail
+	}
+	return s
+}
+
+// AlertTracker evaluates rules against each metrics sample and persists
+// fired alerts so they survive restarts. It is safe for concurrent use and
+// meant to be shared by pointer between model copies.
+type AlertTracker struct {
+	mu       sync.Mutex
+	path     string
+	rules    []AlertRule
+	breaches map[string]time.Time // rule key → when the ongoing breach began
+	active   map[string]int64     // rule key → ID of the ongoing alert
+	alerts   []Alert              // oldest first
+	nextID   int64
+
+	// notify, when set, is called with every alert a Notify rule fires.
+	notify func(Alert)
+
+	// loadErr is set when the history on disk could not be read. Saving is
+	// refused so the unreadable file is left for the user to inspect.
+	loadErr error
+}
+
+// LoadAlertTracker reads the alert history at path (missing is fine) and
+// returns a tracker using the default rules. Alerts left active by a
+// previous run are closed, since their end time is unknown. When the file
+// exists but cannot be read or parsed, the error is returned along with a
+// tracker that still evaluates rules but never writes over the file.
+func LoadAlertTracker(path string) (*AlertTracker, error) {
+	t := &AlertTracker{
+		path:     path,
+		rules:    DefaultAlertRules(),
+		breaches: make(map[string]time.Time),
+		active:   make(map[string]int64),
+		nextID:   1,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+		t.loadErr = fmt.Errorf("cannot read alert history %s: %w", path, err)
+		return t, t.loadErr
+	}
+	if err := json.Unmarshal(data, &t.alerts); err != nil {
+		t.alerts = nil
+		t.loadErr = fmt.Errorf("failed to parse alert history %s: %w", path, err)
+		return t, t.loadErr
+	}
+
+	for i := range t.alerts {
+		if t.alerts[i].Active() {
+			t.alerts[i].EndedAt = t.alerts[i].StartedAt
+		}
+		if t.alerts[i].ID >= t.nextID {
+			t.nextID = t.alerts[i].ID + 1
+		}
+	}
+	return t, nil
+}
+
+// SetRules replaces the rules, e.g. with those of LoadAlertRules. Ongoing
+// alerts of rules that no longer exist end at the next sample.
+func (t *AlertTracker) SetRules(rules []AlertRule) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rules = rules
+}
+
+// SetNotifier sets the function called with every alert fired by a rule
+// with Notify set, or by any rule when all is true.
+func (t *AlertTracker) SetNotifier(notify func(Alert), all bool) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if all {
+		for i := range t.rules {
+			t.rules[i].Notify = true
+		}
+	}
+	t.notify = notify
+}
+
+// Observe evaluates every rule against m. It returns true when an alert
+// fired or ended, i.e. when the history changed.
+func (t *AlertTracker) Observe(m *SystemMetrics) bool {
+	if t == nil || m == nil {
+		return false
+	}
+	t.mu.Lock()
+	changed := false
+	var fired []Alert
+	now := m.CollectedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	live := make(map[string]bool, len(t.rules))
+	for _, r := range t.rules {
+		key := r.key()
+		live[key] = true
+		v, detail, ok := r.metric.value(m)
+		id, isActive := t.active[key]
+
+		if !ok || !r.breached(v) {
+			delete(t.breaches, key)
+			if isActive {
+				t.end(key, id, now)
+				changed = true
+			}
+			continue
+		}
+
+		if isActive {
+			if a := t.find(id); a != nil && r.worse(v, a.Peak) {
+				a.Peak = v
+				if detail != "" {
+					a.Detail = detail
+				}
+			}
+			continue
+		}
+		since, ok := t.breaches[key]
+		if !ok {
+			since = now
+			t.breaches[key] = now
+		}
+		if now.Sub(since) >= r.sustain {
+			a := Alert{
+				ID:        t.nextID,
+				Metric:    r.Metric,
+				Label:     r.Label,
+				Detail:    detail,
+				Threshold: r.Threshold,
+				Below:     r.Below,
+				Peak:      v,
+				StartedAt: since,
+			}
+			t.alerts = append(t.alerts, a)
+			t.active[key] = t.nextID
+			t.nextID++
+			changed = true
+			if r.Notify {
+				fired = append(fired, a)
+			}
+		}
+	}
+	for key, id := range t.active {
+		if !live[key] {
+			t.end(key, id, now)
+			changed = true
+		}
+	}
+
+	if changed {
+		t.trim()
+		_ = t.save()
+	}
+	notify := t.notify
+	t.mu.Unlock()
+
+	if notify != nil {
+		for _, a := range fired {
+			notify(a)
+		}
+	}
+	return changed
+}
+
+// end closes the ongoing alert of a rule. Caller must hold t.mu.
+func (t *AlertTracker) end(key string, id int64, now time.Time) {
+	if a := t.find(id); a != nil {
+		a.EndedAt = now
+	}
+	delete(t.active, key)
+}
+
+// Active returns the ongoing alerts, newest first.
+func (t *AlertTracker) Active() []Alert {
+	var out []Alert
+	for _, a := range t.Alerts() {
+		if a.Active() {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Alerts returns a copy of the history, newest first.
+func (t *AlertTracker) Alerts() []Alert {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]Alert, len(t.alerts))
+	for i, a := range t.alerts {
+		out[len(t.alerts)-1-i] = a
+	}
+	return out
+}
+
+// Unacknowledged returns the number of alerts not yet acknowledged.
+func (t *AlertTracker) Unacknowledged() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := 0
+	for _, a := range t.alerts {
+		if !a.Acknowledged {
+			n++
+		}
+	}
+	return n
+}
+
+// Acknowledge marks one alert as reviewed.
+func (t *AlertTracker) Acknowledge(id int64) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	a := t.find(id)
+	if a == nil {
+		return fmt.Errorf("alert %d not found", id)
+	}
+	a.Acknowledged = true
+	return t.save()
+}
+
+// AcknowledgeAll marks every alert as reviewed.
+func (t *AlertTracker) AcknowledgeAll() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.alerts {
+		t.alerts[i].Acknowledged = true
+	}
+	return t.save()
+}
+
+// find returns the alert with id. Caller must hold t.mu.
+func (t *AlertTracker) find(id int64) *Alert {
+	for i := range t.alerts {
+		if t.alerts[i].ID == id {
+			return &t.alerts[i]
+		}
+	}
+	return nil
+}
+
+// trim drops the oldest acknowledged, then oldest, alerts beyond the cap.
+// Caller must hold t.mu.
+func (t *AlertTracker) trim() {
+	for len(t.alerts) > maxAlertHistory {
+		drop := 0
+		for i, a := range t.alerts {
+			if a.Acknowledged && !a.Active() {
+				drop = i
+				break
+			}
+		}
+		t.alerts = append(t.alerts[:drop], t.alerts[drop+1:]...)
+	}
+}
+
+// save writes the history atomically. It refuses when the history on disk
+// could not be loaded. Caller must hold t.mu.
+func (t *AlertTracker) save() error {
+	if t.path == "" {
+		return nil
+	}
+	if t.loadErr != nil {
+		return fmt.Errorf("not saving alerts: %w (fix or delete the file)", t.loadErr)
+	}
+	dir := filepath.Dir(t.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t.alerts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".alerts-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, t.path)
+}
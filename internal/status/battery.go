@@ -0,0 +1,128 @@
+package status
+
+import (
+	"sync"
+	"time"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// ─── Battery Health ──────────────────────────────────────────────────────────
+// Besides the charge level from Win32_Battery, laptops report the capacity
+// the battery was designed for, what it holds at a full charge today and
+// its cycle count — the figures of `powercfg /batteryreport` — through the
+// battery classes of the root\WMI namespace. Those change slowly, so they
+// are re-read every few minutes rather than every sample; the charge and
+// discharge rates are read every sample.
+
+// batteryHealthRefresh is how often the capacities and cycle count are
+// re-read.
+const batteryHealthRefresh = 5 * time.Minute
+
+// unknownRunTime is the EstimatedRunTime Win32_Battery reports while the
+// estimate is unavailable, such as on AC power.
+const unknownRunTime = 71582788
+
+// win32BatteryEx is the part of Win32_Battery read every sample.
+type win32BatteryEx struct {
+	EstimatedChargeRemaining uint16
+	BatteryStatus            uint16
+	EstimatedRunTime         uint32
+}
+
+// Battery classes of the root\WMI namespace.
+type batteryStaticData struct {
+	DesignedCapacity uint32
+}
+
+type batteryFullChargedCapacity struct {
+	FullChargedCapacity uint32
+}
+
+type batteryCycleCount struct {
+	CycleCount uint32
+}
+
+type batteryStatusWMI struct {
+	RemainingCapacity uint32
+	ChargeRate        int32
+	DischargeRate     int32
+	PowerOnline       bool
+}
+
+// batteryHealth caches the slowly changing battery figures.
+var batteryHealth struct {
+	sync.Mutex
+	readAt     time.Time
+	design     uint32
+	fullCharge uint32
+	cycles     uint32
+}
+
+// CollectBattery reads the battery of a laptop. HasBattery is false on
+// machines without one.
+func CollectBattery() BatteryInfo {
+	var batteries []win32BatteryEx
+	err := wmi.Query("SELECT EstimatedChargeRemaining, BatteryStatus, EstimatedRunTime FROM Win32_Battery", &batteries)
+	if err != nil || len(batteries) == 0 {
+		return BatteryInfo{} // desktop — no battery is fine
+	}
+	b := BatteryInfo{
+		HasBattery: true,
+		Charge:     batteries[0].EstimatedChargeRemaining,
+		IsCharging: batteries[0].BatteryStatus == 2,
+	}
+
+	var status []batteryStatusWMI
+	if wmi.QueryNamespace("SELECT RemainingCapacity, ChargeRate, DischargeRate, PowerOnline FROM BatteryStatus", &status, `root\WMI`) == nil && len(status) > 0 {
+		st := status[0]
+		b.RemainingCapacity = st.RemainingCapacity
+		b.OnAC = st.PowerOnline
+		switch {
+		case st.ChargeRate > 0:
+			b.Rate = st.ChargeRate
+		case st.DischargeRate > 0:
+			b.Rate = -st.DischargeRate
+		}
+	}
+
+	batteryHealth.Lock()
+	if time.Since(batteryHealth.readAt) > batteryHealthRefresh {
+		var design []batteryStaticData
+		if wmi.QueryNamespace("SELECT DesignedCapacity FROM BatteryStaticData", &design, `root\WMI`) == nil && len(design) > 0 {
+			batteryHealth.design = design[0].DesignedCapacity
+		}
+		var full []batteryFullChargedCapacity
+		if wmi.QueryNamespace("SELECT FullChargedCapacity FROM BatteryFullChargedCapacity", &full, `root\WMI`) == nil && len(full) > 0 {
+			batteryHealth.fullCharge = full[0].FullChargedCapacity
+		}
+		var cycles []batteryCycleCount
+		if wmi.QueryNamespace("SELECT CycleCount FROM BatteryCycleCount", &cycles, `root\WMI`) == nil && len(cycles) > 0 {
+			batteryHealth.cycles = cycles[0].CycleCount
+		}
+		batteryHealth.readAt = time.Now()
+	}
+	b.DesignCapacity = batteryHealth.design
+	b.FullChargeCapacity = batteryHealth.fullCharge
+	b.CycleCount = batteryHealth.cycles
+	batteryHealth.Unlock()
+
+	// Windows estimates the time to empty; the time to full follows from
+	// the charge rate.
+	if rt := batteries[0].EstimatedRunTime; rt > 0 && rt < unknownRunTime && !b.IsCharging {
+		b.TimeRemaining = time.Duration(rt) * time.Minute
+	} else if b.Rate > 0 && b.FullChargeCapacity > b.RemainingCapacity {
+		hours := float64(b.FullChargeCapacity-b.RemainingCapacity) / float64(b.Rate)
+		b.TimeRemaining = time.Duration(hours * float64(time.Hour))
+	}
+	return b
+}
+
+// WearPercent returns how much of its design capacity the battery has
+// lost, or -1 when either capacity is unknown.
+func (b BatteryInfo) WearPercent() float64 {
+	if b.DesignCapacity == 0 || b.FullChargeCapacity == 0 {
+		return -1
+	}
+	return max(100-float64(b.FullChargeCapacity)/float64(b.DesignCapacity)*100, 0)
+}
@@ -0,0 +1,526 @@
+package status
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/core"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Connections ─────────────────────────────────────────────────────────────
+// The Network tab lists the TCP and UDP endpoints of every process, as a
+// minimal TCPView: GetExtendedTcpTable and GetExtendedUdpTable give the
+// addresses, state and owning process. Per-connection throughput comes from
+// the TCP extended statistics, which Windows only collects for connections
+// they were switched on for — something only an elevated process may do —
+// so it is shown when running as administrator, and for TCP only.
+
+const (
+	tcpTableOwnerPIDAll = 5 // TCP_TABLE_OWNER_PID_ALL
+	udpTableOwnerPID    = 1 // UDP_TABLE_OWNER_PID
+	tcpEstatsData       = 1 // TcpConnectionEstatsData
+)
+
+var (
+	modIphlpapi                    = windows.NewLazySystemDLL("iphlpapi.dll")
+	procGetExtendedTcpTable        = modIphlpapi.NewProc("GetExtendedTcpTable")
+	procGetExtendedUdpTable        = modIphlpapi.NewProc("GetExtendedUdpTable")
+	procGetPerTcpConnectionEStats  = modIphlpapi.NewProc("GetPerTcpConnectionEStats")
+	procSetPerTcpConnectionEStats  = modIphlpapi.NewProc("SetPerTcpConnectionEStats")
+	procGetPerTcp6ConnectionEStats = modIphlpapi.NewProc("GetPerTcp6ConnectionEStats")
+	procSetPerTcp6ConnectionEStats = modIphlpapi.NewProc("SetPerTcp6ConnectionEStats")
+)
+
+// tcpStates names the MIB_TCP_STATE values.
+var tcpStates = map[uint32]string{
+	1:  "CLOSED",
+	2:  "LISTEN",
+	3:  "SYN_SENT",
+	4:  "SYN_RCVD",
+	5:  "ESTABLISHED",
+	6:  "FIN_WAIT1",
+	7:  "FIN_WAIT2",
+	8:  "CLOSE_WAIT",
+	9:  "CLOSING",
+	10: "LAST_ACK",
+	11: "TIME_WAIT",
+	12: "DELETE_TCB",
+}
+
+// mibTCPRowOwnerPID mirrors MIB_TCPROW_OWNER_PID. Its first five fields
+// are a MIB_TCPROW, as the extended statistics calls expect.
+type mibTCPRowOwnerPID struct {
+	State      uint32
+	LocalAddr  [4]byte
+	LocalPort  [4]byte
+	RemoteAddr [4]byte
+	RemotePort [4]byte
+	OwningPID  uint32
+}
+
+// mibTCP6RowOwnerPID mirrors MIB_TCP6ROW_OWNER_PID.
+type mibTCP6RowOwnerPID struct {
+	LocalAddr     [16]byte
+	LocalScopeID  uint32
+	LocalPort     [4]byte
+	RemoteAddr    [16]byte
+	RemoteScopeID uint32
+	RemotePort    [4]byte
+	State         uint32
+	OwningPID     uint32
+}
+
+// mibTCP6Row mirrors MIB_TCP6ROW.
+type mibTCP6Row struct {
+	State         uint32
+	LocalAddr     [16]byte
+	LocalScopeID  uint32
+	LocalPort     [4]byte
+	RemoteAddr    [16]byte
+	RemoteScopeID uint32
+	RemotePort    [4]byte
+}
+
+// mibUDPRowOwnerPID mirrors MIB_UDPROW_OWNER_PID.
+type mibUDPRowOwnerPID struct {
+	LocalAddr [4]byte
+	LocalPort [4]byte
+	OwningPID uint32
+}
+
+// mibUDP6RowOwnerPID mirrors MIB_UDP6ROW_OWNER_PID.
+type mibUDP6RowOwnerPID struct {
+	LocalAddr    [16]byte
+	LocalScopeID uint32
+	LocalPort    [4]byte
+	OwningPID    uint32
+}
+
+// tcpEstatsDataROD mirrors TCP_ESTATS_DATA_ROD_v0.
+type tcpEstatsDataROD struct {
+	DataBytesOut      uint64
+	DataSegsOut       uint64
+	DataBytesIn       uint64
+	DataSegsIn        uint64
+	SegsOut           uint64
+	SegsIn            uint64
+	SoftErrors        uint32
+	SoftErrorReason   uint32
+	SndUna            uint32
+	SndNxt            uint32
+	SndMax            uint32
+	ThruBytesAcked    uint64
+	RcvNxt            uint32
+	ThruBytesReceived uint64
+}
+
+// Connection is one TCP connection or listener, or one UDP endpoint.
+type Connection struct {
+	Proto   string         `json:"proto"` // TCP, TCP6, UDP or UDP6
+	Local   netip.AddrPort `json:"local"`
+	Remote  netip.AddrPort `json:"remote"` // invalid for UDP
+	State   string         `json:"state"`  // "" for UDP
+	PID     int32          `json:"pid"`
+	Process string         `json:"process"`
+
+	// Bytes moved since statistics were switched on for the connection and
+	// the rates since the previous sample. Only set when HasStats is.
+	HasStats bool   `json:"has_stats"`
+	BytesIn  uint64 `json:"bytes_in"`
+	BytesOut uint64 `json:"bytes_out"`
+	RecvRate uint64 `json:"recv_rate"`
+	SendRate uint64 `json:"send_rate"`
+}
+
+// key identifies the connection across samples.
+func (c Connection) key() string {
+	return c.Proto + " " + c.Local.String() + " " + c.Remote.String()
+}
+
+// ConnectionTable is one sample of the connections of the machine.
+type ConnectionTable struct {
+	Conns       []Connection `json:"connections"`
+	Throughput  bool         `json:"throughput"` // per-connection rates are measured
+	CollectedAt time.Time    `json:"collected_at"`
+}
+
+// CollectConnections lists the TCP and UDP endpoints of the machine. prev,
+// the previous sample, gives the per-connection rates.
+func CollectConnections(prev *ConnectionTable) (*ConnectionTable, error) {
+	t := &ConnectionTable{Throughput: core.IsElevated(), CollectedAt: time.Now()}
+	names := processNames()
+
+	var prevByKey map[string]Connection
+	var secs float64
+	if prev != nil {
+		prevByKey = make(map[string]Connection, len(prev.Conns))
+		for _, c := range prev.Conns {
+			prevByKey[c.key()] = c
+		}
+		secs = t.CollectedAt.Sub(prev.CollectedAt).Seconds()
+	}
+
+	tcp4, err := tcpTable(windows.AF_INET)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the TCP table: %w", err)
+	}
+	for _, r := range tcp4 {
+		c := Connection{
+			Proto:  "TCP",
+			Local:  addrPort4(r.LocalAddr, r.LocalPort),
+			Remote: addrPort4(r.RemoteAddr, r.RemotePort),
+			State:  tcpStates[r.State],
+			PID:    int32(r.OwningPID),
+		}
+		if t.Throughput && r.State == 5 {
+			row := r // MIB_TCPROW is its prefix
+			c.BytesIn, c.BytesOut, c.HasStats = tcpStats(procGetPerTcpConnectionEStats, procSetPerTcpConnectionEStats, unsafe.Pointer(&row))
+		}
+		t.Conns = append(t.Conns, c)
+	}
+
+	if tcp6, err := tcpTable6(); err == nil {
+		for _, r := range tcp6 {
+			c := Connection{
+				Proto:  "TCP6",
+				Local:  addrPort6(r.LocalAddr, r.LocalScopeID, r.LocalPort),
+				Remote: addrPort6(r.RemoteAddr, r.RemoteScopeID, r.RemotePort),
+				State:  tcpStates[r.State],
+				PID:    int32(r.OwningPID),
+			}
+			if t.Throughput && r.State == 5 {
+				row := mibTCP6Row{
+					State:     r.State,
+					LocalAddr: r.LocalAddr, LocalScopeID: r.LocalScopeID, LocalPort: r.LocalPort,
+					RemoteAddr: r.RemoteAddr, RemoteScopeID: r.RemoteScopeID, RemotePort: r.RemotePort,
+				}
+				c.BytesIn, c.BytesOut, c.HasStats = tcpStats(procGetPerTcp6ConnectionEStats, procSetPerTcp6ConnectionEStats, unsafe.Pointer(&row))
+			}
+			t.Conns = append(t.Conns, c)
+		}
+	}
+
+	if udp4, err := udpTable(); err == nil {
+		for _, r := range udp4 {
+			t.Conns = append(t.Conns, Connection{Proto: "UDP", Local: addrPort4(r.LocalAddr, r.LocalPort), PID: int32(r.OwningPID)})
+		}
+	}
+	if udp6, err := udpTable6(); err == nil {
+		for _, r := range udp6 {
+			t.Conns = append(t.Conns, Connection{Proto: "UDP6", Local: addrPort6(r.LocalAddr, r.LocalScopeID, r.LocalPort), PID: int32(r.OwningPID)})
+		}
+	}
+
+	for i := range t.Conns {
+		c := &t.Conns[i]
+		c.Process = names[uint32(c.PID)]
+		if c.PID == 4 {
+			c.Process = "System"
+		}
+		if p, ok := prevByKey[c.key()]; ok && c.HasStats && p.HasStats && secs > 0 &&
+			c.BytesIn >= p.BytesIn && c.BytesOut >= p.BytesOut {
+			c.RecvRate = uint64(float64(c.BytesIn-p.BytesIn) / secs)
+			c.SendRate = uint64(float64(c.BytesOut-p.BytesOut) / secs)
+		}
+	}
+	return t, nil
+}
+
+// extendedTable calls GetExtendedTcpTable or GetExtendedUdpTable, growing
+// the buffer until the table fits. It returns the rows after the entry
+// count.
+func extendedTable(proc *windows.LazyProc, family, class uint32) (buf []byte, n uint32, err error) {
+	size := uint32(16 * 1024)
+	for range 4 {
+		buf = make([]byte, size)
+		ret, _, _ := proc.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)), 0, uintptr(family), uintptr(class), 0)
+		switch windows.Errno(ret) {
+		case 0:
+			return buf[4:], binary.LittleEndian.Uint32(buf), nil
+		case windows.ERROR_INSUFFICIENT_BUFFER:
+			size += 4 * 1024 // connections may open meanwhile
+			continue
+		default:
+			return nil, 0, windows.Errno(ret)
+		}
+	}
+	return nil, 0, windows.ERROR_INSUFFICIENT_BUFFER
+}
+
+// rows reinterprets the rows of an extended table.
+func rows[T any](buf []byte, n uint32) []T {
+	var zero T
+	if n == 0 || uintptr(len(buf)) < uintptr(n)*unsafe.Sizeof(zero) {
+		return nil
+	}
+	return append([]T(nil), unsafe.Slice((*T)(unsafe.Pointer(&buf[0])), n)...)
+}
+
+func tcpTable(family uint32) ([]mibTCPRowOwnerPID, error) {
+	buf, n, err := extendedTable(procGetExtendedTcpTable, family, tcpTableOwnerPIDAll)
+	if err != nil {
+		return nil, err
+	}
+	return rows[mibTCPRowOwnerPID](buf, n), nil
+}
+
+func tcpTable6() ([]mibTCP6RowOwnerPID, error) {
+	buf, n, err := extendedTable(procGetExtendedTcpTable, windows.AF_INET6, tcpTableOwnerPIDAll)
+	if err != nil {
+		return nil, err
+	}
+	return rows[mibTCP6RowOwnerPID](buf, n), nil
+}
+
+func udpTable() ([]mibUDPRowOwnerPID, error) {
+	buf, n, err := extendedTable(procGetExtendedUdpTable, windows.AF_INET, udpTableOwnerPID)
+	if err != nil {
+		return nil, err
+	}
+	return rows[mibUDPRowOwnerPID](buf, n), nil
+}
+
+func udpTable6() ([]mibUDP6RowOwnerPID, error) {
+	buf, n, err := extendedTable(procGetExtendedUdpTable, windows.AF_INET6, udpTableOwnerPID)
+	if err != nil {
+		return nil, err
+	}
+	return rows[mibUDP6RowOwnerPID](buf, n), nil
+}
+
+// tcpStats reads the data counters of an established connection, switching
+// their collection on first if needed.
+func tcpStats(get, set *windows.LazyProc, row unsafe.Pointer) (in, out uint64, ok bool) {
+	var rod tcpEstatsDataROD
+	read := func() bool {
+		ret, _, _ := get.Call(uintptr(row), tcpEstatsData,
+			0, 0, 0, // no read/write parameters
+			0, 0, 0, // no static parameters
+			uintptr(unsafe.Pointer(&rod)), 0, unsafe.Sizeof(rod))
+		return ret == 0
+	}
+	if !read() || (rod.DataBytesIn == 0 && rod.DataBytesOut == 0) {
+		enable := byte(1) // TCP_ESTATS_DATA_RW_v0.EnableCollection
+		if ret, _, _ := set.Call(uintptr(row), tcpEstatsData, uintptr(unsafe.Pointer(&enable)), 0, 1, 0); ret != 0 {
+			return 0, 0, false
+		}
+		if !read() {
+			return 0, 0, false
+		}
+	}
+	return rod.DataBytesIn, rod.DataBytesOut, true
+}
+
+// addrPort4 decodes an IPv4 address and a port in network byte order.
+func addrPort4(addr, port [4]byte) netip.AddrPort {
+	return netip.AddrPortFrom(netip.AddrFrom4(addr), binary.BigEndian.Uint16(port[:2]))
+}
+
+// addrPort6 decodes an IPv6 address with its scope and a port in network
+// byte order.
+func addrPort6(addr [16]byte, scope uint32, port [4]byte) netip.AddrPort {
+	a := netip.AddrFrom16(addr)
+	if scope != 0 {
+		a = a.WithZone(fmt.Sprint(scope))
+	}
+	return netip.AddrPortFrom(a, binary.BigEndian.Uint16(port[:2]))
+}
+
+// processNames maps every running PID to its image name.
+func processNames() map[uint32]string {
+	names := make(map[uint32]string)
+	snap, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return names
+	}
+	defer windows.CloseHandle(snap)
+
+	var e windows.ProcessEntry32
+	e.Size = uint32(unsafe.Sizeof(e))
+	for err = windows.Process32First(snap, &e); err == nil; err = windows.Process32Next(snap, &e) {
+		names[e.ProcessID] = windows.UTF16ToString(e.ExeFile[:])
+	}
+	return names
+}
+
+// ─── Sorting and filtering ───────────────────────────────────────────────────
+
+// ConnSort is an order of the connection table.
+type ConnSort int
+
+const (
+	ConnSortRate ConnSort = iota
+	ConnSortProcess
+	ConnSortRemote
+	ConnSortLocalPort
+	ConnSortState
+)
+
+// ConnSortNames is the label of each order.
+var ConnSortNames = []string{"throughput", "process", "remote address", "local port", "state"}
+
+// SortConnections orders conns in place. Ties keep process order.
+func SortConnections(conns []Connection, by ConnSort) {
+	less := func(a, b Connection) bool {
+		switch by {
+		case ConnSortRate:
+			if ra, rb := a.RecvRate+a.SendRate, b.RecvRate+b.SendRate; ra != rb {
+				return ra > rb
+			}
+			if a.BytesIn+a.BytesOut != b.BytesIn+b.BytesOut {
+				return a.BytesIn+a.BytesOut > b.BytesIn+b.BytesOut
+			}
+		case ConnSortRemote:
+			if c := a.Remote.Compare(b.Remote); c != 0 {
+				return c < 0
+			}
+		case ConnSortLocalPort:
+			if a.Local.Port() != b.Local.Port() {
+				return a.Local.Port() < b.Local.Port()
+			}
+		case ConnSortState:
+			if a.State != b.State {
+				return a.State < b.State
+			}
+		}
+		if !strings.EqualFold(a.Process, b.Process) {
+			return strings.ToLower(a.Process) < strings.ToLower(b.Process)
+		}
+		return a.PID < b.PID
+	}
+	sort.SliceStable(conns, func(i, j int) bool { return less(conns[i], conns[j]) })
+}
+
+// FilterConnections keeps the connections whose protocol, addresses, state,
+// process name or PID contain every word of query, ignoring case.
+func FilterConnections(conns []Connection, query string) []Connection {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return conns
+	}
+	var out []Connection
+	for _, c := range conns {
+		text := strings.ToLower(fmt.Sprintf("%s %s %s %s %s %d", c.Proto, c.Local, c.Remote, c.State, c.Process, c.PID))
+		keep := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// connectionsMsg carries a sample of the connection table.
+type connectionsMsg struct {
+	table *ConnectionTable
+	err   error
+}
+
+// collectConnections samples the connection table. It only runs while the
+// Network tab is shown.
+func (m StatusModel) collectConnections() tea.Cmd {
+	prev := m.conns
+	return func() tea.Msg {
+		t, err := CollectConnections(prev)
+		return connectionsMsg{table: t, err: err}
+	}
+}
+
+// visibleConns returns the connection table sorted and filtered as chosen.
+func (m StatusModel) visibleConns() []Connection {
+	if m.conns == nil {
+		return nil
+	}
+	conns := FilterConnections(m.conns.Conns, m.connFilter)
+	conns = append([]Connection(nil), conns...)
+	SortConnections(conns, m.connSort)
+	return conns
+}
+
+// connViewHeight is the number of connection rows that fit below the
+// throughput summary of the Network tab.
+func (m StatusModel) connViewHeight() int {
+	return max(m.Height-24, 3)
+}
+
+// updateNetworkKey scrolls, sorts and filters the connection table.
+func (m *StatusModel) updateNetworkKey(msg tea.KeyMsg) tea.Cmd {
+	if m.connFiltering {
+		switch msg.String() {
+		case "enter":
+			m.connFiltering = false
+			m.connInput.Blur()
+		case "esc":
+			m.connFiltering = false
+			m.connInput.Blur()
+			m.connFilter = ""
+			m.connInput.SetValue("")
+		default:
+			var cmd tea.Cmd
+			m.connInput, cmd = m.connInput.Update(msg)
+			m.connFilter = m.connInput.Value()
+			m.connOffset = 0
+			return cmd
+		}
+		return nil
+	}
+
+	total := len(m.visibleConns())
+	switch msg.String() {
+	case "up", "k":
+		if m.connOffset > 0 {
+			m.connOffset--
+		}
+	case "down", "j":
+		if m.connOffset < total-m.connViewHeight() {
+			m.connOffset++
+		}
+	case "pgup":
+		m.connOffset = max(m.connOffset-m.connViewHeight(), 0)
+	case "pgdown":
+		m.connOffset = max(min(m.connOffset+m.connViewHeight(), total-m.connViewHeight()), 0)
+	case "s":
+		m.connSort = (m.connSort + 1) % ConnSort(len(ConnSortNames))
+		m.connOffset = 0
+	case "/":
+		m.connFiltering = true
+		m.connInput.SetValue(m.connFilter)
+		m.connInput.CursorEnd()
+		return m.connInput.Focus()
+	case "esc":
+		m.connFilter = ""
+		m.connInput.SetValue("")
+		m.connOffset = 0
+	}
+	return nil
+}
+
+// newConnInput creates the filter prompt of the connection table.
+func newConnInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "process, address, port or state"
+	ti.Prompt = ""
+	ti.CharLimit = 64
+	return ti
+}
+
+// CapturingInput reports whether the dashboard is editing text, in which
+// case printable keys must not trigger shortcuts.
+func (m StatusModel) CapturingInput() bool {
+	return m.connFiltering || m.procFiltering
+}
@@ -0,0 +1,60 @@
+package status
+
+import (
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Derived metrics ─────────────────────────────────────────────────────────
+
+// DerivedMetrics are the computed values published to external monitoring
+// tools, as opposed to the raw counters in SystemMetrics.
+type DerivedMetrics struct {
+	HealthScore      int      `json:"health_score"`
+	CPUPercent       float64  `json:"cpu_pct"`
+	MemoryPercent    float64  `json:"mem_pct"`
+	ReclaimableBytes int64    `json:"reclaimable_bytes"`
+	AppCPU           []AppCPU `json:"app_cpu"`
+}
+
+// maxExportedApps caps the per-app CPU list to keep event payloads small.
+const maxExportedApps = 10
+
+// Derive builds DerivedMetrics from a collection cycle and the most recent
+// reclaimable-space estimate.
+func Derive(m *SystemMetrics, reclaimable int64) DerivedMetrics {
+	apps := m.AppCPU
+	if len(apps) > maxExportedApps {
+		apps = apps[:maxExportedApps]
+	}
+	return DerivedMetrics{
+		HealthScore:      HealthScore(m),
+		CPUPercent:       m.CPU.TotalPercent,
+		MemoryPercent:    m.Memory.UsedPercent,
+		ReclaimableBytes: reclaimable,
+		AppCPU:           apps,
+	}
+}
+
+// EstimateReclaimable returns the number of bytes `pw clean --all` could
+// currently free. High-risk targets (e.g. Windows.old) and the opt-in AI
+// and prefetch categories, which --all never cleans, are excluded;
+// admin-only targets are only counted when elevated. This walks the cache
+// directories, so callers should run it far less often than CollectMetrics.
+func EstimateReclaimable(wl *whitelist.Whitelist) int64 {
+	var targets []config.CleanTarget
+	for _, t := range config.GetCleanTargets() {
+		if t.RiskLevel == "high" || t.Category == config.CategoryAI || t.Category == config.CategoryPrefetch {
+			continue
+		}
+		targets = append(targets, t)
+	}
+
+	total := clean.TotalSizeAll(clean.ScanAll(targets, wl, core.IsElevated()))
+	if rb, err := clean.ScanRecycleBin(); err == nil {
+		total += rb
+	}
+	return total
+}
@@ -0,0 +1,211 @@
+package status
+
+import (
+	"sort"
+	"time"
+	"unsafe"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Disk I/O Breakdown ──────────────────────────────────────────────────────
+// The Disk tab breaks the global read/write totals down per volume and per
+// process, to find what is thrashing the disk. Volumes are read with
+// IOCTL_DISK_PERFORMANCE, the source of the PhysicalDisk performance
+// counters: operation counts give IOPS, the idle time the busy share, and
+// the queue depth is the number of requests outstanding when sampled.
+// Processes are read with GetProcessIoCounters, which counts all of a
+// process's I/O — files, but also pipes and devices — so a process talking
+// to a device may show up without touching the disk.
+
+// ioctlDiskPerformance is IOCTL_DISK_PERFORMANCE.
+const ioctlDiskPerformance = 0x70020
+
+// topDiskProcs is how many processes the Disk tab lists.
+const topDiskProcs = 8
+
+var procGetProcessIoCounters = modKernel32.NewProc("GetProcessIoCounters")
+
+// diskPerformance mirrors DISK_PERFORMANCE.
+type diskPerformance struct {
+	BytesRead           int64
+	BytesWritten        int64
+	ReadTime            int64
+	WriteTime           int64
+	IdleTime            int64
+	ReadCount           uint32
+	WriteCount          uint32
+	QueueDepth          uint32
+	SplitCount          uint32
+	QueryTime           int64
+	StorageDeviceNumber uint32
+	StorageManagerName  [8]uint16
+	_                   uint32
+}
+
+// VolumeIO is the activity of one volume since the previous sample.
+type VolumeIO struct {
+	Volume     string  `json:"volume"`
+	ReadRate   uint64  `json:"read_rate"`
+	WriteRate  uint64  `json:"write_rate"`
+	ReadIOPS   float64 `json:"read_iops"`
+	WriteIOPS  float64 `json:"write_iops"`
+	QueueDepth uint32  `json:"queue_depth"`
+	BusyPct    float64 `json:"busy_pct"`
+}
+
+// ProcessIO is the I/O of one process since the previous sample.
+type ProcessIO struct {
+	PID       int32   `json:"pid"`
+	Name      string  `json:"name"`
+	ReadRate  uint64  `json:"read_rate"`
+	WriteRate uint64  `json:"write_rate"`
+	ReadOps   float64 `json:"read_ops"`  // per second
+	WriteOps  float64 `json:"write_ops"` // per second
+}
+
+// DiskIOSample is one sample of volume and process I/O. Rates are zero in
+// the first sample, which only sets the baseline.
+type DiskIOSample struct {
+	Volumes     []VolumeIO  `json:"volumes"`
+	TopProcs    []ProcessIO `json:"top_processes"` // busiest first
+	CollectedAt time.Time   `json:"collected_at"`
+
+	volumes map[string]diskPerformance
+	procs   map[uint32]windows.IO_COUNTERS
+}
+
+// CollectDiskIO samples the I/O of every fixed volume and every process.
+// prev, the previous sample, gives the rates.
+func CollectDiskIO(prev *DiskIOSample) *DiskIOSample {
+	s := &DiskIOSample{
+		CollectedAt: time.Now(),
+		volumes:     volumeCounters(),
+		procs:       make(map[uint32]windows.IO_COUNTERS),
+	}
+	names := processNames()
+	for pid := range names {
+		if c, ok := processIOCounters(pid); ok {
+			s.procs[pid] = c
+		}
+	}
+	if prev == nil {
+		return s
+	}
+	secs := s.CollectedAt.Sub(prev.CollectedAt).Seconds()
+	if secs <= 0 {
+		return s
+	}
+
+	for vol, cur := range s.volumes {
+		old, ok := prev.volumes[vol]
+		if !ok || cur.BytesRead < old.BytesRead || cur.BytesWritten < old.BytesWritten {
+			continue
+		}
+		v := VolumeIO{
+			Volume:     vol,
+			ReadRate:   uint64(float64(cur.BytesRead-old.BytesRead) / secs),
+			WriteRate:  uint64(float64(cur.BytesWritten-old.BytesWritten) / secs),
+			ReadIOPS:   float64(cur.ReadCount-old.ReadCount) / secs,
+			WriteIOPS:  float64(cur.WriteCount-old.WriteCount) / secs,
+			QueueDepth: cur.QueueDepth,
+		}
+		// Idle and query times are in 100 ns units.
+		if span := cur.QueryTime - old.QueryTime; span > 0 {
+			idle := float64(cur.IdleTime-old.IdleTime) / float64(span)
+			v.BusyPct = min(max((1-idle)*100, 0), 100)
+		}
+		s.Volumes = append(s.Volumes, v)
+	}
+	sort.Slice(s.Volumes, func(i, j int) bool { return s.Volumes[i].Volume < s.Volumes[j].Volume })
+
+	for pid, cur := range s.procs {
+		old, ok := prev.procs[pid]
+		if !ok || cur.ReadTransferCount < old.ReadTransferCount || cur.WriteTransferCount < old.WriteTransferCount {
+			continue
+		}
+		p := ProcessIO{
+			PID:       int32(pid),
+			Name:      names[pid],
+			ReadRate:  uint64(float64(cur.ReadTransferCount-old.ReadTransferCount) / secs),
+			WriteRate: uint64(float64(cur.WriteTransferCount-old.WriteTransferCount) / secs),
+			ReadOps:   float64(cur.ReadOperationCount-old.ReadOperationCount) / secs,
+			WriteOps:  float64(cur.WriteOperationCount-old.WriteOperationCount) / secs,
+		}
+		if p.ReadRate+p.WriteRate > 0 {
+			s.TopProcs = append(s.TopProcs, p)
+		}
+	}
+	sort.Slice(s.TopProcs, func(i, j int) bool {
+		return s.TopProcs[i].ReadRate+s.TopProcs[i].WriteRate > s.TopProcs[j].ReadRate+s.TopProcs[j].WriteRate
+	})
+	if len(s.TopProcs) > topDiskProcs {
+		s.TopProcs = s.TopProcs[:topDiskProcs]
+	}
+	return s
+}
+
+// volumeCounters reads the performance counters of every fixed volume, by
+// drive ("C:").
+func volumeCounters() map[string]diskPerformance {
+	out := make(map[string]diskPerformance)
+	mask, err := windows.GetLogicalDrives()
+	if err != nil {
+		return out
+	}
+	for i := range 26 {
+		if mask&(1<<uint(i)) == 0 {
+			continue
+		}
+		drive := string(rune('A'+i)) + ":"
+		if windows.GetDriveType(windows.StringToUTF16Ptr(drive+`\`)) != windows.DRIVE_FIXED {
+			continue
+		}
+		h, err := windows.CreateFile(windows.StringToUTF16Ptr(`\\.\`+drive), 0,
+			windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE, nil, windows.OPEN_EXISTING, 0, 0)
+		if err != nil {
+			continue
+		}
+		var perf diskPerformance
+		var n uint32
+		err = windows.DeviceIoControl(h, ioctlDiskPerformance, nil, 0,
+			(*byte)(unsafe.Pointer(&perf)), uint32(unsafe.Sizeof(perf)), &n, nil)
+		windows.CloseHandle(h)
+		if err == nil {
+			out[drive] = perf
+		}
+	}
+	return out
+}
+
+// processIOCounters reads the I/O counters of process pid.
+func processIOCounters(pid uint32) (windows.IO_COUNTERS, bool) {
+	var c windows.IO_COUNTERS
+	if pid == 0 {
+		return c, false
+	}
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return c, false
+	}
+	defer windows.CloseHandle(h)
+	ret, _, _ := procGetProcessIoCounters.Call(uintptr(h), uintptr(unsafe.Pointer(&c)))
+	return c, ret != 0
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// diskIOMsg carries a sample of volume and process I/O.
+type diskIOMsg struct {
+	sample *DiskIOSample
+}
+
+// collectDiskIO samples volume and process I/O. It only runs while the Disk
+// tab is shown.
+func (m StatusModel) collectDiskIO() tea.Cmd {
+	prev := m.diskIO
+	return func() tea.Msg {
+		return diskIOMsg{sample: CollectDiskIO(prev)}
+	}
+}
@@ -0,0 +1,87 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── ETW publishing ──────────────────────────────────────────────────────────
+
+// ETWProviderName is the friendly name of the PureWin status provider.
+const ETWProviderName = "PureWin-Status"
+
+// ETWProviderGUID identifies the provider to tracing tools, e.g.
+//
+//	logman start purewin -p {3f6b1c2e-8d4a-4e7b-9a51-2c7d0e9b4a16} -o purewin.etl -ets
+const ETWProviderGUID = "{3f6b1c2e-8d4a-4e7b-9a51-2c7d0e9b4a16}"
+
+// etwLevelInfo is TRACE_LEVEL_INFORMATION.
+const etwLevelInfo = 4
+
+var (
+	modAdvapi32          = windows.NewLazySystemDLL("advapi32.dll")
+	procEventRegister    = modAdvapi32.NewProc("EventRegister")
+	procEventUnregister  = modAdvapi32.NewProc("EventUnregister")
+	procEventWriteString = modAdvapi32.NewProc("EventWriteString")
+)
+
+// ETWPublisher writes derived metrics as manifest-less ETW string events.
+// Each event payload is a single JSON object (see DerivedMetrics).
+type ETWPublisher struct {
+	handle uint64
+}
+
+// NewETWPublisher registers the PureWin ETW provider.
+func NewETWPublisher() (*ETWPublisher, error) {
+	guid, err := windows.GUIDFromString(ETWProviderGUID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider GUID: %w", err)
+	}
+
+	p := &ETWPublisher{}
+	ret, _, _ := procEventRegister.Call(
+		uintptr(unsafe.Pointer(&guid)),
+		0, // No enable callback.
+		0,
+		uintptr(unsafe.Pointer(&p.handle)),
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("EventRegister failed: %w", windows.Errno(ret))
+	}
+	return p, nil
+}
+
+// Publish writes one event containing d. Events are dropped by the OS when
+// no trace session has enabled the provider, so this is cheap when unused.
+func (p *ETWPublisher) Publish(d DerivedMetrics) error {
+	payload, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+	msg, err := windows.UTF16PtrFromString(string(payload))
+	if err != nil {
+		return err
+	}
+
+	ret, _, _ := procEventWriteString.Call(
+		uintptr(p.handle),
+		etwLevelInfo,
+		0, // Keyword: none.
+		uintptr(unsafe.Pointer(msg)),
+	)
+	if ret != 0 {
+		return fmt.Errorf("EventWriteString failed: %w", windows.Errno(ret))
+	}
+	return nil
+}
+
+// Close unregisters the provider.
+func (p *ETWPublisher) Close() {
+	if p.handle != 0 {
+		_, _, _ = procEventUnregister.Call(uintptr(p.handle))
+		p.handle = 0
+	}
+}
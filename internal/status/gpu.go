@@ -0,0 +1,254 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/yusufpapurcu/wmi"
+	"golang.org/x/sys/windows"
+)
+
+// ─── GPU ─────────────────────────────────────────────────────────────────────
+// Adapters are enumerated through DXGI, which reports the full dedicated
+// VRAM (WMI's AdapterRAM is a 32-bit field that tops out at 4 GB). VRAM in
+// use comes from the GPU Adapter Memory performance counters, the driver
+// version from WMI, and, for NVIDIA cards, nvidia-smi when it is installed.
+
+// nvidiaSMITimeout bounds one nvidia-smi query.
+const nvidiaSMITimeout = 5 * time.Second
+
+var (
+	modDXGI                = windows.NewLazySystemDLL("dxgi.dll")
+	procCreateDXGIFactory1 = modDXGI.NewProc("CreateDXGIFactory1")
+
+	// iidIDXGIFactory1 is {770aae78-f26f-4dba-a829-253c83d1b387}.
+	iidIDXGIFactory1 = windows.GUID{
+		Data1: 0x770aae78, Data2: 0xf26f, Data3: 0x4dba,
+		Data4: [8]byte{0xa8, 0x29, 0x25, 0x3c, 0x83, 0xd1, 0xb3, 0x87},
+	}
+)
+
+// COM vtable slots used below, counted from IUnknown.
+const (
+	vtblRelease       = 2  // IUnknown::Release
+	vtblGetDesc1      = 10 // IDXGIAdapter1::GetDesc1
+	vtblEnumAdapters1 = 12 // IDXGIFactory1::EnumAdapters1
+)
+
+const (
+	dxgiErrorNotFound       = 0x887A0002
+	dxgiAdapterFlagSoftware = 0x2
+)
+
+// gpuVendors maps PCI vendor IDs to names.
+var gpuVendors = map[uint32]string{
+	0x10DE: "NVIDIA",
+	0x1002: "AMD",
+	0x8086: "Intel",
+	0x1414: "Microsoft",
+	0x5143: "Qualcomm",
+}
+
+// dxgiAdapterDesc1 mirrors DXGI_ADAPTER_DESC1.
+type dxgiAdapterDesc1 struct {
+	Description           [128]uint16
+	VendorID              uint32
+	DeviceID              uint32
+	SubSysID              uint32
+	Revision              uint32
+	DedicatedVideoMemory  uintptr
+	DedicatedSystemMemory uintptr
+	SharedSystemMemory    uintptr
+	AdapterLuid           windows.LUID
+	Flags                 uint32
+}
+
+type win32GPUMemory struct {
+	Name           string
+	DedicatedUsage uint64
+}
+
+type win32VideoDriver struct {
+	Name          string
+	DriverVersion string
+}
+
+// CollectGPUs returns the hardware graphics adapters, the one driving the
+// primary display first. Fields that cannot be read are left zero.
+func CollectGPUs() []GPUInfo {
+	gpus, luids, err := dxgiAdapters()
+	if err != nil || len(gpus) == 0 {
+		return wmiGPUs()
+	}
+
+	// Driver versions by adapter name.
+	var drivers []win32VideoDriver
+	if wmi.Query("SELECT Name, DriverVersion FROM Win32_VideoController", &drivers) == nil {
+		for i := range gpus {
+			for _, d := range drivers {
+				if strings.EqualFold(strings.TrimSpace(d.Name), gpus[i].Name) {
+					gpus[i].DriverVersion = d.DriverVersion
+					break
+				}
+			}
+		}
+	}
+
+	// Dedicated VRAM in use, per adapter LUID. Instances are named like
+	// luid_0x00000000_0x0000D1F5_phys_0.
+	var usage []win32GPUMemory
+	if wmi.Query("SELECT Name, DedicatedUsage FROM Win32_PerfFormattedData_GPUPerformanceCounters_GPUAdapterMemory", &usage) == nil {
+		for i := range gpus {
+			prefix := fmt.Sprintf("luid_0x%08x_0x%08x", uint32(luids[i].HighPart), luids[i].LowPart)
+			for _, u := range usage {
+				if strings.HasPrefix(strings.ToLower(u.Name), prefix) {
+					gpus[i].VRAMUsed += u.DedicatedUsage
+				}
+			}
+		}
+	}
+
+	applyNvidiaSMI(gpus)
+	return gpus
+}
+
+// dxgiAdapters enumerates hardware adapters through DXGI, returning each
+// adapter's LUID alongside it.
+func dxgiAdapters() ([]GPUInfo, []windows.LUID, error) {
+	if err := procCreateDXGIFactory1.Find(); err != nil {
+		return nil, nil, err
+	}
+	var factory unsafe.Pointer
+	hr, _, _ := procCreateDXGIFactory1.Call(
+		uintptr(unsafe.Pointer(&iidIDXGIFactory1)),
+		uintptr(unsafe.Pointer(&factory)),
+	)
+	if int32(hr) < 0 {
+		return nil, nil, fmt.Errorf("CreateDXGIFactory1 failed: 0x%08X", uint32(hr))
+	}
+	defer comCall(factory, vtblRelease)
+
+	var gpus []GPUInfo
+	var luids []windows.LUID
+	for i := 0; ; i++ {
+		var adapter unsafe.Pointer
+		hr := comCall(factory, vtblEnumAdapters1, uintptr(i), uintptr(unsafe.Pointer(&adapter)))
+		if uint32(hr) == dxgiErrorNotFound {
+			break
+		}
+		if int32(hr) < 0 {
+			return gpus, luids, fmt.Errorf("EnumAdapters1 failed: 0x%08X", uint32(hr))
+		}
+
+		var desc dxgiAdapterDesc1
+		hr = comCall(adapter, vtblGetDesc1, uintptr(unsafe.Pointer(&desc)))
+		comCall(adapter, vtblRelease)
+		if int32(hr) < 0 || desc.Flags&dxgiAdapterFlagSoftware != 0 {
+			continue // Microsoft Basic Render Driver and the like.
+		}
+
+		// The same adapter is listed once per output it drives.
+		dup := false
+		for _, l := range luids {
+			if l == desc.AdapterLuid {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			continue
+		}
+
+		vendor, ok := gpuVendors[desc.VendorID]
+		if !ok {
+			vendor = fmt.Sprintf("0x%04X", desc.VendorID)
+		}
+		gpus = append(gpus, GPUInfo{
+			Name:      strings.TrimSpace(windows.UTF16ToString(desc.Description[:])),
+			Vendor:    vendor,
+			VRAMTotal: uint64(desc.DedicatedVideoMemory),
+		})
+		luids = append(luids, desc.AdapterLuid)
+	}
+	return gpus, luids, nil
+}
+
+// comCall calls method index of the COM interface obj and returns its
+// HRESULT.
+func comCall(obj unsafe.Pointer, index int, args ...uintptr) uintptr {
+	vtbl := *(**[16]uintptr)(obj)
+	ret, _, _ := syscall.SyscallN(vtbl[index], append([]uintptr{uintptr(obj)}, args...)...)
+	return ret
+}
+
+// wmiGPUs is the fallback when DXGI is unavailable.
+func wmiGPUs() []GPUInfo {
+	var controllers []win32VideoController
+	if err := wmi.Query("SELECT Name, AdapterRAM, DriverVersion FROM Win32_VideoController", &controllers); err != nil {
+		return nil
+	}
+	gpus := make([]GPUInfo, 0, len(controllers))
+	for _, c := range controllers {
+		gpus = append(gpus, GPUInfo{
+			Name:          c.Name,
+			AdapterRAM:    c.AdapterRAM,
+			DriverVersion: c.DriverVersion,
+			VRAMTotal:     uint64(c.AdapterRAM),
+		})
+	}
+	return gpus
+}
+
+// applyNvidiaSMI fills NVIDIA adapters from nvidia-smi, which reports the
+// driver's own version number and exact memory use.
+func applyNvidiaSMI(gpus []GPUInfo) {
+	hasNvidia := false
+	for _, g := range gpus {
+		if g.Vendor == "NVIDIA" {
+			hasNvidia = true
+		}
+	}
+	if !hasNvidia {
+		return
+	}
+	path, err := exec.LookPath("nvidia-smi")
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nvidiaSMITimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path,
+		"--query-gpu=name,driver_version,memory.used,memory.total",
+		"--format=csv,noheader,nounits").Output()
+	if err != nil {
+		return
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			continue
+		}
+		name := strings.TrimSpace(fields[0])
+		usedMiB, usedErr := strconv.ParseUint(strings.TrimSpace(fields[2]), 10, 64)
+		totalMiB, totalErr := strconv.ParseUint(strings.TrimSpace(fields[3]), 10, 64)
+		for i := range gpus {
+			if !strings.EqualFold(gpus[i].Name, name) {
+				continue
+			}
+			gpus[i].DriverVersion = strings.TrimSpace(fields[1])
+			if usedErr == nil && totalErr == nil {
+				gpus[i].VRAMUsed = usedMiB << 20
+				gpus[i].VRAMTotal = totalMiB << 20
+			}
+			break
+		}
+	}
+}
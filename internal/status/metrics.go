@@ -1,9 +1,11 @@
 package status
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,7 +15,6 @@ import (
 	"github.com/shirou/gopsutil/v4/mem"
 	"github.com/shirou/gopsutil/v4/net"
 	"github.com/shirou/gopsutil/v4/process"
-	"github.com/yusufpapurcu/wmi"
 )
 
 // ─── Metric structs ──────────────────────────────────────────────────────────
@@ -43,6 +44,7 @@ type DiskMetrics struct {
 	Partitions []DiskPartition
 	ReadBytes  uint64
 	WriteBytes uint64
+	Health     []DiskHealth // S.M.A.R.T. status per physical disk (see smart.go)
 }
 
 // DiskPartition is a single mount point.
@@ -64,23 +66,43 @@ type NetworkMetrics struct {
 
 // ProcessInfo describes a single process for the top-N list.
 type ProcessInfo struct {
-	PID    int32
-	Name   string
-	CPUPct float64
-	MemPct float32
+	PID     int32
+	Name    string
+	CPUPct  float64
+	MemPct  float32
+	IOBytes uint64 // read and written since the process started
 }
 
-// GPUInfo holds basic GPU information from WMI.
+// AppCPU is the combined CPU usage of all processes sharing an image name.
+type AppCPU struct {
+	Name      string  `json:"name"`
+	Processes int     `json:"processes"`
+	CPUPct    float64 `json:"cpu_pct"`
+}
+
+// GPUInfo describes a graphics adapter and its video memory.
 type GPUInfo struct {
-	Name       string
-	AdapterRAM uint32
+	Name          string
+	AdapterRAM    uint32 // From WMI; capped at 4 GB.
+	Vendor        string // e.g. "NVIDIA", "AMD", "Intel"
+	DriverVersion string
+	VRAMTotal     uint64 // Dedicated video memory.
+	VRAMUsed      uint64 // Dedicated video memory in use; 0 when unknown.
 }
 
-// BatteryInfo holds battery status (laptops only).
+// BatteryInfo holds battery status and health (laptops only). Capacities
+// are in mWh; zero when the battery does not report them.
 type BatteryInfo struct {
-	HasBattery bool
-	Charge     uint16
-	IsCharging bool
+	HasBattery         bool
+	Charge             uint16
+	IsCharging         bool
+	OnAC               bool
+	DesignCapacity     uint32
+	FullChargeCapacity uint32
+	RemainingCapacity  uint32
+	CycleCount         uint32
+	Rate               int32         // mW; positive while charging, negative while discharging
+	TimeRemaining      time.Duration // to empty, or to full while charging; 0 when unknown
 }
 
 // HardwareInfo holds static machine identification.
@@ -101,22 +123,21 @@ type SystemMetrics struct {
 	Disk        DiskMetrics    `json:"disk"`
 	Network     NetworkMetrics `json:"network"`
 	TopProcs    []ProcessInfo  `json:"top_processes"`
+	Procs       []ProcessInfo  `json:"-"` // every process, for the Processes tab
+	AppCPU      []AppCPU       `json:"app_cpu"`
 	GPU         GPUInfo        `json:"gpu"`
 	Battery     BatteryInfo    `json:"battery"`
 	Hardware    HardwareInfo   `json:"hardware"`
+	System      SystemState    `json:"system"`
 	CollectedAt time.Time      `json:"collected_at"`
 }
 
 // ─── WMI helper structs ──────────────────────────────────────────────────────
 
 type win32VideoController struct {
-	Name       string
-	AdapterRAM uint32
-}
-
-type win32Battery struct {
-	EstimatedChargeRemaining uint16
-	BatteryStatus            uint16
+	Name          string
+	AdapterRAM    uint32
+	DriverVersion string
 }
 
 // ─── Collection ──────────────────────────────────────────────────────────────
@@ -158,25 +179,13 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		vm, err := mem.VirtualMemory()
+		memory, err := CollectMemory()
 		if err != nil {
 			return
 		}
-		swap, _ := mem.SwapMemory()
 
 		mu.Lock()
-		m.Memory = MemoryMetrics{
-			Total:       vm.Total,
-			Used:        vm.Used,
-			Available:   vm.Available,
-			Free:        vm.Free,
-			UsedPercent: vm.UsedPercent,
-		}
-		if swap != nil {
-			m.Memory.SwapTotal = swap.Total
-			m.Memory.SwapUsed = swap.Used
-			m.Memory.SwapPercent = swap.UsedPercent
-		}
+		m.Memory = memory
 		mu.Unlock()
 	}()
 
@@ -210,11 +219,19 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 		}
 
 		mu.Lock()
-		m.Disk = DiskMetrics{
-			Partitions: partitions,
-			ReadBytes:  readB,
-			WriteBytes: writeB,
-		}
+		m.Disk.Partitions = partitions
+		m.Disk.ReadBytes = readB
+		m.Disk.WriteBytes = writeB
+		mu.Unlock()
+	}()
+
+	// ── S.M.A.R.T. ───────────────────────────────────────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		health := CollectDiskHealth()
+		mu.Lock()
+		m.Disk.Health = health
 		mu.Unlock()
 	}()
 
@@ -262,39 +279,44 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 			}
 			cpuPct, _ := p.CPUPercent()
 			memPct, _ := p.MemoryPercent()
-			infos = append(infos, ProcessInfo{
+			info := ProcessInfo{
 				PID:    p.Pid,
 				Name:   name,
 				CPUPct: cpuPct,
 				MemPct: memPct,
-			})
+			}
+			if io, ok := processIOCounters(uint32(p.Pid)); ok {
+				info.IOBytes = io.ReadTransferCount + io.WriteTransferCount
+			}
+			infos = append(infos, info)
 		}
+		apps := GroupAppCPU(infos)
+
 		sort.Slice(infos, func(i, j int) bool {
 			return infos[i].CPUPct > infos[j].CPUPct
 		})
-		if len(infos) > 5 {
-			infos = infos[:5]
+		top := infos
+		if len(top) > 5 {
+			top = top[:5]
 		}
 
 		mu.Lock()
-		m.TopProcs = infos
+		m.TopProcs = top
+		m.Procs = infos
+		m.AppCPU = apps
 		mu.Unlock()
 	}()
 
-	// ── GPU via WMI ──────────────────────────────────────────
+	// ── GPU ──────────────────────────────────────────────────
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		var controllers []win32VideoController
-		err := wmi.Query("SELECT Name, AdapterRAM FROM Win32_VideoController", &controllers)
-		if err != nil || len(controllers) == 0 {
+		gpus := CollectGPUs()
+		if len(gpus) == 0 {
 			return
 		}
 		mu.Lock()
-		m.GPU = GPUInfo{
-			Name:       controllers[0].Name,
-			AdapterRAM: controllers[0].AdapterRAM,
-		}
+		m.GPU = gpus[0]
 		mu.Unlock()
 	}()
 
@@ -302,17 +324,22 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		var batteries []win32Battery
-		err := wmi.Query("SELECT EstimatedChargeRemaining, BatteryStatus FROM Win32_Battery", &batteries)
-		if err != nil || len(batteries) == 0 {
-			return // desktop — no battery is fine
+		battery := CollectBattery()
+		if !battery.HasBattery {
+			return
 		}
 		mu.Lock()
-		m.Battery = BatteryInfo{
-			HasBattery: true,
-			Charge:     batteries[0].EstimatedChargeRemaining,
-			IsCharging: batteries[0].BatteryStatus == 2,
-		}
+		m.Battery = battery
+		mu.Unlock()
+	}()
+
+	// ── Uptime, pending restart and critical events ──────────
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		state := CollectSystemState()
+		mu.Lock()
+		m.System = state
 		mu.Unlock()
 	}()
 
@@ -344,6 +371,52 @@ func CollectMetrics(prevNet *NetworkMetrics, interval time.Duration) (*SystemMet
 	return m, nil
 }
 
+// CollectMemory reads current RAM and swap utilization.
+func CollectMemory() (MemoryMetrics, error) {
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return MemoryMetrics{}, err
+	}
+	m := MemoryMetrics{
+		Total:       vm.Total,
+		Used:        vm.Used,
+		Available:   vm.Available,
+		Free:        vm.Free,
+		UsedPercent: vm.UsedPercent,
+	}
+	if swap, _ := mem.SwapMemory(); swap != nil {
+		m.SwapTotal = swap.Total
+		m.SwapUsed = swap.Used
+		m.SwapPercent = swap.UsedPercent
+	}
+	return m, nil
+}
+
+// GroupAppCPU sums CPU usage per process image name (case-insensitive),
+// returning apps sorted by combined usage, highest first.
+func GroupAppCPU(procs []ProcessInfo) []AppCPU {
+	index := make(map[string]int)
+	var apps []AppCPU
+	for _, p := range procs {
+		key := strings.ToLower(p.Name)
+		i, ok := index[key]
+		if !ok {
+			i = len(apps)
+			index[key] = i
+			apps = append(apps, AppCPU{Name: p.Name})
+		}
+		apps[i].Processes++
+		apps[i].CPUPct += p.CPUPct
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if apps[i].CPUPct != apps[j].CPUPct {
+			return apps[i].CPUPct > apps[j].CPUPct
+		}
+		return strings.ToLower(apps[i].Name) < strings.ToLower(apps[j].Name)
+	})
+	return apps
+}
+
 // ─── Hardware ────────────────────────────────────────────────────────────────
 
 // GetHardwareInfo collects static machine identification data.
@@ -379,6 +452,9 @@ func GetHardwareInfo() HardwareInfo {
 //	CPU  >80 → -30, >60 → -20, >40 → -10
 //	Mem  >90 → -25, >75 → -15, >60 → -10
 //	Disk >95 → -20, >85 → -15, >75 → -10  (worst partition)
+//	Drive predicting failure → -30, reallocated sectors → -10
+//	Restart pending → -10
+//	Critical events in 24h ≥5 → -15, ≥1 → -10
 func HealthScore(m *SystemMetrics) int {
 	score := 100
 
@@ -416,8 +492,61 @@ func HealthScore(m *SystemMetrics) int {
 		score -= 10
 	}
 
+	var failing, reallocated bool
+	for _, d := range m.Disk.Health {
+		failing = failing || d.PredictFailure
+		reallocated = reallocated || d.Reallocated > 0
+	}
+	switch {
+	case failing:
+		score -= 30
+	case reallocated:
+		score -= 10
+	}
+
+	if m.System.RebootPending {
+		score -= 10
+	}
+	switch {
+	case m.System.CriticalEvents >= 5:
+		score -= 15
+	case m.System.CriticalEvents >= 1:
+		score -= 10
+	}
+
 	if score < 0 {
 		score = 0
 	}
 	return score
 }
+
+// HealthIssues names what lowers the health score, most pressing first.
+func HealthIssues(m *SystemMetrics) []string {
+	var issues []string
+	for _, d := range m.Disk.Health {
+		switch {
+		case d.PredictFailure:
+			issues = append(issues, d.Model+" predicts failure")
+		case d.Reallocated > 0:
+			issues = append(issues, fmt.Sprintf("%s has %d reallocated sectors", d.Model, d.Reallocated))
+		}
+	}
+	if n := m.System.CriticalEvents; n > 0 {
+		issues = append(issues, fmt.Sprintf("%d critical events in 24h", n))
+	}
+	if m.System.RebootPending {
+		issues = append(issues, "restart pending ("+strings.Join(m.System.RebootReasons, ", ")+")")
+	}
+	if m.CPU.TotalPercent > 40 {
+		issues = append(issues, fmt.Sprintf("CPU %.0f%%", m.CPU.TotalPercent))
+	}
+	if m.Memory.UsedPercent > 60 {
+		issues = append(issues, fmt.Sprintf("memory %.0f%%", m.Memory.UsedPercent))
+	}
+	for _, p := range m.Disk.Partitions {
+		if p.UsedPercent > 75 {
+			issues = append(issues, fmt.Sprintf("%s %.0f%% full", p.Path, p.UsedPercent))
+		}
+	}
+	return issues
+}
@@ -3,6 +3,7 @@ package status
 import (
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -18,10 +19,12 @@ const (
 	TabDisk
 	TabNetwork
 	TabProcesses
+	TabAlerts
+	TabServices
 )
 
 // TabNames is the display label for each tab.
-var TabNames = []string{"Overview", "CPU", "Memory", "Disk", "Network", "Processes"}
+var TabNames = []string{"Overview", "CPU", "Memory", "Disk", "Network", "Processes", "Alerts", "Services"}
 
 // ─── Messages ────────────────────────────────────────────────────────────────
 
@@ -32,6 +35,21 @@ type metricsMsg struct {
 	err     error
 }
 
+type processDetailMsg struct {
+	pid    int32
+	detail *ProcessDetail
+	err    error
+}
+
+// processActionMsg reports the outcome of ending a process or changing its
+// priority or affinity.
+type processActionMsg struct {
+	pid    int32
+	notice string
+	exited bool // the process was ended, so its detail pane closes
+	err    error
+}
+
 // ─── Model ───────────────────────────────────────────────────────────────────
 
 // StatusModel is the bubbletea Model for the system health dashboard.
@@ -42,6 +60,9 @@ type StatusModel struct {
 	Width           int
 	Height          int
 	refreshInterval time.Duration
+	paused          bool // sampling is paused (see refresh.go)
+	stalled         bool // the tick loop stopped while paused
+	blurred         bool // the terminal lost focus
 	quitting        bool
 	Err             error
 
@@ -50,6 +71,66 @@ type StatusModel struct {
 	NetRecvHistory []uint64
 	CPUHistory     []float64
 	MemHistory     []float64
+	BatteryHistory []float64 // charge level; laptops only
+
+	// Alerts persists threshold breaches; nil disables alerting.
+	Alerts      *AlertTracker
+	alertCursor int
+
+	// Recorder appends every sample to a file; nil records nothing.
+	Recorder *Recorder
+
+	// Processes tab: how the list is sorted, filtered and cut (see
+	// proclist.go), the selected row and its PID, the detail pane of one
+	// process while open, and the action being confirmed (see
+	// procactions.go).
+	procSort       ProcSort
+	procFilter     string
+	procFiltering  bool
+	procInput      textinput.Model
+	procRowChoice  int
+	procIORate     map[int32]uint64
+	procCursor     int
+	procSelPID     int32
+	procOffset     int
+	detailPID      int32
+	detail         *ProcessDetail
+	detailErr      error
+	action         processAction
+	actionPID      int32
+	actionName     string
+	priorityCursor int
+	affinityMask   uint64
+	affinitySystem uint64
+	affinityCursor int
+	notice         string
+
+	// Network tab: the connection table, sampled while the tab is shown,
+	// and how it is sorted, filtered and scrolled (see connections.go).
+	conns         *ConnectionTable
+	connErr       error
+	connSort      ConnSort
+	connFilter    string
+	connFiltering bool
+	connInput     textinput.Model
+	connOffset    int
+
+	// Disk tab: the latest volume and process I/O sample, taken while the
+	// tab is shown (see diskio.go).
+	diskIO *DiskIOSample
+
+	// WatchedServices are the services on the Services tab, read while the
+	// tab is shown, with the selected row and the service whose restart is
+	// being confirmed (see services.go).
+	WatchedServices []string
+	services        []ServiceStatus
+	serviceCursor   int
+	serviceConfirm  string
+
+	// OnCollect, if set, runs in the collection goroutine after every
+	// sample. Used to enforce per-process priority rules while the
+	// monitor is open.
+	OnCollect func()
 }
 
 // NewStatusModel creates a StatusModel with the given refresh cadence.
@@ -57,15 +138,19 @@ func NewStatusModel(refreshInterval time.Duration) StatusModel {
 	if refreshInterval <= 0 {
 		refreshInterval = time.Second
 	}
+	refreshInterval = max(refreshInterval, MinRefreshInterval)
 	return StatusModel{
 		Width:           80,
 		Height:          24,
 		refreshInterval: refreshInterval,
+		connInput:       newConnInput(),
+		procInput:       newProcInput(),
+		WatchedServices: DefaultWatchedServices(),
 	}
 }
 
 func (m StatusModel) doTick() tea.Cmd {
-	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
+	return tea.Tick(m.interval(), func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -73,8 +158,17 @@ func (m StatusModel) doTick() tea.Cmd {
 func (m StatusModel) collectMetrics() tea.Cmd {
 	prevNet := m.prevNet
 	interval := m.refreshInterval
+	if m.Metrics != nil {
+		// Rates are over the time since the previous sample, which is
+		// longer than the interval after a pause or while unfocused.
+		interval = time.Since(m.Metrics.CollectedAt)
+	}
+	onCollect := m.OnCollect
 	return func() tea.Msg {
 		metrics, err := CollectMetrics(prevNet, interval)
+		if onCollect != nil {
+			onCollect()
+		}
 		return metricsMsg{metrics: metrics, err: err}
 	}
 }
@@ -95,7 +189,45 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Height = msg.Height
 		return m, nil
 
+	case tea.BlurMsg:
+		m.blurred = true
+		return m, nil
+
+	case tea.FocusMsg:
+		m.blurred = false
+		return m, nil
+
 	case tea.KeyMsg:
+		m.notice = ""
+		if m.connFiltering {
+			return m, m.updateNetworkKey(msg)
+		}
+		if m.procFiltering {
+			return m, m.updateProcFilterKey(msg)
+		}
+		if m.Tab == TabProcesses && m.action != actionNone {
+			return m, m.updateActionKey(msg.String())
+		}
+		if m.Tab == TabProcesses && m.detailPID != 0 {
+			if cmd, handled := m.updateDetailKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		if m.Tab == TabServices && m.serviceConfirm != "" {
+			return m, m.updateServicesKey(msg.String())
+		}
+		if msg.String() == "esc" && m.Tab == TabNetwork && m.connFilter != "" {
+			return m, m.updateNetworkKey(msg)
+		}
+		if m.Tab == TabProcesses {
+			if cmd, handled := m.updateProcListKey(msg.String()); handled {
+				return m, cmd
+			}
+		}
+		if cmd, handled := m.updateRefreshKey(msg.String()); handled {
+			return m, cmd
+		}
+		prevTab := m.Tab
 		switch msg.String() {
 		case "q", "esc", "ctrl+c":
 			m.quitting = true
@@ -120,17 +252,106 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.Tab = TabNetwork
 		case "6":
 			m.Tab = TabProcesses
+		case "7":
+			m.Tab = TabAlerts
+		case "8":
+			m.Tab = TabServices
+		default:
+			switch m.Tab {
+			case TabAlerts:
+				m.updateAlertsKey(msg.String())
+			case TabNetwork:
+				return m, m.updateNetworkKey(msg)
+			case TabProcesses:
+				return m, m.updateProcessesKey(msg.String())
+			case TabServices:
+				return m, m.updateServicesKey(msg.String())
+			}
+		}
+		if m.Tab == TabNetwork && prevTab != TabNetwork {
+			return m, m.collectConnections()
+		}
+		if m.Tab == TabDisk && prevTab != TabDisk {
+			m.diskIO = nil // a stale baseline would average over the time away
+			return m, m.collectDiskIO()
+		}
+		if m.Tab == TabServices && prevTab != TabServices {
+			return m, m.collectServices()
 		}
 		return m, nil
 
 	case tickMsg:
-		return m, m.collectMetrics()
+		if m.paused {
+			m.stalled = true
+			return m, nil
+		}
+		cmds := []tea.Cmd{m.collectMetrics()}
+		if m.detailPID != 0 {
+			cmds = append(cmds, m.collectDetail())
+		}
+		if m.Tab == TabNetwork {
+			cmds = append(cmds, m.collectConnections())
+		}
+		if m.Tab == TabDisk {
+			cmds = append(cmds, m.collectDiskIO())
+		}
+		if m.Tab == TabServices {
+			cmds = append(cmds, m.collectServices())
+		}
+		return m, tea.Batch(cmds...)
+
+	case servicesMsg:
+		m.services = msg.services
+		m.serviceCursor = min(m.serviceCursor, max(len(m.services)-1, 0))
+		return m, nil
+
+	case serviceRestartMsg:
+		if msg.err != nil {
+			m.notice, m.Err = "", msg.err
+		} else {
+			m.notice = "Restarted " + msg.name
+		}
+		return m, m.collectServices()
+
+	case diskIOMsg:
+		m.diskIO = msg.sample
+		return m, nil
+
+	case connectionsMsg:
+		if msg.err != nil {
+			m.connErr = msg.err
+			return m, nil
+		}
+		m.conns, m.connErr = msg.table, nil
+		if total := len(m.visibleConns()); m.connOffset > total-m.connViewHeight() {
+			m.connOffset = max(total-m.connViewHeight(), 0)
+		}
+		return m, nil
+
+	case processDetailMsg:
+		if msg.pid == m.detailPID {
+			m.detail, m.detailErr = msg.detail, msg.err
+		}
+		return m, nil
+
+	case processActionMsg:
+		m.notice, m.Err = msg.notice, msg.err
+		if msg.exited && msg.pid == m.detailPID {
+			m.closeDetail()
+		} else if m.detailPID != 0 {
+			return m, m.collectDetail()
+		}
+		return m, nil
 
 	case metricsMsg:
 		if msg.err != nil {
 			m.Err = msg.err
 			return m, m.doTick()
 		}
+		if m.paused {
+			return m, m.doTick() // collected before the pause; keep the frozen view
+		}
+		m.updateProcIORate(m.Metrics, msg.metrics)
 		m.Metrics = msg.metrics
 		m.prevNet = &msg.metrics.Network
 
@@ -139,6 +360,15 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.MemHistory = appendF64(m.MemHistory, msg.metrics.Memory.UsedPercent, 60)
 		m.NetSendHistory = appendU64(m.NetSendHistory, msg.metrics.Network.SendSpeed, 60)
 		m.NetRecvHistory = appendU64(m.NetRecvHistory, msg.metrics.Network.RecvSpeed, 60)
+		if msg.metrics.Battery.HasBattery {
+			m.BatteryHistory = appendF64(m.BatteryHistory, float64(msg.metrics.Battery.Charge), 60)
+		}
+
+		m.Alerts.Observe(msg.metrics)
+		if err := m.Recorder.Record(msg.metrics); err != nil {
+			m.Err = err
+		}
+		m.followSelection()
 
 		return m, m.doTick()
 	}
@@ -146,6 +376,31 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateAlertsKey handles navigation and acknowledgement on the alerts tab.
+func (m *StatusModel) updateAlertsKey(key string) {
+	alerts := m.Alerts.Alerts()
+	switch key {
+	case "up", "k":
+		if m.alertCursor > 0 {
+			m.alertCursor--
+		}
+	case "down", "j":
+		if m.alertCursor < len(alerts)-1 {
+			m.alertCursor++
+		}
+	case "a", "enter":
+		if m.alertCursor < len(alerts) {
+			if err := m.Alerts.Acknowledge(alerts[m.alertCursor].ID); err != nil {
+				m.Err = err
+			}
+		}
+	case "A":
+		if err := m.Alerts.AcknowledgeAll(); err != nil {
+			m.Err = err
+		}
+	}
+}
+
 func (m StatusModel) View() string {
 	if m.quitting {
 		return ""
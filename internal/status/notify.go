@@ -0,0 +1,63 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ─── Notifications ───────────────────────────────────────────────────────────
+// Alerts of rules with "notify" set are also shown as Windows toast
+// notifications, so they reach the user while the dashboard is hidden or
+// only the recorder runs. Toasts are raised through PowerShell's WinRT
+// bridge under PowerShell's own app ID, which every Windows install has
+// registered; an unpackaged binary cannot show toasts under its own name.
+
+// toastTimeout bounds one PowerShell toast invocation.
+const toastTimeout = 30 * time.Second
+
+// toastAppID is the AppUserModelID of Windows PowerShell.
+const toastAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// ShowToast shows a Windows toast notification.
+func ShowToast(title, message string) error {
+	xml := "<toast><visual><binding template=\"ToastGeneric\"><text>" + xmlEscape(title) +
+		"</text><text>" + xmlEscape(message) + "</text></binding></visual></toast>"
+	script := "$ErrorActionPreference = 'Stop'; " +
+		"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null; " +
+		"[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] | Out-Null; " +
+		"$x = New-Object Windows.Data.Xml.Dom.XmlDocument; " +
+		"$x.LoadXml('" + psQuote(xml) + "'); " +
+		"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('" + psQuote(toastAppID) + "')" +
+		".Show([Windows.UI.Notifications.ToastNotification]::new($x))"
+
+	ctx, cancel := context.WithTimeout(context.Background(), toastTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-NonInteractive", "-Command", script).CombinedOutput()
+	if err != nil {
+		text := strings.TrimSpace(string(out))
+		if text == "" {
+			text = err.Error()
+		}
+		return fmt.Errorf("cannot show notification: %s", text)
+	}
+	return nil
+}
+
+// NotifyAlert shows a toast for a fired alert in the background.
+func NotifyAlert(a Alert) {
+	go func() { _ = ShowToast("PureWin: "+a.Label, a.String()) }()
+}
+
+// psQuote escapes s for a single-quoted PowerShell string.
+func psQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// xmlEscape escapes s for XML text.
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;").Replace(s)
+}
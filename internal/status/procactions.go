@@ -0,0 +1,190 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Process Actions ─────────────────────────────────────────────────────────
+// On the Processes tab, K ends the selected process, P changes its priority
+// class and A chooses the CPUs it may run on — from the list or from the
+// detail pane. Ending a process asks for confirmation, and the processes
+// Windows cannot run without are refused outright. Processes of other users
+// and services can only be changed elevated; when that is what failed, the
+// error says so.
+
+// processAction is the action being confirmed on the Processes tab.
+type processAction int
+
+const (
+	actionNone processAction = iota
+	actionKill
+	actionPriority
+	actionAffinity
+)
+
+// actionForKey maps the action keys to their action.
+var actionForKey = map[string]processAction{
+	"K": actionKill,
+	"P": actionPriority,
+	"A": actionAffinity,
+}
+
+// criticalProcesses are system processes whose termination crashes or
+// logs off Windows, by lower-case image name.
+var criticalProcesses = map[string]bool{
+	"system":       true,
+	"registry":     true,
+	"smss.exe":     true,
+	"csrss.exe":    true,
+	"wininit.exe":  true,
+	"winlogon.exe": true,
+	"services.exe": true,
+	"lsass.exe":    true,
+	"lsaiso.exe":   true,
+}
+
+// KillProcess ends process pid immediately, as Task Manager's End task does.
+// Critical system processes are refused.
+func KillProcess(pid int32, name string) error {
+	if pid <= 4 || criticalProcesses[strings.ToLower(name)] {
+		return fmt.Errorf("%s is a critical system process; ending it would crash Windows", name)
+	}
+	h, err := windows.OpenProcess(windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return explainAccess(fmt.Errorf("cannot open process %d: %w", pid, err))
+	}
+	defer windows.CloseHandle(h)
+	if err := windows.TerminateProcess(h, 1); err != nil {
+		return explainAccess(fmt.Errorf("cannot end process %d: %w", pid, err))
+	}
+	return nil
+}
+
+// explainAccess adds a hint to access-denied errors when not elevated.
+func explainAccess(err error) error {
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) && !core.IsElevated() {
+		return fmt.Errorf("%w (run pw as administrator to manage processes of other users and services)", err)
+	}
+	return err
+}
+
+// startAction begins action on process pid: a confirmation, a priority
+// list or a CPU picker, answered by updateActionKey.
+func (m *StatusModel) startAction(action processAction, pid int32, name string) {
+	switch action {
+	case actionKill:
+		if pid <= 4 || criticalProcesses[strings.ToLower(name)] {
+			m.Err = fmt.Errorf("%s is a critical system process; ending it would crash Windows", name)
+			return
+		}
+	case actionPriority:
+		m.priorityCursor = 2 // normal
+		if m.detail != nil && m.detail.PID == pid {
+			for i, p := range optimize.PriorityNames {
+				if p == m.detail.Priority {
+					m.priorityCursor = i
+				}
+			}
+		}
+	case actionAffinity:
+		mask, system, err := optimize.ProcessAffinity(uint32(pid))
+		if err != nil {
+			m.Err = explainAccess(err)
+			return
+		}
+		m.affinityMask, m.affinitySystem, m.affinityCursor = mask, system, 0
+	}
+	m.action, m.actionPID, m.actionName = action, pid, name
+}
+
+// updateActionKey answers the action being confirmed.
+func (m *StatusModel) updateActionKey(key string) tea.Cmd {
+	pid, name := m.actionPID, m.actionName
+	switch m.action {
+	case actionKill:
+		m.action = actionNone
+		if key == "enter" {
+			return killProcess(pid, name)
+		}
+
+	case actionPriority:
+		switch key {
+		case "up", "k":
+			if m.priorityCursor > 0 {
+				m.priorityCursor--
+			}
+		case "down", "j":
+			if m.priorityCursor < len(optimize.PriorityNames)-1 {
+				m.priorityCursor++
+			}
+		case "enter":
+			m.action = actionNone
+			return setPriority(pid, name, optimize.PriorityNames[m.priorityCursor])
+		case "esc", "q":
+			m.action = actionNone
+		}
+
+	case actionAffinity:
+		cpus := bits.Len64(m.affinitySystem)
+		switch key {
+		case "left", "h":
+			if m.affinityCursor > 0 {
+				m.affinityCursor--
+			}
+		case "right", "l":
+			if m.affinityCursor < cpus-1 {
+				m.affinityCursor++
+			}
+		case " ", "x":
+			if bit := uint64(1) << uint(m.affinityCursor); m.affinitySystem&bit != 0 {
+				m.affinityMask ^= bit
+			}
+		case "a":
+			m.affinityMask = m.affinitySystem
+		case "enter":
+			if m.affinityMask&m.affinitySystem == 0 {
+				return nil // at least one CPU is needed
+			}
+			m.action = actionNone
+			return setAffinity(pid, name, m.affinityMask&m.affinitySystem)
+		case "esc", "q":
+			m.action = actionNone
+		}
+	}
+	return nil
+}
+
+func killProcess(pid int32, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := KillProcess(pid, name); err != nil {
+			return processActionMsg{err: err}
+		}
+		return processActionMsg{pid: pid, notice: fmt.Sprintf("Ended %s (PID %d)", name, pid), exited: true}
+	}
+}
+
+func setPriority(pid int32, name, priority string) tea.Cmd {
+	return func() tea.Msg {
+		if err := optimize.SetProcessPriority(uint32(pid), priority); err != nil {
+			return processActionMsg{err: explainAccess(err)}
+		}
+		return processActionMsg{notice: fmt.Sprintf("Set %s (PID %d) to %s priority", name, pid, priority)}
+	}
+}
+
+func setAffinity(pid int32, name string, mask uint64) tea.Cmd {
+	return func() tea.Msg {
+		if err := optimize.SetProcessAffinity(uint32(pid), mask); err != nil {
+			return processActionMsg{err: explainAccess(err)}
+		}
+		return processActionMsg{notice: fmt.Sprintf("Limited %s (PID %d) to %d CPUs", name, pid, bits.OnesCount64(mask))}
+	}
+}
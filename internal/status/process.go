@@ -0,0 +1,242 @@
+package status
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+	"unsafe"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/cy-infamous/purewin/internal/optimize"
+	"github.com/shirou/gopsutil/v4/process"
+	"golang.org/x/sys/windows"
+)
+
+// ─── Process Detail ──────────────────────────────────────────────────────────
+// Enter on the Processes tab opens a detail pane for the selected process:
+// its command line, parent, start time, thread and handle counts, a
+// breakdown of its memory and its disk I/O rate, refreshed with the rest of
+// the dashboard. From there it can be shown in Explorer, or acted on as
+// from the list (see procactions.go).
+
+var (
+	modKernel32              = windows.NewLazySystemDLL("kernel32.dll")
+	procK32GetProcessMemInfo = modKernel32.NewProc("K32GetProcessMemoryInfo")
+)
+
+// processMemoryCountersEx mirrors PROCESS_MEMORY_COUNTERS_EX.
+type processMemoryCountersEx struct {
+	CB                         uint32
+	PageFaultCount             uint32
+	PeakWorkingSetSize         uintptr
+	WorkingSetSize             uintptr
+	QuotaPeakPagedPoolUsage    uintptr
+	QuotaPagedPoolUsage        uintptr
+	QuotaPeakNonPagedPoolUsage uintptr
+	QuotaNonPagedPoolUsage     uintptr
+	PagefileUsage              uintptr
+	PeakPagefileUsage          uintptr
+	PrivateUsage               uintptr
+}
+
+// ProcessMemory is the memory breakdown of one process.
+type ProcessMemory struct {
+	WorkingSet     uint64 `json:"working_set"`
+	PeakWorkingSet uint64 `json:"peak_working_set"`
+	Private        uint64 `json:"private"` // committed memory no other process shares
+	PagedPool      uint64 `json:"paged_pool"`
+	NonPagedPool   uint64 `json:"nonpaged_pool"`
+	PageFaults     uint32 `json:"page_faults"`
+}
+
+// ProcessDetail is everything the detail pane shows about one process.
+type ProcessDetail struct {
+	PID        int32         `json:"pid"`
+	Name       string        `json:"name"`
+	Exe        string        `json:"exe"`
+	CmdLine    string        `json:"cmdline"`
+	User       string        `json:"user"`
+	ParentPID  int32         `json:"parent_pid"`
+	ParentName string        `json:"parent_name"`
+	StartTime  time.Time     `json:"start_time"`
+	Threads    int32         `json:"threads"`
+	Handles    int32         `json:"handles"`
+	Priority   string        `json:"priority"`
+	CPUPct     float64       `json:"cpu_pct"`
+	Memory     ProcessMemory `json:"memory"`
+
+	// Disk I/O totals since the process started, and rates since the
+	// previous sample (zero on the first).
+	ReadBytes  uint64 `json:"read_bytes"`
+	WriteBytes uint64 `json:"write_bytes"`
+	ReadRate   uint64 `json:"read_rate"`
+	WriteRate  uint64 `json:"write_rate"`
+
+	CollectedAt time.Time `json:"collected_at"`
+}
+
+// CollectProcessDetail reads the detail of process pid. prev, the previous
+// sample of the same process, gives the I/O rates. Fields the process does
+// not let us read (often the case for system processes without elevation)
+// are left empty.
+func CollectProcessDetail(pid int32, prev *ProcessDetail) (*ProcessDetail, error) {
+	p, err := process.NewProcess(pid)
+	if err != nil {
+		return nil, fmt.Errorf("process %d has exited", pid)
+	}
+	name, err := p.Name()
+	if err != nil {
+		return nil, fmt.Errorf("process %d has exited", pid)
+	}
+
+	d := &ProcessDetail{PID: pid, Name: name, CollectedAt: time.Now()}
+	d.Exe, _ = p.Exe()
+	d.CmdLine, _ = p.Cmdline()
+	d.User, _ = p.Username()
+	d.Threads, _ = p.NumThreads()
+	d.Handles, _ = p.NumFDs() // the handle count on Windows
+	d.CPUPct, _ = p.CPUPercent()
+	if ms, err := p.CreateTime(); err == nil {
+		d.StartTime = time.UnixMilli(ms)
+	}
+	if ppid, err := p.Ppid(); err == nil {
+		d.ParentPID = ppid
+		if parent, err := process.NewProcess(ppid); err == nil {
+			d.ParentName, _ = parent.Name()
+		}
+	}
+	if io, err := p.IOCounters(); err == nil {
+		d.ReadBytes, d.WriteBytes = io.ReadBytes, io.WriteBytes
+		if prev != nil && prev.PID == pid {
+			secs := d.CollectedAt.Sub(prev.CollectedAt).Seconds()
+			if secs > 0 && d.ReadBytes >= prev.ReadBytes && d.WriteBytes >= prev.WriteBytes {
+				d.ReadRate = uint64(float64(d.ReadBytes-prev.ReadBytes) / secs)
+				d.WriteRate = uint64(float64(d.WriteBytes-prev.WriteBytes) / secs)
+			}
+		}
+	}
+
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION|windows.PROCESS_VM_READ, false, uint32(pid))
+	if err != nil {
+		h, err = windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	}
+	if err == nil {
+		defer windows.CloseHandle(h)
+		if class, err := windows.GetPriorityClass(h); err == nil {
+			d.Priority = optimize.PriorityName(class)
+		}
+		d.Memory = processMemory(h)
+	}
+	return d, nil
+}
+
+// processMemory reads the memory counters of an open process.
+func processMemory(h windows.Handle) ProcessMemory {
+	var c processMemoryCountersEx
+	c.CB = uint32(unsafe.Sizeof(c))
+	ret, _, _ := procK32GetProcessMemInfo.Call(uintptr(h), uintptr(unsafe.Pointer(&c)), uintptr(c.CB))
+	if ret == 0 {
+		return ProcessMemory{}
+	}
+	return ProcessMemory{
+		WorkingSet:     uint64(c.WorkingSetSize),
+		PeakWorkingSet: uint64(c.PeakWorkingSetSize),
+		Private:        uint64(c.PrivateUsage),
+		PagedPool:      uint64(c.QuotaPagedPoolUsage),
+		NonPagedPool:   uint64(c.QuotaNonPagedPoolUsage),
+		PageFaults:     c.PageFaultCount,
+	}
+}
+
+// showInExplorer opens the folder holding exe with the file selected.
+func showInExplorer(exe string) error {
+	if exe == "" {
+		return fmt.Errorf("the location of this process is not readable")
+	}
+	return exec.Command("explorer", "/select,", exe).Start()
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// collectDetail samples the process shown in the detail pane.
+func (m StatusModel) collectDetail() tea.Cmd {
+	pid, prev := m.detailPID, m.detail
+	return func() tea.Msg {
+		d, err := CollectProcessDetail(pid, prev)
+		return processDetailMsg{pid: pid, detail: d, err: err}
+	}
+}
+
+// updateProcessesKey moves the selection on the Processes tab, opens the
+// detail pane of the selected process and starts actions on it.
+func (m *StatusModel) updateProcessesKey(key string) tea.Cmd {
+	procs := m.visibleProcs()
+	switch key {
+	case "up", "k":
+		m.selectProc(procs, m.procCursor-1)
+	case "down", "j":
+		m.selectProc(procs, m.procCursor+1)
+	case "pgup":
+		m.selectProc(procs, m.procCursor-m.procViewHeight())
+	case "pgdown":
+		m.selectProc(procs, m.procCursor+m.procViewHeight())
+	case "home", "g":
+		m.selectProc(procs, 0)
+	case "end", "G":
+		m.selectProc(procs, len(procs)-1)
+	case "enter", "right", "l":
+		if m.procCursor < len(procs) {
+			m.detailPID = procs[m.procCursor].PID
+			m.detail, m.detailErr = nil, nil
+			return m.collectDetail()
+		}
+	case "K", "P", "A":
+		if m.procCursor < len(procs) {
+			m.startAction(actionForKey[key], procs[m.procCursor].PID, procs[m.procCursor].Name)
+		}
+	}
+	return nil
+}
+
+// updateDetailKey handles keys while the detail pane is open. It reports
+// false for keys the dashboard handles as usual, such as switching tabs.
+func (m *StatusModel) updateDetailKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "esc", "backspace", "left", "h":
+		m.closeDetail()
+	case "o":
+		if m.detail != nil {
+			if err := showInExplorer(m.detail.Exe); err != nil {
+				m.Err = err
+			}
+		}
+	case "K", "P", "A":
+		if m.detail != nil {
+			m.startAction(actionForKey[key], m.detail.PID, m.detail.Name)
+		}
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// closeDetail returns from the detail pane to the process list.
+func (m *StatusModel) closeDetail() {
+	m.detailPID, m.detail, m.detailErr = 0, nil, nil
+	m.action = actionNone
+}
+
+// HasOverlay reports whether Esc closes something inside the dashboard —
+// the process detail pane, a process action, a filter or a service
+// restart being confirmed — rather than leaving it.
+func (m StatusModel) HasOverlay() bool {
+	switch {
+	case m.detailPID != 0, m.action != actionNone, m.connFiltering, m.procFiltering, m.serviceConfirm != "":
+		return true
+	case m.Tab == TabNetwork:
+		return m.connFilter != ""
+	case m.Tab == TabProcesses:
+		return m.procFilter != ""
+	}
+	return false
+}
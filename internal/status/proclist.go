@@ -0,0 +1,210 @@
+package status
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Process List ────────────────────────────────────────────────────────────
+// The Processes tab lists every process rather than the top five: c, m, d
+// and n sort it by CPU, memory, disk I/O or name, / keeps the names
+// containing a substring, and r switches between 10, 25 and 50 rows. The
+// selection follows its process, by PID, as the order changes between
+// refreshes.
+
+// ProcSort is an order of the process list.
+type ProcSort int
+
+const (
+	ProcSortCPU ProcSort = iota
+	ProcSortMemory
+	ProcSortIO
+	ProcSortName
+)
+
+// ProcSortNames is the label of each order.
+var ProcSortNames = []string{"CPU", "memory", "disk I/O", "name"}
+
+// procSortKeys maps the sort keys to their order.
+var procSortKeys = map[string]ProcSort{
+	"c": ProcSortCPU,
+	"m": ProcSortMemory,
+	"d": ProcSortIO,
+	"n": ProcSortName,
+}
+
+// procRowChoices are the list lengths r cycles through.
+var procRowChoices = []int{10, 25, 50}
+
+// SortProcesses orders procs in place; ioRate gives the disk I/O rate of
+// each PID for ProcSortIO.
+func SortProcesses(procs []ProcessInfo, by ProcSort, ioRate map[int32]uint64) {
+	sort.SliceStable(procs, func(i, j int) bool {
+		a, b := procs[i], procs[j]
+		switch by {
+		case ProcSortCPU:
+			if a.CPUPct != b.CPUPct {
+				return a.CPUPct > b.CPUPct
+			}
+		case ProcSortMemory:
+			if a.MemPct != b.MemPct {
+				return a.MemPct > b.MemPct
+			}
+		case ProcSortIO:
+			if ioRate[a.PID] != ioRate[b.PID] {
+				return ioRate[a.PID] > ioRate[b.PID]
+			}
+		}
+		if !strings.EqualFold(a.Name, b.Name) {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+		return a.PID < b.PID
+	})
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// visibleProcs returns the rows of the process list: sorted, filtered and
+// cut to the chosen length.
+func (m StatusModel) visibleProcs() []ProcessInfo {
+	if m.Metrics == nil {
+		return nil
+	}
+	var procs []ProcessInfo
+	filter := strings.ToLower(m.procFilter)
+	for _, p := range m.Metrics.Procs {
+		if filter == "" || strings.Contains(strings.ToLower(p.Name), filter) {
+			procs = append(procs, p)
+		}
+	}
+	SortProcesses(procs, m.procSort, m.procIORate)
+	if rows := m.procRows(); len(procs) > rows {
+		procs = procs[:rows]
+	}
+	return procs
+}
+
+// procRows returns the chosen list length.
+func (m StatusModel) procRows() int {
+	return procRowChoices[m.procRowChoice%len(procRowChoices)]
+}
+
+// procViewHeight is the number of list rows that fit on screen.
+func (m StatusModel) procViewHeight() int {
+	return max(m.Height-14, 5)
+}
+
+// updateProcIORate derives the disk I/O rate of every process from the
+// previous sample prev and the new one cur.
+func (m *StatusModel) updateProcIORate(prev, cur *SystemMetrics) {
+	m.procIORate = make(map[int32]uint64, len(cur.Procs))
+	if prev == nil {
+		return
+	}
+	secs := cur.CollectedAt.Sub(prev.CollectedAt).Seconds()
+	if secs <= 0 {
+		return
+	}
+	before := make(map[int32]uint64, len(prev.Procs))
+	for _, p := range prev.Procs {
+		before[p.PID] = p.IOBytes
+	}
+	for _, p := range cur.Procs {
+		if b, ok := before[p.PID]; ok && p.IOBytes >= b {
+			m.procIORate[p.PID] = uint64(float64(p.IOBytes-b) / secs)
+		}
+	}
+}
+
+// selectProc puts the cursor on row i of the list and remembers its PID.
+func (m *StatusModel) selectProc(procs []ProcessInfo, i int) {
+	if len(procs) == 0 {
+		m.procCursor, m.procSelPID, m.procOffset = 0, 0, 0
+		return
+	}
+	m.procCursor = min(max(i, 0), len(procs)-1)
+	m.procSelPID = procs[m.procCursor].PID
+	if m.procCursor < m.procOffset {
+		m.procOffset = m.procCursor
+	}
+	if h := m.procViewHeight(); m.procCursor >= m.procOffset+h {
+		m.procOffset = m.procCursor - h + 1
+	}
+}
+
+// followSelection moves the cursor to the selected process after the list
+// changed, or keeps it on the same row when the process left the list.
+func (m *StatusModel) followSelection() {
+	procs := m.visibleProcs()
+	for i, p := range procs {
+		if p.PID == m.procSelPID {
+			m.selectProc(procs, i)
+			return
+		}
+	}
+	m.selectProc(procs, m.procCursor)
+}
+
+// updateProcFilterKey edits the name filter while its prompt is open.
+func (m *StatusModel) updateProcFilterKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		m.procFiltering = false
+		m.procInput.Blur()
+	case "esc":
+		m.procFiltering = false
+		m.procInput.Blur()
+		m.procFilter = ""
+		m.procInput.SetValue("")
+		m.followSelection()
+	default:
+		var cmd tea.Cmd
+		m.procInput, cmd = m.procInput.Update(msg)
+		m.procFilter = m.procInput.Value()
+		m.followSelection()
+		return cmd
+	}
+	return nil
+}
+
+// updateProcListKey handles the sort, filter and length keys of the list.
+// It reports false for other keys.
+func (m *StatusModel) updateProcListKey(key string) (tea.Cmd, bool) {
+	if by, ok := procSortKeys[key]; ok {
+		m.procSort = by
+		m.followSelection()
+		return nil, true
+	}
+	switch key {
+	case "r":
+		m.procRowChoice = (m.procRowChoice + 1) % len(procRowChoices)
+		m.followSelection()
+	case "/":
+		m.procFiltering = true
+		m.procInput.SetValue(m.procFilter)
+		m.procInput.CursorEnd()
+		return m.procInput.Focus(), true
+	case "esc":
+		if m.procFilter == "" {
+			return nil, false
+		}
+		m.procFilter = ""
+		m.procInput.SetValue("")
+		m.followSelection()
+	default:
+		return nil, false
+	}
+	return nil, true
+}
+
+// newProcInput creates the name filter prompt of the process list.
+func newProcInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "process name"
+	ti.Prompt = ""
+	ti.CharLimit = 64
+	return ti
+}
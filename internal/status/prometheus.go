@@ -0,0 +1,225 @@
+package status
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ─── Prometheus Exporter ─────────────────────────────────────────────────────
+// `pw status --listen :9182` serves the latest sample at /metrics in the
+// Prometheus text exposition format, so a Prometheus server can scrape the
+// machine without a separate windows_exporter. Metrics are named purewin_*
+// and translated from SystemMetrics; byte and operation counts that only
+// grow are counters, everything else gauges.
+
+// PrometheusContentType is the content type of the text exposition format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// promWriter writes metric families, each with its HELP and TYPE lines.
+type promWriter struct {
+	w *bufio.Writer
+}
+
+// family starts a metric family.
+func (p promWriter) family(name, typ, help string) {
+	fmt.Fprintf(p.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels alternate names and values.
+func (p promWriter) sample(name string, v float64, labels ...string) {
+	p.w.WriteString(name)
+	if len(labels) > 0 {
+		p.w.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				p.w.WriteByte(',')
+			}
+			fmt.Fprintf(p.w, "%s=\"%s\"", labels[i], promEscape(labels[i+1]))
+		}
+		p.w.WriteByte('}')
+	}
+	p.w.WriteByte(' ')
+	p.w.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	p.w.WriteByte('\n')
+}
+
+// gauge writes a family with a single unlabelled sample.
+func (p promWriter) gauge(name, help string, v float64) {
+	p.family(name, "gauge", help)
+	p.sample(name, v)
+}
+
+// counter writes a counter family with a single unlabelled sample.
+func (p promWriter) counter(name, help string, v float64) {
+	p.family(name, "counter", help)
+	p.sample(name, v)
+}
+
+// promEscape escapes a label value.
+func promEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// WritePrometheus writes m in the Prometheus text exposition format.
+// activeAlerts is the number of ongoing alerts.
+func WritePrometheus(w io.Writer, m *SystemMetrics, activeAlerts int) error {
+	p := promWriter{w: bufio.NewWriter(w)}
+
+	hw := m.Hardware
+	p.family("purewin_info", "gauge", "Machine identification; always 1.")
+	p.sample("purewin_info", 1, "hostname", hw.Hostname, "os", hw.OS, "os_version", hw.OSVersion,
+		"cpu", hw.CPUModel, "arch", hw.Architecture)
+	p.gauge("purewin_health_score", "Overall health score from 0 to 100.", float64(HealthScore(m)))
+	p.gauge("purewin_alerts_active", "Number of ongoing threshold alerts.", float64(activeAlerts))
+	p.gauge("purewin_collected_timestamp_seconds", "Unix time the sample was collected.",
+		float64(m.CollectedAt.UnixMilli())/1000)
+
+	if st := m.System; !st.BootTime.IsZero() {
+		p.gauge("purewin_boot_timestamp_seconds", "Unix time the machine last booted.", float64(st.BootTime.Unix()))
+	}
+	pending := 0.0
+	if m.System.RebootPending {
+		pending = 1
+	}
+	p.gauge("purewin_reboot_pending", "1 while Windows waits for a restart.", pending)
+	if m.System.CriticalEvents >= 0 {
+		p.gauge("purewin_critical_events_24h", "Critical events in the System log over the last 24 hours.", float64(m.System.CriticalEvents))
+	}
+
+	// CPU
+	p.gauge("purewin_cpu_usage_percent", "Total CPU usage.", m.CPU.TotalPercent)
+	p.gauge("purewin_cpu_logical_cores", "Number of logical processors.", float64(m.CPU.CoreCount))
+	if len(m.CPU.PerCore) > 0 {
+		p.family("purewin_cpu_core_usage_percent", "gauge", "CPU usage per logical processor.")
+		for i, v := range m.CPU.PerCore {
+			p.sample("purewin_cpu_core_usage_percent", v, "core", strconv.Itoa(i))
+		}
+	}
+	if len(m.AppCPU) > 0 {
+		apps := m.AppCPU
+		if len(apps) > maxExportedApps {
+			apps = apps[:maxExportedApps]
+		}
+		p.family("purewin_app_cpu_percent", "gauge", "CPU usage of the busiest applications, all processes of an image combined.")
+		for _, a := range apps {
+			p.sample("purewin_app_cpu_percent", a.CPUPct, "app", a.Name)
+		}
+	}
+
+	// Memory
+	p.gauge("purewin_memory_total_bytes", "Installed physical memory.", float64(m.Memory.Total))
+	p.gauge("purewin_memory_used_bytes", "Physical memory in use.", float64(m.Memory.Used))
+	p.gauge("purewin_memory_available_bytes", "Physical memory available to processes.", float64(m.Memory.Available))
+	p.gauge("purewin_swap_total_bytes", "Page file size.", float64(m.Memory.SwapTotal))
+	p.gauge("purewin_swap_used_bytes", "Page file in use.", float64(m.Memory.SwapUsed))
+
+	// Disk
+	if len(m.Disk.Partitions) > 0 {
+		parts := append([]DiskPartition(nil), m.Disk.Partitions...)
+		sort.Slice(parts, func(i, j int) bool { return parts[i].Path < parts[j].Path })
+		p.family("purewin_volume_size_bytes", "gauge", "Volume capacity.")
+		for _, d := range parts {
+			p.sample("purewin_volume_size_bytes", float64(d.Total), "volume", d.Path)
+		}
+		p.family("purewin_volume_free_bytes", "gauge", "Volume free space.")
+		for _, d := range parts {
+			p.sample("purewin_volume_free_bytes", float64(d.Free), "volume", d.Path)
+		}
+	}
+	if len(m.Disk.Health) > 0 {
+		p.family("purewin_disk_smart_failure_predicted", "gauge", "1 while a drive's S.M.A.R.T. status predicts its failure.")
+		for _, d := range m.Disk.Health {
+			failing := 0.0
+			if d.PredictFailure {
+				failing = 1
+			}
+			p.sample("purewin_disk_smart_failure_predicted", failing, "drive", d.Model, "device", d.Device)
+		}
+		p.family("purewin_disk_reallocated_sectors", "gauge", "Sectors a drive remapped after they went bad.")
+		for _, d := range m.Disk.Health {
+			if d.HasAttributes {
+				p.sample("purewin_disk_reallocated_sectors", float64(d.Reallocated), "drive", d.Model, "device", d.Device)
+			}
+		}
+		p.family("purewin_disk_temperature_celsius", "gauge", "Drive temperature.")
+		for _, d := range m.Disk.Health {
+			if d.TemperatureC > 0 {
+				p.sample("purewin_disk_temperature_celsius", float64(d.TemperatureC), "drive", d.Model, "device", d.Device)
+			}
+		}
+	}
+	p.counter("purewin_disk_read_bytes_total", "Bytes read from all disks.", float64(m.Disk.ReadBytes))
+	p.counter("purewin_disk_written_bytes_total", "Bytes written to all disks.", float64(m.Disk.WriteBytes))
+
+	// Network
+	p.counter("purewin_network_received_bytes_total", "Bytes received on all interfaces.", float64(m.Network.BytesRecv))
+	p.counter("purewin_network_sent_bytes_total", "Bytes sent on all interfaces.", float64(m.Network.BytesSent))
+
+	// GPU and battery, when present
+	if m.GPU.Name != "" {
+		p.family("purewin_gpu_memory_total_bytes", "gauge", "Dedicated video memory.")
+		p.sample("purewin_gpu_memory_total_bytes", float64(m.GPU.VRAMTotal), "gpu", m.GPU.Name)
+		if m.GPU.VRAMUsed > 0 {
+			p.family("purewin_gpu_memory_used_bytes", "gauge", "Dedicated video memory in use.")
+			p.sample("purewin_gpu_memory_used_bytes", float64(m.GPU.VRAMUsed), "gpu", m.GPU.Name)
+		}
+	}
+	if m.Battery.HasBattery {
+		charging := 0.0
+		if m.Battery.IsCharging {
+			charging = 1
+		}
+		p.gauge("purewin_battery_charge_percent", "Battery charge.", float64(m.Battery.Charge))
+		p.gauge("purewin_battery_charging", "1 while the battery is charging.", charging)
+		if b := m.Battery; b.DesignCapacity > 0 {
+			p.gauge("purewin_battery_design_capacity_mwh", "Capacity the battery was designed for.", float64(b.DesignCapacity))
+			p.gauge("purewin_battery_full_charge_capacity_mwh", "Capacity the battery holds at a full charge.", float64(b.FullChargeCapacity))
+		}
+		if m.Battery.CycleCount > 0 {
+			p.gauge("purewin_battery_cycle_count", "Charge cycles reported by the battery.", float64(m.Battery.CycleCount))
+		}
+	}
+
+	return p.w.Flush()
+}
+
+// PrometheusHandler serves the latest sample at /metrics.
+type PrometheusHandler struct {
+	latest atomic.Pointer[SystemMetrics]
+	alerts *AlertTracker
+}
+
+// NewPrometheusHandler returns a handler serving the samples passed to
+// Update, with the ongoing alerts of alerts (which may be nil).
+func NewPrometheusHandler(alerts *AlertTracker) *PrometheusHandler {
+	return &PrometheusHandler{alerts: alerts}
+}
+
+// Update makes m the sample served.
+func (h *PrometheusHandler) Update(m *SystemMetrics) {
+	h.latest.Store(m)
+}
+
+func (h *PrometheusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head><title>PureWin Exporter</title></head><body><h1>PureWin Exporter</h1><p><a href="/metrics">Metrics</a></p></body></html>`)
+	case "/metrics":
+		m := h.latest.Load()
+		if m == nil {
+			http.Error(w, "no sample collected yet", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", PrometheusContentType)
+		_ = WritePrometheus(w, m, len(h.alerts.Active()))
+	default:
+		http.NotFound(w, r)
+	}
+}
@@ -0,0 +1,293 @@
+package status
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Metrics Recording ───────────────────────────────────────────────────────
+// `pw status --record FILE` appends every sample to a CSV or JSON Lines file
+// (chosen by its extension), and `--daemon` does so headless, so spikes can
+// be analysed after the fact. The dashboard seeds its sparklines from the
+// tail of the file, so its history survives restarts.
+
+// RecordFileName is the file the daemon records to when no --record path
+// is given, in the config directory.
+const RecordFileName = "metrics.csv"
+
+// historyMaxAge is how old the last recorded sample may be for the file to
+// seed the sparklines; older history would draw a misleading trend.
+const historyMaxAge = 10 * time.Minute
+
+// historyTailBytes is how much of the end of a recording is read to seed
+// the sparklines, which is ample for 60 samples of either format.
+const historyTailBytes = 64 * 1024
+
+// sampleColumns are the CSV columns, in order, and the JSON field names.
+var sampleColumns = []string{
+	"time", "cpu_pct", "mem_pct", "swap_pct",
+	"disk_read_bytes", "disk_write_bytes",
+	"net_sent_bytes", "net_recv_bytes", "net_send_bps", "net_recv_bps",
+	"gpu_vram_used", "battery_pct", "health_score",
+}
+
+// Sample is one recorded row: the scalar metrics of a collection cycle.
+type Sample struct {
+	Time         time.Time `json:"time"`
+	CPUPercent   float64   `json:"cpu_pct"`
+	MemPercent   float64   `json:"mem_pct"`
+	SwapPercent  float64   `json:"swap_pct"`
+	DiskRead     uint64    `json:"disk_read_bytes"`
+	DiskWrite    uint64    `json:"disk_write_bytes"`
+	NetSent      uint64    `json:"net_sent_bytes"`
+	NetRecv      uint64    `json:"net_recv_bytes"`
+	NetSendSpeed uint64    `json:"net_send_bps"`
+	NetRecvSpeed uint64    `json:"net_recv_bps"`
+	GPUVRAMUsed  uint64    `json:"gpu_vram_used"`
+	BatteryPct   int       `json:"battery_pct"` // -1 without a battery
+	HealthScore  int       `json:"health_score"`
+}
+
+// NewSample flattens a collection cycle into a Sample.
+func NewSample(m *SystemMetrics) Sample {
+	s := Sample{
+		Time:         m.CollectedAt,
+		CPUPercent:   m.CPU.TotalPercent,
+		MemPercent:   m.Memory.UsedPercent,
+		SwapPercent:  m.Memory.SwapPercent,
+		DiskRead:     m.Disk.ReadBytes,
+		DiskWrite:    m.Disk.WriteBytes,
+		NetSent:      m.Network.BytesSent,
+		NetRecv:      m.Network.BytesRecv,
+		NetSendSpeed: m.Network.SendSpeed,
+		NetRecvSpeed: m.Network.RecvSpeed,
+		GPUVRAMUsed:  m.GPU.VRAMUsed,
+		BatteryPct:   -1,
+		HealthScore:  HealthScore(m),
+	}
+	if s.Time.IsZero() {
+		s.Time = time.Now()
+	}
+	if m.Battery.HasBattery {
+		s.BatteryPct = int(m.Battery.Charge)
+	}
+	return s
+}
+
+// record returns the sample as CSV fields, in sampleColumns order.
+func (s Sample) record() []string {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	u := func(v uint64) string { return strconv.FormatUint(v, 10) }
+	return []string{
+		s.Time.Format(time.RFC3339), f(s.CPUPercent), f(s.MemPercent), f(s.SwapPercent),
+		u(s.DiskRead), u(s.DiskWrite),
+		u(s.NetSent), u(s.NetRecv), u(s.NetSendSpeed), u(s.NetRecvSpeed),
+		u(s.GPUVRAMUsed), strconv.Itoa(s.BatteryPct), strconv.Itoa(s.HealthScore),
+	}
+}
+
+// parseSampleRecord parses CSV fields written by record.
+func parseSampleRecord(rec []string) (Sample, error) {
+	if len(rec) != len(sampleColumns) {
+		return Sample{}, fmt.Errorf("expected %d columns, got %d", len(sampleColumns), len(rec))
+	}
+	var s Sample
+	var err error
+	if s.Time, err = time.Parse(time.RFC3339, rec[0]); err != nil {
+		return Sample{}, err
+	}
+	floats := []*float64{&s.CPUPercent, &s.MemPercent, &s.SwapPercent}
+	for i, p := range floats {
+		if *p, err = strconv.ParseFloat(rec[1+i], 64); err != nil {
+			return Sample{}, err
+		}
+	}
+	uints := []*uint64{&s.DiskRead, &s.DiskWrite, &s.NetSent, &s.NetRecv, &s.NetSendSpeed, &s.NetRecvSpeed, &s.GPUVRAMUsed}
+	for i, p := range uints {
+		if *p, err = strconv.ParseUint(rec[4+i], 10, 64); err != nil {
+			return Sample{}, err
+		}
+	}
+	if s.BatteryPct, err = strconv.Atoi(rec[11]); err != nil {
+		return Sample{}, err
+	}
+	if s.HealthScore, err = strconv.Atoi(rec[12]); err != nil {
+		return Sample{}, err
+	}
+	return s, nil
+}
+
+// isJSONL reports whether path records JSON Lines rather than CSV.
+func isJSONL(path string) (bool, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return false, nil
+	case ".jsonl", ".ndjson", ".json":
+		return true, nil
+	}
+	return false, fmt.Errorf("cannot record to %s: use a .csv or .jsonl file", filepath.Base(path))
+}
+
+// Recorder appends samples to a CSV or JSON Lines file.
+type Recorder struct {
+	mu    sync.Mutex
+	path  string
+	jsonl bool
+	f     *os.File
+}
+
+// OpenRecorder opens path for appending, creating it — with a header row
+// for CSV — when missing.
+func OpenRecorder(path string) (*Recorder, error) {
+	jsonl, err := isJSONL(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", path, err)
+	}
+	r := &Recorder{path: path, jsonl: jsonl, f: f}
+	if info, err := f.Stat(); err == nil && info.Size() == 0 && !jsonl {
+		if err := r.writeCSV(sampleColumns); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Path returns the file being recorded to.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Record appends one sample of m. A nil recorder records nothing.
+func (r *Recorder) Record(m *SystemMetrics) error {
+	if r == nil || m == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := NewSample(m)
+	if r.jsonl {
+		line, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if _, err := r.f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("cannot write to %s: %w", r.path, err)
+		}
+		return nil
+	}
+	return r.writeCSV(s.record())
+}
+
+func (r *Recorder) writeCSV(fields []string) error {
+	w := csv.NewWriter(r.f)
+	_ = w.Write(fields)
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("cannot write to %s: %w", r.path, err)
+	}
+	return nil
+}
+
+// Close closes the file. A nil recorder is ignored.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.f.Close()
+}
+
+// LoadHistory returns up to the last n samples recorded at path, oldest
+// first. Unparseable lines, such as the CSV header, are skipped.
+func LoadHistory(path string, n int) ([]Sample, error) {
+	jsonl, err := isJSONL(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	start := max(info.Size()-historyTailBytes, 0)
+	if _, err := f.Seek(start, io.SeekStart); err != nil {
+		return nil, err
+	}
+	tail, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+	if start > 0 {
+		// Drop the line the window starts inside.
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+
+	var samples []Sample
+	sc := bufio.NewScanner(bytes.NewReader(tail))
+	for sc.Scan() {
+		line := sc.Text()
+		var s Sample
+		if jsonl {
+			if json.Unmarshal([]byte(line), &s) != nil {
+				continue
+			}
+		} else {
+			rec, err := csv.NewReader(strings.NewReader(line)).Read()
+			if err != nil {
+				continue
+			}
+			if s, err = parseSampleRecord(rec); err != nil {
+				continue
+			}
+		}
+		samples = append(samples, s)
+	}
+	if len(samples) > n {
+		samples = samples[len(samples)-n:]
+	}
+	return samples, sc.Err()
+}
+
+// SeedHistory fills the sparkline histories from recorded samples, unless
+// the last of them is too old to continue the trend.
+func (m *StatusModel) SeedHistory(samples []Sample) {
+	if len(samples) == 0 || time.Since(samples[len(samples)-1].Time) > historyMaxAge {
+		return
+	}
+	for _, s := range samples {
+		m.CPUHistory = appendF64(m.CPUHistory, s.CPUPercent, 60)
+		m.MemHistory = appendF64(m.MemHistory, s.MemPercent, 60)
+		m.NetSendHistory = appendU64(m.NetSendHistory, s.NetSendSpeed, 60)
+		m.NetRecvHistory = appendU64(m.NetRecvHistory, s.NetRecvSpeed, 60)
+		if s.BatteryPct >= 0 {
+			m.BatteryHistory = appendF64(m.BatteryHistory, float64(s.BatteryPct), 60)
+		}
+	}
+}
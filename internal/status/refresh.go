@@ -0,0 +1,95 @@
+package status
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ─── Refresh Control ─────────────────────────────────────────────────────────
+// + and - step the sampling interval through refreshSteps, and p pauses
+// sampling so the sparklines hold still for inspection. While the terminal
+// reports that it lost focus, the dashboard samples at most every
+// unfocusedInterval, since nobody is watching; the monitor should not be
+// what keeps the CPU busy.
+
+// refreshSteps are the intervals + and - step through.
+var refreshSteps = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// unfocusedInterval is the slowest the dashboard samples while unfocused
+// at its usual interval.
+const unfocusedInterval = 5 * time.Second
+
+// MinRefreshInterval is the shortest interval accepted; collection itself
+// takes about 200ms.
+const MinRefreshInterval = 250 * time.Millisecond
+
+// interval returns the interval until the next sample.
+func (m StatusModel) interval() time.Duration {
+	if m.blurred {
+		return max(m.refreshInterval, unfocusedInterval)
+	}
+	return m.refreshInterval
+}
+
+// stepInterval moves the interval to the next longer (dir > 0) or shorter
+// step.
+func (m *StatusModel) stepInterval(dir int) {
+	cur := m.refreshInterval
+	if dir > 0 {
+		for _, d := range refreshSteps {
+			if d > cur {
+				m.refreshInterval = d
+				break
+			}
+		}
+	} else {
+		for i := len(refreshSteps) - 1; i >= 0; i-- {
+			if refreshSteps[i] < cur {
+				m.refreshInterval = refreshSteps[i]
+				break
+			}
+		}
+	}
+	m.notice = "Refreshing every " + m.refreshInterval.String()
+}
+
+// togglePause pauses or resumes sampling. The tick loop stops at the first
+// tick after pausing; resuming restarts it only if it has stopped, so a
+// quick pause and resume never runs two loops.
+func (m *StatusModel) togglePause() tea.Cmd {
+	m.paused = !m.paused
+	if m.paused {
+		m.notice = "Paused — p to resume"
+		return nil
+	}
+	if m.stalled {
+		m.stalled = false
+		return m.collectMetrics()
+	}
+	return nil
+}
+
+// updateRefreshKey handles the refresh keys. It reports false for other
+// keys.
+func (m *StatusModel) updateRefreshKey(key string) (tea.Cmd, bool) {
+	switch key {
+	case "+", "=":
+		m.stepInterval(1)
+	case "-", "_":
+		m.stepInterval(-1)
+	case "p":
+		return m.togglePause(), true
+	default:
+		return nil, false
+	}
+	return nil, true
+}
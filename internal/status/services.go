@@ -0,0 +1,182 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/mgr"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
+)
+
+// ─── Service Health ──────────────────────────────────────────────────────────
+// The Services tab watches a list of Windows services: their state and
+// start type, with automatic services that are not running flagged, since
+// Windows meant them to be. The list defaults to the services `pw
+// optimize` manages; watched_services.json in the config directory — a
+// JSON array of service names — replaces it. R restarts the selected
+// service, and the services depending on it, through optimize.RestartService
+// after confirmation; that needs an elevated prompt.
+
+// WatchedServicesFileName is the watchlist file in the config directory.
+const WatchedServicesFileName = "watched_services.json"
+
+// serviceStates names the states of a service.
+var serviceStates = map[svc.State]string{
+	svc.Stopped:         "Stopped",
+	svc.StartPending:    "Starting",
+	svc.StopPending:     "Stopping",
+	svc.Running:         "Running",
+	svc.ContinuePending: "Resuming",
+	svc.PausePending:    "Pausing",
+	svc.Paused:          "Paused",
+}
+
+// serviceStartTypes names the start types of a service.
+var serviceStartTypes = map[uint32]string{
+	mgr.StartAutomatic:           "Automatic",
+	mgr.StartManual:              "Manual",
+	mgr.StartDisabled:            "Disabled",
+	windows.SERVICE_BOOT_START:   "Boot",
+	windows.SERVICE_SYSTEM_START: "System",
+}
+
+// ServiceStatus is the health of one watched service.
+type ServiceStatus struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	State       string `json:"state"`
+	StartType   string `json:"start_type"`
+	Flagged     bool   `json:"flagged"` // automatic but not running
+	Err         string `json:"error,omitempty"`
+}
+
+// DefaultWatchedServices returns the services optimize manages.
+func DefaultWatchedServices() []string {
+	var names []string
+	for _, s := range optimize.GetManagedServices() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// LoadWatchedServices reads the watchlist file. A missing file yields the
+// default watchlist.
+func LoadWatchedServices(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultWatchedServices(), nil
+		}
+		return nil, fmt.Errorf("cannot read watched services %s: %w", path, err)
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse watched services %s: %w", path, err)
+	}
+	return names, nil
+}
+
+// CollectServices reads the state and start type of each named service.
+// A service that cannot be read, such as one that is not installed, is
+// listed with its error.
+func CollectServices(names []string) []ServiceStatus {
+	out := make([]ServiceStatus, 0, len(names))
+	for _, name := range names {
+		out = append(out, queryService(name))
+	}
+	return out
+}
+
+// queryService reads one service.
+func queryService(name string) ServiceStatus {
+	st := ServiceStatus{Name: name, DisplayName: name}
+	s, err := core.OpenService(name, windows.SERVICE_QUERY_STATUS|windows.SERVICE_QUERY_CONFIG)
+	if err != nil {
+		st.Err = err.Error()
+		return st
+	}
+	defer s.Close()
+
+	if cfg, err := s.Config(); err == nil {
+		if cfg.DisplayName != "" {
+			st.DisplayName = cfg.DisplayName
+		}
+		st.StartType = serviceStartTypes[cfg.StartType]
+		if cfg.StartType == mgr.StartAutomatic && cfg.DelayedAutoStart {
+			st.StartType += " (delayed)"
+		}
+	}
+	status, err := s.Query()
+	if err != nil {
+		st.Err = fmt.Sprintf("cannot query %s: %v", name, err)
+		return st
+	}
+	st.State = serviceStates[status.State]
+	st.Flagged = strings.HasPrefix(st.StartType, "Automatic") && status.State == svc.Stopped
+	return st
+}
+
+// ─── Model integration ───────────────────────────────────────────────────────
+
+// servicesMsg carries the state of the watched services.
+type servicesMsg struct {
+	services []ServiceStatus
+}
+
+// serviceRestartMsg reports the outcome of restarting a service.
+type serviceRestartMsg struct {
+	name string
+	err  error
+}
+
+// collectServices reads the watched services. It only runs while the
+// Services tab is shown.
+func (m StatusModel) collectServices() tea.Cmd {
+	names := m.WatchedServices
+	return func() tea.Msg {
+		return servicesMsg{services: CollectServices(names)}
+	}
+}
+
+// restartService restarts a service with its dependents.
+func restartService(name string) tea.Cmd {
+	return func() tea.Msg {
+		return serviceRestartMsg{name: name, err: optimize.RestartService(name)}
+	}
+}
+
+// updateServicesKey handles selection and the restart action on the
+// Services tab.
+func (m *StatusModel) updateServicesKey(key string) tea.Cmd {
+	if m.serviceConfirm != "" {
+		name := m.serviceConfirm
+		m.serviceConfirm = ""
+		if key == "enter" {
+			m.notice = "Restarting " + name + "…"
+			return restartService(name)
+		}
+		return nil
+	}
+	switch key {
+	case "up", "k":
+		if m.serviceCursor > 0 {
+			m.serviceCursor--
+		}
+	case "down", "j":
+		if m.serviceCursor < len(m.services)-1 {
+			m.serviceCursor++
+		}
+	case "R":
+		if m.serviceCursor < len(m.services) {
+			m.serviceConfirm = m.services[m.serviceCursor].Name
+		}
+	}
+	return nil
+}
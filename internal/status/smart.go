@@ -0,0 +1,168 @@
+package status
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yusufpapurcu/wmi"
+)
+
+// ─── S.M.A.R.T. Disk Health ──────────────────────────────────────────────────
+// The Disk tab shows what each physical disk's S.M.A.R.T. self-monitoring
+// reports: whether the drive predicts its own failure, its temperature,
+// reallocated sectors and power-on hours. The storage driver exposes these
+// through the MSStorageDriver_FailurePredict* classes of root\WMI, which
+// require an elevated prompt and cover ATA/SATA drives; NVMe drives and
+// drives behind RAID controllers are often missing. A drive predicting its
+// failure costs the health score more than anything else. S.M.A.R.T. data
+// changes slowly, so it is re-read every few minutes rather than every
+// sample.
+
+// smartRefresh is how often S.M.A.R.T. data is re-read.
+const smartRefresh = 5 * time.Minute
+
+// S.M.A.R.T. attribute IDs.
+const (
+	smartReallocatedSectors = 5
+	smartPowerOnHours       = 9
+	smartAirflowTemperature = 190
+	smartTemperature        = 194
+)
+
+// smartAttributeSize is the size of an entry of the attribute table, which
+// starts after a two-byte revision number in the vendor-specific data.
+const smartAttributeSize = 12
+
+type msStorageDriverFailurePredictStatus struct {
+	InstanceName   string
+	PredictFailure bool
+	Reason         uint32
+}
+
+type msStorageDriverFailurePredictData struct {
+	InstanceName   string
+	VendorSpecific []uint8
+}
+
+type win32DiskDrive struct {
+	Model       string
+	PNPDeviceID string
+}
+
+// DiskHealth is the S.M.A.R.T. status of one physical disk.
+type DiskHealth struct {
+	Model          string
+	Device         string // storage driver instance, unique per disk
+	PredictFailure bool   // the drive expects to fail; back it up
+	Reason         uint32 // vendor-specific reason for the prediction
+	TemperatureC   int    // 0 when not reported
+	Reallocated    uint64 // sectors remapped after going bad
+	PowerOnHours   uint64
+	HasAttributes  bool // the attributes above were read
+}
+
+// smartCache caches the last S.M.A.R.T. reading.
+var smartCache struct {
+	sync.Mutex
+	readAt time.Time
+	disks  []DiskHealth
+}
+
+// CollectDiskHealth returns the S.M.A.R.T. status of every disk that
+// reports it, from the cache when it is recent.
+func CollectDiskHealth() []DiskHealth {
+	smartCache.Lock()
+	defer smartCache.Unlock()
+	if time.Since(smartCache.readAt) > smartRefresh {
+		smartCache.disks = readDiskHealth()
+		smartCache.readAt = time.Now()
+	}
+	return smartCache.disks
+}
+
+// readDiskHealth queries root\WMI for the failure prediction and attributes
+// of every disk.
+func readDiskHealth() []DiskHealth {
+	var statuses []msStorageDriverFailurePredictStatus
+	if err := wmi.QueryNamespace("SELECT InstanceName, PredictFailure, Reason FROM MSStorageDriver_FailurePredictStatus", &statuses, `root\WMI`); err != nil || len(statuses) == 0 {
+		return nil
+	}
+
+	var data []msStorageDriverFailurePredictData
+	_ = wmi.QueryNamespace("SELECT InstanceName, VendorSpecific FROM MSStorageDriver_FailurePredictData", &data, `root\WMI`)
+	attrs := make(map[string][]uint8, len(data))
+	for _, d := range data {
+		attrs[strings.ToLower(d.InstanceName)] = d.VendorSpecific
+	}
+
+	var drives []win32DiskDrive
+	_ = wmi.Query("SELECT Model, PNPDeviceID FROM Win32_DiskDrive", &drives)
+
+	disks := make([]DiskHealth, 0, len(statuses))
+	for _, st := range statuses {
+		h := DiskHealth{
+			Model:          diskModel(st.InstanceName, drives),
+			Device:         st.InstanceName,
+			PredictFailure: st.PredictFailure,
+			Reason:         st.Reason,
+		}
+		if raw, ok := attrs[strings.ToLower(st.InstanceName)]; ok {
+			parseSmartAttributes(raw, &h)
+		}
+		disks = append(disks, h)
+	}
+	return disks
+}
+
+// diskModel names the drive of a storage driver instance, whose name is
+// the drive's PnP device ID with an instance suffix such as "_0".
+func diskModel(instance string, drives []win32DiskDrive) string {
+	id := instance
+	if i := strings.LastIndexByte(id, '_'); i > 0 {
+		id = id[:i]
+	}
+	for _, d := range drives {
+		if strings.EqualFold(d.PNPDeviceID, id) {
+			return strings.TrimSpace(d.Model)
+		}
+	}
+	// Fall back to the device part of the ID, e.g. "Disk&Ven_WDC&Prod_…".
+	parts := strings.Split(id, `\`)
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return id
+}
+
+// parseSmartAttributes reads the attributes of interest from the raw
+// attribute table.
+func parseSmartAttributes(raw []uint8, h *DiskHealth) {
+	for off := 2; off+smartAttributeSize <= len(raw); off += smartAttributeSize {
+		a := raw[off : off+smartAttributeSize]
+		id := a[0]
+		if id == 0 {
+			continue
+		}
+		// Bytes 5-10 hold the raw value, little-endian.
+		var value uint64
+		for i := 10; i >= 5; i-- {
+			value = value<<8 | uint64(a[i])
+		}
+		switch id {
+		case smartReallocatedSectors:
+			h.Reallocated = value
+		case smartPowerOnHours:
+			h.PowerOnHours = value & 0xFFFFFFFF // the upper bytes hold minutes on some drives
+		case smartTemperature:
+			h.TemperatureC = int(a[5]) // the other bytes hold minimum and maximum
+		case smartAirflowTemperature:
+			if h.TemperatureC == 0 {
+				h.TemperatureC = int(a[5])
+			}
+		default:
+			continue
+		}
+		h.HasAttributes = true
+	}
+}
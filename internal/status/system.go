@@ -0,0 +1,152 @@
+package status
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/shirou/gopsutil/v4/host"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// ─── Uptime and System Events ────────────────────────────────────────────────
+// The Overview shows how long the machine has been up, whether Windows is
+// waiting for a restart to finish installing something, and how many
+// critical events the System log recorded in the last day — kernel power
+// losses, bugchecks and the like. A pending restart or critical events lower
+// the health score, and the Overview names them next to it. Windows flags a
+// pending restart in three places: component servicing, Windows Update and
+// the file renames Session Manager performs at boot.
+
+// criticalEventWindow is how far back critical events are counted.
+const criticalEventWindow = 24 * time.Hour
+
+// criticalEventRefresh is how often the System log is re-read.
+const criticalEventRefresh = 5 * time.Minute
+
+// maxCriticalEvents caps the count, which only has to tell a few from many.
+const maxCriticalEvents = 999
+
+// criticalEventQuery selects the critical (level 1) events of the window.
+var criticalEventQuery = fmt.Sprintf("*[System[Level=1 and TimeCreated[timediff(@SystemTime) <= %d]]]",
+	criticalEventWindow.Milliseconds())
+
+const (
+	evtQueryChannelPath = 0x1
+	evtInfiniteTimeout  = 0xFFFFFFFF
+)
+
+var (
+	modWevtapi   = windows.NewLazySystemDLL("wevtapi.dll")
+	procEvtQuery = modWevtapi.NewProc("EvtQuery")
+	procEvtNext  = modWevtapi.NewProc("EvtNext")
+	procEvtClose = modWevtapi.NewProc("EvtClose")
+)
+
+// rebootFlag is a registry key — or, when value is set, a value — whose
+// presence means a restart is pending.
+type rebootFlag struct {
+	reason string
+	key    string
+	value  string
+}
+
+var rebootFlags = []rebootFlag{
+	{reason: "component servicing", key: `SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\RebootPending`},
+	{reason: "Windows Update", key: `SOFTWARE\Microsoft\Windows\CurrentVersion\WindowsUpdate\Auto Update\RebootRequired`},
+	{reason: "file renames", key: `SYSTEM\CurrentControlSet\Control\Session Manager`, value: "PendingFileRenameOperations"},
+}
+
+// SystemState is the uptime and restart state of the machine.
+type SystemState struct {
+	BootTime       time.Time     `json:"boot_time"`
+	Uptime         time.Duration `json:"uptime_ns"`
+	RebootPending  bool          `json:"reboot_pending"`
+	RebootReasons  []string      `json:"reboot_reasons,omitempty"`
+	CriticalEvents int           `json:"critical_events_24h"` // -1 when the log could not be read
+}
+
+// criticalEvents caches the count of critical events.
+var criticalEvents struct {
+	sync.Mutex
+	readAt time.Time
+	count  int
+}
+
+// CollectSystemState reads the boot time, the pending restart flags and the
+// recent critical events.
+func CollectSystemState() SystemState {
+	var s SystemState
+	if boot, err := host.BootTime(); err == nil && boot > 0 {
+		s.BootTime = time.Unix(int64(boot), 0)
+		s.Uptime = time.Since(s.BootTime).Truncate(time.Second)
+	}
+	s.RebootReasons = pendingRebootReasons()
+	s.RebootPending = len(s.RebootReasons) > 0
+
+	criticalEvents.Lock()
+	if time.Since(criticalEvents.readAt) > criticalEventRefresh {
+		criticalEvents.count = countCriticalEvents()
+		criticalEvents.readAt = time.Now()
+	}
+	s.CriticalEvents = criticalEvents.count
+	criticalEvents.Unlock()
+	return s
+}
+
+// pendingRebootReasons returns why a restart is pending, if it is.
+func pendingRebootReasons() []string {
+	var reasons []string
+	for _, f := range rebootFlags {
+		k, err := registry.OpenKey(registry.LOCAL_MACHINE, f.key, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		pending := f.value == ""
+		if !pending {
+			if v, _, err := k.GetStringsValue(f.value); err == nil && len(v) > 0 {
+				pending = true
+			}
+		}
+		k.Close()
+		if pending {
+			reasons = append(reasons, f.reason)
+		}
+	}
+	return reasons
+}
+
+// countCriticalEvents counts the critical events of the System log in the
+// last criticalEventWindow, or returns -1 when the log cannot be read.
+func countCriticalEvents() int {
+	if procEvtQuery.Find() != nil {
+		return -1
+	}
+	path, _ := windows.UTF16PtrFromString("System")
+	query, _ := windows.UTF16PtrFromString(criticalEventQuery)
+	results, _, _ := procEvtQuery.Call(0, uintptr(unsafe.Pointer(path)),
+		uintptr(unsafe.Pointer(query)), evtQueryChannelPath)
+	if results == 0 {
+		return -1
+	}
+	defer procEvtClose.Call(results)
+
+	count := 0
+	var events [64]uintptr
+	for count < maxCriticalEvents {
+		var returned uint32
+		ok, _, _ := procEvtNext.Call(results, uintptr(len(events)),
+			uintptr(unsafe.Pointer(&events[0])), evtInfiniteTimeout, 0,
+			uintptr(unsafe.Pointer(&returned)))
+		if ok == 0 {
+			break // ERROR_NO_MORE_ITEMS, or the log went away
+		}
+		for _, e := range events[:returned] {
+			procEvtClose.Call(e)
+		}
+		count += int(returned)
+	}
+	return min(count, maxCriticalEvents)
+}
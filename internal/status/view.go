@@ -3,10 +3,13 @@ package status
 import (
 	"fmt"
 	"math"
+	"math/bits"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/optimize"
 	"github.com/cy-infamous/purewin/internal/ui"
 )
 
@@ -41,6 +44,8 @@ func (m StatusModel) renderView() string {
 		return s.String()
 	}
 
+	s.WriteString(m.renderAlertBanner())
+
 	switch m.Tab {
 	case TabOverview:
 		s.WriteString(m.renderOverview(w))
@@ -54,6 +59,10 @@ func (m StatusModel) renderView() string {
 		s.WriteString(m.renderNetwork(w))
 	case TabProcesses:
 		s.WriteString(m.renderProcesses(w))
+	case TabAlerts:
+		s.WriteString(m.renderAlerts(w))
+	case TabServices:
+		s.WriteString(m.renderServices(w))
 	}
 
 	s.WriteString("\n")
@@ -61,6 +70,36 @@ func (m StatusModel) renderView() string {
 	return s.String()
 }
 
+// ─── Alert banner ───────────────────────────────────────────────────────────
+
+// maxBannerAlerts caps the ongoing alerts listed above the tabs' content.
+const maxBannerAlerts = 3
+
+// renderAlertBanner warns about ongoing alerts on every tab.
+func (m StatusModel) renderAlertBanner() string {
+	active := m.Alerts.Active()
+	if len(active) == 0 {
+		return ""
+	}
+	var s strings.Builder
+	for i, a := range active {
+		if i == maxBannerAlerts {
+			s.WriteString(ui.WarningStyle().Render(fmt.Sprintf("  %s and %d more — press 7 to review", ui.IconWarning, len(active)-i)) + "\n")
+			break
+		}
+		cmp := "above"
+		if a.Below {
+			cmp = "below"
+		}
+		line := fmt.Sprintf("  %s %s %s %.0f%% since %s", ui.IconWarning, a.Label, cmp, a.Threshold, a.StartedAt.Local().Format("15:04"))
+		if a.Detail != "" {
+			line += " on " + a.Detail
+		}
+		s.WriteString(ui.WarningStyle().Bold(true).Render(line) + "\n")
+	}
+	return s.String()
+}
+
 // ─── Tab bar ─────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderTabs(w int) string {
@@ -120,6 +159,15 @@ func (m StatusModel) renderOverview(w int) string {
 	s.WriteString(fmt.Sprintf("  %s  %s\n",
 		scoreTag.Render(fmt.Sprintf(" %d ", score)),
 		dimStyle.Render(scoreLabel)))
+	if issues := HealthIssues(met); len(issues) > 0 {
+		s.WriteString("  " + dimStyle.Render(ui.IconArrow+" ") +
+			subtleStyle.Render(strings.Join(issues, "  ·  ")) + "\n")
+	}
+	if n := m.Alerts.Unacknowledged(); n > 0 {
+		s.WriteString(fmt.Sprintf("  %s  %s\n",
+			ui.TagWarningStyle().Render(fmt.Sprintf(" %d ", n)),
+			ui.WarningStyle().Render("unacknowledged alerts — press 7 to review")))
+	}
 	s.WriteString("\n")
 
 	// ── System ──
@@ -141,15 +189,8 @@ func (m StatusModel) renderOverview(w int) string {
 
 	s.WriteString(hwLine1 + "\n")
 	s.WriteString(hwLine2 + "\n")
-
-	if met.Battery.HasBattery {
-		batt := fmt.Sprintf("%d%%", met.Battery.Charge)
-		if met.Battery.IsCharging {
-			batt += " charging"
-		}
-		s.WriteString(fmt.Sprintf("  %s %s\n",
-			dimStyle.Render("Battery"),
-			subtleStyle.Render(batt)))
+	if line := renderSystemState(met.System); line != "" {
+		s.WriteString(line + "\n")
 	}
 
 	s.WriteString("\n")
@@ -194,6 +235,19 @@ func (m StatusModel) renderOverview(w int) string {
 		s.WriteString("\n")
 	}
 
+	// GPU memory
+	if met.GPU.VRAMTotal > 0 && met.GPU.VRAMUsed > 0 {
+		detail := fmt.Sprintf("%s / %s",
+			core.FormatSize(int64(met.GPU.VRAMUsed)),
+			core.FormatSize(int64(met.GPU.VRAMTotal)))
+		if met.GPU.DriverVersion != "" {
+			detail += "  " + dimStyle.Render("driver "+met.GPU.DriverVersion)
+		}
+		s.WriteString(renderMetricRow("VRAM",
+			float64(met.GPU.VRAMUsed)/float64(met.GPU.VRAMTotal)*100, barW, detail))
+		s.WriteString("\n")
+	}
+
 	// Network
 	dlStyle := lipgloss.NewStyle().Foreground(ui.ColorTeal)
 	ulStyle := lipgloss.NewStyle().Foreground(ui.ColorAccent)
@@ -213,9 +267,106 @@ func (m StatusModel) renderOverview(w int) string {
 			renderSparklineU64(m.NetSendHistory, graphW/2, ui.ColorAccent)))
 	}
 
+	if met.Battery.HasBattery {
+		s.WriteString("\n")
+		s.WriteString(m.renderBattery(w, barW, graphW))
+	}
+
 	return s.String()
 }
 
+// renderBattery renders the Battery section of the Overview: charge, health
+// and time remaining, with the recent charge level.
+func (m StatusModel) renderBattery(w, barW, graphW int) string {
+	b := m.Metrics.Battery
+	var s strings.Builder
+	s.WriteString("  " + ui.SectionHeader("Battery", w-4) + "\n")
+
+	state := "on battery"
+	switch {
+	case b.IsCharging:
+		state = "charging"
+	case b.OnAC:
+		state = "plugged in"
+	}
+	if b.TimeRemaining > 0 {
+		if b.IsCharging {
+			state += ", full in " + formatHoursMinutes(b.TimeRemaining)
+		} else {
+			state += ", " + formatHoursMinutes(b.TimeRemaining) + " left"
+		}
+	}
+	if b.Rate != 0 {
+		state += fmt.Sprintf("  %s", dimStyle.Render(fmt.Sprintf("%+.1f W", float64(b.Rate)/1000)))
+	}
+	s.WriteString(renderMetricRow("BAT", float64(b.Charge), barW, state))
+	if len(m.BatteryHistory) > 1 {
+		s.WriteString(fmt.Sprintf("  %s  %s\n", dimStyle.Render("       "),
+			renderSparkline(m.BatteryHistory, graphW, ui.ColorSuccess)))
+	}
+
+	if wear := b.WearPercent(); wear >= 0 {
+		healthStyle := textStyle
+		if wear >= 20 {
+			healthStyle = ui.WarningStyle()
+		}
+		if wear >= 40 {
+			healthStyle = ui.ErrorStyle()
+		}
+		s.WriteString(fmt.Sprintf("  %s  %s  %s\n",
+			dimStyle.Render("Health "),
+			healthStyle.Render(fmt.Sprintf("%.0f%% wear", wear)),
+			subtleStyle.Render(fmt.Sprintf("%.1f of %.1f Wh design capacity",
+				float64(b.FullChargeCapacity)/1000, float64(b.DesignCapacity)/1000))))
+	}
+	if b.CycleCount > 0 {
+		s.WriteString(fmt.Sprintf("  %s  %s\n", dimStyle.Render("Cycles "), subtleStyle.Render(fmt.Sprintf("%d", b.CycleCount))))
+	}
+	return s.String()
+}
+
+// formatHoursMinutes formats d as "2h 05m" or "45m".
+func formatHoursMinutes(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// renderSystemState renders the uptime, boot time, pending restart and
+// critical event count of the System section.
+func renderSystemState(st SystemState) string {
+	var parts []string
+	if !st.BootTime.IsZero() {
+		parts = append(parts,
+			dimStyle.Render("up ")+textStyle.Render(formatUptime(st.Uptime)),
+			dimStyle.Render("booted ")+subtleStyle.Render(st.BootTime.Format("Jan 2 15:04")))
+	}
+	if st.RebootPending {
+		parts = append(parts, ui.WarningStyle().Render(ui.IconWarning+" restart pending"))
+	}
+	switch n := st.CriticalEvents; {
+	case n > 0:
+		parts = append(parts, ui.ErrorStyle().Render(fmt.Sprintf("%s %d critical events in 24h", ui.IconError, n)))
+	case n == 0:
+		parts = append(parts, subtleStyle.Render("no critical events in 24h"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(parts, dimStyle.Render("  ·  "))
+}
+
+// formatUptime renders an uptime as days, hours and minutes.
+func formatUptime(d time.Duration) string {
+	if d < 24*time.Hour {
+		return formatHoursMinutes(d)
+	}
+	days := int(d / (24 * time.Hour))
+	return fmt.Sprintf("%dd %s", days, formatHoursMinutes(d%(24*time.Hour)))
+}
+
 // renderMetricRow renders a single metric: label + bar + percent + optional detail.
 func renderMetricRow(label string, pct float64, barW int, detail string) string {
 	bar := ui.GradientBar(pct, barW)
@@ -363,9 +514,97 @@ func (m StatusModel) renderDisk(w int) string {
 			rdLabel, dv.Render(core.FormatSize(int64(met.Disk.ReadBytes))),
 			wrLabel, dv.Render(core.FormatSize(int64(met.Disk.WriteBytes)))))
 
+	lines = append(lines, "")
+	lines = append(lines, renderDiskHealth(met.Disk.Health, w)...)
+	lines = append(lines, "")
+	lines = append(lines, m.renderDiskIO(w)...)
 	return strings.Join(lines, "\n")
 }
 
+// renderDiskHealth renders the S.M.A.R.T. status of each physical disk.
+func renderDiskHealth(disks []DiskHealth, w int) []string {
+	lines := []string{"  " + ui.SectionHeader("Drive Health", w-4)}
+	if len(disks) == 0 {
+		return append(lines, dimStyle.Italic(true).Render("  (no S.M.A.R.T. data — run as administrator; NVMe drives may not report it)"))
+	}
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("    %-32s %-8s %6s %12s %10s", "Drive", "Verdict", "Temp", "Reallocated", "Power on")))
+	for _, d := range disks {
+		name := d.Model
+		if r := []rune(name); len(r) > 32 {
+			name = string(r[:31]) + "…"
+		}
+		verdict := ui.TagAccentStyle().Render(" PASS ")
+		if d.PredictFailure {
+			verdict = ui.TagErrorStyle().Render(" FAIL ")
+		}
+		temp, realloc, hours := "—", "—", "—"
+		if d.HasAttributes {
+			if d.TemperatureC > 0 {
+				temp = fmt.Sprintf("%d°C", d.TemperatureC)
+			}
+			realloc = fmt.Sprintf("%d", d.Reallocated)
+			hours = fmt.Sprintf("%dh", d.PowerOnHours)
+		}
+		reallocStyle := textStyle
+		if d.Reallocated > 0 {
+			reallocStyle = ui.WarningStyle()
+		}
+		tempStyle := textStyle
+		if d.TemperatureC >= 55 {
+			tempStyle = ui.WarningStyle()
+		}
+		lines = append(lines, fmt.Sprintf("    %s %s  %s %s %s",
+			textStyle.Render(fmt.Sprintf("%-32s", name)),
+			verdict,
+			tempStyle.Render(fmt.Sprintf("%7s", temp)),
+			reallocStyle.Render(fmt.Sprintf("%12s", realloc)),
+			subtleStyle.Render(fmt.Sprintf("%10s", hours))))
+		if d.PredictFailure {
+			lines = append(lines, ui.ErrorStyle().Bold(true).Render(
+				fmt.Sprintf("    %s The drive predicts its own failure — back up its data and replace it", ui.IconError)))
+		}
+	}
+	return lines
+}
+
+// renderDiskIO renders the per-volume and per-process I/O of the Disk tab.
+func (m StatusModel) renderDiskIO(w int) []string {
+	var lines []string
+	lines = append(lines, "  "+ui.SectionHeader("Volume Activity", w-4))
+	if m.diskIO == nil || m.diskIO.Volumes == nil {
+		return append(lines, dimStyle.Italic(true).Render("  Measuring…"))
+	}
+
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("    %-6s %10s %10s %8s %8s %6s  %s", "Volume", "Read", "Write", "R IOPS", "W IOPS", "Queue", "Busy")))
+	for _, v := range m.diskIO.Volumes {
+		busy := textStyle
+		if v.BusyPct >= 90 || v.QueueDepth >= 4 {
+			busy = ui.WarningStyle()
+		}
+		lines = append(lines, fmt.Sprintf("    %s %10s %10s %8.0f %8.0f %6d  %s",
+			accentStyle.Bold(true).Render(fmt.Sprintf("%-6s", v.Volume)),
+			formatSpeed(v.ReadRate), formatSpeed(v.WriteRate),
+			v.ReadIOPS, v.WriteIOPS, v.QueueDepth,
+			busy.Render(fmt.Sprintf("%5.1f%%", v.BusyPct))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, "  "+ui.SectionHeader("Top Processes by I/O", w-4))
+	if len(m.diskIO.TopProcs) == 0 {
+		return append(lines, dimStyle.Italic(true).Render("  (no process I/O since the last sample)"))
+	}
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("    %-24s %7s %10s %10s %8s", "Process", "PID", "Read", "Write", "Ops/s")))
+	for _, p := range m.diskIO.TopProcs {
+		name := p.Name
+		if len(name) > 24 {
+			name = name[:23] + "…"
+		}
+		lines = append(lines, textStyle.Render(fmt.Sprintf("    %-24s %7d %10s %10s %8.0f",
+			name, p.PID, formatSpeed(p.ReadRate), formatSpeed(p.WriteRate), p.ReadOps+p.WriteOps)))
+	}
+	return lines
+}
+
 // ─── Network tab ─────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderNetwork(w int) string {
@@ -400,33 +639,123 @@ func (m StatusModel) renderNetwork(w int) string {
 			ulStyle.Render("  "+ui.IconArrow+" ")+renderSparklineU64(m.NetSendHistory, 30, ui.ColorAccent))
 	}
 
+	lines = append(lines, "")
+	lines = append(lines, m.renderConnections(w)...)
 	return strings.Join(lines, "\n")
 }
 
+// renderConnections renders the connection table of the Network tab.
+func (m StatusModel) renderConnections(w int) []string {
+	var lines []string
+	lines = append(lines, "  "+ui.SectionHeader("Connections", w-4))
+
+	switch {
+	case m.connErr != nil:
+		return append(lines, ui.ErrorStyle().Render("  "+ui.IconError+" "+m.connErr.Error()))
+	case m.conns == nil:
+		return append(lines, dimStyle.Italic(true).Render("  Reading connections…"))
+	}
+
+	conns := m.visibleConns()
+	summary := fmt.Sprintf("  %d of %d endpoints  %s  sorted by %s", len(conns), len(m.conns.Conns), ui.IconPipe, ConnSortNames[m.connSort])
+	if !m.conns.Throughput {
+		summary += "  " + ui.IconPipe + "  run as administrator for per-connection throughput"
+	}
+	lines = append(lines, dimStyle.Render(summary))
+
+	addrW := max((w-60)/2, 15)
+	header := fmt.Sprintf("    %-5s %-*s %-*s %-11s %6s  %-18s", "Proto", addrW, "Local", addrW, "Remote", "State", "PID", "Process")
+	if m.conns.Throughput {
+		header += fmt.Sprintf(" %10s %10s", "Recv", "Send")
+	}
+	lines = append(lines, dimStyle.Render(header))
+	lines = append(lines, "  "+ui.Divider(w-4))
+
+	end := min(m.connOffset+m.connViewHeight(), len(conns))
+	for _, c := range conns[min(m.connOffset, end):end] {
+		remote := "*"
+		if c.Remote.IsValid() {
+			remote = c.Remote.String()
+		}
+		row := fmt.Sprintf("    %-5s %-*s %-*s %-11s %6d  %-18s", c.Proto,
+			addrW, truncateLeft(c.Local.String(), addrW),
+			addrW, truncateLeft(remote, addrW),
+			c.State, c.PID, truncateLeft(c.Process, 18))
+		if m.conns.Throughput {
+			if c.HasStats {
+				row += fmt.Sprintf(" %10s %10s", formatSpeed(c.RecvRate), formatSpeed(c.SendRate))
+			} else {
+				row += fmt.Sprintf(" %10s %10s", "—", "—")
+			}
+		}
+		style := textStyle
+		if c.RecvRate+c.SendRate == 0 {
+			style = subtleStyle
+		}
+		lines = append(lines, style.Render(row))
+	}
+	if len(conns) == 0 {
+		lines = append(lines, dimStyle.Italic(true).Render("    (no matching connections)"))
+	}
+
+	lines = append(lines, "")
+	switch {
+	case m.connFiltering:
+		lines = append(lines, "  "+accentStyle.Render("Filter: ")+m.connInput.View())
+		lines = append(lines, dimStyle.Render("  Enter keep  "+ui.IconPipe+"  Esc clear"))
+	case m.connFilter != "":
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  Filter: %s  %s  / edit  %s  Esc clear  %s  s sort  %s  ↑/↓ scroll", m.connFilter, ui.IconPipe, ui.IconPipe, ui.IconPipe, ui.IconPipe)))
+	default:
+		lines = append(lines, dimStyle.Render("  ↑/↓ scroll  "+ui.IconPipe+"  s sort  "+ui.IconPipe+"  / filter"))
+	}
+	return lines
+}
+
+// truncateLeft shortens s to n columns, keeping its end, which holds the
+// port of an address.
+func truncateLeft(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return "…" + string(r[len(r)-n+1:])
+}
+
 // ─── Processes tab ───────────────────────────────────────────────────────────
 
 func (m StatusModel) renderProcesses(w int) string {
+	if m.detailPID != 0 {
+		return m.renderProcessDetail(w)
+	}
 	met := m.Metrics
 	barW := 24
 	if w > 100 {
 		barW = 32
 	}
 
+	procs := m.visibleProcs()
+
 	var lines []string
 	lines = append(lines, "")
-	lines = append(lines, "  "+ui.SectionHeader("Top Processes", w-4))
-	lines = append(lines, "")
+	lines = append(lines, "  "+ui.SectionHeader("Processes", w-4))
+	summary := fmt.Sprintf("  top %d of %d by %s", len(procs), len(met.Procs), ProcSortNames[m.procSort])
+	if m.procFilter != "" {
+		summary += fmt.Sprintf("  %s  name contains %q", ui.IconPipe, m.procFilter)
+	}
+	lines = append(lines, dimStyle.Render(summary))
 
 	nameW := 22
 	if w > 100 {
 		nameW = 30
 	}
 
-	header := fmt.Sprintf("  %-6s %-*s %s  %6s  %6s", "PID", nameW, "Name", strings.Repeat(" ", barW), "CPU%", "Mem%")
+	header := fmt.Sprintf("    %-6s %-*s %s  %6s  %6s  %10s", "PID", nameW, "Name", strings.Repeat(" ", barW), "CPU%", "Mem%", "Disk I/O")
 	lines = append(lines, dimStyle.Render(header))
 	lines = append(lines, "  "+ui.Divider(w-4))
 
-	for _, p := range met.TopProcs {
+	end := min(m.procOffset+m.procViewHeight(), len(procs))
+	for i := min(m.procOffset, end); i < end; i++ {
+		p := procs[i]
 		name := p.Name
 		if len(name) > nameW {
 			name = name[:nameW-1] + "…"
@@ -436,28 +765,326 @@ func (m StatusModel) renderProcesses(w int) string {
 			cpuClamp = 100
 		}
 		bar := ui.GradientBar(cpuClamp, barW)
+		marker := "  "
+		if i == m.procCursor {
+			marker = accentStyle.Render(ui.IconArrow + " ")
+		}
 		lines = append(lines,
-			fmt.Sprintf("  %s %s %s  %s  %s",
+			fmt.Sprintf("  %s%s %s %s  %s  %s  %s",
+				marker,
 				subtleStyle.Render(fmt.Sprintf("%-6d", p.PID)),
 				textStyle.Render(fmt.Sprintf("%-*s", nameW, name)),
 				bar,
 				textStyle.Render(fmt.Sprintf("%5.1f%%", p.CPUPct)),
-				subtleStyle.Render(fmt.Sprintf("%5.1f%%", p.MemPct))))
+				subtleStyle.Render(fmt.Sprintf("%5.1f%%", p.MemPct)),
+				subtleStyle.Render(fmt.Sprintf("%10s", formatSpeed(m.procIORate[p.PID])))))
 	}
 
-	if len(met.TopProcs) == 0 {
-		lines = append(lines,
-			dimStyle.Italic(true).Render("  (no process data yet)"))
+	if len(procs) == 0 {
+		if m.procFilter != "" {
+			lines = append(lines, dimStyle.Italic(true).Render("  (no matching processes)"))
+		} else {
+			lines = append(lines, dimStyle.Italic(true).Render("  (no process data yet)"))
+		}
+	}
+
+	if m.procFiltering {
+		lines = append(lines, "")
+		lines = append(lines, "  "+accentStyle.Render("Filter: ")+m.procInput.View())
+		lines = append(lines, dimStyle.Render("  Enter keep  "+ui.IconPipe+"  Esc clear"))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, "")
+	if m.action != actionNone {
+		lines = append(lines, "")
+		lines = append(lines, m.renderActionPrompt()...)
+	} else {
+		lines = append(lines, dimStyle.Render("  ↑/↓ select  "+ui.IconPipe+"  Enter details  "+ui.IconPipe+"  K end  "+ui.IconPipe+"  P priority  "+ui.IconPipe+"  A affinity"))
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  c/m/d/n sort  %s  / filter  %s  r %d rows", ui.IconPipe, ui.IconPipe, m.procRows())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderProcessDetail renders the detail pane of the selected process.
+func (m StatusModel) renderProcessDetail(w int) string {
+	var lines []string
+	lines = append(lines, "")
+	d := m.detail
+	switch {
+	case m.detailErr != nil:
+		lines = append(lines, "  "+ui.SectionHeader(fmt.Sprintf("Process %d", m.detailPID), w-4), "")
+		lines = append(lines, ui.WarningStyle().Render("  "+m.detailErr.Error()))
+		lines = append(lines, "", dimStyle.Render("  Esc back"))
+		return strings.Join(lines, "\n")
+	case d == nil:
+		lines = append(lines, "  "+ui.SectionHeader(fmt.Sprintf("Process %d", m.detailPID), w-4), "")
+		lines = append(lines, dimStyle.Italic(true).Render("  Reading process details..."))
+		return strings.Join(lines, "\n")
+	}
+
+	lines = append(lines, "  "+ui.SectionHeader(fmt.Sprintf("%s  (PID %d)", d.Name, d.PID), w-4), "")
+
+	orDash := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	row := func(label, value string) string {
+		return fmt.Sprintf("  %s %s", dimStyle.Render(fmt.Sprintf("%-14s", label)), textStyle.Render(value))
+	}
+	started := "-"
+	if !d.StartTime.IsZero() {
+		started = fmt.Sprintf("%s  (%s ago)", d.StartTime.Format("Jan 02 15:04:05"),
+			time.Since(d.StartTime).Round(time.Second))
+	}
+	parent := "-"
+	if d.ParentPID != 0 {
+		parent = fmt.Sprintf("%s (PID %d)", orDash(d.ParentName), d.ParentPID)
+	}
+	cmdW := w - 20
+	if cmdW < 20 {
+		cmdW = 20
+	}
+	cmdline := orDash(d.CmdLine)
+	if len(cmdline) > cmdW {
+		cmdline = cmdline[:cmdW-1] + "…"
+	}
+
+	lines = append(lines,
+		row("Path", orDash(d.Exe)),
+		row("Command line", cmdline),
+		row("User", orDash(d.User)),
+		row("Parent", parent),
+		row("Started", started),
+		row("Priority", orDash(d.Priority)),
+		row("CPU", fmt.Sprintf("%.1f%%", d.CPUPct)),
+		row("Threads", fmt.Sprintf("%d", d.Threads)),
+		row("Handles", fmt.Sprintf("%d", d.Handles)),
+		"",
+		"  "+ui.SectionHeader("Memory", w-4),
+		row("Working set", fmt.Sprintf("%s  (peak %s)", core.FormatSize(int64(d.Memory.WorkingSet)), core.FormatSize(int64(d.Memory.PeakWorkingSet)))),
+		row("Private", core.FormatSize(int64(d.Memory.Private))),
+		row("Paged pool", core.FormatSize(int64(d.Memory.PagedPool))),
+		row("Nonpaged pool", core.FormatSize(int64(d.Memory.NonPagedPool))),
+		row("Page faults", fmt.Sprintf("%d", d.Memory.PageFaults)),
+		"",
+		"  "+ui.SectionHeader("Disk I/O", w-4),
+		row("Read", fmt.Sprintf("%s  (%s total)", formatSpeed(d.ReadRate), core.FormatSize(int64(d.ReadBytes)))),
+		row("Write", fmt.Sprintf("%s  (%s total)", formatSpeed(d.WriteRate), core.FormatSize(int64(d.WriteBytes)))),
+		"")
+
+	if m.action != actionNone {
+		lines = append(lines, m.renderActionPrompt()...)
+	} else {
+		lines = append(lines, dimStyle.Render("  K end process  "+ui.IconPipe+"  P priority  "+ui.IconPipe+"  A affinity  "+ui.IconPipe+"  o open file location  "+ui.IconPipe+"  Esc back"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderActionPrompt renders the confirmation, priority list or CPU picker
+// of the action being answered.
+func (m StatusModel) renderActionPrompt() []string {
+	var lines []string
+	switch m.action {
+	case actionKill:
+		lines = append(lines, ui.ErrorStyle().Bold(true).Render(
+			fmt.Sprintf("  %s End %s (PID %d)? Unsaved work in it is lost. Enter to confirm, any other key to cancel", ui.IconWarning, m.actionName, m.actionPID)))
+	case actionPriority:
+		lines = append(lines, "  "+ui.BoldStyle().Render(fmt.Sprintf("Priority of %s (PID %d)", m.actionName, m.actionPID)))
+		for i, name := range optimize.PriorityNames {
+			marker := "    "
+			if i == m.priorityCursor {
+				marker = "  " + accentStyle.Render(ui.IconArrow+" ")
+			}
+			lines = append(lines, marker+textStyle.Render(name))
+		}
+		lines = append(lines, dimStyle.Render("  ↑/↓ choose  "+ui.IconPipe+"  Enter apply  "+ui.IconPipe+"  Esc cancel"))
+	case actionAffinity:
+		lines = append(lines, "  "+ui.BoldStyle().Render(fmt.Sprintf("CPUs %s (PID %d) may run on", m.actionName, m.actionPID)))
+		var row strings.Builder
+		row.WriteString("  ")
+		for i := 0; i < bits.Len64(m.affinitySystem); i++ {
+			bit := uint64(1) << uint(i)
+			box := "[ ]"
+			if m.affinityMask&bit != 0 {
+				box = "[x]"
+			}
+			cell := fmt.Sprintf("%s%-2d ", box, i)
+			switch {
+			case i == m.affinityCursor:
+				row.WriteString(accentStyle.Bold(true).Render(cell))
+			case m.affinitySystem&bit == 0:
+				row.WriteString(subtleStyle.Render(cell))
+			default:
+				row.WriteString(textStyle.Render(cell))
+			}
+			if i%16 == 15 {
+				row.WriteString("\n  ")
+			}
+		}
+		lines = append(lines, strings.TrimRight(row.String(), " \n"))
+		if m.affinityMask&m.affinitySystem == 0 {
+			lines = append(lines, ui.WarningStyle().Render("  "+ui.IconWarning+" Select at least one CPU"))
+		}
+		lines = append(lines, dimStyle.Render("  ←/→ move  "+ui.IconPipe+"  Space toggle  "+ui.IconPipe+"  a all  "+ui.IconPipe+"  Enter apply  "+ui.IconPipe+"  Esc cancel"))
+	}
+	return lines
+}
+
+// ─── Alerts tab ──────────────────────────────────────────────────────────────
+
+func (m StatusModel) renderAlerts(w int) string {
+	var lines []string
+	lines = append(lines, "")
+	lines = append(lines, "  "+ui.SectionHeader("Alert History", w-4))
+	lines = append(lines, "")
+
+	if m.Alerts == nil {
+		lines = append(lines, dimStyle.Italic(true).Render("  (alert history unavailable)"))
+		return strings.Join(lines, "\n")
 	}
 
+	alerts := m.Alerts.Alerts()
+	if len(alerts) == 0 {
+		lines = append(lines, dimStyle.Italic(true).Render("  (no alerts recorded)"))
+		return strings.Join(lines, "\n")
+	}
+
+	header := fmt.Sprintf("    %-14s %-14s %-10s %7s  %s", "Started", "Metric", "Duration", "Peak", "Detail")
+	lines = append(lines, dimStyle.Render(header))
+	lines = append(lines, "  "+ui.Divider(w-4))
+
+	// Keep the cursor row visible when the history is longer than the pane.
+	maxRows := m.Height - 10
+	if maxRows < 5 {
+		maxRows = 5
+	}
+	start := 0
+	if m.alertCursor >= maxRows {
+		start = m.alertCursor - maxRows + 1
+	}
+
+	for i := start; i < len(alerts) && i < start+maxRows; i++ {
+		a := alerts[i]
+
+		marker := "  "
+		if i == m.alertCursor {
+			marker = accentStyle.Render(ui.IconArrow + " ")
+		}
+
+		duration := "ongoing"
+		if !a.Active() {
+			duration = a.EndedAt.Sub(a.StartedAt).Round(time.Second).String()
+		}
+
+		rowStyle := textStyle
+		state := ui.WarningStyle().Render(ui.IconWarning)
+		if a.Acknowledged {
+			rowStyle = subtleStyle
+			state = subtleStyle.Render(ui.IconSuccess)
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s%s %s",
+			marker,
+			rowStyle.Render(fmt.Sprintf("%-14s %-14s %-10s %6.1f%%",
+				a.StartedAt.Local().Format("Jan 02 15:04"), a.Label, duration, a.Peak)),
+			state+" "+subtleStyle.Render(a.Detail)))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, dimStyle.Render("  ↑/↓ select  "+ui.IconPipe+"  a acknowledge  "+ui.IconPipe+"  A acknowledge all"))
+	return strings.Join(lines, "\n")
+}
+
+// ─── Services tab ────────────────────────────────────────────────────────────
+
+func (m StatusModel) renderServices(w int) string {
+	var lines []string
+	lines = append(lines, "")
+	lines = append(lines, "  "+ui.SectionHeader("Watched Services", w-4))
+	lines = append(lines, "")
+
+	if m.services == nil {
+		lines = append(lines, dimStyle.Italic(true).Render("  Reading services..."))
+		return strings.Join(lines, "\n")
+	}
+	if len(m.services) == 0 {
+		lines = append(lines, dimStyle.Italic(true).Render("  (no services watched — list them in "+WatchedServicesFileName+")"))
+		return strings.Join(lines, "\n")
+	}
+
+	nameW := max(min(w-50, 40), 16)
+	header := fmt.Sprintf("    %-*s %-10s %-20s %s", nameW, "Service", "State", "Start type", "Name")
+	lines = append(lines, dimStyle.Render(header))
+	lines = append(lines, "  "+ui.Divider(w-4))
+
+	flagged := 0
+	for i, svc := range m.services {
+		marker := "  "
+		if i == m.serviceCursor {
+			marker = accentStyle.Render(ui.IconArrow + " ")
+		}
+		display := svc.DisplayName
+		if r := []rune(display); len(r) > nameW {
+			display = string(r[:nameW-1]) + "…"
+		}
+		if svc.Err != "" {
+			lines = append(lines, fmt.Sprintf("  %s%s %s",
+				marker,
+				subtleStyle.Render(fmt.Sprintf("%-*s", nameW, display)),
+				ui.ErrorStyle().Render(svc.Err)))
+			continue
+		}
+
+		state := textStyle.Render(fmt.Sprintf("%-10s", svc.State))
+		switch {
+		case svc.Flagged:
+			flagged++
+			state = ui.ErrorStyle().Render(fmt.Sprintf("%-10s", svc.State))
+		case svc.State != "Running":
+			state = subtleStyle.Render(fmt.Sprintf("%-10s", svc.State))
+		}
+		line := fmt.Sprintf("  %s%s %s %s %s",
+			marker,
+			textStyle.Render(fmt.Sprintf("%-*s", nameW, display)),
+			state,
+			subtleStyle.Render(fmt.Sprintf("%-20s", svc.StartType)),
+			dimStyle.Render(svc.Name))
+		if svc.Flagged {
+			line += "  " + ui.ErrorStyle().Render(ui.IconWarning+" should be running")
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "")
+	if flagged > 0 {
+		lines = append(lines, ui.WarningStyle().Render(fmt.Sprintf("  %s %d automatic service(s) not running", ui.IconWarning, flagged)))
+	}
+	if m.serviceConfirm != "" {
+		lines = append(lines, ui.WarningStyle().Bold(true).Render(
+			fmt.Sprintf("  %s Restart %s and the services depending on it? Enter to confirm, any other key to cancel", ui.IconWarning, m.serviceConfirm)))
+	} else {
+		lines = append(lines, dimStyle.Render("  ↑/↓ select  "+ui.IconPipe+"  R restart (administrator)"))
+	}
 	return strings.Join(lines, "\n")
 }
 
 // ─── Footer ──────────────────────────────────────────────────────────────────
 
 func (m StatusModel) renderStatusFooter() string {
-	hints := "  Tab/Shift-Tab switch  " + ui.IconPipe + "  1-6 jump  " + ui.IconPipe + "  q quit"
+	hints := "  Tab/Shift-Tab switch  " + ui.IconPipe + "  1-8 jump  " + ui.IconPipe +
+		"  +/- every " + m.refreshInterval.String() + "  " + ui.IconPipe + "  p pause  " + ui.IconPipe + "  q quit"
 	footer := ui.HintBarStyle().Render(hints)
+	if m.paused {
+		footer = ui.TagWarningStyle().Render(" PAUSED ") + footer
+	} else if m.blurred && m.interval() != m.refreshInterval {
+		footer = dimStyle.Render("  unfocused, every "+m.interval().String()) + "\n" + footer
+	}
+	if m.notice != "" {
+		footer = lipgloss.NewStyle().Foreground(ui.ColorInfo).Render("  "+m.notice) + "\n" + footer
+	}
 
 	if m.Err != nil {
 		errStr := lipgloss.NewStyle().
@@ -0,0 +1,281 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/analyze"
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Analyze Pane ────────────────────────────────────────────────────────────
+// Wraps analyze.AnalyzeModel with a path prompt and an in-pane scan, and
+// adds the "c" handoff that opens the highlighted directory in the clean
+// pane and the "J" handoff that sends the junk already found under the
+// current directory.
+
+// scanProgressInterval is how often the scan counter redraws.
+const scanProgressInterval = 100 * time.Millisecond
+
+type analyzeScanDoneMsg struct {
+	root *analyze.DirEntry
+	err  error
+}
+
+type analyzeScanTickMsg struct{}
+
+// cleanPathMsg asks the host to switch to the clean pane and scan path.
+type cleanPathMsg struct {
+	path string
+}
+
+// cleanItemsMsg asks the host to switch to the clean pane with items
+// already classified, skipping the rescan.
+type cleanItemsMsg struct {
+	label string
+	items []clean.CleanItem
+}
+
+type analyzePane struct {
+	input     textinput.Model
+	prompting bool
+
+	scanner  *analyze.Scanner
+	scanPath string
+	frame    int
+
+	model  *analyze.AnalyzeModel
+	wl     *whitelist.Whitelist
+	dryRun bool
+	err    error
+
+	width  int
+	height int
+}
+
+func newAnalyzePane(startPath string, wl *whitelist.Whitelist, dryRun bool) analyzePane {
+	ti := textinput.New()
+	ti.Placeholder = `C:\Users`
+	ti.Prompt = ""
+	ti.CharLimit = 512
+
+	p := analyzePane{input: ti, width: 80, height: 20, scanPath: startPath, wl: wl, dryRun: dryRun}
+	if startPath == "" {
+		p.startPrompt()
+	}
+	return p
+}
+
+// init kicks off the startup scan, if a path was given.
+func (p *analyzePane) init() tea.Cmd {
+	if p.scanPath == "" {
+		return textinput.Blink
+	}
+	return p.startScan(p.scanPath)
+}
+
+func (p *analyzePane) startPrompt() {
+	p.prompting = true
+	if cwd, err := os.Getwd(); err == nil && p.input.Value() == "" {
+		p.input.SetValue(cwd)
+		p.input.CursorEnd()
+	}
+	p.input.Focus()
+}
+
+// startScan scans path in the background, preferring a valid cache.
+func (p *analyzePane) startScan(path string) tea.Cmd {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		p.err = err
+		return nil
+	}
+	p.scanPath = abs
+	p.err = nil
+	p.scanner = analyze.NewScanner(8, nil)
+	p.scanner.ExpectScanSize(analyze.ExpectedScanSize(abs))
+	scanner := p.scanner
+
+	scan := func() tea.Msg {
+		if root, cacheErr := analyze.LoadCache(abs); cacheErr == nil {
+			return analyzeScanDoneMsg{root: root}
+		}
+		if root, cacheErr := analyze.LoadStaleCache(abs); cacheErr == nil {
+			if _, refreshErr := scanner.Refresh(root); refreshErr == nil {
+				_ = analyze.SaveCache(root, abs)
+				return analyzeScanDoneMsg{root: root}
+			}
+		}
+		root, scanErr := scanner.Scan(abs)
+		if scanErr == nil {
+			_ = analyze.SaveCache(root, abs)
+		}
+		return analyzeScanDoneMsg{root: root, err: scanErr}
+	}
+	return tea.Batch(scan, analyzeScanTick())
+}
+
+func analyzeScanTick() tea.Cmd {
+	return tea.Tick(scanProgressInterval, func(time.Time) tea.Msg {
+		return analyzeScanTickMsg{}
+	})
+}
+
+func (p *analyzePane) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+		return p.forward(p.modelSize())
+
+	case analyzeScanTickMsg:
+		if p.scanner == nil {
+			return nil
+		}
+		p.frame = (p.frame + 1) % len(ui.SpinnerFrames)
+		return analyzeScanTick()
+
+	case analyzeScanDoneMsg:
+		p.scanner = nil
+		if msg.err != nil {
+			p.err = msg.err
+			p.startPrompt()
+			return nil
+		}
+		model := analyze.NewAnalyzeModel(msg.root)
+		model.DryRun = p.dryRun
+		if p.wl != nil {
+			model.IsWhitelisted = p.wl.IsWhitelisted
+		}
+		p.model = &model
+		return tea.Batch(model.Init(), p.forward(p.modelSize()))
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+
+	return p.forward(msg)
+}
+
+func (p *analyzePane) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if p.prompting {
+		switch msg.String() {
+		case "enter":
+			path := strings.TrimSpace(p.input.Value())
+			if path == "" {
+				return nil
+			}
+			p.prompting = false
+			p.input.Blur()
+			return p.startScan(path)
+		case "esc":
+			if p.model != nil {
+				p.prompting = false
+				p.input.Blur()
+			}
+			return nil
+		}
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		return cmd
+	}
+
+	if p.scanner != nil || p.model == nil {
+		return nil
+	}
+
+	// The analyzer's own prompts take every key.
+	if p.model.CapturingInput() {
+		return p.forward(msg)
+	}
+
+	switch msg.String() {
+	case "esc":
+		// The standalone analyzer quits on esc; the host owns quitting.
+		// Esc still clears a search.
+		if p.model.SearchActive() {
+			return p.forward(msg)
+		}
+		return nil
+	case "o":
+		p.startPrompt()
+		return textinput.Blink
+	case "c":
+		target := p.model.Current()
+		if sel := p.model.Selected(); sel != nil && sel.IsDir {
+			target = sel
+		}
+		if target == nil {
+			return nil
+		}
+		path := target.Path
+		return func() tea.Msg { return cleanPathMsg{path: path} }
+	case "J":
+		// The standalone analyzer quits to run its own selector; here the
+		// junk goes to the clean pane instead.
+		current := p.model.Current()
+		items := analyze.JunkItems(current)
+		if len(items) == 0 {
+			return nil
+		}
+		label := "Junk in " + current.Path
+		return func() tea.Msg { return cleanItemsMsg{label: label, items: items} }
+	}
+	return p.forward(msg)
+}
+
+// modelSize is the area left for the analyzer below the pane's hint line.
+func (p *analyzePane) modelSize() tea.WindowSizeMsg {
+	return tea.WindowSizeMsg{Width: p.width, Height: p.height - 1}
+}
+
+// forward passes msg to the wrapped analyzer, if one is loaded.
+func (p *analyzePane) forward(msg tea.Msg) tea.Cmd {
+	if p.model == nil {
+		return nil
+	}
+	updated, cmd := p.model.Update(msg)
+	model := updated.(analyze.AnalyzeModel)
+	p.model = &model
+	return cmd
+}
+
+func (p analyzePane) view() string {
+	var s strings.Builder
+
+	if p.prompting {
+		s.WriteString("\n")
+		s.WriteString("  " + ui.SectionHeader("Analyze Disk Usage", p.width-4) + "\n\n")
+		s.WriteString("  " + ui.BoldStyle().Render("Path to scan: ") + p.input.View() + "\n\n")
+		if p.err != nil {
+			s.WriteString(ui.ErrorStyle().Render(fmt.Sprintf("  %s %v", ui.IconError, p.err)) + "\n\n")
+		}
+		s.WriteString(ui.HintBarStyle().Render("  Enter scan  " + ui.IconPipe + "  Esc cancel"))
+		return s.String()
+	}
+
+	if p.scanner != nil {
+		s.WriteString("\n")
+		s.WriteString(fmt.Sprintf("  %s Scanning %s … %s\n",
+			ui.SpinnerFrames[p.frame], p.scanPath, analyze.FormatScanProgress(p.scanner.Progress())))
+		return s.String()
+	}
+
+	if p.model == nil {
+		return "\n" + ui.MutedStyle().Render("  No scan loaded. Press o to choose a path.")
+	}
+
+	s.WriteString(p.model.View())
+	s.WriteString("\n")
+	s.WriteString(ui.HintBarStyle().Render("  o open path  " + ui.IconPipe + "  c clean selected folder  " + ui.IconPipe + "  J clean junk here"))
+	return s.String()
+}
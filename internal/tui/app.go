@@ -0,0 +1,256 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/status"
+	"github.com/cy-infamous/purewin/internal/uninstall"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Unified TUI ─────────────────────────────────────────────────────────────
+// AppModel hosts status, analyze, clean and uninstall as switchable panes in
+// a single bubbletea program. Every pane keeps running while hidden (status
+// keeps sampling, scans keep going), and panes can hand work to each other,
+// e.g. cleaning the directory highlighted in analyze.
+
+// Pane identifies one of the hosted panes.
+type Pane int
+
+const (
+	PaneStatus Pane = iota
+	PaneAnalyze
+	PaneClean
+	PaneUninstall
+)
+
+// PaneNames is the display label for each pane.
+var PaneNames = []string{"Status", "Analyze", "Clean", "Uninstall"}
+
+// chromeHeight is the number of lines the app's pane bar and footer use.
+const chromeHeight = 4
+
+// Options configures the shared state handed to every pane.
+type Options struct {
+	// RefreshInterval is the status sampling cadence.
+	RefreshInterval time.Duration
+
+	// Whitelist protects paths from clean and analyze deletions.
+	Whitelist *whitelist.Whitelist
+
+	// Protect guards applications from the uninstall pane.
+	Protect *uninstall.ProtectionList
+
+	// Alerts persists status threshold alerts; nil disables alerting.
+	Alerts *status.AlertTracker
+
+	// WatchedServices are listed on the status Services tab; nil keeps the
+	// default watchlist.
+	WatchedServices []string
+
+	// OnCollect runs after every status sample (see StatusModel.OnCollect).
+	OnCollect func()
+
+	// AnalyzePath is scanned on startup when set.
+	AnalyzePath string
+
+	// IsAdmin enables admin-only clean targets.
+	IsAdmin bool
+
+	// DryRun makes every pane report what it would delete or uninstall
+	// instead of doing it.
+	DryRun bool
+
+	// MaxRisk is the highest clean target risk the clean pane lists, as
+	// with `pw clean --max-risk`; "" means high (no limit).
+	MaxRisk string
+
+	// MinAge limits the clean pane to files not modified within it, as
+	// with `pw clean --older-than`.
+	MinAge time.Duration
+
+	// FreeCloud frees the local copy of items in cloud sync folders
+	// instead of deleting them, as with `pw clean --free-cloud`.
+	FreeCloud bool
+
+	// NoRestorePoint skips the restore point the clean pane creates before
+	// deleting high-risk targets.
+	NoRestorePoint bool
+}
+
+// AppModel is the bubbletea Model for `pw tui`.
+type AppModel struct {
+	pane      Pane
+	status    status.StatusModel
+	analyze   analyzePane
+	clean     cleanPane
+	uninstall uninstallPane
+
+	width    int
+	height   int
+	dryRun   bool
+	quitting bool
+}
+
+// NewAppModel creates the unified TUI with the given shared state.
+func NewAppModel(opts Options) AppModel {
+	st := status.NewStatusModel(opts.RefreshInterval)
+	st.Alerts = opts.Alerts
+	if opts.WatchedServices != nil {
+		st.WatchedServices = opts.WatchedServices
+	}
+	st.OnCollect = opts.OnCollect
+
+	m := AppModel{
+		status:    st,
+		analyze:   newAnalyzePane(opts.AnalyzePath, opts.Whitelist, opts.DryRun),
+		clean:     newCleanPane(opts),
+		uninstall: newUninstallPane(opts.Protect, opts.DryRun),
+		dryRun:    opts.DryRun,
+		width:     80,
+		height:    24,
+	}
+	if opts.AnalyzePath != "" {
+		m.pane = PaneAnalyze
+	}
+	return m
+}
+
+// Init starts status sampling and any startup scan.
+func (m AppModel) Init() tea.Cmd {
+	return tea.Batch(m.status.Init(), m.analyze.init())
+}
+
+// Update routes keys to the active pane and every other message to all
+// panes, since background work (metric ticks, scans) must keep flowing
+// regardless of which pane is visible.
+func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		inner := tea.WindowSizeMsg{Width: msg.Width, Height: msg.Height - chromeHeight}
+		return m.broadcast(inner)
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case cleanPathMsg:
+		// Cross-pane handoff: analyze asked clean to scan a directory.
+		m.pane = PaneClean
+		cmd := m.clean.scanPath(msg.path)
+		return m, cmd
+
+	case cleanItemsMsg:
+		m.pane = PaneClean
+		return m, m.clean.loadItems(msg.label, msg.items)
+	}
+
+	return m.broadcast(msg)
+}
+
+// handleKey processes global keys, then forwards to the active pane.
+func (m AppModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	if key == "ctrl+c" {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	switch key {
+	case "f1", "alt+1":
+		m.pane = PaneStatus
+		return m, nil
+	case "f2", "alt+2":
+		m.pane = PaneAnalyze
+		return m, nil
+	case "f3", "alt+3":
+		m.pane = PaneClean
+		return m, nil
+	case "f4", "alt+4":
+		m.pane = PaneUninstall
+		return m, m.uninstall.activate()
+	case "ctrl+right":
+		m.pane = (m.pane + 1) % Pane(len(PaneNames))
+		return m, m.activated()
+	case "ctrl+left":
+		m.pane = (m.pane + Pane(len(PaneNames)) - 1) % Pane(len(PaneNames))
+		return m, m.activated()
+	}
+
+	// "q" quits unless the active pane is capturing text.
+	if key == "q" && !m.capturingInput() {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	var cmd tea.Cmd
+	switch m.pane {
+	case PaneStatus:
+		// The standalone dashboard quits on esc; the host owns quitting.
+		// Esc still closes the process detail pane.
+		if key == "esc" && !m.status.HasOverlay() {
+			return m, nil
+		}
+		var updated tea.Model
+		updated, cmd = m.status.Update(msg)
+		m.status = updated.(status.StatusModel)
+	case PaneAnalyze:
+		cmd = m.analyze.update(msg)
+	case PaneClean:
+		cmd = m.clean.update(msg)
+	case PaneUninstall:
+		cmd = m.uninstall.update(msg)
+	}
+	return m, cmd
+}
+
+// activated runs any lazy initialization for the newly active pane.
+func (m *AppModel) activated() tea.Cmd {
+	if m.pane == PaneUninstall {
+		return m.uninstall.activate()
+	}
+	return nil
+}
+
+// capturingInput reports whether the active pane is editing text, in
+// which case printable keys must not trigger global shortcuts.
+func (m AppModel) capturingInput() bool {
+	switch m.pane {
+	case PaneStatus:
+		return m.status.CapturingInput()
+	case PaneAnalyze:
+		return m.analyze.prompting || (m.analyze.model != nil && m.analyze.model.CapturingInput())
+	case PaneUninstall:
+		return m.uninstall.filtering
+	}
+	return false
+}
+
+// broadcast delivers a non-key message to every pane.
+func (m AppModel) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	updated, cmd := m.status.Update(msg)
+	m.status = updated.(status.StatusModel)
+	cmds = append(cmds, cmd)
+
+	cmds = append(cmds,
+		m.analyze.update(msg),
+		m.clean.update(msg),
+		m.uninstall.update(msg),
+	)
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the pane bar, the active pane and the global footer.
+func (m AppModel) View() string {
+	if m.quitting {
+		return ""
+	}
+	return m.renderView()
+}
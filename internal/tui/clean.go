@@ -0,0 +1,492 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/clean"
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/pkg/whitelist"
+)
+
+// ─── Clean Pane ──────────────────────────────────────────────────────────────
+// Scans cache categories (or a directory handed over from analyze), lists
+// the results per target with checkboxes, and deletes the checked groups
+// with the same gates as `pw clean`: the risk ceiling and age limit, a
+// second confirmation for privacy-sensitive targets, a restore point before
+// high-risk targets, and clean.RemoveItem for cloud sync folders. Only
+// low-risk groups start checked.
+
+type cleanScanDoneMsg struct {
+	label   string
+	results []clean.ScanResult
+}
+
+type cleanDoneMsg struct {
+	freed      int64
+	cleaned    int
+	failed     int
+	dryRun     bool
+	restoreErr error // set when the restore point failed and nothing ran
+}
+
+// Confirmation steps before a clean starts.
+const (
+	confirmNone    = iota
+	confirmDelete  // "Delete N bytes?"
+	confirmPrivacy // privacy notes of the checked targets
+)
+
+type cleanPane struct {
+	wl             *whitelist.Whitelist
+	isAdmin        bool
+	dryRun         bool
+	maxRisk        string
+	minAge         time.Duration
+	freeCloud      bool
+	noRestorePoint bool
+
+	label    string // what was scanned, e.g. "User caches" or a path
+	results  []clean.ScanResult
+	selected map[int]bool
+	cursor   int
+	offset   int
+	held     []clean.ScanResult // groups above maxRisk, not listed
+
+	scanning   bool
+	cleaning   bool
+	confirming int
+	summary    string
+
+	width  int
+	height int
+}
+
+func newCleanPane(opts Options) cleanPane {
+	maxRisk := opts.MaxRisk
+	if maxRisk == "" {
+		maxRisk = config.RiskHigh
+	}
+	return cleanPane{
+		wl:             opts.Whitelist,
+		isAdmin:        opts.IsAdmin,
+		dryRun:         opts.DryRun,
+		maxRisk:        maxRisk,
+		minAge:         opts.MinAge,
+		freeCloud:      opts.FreeCloud,
+		noRestorePoint: opts.NoRestorePoint,
+		selected:       make(map[int]bool),
+		width:          80,
+		height:         20,
+	}
+}
+
+// scanCategory scans one of the standard clean categories in the background.
+func (p *cleanPane) scanCategory(category string) tea.Cmd {
+	if category == "system" && !p.isAdmin {
+		p.summary = ui.WarningStyle().Render("  " + ui.IconWarning + " System caches require administrator privileges.")
+		return nil
+	}
+	p.beginScan(strings.ToUpper(category[:1]) + category[1:] + " caches")
+
+	wl, isAdmin := p.wl, p.isAdmin
+	label := p.label
+	return func() tea.Msg {
+		var results []clean.ScanResult
+		switch category {
+		case "browser":
+			results = groupItems(clean.ScanBrowserCaches(wl))
+		case "dev":
+			results = groupItems(clean.ScanDevCaches(wl))
+		default:
+			results = clean.ScanAll(config.GetTargetsByCategory(category), wl, isAdmin)
+		}
+		return cleanScanDoneMsg{label: label, results: results}
+	}
+}
+
+// scanPath scans a directory for junk, as `pw clean <path>` does.
+func (p *cleanPane) scanPath(path string) tea.Cmd {
+	p.beginScan(path)
+
+	wl := p.wl
+	return func() tea.Msg {
+		var results []clean.ScanResult
+		for _, r := range clean.ScanPath(path, wl, 0) {
+			results = append(results, clean.ItemsToResult(r.Label, r.Items))
+		}
+		return cleanScanDoneMsg{label: path, results: results}
+	}
+}
+
+// loadItems shows already-classified items (junk picked in analyze)
+// grouped by category, without scanning again.
+func (p *cleanPane) loadItems(label string, items []clean.CleanItem) tea.Cmd {
+	p.beginScan(label)
+	return func() tea.Msg {
+		return cleanScanDoneMsg{label: label, results: groupItems(items)}
+	}
+}
+
+func (p *cleanPane) beginScan(label string) {
+	p.label = label
+	p.scanning = true
+	p.confirming = confirmNone
+	p.summary = ""
+	p.results = nil
+	p.held = nil
+	p.selected = make(map[int]bool)
+	p.cursor = 0
+	p.offset = 0
+}
+
+// groupItems turns scanner items into one result per description.
+func groupItems(items []clean.CleanItem) []clean.ScanResult {
+	groups := make(map[string][]clean.CleanItem)
+	for _, item := range items {
+		groups[item.Description] = append(groups[item.Description], item)
+	}
+	results := make([]clean.ScanResult, 0, len(groups))
+	for name, group := range groups {
+		results = append(results, clean.ItemsToResult(name, group))
+	}
+	return results
+}
+
+func (p *cleanPane) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+	case cleanScanDoneMsg:
+		// Ignore results from a scan that has since been replaced.
+		if msg.label != p.label {
+			return nil
+		}
+		p.scanning = false
+		results := clean.FilterResultsOlderThan(msg.results, p.minAge, time.Now())
+		p.results, p.held = clean.FilterResultsByRisk(results, p.maxRisk)
+		sort.Slice(p.results, func(i, j int) bool {
+			return p.results[i].TotalSize > p.results[j].TotalSize
+		})
+		for i, r := range p.results {
+			p.selected[i] = r.RiskLevel == config.RiskLow
+		}
+		if len(p.results) == 0 {
+			p.summary = ui.SuccessStyle().Render("  " + ui.IconSuccess + " Nothing to clean.")
+		}
+		if held := p.heldSummary(); held != "" {
+			if p.summary != "" {
+				p.summary += "\n"
+			}
+			p.summary += held
+		}
+
+	case cleanDoneMsg:
+		p.cleaning = false
+		if msg.restoreErr != nil {
+			p.summary = ui.ErrorStyle().Render(fmt.Sprintf(
+				"  %s Cannot create restore point: %v — nothing was deleted.", ui.IconError, msg.restoreErr)) +
+				"\n" + ui.MutedStyle().Render(
+				"  Turn on System Protection for the system drive, or start pw tui with --no-restore-point.")
+			return nil
+		}
+		verb := "Freed"
+		if msg.dryRun {
+			verb = "[DRY RUN] Would free"
+		}
+		p.summary = ui.SuccessStyle().Render(fmt.Sprintf("  %s %s %s across %d items",
+			ui.IconSuccess, verb, ui.FormatSize(msg.freed), msg.cleaned))
+		if msg.failed > 0 {
+			p.summary += "\n" + ui.WarningStyle().Render(fmt.Sprintf(
+				"  %s %d items skipped (locked, access denied, whitelisted, or safety check)",
+				ui.IconWarning, msg.failed))
+		}
+		p.results = nil
+		p.selected = make(map[int]bool)
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+	return nil
+}
+
+func (p *cleanPane) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if p.scanning || p.cleaning {
+		return nil
+	}
+	key := msg.String()
+
+	if p.confirming != confirmNone {
+		step := p.confirming
+		p.confirming = confirmNone
+		if key != "y" && key != "Y" {
+			return nil
+		}
+		if step == confirmDelete && len(p.privacyNotes()) > 0 {
+			p.confirming = confirmPrivacy
+			return nil
+		}
+		return p.startClean()
+	}
+
+	switch key {
+	case "u":
+		return p.scanCategory("user")
+	case "b":
+		return p.scanCategory("browser")
+	case "d":
+		return p.scanCategory("dev")
+	case "s":
+		return p.scanCategory("system")
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.results)-1 {
+			p.cursor++
+		}
+	case " ":
+		if len(p.results) > 0 {
+			p.selected[p.cursor] = !p.selected[p.cursor]
+		}
+	case "a":
+		all := p.selectedCount() < len(p.results)
+		for i := range p.results {
+			p.selected[i] = all
+		}
+	case "enter":
+		if p.selectedCount() > 0 {
+			p.confirming = confirmDelete
+		}
+	}
+	p.ensureVisible()
+	return nil
+}
+
+func (p *cleanPane) selectedCount() int {
+	n := 0
+	for i := range p.results {
+		if p.selected[i] {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *cleanPane) selectedSize() int64 {
+	var total int64
+	for i, r := range p.results {
+		if p.selected[i] {
+			total += r.TotalSize
+		}
+	}
+	return total
+}
+
+// heldSummary describes the groups held back by the risk ceiling, or "".
+func (p *cleanPane) heldSummary() string {
+	var items int
+	var size int64
+	for _, r := range p.held {
+		items += r.ItemCount
+		size += r.TotalSize
+	}
+	if items == 0 {
+		return ""
+	}
+	return ui.MutedStyle().Render(fmt.Sprintf(
+		"  Held back %d items (%s) above %s risk — start pw tui with a higher --max-risk to include them",
+		items, ui.FormatSize(size), p.maxRisk))
+}
+
+// privacyNotes returns the privacy note of every checked target that has
+// one, keyed by target name.
+func (p *cleanPane) privacyNotes() map[string]string {
+	notes := make(map[string]string)
+	for _, t := range config.GetCleanTargets() {
+		if t.PrivacyNote == "" {
+			continue
+		}
+		for i, r := range p.results {
+			if p.selected[i] && r.Category == t.Name {
+				notes[t.Name] = t.PrivacyNote
+			}
+		}
+	}
+	return notes
+}
+
+// startClean deletes every item in the checked groups in the background,
+// creating a restore point first when a high-risk group is checked.
+func (p *cleanPane) startClean() tea.Cmd {
+	var items []clean.CleanItem
+	var highRisk bool
+	for i, r := range p.results {
+		if p.selected[i] {
+			items = append(items, r.Items...)
+			highRisk = highRisk || r.RiskLevel == config.RiskHigh
+		}
+	}
+	p.cleaning = true
+
+	var isWhitelisted func(string) bool
+	if p.wl != nil {
+		isWhitelisted = p.wl.IsWhitelisted
+	}
+	dryRun, freeCloud := p.dryRun, p.freeCloud
+	// Without admin rights no restore point can be made; `pw clean` goes
+	// ahead in that case too.
+	wantRestorePoint := highRisk && !dryRun && !p.noRestorePoint && p.isAdmin
+	return func() tea.Msg {
+		done := cleanDoneMsg{dryRun: dryRun}
+		if wantRestorePoint {
+			err := core.CreateRestorePoint("PureWin: before pw tui clean")
+			if err != nil && !errors.Is(err, core.ErrRestorePointRecent) {
+				done.restoreErr = err
+				return done
+			}
+		}
+		for _, item := range items {
+			var freed int64
+			var err error
+			if dryRun {
+				freed, err = core.SafeDeleteWithWhitelist(item.Path, true, isWhitelisted)
+			} else if isWhitelisted != nil && isWhitelisted(item.Path) {
+				err = fmt.Errorf("whitelisted: %s", item.Path)
+			} else {
+				freed, _, err = clean.RemoveItem(item.Path, freeCloud)
+			}
+			if err != nil {
+				done.failed++
+				continue
+			}
+			done.freed += freed
+			done.cleaned++
+		}
+		return done
+	}
+}
+
+func (p *cleanPane) listHeight() int {
+	h := p.height - 9
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+func (p *cleanPane) ensureVisible() {
+	vh := p.listHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+vh {
+		p.offset = p.cursor - vh + 1
+	}
+}
+
+func (p cleanPane) view() string {
+	var s strings.Builder
+	s.WriteString("\n")
+
+	title := "Clean"
+	if p.label != "" {
+		title = "Clean " + ui.IconArrow + " " + p.label
+	}
+	s.WriteString("  " + ui.SectionHeader(title, p.width-4) + "\n\n")
+
+	switch {
+	case p.scanning:
+		s.WriteString(ui.MutedStyle().Render("  Scanning...") + "\n")
+	case p.cleaning:
+		s.WriteString(ui.MutedStyle().Render(fmt.Sprintf("  Cleaning %s...", ui.FormatSize(p.selectedSize()))) + "\n")
+	case len(p.results) > 0:
+		end := p.offset + p.listHeight()
+		if end > len(p.results) {
+			end = len(p.results)
+		}
+		for i := p.offset; i < end; i++ {
+			r := p.results[i]
+			check := "[ ]"
+			if p.selected[i] {
+				check = "[" + ui.IconSuccess + "]"
+			}
+			line := fmt.Sprintf("%s %-36s %10s  %s", check, truncate(r.Category, 36),
+				ui.FormatSize(r.TotalSize), ui.MutedStyle().Render(fmt.Sprintf("%d items", r.ItemCount)))
+			if r.RiskLevel != config.RiskLow {
+				line += "  " + ui.WarningStyle().Render(riskLabel(r.RiskLevel))
+			}
+			if i == p.cursor {
+				s.WriteString(ui.MenuItemActiveStyle().Render(ui.IconArrow+" "+line) + "\n")
+			} else {
+				s.WriteString("  " + line + "\n")
+			}
+		}
+		s.WriteString("\n")
+		s.WriteString(fmt.Sprintf("  %s %s %s\n",
+			ui.BoldStyle().Render("Selected:"),
+			ui.FormatSize(p.selectedSize()),
+			ui.MutedStyle().Render(fmt.Sprintf("(%d of %d groups)", p.selectedCount(), len(p.results)))))
+		switch p.confirming {
+		case confirmDelete:
+			s.WriteString("\n" + ui.WarningStyle().Render(fmt.Sprintf(
+				"  %s Delete %s? Press y to confirm, any other key to cancel.",
+				ui.IconWarning, ui.FormatSize(p.selectedSize()))) + "\n")
+		case confirmPrivacy:
+			notes := p.privacyNotes()
+			names := make([]string, 0, len(notes))
+			for name := range notes {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			s.WriteString("\n")
+			for _, name := range names {
+				s.WriteString(ui.WarningStyle().Render("  "+ui.IconWarning+"  "+name) + "\n")
+				s.WriteString(ui.MutedStyle().Render("     "+notes[name]) + "\n")
+			}
+			s.WriteString(ui.ErrorStyle().Render(
+				"  Permanently delete this AI feature data? Press y to confirm, any other key to cancel.") + "\n")
+		}
+	default:
+		s.WriteString(ui.MutedStyle().Render("  Choose what to scan, or press c on a folder in Analyze.") + "\n")
+	}
+
+	if p.summary != "" {
+		s.WriteString("\n" + p.summary + "\n")
+	}
+
+	hints := "  u user  b browser  d dev  s system  " + ui.IconPipe + "  Space toggle  a all  " + ui.IconPipe + "  Enter clean"
+	s.WriteString("\n" + ui.HintBarStyle().Render(hints))
+	return s.String()
+}
+
+// riskLabel renders a risk level for the group list; unknown levels count
+// as high, as in config.RiskAllowed.
+func riskLabel(level string) string {
+	switch level {
+	case config.RiskMedium:
+		return "medium risk"
+	default:
+		return "high risk"
+	}
+}
+
+// truncate shortens s to width runes, marking the cut with an ellipsis.
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-1]) + "…"
+}
@@ -0,0 +1,362 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/ui"
+	"github.com/cy-infamous/purewin/internal/uninstall"
+)
+
+// ─── Uninstall Pane ──────────────────────────────────────────────────────────
+// Lists installed applications with a live name filter. The list is loaded
+// the first time the pane is shown, since reading every uninstall key and
+// package takes a moment.
+
+type appsLoadedMsg struct {
+	apps []uninstall.InstalledApp
+	err  error
+}
+
+// uninstallOutputMsg is a line of output from the running uninstaller.
+type uninstallOutputMsg struct {
+	line string
+}
+
+type appUninstalledMsg struct {
+	name   string
+	dryRun bool
+	err    error
+}
+
+type uninstallPane struct {
+	protect *uninstall.ProtectionList
+	dryRun  bool
+
+	apps    []uninstall.InstalledApp
+	visible []uninstall.InstalledApp
+	loaded  bool
+	loading bool
+
+	filter    textinput.Model
+	filtering bool
+
+	cursor     int
+	offset     int
+	confirming bool
+	running    string
+	summary    string
+
+	// Set while an uninstaller runs: its latest output line, the channel
+	// further lines arrive on, and the cancel func behind the x key.
+	lastLine string
+	lines    chan string
+	cancel   context.CancelFunc
+
+	width  int
+	height int
+}
+
+func newUninstallPane(protect *uninstall.ProtectionList, dryRun bool) uninstallPane {
+	ti := textinput.New()
+	ti.Placeholder = "filter by name"
+	ti.Prompt = ""
+	ti.CharLimit = 128
+
+	return uninstallPane{protect: protect, dryRun: dryRun, filter: ti, width: 80, height: 20}
+}
+
+// activate loads the application list on first use.
+func (p *uninstallPane) activate() tea.Cmd {
+	if p.loaded || p.loading {
+		return nil
+	}
+	p.loading = true
+	return func() tea.Msg {
+		apps, err := uninstall.GetInstalledApps(false)
+		return appsLoadedMsg{apps: apps, err: err}
+	}
+}
+
+func (p *uninstallPane) update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+	case appsLoadedMsg:
+		p.loading = false
+		p.loaded = true
+		if msg.err != nil {
+			p.summary = ui.ErrorStyle().Render(fmt.Sprintf("  %s Failed to read registry: %v", ui.IconError, msg.err))
+			return nil
+		}
+		p.apps = msg.apps
+		sort.Slice(p.apps, func(i, j int) bool {
+			return strings.ToLower(p.apps[i].Name) < strings.ToLower(p.apps[j].Name)
+		})
+		p.applyFilter()
+
+	case uninstallOutputMsg:
+		p.lastLine = msg.line
+		return waitForOutput(p.lines)
+
+	case appUninstalledMsg:
+		p.running = ""
+		p.lastLine = ""
+		if p.cancel != nil {
+			p.cancel()
+			p.cancel = nil
+		}
+		if errors.Is(msg.err, uninstall.ErrCancelled) {
+			p.summary = ui.WarningStyle().Render(fmt.Sprintf("  %s Cancelled uninstalling %s", ui.IconWarning, msg.name))
+			return nil
+		}
+		if msg.err != nil {
+			p.summary = ui.ErrorStyle().Render(fmt.Sprintf("  %s %s: %v", ui.IconError, msg.name, msg.err))
+			return nil
+		}
+		if msg.dryRun {
+			p.summary = ui.InfoStyle().Render(fmt.Sprintf("  [DRY RUN] Would uninstall %s", msg.name))
+			return nil
+		}
+		p.summary = ui.SuccessStyle().Render(fmt.Sprintf("  %s %s uninstalled", ui.IconSuccess, msg.name))
+		// Reload so the list reflects what is actually still installed.
+		p.loaded = false
+		return p.activate()
+
+	case tea.KeyMsg:
+		return p.handleKey(msg)
+	}
+	return nil
+}
+
+func (p *uninstallPane) handleKey(msg tea.KeyMsg) tea.Cmd {
+	key := msg.String()
+
+	if p.filtering {
+		switch key {
+		case "enter", "esc":
+			p.filtering = false
+			p.filter.Blur()
+			if key == "esc" {
+				p.filter.SetValue("")
+				p.applyFilter()
+			}
+			return nil
+		}
+		var cmd tea.Cmd
+		p.filter, cmd = p.filter.Update(msg)
+		p.applyFilter()
+		return cmd
+	}
+
+	if p.running != "" {
+		if key == "x" && p.cancel != nil {
+			p.cancel()
+		}
+		return nil
+	}
+	if p.loading {
+		return nil
+	}
+
+	if p.confirming {
+		p.confirming = false
+		if key == "y" || key == "Y" {
+			return p.startUninstall()
+		}
+		return nil
+	}
+
+	switch key {
+	case "/":
+		p.filtering = true
+		p.filter.Focus()
+		return textinput.Blink
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.visible)-1 {
+			p.cursor++
+		}
+	case "enter", "u":
+		if p.cursor < len(p.visible) {
+			app := p.visible[p.cursor]
+			if rule, ok := p.protect.Match(app); ok {
+				p.summary = ui.WarningStyle().Render(fmt.Sprintf(
+					"  %s %s is protected (rule %q)", ui.IconWarning, app.Name, rule))
+				return nil
+			}
+			p.confirming = true
+		}
+	case "r":
+		p.loaded = false
+		return p.activate()
+	}
+	p.ensureVisible()
+	return nil
+}
+
+// applyFilter recomputes the visible list from the filter text.
+func (p *uninstallPane) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(p.filter.Value()))
+	p.visible = p.visible[:0]
+	for _, app := range p.apps {
+		if query == "" || strings.Contains(strings.ToLower(app.Name), query) {
+			p.visible = append(p.visible, app)
+		}
+	}
+	if p.cursor >= len(p.visible) {
+		p.cursor = len(p.visible) - 1
+	}
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	p.offset = 0
+	p.ensureVisible()
+}
+
+// startUninstall runs the selected app's uninstaller in the background,
+// streaming its output lines to the pane.
+func (p *uninstallPane) startUninstall() tea.Cmd {
+	app := p.visible[p.cursor]
+	p.running = app.Name
+	p.summary = ""
+	protect, dryRun := p.protect, p.dryRun
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines := make(chan string, 16)
+	p.cancel, p.lines = cancel, lines
+
+	run := func() tea.Msg {
+		defer close(lines)
+		if err := protect.Check(app); err != nil {
+			return appUninstalledMsg{name: app.Name, err: err}
+		}
+		if dryRun {
+			return appUninstalledMsg{name: app.Name, dryRun: true}
+		}
+		err := uninstall.UninstallAppContext(ctx, app, uninstall.RunOptions{
+			Output: func(line string) {
+				select {
+				case lines <- line:
+				default: // the pane only shows the latest line
+				}
+			},
+		})
+		if cfg, cfgErr := config.Load(); cfgErr == nil {
+			_ = uninstall.RecordUninstall(cfg.ConfigDir, uninstall.NewHistoryEntry(app, err, time.Now()))
+		}
+		return appUninstalledMsg{name: app.Name, err: err}
+	}
+	return tea.Batch(run, waitForOutput(lines))
+}
+
+// waitForOutput waits for the next uninstaller output line.
+func waitForOutput(lines <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return nil
+		}
+		return uninstallOutputMsg{line: line}
+	}
+}
+
+func (p *uninstallPane) listHeight() int {
+	h := p.height - 10
+	if h < 3 {
+		h = 3
+	}
+	return h
+}
+
+func (p *uninstallPane) ensureVisible() {
+	vh := p.listHeight()
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+vh {
+		p.offset = p.cursor - vh + 1
+	}
+}
+
+func (p uninstallPane) view() string {
+	var s strings.Builder
+	s.WriteString("\n")
+	s.WriteString("  " + ui.SectionHeader("Uninstall", p.width-4) + "\n\n")
+
+	filterLine := ui.MutedStyle().Render("  / to filter")
+	if p.filtering || p.filter.Value() != "" {
+		filterLine = "  " + ui.BoldStyle().Render("Filter: ") + p.filter.View()
+	}
+	s.WriteString(filterLine + "\n\n")
+
+	switch {
+	case p.loading:
+		s.WriteString(ui.MutedStyle().Render("  Scanning installed applications...") + "\n")
+	case len(p.visible) == 0:
+		s.WriteString(ui.MutedStyle().Render("  No applications to show.") + "\n")
+	default:
+		nameW := p.width - 40
+		if nameW < 20 {
+			nameW = 20
+		}
+		end := p.offset + p.listHeight()
+		if end > len(p.visible) {
+			end = len(p.visible)
+		}
+		for i := p.offset; i < end; i++ {
+			app := p.visible[i]
+			size := ""
+			if app.EstimatedSize > 0 {
+				size = ui.FormatSize(app.EstimatedSize)
+			}
+			line := fmt.Sprintf("%-*s %10s  %s", nameW, truncate(app.Name, nameW), size,
+				ui.MutedStyle().Render(truncate(app.Version, 16)))
+			if _, protected := p.protect.Match(app); protected {
+				line = ui.MutedStyle().Render(fmt.Sprintf("%-*s %10s  protected", nameW, truncate(app.Name, nameW), size))
+			}
+			if i == p.cursor {
+				s.WriteString(ui.MenuItemActiveStyle().Render(ui.IconArrow+" "+line) + "\n")
+			} else {
+				s.WriteString("  " + line + "\n")
+			}
+		}
+		s.WriteString(ui.MutedStyle().Render(fmt.Sprintf("\n  %d of %d applications", len(p.visible), len(p.apps))) + "\n")
+	}
+
+	if p.running != "" {
+		s.WriteString("\n" + ui.InfoStyle().Render("  Uninstalling "+p.running+"...") + "\n")
+		if p.lastLine != "" {
+			s.WriteString(ui.MutedStyle().Render("  "+truncate(p.lastLine, p.width-4)) + "\n")
+		}
+	}
+	if p.confirming && p.cursor < len(p.visible) {
+		s.WriteString("\n" + ui.WarningStyle().Render(fmt.Sprintf(
+			"  %s Uninstall %s? Press y to confirm, any other key to cancel.",
+			ui.IconWarning, p.visible[p.cursor].Name)) + "\n")
+	}
+	if p.summary != "" {
+		s.WriteString("\n" + p.summary + "\n")
+	}
+
+	hints := "  ↑↓ select  " + ui.IconPipe + "  / filter  " + ui.IconPipe + "  Enter uninstall  " + ui.IconPipe + "  r reload"
+	if p.running != "" {
+		hints = "  x cancel uninstall"
+	}
+	s.WriteString("\n" + ui.HintBarStyle().Render(hints))
+	return s.String()
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── Rendering ───────────────────────────────────────────────────────────────
+
+func (m AppModel) renderView() string {
+	w := m.width
+	if w < 50 {
+		w = 50
+	}
+
+	var s strings.Builder
+	s.WriteString(m.renderPaneBar(w))
+	s.WriteString("\n")
+
+	switch m.pane {
+	case PaneStatus:
+		s.WriteString(m.status.View())
+	case PaneAnalyze:
+		s.WriteString(m.analyze.view())
+	case PaneClean:
+		s.WriteString(m.clean.view())
+	case PaneUninstall:
+		s.WriteString(m.uninstall.view())
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.renderAppFooter())
+	return s.String()
+}
+
+// renderPaneBar draws the pane switcher above the active pane.
+func (m AppModel) renderPaneBar(w int) string {
+	brand := lipgloss.NewStyle().
+		Foreground(ui.ColorPrimary).
+		Bold(true).
+		Render(" " + ui.IconDiamond + " PureWin ")
+
+	active := lipgloss.NewStyle().
+		Foreground(ui.ColorText).
+		Background(ui.ColorPrimary).
+		Bold(true).
+		Padding(0, 1)
+
+	inactive := lipgloss.NewStyle().
+		Foreground(ui.ColorMuted).
+		Padding(0, 1)
+
+	parts := []string{brand}
+	for i, name := range PaneNames {
+		label := fmt.Sprintf("F%d %s", i+1, name)
+		if Pane(i) == m.pane {
+			parts = append(parts, active.Render(label))
+		} else {
+			parts = append(parts, inactive.Render(label))
+		}
+	}
+
+	if m.dryRun {
+		parts = append(parts, "  "+ui.TagAccentStyle().Render(" DRY RUN "))
+	}
+	if n := m.status.Alerts.Unacknowledged(); n > 0 {
+		parts = append(parts, "  "+ui.TagWarningStyle().Render(fmt.Sprintf(" %d alerts ", n)))
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Center, parts...) + "\n" + ui.Divider(w)
+}
+
+// renderAppFooter shows the global key hints.
+func (m AppModel) renderAppFooter() string {
+	hints := "  F1-F4 / Ctrl+←→ switch pane  " + ui.IconPipe + "  q quit"
+	return ui.HintBarStyle().Render(hints)
+}
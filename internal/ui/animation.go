@@ -47,6 +47,12 @@ func isTerminal() bool {
 	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
 }
 
+// IsTerminal reports whether stdout is an interactive terminal, so callers
+// can skip full-screen views when output is piped.
+func IsTerminal() bool {
+	return isTerminal()
+}
+
 // enableVTProcessing enables Virtual Terminal Processing on the Windows console
 // so that ANSI escape codes work in cmd.exe and older PowerShell versions.
 func enableVTProcessing() {
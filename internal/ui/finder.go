@@ -0,0 +1,426 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Finder Data ─────────────────────────────────────────────────────────────
+
+// FinderItem is a SelectorItem with the extra data the finder sorts on and
+// shows in its details pane.
+type FinderItem struct {
+	SelectorItem
+
+	// Date sorts items newest first (e.g. an install date); items without
+	// one sort last.
+	Date time.Time
+
+	// Details are shown, one per line, in the details pane while the item
+	// is under the cursor.
+	Details []string
+}
+
+// Finder sort orders, cycled with ctrl+s.
+const (
+	finderSortRelevance = iota
+	finderSortName
+	finderSortSize
+	finderSortDate
+	finderSortCount
+)
+
+var finderSortNames = [finderSortCount]string{"relevance", "name", "size", "date"}
+
+// finderMatch is an item that passes the query.
+type finderMatch struct {
+	idx       int
+	score     int
+	positions []int // matched rune indexes in the label
+}
+
+// ─── Finder Model ────────────────────────────────────────────────────────────
+
+// FinderModel is a Bubbletea model for a type-to-filter multi-select list:
+// the query is fuzzy-matched against each label (then description), the
+// matched characters are highlighted, and the list can be re-sorted by
+// name, size or date. The item under the cursor is described in a details
+// pane below the list.
+type FinderModel struct {
+	items     []FinderItem
+	matches   []finderMatch
+	query     textinput.Model
+	sortBy    int
+	cursor    int
+	offset    int
+	width     int
+	height    int
+	title     string
+	confirmed bool
+	quitting  bool
+}
+
+// NewFinderModel creates a FinderModel over items with an initial query.
+func NewFinderModel(items []FinderItem, query string) FinderModel {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "type to search"
+	ti.SetValue(query)
+	ti.Focus()
+
+	m := FinderModel{items: items, query: ti, width: 80, height: 24}
+	m.refresh()
+	return m
+}
+
+// SetTitle sets an optional header displayed above the finder.
+func (m FinderModel) SetTitle(title string) FinderModel {
+	m.title = title
+	return m
+}
+
+// GetSelected returns all items currently marked as selected.
+func (m FinderModel) GetSelected() []FinderItem {
+	var result []FinderItem
+	for _, item := range m.items {
+		if item.Selected {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Confirmed returns true if the user pressed Enter to confirm.
+func (m FinderModel) Confirmed() bool {
+	return m.confirmed
+}
+
+// ─── Matching and Sorting ────────────────────────────────────────────────────
+
+// refresh recomputes the matches for the current query and sort order and
+// resets the cursor.
+func (m *FinderModel) refresh() {
+	query := strings.TrimSpace(m.query.Value())
+	m.matches = m.matches[:0]
+	for i, item := range m.items {
+		if score, pos, ok := FuzzyMatch(query, item.Label); ok {
+			m.matches = append(m.matches, finderMatch{idx: i, score: score, positions: pos})
+			continue
+		}
+		// Descriptions (publisher, version) match too, ranked below labels.
+		if score, _, ok := FuzzyMatch(query, item.Description); ok {
+			m.matches = append(m.matches, finderMatch{idx: i, score: score / 2})
+		}
+	}
+	m.sortMatches()
+	m.cursor, m.offset = 0, 0
+}
+
+// sortMatches orders the matches by the current sort, falling back to the
+// original item order.
+func (m *FinderModel) sortMatches() {
+	sort.SliceStable(m.matches, func(i, j int) bool {
+		a, b := m.matches[i], m.matches[j]
+		ia, ib := m.items[a.idx], m.items[b.idx]
+		switch m.sortBy {
+		case finderSortName:
+			return strings.ToLower(ia.Label) < strings.ToLower(ib.Label)
+		case finderSortSize:
+			return ia.Bytes > ib.Bytes
+		case finderSortDate:
+			if ia.Date.IsZero() != ib.Date.IsZero() {
+				return !ia.Date.IsZero()
+			}
+			return ia.Date.After(ib.Date)
+		default:
+			if a.score != b.score {
+				return a.score > b.score
+			}
+			return a.idx < b.idx
+		}
+	})
+}
+
+// ─── Size Calculation ────────────────────────────────────────────────────────
+
+func (m FinderModel) selectedCount() (int, int64) {
+	var n int
+	var total int64
+	for _, item := range m.items {
+		if item.Selected {
+			n++
+			total += item.Bytes
+		}
+	}
+	return n, total
+}
+
+// listHeight is the number of rows available for items.
+func (m FinderModel) listHeight() int {
+	// Title, summary, query, details pane and hints.
+	h := m.height - 16
+	if h < 5 {
+		h = 5
+	}
+	return h
+}
+
+// moveCursor moves the cursor by delta, clamped, keeping it in view.
+func (m *FinderModel) moveCursor(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.cursor = max(0, min(len(m.matches)-1, m.cursor+delta))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if h := m.listHeight(); m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+}
+
+// ─── Bubbletea Interface ─────────────────────────────────────────────────────
+
+// Init returns the initial command.
+func (m FinderModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles input messages. Printable keys edit the query; selection
+// and sorting use tab and ctrl keys so every character can be searched.
+func (m FinderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.moveCursor(0)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			if m.query.Value() != "" {
+				m.query.SetValue("")
+				m.refresh()
+				return m, nil
+			}
+			m.quitting = true
+			return m, tea.Quit
+
+		case "up", "ctrl+p":
+			m.moveCursor(-1)
+			return m, nil
+		case "down", "ctrl+n":
+			m.moveCursor(1)
+			return m, nil
+		case "pgup":
+			m.moveCursor(-m.listHeight())
+			return m, nil
+		case "pgdown":
+			m.moveCursor(m.listHeight())
+			return m, nil
+
+		case "tab":
+			if len(m.matches) > 0 {
+				item := &m.items[m.matches[m.cursor].idx]
+				if !item.Disabled {
+					item.Selected = !item.Selected
+				}
+				m.moveCursor(1)
+			}
+			return m, nil
+
+		case "ctrl+a":
+			for _, match := range m.matches {
+				if !m.items[match.idx].Disabled {
+					m.items[match.idx].Selected = true
+				}
+			}
+			return m, nil
+
+		case "ctrl+x":
+			for i := range m.items {
+				m.items[i].Selected = false
+			}
+			return m, nil
+
+		case "ctrl+s":
+			m.sortBy = (m.sortBy + 1) % finderSortCount
+			m.sortMatches()
+			m.cursor, m.offset = 0, 0
+			return m, nil
+
+		case "enter":
+			// With nothing ticked, Enter picks the item under the cursor.
+			if n, _ := m.selectedCount(); n == 0 && len(m.matches) > 0 {
+				item := &m.items[m.matches[m.cursor].idx]
+				if item.Disabled {
+					return m, nil
+				}
+				item.Selected = true
+			}
+			m.confirmed = true
+			return m, tea.Quit
+		}
+	}
+
+	before := m.query.Value()
+	var cmd tea.Cmd
+	m.query, cmd = m.query.Update(msg)
+	if m.query.Value() != before {
+		m.refresh()
+	}
+	return m, cmd
+}
+
+// View renders the finder UI.
+func (m FinderModel) View() string {
+	if m.quitting && !m.confirmed {
+		return ""
+	}
+
+	var b strings.Builder
+
+	// ── Title ──
+	if m.title != "" {
+		b.WriteString(HeaderStyle().Render(m.title))
+		b.WriteString(Divider(50))
+		b.WriteString("\n\n")
+	}
+
+	// ── Selection summary ──
+	n, total := m.selectedCount()
+	summary := TagStyle().Render(fmt.Sprintf(" %d/%d ", n, len(m.items)))
+	if total > 0 {
+		summary += "  " + TagAccentStyle().Render(" "+FormatSizePlain(total)+" ")
+	}
+	summary += "  " + MutedStyle().Render(fmt.Sprintf("%d shown • sort: %s", len(m.matches), finderSortNames[m.sortBy]))
+	b.WriteString("  " + summary + "\n\n")
+
+	// ── Query ──
+	prompt := lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(IconPrompt + " ")
+	b.WriteString("  " + prompt + m.query.View() + "\n\n")
+
+	// ── Items ──
+	if len(m.matches) == 0 {
+		b.WriteString(MutedStyle().Render("  No matches.") + "\n")
+	}
+	end := min(len(m.matches), m.offset+m.listHeight())
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderRow(m.matches[i], i == m.cursor))
+		b.WriteByte('\n')
+	}
+	if len(m.matches) > end || m.offset > 0 {
+		b.WriteString(MutedStyle().Render(fmt.Sprintf("  %d–%d of %d", m.offset+1, end, len(m.matches))))
+		b.WriteByte('\n')
+	}
+
+	// ── Details ──
+	if len(m.matches) > 0 {
+		item := m.items[m.matches[m.cursor].idx]
+		b.WriteByte('\n')
+		b.WriteString(SectionHeader("Details", 50))
+		b.WriteByte('\n')
+		if item.Description != "" {
+			b.WriteString("  " + MutedStyle().Italic(true).Render(item.Description) + "\n")
+		}
+		for _, line := range item.Details {
+			b.WriteString("  " + MutedStyle().Render(line) + "\n")
+		}
+	}
+
+	// ── Hint Bar ──
+	b.WriteByte('\n')
+	hints := []string{"type to search", "↑↓ nav", "tab toggle", "ctrl+a all", "ctrl+x none", "ctrl+s sort", "enter ok", "esc clear/quit"}
+	b.WriteString(HintBarStyle().Render("  " + strings.Join(hints, " "+IconPipe+" ")))
+	b.WriteByte('\n')
+
+	return b.String()
+}
+
+// renderRow renders one list row with the matched characters highlighted.
+func (m FinderModel) renderRow(match finderMatch, active bool) string {
+	item := m.items[match.idx]
+	var line strings.Builder
+
+	if active {
+		line.WriteString(lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(IconBlock + " "))
+	} else {
+		line.WriteString("  ")
+	}
+
+	switch {
+	case item.Disabled:
+		line.WriteString(MutedStyle().Render(IconDash + " "))
+	case item.Selected:
+		line.WriteString(lipgloss.NewStyle().Foreground(ColorBlue).Bold(true).Render(IconRadioOn + " "))
+	default:
+		line.WriteString(MutedStyle().Render(IconRadioOff + " "))
+	}
+
+	base := lipgloss.NewStyle().Foreground(ColorText)
+	switch {
+	case item.Disabled:
+		base = MutedStyle()
+	case active:
+		base = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
+	case item.Selected:
+		base = lipgloss.NewStyle().Foreground(ColorBlue)
+	}
+	hit := base.Foreground(ColorAccent).Bold(true).Underline(true)
+
+	matched := make(map[int]bool, len(match.positions))
+	for _, p := range match.positions {
+		matched[p] = true
+	}
+	for i, r := range []rune(item.Label) {
+		if matched[i] {
+			line.WriteString(hit.Render(string(r)))
+		} else {
+			line.WriteString(base.Render(string(r)))
+		}
+	}
+
+	if item.Size != "" {
+		line.WriteString("  ")
+		sizeStyle := MutedStyle()
+		if item.Selected && !item.Disabled {
+			sizeStyle = lipgloss.NewStyle().Foreground(ColorBlue)
+		}
+		line.WriteString(sizeStyle.Render(item.Size))
+	}
+	return line.String()
+}
+
+// ─── Runner ──────────────────────────────────────────────────────────────────
+
+// RunFinder runs the finder over items, starting with query, and returns
+// the selected items. Returns (nil, nil) if the user quit without
+// confirming.
+func RunFinder(items []FinderItem, title, query string) ([]FinderItem, error) {
+	m := NewFinderModel(items, query).SetTitle(title)
+	p := tea.NewProgram(m, tea.WithAltScreen())
+
+	final, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("finder error: %w", err)
+	}
+
+	result, ok := final.(FinderModel)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type from finder")
+	}
+	if !result.Confirmed() {
+		return nil, nil
+	}
+	return result.GetSelected(), nil
+}
@@ -0,0 +1,98 @@
+package ui
+
+import "unicode"
+
+// ─── Fuzzy Matching ──────────────────────────────────────────────────────────
+// A small fzf-style matcher: every rune of the pattern must appear in order
+// (case-insensitive). Matches that start words, run consecutively or start
+// early rank higher.
+
+// Fuzzy match scoring.
+const (
+	fuzzyMatch       = 16
+	fuzzyConsecutive = 12
+	fuzzyWordStart   = 10
+	fuzzyFirstRune   = 8
+	fuzzyGap         = 1
+)
+
+// FuzzyMatch reports whether pattern matches s, with a score (higher is
+// better) and the rune indexes in s that matched. An empty pattern matches
+// everything with score 0.
+func FuzzyMatch(pattern, s string) (score int, positions []int, ok bool) {
+	pat := []rune(foldRunes(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	text := []rune(s)
+	lower := []rune(foldRunes(s))
+
+	// Try every start position of the first rune and keep the best greedy
+	// match; names are short, so this is cheap.
+	found := false
+	for start := range lower {
+		if lower[start] != pat[0] {
+			continue
+		}
+		sc, pos, matched := fuzzyFrom(pat, text, lower, start)
+		if matched && (!found || sc > score) {
+			found, score, positions = true, sc, pos
+		}
+	}
+	return score, positions, found
+}
+
+// fuzzyFrom greedily matches pat in lower from start.
+func fuzzyFrom(pat, text, lower []rune, start int) (int, []int, bool) {
+	positions := make([]int, 0, len(pat))
+	score, prev, p := 0, -1, 0
+	for i := start; i < len(lower) && p < len(pat); i++ {
+		if lower[i] != pat[p] {
+			continue
+		}
+		score += fuzzyMatch
+		switch {
+		case prev >= 0 && i == prev+1:
+			score += fuzzyConsecutive
+		case prev >= 0:
+			score -= fuzzyGap * (i - prev - 1)
+		}
+		if isWordStart(text, i) {
+			score += fuzzyWordStart
+		}
+		if i == 0 {
+			score += fuzzyFirstRune
+		}
+		positions = append(positions, i)
+		prev = i
+		p++
+	}
+	if p < len(pat) {
+		return 0, nil, false
+	}
+	// Prefer matches that begin early in the string.
+	score -= start
+	return score, positions, true
+}
+
+// isWordStart reports whether text[i] begins a word: the first rune, a
+// rune after a separator, or an upper-case rune after a lower-case one.
+func isWordStart(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// foldRunes lower-cases s rune by rune, keeping the rune count.
+func foldRunes(s string) string {
+	r := []rune(s)
+	for i, c := range r {
+		r[i] = unicode.ToLower(c)
+	}
+	return string(r)
+}
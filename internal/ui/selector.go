@@ -34,8 +34,9 @@ type SelectorItem struct {
 	// value appear under a shared header.
 	Category string
 
-	// sizeBytes is used internally for total-size calculation.
-	sizeBytes int64
+	// Bytes is the raw size behind Size. Selected items are summed into
+	// the live total shown above the list.
+	Bytes int64
 }
 
 // ─── Selector Model ──────────────────────────────────────────────────────────
@@ -148,12 +149,50 @@ func (m SelectorModel) totalSelectedBytes() int64 {
 	var total int64
 	for _, item := range m.items {
 		if item.Selected {
-			total += item.sizeBytes
+			total += item.Bytes
 		}
 	}
 	return total
 }
 
+// categorySelectedBytes returns the selected size within one category.
+func (m SelectorModel) categorySelectedBytes(category string) int64 {
+	var total int64
+	for _, item := range m.items {
+		if item.Category == category && item.Selected {
+			total += item.Bytes
+		}
+	}
+	return total
+}
+
+// hasCategories reports whether any item is grouped under a category.
+func (m SelectorModel) hasCategories() bool {
+	for _, item := range m.items {
+		if item.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleCategory selects every enabled item in the category, or clears
+// them all when they are already selected.
+func (m *SelectorModel) toggleCategory(category string) {
+	all := true
+	for _, item := range m.items {
+		if item.Category == category && !item.Disabled && !item.Selected {
+			all = false
+			break
+		}
+	}
+	for i := range m.items {
+		if m.items[i].Category == category && !m.items[i].Disabled {
+			m.items[i].Selected = !all
+		}
+	}
+}
+
 // ─── Bubbletea Interface ─────────────────────────────────────────────────────
 
 // Init returns the initial command.
@@ -239,6 +278,12 @@ func (m SelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		// ── Toggle Current Category ──
+		case "c":
+			if len(m.items) > 0 && m.items[m.cursor].Category != "" {
+				m.toggleCategory(m.items[m.cursor].Category)
+			}
+
 		// ── Deselect All ──
 		case "n":
 			for i := range m.items {
@@ -300,6 +345,9 @@ func (m SelectorModel) View() string {
 		if item.Category != "" && item.Category != lastCategory {
 			lastCategory = item.Category
 			b.WriteString(SectionHeader(item.Category, 50))
+			if sel := m.categorySelectedBytes(item.Category); sel > 0 {
+				b.WriteString(MutedStyle().Render("  " + FormatSizePlain(sel)))
+			}
 			b.WriteByte('\n')
 		}
 
@@ -382,6 +430,9 @@ func (m SelectorModel) View() string {
 	hints = append(hints, "↑↓ nav")
 	hints = append(hints, "space toggle")
 	hints = append(hints, "a all")
+	if m.hasCategories() {
+		hints = append(hints, "c category")
+	}
 	hints = append(hints, "n none")
 	if totalPages > 1 {
 		hints = append(hints, "pgup/pgdn pages")
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ─── Target Progress Model (Bubbletea) ───────────────────────────────────────
+// A live progress screen for multi-target operations such as cleanup: one
+// bar per target plus an overall bar. The caller runs the work in the
+// background and feeds the model TargetProgressMsg values via Program.Send,
+// then sends TargetProgressDoneMsg to end the program.
+
+// TargetSpec describes one target before work starts.
+type TargetSpec struct {
+	Name  string
+	Items int
+	Size  int64
+}
+
+// TargetProgressMsg reports progress within a target. Done == 0 marks the
+// start of the target.
+type TargetProgressMsg struct {
+	Target string
+	Path   string // item just finished
+	Done   int    // items finished so far
+	Total  int
+	Freed  int64 // bytes freed by the item just finished
+	Failed bool  // the item could not be processed
+}
+
+// TargetProgressDoneMsg ends the progress screen.
+type TargetProgressDoneMsg struct{}
+
+// targetRow is the display state of one target.
+type targetRow struct {
+	name   string
+	total  int
+	done   int
+	failed int
+	freed  int64
+}
+
+// TargetProgressModel renders per-target and overall progress.
+type TargetProgressModel struct {
+	title    string
+	rows     []targetRow
+	index    map[string]int
+	active   int
+	path     string
+	bar      progress.Model
+	width    int
+	height   int
+	cancel   func()
+	stopping bool
+	finished bool
+}
+
+// NewTargetProgress creates a progress screen for targets. cancel, if
+// non-nil, is called when the user presses ctrl+c; the screen stays up
+// until TargetProgressDoneMsg arrives.
+func NewTargetProgress(title string, targets []TargetSpec, cancel func()) TargetProgressModel {
+	m := TargetProgressModel{
+		title:  title,
+		index:  make(map[string]int, len(targets)),
+		active: -1,
+		bar: progress.New(
+			progress.WithScaledGradient(string(ColorPrimary.Dark), string(ColorSecondary.Dark)),
+			progress.WithWidth(20),
+			progress.WithoutPercentage(),
+		),
+		width:  80,
+		height: 24,
+		cancel: cancel,
+	}
+	for _, t := range targets {
+		m.index[t.Name] = len(m.rows)
+		m.rows = append(m.rows, targetRow{name: t.Name, total: t.Items})
+	}
+	return m
+}
+
+// Stopped reports whether the user asked to stop early.
+func (m TargetProgressModel) Stopped() bool {
+	return m.stopping
+}
+
+// Init implements tea.Model.
+func (m TargetProgressModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update applies progress messages, resizes and the stop key.
+func (m TargetProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.bar.Width = max(10, min(30, msg.Width-60))
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" && !m.stopping {
+			m.stopping = true
+			if m.cancel != nil {
+				m.cancel()
+			}
+		}
+		return m, nil
+
+	case TargetProgressMsg:
+		i, ok := m.index[msg.Target]
+		if !ok {
+			i = len(m.rows)
+			m.index[msg.Target] = i
+			m.rows = append(m.rows, targetRow{name: msg.Target})
+		}
+		row := &m.rows[i]
+		row.total = max(row.total, msg.Total)
+		if msg.Done > 0 {
+			row.done = msg.Done
+			row.freed += msg.Freed
+			if msg.Failed {
+				row.failed++
+			}
+			m.path = msg.Path
+		}
+		m.active = i
+		return m, nil
+
+	case TargetProgressDoneMsg:
+		m.finished = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// View renders a window of target rows around the active one, then the
+// overall bar and the current path.
+func (m TargetProgressModel) View() string {
+	var b strings.Builder
+	b.WriteString("\n  " + BoldStyle().Render(m.title) + "\n\n")
+
+	nameWidth := 8
+	for _, r := range m.rows {
+		nameWidth = max(nameWidth, lipgloss.Width(r.name))
+	}
+	nameWidth = min(nameWidth, 28)
+
+	visible := max(3, m.height-9)
+	start := 0
+	if len(m.rows) > visible {
+		start = min(max(0, m.active-visible/2), len(m.rows)-visible)
+	}
+	end := min(len(m.rows), start+visible)
+	if start > 0 {
+		b.WriteString(MutedStyle().Render(fmt.Sprintf("    … %d more above", start)) + "\n")
+	}
+
+	var done, total, failed int
+	var freed int64
+	for i, r := range m.rows {
+		done += r.done
+		total += r.total
+		failed += r.failed
+		freed += r.freed
+		if i < start || i >= end {
+			continue
+		}
+
+		icon := MutedStyle().Render(IconCircle)
+		switch {
+		case r.total > 0 && r.done >= r.total:
+			icon = SuccessStyle().Render(IconCheck)
+		case i == m.active:
+			icon = InfoStyle().Render(IconArrow)
+		}
+		fmt.Fprintf(&b, "  %s %-*s %s %5d/%-5d %s",
+			icon, nameWidth, truncateLeft(r.name, nameWidth),
+			m.bar.ViewAs(ratio(r.done, r.total)), r.done, r.total, FormatSize(r.freed))
+		if r.failed > 0 {
+			b.WriteString(WarningStyle().Render(fmt.Sprintf("  %d skipped", r.failed)))
+		}
+		b.WriteString("\n")
+	}
+	if end < len(m.rows) {
+		b.WriteString(MutedStyle().Render(fmt.Sprintf("    … %d more below", len(m.rows)-end)) + "\n")
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "  %-*s %s %5d/%-5d %s freed",
+		nameWidth+2, "Overall", m.bar.ViewAs(ratio(done, total)), done, total, FormatSize(freed))
+	if failed > 0 {
+		b.WriteString(WarningStyle().Render(fmt.Sprintf("  %d skipped", failed)))
+	}
+	b.WriteString("\n")
+
+	if m.finished {
+		return b.String()
+	}
+	if m.path != "" {
+		b.WriteString(MutedStyle().Render("  "+truncateLeft(m.path, max(20, m.width-4))) + "\n")
+	}
+	if m.stopping {
+		b.WriteString(WarningStyle().Render("  Stopping after the current item...") + "\n")
+	} else {
+		b.WriteString(MutedStyle().Render("  ctrl+c to stop after the current item") + "\n")
+	}
+	return b.String()
+}
+
+// ratio returns done/total clamped to [0, 1].
+func ratio(done, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return min(1, float64(done)/float64(total))
+}
+
+// truncateLeft shortens s to width runes, keeping its end (the most
+// specific part of a path).
+func truncateLeft(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width || width < 2 {
+		return s
+	}
+	return "…" + string(r[len(r)-width+1:])
+}
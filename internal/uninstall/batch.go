@@ -1,23 +1,69 @@
 package uninstall
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
 
 	"github.com/cy-infamous/purewin/internal/core"
 	"github.com/cy-infamous/purewin/internal/ui"
 )
 
-// RunBatchUninstall presents a multi-select UI for the given applications,
+// BatchOptions configures a batch uninstall.
+type BatchOptions struct {
+	// DryRun lists the selected apps without uninstalling them.
+	DryRun bool
+
+	// Protect locks matching apps; they are never uninstalled.
+	Protect *ProtectionList
+
+	// BackupDir receives .reg backups of leftover registry keys.
+	BackupDir string
+
+	// Timeout is the per-app uninstaller timeout; zero means
+	// DefaultUninstallTimeout.
+	Timeout time.Duration
+
+	// MSILogDir, if set, receives a verbose log of each msiexec uninstall
+	// (see RunOptions.MSILogDir).
+	MSILogDir string
+
+	// HistoryDir, if set, receives a record of every uninstall (see
+	// RecordUninstall).
+	HistoryDir string
+
+	// Query is the initial search in the finder.
+	Query string
+
+	// Footprint shows each selected app's footprint before the
+	// confirmation.
+	Footprint bool
+
+	// BeforeRun, if non-nil, is called once the selection is confirmed
+	// and before anything is uninstalled (e.g. to create a restore
+	// point). Returning false cancels the batch.
+	BeforeRun func() bool
+}
+
+// RunBatchUninstall presents a fuzzy finder over the given applications
+// (type to filter, sort by name, size or date, with a details pane),
 // confirms the selection, and executes uninstalls with progress feedback.
-// In dryRun mode, operations are listed but not executed.
-func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
+// In dry-run mode, operations are listed but not executed. Apps matched by
+// the protection list are shown locked and are never uninstalled. Leftovers
+// of the removed apps are offered for cleanup afterwards.
+func RunBatchUninstall(apps []InstalledApp, opts BatchOptions) error {
+	protect := opts.Protect
 	if len(apps) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications found."))
 		return nil
 	}
 
-	// 1. Convert to selector items.
-	items := make([]ui.SelectorItem, len(apps))
+	// 1. Convert to finder items.
+	items := make([]ui.FinderItem, len(apps))
 	for i, app := range apps {
 		desc := app.Publisher
 		if app.Version != "" {
@@ -26,19 +72,115 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 			}
 			desc += "v" + app.Version
 		}
+		if app.Source == SourceMSIX {
+			if desc != "" {
+				desc += " • "
+			}
+			desc += "MSIX package"
+		}
 
+		if app.ActualSize > 0 {
+			if desc != "" {
+				desc += " • "
+			}
+			desc += "registry: " + registrySizeLabel(app.EstimatedSize)
+		}
+
+		rule, locked := protect.Match(app)
+		if locked {
+			desc = fmt.Sprintf("Protected (rule %q)", rule)
+		}
+
+		installed, _ := app.InstalledOn()
+		items[i] = ui.FinderItem{
+			SelectorItem: ui.SelectorItem{
+				Label:       app.Name,
+				Description: desc,
+				Size:        formatAppSize(app.DisplaySize()),
+				Bytes:       app.DisplaySize(),
+				Disabled:    locked,
+			},
+			Date:    installed,
+			Details: appDetails(app),
+		}
+	}
+
+	// 2. Run the finder.
+	found, err := ui.RunFinder(items, "Select applications to uninstall", opts.Query)
+	if err != nil {
+		return fmt.Errorf("finder error: %w", err)
+	}
+	selected := make([]ui.SelectorItem, len(found))
+	for i, f := range found {
+		selected[i] = f.SelectorItem
+	}
+	return uninstallSelected(apps, selected, false, opts)
+}
+
+// appDetails describes app for the finder's details pane.
+func appDetails(app InstalledApp) []string {
+	var lines []string
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%-10s %s", label, value))
+		}
+	}
+	if installed, ok := app.InstalledOn(); ok {
+		add("Installed", installed.Format("2006-01-02"))
+	}
+	if app.ActualSize > 0 {
+		add("Size", fmt.Sprintf("%s on disk (registry: %s)", core.FormatSize(app.ActualSize), registrySizeLabel(app.EstimatedSize)))
+	} else {
+		add("Size", formatAppSize(app.EstimatedSize))
+	}
+	add("Location", app.InstallLocation)
+	add("Package", app.PackageFullName)
+	add("Uninstall", truncateLine(chooseUninstallCommand(app, false), 70))
+	return lines
+}
+
+// RunBloatwareUninstall lists the catalog matches grouped by category, with
+// the unprotected OEM utilities and trials preselected, and removes the chosen ones like RunBatchUninstall. Silent
+// uninstall commands are preferred so a debloat needs no clicking through
+// vendor wizards.
+func RunBloatwareUninstall(matches []BloatwareMatch, opts BatchOptions) error {
+	if len(matches) == 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s No known bloatware found.", ui.IconSuccess)))
+		return nil
+	}
+
+	apps := make([]InstalledApp, len(matches))
+	items := make([]ui.SelectorItem, len(matches))
+	for i, m := range matches {
+		apps[i] = m.App
+		desc := m.Entry.Reason
+		rule, locked := opts.Protect.Match(m.App)
+		if locked {
+			desc = fmt.Sprintf("Protected (rule %q)", rule)
+		}
 		items[i] = ui.SelectorItem{
-			Label:       app.Name,
+			Label:       m.App.Name,
 			Description: desc,
-			Size:        formatAppSize(app.EstimatedSize),
+			Size:        formatAppSize(m.App.EstimatedSize),
+			Bytes:       m.App.EstimatedSize,
+			Category:    m.Entry.Category,
+			Selected:    !locked && m.Entry.Category == BloatOEM,
+			Disabled:    locked,
 		}
 	}
 
-	// 2. Run the selector.
-	selected, err := ui.RunSelector(items, "Select applications to uninstall")
+	selected, err := ui.RunSelector(items, "Select bloatware to remove")
 	if err != nil {
 		return fmt.Errorf("selector error: %w", err)
 	}
+	return uninstallSelected(apps, selected, true, opts)
+}
+
+// uninstallSelected confirms and uninstalls the apps behind the selected
+// items (matched by Label), then offers their leftovers for cleanup. quiet
+// prefers silent uninstall commands.
+func uninstallSelected(apps []InstalledApp, selected []ui.SelectorItem, quiet bool, opts BatchOptions) error {
 	if len(selected) == 0 {
 		fmt.Println(ui.MutedStyle().Render("  No applications selected."))
 		return nil
@@ -53,15 +195,19 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 		fmt.Sprintf("  %d application(s) selected for removal:", len(selectedApps))))
 	for _, app := range selectedApps {
 		sizeStr := ""
-		if app.EstimatedSize > 0 {
-			sizeStr = " (" + core.FormatSize(app.EstimatedSize) + ")"
+		if size := app.DisplaySize(); size > 0 {
+			sizeStr = " (" + core.FormatSize(size) + ")"
 		}
 		fmt.Printf("  %s %s%s\n", ui.IconBullet, app.Name, sizeStr)
 	}
 	fmt.Println()
 
+	if opts.Footprint {
+		showFootprints(selectedApps)
+	}
+
 	// 5. Dry-run: report only.
-	if dryRun {
+	if opts.DryRun {
 		fmt.Println(ui.WarningStyle().Render(
 			"  DRY RUN — no applications will be uninstalled."))
 		return nil
@@ -77,21 +223,46 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 		return nil
 	}
 
-	// 7. Execute uninstalls with progress.
+	if opts.BeforeRun != nil && !opts.BeforeRun() {
+		fmt.Println(ui.MutedStyle().Render("  Cancelled."))
+		return nil
+	}
+
+	// 7. Execute uninstalls with progress. Ctrl+C kills the running
+	// uninstaller and skips the rest.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	fmt.Println()
-	var successes, failures int
+	fmt.Println(ui.MutedStyle().Render("  Press Ctrl+C to cancel."))
+	var successes, failures, skipped int
+	var removed []InstalledApp
+	var history []HistoryEntry
 
-	for _, app := range selectedApps {
-		spin := ui.NewInlineSpinner()
-		spin.Start(fmt.Sprintf("Uninstalling %s...", app.Name))
+	for i, app := range selectedApps {
+		if ctx.Err() != nil {
+			skipped = len(selectedApps) - i
+			break
+		}
+
+		// Re-check at execution time so no path can bypass protection.
+		if protectErr := opts.Protect.Check(app); protectErr != nil {
+			fmt.Println(ui.WarningStyle().Render(
+				fmt.Sprintf("  %s Refusing to uninstall: %s", ui.IconWarning, protectErr)))
+			failures++
+			continue
+		}
 
-		uninstErr := UninstallApp(app, false)
-		if uninstErr != nil {
-			spin.StopWithError(fmt.Sprintf("Failed to uninstall %s: %s", app.Name, uninstErr))
+		uninstErr := UninstallWithSpinner(ctx, app, RunOptions{Quiet: quiet, Timeout: opts.Timeout, MSILogDir: opts.MSILogDir})
+		history = append(history, NewHistoryEntry(app, uninstErr, time.Now()))
+		switch {
+		case errors.Is(uninstErr, ErrCancelled):
+			skipped = len(selectedApps) - i
+		case uninstErr != nil:
 			failures++
-		} else {
-			spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
+		default:
 			successes++
+			removed = append(removed, app)
 		}
 	}
 
@@ -106,8 +277,148 @@ func RunBatchUninstall(apps []InstalledApp, dryRun bool) error {
 		fmt.Println(ui.ErrorStyle().Render(
 			fmt.Sprintf("  %s %d application(s) failed to uninstall", ui.IconError, failures)))
 	}
+	if skipped > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s Cancelled; %d application(s) not uninstalled", ui.IconWarning, skipped)))
+	}
+
+	cleaned, err := CleanLeftovers(removed, opts.BackupDir)
+	if opts.HistoryDir != "" {
+		for _, e := range history {
+			e.Leftovers = cleaned[e.Name]
+			_ = RecordUninstall(opts.HistoryDir, e)
+		}
+	}
+	return err
+}
 
-	return nil
+// showFootprints scans and prints the footprint of each app.
+func showFootprints(apps []InstalledApp) {
+	for _, app := range apps {
+		spin := ui.NewInlineSpinner()
+		spin.Start(fmt.Sprintf("Measuring footprint of %s...", app.Name))
+		fp := ScanFootprint(app)
+		spin.Stop("Footprint")
+		PrintFootprint(app, fp)
+	}
+}
+
+// UninstallWithSpinner uninstalls app behind a spinner that shows the
+// uninstaller's latest output line, and reports the outcome.
+func UninstallWithSpinner(ctx context.Context, app InstalledApp, opts RunOptions) error {
+	msg := fmt.Sprintf("Uninstalling %s...", app.Name)
+	spin := ui.NewInlineSpinner()
+	spin.Start(msg)
+
+	opts.Output = func(line string) {
+		spin.UpdateMessage(msg + " " + ui.MutedStyle().Render(truncateLine(line, 60)))
+	}
+	err := UninstallAppContext(ctx, app, opts)
+	switch {
+	case errors.Is(err, ErrCancelled):
+		spin.StopWithError(fmt.Sprintf("Cancelled uninstalling %s", app.Name))
+	case err != nil:
+		spin.StopWithError(fmt.Sprintf("Failed to uninstall %s: %s", app.Name, err))
+	default:
+		spin.Stop(fmt.Sprintf("Uninstalled %s", app.Name))
+	}
+	return err
+}
+
+// truncateLine shortens s to at most n runes.
+func truncateLine(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// CleanLeftovers scans for what the uninstalled apps left behind, lets the
+// user pick what to remove in a checklist and removes it. Install folders,
+// orphaned shortcuts and uninstall keys are preselected; data folders and
+// settings keys are not, since they may be wanted for a reinstall. Install
+// folders another installed app still uses are not offered. The removed
+// leftovers are returned by app name.
+func CleanLeftovers(apps []InstalledApp, backupDir string) (map[string][]string, error) {
+	if len(apps) == 0 {
+		return nil, nil
+	}
+
+	fmt.Println()
+	spin := ui.NewInlineSpinner()
+	spin.Start("Looking for leftovers...")
+
+	// Compare against the apps still installed, so a folder shared with
+	// one of them is never offered.
+	installed, _ := GetInstalledApps(true)
+
+	var leftovers []Leftover
+	var owners []string
+	var items []ui.SelectorItem
+	for _, app := range apps {
+		spin.UpdateMessage(fmt.Sprintf("Looking for leftovers of %s...", app.Name))
+		for _, l := range FindLeftovers(app, installed) {
+			items = append(items, ui.SelectorItem{
+				Label:       l.Path,
+				Description: leftoverLabel(l),
+				Value:       strconv.Itoa(len(leftovers)),
+				Size:        formatAppSize(l.Size),
+				Bytes:       l.Size,
+				Selected:    l.Likely,
+				Category:    app.Name,
+			})
+			leftovers = append(leftovers, l)
+			owners = append(owners, app.Name)
+		}
+	}
+
+	if len(leftovers) == 0 {
+		spin.Stop("No leftovers found")
+		return nil, nil
+	}
+	spin.Stop(fmt.Sprintf("Found %d leftovers", len(leftovers)))
+
+	selected, err := ui.RunSelector(items, "Select leftovers to remove")
+	if err != nil {
+		return nil, fmt.Errorf("selector error: %w", err)
+	}
+	if len(selected) == 0 {
+		fmt.Println(ui.MutedStyle().Render("  Leftovers kept."))
+		return nil, nil
+	}
+
+	fmt.Println()
+	cleaned := make(map[string][]string)
+	var freed int64
+	var removed, failed int
+	for _, item := range selected {
+		idx, _ := strconv.Atoi(item.Value)
+		l := leftovers[idx]
+
+		n, rmErr := RemoveLeftover(l, backupDir, false)
+		if rmErr != nil {
+			failed++
+			fmt.Println(ui.ErrorStyle().Render(
+				fmt.Sprintf("  %s %s: %v", ui.IconError, l.Path, rmErr)))
+			continue
+		}
+		removed++
+		freed += n
+		cleaned[owners[idx]] = append(cleaned[owners[idx]], l.Path)
+		fmt.Printf("  %s %s\n", ui.SuccessStyle().Render(ui.IconSuccess), l.Path)
+	}
+
+	fmt.Println()
+	if removed > 0 {
+		fmt.Println(ui.SuccessStyle().Render(
+			fmt.Sprintf("  %s Removed %d leftovers, freed %s", ui.IconSuccess, removed, core.FormatSize(freed))))
+	}
+	if failed > 0 {
+		fmt.Println(ui.WarningStyle().Render(
+			fmt.Sprintf("  %s %d leftovers could not be removed (machine-wide ones need admin)", ui.IconWarning, failed)))
+	}
+	return cleaned, nil
 }
 
 // mapSelectedApps maps selected SelectorItems back to InstalledApp entries
@@ -127,6 +438,15 @@ func mapSelectedApps(apps []InstalledApp, selected []ui.SelectorItem) []Installe
 	return result
 }
 
+// registrySizeLabel describes the registry's size estimate next to a
+// measured size.
+func registrySizeLabel(bytes int64) string {
+	if bytes <= 0 {
+		return "no size"
+	}
+	return core.FormatSize(bytes)
+}
+
 // formatAppSize returns a human-readable size string for display.
 func formatAppSize(bytes int64) string {
 	if bytes <= 0 {
@@ -0,0 +1,176 @@
+package uninstall
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Bloatware Catalog ───────────────────────────────────────────────────────
+// A curated list of apps that ship preinstalled on new PCs and that most
+// people never use: OEM utilities and trials, sponsored Store titles and
+// the consumer Store apps bundled with Windows. Entries are conservative —
+// drivers, firmware updaters, security software and anything needed for
+// hardware to work are never listed, nor are apps people commonly use,
+// such as Media Player, Phone Link, Teams or the Xbox app. The protection
+// list still applies, so trial antivirus is shown locked until the user
+// removes its rule. Only OEM utilities and trials are preselected for
+// removal; sponsored and Store apps must be picked one by one.
+
+// Bloatware categories.
+const (
+	BloatOEM      = "OEM utilities and trials"
+	BloatSponsor  = "Sponsored apps and games"
+	BloatStoreApp = "Preinstalled Store apps"
+)
+
+// packagePrefix marks a catalog pattern that matches an MSIX package name
+// (e.g. king.com.CandyCrushSaga) instead of the display name.
+const packagePrefix = "package:"
+
+// BloatwareEntry is one catalog rule.
+type BloatwareEntry struct {
+	// Pattern matches the display name with * and ? wildcards, or the
+	// package name when prefixed with "package:".
+	Pattern  string `json:"pattern"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// bloatwareCatalog is the built-in list of known bloatware.
+var bloatwareCatalog = []BloatwareEntry{
+	// OEM utilities and trials.
+	{"McAfee LiveSafe*", BloatOEM, "Preinstalled antivirus trial"},
+	{"McAfee WebAdvisor*", BloatOEM, "Browser add-on bundled with trials"},
+	{"Norton Security*", BloatOEM, "Preinstalled antivirus trial"},
+	{"Norton 360*", BloatOEM, "Preinstalled antivirus trial"},
+	{"ExpressVPN*", BloatOEM, "Preinstalled VPN trial"},
+	{"Dropbox Promotion*", BloatOEM, "Storage offer"},
+	{"Booking.com*", BloatOEM, "Sponsored shortcut"},
+	{"WildTangent*", BloatOEM, "Preinstalled games portal"},
+	{"HP JumpStart*", BloatOEM, "OEM onboarding app"},
+	{"HP Documentation*", BloatOEM, "OEM manuals viewer"},
+	{"package:AD2F1837.HPJumpStarts", BloatOEM, "OEM onboarding app"},
+	{"package:AD2F1837.HPPrivacySettings", BloatOEM, "OEM privacy prompts"},
+	{"package:AD2F1837.HPSupportAssistant", BloatOEM, "OEM support app"},
+	{"Dell Customer Connect*", BloatOEM, "OEM survey app"},
+	{"Dell Digital Delivery*", BloatOEM, "OEM software delivery"},
+	{"Dell Update for Windows 10 S*", BloatOEM, "Duplicate OEM updater"},
+	{"package:DellInc.DellCustomerConnect", BloatOEM, "OEM survey app"},
+	{"package:DellInc.PartnerPromo", BloatOEM, "OEM promotions"},
+	{"Lenovo Welcome*", BloatOEM, "OEM onboarding app"},
+	{"package:E046963F.LenovoCompanion", BloatOEM, "OEM companion app"},
+	{"package:E0469640.LenovoUtility", BloatOEM, "OEM utility"},
+	{"Acer Collection*", BloatOEM, "OEM app store"},
+	{"Acer Jumpstart*", BloatOEM, "OEM onboarding app"},
+	{"package:AcerIncorporated.AcerCollectionS", BloatOEM, "OEM app store"},
+	{"ASUS GiftBox*", BloatOEM, "OEM app store"},
+	{"package:B9ECED6F.ASUSGIFTBOX", BloatOEM, "OEM app store"},
+	{"CyberLink Power2Go*", BloatOEM, "Bundled disc-burning trial"},
+	{"CyberLink PowerDVD*", BloatOEM, "Bundled player trial"},
+	{"CyberLink Media Suite*", BloatOEM, "Bundled media suite trial"},
+
+	// Sponsored apps and games.
+	{"package:king.com.*", BloatSponsor, "Sponsored game (Candy Crush and similar)"},
+	{"package:*.BubbleWitch3Saga", BloatSponsor, "Sponsored game"},
+	{"package:*.MarchofEmpires", BloatSponsor, "Sponsored game"},
+	{"package:*.HiddenCity*", BloatSponsor, "Sponsored game"},
+	{"package:*.Disney*", BloatSponsor, "Sponsored app"},
+	{"package:*.Facebook", BloatSponsor, "Sponsored app"},
+	{"package:*.Instagram", BloatSponsor, "Sponsored app"},
+	{"package:*.Twitter", BloatSponsor, "Sponsored app"},
+	{"package:*.TikTok", BloatSponsor, "Sponsored app"},
+	{"package:AmazonVideo.PrimeVideo", BloatSponsor, "Sponsored app"},
+	{"package:*.PicsArt-PhotoStudio", BloatSponsor, "Sponsored app"},
+	{"package:*.AdobePhotoshopExpress", BloatSponsor, "Sponsored app"},
+	{"package:*.Duolingo*", BloatSponsor, "Sponsored app"},
+	{"package:*.WinZipUniversal", BloatSponsor, "Sponsored trial"},
+	{"package:*.LinkedInforWindows", BloatSponsor, "Sponsored app"},
+	{"package:Microsoft.MicrosoftSolitaireCollection", BloatSponsor, "Ad-supported game"},
+
+	// Preinstalled Store apps.
+	{"package:Microsoft.BingNews", BloatStoreApp, "News feed"},
+	{"package:Microsoft.BingWeather", BloatStoreApp, "Weather app"},
+	{"package:Microsoft.BingFinance", BloatStoreApp, "Finance feed"},
+	{"package:Microsoft.BingSports", BloatStoreApp, "Sports feed"},
+	{"package:Microsoft.GetHelp", BloatStoreApp, "Support app"},
+	{"package:Microsoft.Getstarted", BloatStoreApp, "Tips app"},
+	{"package:Microsoft.Microsoft3DViewer", BloatStoreApp, "3D viewer"},
+	{"package:Microsoft.MixedReality.Portal", BloatStoreApp, "Mixed reality portal"},
+	{"package:Microsoft.Office.OneNote", BloatStoreApp, "Store OneNote (superseded)"},
+	{"package:Microsoft.MicrosoftOfficeHub", BloatStoreApp, "Office upsell app"},
+	{"package:Microsoft.OneConnect", BloatStoreApp, "Mobile plans"},
+	{"package:Microsoft.People", BloatStoreApp, "People app"},
+	{"package:Microsoft.SkypeApp", BloatStoreApp, "Store Skype (discontinued)"},
+	{"package:Microsoft.Wallet", BloatStoreApp, "Wallet"},
+	{"package:Microsoft.WindowsFeedbackHub", BloatStoreApp, "Feedback Hub"},
+	{"package:Microsoft.WindowsMaps", BloatStoreApp, "Maps"},
+	{"package:Microsoft.ZuneVideo", BloatStoreApp, "Movies & TV"},
+	{"package:Microsoft.Messaging", BloatStoreApp, "Messaging"},
+	{"package:Microsoft.Print3D", BloatStoreApp, "Print 3D"},
+	{"package:Microsoft.MSPaint", BloatStoreApp, "Paint 3D"},
+	{"package:Clipchamp.Clipchamp", BloatStoreApp, "Video editor upsell"},
+	{"package:Microsoft.XboxApp", BloatStoreApp, "Xbox Console Companion"},
+}
+
+// BloatwareMatch is an installed app found in the catalog.
+type BloatwareMatch struct {
+	App   InstalledApp   `json:"app"`
+	Entry BloatwareEntry `json:"entry"`
+}
+
+// MatchBloatware returns the catalog entry that flags app, if any.
+func MatchBloatware(app InstalledApp) (BloatwareEntry, bool) {
+	name := strings.ToLower(app.Name)
+	var pkgName string
+	if pkg, ok := parsePackageFullName(app.PackageFullName); ok {
+		pkgName = strings.ToLower(pkg.Name)
+	}
+
+	for _, e := range bloatwareCatalog {
+		lower := strings.ToLower(e.Pattern)
+		if strings.HasPrefix(lower, packagePrefix) {
+			if pkgName != "" && matchWildcard(lower[len(packagePrefix):], pkgName) {
+				return e, true
+			}
+			continue
+		}
+		if matchWildcard(lower, name) {
+			return e, true
+		}
+	}
+	return BloatwareEntry{}, false
+}
+
+// FindBloatware returns the installed apps flagged by the catalog. Apps
+// without a registered size are measured from their install folder.
+func FindBloatware() ([]BloatwareMatch, error) {
+	apps, err := GetInstalledApps(true)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []BloatwareMatch
+	for _, app := range apps {
+		entry, ok := MatchBloatware(app)
+		if !ok {
+			continue
+		}
+		if app.EstimatedSize == 0 && app.InstallLocation != "" {
+			app.EstimatedSize, _ = core.GetDirSize(app.InstallLocation)
+		}
+		matches = append(matches, BloatwareMatch{App: app, Entry: entry})
+	}
+
+	// Group by category for the selector, largest first within each.
+	order := map[string]int{BloatOEM: 0, BloatSponsor: 1, BloatStoreApp: 2}
+	sort.SliceStable(matches, func(i, j int) bool {
+		ci, cj := order[matches[i].Entry.Category], order[matches[j].Entry.Category]
+		if ci != cj {
+			return ci < cj
+		}
+		return matches[i].App.EstimatedSize > matches[j].App.EstimatedSize
+	})
+	return matches, nil
+}
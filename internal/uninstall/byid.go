@@ -0,0 +1,82 @@
+package uninstall
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ─── Uninstall by Id ─────────────────────────────────────────────────────────
+// Scripts and fleet tooling name apps by Id, "Name|Version" — the same key
+// GetInstalledApps deduplicates on — so the right version is removed even
+// when several are installed. The version may be left out when only one
+// version of the app is installed.
+
+// ErrAppNotFound is returned when no installed app matches an Id.
+var ErrAppNotFound = errors.New("application not installed")
+
+// ID returns the app's identifier, "Name|Version".
+func (a InstalledApp) ID() string {
+	return a.Name + "|" + a.Version
+}
+
+// FindByID returns the app with the given Id, compared case-insensitively.
+// An Id without a version ("Name" or "Name|") matches by name and is an
+// error when more than one version is installed.
+func FindByID(apps []InstalledApp, id string) (InstalledApp, error) {
+	name, version, hasVersion := strings.Cut(strings.TrimSpace(id), "|")
+	name, version = strings.TrimSpace(name), strings.TrimSpace(version)
+	if name == "" {
+		return InstalledApp{}, fmt.Errorf("invalid app id %q: name is empty", id)
+	}
+
+	var matches []InstalledApp
+	for _, app := range apps {
+		if !strings.EqualFold(app.Name, name) {
+			continue
+		}
+		if hasVersion && version != "" && !strings.EqualFold(app.Version, version) {
+			continue
+		}
+		matches = append(matches, app)
+	}
+
+	switch len(matches) {
+	case 0:
+		return InstalledApp{}, fmt.Errorf("%w: %s", ErrAppNotFound, id)
+	case 1:
+		return matches[0], nil
+	}
+	versions := make([]string, len(matches))
+	for i, m := range matches {
+		versions[i] = m.Version
+	}
+	return InstalledApp{}, fmt.Errorf("%q matches %d installed versions (%s); give the full Id as Name|Version",
+		id, len(matches), strings.Join(versions, ", "))
+}
+
+// ReadIDFile reads app Ids from path, one per line. Blank lines and lines
+// starting with # are ignored.
+func ReadIDFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read app list: %w", err)
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading app list %s: %w", path, err)
+	}
+	return ids, nil
+}
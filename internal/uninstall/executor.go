@@ -1,42 +1,104 @@
 package uninstall
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 )
 
 const (
-	// uninstallTimeout is the maximum time to wait for an uninstall process.
-	uninstallTimeout = 120 * time.Second
+	// DefaultUninstallTimeout is the maximum time to wait for an uninstall
+	// process unless RunOptions.Timeout says otherwise.
+	DefaultUninstallTimeout = 120 * time.Second
+
+	// killWaitDelay bounds how long Wait blocks on output pipes after the
+	// process tree was killed.
+	killWaitDelay = 5 * time.Second
 )
 
 // msiGUIDPattern matches MSI product GUIDs like {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}.
 var msiGUIDPattern = regexp.MustCompile(`\{[0-9A-Fa-f-]+\}`)
 
+// ErrCancelled is returned when an uninstall is cancelled by the caller.
+var ErrCancelled = errors.New("uninstall cancelled")
+
+// RunOptions controls how an uninstaller is run.
+type RunOptions struct {
+	// Quiet prefers the QuietUninstallString and silent msiexec flags.
+	Quiet bool
+
+	// Timeout kills the uninstaller after this long; zero means
+	// DefaultUninstallTimeout.
+	Timeout time.Duration
+
+	// Output, if non-nil, receives each line the uninstaller writes to
+	// stdout or stderr as it runs.
+	Output func(line string)
+
+	// MSILogDir, if set, makes msiexec uninstalls write a verbose log
+	// (/L*V) to a per-app file in this directory. The path is included in
+	// the error when the uninstall fails.
+	MSILogDir string
+}
+
 // ─── Public API ──────────────────────────────────────────────────────────────
 
 // UninstallApp executes the uninstall command for the given application.
 // If quiet is true and a QuietUninstallString is available, it is preferred.
 // The process is given a 120-second timeout.
 func UninstallApp(app InstalledApp, quiet bool) error {
-	cmdStr := chooseUninstallCommand(app, quiet)
+	return UninstallAppContext(context.Background(), app, RunOptions{Quiet: quiet})
+}
+
+// UninstallAppContext is UninstallApp with live output, a custom timeout
+// and cancellation: when ctx is cancelled the uninstaller and the
+// processes it started are killed and ErrCancelled is returned.
+func UninstallAppContext(ctx context.Context, app InstalledApp, opts RunOptions) error {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultUninstallTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Packaged apps have no uninstaller executable.
+	if app.PackageFullName != "" {
+		return runAppxUninstall(ctx, app.PackageFullName, opts, timeout)
+	}
+
+	cmdStr := chooseUninstallCommand(app, opts.Quiet)
 	if cmdStr == "" {
 		return fmt.Errorf("no uninstall command found for %q", app.Name)
 	}
 
 	// Detect MSI-based uninstalls and handle them specially.
 	if isMSIUninstall(cmdStr) {
-		return runMSIUninstall(cmdStr, quiet)
+		var logPath string
+		if opts.MSILogDir != "" {
+			logPath = msiLogPath(app.Name, opts.MSILogDir)
+			if err := os.MkdirAll(opts.MSILogDir, 0o755); err != nil {
+				return fmt.Errorf("create MSI log directory: %w", err)
+			}
+		}
+		err := runMSIUninstall(ctx, cmdStr, logPath, opts, timeout)
+		if err != nil && logPath != "" && !errors.Is(err, ErrCancelled) {
+			return fmt.Errorf("%w (MSI log: %s)", err, logPath)
+		}
+		return err
 	}
 
-	return runUninstallCommand(cmdStr)
+	return runUninstallCommand(ctx, cmdStr, opts, timeout)
 }
 
 // ─── Internal Helpers ────────────────────────────────────────────────────────
@@ -54,28 +116,30 @@ func isMSIUninstall(cmd string) bool {
 	return strings.Contains(strings.ToLower(cmd), "msiexec")
 }
 
-// runMSIUninstall extracts the GUID and runs msiexec with proper flags.
-func runMSIUninstall(cmdStr string, quiet bool) error {
+// msiLogPath returns a log file in dir named after the app and the time.
+func msiLogPath(name, dir string) string {
+	name = unsafeFileChars.ReplaceAllString(name, "_")
+	return filepath.Join(dir, fmt.Sprintf("%s-%s.log", name, time.Now().Format("20060102-150405")))
+}
+
+// runMSIUninstall extracts the GUID and runs msiexec with proper flags,
+// logging verbosely to logPath when it is set.
+func runMSIUninstall(ctx context.Context, cmdStr, logPath string, opts RunOptions, timeout time.Duration) error {
 	guid := msiGUIDPattern.FindString(cmdStr)
 	if guid == "" {
 		// Fallback to running the raw command if we can't parse the GUID.
-		return runUninstallCommand(cmdStr)
+		return runUninstallCommand(ctx, cmdStr, opts, timeout)
 	}
 
 	args := []string{"/x", guid}
-	if quiet {
+	if opts.Quiet {
 		args = append(args, "/qn", "/norestart")
 	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), uninstallTimeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, "msiexec.exe", args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return handleExitError(err, output)
+	if logPath != "" {
+		args = append(args, "/L*V", logPath)
 	}
-	return nil
+
+	return runProcess(ctx, exec.CommandContext(ctx, "msiexec.exe", args...), opts, timeout)
 }
 
 // parseExePath extracts the executable path from an uninstall command string.
@@ -113,10 +177,7 @@ func parseExePath(cmdStr string) string {
 // It first attempts direct execution (without cmd.exe) to prevent shell
 // metacharacter injection (e.g., & | > < chaining). Only falls back to
 // cmd /C when the executable can't be resolved on disk.
-func runUninstallCommand(cmdStr string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), uninstallTimeout)
-	defer cancel()
-
+func runUninstallCommand(ctx context.Context, cmdStr string, opts RunOptions, timeout time.Duration) error {
 	// Attempt direct execution: parse the exe path and verify it exists.
 	// This prevents command injection because CreateProcess does not
 	// interpret shell metacharacters like & | > <.
@@ -127,54 +188,147 @@ func runUninstallCommand(cmdStr string) error {
 			cmd.SysProcAttr = &syscall.SysProcAttr{
 				CmdLine: cmdStr, // Pass the full command line verbatim.
 			}
-			output, err := cmd.CombinedOutput()
-			if err != nil {
-				return handleExitError(err, output)
-			}
-			return nil
+			return runProcess(ctx, cmd, opts, timeout)
 		}
 	}
 
 	// Fallback: use cmd /C for commands where the executable can't be
 	// resolved (e.g., PATH-relative executables). Most legitimate uninstall
 	// strings use absolute paths, so this path should be rare.
-	cmd := exec.CommandContext(ctx, "cmd.exe", "/C", cmdStr)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return handleExitError(err, output)
+	return runProcess(ctx, exec.CommandContext(ctx, "cmd.exe", "/C", cmdStr), opts, timeout)
+}
+
+// runProcess runs cmd, passing its combined output to opts.Output line by
+// line. When ctx ends, the whole process tree is killed: uninstallers
+// often hand off to a copy of themselves or to msiexec.
+func runProcess(ctx context.Context, cmd *exec.Cmd, opts RunOptions, timeout time.Duration) error {
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+	cmd.Cancel = func() error {
+		_ = exec.Command("taskkill.exe", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+		return cmd.Process.Kill()
+	}
+	cmd.WaitDelay = killWaitDelay
+
+	if err := cmd.Start(); err != nil {
+		pw.Close()
+		return fmt.Errorf("cannot start uninstaller: %w", err)
+	}
+
+	// Keep the last lines for error messages.
+	var tail []string
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(pr)
+		scanner.Split(splitLinesOrCR)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if opts.Output != nil {
+				opts.Output(line)
+			}
+			tail = append(tail, line)
+			if len(tail) > 5 {
+				tail = tail[1:]
+			}
+		}
+		_, _ = io.Copy(io.Discard, pr)
+	}()
+
+	err := cmd.Wait()
+	pw.Close()
+	<-done
+
+	if err == nil {
+		return nil
 	}
-	return nil
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("uninstall timed out after %s", timeout)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return ErrCancelled
+	}
+	return handleExitError(err, []byte(strings.Join(tail, " ")))
+}
+
+// splitLinesOrCR is a bufio.SplitFunc that ends tokens at \r or \n, so
+// progress lines redrawn in place are reported as they change.
+func splitLinesOrCR(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// msiExitMessages translates common Windows Installer exit codes, which
+// many non-MSI uninstallers pass through as well, into what to do next.
+var msiExitMessages = map[int]string{
+	1601: "Windows Installer service could not be accessed — check that the msiserver service is not disabled",
+	1602: "uninstall was cancelled in the uninstaller",
+	1603: "fatal error during uninstall — an app or service may be holding its files; close it and retry, or check the MSI log",
+	1605: "product is not currently installed",
+	1612: "installation source is unavailable — reconnect the drive or share it was installed from",
+	1618: "another installation is in progress — wait for it to finish and retry",
+	1619: "installation package could not be opened — the cached MSI may be missing",
+	1620: "installation package is invalid — the cached MSI may be corrupt",
+	1622: "could not write the MSI log file — check the log directory",
+	1625: "uninstall is blocked by system policy — ask an administrator",
+	1638: "another version of this product is installed",
+	1639: "invalid command-line arguments to msiexec",
+	1641: "uninstall succeeded — restart initiated",
+	3010: "uninstall succeeded — restart required",
+}
+
+// ExitCodeError is an uninstaller that exited with a non-zero code.
+type ExitCodeError struct {
+	Code int
+	msg  string
+}
+
+func (e *ExitCodeError) Error() string { return e.msg }
+
+// ExitCode returns the uninstaller's exit code for an error returned by
+// UninstallAppContext: 0 for nil, -1 when the uninstaller never exited
+// normally (not started, cancelled or timed out).
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var codeErr *ExitCodeError
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+	return -1
 }
 
 // handleExitError wraps an exec error with contextual information.
 // Common MSI exit codes are translated to human-readable messages.
 func handleExitError(err error, output []byte) error {
-	if errors.Is(err, context.DeadlineExceeded) {
-		return fmt.Errorf("uninstall timed out after %s", uninstallTimeout)
-	}
-
 	var exitErr *exec.ExitError
 	if errors.As(err, &exitErr) {
 		code := exitErr.ExitCode()
-		switch code {
-		case 1605:
-			return fmt.Errorf("product is not currently installed (exit code 1605)")
-		case 1641:
-			// Restart required but uninstall itself succeeded.
-			return fmt.Errorf("uninstall succeeded — restart required (exit code 1641)")
-		case 3010:
-			// Restart required but uninstall itself succeeded.
-			return fmt.Errorf("uninstall succeeded — restart required (exit code 3010)")
-		default:
+		var msg string
+		if known, ok := msiExitMessages[code]; ok {
+			msg = fmt.Sprintf("%s (exit code %d)", known, code)
+		} else {
 			outputStr := strings.TrimSpace(string(output))
 			if len(outputStr) > 200 {
 				outputStr = outputStr[:200] + "..."
 			}
 			if outputStr != "" {
-				return fmt.Errorf("uninstall failed (exit code %d): %s", code, outputStr)
+				msg = fmt.Sprintf("uninstall failed (exit code %d): %s", code, outputStr)
+			} else {
+				msg = fmt.Sprintf("uninstall failed (exit code %d)", code)
 			}
-			return fmt.Errorf("uninstall failed (exit code %d)", code)
 		}
+		return &ExitCodeError{Code: code, msg: msg}
 	}
 
 	return fmt.Errorf("uninstall command error: %w", err)
@@ -0,0 +1,95 @@
+package uninstall
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ─── App Filters ─────────────────────────────────────────────────────────────
+
+// AppFilter narrows a list of installed apps. Zero fields do not filter.
+type AppFilter struct {
+	// Publisher matches apps whose publisher contains it (case-insensitive).
+	Publisher string
+
+	// InstalledBefore and InstalledAfter bound the install date; apps
+	// without a recorded install date never match a date bound.
+	InstalledBefore time.Time
+	InstalledAfter  time.Time
+
+	// MinSize keeps apps at least this large (see DisplaySize).
+	MinSize int64
+}
+
+// IsZero reports whether f filters nothing.
+func (f AppFilter) IsZero() bool {
+	return f == AppFilter{}
+}
+
+// Apply returns the apps that pass every filter in f.
+func (f AppFilter) Apply(apps []InstalledApp) []InstalledApp {
+	if f.IsZero() {
+		return apps
+	}
+	publisher := strings.ToLower(f.Publisher)
+	dated := !f.InstalledBefore.IsZero() || !f.InstalledAfter.IsZero()
+
+	var filtered []InstalledApp
+	for _, app := range apps {
+		if publisher != "" && !strings.Contains(strings.ToLower(app.Publisher), publisher) {
+			continue
+		}
+		if dated {
+			installed, ok := app.InstalledOn()
+			if !ok {
+				continue
+			}
+			if !f.InstalledBefore.IsZero() && !installed.Before(f.InstalledBefore) {
+				continue
+			}
+			if !f.InstalledAfter.IsZero() && installed.Before(f.InstalledAfter) {
+				continue
+			}
+		}
+		if f.MinSize > 0 && app.DisplaySize() < f.MinSize {
+			continue
+		}
+		filtered = append(filtered, app)
+	}
+	return filtered
+}
+
+// InstalledOn parses the registry's InstallDate (YYYYMMDD).
+func (app InstalledApp) InstalledOn() (time.Time, bool) {
+	t, err := time.ParseInLocation("20060102", strings.TrimSpace(app.InstallDate), time.Local)
+	return t, err == nil
+}
+
+// ParseDateBound parses a date given as YYYY, YYYY-MM or YYYY-MM-DD into
+// the start of that period, or the start of the next one when end is true.
+// Both bounds are exclusive of the period named: "before 2022" ends on
+// 2021-12-31 and "after 2022" starts on 2023-01-01.
+func ParseDateBound(s string, end bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []struct {
+		format string
+		years  int
+		months int
+		days   int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	} {
+		t, err := time.ParseInLocation(layout.format, s, time.Local)
+		if err != nil {
+			continue
+		}
+		if end {
+			t = t.AddDate(layout.years, layout.months, layout.days)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (want YYYY, YYYY-MM or YYYY-MM-DD)", s)
+}
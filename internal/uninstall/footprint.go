@@ -0,0 +1,208 @@
+package uninstall
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+	"github.com/cy-infamous/purewin/internal/envutil"
+	"github.com/cy-infamous/purewin/internal/ui"
+)
+
+// ─── App Footprint ───────────────────────────────────────────────────────────
+// Everything an installed app owns beyond its entry in Apps & Features: the
+// install folder, data folders in AppData and ProgramData, settings keys,
+// and the services and scheduled tasks that run its executables. Shown
+// before an uninstall so it is clear what the uninstaller is expected to
+// remove — and what will be left for the leftover scan.
+
+// servicesPath is where Windows services are registered.
+const servicesPath = `SYSTEM\CurrentControlSet\Services`
+
+// FootprintDir is a folder owned by an app.
+type FootprintDir struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// FootprintKey is a settings key owned by an app, with the number of keys
+// in its tree (itself included).
+type FootprintKey struct {
+	Path    string `json:"path"`
+	SubKeys int    `json:"subkeys"`
+}
+
+// Footprint is what an installed app occupies on the machine.
+type Footprint struct {
+	InstallDir  string         `json:"install_dir,omitempty"`
+	InstallSize int64          `json:"install_size"`
+	DataDirs    []FootprintDir `json:"data_dirs,omitempty"`
+	DataSize    int64          `json:"data_size"`
+	Registry    []FootprintKey `json:"registry,omitempty"`
+	Services    []string       `json:"services,omitempty"`
+	Tasks       []string       `json:"tasks,omitempty"`
+}
+
+// TotalSize is the install folder plus the data folders.
+func (f Footprint) TotalSize() int64 {
+	return f.InstallSize + f.DataSize
+}
+
+// RegistryKeyCount is the number of keys across all settings key trees.
+func (f Footprint) RegistryKeyCount() int {
+	var n int
+	for _, k := range f.Registry {
+		n += k.SubKeys
+	}
+	return n
+}
+
+// ScanFootprint measures what app occupies. Data folders and settings keys
+// are found by name like FindLeftovers; services and scheduled tasks are
+// the ones whose executable lives in the install folder.
+func ScanFootprint(app InstalledApp) Footprint {
+	var fp Footprint
+	names := appNameKeys(app)
+	publisher := normalizeName(publisherSuffix.ReplaceAllString(strings.TrimSpace(app.Publisher), ""))
+	if reservedNames[publisher] {
+		publisher = ""
+	}
+
+	if dir := measurableDir(app.InstallLocation); dir != "" {
+		fp.InstallDir = dir
+		fp.InstallSize, _ = core.GetDirSize(dir)
+	}
+
+	for _, root := range dataRoots() {
+		for _, dir := range matchDirs(root, names, publisher) {
+			if fp.InstallDir != "" && isUnder(dir, fp.InstallDir) {
+				continue
+			}
+			size, _ := core.GetDirSize(dir)
+			fp.DataDirs = append(fp.DataDirs, FootprintDir{Path: dir, Size: size})
+			fp.DataSize += size
+		}
+	}
+
+	for _, key := range matchSoftwareKeys(names, publisher) {
+		fp.Registry = append(fp.Registry, FootprintKey{Path: key, SubKeys: countKeyTree(key)})
+	}
+
+	if fp.InstallDir != "" {
+		for _, p := range servicesUnder(fp.InstallDir) {
+			fp.Services = append(fp.Services, p.Name)
+		}
+		for _, p := range tasksUnder(fp.InstallDir) {
+			fp.Tasks = append(fp.Tasks, p.Name)
+		}
+	}
+	return fp
+}
+
+// PrintFootprint shows fp as an indented breakdown under the app's name.
+func PrintFootprint(app InstalledApp, fp Footprint) {
+	fmt.Println(ui.HeaderStyle().Render(fmt.Sprintf("  %s — %s", app.Name, core.FormatSize(fp.TotalSize()))))
+
+	row := func(label, value string) {
+		fmt.Printf("    %-14s %s\n", label, value)
+	}
+	if fp.InstallDir != "" {
+		row("Install", fmt.Sprintf("%s  %s", core.FormatSize(fp.InstallSize), ui.MutedStyle().Render(fp.InstallDir)))
+	} else {
+		row("Install", ui.MutedStyle().Render("no install folder recorded"))
+	}
+	for _, d := range fp.DataDirs {
+		row("Data", fmt.Sprintf("%s  %s", core.FormatSize(d.Size), ui.MutedStyle().Render(d.Path)))
+	}
+	for _, k := range fp.Registry {
+		row("Registry", fmt.Sprintf("%d keys  %s", k.SubKeys, ui.MutedStyle().Render(k.Path)))
+	}
+	if len(fp.Services) > 0 {
+		row("Services", strings.Join(fp.Services, ", "))
+	}
+	if len(fp.Tasks) > 0 {
+		row("Tasks", strings.Join(fp.Tasks, ", "))
+	}
+	fmt.Println()
+}
+
+// countKeyTree returns the number of keys in the tree at a key such as
+// `HKCU\SOFTWARE\Vendor\App`, itself included; 0 if it cannot be opened.
+func countKeyTree(full string) int {
+	rootName, path, ok := strings.Cut(full, `\`)
+	if !ok {
+		return 0
+	}
+	var root registry.Key
+	switch rootName {
+	case "HKLM":
+		root = registry.LOCAL_MACHINE
+	case "HKCU":
+		root = registry.CURRENT_USER
+	default:
+		return 0
+	}
+
+	var count func(path string, depth int) int
+	count = func(path string, depth int) int {
+		key, err := registry.OpenKey(root, path, registry.ENUMERATE_SUB_KEYS)
+		if err != nil {
+			return 0
+		}
+		names, _ := key.ReadSubKeyNames(-1)
+		key.Close()
+
+		n := 1
+		if depth < 16 {
+			for _, name := range names {
+				n += count(path+`\`+name, depth+1)
+			}
+		}
+		return n
+	}
+	return count(path, 0)
+}
+
+// ownedProgram is a service or scheduled task and the program it runs.
+type ownedProgram struct {
+	Name string
+	Exe  string
+}
+
+// servicesUnder returns the services whose executable is in dir.
+func servicesUnder(dir string) []ownedProgram {
+	var found []ownedProgram
+	for _, name := range subKeyNames(registry.LOCAL_MACHINE, servicesPath) {
+		key, err := registry.OpenKey(registry.LOCAL_MACHINE, servicesPath+`\`+name, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		image, _, _ := key.GetStringValue("ImagePath")
+		key.Close()
+
+		exe := parseExePath(envutil.ExpandWindowsEnv(image))
+		if exe != "" && isUnder(exe, dir) {
+			found = append(found, ownedProgram{Name: name, Exe: exe})
+		}
+	}
+	return found
+}
+
+// tasksUnder returns the scheduled tasks whose action runs a program in
+// dir. Without admin rights, tasks the user cannot read are missed.
+func tasksUnder(dir string) []ownedProgram {
+	tasks, err := core.ListAllScheduledTasks()
+	if err != nil {
+		return nil
+	}
+	var found []ownedProgram
+	for _, t := range tasks {
+		exe := parseExePath(envutil.ExpandWindowsEnv(t.Command))
+		if exe != "" && isUnder(exe, dir) {
+			found = append(found, ownedProgram{Name: t.Name, Exe: exe})
+		}
+	}
+	return found
+}
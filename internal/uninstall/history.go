@@ -0,0 +1,154 @@
+package uninstall
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryFileName is the JSON-lines file, in the config directory, that
+// holds one record per uninstall.
+const HistoryFileName = "uninstall_history.jsonl"
+
+// ─── Uninstall History ───────────────────────────────────────────────────────
+// Every uninstall PureWin runs is recorded with the app's metadata, the
+// uninstaller's exit code and the leftovers removed afterwards, so it is
+// possible to tell what was removed last week and where to get it back.
+
+// Uninstall outcomes recorded in the history.
+const (
+	HistoryUninstalled = "uninstalled"
+	HistoryFailed      = "failed"
+	HistoryCancelled   = "cancelled"
+)
+
+// HistoryEntry records one uninstall.
+type HistoryEntry struct {
+	Timestamp       time.Time `json:"timestamp"`
+	Name            string    `json:"name"`
+	Version         string    `json:"version,omitempty"`
+	Publisher       string    `json:"publisher,omitempty"`
+	Source          string    `json:"source,omitempty"`
+	InstallLocation string    `json:"install_location,omitempty"`
+	PackageFullName string    `json:"package_full_name,omitempty"`
+
+	// DownloadURL is where the app can be downloaded again, when known:
+	// the publisher's update or product page, or the Store listing.
+	DownloadURL string `json:"download_url,omitempty"`
+
+	Status   string `json:"status"`
+	ExitCode int    `json:"exit_code"` // -1 when the uninstaller never exited normally
+	Error    string `json:"error,omitempty"`
+
+	// Leftovers are the paths and keys removed after the uninstall.
+	Leftovers []string `json:"leftovers,omitempty"`
+}
+
+// NewHistoryEntry describes the outcome of uninstalling app, where err is
+// what UninstallAppContext returned.
+func NewHistoryEntry(app InstalledApp, err error, now time.Time) HistoryEntry {
+	e := HistoryEntry{
+		Timestamp:       now,
+		Name:            app.Name,
+		Version:         app.Version,
+		Publisher:       app.Publisher,
+		Source:          app.Source,
+		InstallLocation: app.InstallLocation,
+		PackageFullName: app.PackageFullName,
+		DownloadURL:     downloadURL(app),
+		Status:          HistoryUninstalled,
+		ExitCode:        ExitCode(err),
+	}
+	switch {
+	case errors.Is(err, ErrCancelled):
+		e.Status = HistoryCancelled
+		e.Error = err.Error()
+	case err != nil:
+		e.Status = HistoryFailed
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// downloadURL returns the best-known page to reinstall app from.
+func downloadURL(app InstalledApp) string {
+	if pkg, ok := parsePackageFullName(app.PackageFullName); ok {
+		return "ms-windows-store://pdp/?PFN=" + pkg.family()
+	}
+	for _, u := range []string{app.URLUpdateInfo, app.URLInfoAbout} {
+		lower := strings.ToLower(u)
+		if strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") {
+			return u
+		}
+	}
+	return ""
+}
+
+// RecordUninstall appends an entry to the history file in dir.
+func RecordUninstall(dir string, e HistoryEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, HistoryFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadHistory returns the entries in the history file in dir, newest
+// first. A missing file is an empty history; malformed lines are skipped.
+func LoadHistory(dir string) ([]HistoryEntry, error) {
+	f, err := os.Open(filepath.Join(dir, HistoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var e HistoryEntry
+		if json.Unmarshal(sc.Bytes(), &e) != nil || e.Name == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries, sc.Err()
+}
+
+// FilterHistory returns the entries recorded at or after since whose name
+// contains search (case-insensitive). A zero since or empty search does
+// not filter.
+func FilterHistory(entries []HistoryEntry, since time.Time, search string) []HistoryEntry {
+	lower := strings.ToLower(search)
+	var out []HistoryEntry
+	for _, e := range entries {
+		if !since.IsZero() && e.Timestamp.Before(since) {
+			continue
+		}
+		if lower != "" && !strings.Contains(strings.ToLower(e.Name), lower) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
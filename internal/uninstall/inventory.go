@@ -0,0 +1,152 @@
+package uninstall
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Inventory Export ────────────────────────────────────────────────────────
+// An asset inventory of the installed applications, written as JSON for
+// tooling, CSV for spreadsheets or a Markdown table for tickets and wikis.
+
+// Inventory formats.
+const (
+	InventoryJSON     = "json"
+	InventoryCSV      = "csv"
+	InventoryMarkdown = "markdown"
+)
+
+// InventoryRecord is one application in an inventory export.
+type InventoryRecord struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Publisher   string `json:"publisher"`
+	Size        int64  `json:"size"`
+	InstallDate string `json:"install_date"` // YYYY-MM-DD when known
+	Location    string `json:"location"`
+	Source      string `json:"source"`
+}
+
+// Inventory is the JSON form of an export.
+type Inventory struct {
+	Computer    string            `json:"computer"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Count       int               `json:"count"`
+	TotalSize   int64             `json:"total_size"`
+	Apps        []InventoryRecord `json:"apps"`
+}
+
+// InventoryFormat resolves the export format from the --format value or,
+// when that is empty, from the extension of path.
+func InventoryFormat(format, path string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch strings.ToLower(format) {
+	case "json":
+		return InventoryJSON, nil
+	case "csv":
+		return InventoryCSV, nil
+	case "md", "markdown":
+		return InventoryMarkdown, nil
+	case "":
+		return "", fmt.Errorf("cannot tell the export format from %q; use --format json, csv or markdown", path)
+	}
+	return "", fmt.Errorf("unsupported export format %q (use json, csv or markdown)", format)
+}
+
+// NewInventory builds an inventory of apps, sorted by name.
+func NewInventory(apps []InstalledApp, now time.Time) Inventory {
+	host, _ := os.Hostname()
+	inv := Inventory{Computer: host, GeneratedAt: now, Apps: make([]InventoryRecord, 0, len(apps))}
+	for _, app := range apps {
+		inv.Apps = append(inv.Apps, InventoryRecord{
+			ID:          app.ID(),
+			Name:        app.Name,
+			Version:     app.Version,
+			Publisher:   app.Publisher,
+			Size:        app.EstimatedSize,
+			InstallDate: formatInstallDate(app.InstallDate),
+			Location:    app.InstallLocation,
+			Source:      app.Source,
+		})
+		inv.TotalSize += app.EstimatedSize
+	}
+	sort.Slice(inv.Apps, func(i, j int) bool {
+		return strings.ToLower(inv.Apps[i].ID) < strings.ToLower(inv.Apps[j].ID)
+	})
+	inv.Count = len(inv.Apps)
+	return inv
+}
+
+// Write renders the inventory to w in the given format.
+func (inv Inventory) Write(w io.Writer, format string) error {
+	switch format {
+	case InventoryJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(inv)
+	case InventoryCSV:
+		return inv.writeCSV(w)
+	case InventoryMarkdown:
+		return inv.writeMarkdown(w)
+	}
+	return fmt.Errorf("unsupported export format %q", format)
+}
+
+// writeCSV writes one header row and one row per app. Sizes are in bytes.
+func (inv Inventory) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"name", "version", "publisher", "size_bytes", "install_date", "location", "source"})
+	for _, r := range inv.Apps {
+		_ = cw.Write([]string{r.Name, r.Version, r.Publisher, strconv.FormatInt(r.Size, 10),
+			r.InstallDate, r.Location, r.Source})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeMarkdown writes a heading, a summary line and a table.
+func (inv Inventory) writeMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Installed Applications — %s\n\n", inv.Computer)
+	fmt.Fprintf(&sb, "%d applications, %s, generated %s.\n\n",
+		inv.Count, core.FormatSize(inv.TotalSize), inv.GeneratedAt.Format("2006-01-02 15:04"))
+	sb.WriteString("| Name | Version | Publisher | Size | Installed | Location |\n|---|---|---|---:|---|---|\n")
+	for _, r := range inv.Apps {
+		size := ""
+		if r.Size > 0 {
+			size = core.FormatSize(r.Size)
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s | %s |\n",
+			mdCell(r.Name), mdCell(r.Version), mdCell(r.Publisher), size, r.InstallDate, mdCell(r.Location))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// mdCell escapes s for a Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// formatInstallDate turns the registry's YYYYMMDD InstallDate into
+// YYYY-MM-DD. Other values are returned as they are.
+func formatInstallDate(s string) string {
+	if t, err := time.Parse("20060102", s); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return s
+}
@@ -0,0 +1,533 @@
+package uninstall
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/sys/windows/registry"
+
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Leftover Detection ──────────────────────────────────────────────────────
+// Uninstallers routinely leave their install folder, per-user data,
+// Start Menu shortcuts and registry keys behind. After an app is removed,
+// FindLeftovers looks for these by the app's install location and by
+// folders and keys named after the app (or the app under its publisher).
+// Matching is by exact normalized name, never by substring, so a leftover
+// for "Foo" never picks up "FooBar".
+
+// serviceCommandTimeout bounds how long a leftover service may take to stop.
+const serviceCommandTimeout = 30 * time.Second
+
+// Kinds of leftover.
+const (
+	LeftoverInstallDir = "install folder"
+	LeftoverData       = "app data"
+	LeftoverShortcut   = "shortcut"
+	LeftoverRegistry   = "registry key"
+	LeftoverService    = "service"
+	LeftoverTask       = "scheduled task"
+)
+
+// Leftover is a file, folder or registry key left behind by an uninstalled
+// application.
+type Leftover struct {
+	Kind string `json:"kind"`
+
+	// Path is a file system path, a registry key such as
+	// `HKCU\Software\Vendor\App` for LeftoverRegistry, a service name for
+	// LeftoverService or a task path such as `Vendor\Update` for
+	// LeftoverTask.
+	Path string `json:"path"`
+
+	// Size is the size on disk; zero for registry keys.
+	Size int64 `json:"size"`
+
+	// Likely is true for leftovers that are almost certainly dead (the
+	// install folder, shortcuts to missing targets, the uninstall key).
+	// Settings and data folders may still be wanted for a reinstall.
+	Likely bool `json:"likely"`
+}
+
+// reservedNames are folder and key names that are never leftovers, even
+// when an app or publisher is named the same.
+var reservedNames = map[string]bool{
+	"microsoft": true, "windows": true, "packages": true, "programs": true,
+	"temp": true, "classes": true, "policies": true, "wow6432node": true,
+	"clients": true, "registeredapplications": true, "commonfiles": true,
+}
+
+// versionSuffix matches trailing versions, architectures and editions in
+// display names, e.g. " 2.4.1", " (x64)", " x64 en-US", " - 64-bit".
+var versionSuffix = regexp.MustCompile(`(?i)(\s*[-–(]?\s*(v?\d+(\.\d+)*|x64|x86|amd64|arm64|64-bit|32-bit|[a-z]{2}-[a-z]{2})\s*\)?)+$`)
+
+// publisherSuffix matches legal suffixes on publisher names.
+var publisherSuffix = regexp.MustCompile(`(?i)[,.]?\s+(inc|llc|ltd|gmbh|corp|corporation|co|limited|s\.?a|b\.?v|ag|pty)\.?$`)
+
+// FindLeftovers returns what app left behind after it was uninstalled.
+// Packaged (MSIX) apps are removed by Windows with their data, so none are
+// reported for them. installed are the apps still installed: an install
+// folder one of them shares, contains or lives in is not a leftover.
+func FindLeftovers(app InstalledApp, installed []InstalledApp) []Leftover {
+	if app.PackageFullName != "" {
+		return nil
+	}
+
+	names := appNameKeys(app)
+	publisher := normalizeName(publisherSuffix.ReplaceAllString(strings.TrimSpace(app.Publisher), ""))
+	if reservedNames[publisher] {
+		publisher = ""
+	}
+
+	var out []Leftover
+	seen := make(map[string]bool)
+	add := func(l Leftover) {
+		key := strings.ToLower(filepath.Clean(l.Path))
+		if seen[key] {
+			return
+		}
+		// Never report a folder inside one already reported.
+		for s := range seen {
+			if strings.HasPrefix(key, s+`\`) {
+				return
+			}
+		}
+		seen[key] = true
+		out = append(out, l)
+	}
+
+	installDir := measurableDir(app.InstallLocation)
+	shared := installDir != "" && sharedInstallDir(installDir, app, installed)
+	if installDir != "" && !shared {
+		add(dirLeftover(LeftoverInstallDir, installDir, true))
+	}
+
+	for _, root := range dataRoots() {
+		for _, dir := range matchDirs(root, names, publisher) {
+			add(dirLeftover(LeftoverData, dir, false))
+		}
+	}
+
+	for _, l := range findShortcuts(names, installDir) {
+		add(l)
+	}
+
+	if app.RegistryKey != "" && registryKeyExists(app.RegistryKey) {
+		add(Leftover{Kind: LeftoverRegistry, Path: app.RegistryKey, Likely: true})
+	}
+	for _, key := range matchSoftwareKeys(names, publisher) {
+		add(Leftover{Kind: LeftoverRegistry, Path: key})
+	}
+
+	// Services and scheduled tasks that run a program from the install
+	// folder fail on every boot once it is gone. They are not paths, so
+	// they skip the folder de-duplication above.
+	if dir := ownedDir(app.InstallLocation); dir != "" && !shared {
+		for _, p := range servicesUnder(dir) {
+			out = append(out, Leftover{Kind: LeftoverService, Path: p.Name, Likely: fileSize(p.Exe) == 0})
+		}
+		for _, p := range tasksUnder(dir) {
+			out = append(out, Leftover{Kind: LeftoverTask, Path: p.Name, Likely: fileSize(p.Exe) == 0})
+		}
+	}
+
+	return out
+}
+
+// RemoveLeftover deletes l and returns the bytes freed. Registry keys are
+// exported to a .reg file in backupDir first. In dryRun mode nothing is
+// changed.
+func RemoveLeftover(l Leftover, backupDir string, dryRun bool) (int64, error) {
+	switch l.Kind {
+	case LeftoverRegistry:
+		if dryRun {
+			return 0, nil
+		}
+		_, err := exportAndDeleteKey(l.Path, filepath.Base(l.Path), backupDir)
+		return 0, err
+	case LeftoverService:
+		if dryRun {
+			return 0, nil
+		}
+		return 0, core.DeleteService(l.Path, serviceCommandTimeout)
+	case LeftoverTask:
+		if dryRun {
+			return 0, nil
+		}
+		return 0, core.DeleteTaskByPath(l.Path)
+	}
+	return core.SafeDelete(l.Path, dryRun)
+}
+
+// ─── Name Matching ───────────────────────────────────────────────────────────
+
+// normalizeName lower-cases s and drops everything but letters and digits,
+// so "Notepad++", "notepad++ " and "NotePad++" compare equal.
+func normalizeName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// appNameKeys returns the normalized names a leftover of app may have: the
+// display name without version or architecture and that name without the
+// publisher in front ("Mozilla Firefox" → "firefox"). The install folder's
+// name is not used: generic names like "App" or "Bin" match unrelated
+// folders. Names shorter than three characters are dropped.
+func appNameKeys(app InstalledApp) map[string]bool {
+	base := strings.TrimSpace(versionSuffix.ReplaceAllString(app.Name, ""))
+	candidates := []string{app.Name, base}
+
+	if fields := strings.Fields(app.Publisher); len(fields) > 0 {
+		if rest, ok := cutPrefixFold(base, fields[0]+" "); ok {
+			candidates = append(candidates, rest)
+		}
+	}
+
+	keys := make(map[string]bool)
+	for _, c := range candidates {
+		if n := normalizeName(c); len(n) >= 3 && !reservedNames[n] {
+			keys[n] = true
+		}
+	}
+	return keys
+}
+
+// sharedInstallDir reports whether another installed app uses dir: its
+// install folder is dir, lies inside it or contains it.
+func sharedInstallDir(dir string, app InstalledApp, installed []InstalledApp) bool {
+	for _, other := range installed {
+		// The app's own entry lingers when its uninstall key was left behind.
+		if strings.EqualFold(other.Name, app.Name) ||
+			(other.RegistryKey != "" && strings.EqualFold(other.RegistryKey, app.RegistryKey)) {
+			continue
+		}
+		loc := ownedDir(other.InstallLocation)
+		if loc == "" {
+			continue
+		}
+		if isUnder(loc, dir) || isUnder(dir, loc) {
+			return true
+		}
+	}
+	return false
+}
+
+// cutPrefixFold is strings.CutPrefix with case-insensitive matching.
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// ─── File System ─────────────────────────────────────────────────────────────
+
+// dataRoots returns the folders where apps keep per-user and shared data.
+func dataRoots() []string {
+	var roots []string
+	for _, env := range []string{"APPDATA", "LOCALAPPDATA", "ProgramData"} {
+		if dir := os.Getenv(env); dir != "" {
+			roots = append(roots, dir)
+		}
+	}
+	if local := os.Getenv("LOCALAPPDATA"); local != "" {
+		roots = append(roots, filepath.Join(local, "Programs"))
+	}
+	return roots
+}
+
+// matchDirs returns the folders directly under root named after the app,
+// and the app's folder under a folder named after its publisher.
+func matchDirs(root string, names map[string]bool, publisher string) []string {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil
+	}
+
+	var dirs []string
+	for _, e := range entries {
+		if !e.IsDir() || e.Type()&os.ModeSymlink != 0 {
+			continue
+		}
+		n := normalizeName(e.Name())
+		dir := filepath.Join(root, e.Name())
+		switch {
+		case names[n]:
+			dirs = append(dirs, dir)
+		case publisher != "" && n == publisher:
+			children, childErr := os.ReadDir(dir)
+			if childErr != nil {
+				continue
+			}
+			for _, c := range children {
+				if c.IsDir() && c.Type()&os.ModeSymlink == 0 && names[normalizeName(c.Name())] {
+					dirs = append(dirs, filepath.Join(dir, c.Name()))
+				}
+			}
+		}
+	}
+	return dirs
+}
+
+// dirLeftover builds a Leftover for a folder, measuring its size.
+func dirLeftover(kind, dir string, likely bool) Leftover {
+	size, _ := core.GetDirSize(dir)
+	return Leftover{Kind: kind, Path: dir, Size: size, Likely: likely}
+}
+
+// isDir reports whether path is an existing directory.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// ─── Shortcuts ───────────────────────────────────────────────────────────────
+
+// shortcutRoots returns the Start Menu and desktop folders, per-user and
+// shared.
+func shortcutRoots() []string {
+	var roots []string
+	if dir := os.Getenv("APPDATA"); dir != "" {
+		roots = append(roots, filepath.Join(dir, "Microsoft", "Windows", "Start Menu", "Programs"))
+	}
+	if dir := os.Getenv("ProgramData"); dir != "" {
+		roots = append(roots, filepath.Join(dir, "Microsoft", "Windows", "Start Menu", "Programs"))
+	}
+	if dir := os.Getenv("USERPROFILE"); dir != "" {
+		roots = append(roots, filepath.Join(dir, "Desktop"))
+	}
+	if dir := os.Getenv("PUBLIC"); dir != "" {
+		roots = append(roots, filepath.Join(dir, "Desktop"))
+	}
+	return roots
+}
+
+// findShortcuts returns orphaned shortcuts of the app: .lnk files whose
+// target is missing and which either point into installDir or are named
+// after the app, plus Start Menu folders named after the app that hold
+// nothing but such shortcuts.
+func findShortcuts(names map[string]bool, installDir string) []Leftover {
+	var out []Leftover
+	for _, root := range shortcutRoots() {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			path := filepath.Join(root, e.Name())
+			if !e.IsDir() {
+				if isOrphanedShortcut(path, names, installDir) {
+					out = append(out, Leftover{Kind: LeftoverShortcut, Path: path, Size: fileSize(path), Likely: true})
+				}
+				continue
+			}
+			if !names[normalizeName(e.Name())] || e.Type()&os.ModeSymlink != 0 {
+				continue
+			}
+			if orphanedFolder(path, installDir) {
+				out = append(out, dirLeftover(LeftoverShortcut, path, true))
+			}
+		}
+	}
+	return out
+}
+
+// isOrphanedShortcut reports whether path is a .lnk of the app whose
+// target no longer exists.
+func isOrphanedShortcut(path string, names map[string]bool, installDir string) bool {
+	if !strings.EqualFold(filepath.Ext(path), ".lnk") {
+		return false
+	}
+	target := shortcutTarget(path)
+	if target == "" || pathExists(target) {
+		return false
+	}
+	if installDir != "" && isUnder(target, installDir) {
+		return true
+	}
+	return names[normalizeName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))]
+}
+
+// orphanedFolder reports whether every shortcut in the Start Menu folder
+// dir points at a missing target (inside installDir when known). Files
+// other than shortcuts and desktop.ini keep the folder.
+func orphanedFolder(dir, installDir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			if !orphanedFolder(path, installDir) {
+				return false
+			}
+		case strings.EqualFold(e.Name(), "desktop.ini"):
+		case strings.EqualFold(filepath.Ext(e.Name()), ".url"):
+		case strings.EqualFold(filepath.Ext(e.Name()), ".lnk"):
+			target := shortcutTarget(path)
+			if target == "" || pathExists(target) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// shortcutTarget returns the local target path stored in a .lnk file, or
+// "" when it has none (e.g. shortcuts to shell items or network paths).
+// Only the LinkInfo structure of the Shell Link format is read.
+func shortcutTarget(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < 0x4C || binary.LittleEndian.Uint32(data) != 0x4C {
+		return ""
+	}
+
+	const (
+		hasTargetIDList = 1 << 0
+		hasLinkInfo     = 1 << 1
+		volumeIDAndPath = 1 << 0
+	)
+	flags := binary.LittleEndian.Uint32(data[0x14:])
+	if flags&hasLinkInfo == 0 {
+		return ""
+	}
+
+	off := 0x4C
+	if flags&hasTargetIDList != 0 {
+		if len(data) < off+2 {
+			return ""
+		}
+		off += 2 + int(binary.LittleEndian.Uint16(data[off:]))
+	}
+	if len(data) < off+20 {
+		return ""
+	}
+	info := data[off:]
+	if binary.LittleEndian.Uint32(info[8:])&volumeIDAndPath == 0 {
+		return ""
+	}
+	start := int(binary.LittleEndian.Uint32(info[16:]))
+	if start <= 0 || start >= len(info) {
+		return ""
+	}
+	end := start
+	for end < len(info) && info[end] != 0 {
+		end++
+	}
+	target := string(info[start:end])
+	if !filepath.IsAbs(target) {
+		return ""
+	}
+	return target
+}
+
+// isUnder reports whether path is dir or inside it (case-insensitive).
+func isUnder(path, dir string) bool {
+	p := strings.ToLower(filepath.Clean(path))
+	d := strings.ToLower(filepath.Clean(dir))
+	return p == d || strings.HasPrefix(p, d+`\`)
+}
+
+// fileSize returns the size of the file at path, or 0.
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// ─── Registry ────────────────────────────────────────────────────────────────
+
+// softwareRoots are the registry keys apps keep their settings under.
+var softwareRoots = []registrySource{
+	{registry.CURRENT_USER, `SOFTWARE`},
+	{registry.LOCAL_MACHINE, `SOFTWARE`},
+	{registry.LOCAL_MACHINE, `SOFTWARE\WOW6432Node`},
+}
+
+// matchSoftwareKeys returns the settings keys named after the app, directly
+// under SOFTWARE or under the publisher's key.
+func matchSoftwareKeys(names map[string]bool, publisher string) []string {
+	var keys []string
+	for _, src := range softwareRoots {
+		prefix := registryRootName(src.root) + `\` + src.path + `\`
+		for _, name := range subKeyNames(src.root, src.path) {
+			n := normalizeName(name)
+			switch {
+			case reservedNames[n]:
+			case names[n]:
+				keys = append(keys, prefix+name)
+			case publisher != "" && n == publisher:
+				for _, child := range subKeyNames(src.root, src.path+`\`+name) {
+					if names[normalizeName(child)] {
+						keys = append(keys, prefix+name+`\`+child)
+					}
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// subKeyNames lists the subkeys of root\path, or nil.
+func subKeyNames(root registry.Key, path string) []string {
+	key, err := registry.OpenKey(root, path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	defer key.Close()
+	names, _ := key.ReadSubKeyNames(-1)
+	return names
+}
+
+// registryKeyExists reports whether a key such as `HKLM\SOFTWARE\...`
+// still exists.
+func registryKeyExists(full string) bool {
+	rootName, path, ok := strings.Cut(full, `\`)
+	if !ok {
+		return false
+	}
+	var root registry.Key
+	switch strings.ToUpper(rootName) {
+	case "HKLM":
+		root = registry.LOCAL_MACHINE
+	case "HKCU":
+		root = registry.CURRENT_USER
+	default:
+		return false
+	}
+	key, err := registry.OpenKey(root, path, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	key.Close()
+	return true
+}
+
+// leftoverLabel is a short description of l for lists.
+func leftoverLabel(l Leftover) string {
+	switch {
+	case l.Likely:
+		return l.Kind
+	case l.Kind == LeftoverService || l.Kind == LeftoverTask:
+		return fmt.Sprintf("%s (its program still exists)", l.Kind)
+	}
+	return fmt.Sprintf("%s (may hold settings)", l.Kind)
+}
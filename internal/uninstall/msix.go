@@ -0,0 +1,226 @@
+package uninstall
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// ─── MSIX / AppX Packages ────────────────────────────────────────────────────
+
+// msixRepositoryPath is the per-user package repository. Each subkey is a
+// PackageFullName (Name_Version_Arch_ResourceId_PublisherId).
+const msixRepositoryPath = `Software\Classes\Local Settings\Software\Microsoft\Windows\CurrentVersion\AppModel\Repository\Packages`
+
+var (
+	modShlwapi               = syscall.NewLazyDLL("shlwapi.dll")
+	procSHLoadIndirectString = modShlwapi.NewProc("SHLoadIndirectString")
+)
+
+// packageFullNamePattern validates a PackageFullName before it is passed
+// to PowerShell.
+var packageFullNamePattern = regexp.MustCompile(`^[A-Za-z0-9.\-]+_[A-Za-z0-9.]+_[A-Za-z0-9]*_[A-Za-z0-9.\-~]*_[a-z0-9]+$`)
+
+// frameworkPackagePrefixes are lowercase name prefixes of framework
+// packages that other apps depend on.
+var frameworkPackagePrefixes = []string{
+	"microsoft.net.",
+	"microsoft.vclibs.",
+	"microsoft.ui.xaml.",
+	"microsoft.windowsappruntime.",
+	"microsoft.services.store.engagement",
+}
+
+// systemAppsFolderFragment marks inbox apps installed under SystemApps.
+const systemAppsFolderFragment = `\windows\systemapps\`
+
+// msixPackageName holds the parsed parts of a PackageFullName.
+type msixPackageName struct {
+	Name        string
+	Version     string
+	Arch        string
+	ResourceID  string
+	PublisherID string
+}
+
+// parsePackageFullName splits a PackageFullName into its components.
+func parsePackageFullName(full string) (msixPackageName, bool) {
+	parts := strings.Split(full, "_")
+	if len(parts) != 5 {
+		return msixPackageName{}, false
+	}
+	return msixPackageName{
+		Name:        parts[0],
+		Version:     parts[1],
+		Arch:        parts[2],
+		ResourceID:  parts[3],
+		PublisherID: parts[4],
+	}, true
+}
+
+// family returns the package family name (Name_PublisherId).
+func (p msixPackageName) family() string {
+	return p.Name + "_" + p.PublisherID
+}
+
+// readMSIXPackages enumerates packaged apps registered for the current user.
+// Resource packs are skipped; frameworks and inbox apps are flagged as
+// system components so they are hidden unless showAll is set. Only the
+// newest version of each package family is returned.
+func readMSIXPackages() []InstalledApp {
+	key, err := registry.OpenKey(registry.CURRENT_USER, msixRepositoryPath, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil
+	}
+	names, err := key.ReadSubKeyNames(-1)
+	key.Close()
+	if err != nil {
+		return nil
+	}
+
+	byFamily := make(map[string]InstalledApp)
+	for _, full := range names {
+		pkg, ok := parsePackageFullName(full)
+		if !ok || strings.Contains(strings.ToLower(pkg.ResourceID), "split") {
+			continue
+		}
+
+		sub, subErr := registry.OpenKey(registry.CURRENT_USER, msixRepositoryPath+`\`+full, registry.QUERY_VALUE)
+		if subErr != nil {
+			continue
+		}
+		displayName := resolvePackageString(full, pkg.Name, readStringValue(sub, "DisplayName"))
+		rootFolder := sanitizeRegistryString(readStringValue(sub, "PackageRootFolder"), 1024)
+		sub.Close()
+
+		app := InstalledApp{
+			Name:              sanitizeRegistryString(displayName, 512),
+			Version:           pkg.Version,
+			Publisher:         pkg.PublisherID,
+			InstallLocation:   rootFolder,
+			UninstallString:   "Remove-AppxPackage -Package " + full,
+			Source:            SourceMSIX,
+			PackageFullName:   full,
+			IsSystemComponent: isSystemPackage(pkg.Name, rootFolder),
+		}
+		if app.Name == "" {
+			continue
+		}
+
+		fam := strings.ToLower(pkg.family())
+		if prev, exists := byFamily[fam]; exists && compareVersions(prev.Version, app.Version) >= 0 {
+			continue
+		}
+		byFamily[fam] = app
+	}
+
+	apps := make([]InstalledApp, 0, len(byFamily))
+	for _, app := range byFamily {
+		apps = append(apps, app)
+	}
+	return apps
+}
+
+// isSystemPackage reports whether a package is a framework dependency or
+// an inbox app that Settings → Apps does not offer to uninstall.
+func isSystemPackage(name, rootFolder string) bool {
+	lower := strings.ToLower(name)
+	for _, prefix := range frameworkPackagePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return strings.Contains(strings.ToLower(rootFolder), systemAppsFolderFragment)
+}
+
+// resolvePackageString turns an indirect "@{...?ms-resource://...}" or
+// "ms-resource:" display name into readable text. Falls back to the raw
+// package name when the resource can't be loaded.
+func resolvePackageString(fullName, pkgName, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return pkgName
+	}
+
+	source := value
+	switch {
+	case strings.HasPrefix(value, "@{"):
+		// Already an indirect string.
+	case strings.HasPrefix(value, "ms-resource://"):
+		source = "@{" + fullName + "?" + value + "}"
+	case strings.HasPrefix(value, "ms-resource:"):
+		res := strings.TrimPrefix(value, "ms-resource:")
+		if !strings.Contains(res, "/") {
+			res = "Resources/" + res
+		}
+		source = "@{" + fullName + "?ms-resource://" + pkgName + "/" + res + "}"
+	default:
+		return value
+	}
+
+	if resolved, err := loadIndirectString(source); err == nil && resolved != "" {
+		return resolved
+	}
+	return pkgName
+}
+
+// loadIndirectString resolves an indirect string via SHLoadIndirectString.
+func loadIndirectString(source string) (string, error) {
+	src, err := syscall.UTF16PtrFromString(source)
+	if err != nil {
+		return "", err
+	}
+	buf := make([]uint16, 512)
+	ret, _, _ := procSHLoadIndirectString.Call(
+		uintptr(unsafe.Pointer(src)),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)),
+		0,
+	)
+	if ret != 0 {
+		return "", fmt.Errorf("SHLoadIndirectString failed: HRESULT 0x%08x", uint32(ret))
+	}
+	return syscall.UTF16ToString(buf), nil
+}
+
+// compareVersions compares dotted numeric versions. Returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var av, bv int
+		if i < len(as) {
+			fmt.Sscanf(as[i], "%d", &av)
+		}
+		if i < len(bs) {
+			fmt.Sscanf(bs[i], "%d", &bv)
+		}
+		switch {
+		case av < bv:
+			return -1
+		case av > bv:
+			return 1
+		}
+	}
+	return 0
+}
+
+// runAppxUninstall removes a packaged app for the current user. The package
+// name is validated before being embedded in the PowerShell command.
+func runAppxUninstall(ctx context.Context, fullName string, opts RunOptions, timeout time.Duration) error {
+	if !packageFullNamePattern.MatchString(fullName) {
+		return fmt.Errorf("invalid package name %q", fullName)
+	}
+
+	cmd := exec.CommandContext(ctx, "powershell.exe",
+		"-NoProfile", "-NonInteractive", "-Command",
+		"Remove-AppxPackage -Package '"+fullName+"'")
+	return runProcess(ctx, cmd, opts, timeout)
+}
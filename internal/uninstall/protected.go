@@ -0,0 +1,183 @@
+package uninstall
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProtectedFileName is the protection list file inside the config directory.
+const ProtectedFileName = "protected_apps.txt"
+
+// publisherPrefix marks a rule that matches the publisher instead of the name.
+const publisherPrefix = "publisher:"
+
+// ErrProtected is returned when an operation targets a protected app.
+var ErrProtected = errors.New("application is protected")
+
+// defaultProtectedPatterns seed the protection list. Removing any of these
+// in bulk can leave a machine unprotected, offline, or unmanaged.
+var defaultProtectedPatterns = []string{
+	// Antivirus and endpoint protection.
+	"*Defender*",
+	"*Antivirus*",
+	"*Bitdefender*",
+	"*Kaspersky*",
+	"*Norton*",
+	"*McAfee*",
+	"ESET*",
+	"Avast*",
+	"AVG *",
+	"Malwarebytes*",
+	"Sophos*",
+	"*CrowdStrike*",
+	"*SentinelOne*",
+	"*Carbon Black*",
+
+	// VPN clients.
+	"*VPN*",
+	"WireGuard*",
+	"OpenVPN*",
+	"GlobalProtect*",
+	"*AnyConnect*",
+	"Tailscale*",
+	"ZeroTier*",
+
+	// Corporate management agents.
+	"*Intune*",
+	"*Configuration Manager*",
+	"*Tanium*",
+	"*BigFix*",
+	"Qualys*",
+	"Rapid7*",
+	"Zscaler*",
+	"Netskope*",
+	"ManageEngine*",
+	"Kaseya*",
+	"NinjaRMM*",
+
+	// PureWin itself.
+	"PureWin*",
+}
+
+// ProtectionList holds wildcard rules for apps that must never be removed
+// through PureWin's uninstall flows.
+type ProtectionList struct {
+	patterns []string
+}
+
+// DefaultProtectionList returns a list containing only the built-in rules.
+func DefaultProtectionList() *ProtectionList {
+	return &ProtectionList{patterns: append([]string(nil), defaultProtectedPatterns...)}
+}
+
+// LoadProtectionList reads rules from path, one per line. Rules match the
+// display name with * and ? wildcards (case-insensitive); a rule prefixed
+// with "publisher:" matches the publisher. If the file does not exist it is
+// created with the default rules.
+func LoadProtectionList(path string) (*ProtectionList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("cannot read protection list %s: %w", path, err)
+		}
+		p := DefaultProtectionList()
+		if saveErr := p.save(path); saveErr != nil {
+			return nil, fmt.Errorf("cannot save default protection list: %w", saveErr)
+		}
+		return p, nil
+	}
+
+	p := &ProtectionList{}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.patterns = append(p.patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading protection list: %w", err)
+	}
+	return p, nil
+}
+
+// save writes the rules to path with an explanatory header.
+func (p *ProtectionList) save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# PureWin protected applications — one rule per line\n")
+	sb.WriteString("# Rules match the app name; * and ? are wildcards (case-insensitive)\n")
+	sb.WriteString("# Prefix a rule with publisher: to match the publisher instead\n\n")
+	for _, pat := range p.patterns {
+		sb.WriteString(pat + "\n")
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
+
+// Match returns the rule protecting app, if any.
+func (p *ProtectionList) Match(app InstalledApp) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	name := strings.ToLower(app.Name)
+	publisher := strings.ToLower(app.Publisher)
+	for _, rule := range p.patterns {
+		lower := strings.ToLower(rule)
+		if strings.HasPrefix(lower, publisherPrefix) {
+			pat := strings.TrimSpace(lower[len(publisherPrefix):])
+			if publisher != "" && matchWildcard(pat, publisher) {
+				return rule, true
+			}
+			continue
+		}
+		if matchWildcard(lower, name) {
+			return rule, true
+		}
+	}
+	return "", false
+}
+
+// Check returns an ErrProtected-wrapping error if app is protected.
+func (p *ProtectionList) Check(app InstalledApp) error {
+	if rule, ok := p.Match(app); ok {
+		return fmt.Errorf("%w: %s (rule %q)", ErrProtected, app.Name, rule)
+	}
+	return nil
+}
+
+// matchWildcard matches s against a pattern where * matches any run of
+// characters and ? matches exactly one. Unlike filepath.Match, path
+// separators are not special.
+func matchWildcard(pattern, s string) bool {
+	p, str := []rune(pattern), []rune(s)
+	pi, si := 0, 0
+	star, mark := -1, 0
+	for si < len(str) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == str[si]):
+			pi++
+			si++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, si
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			si = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
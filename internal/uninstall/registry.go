@@ -11,18 +11,55 @@ import (
 
 // InstalledApp represents an application found in the Windows registry.
 type InstalledApp struct {
-	Name                 string
-	Version              string
-	Publisher            string
-	InstallDate          string
-	EstimatedSize        int64
-	UninstallString      string
-	QuietUninstallString string
-	InstallLocation      string
-	BundleID             string
-	IsSystemComponent    bool
+	Name          string `json:"name"`
+	Version       string `json:"version,omitempty"`
+	Publisher     string `json:"publisher,omitempty"`
+	InstallDate   string `json:"install_date,omitempty"`
+	EstimatedSize int64  `json:"estimated_size"`
+
+	// ActualSize is the measured size of InstallLocation, set by
+	// MeasureSizes; zero when not measured.
+	ActualSize int64 `json:"actual_size,omitempty"`
+
+	UninstallString      string `json:"uninstall_string,omitempty"`
+	QuietUninstallString string `json:"quiet_uninstall_string,omitempty"`
+	InstallLocation      string `json:"install_location,omitempty"`
+	BundleID             string `json:"bundle_id,omitempty"`
+	IsSystemComponent    bool   `json:"system_component,omitempty"`
+
+	// DisplayIcon is the icon resource path, usually the app's main exe.
+	DisplayIcon string `json:"display_icon,omitempty"`
+
+	// URLInfoAbout and URLUpdateInfo are the publisher's product and
+	// download pages, when the installer registered them.
+	URLInfoAbout  string `json:"url_info_about,omitempty"`
+	URLUpdateInfo string `json:"url_update_info,omitempty"`
+
+	// Source identifies where the entry was discovered (see Source* constants).
+	Source string `json:"source"`
+
+	// PackageFullName is set for MSIX/AppX packages; they are removed via
+	// Remove-AppxPackage instead of an uninstall string.
+	PackageFullName string `json:"package_full_name,omitempty"`
+
+	// RegistryKey is the full uninstall key, e.g. `HKLM\SOFTWARE\...\Uninstall\App`.
+	// It is empty for MSIX packages.
+	RegistryKey string `json:"registry_key,omitempty"`
 }
 
+// Sources an InstalledApp can be discovered from.
+const (
+	// SourceARP is a standard Add/Remove Programs uninstall key.
+	SourceARP = "arp"
+
+	// SourceMSI is an ARP key for a Windows Installer product whose
+	// uninstall command was synthesized from its product code.
+	SourceMSI = "msi"
+
+	// SourceMSIX is a packaged (MSIX/AppX) application.
+	SourceMSIX = "msix"
+)
+
 // ─── Registry Sources ────────────────────────────────────────────────────────
 
 // registrySource describes one registry hive + path to scan.
@@ -38,6 +75,13 @@ var uninstallSources = []registrySource{
 	{registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall`},
 }
 
+// appPathsSources are the App Paths registrations used to fill in missing
+// install locations for ARP entries.
+var appPathsSources = []registrySource{
+	{registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Windows\CurrentVersion\App Paths`},
+	{registry.CURRENT_USER, `SOFTWARE\Microsoft\Windows\CurrentVersion\App Paths`},
+}
+
 // kbPattern matches Windows update identifiers like KB1234567.
 var kbPattern = regexp.MustCompile(`(?i)\bKB\d{6,}\b`)
 
@@ -82,6 +126,22 @@ func GetInstalledApps(showAll bool) ([]InstalledApp, error) {
 		}
 	}
 
+	// Packaged apps don't appear in the uninstall keys.
+	for _, app := range readMSIXPackages() {
+		key := strings.ToLower(app.Name + "|" + app.Version)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if !showAll && app.IsSystemComponent {
+			continue
+		}
+		apps = append(apps, app)
+	}
+
+	fillInstallLocations(apps, readAppPaths())
+
 	// Sort by size descending — largest first.
 	sort.Slice(apps, func(i, j int) bool {
 		return apps[i].EstimatedSize > apps[j].EstimatedSize
@@ -159,6 +219,24 @@ func readAppFromSubKey(root registry.Key, path string) (InstalledApp, error) {
 		BundleID:             sanitizeRegistryString(readStringValue(key, "BundleCachePath"), 1024),
 	}
 
+	app.DisplayIcon = sanitizeRegistryString(readStringValue(key, "DisplayIcon"), 1024)
+	app.URLInfoAbout = sanitizeRegistryString(readStringValue(key, "URLInfoAbout"), 1024)
+	app.URLUpdateInfo = sanitizeRegistryString(readStringValue(key, "URLUpdateInfo"), 1024)
+	app.Source = SourceARP
+	app.RegistryKey = registryRootName(root) + `\` + path
+
+	// Windows Installer products sometimes omit UninstallString; the
+	// subkey name is the product code, so msiexec can be invoked directly.
+	if wi, _, wiErr := key.GetIntegerValue("WindowsInstaller"); wiErr == nil && wi == 1 {
+		if app.UninstallString == "" {
+			productCode := path[strings.LastIndex(path, `\`)+1:]
+			if msiGUIDPattern.MatchString(productCode) {
+				app.UninstallString = "MsiExec.exe /X" + productCode
+				app.Source = SourceMSI
+			}
+		}
+	}
+
 	// EstimatedSize is stored in KB as a DWORD.
 	if size, _, sizeErr := key.GetIntegerValue("EstimatedSize"); sizeErr == nil {
 		app.EstimatedSize = int64(size) * 1024 // Convert KB → bytes.
@@ -195,6 +273,21 @@ func FilterByPath(apps []InstalledApp, path string) []InstalledApp {
 	return filtered
 }
 
+// registryRootName returns the reg.exe abbreviation for a predefined root key.
+func registryRootName(root registry.Key) string {
+	switch root {
+	case registry.LOCAL_MACHINE:
+		return "HKLM"
+	case registry.CURRENT_USER:
+		return "HKCU"
+	case registry.USERS:
+		return "HKU"
+	case registry.CLASSES_ROOT:
+		return "HKCR"
+	}
+	return ""
+}
+
 // readStringValue safely reads a string value from a registry key.
 // Returns an empty string on any error.
 func readStringValue(key registry.Key, name string) string {
@@ -204,3 +297,81 @@ func readStringValue(key registry.Key, name string) string {
 	}
 	return val
 }
+
+// ─── App Paths ───────────────────────────────────────────────────────────────
+
+// readAppPaths returns a map of lowercase exe name → directory from the
+// App Paths registrations. The "Path" value is preferred; otherwise the
+// directory of the default value (the exe path) is used.
+func readAppPaths() map[string]string {
+	dirs := make(map[string]string)
+
+	for _, src := range appPathsSources {
+		key, err := registry.OpenKey(src.root, src.path, registry.ENUMERATE_SUB_KEYS)
+		if err != nil {
+			continue
+		}
+		names, err := key.ReadSubKeyNames(-1)
+		key.Close()
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			sub, subErr := registry.OpenKey(src.root, src.path+`\`+name, registry.QUERY_VALUE)
+			if subErr != nil {
+				continue
+			}
+			dir := sanitizeRegistryString(readStringValue(sub, "Path"), 1024)
+			if dir == "" {
+				exe := strings.Trim(sanitizeRegistryString(readStringValue(sub, ""), 1024), `"`)
+				if i := strings.LastIndex(exe, `\`); i > 0 {
+					dir = exe[:i]
+				}
+			}
+			sub.Close()
+
+			dir = strings.TrimRight(strings.TrimSpace(dir), `;\`)
+			if dir == "" {
+				continue
+			}
+			exeName := strings.ToLower(name)
+			if _, exists := dirs[exeName]; !exists {
+				dirs[exeName] = dir
+			}
+		}
+	}
+
+	return dirs
+}
+
+// fillInstallLocations sets InstallLocation for apps that lack one when
+// their DisplayIcon exe has an App Paths registration.
+func fillInstallLocations(apps []InstalledApp, appPaths map[string]string) {
+	if len(appPaths) == 0 {
+		return
+	}
+	for i := range apps {
+		if apps[i].InstallLocation != "" || apps[i].DisplayIcon == "" {
+			continue
+		}
+		exe := exeNameFromIcon(apps[i].DisplayIcon)
+		if dir, ok := appPaths[exe]; ok {
+			apps[i].InstallLocation = dir
+		}
+	}
+}
+
+// exeNameFromIcon extracts the lowercase file name from a DisplayIcon
+// value such as `C:\App\app.exe,0` or `"C:\App\app.exe"`.
+func exeNameFromIcon(icon string) string {
+	icon = strings.TrimSpace(icon)
+	if i := strings.LastIndex(icon, ","); i > 0 {
+		icon = icon[:i]
+	}
+	icon = strings.Trim(icon, `"`)
+	if i := strings.LastIndex(icon, `\`); i >= 0 {
+		icon = icon[i+1:]
+	}
+	return strings.ToLower(icon)
+}
@@ -0,0 +1,119 @@
+package uninstall
+
+import (
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cy-infamous/purewin/internal/config"
+	"github.com/cy-infamous/purewin/internal/core"
+)
+
+// ─── Measured Sizes ──────────────────────────────────────────────────────────
+// EstimatedSize is whatever the installer wrote to the registry: often
+// missing, sometimes the download size, rarely updated after patches.
+// MeasureSizes walks each install folder to get the real figure.
+
+// MeasureSizes sets ActualSize for every app whose InstallLocation is a
+// folder of its own, walking the folders in parallel. Locations shared by
+// several apps, drive roots and the protected system folders themselves
+// (an installer that registered "C:\Program Files") are not measured. progress, if
+// non-nil, is called after each folder with the number done and the total.
+func MeasureSizes(apps []InstalledApp, progress func(done, total int)) {
+	// Measure each distinct folder once.
+	byDir := make(map[string][]int)
+	var dirs []string
+	for i, app := range apps {
+		dir := measurableDir(app.InstallLocation)
+		if dir == "" {
+			continue
+		}
+		key := strings.ToLower(dir)
+		if _, ok := byDir[key]; !ok {
+			dirs = append(dirs, dir)
+		}
+		byDir[key] = append(byDir[key], i)
+	}
+
+	sizes := make([]int64, len(dirs))
+	var done atomic.Int32
+	sem := make(chan struct{}, max(4, runtime.NumCPU()))
+	var wg sync.WaitGroup
+	for i := range dirs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			sizes[i], _ = core.GetDirSize(dirs[i])
+			<-sem
+			if progress != nil {
+				progress(int(done.Add(1)), len(dirs))
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, dir := range dirs {
+		owners := byDir[strings.ToLower(dir)]
+		if len(owners) != 1 {
+			continue // shared folder: the size belongs to none of them alone
+		}
+		apps[owners[0]].ActualSize = sizes[i]
+	}
+}
+
+// SortByActualSize orders apps by measured size, largest first, falling
+// back to EstimatedSize for apps that were not measured.
+func SortByActualSize(apps []InstalledApp) {
+	sort.SliceStable(apps, func(i, j int) bool {
+		return apps[i].DisplaySize() > apps[j].DisplaySize()
+	})
+}
+
+// DisplaySize returns the measured size when known, otherwise the
+// registry estimate.
+func (a InstalledApp) DisplaySize() int64 {
+	if a.ActualSize > 0 {
+		return a.ActualSize
+	}
+	return a.EstimatedSize
+}
+
+// measurableDir returns the cleaned install folder, or "" when it should
+// not be walked.
+func measurableDir(loc string) string {
+	if dir := ownedDir(loc); dir != "" && isDir(dir) {
+		return dir
+	}
+	return ""
+}
+
+// ownedDir cleans an InstallLocation that belongs to the app alone: an
+// absolute path that is not a drive root or a never-delete folder. The
+// folder need not exist any more.
+func ownedDir(loc string) string {
+	loc = strings.Trim(strings.TrimSpace(loc), `"`)
+	if loc == "" || !filepath.IsAbs(loc) {
+		return ""
+	}
+	dir := filepath.Clean(loc)
+	if filepath.Dir(dir) == dir || isProtectedRoot(dir) {
+		return ""
+	}
+	return dir
+}
+
+// isProtectedRoot reports whether dir is itself one of the never-delete
+// folders, such as C:\Program Files or C:\Windows, which some installers
+// register as their location.
+func isProtectedRoot(dir string) bool {
+	for _, p := range config.GetNeverDeletePaths() {
+		if strings.EqualFold(dir, filepath.Clean(p)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package uninstall
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// ─── Stale Uninstall Entries ─────────────────────────────────────────────────
+// When an application folder is deleted by hand, its Add/Remove Programs
+// key stays behind and Apps & Features keeps listing a program that can
+// neither run nor uninstall. These helpers find such keys and remove them
+// after exporting each one to a .reg file.
+
+// StaleEntry is an uninstall key whose application is gone from disk.
+type StaleEntry struct {
+	App InstalledApp `json:"app"`
+
+	// Reason describes the missing path, for display.
+	Reason string `json:"reason"`
+
+	// Broken is set when only one of the two paths is missing. With the
+	// uninstaller gone the entry cannot uninstall anything, but the app's
+	// files may still be in use; with the folder gone the uninstaller may
+	// still clean up after it. Either way the entry is listed unselected.
+	Broken bool `json:"broken,omitempty"`
+}
+
+// unsafeFileChars matches characters that cannot appear in a file name.
+var unsafeFileChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+// FindStaleEntries returns uninstall keys ("ghost apps") whose install
+// directory or uninstaller is missing. Windows Installer products are
+// skipped: their keys are owned by msiexec and are repaired with its own
+// tools.
+func FindStaleEntries() ([]StaleEntry, error) {
+	var stale []StaleEntry
+	reachable := volumeReachableCached()
+
+	for _, src := range uninstallSources {
+		apps, err := readAppsFromKey(src.root, src.path)
+		if err != nil {
+			continue
+		}
+		for _, app := range apps {
+			if app.IsSystemComponent || kbPattern.MatchString(app.Name) {
+				continue
+			}
+			if app.Source != SourceARP || isMSIUninstall(app.UninstallString) {
+				continue
+			}
+			if entry, ok := checkStale(app, pathExists, reachable); ok {
+				stale = append(stale, entry)
+			}
+		}
+	}
+
+	return stale, nil
+}
+
+// checkStale reports whether app points at paths that no longer exist.
+// With both paths gone the entry is a ghost; with only one of them gone it
+// is Broken. An entry with no absolute path to check is never
+// stale, and neither is a path on a volume that is not reachable — an
+// unplugged drive or an offline share — since it cannot be told apart
+// from one that is gone.
+func checkStale(app InstalledApp, exists, reachable func(string) bool) (StaleEntry, bool) {
+	loc := strings.Trim(strings.TrimSpace(app.InstallLocation), `"`)
+	if !filepath.IsAbs(loc) {
+		loc = ""
+	}
+	exe := parseExePath(app.UninstallString)
+	if !filepath.IsAbs(exe) {
+		exe = ""
+	}
+
+	for _, p := range []string{loc, exe} {
+		if p != "" && !reachable(p) {
+			return StaleEntry{}, false
+		}
+	}
+
+	locGone := loc != "" && !exists(loc)
+	exeGone := exe != "" && !exists(exe)
+
+	switch {
+	case locGone && exeGone:
+		return StaleEntry{App: app, Reason: fmt.Sprintf("%s and its uninstaller are missing", loc)}, true
+	case locGone && exe == "":
+		return StaleEntry{App: app, Reason: fmt.Sprintf("%s is missing", loc)}, true
+	case exeGone && loc == "":
+		return StaleEntry{App: app, Reason: fmt.Sprintf("%s is missing", exe)}, true
+	case exeGone:
+		return StaleEntry{App: app, Reason: fmt.Sprintf("uninstaller %s is missing; files remain in %s", exe, loc), Broken: true}, true
+	case locGone:
+		return StaleEntry{App: app, Reason: fmt.Sprintf("%s is missing; uninstaller %s remains", loc, exe), Broken: true}, true
+	}
+	return StaleEntry{}, false
+}
+
+// pathExists reports whether path exists on disk. Only a path known to be
+// missing counts as gone: access denied does not make an entry stale. A
+// missing drive also reads as not found, so callers check the volume with
+// volumeReachable first.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
+
+// volumeReachable reports whether the volume of path — a drive letter or
+// a UNC share — is mounted and answers.
+func volumeReachable(path string) bool {
+	vol := filepath.VolumeName(path)
+	if vol == "" {
+		return false
+	}
+	root, err := windows.UTF16PtrFromString(vol + `\`)
+	if err != nil {
+		return false
+	}
+	return windows.GetVolumeInformation(root, nil, 0, nil, nil, nil, nil, 0) == nil
+}
+
+// volumeReachableCached returns volumeReachable with the answer for each
+// volume remembered, so an offline share times out only once per scan.
+func volumeReachableCached() func(string) bool {
+	known := make(map[string]bool)
+	return func(path string) bool {
+		vol := strings.ToLower(filepath.VolumeName(path))
+		ok, seen := known[vol]
+		if !seen {
+			ok = volumeReachable(path)
+			known[vol] = ok
+		}
+		return ok
+	}
+}
+
+// RemoveStaleEntry exports the entry's registry key to a .reg file in
+// backupDir and then deletes the key. It returns the backup path. In
+// dryRun mode nothing is written and the would-be backup path is returned.
+func RemoveStaleEntry(entry StaleEntry, backupDir string, dryRun bool) (string, error) {
+	key := entry.App.RegistryKey
+	if key == "" {
+		return "", fmt.Errorf("%s has no registry key", entry.App.Name)
+	}
+
+	if dryRun {
+		return backupPath(key, entry.App.Name, backupDir), nil
+	}
+	return exportAndDeleteKey(key, entry.App.Name, backupDir)
+}
+
+// backupPath returns the .reg file the backup of key, named after name, is
+// written to. A hash of the key path keeps the backups of keys with the
+// same name apart — the HKLM, HKCU and WOW6432Node copies of one app —
+// even when they are removed in the same second.
+func backupPath(key, name, backupDir string) string {
+	name = unsafeFileChars.ReplaceAllString(name, "_")
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(key)))
+	return filepath.Join(backupDir,
+		fmt.Sprintf("%s-%s-%08x.reg", name, time.Now().Format("20060102-150405"), h.Sum32()))
+}
+
+// exportAndDeleteKey exports key to a .reg file in backupDir named after
+// name, then deletes the key with its subkeys. It returns the backup path.
+func exportAndDeleteKey(key, name, backupDir string) (string, error) {
+	backup := backupPath(key, name, backupDir)
+
+	if err := os.MkdirAll(backupDir, 0o755); err != nil {
+		return "", fmt.Errorf("create backup directory: %w", err)
+	}
+
+	if out, err := exec.Command("reg.exe", "export", key, backup, "/y").CombinedOutput(); err != nil {
+		return "", fmt.Errorf("back up %s: %s", key, strings.TrimSpace(string(out)))
+	}
+
+	// Never delete a key whose backup is missing.
+	if info, err := os.Stat(backup); err != nil || info.Size() == 0 {
+		return "", fmt.Errorf("back up %s: export produced no file", key)
+	}
+
+	if out, err := exec.Command("reg.exe", "delete", key, "/f").CombinedOutput(); err != nil {
+		return backup, fmt.Errorf("delete %s: %s", key, strings.TrimSpace(string(out)))
+	}
+
+	return backup, nil
+}
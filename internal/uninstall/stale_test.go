@@ -0,0 +1,116 @@
+package uninstall
+
+import (
+	"strings"
+	"testing"
+)
+
+// existsOnly returns an exists func that reports only the given paths.
+func existsOnly(paths ...string) func(string) bool {
+	return func(p string) bool {
+		for _, q := range paths {
+			if strings.EqualFold(p, q) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// allReachable reports every volume as reachable.
+func allReachable(string) bool { return true }
+
+const (
+	staleLoc = `C:\Program Files\Gone`
+	staleExe = `C:\Program Files\Gone\unins000.exe`
+)
+
+func TestCheckStale_BothMissingIsGhost(t *testing.T) {
+	app := InstalledApp{Name: "Gone", InstallLocation: staleLoc, UninstallString: `"` + staleExe + `" /SILENT`}
+	entry, ok := checkStale(app, existsOnly(), allReachable)
+	if !ok {
+		t.Fatal("entry with folder and uninstaller missing should be stale")
+	}
+	if entry.Broken {
+		t.Error("entry with both paths missing should be a ghost, not Broken")
+	}
+}
+
+func TestCheckStale_OnlyUninstallerMissingIsBroken(t *testing.T) {
+	app := InstalledApp{Name: "Gone", InstallLocation: staleLoc, UninstallString: staleExe}
+	entry, ok := checkStale(app, existsOnly(staleLoc), allReachable)
+	if !ok || !entry.Broken {
+		t.Errorf("entry whose folder remains should be stale and Broken, got ok=%v broken=%v", ok, entry.Broken)
+	}
+}
+
+func TestCheckStale_SinglePathMissing(t *testing.T) {
+	for _, app := range []InstalledApp{
+		{Name: "NoExe", InstallLocation: staleLoc},
+		{Name: "NoLoc", UninstallString: staleExe},
+	} {
+		entry, ok := checkStale(app, existsOnly(), allReachable)
+		if !ok || entry.Broken {
+			t.Errorf("%s: should be a ghost, got ok=%v broken=%v", app.Name, ok, entry.Broken)
+		}
+	}
+}
+
+func TestCheckStale_PresentIsNotStale(t *testing.T) {
+	app := InstalledApp{Name: "Here", InstallLocation: staleLoc, UninstallString: staleExe}
+	if _, ok := checkStale(app, existsOnly(staleLoc, staleExe), allReachable); ok {
+		t.Error("entry whose paths exist should not be stale")
+	}
+}
+
+func TestCheckStale_OnlyFolderMissingIsBroken(t *testing.T) {
+	// The uninstaller lives elsewhere (e.g. a shared cache) and may still work.
+	app := InstalledApp{Name: "Cached", InstallLocation: staleLoc, UninstallString: `C:\ProgramData\Cache\setup.exe /uninstall`}
+	entry, ok := checkStale(app, existsOnly(`C:\ProgramData\Cache\setup.exe`), allReachable)
+	if !ok || !entry.Broken {
+		t.Errorf("entry whose uninstaller remains should be stale and Broken, got ok=%v broken=%v", ok, entry.Broken)
+	}
+}
+
+func TestCheckStale_NoAbsolutePathIsNeverStale(t *testing.T) {
+	for _, app := range []InstalledApp{
+		{Name: "Empty"},
+		{Name: "Relative", InstallLocation: `Gone`, UninstallString: `MsiExec.exe /X{GUID}`},
+	} {
+		if _, ok := checkStale(app, existsOnly(), allReachable); ok {
+			t.Errorf("%s: entry without an absolute path should never be stale", app.Name)
+		}
+	}
+}
+
+func TestCheckStale_UnreachableVolumeIsNotStale(t *testing.T) {
+	// An app on an unplugged drive or an offline share reads as missing.
+	for _, app := range []InstalledApp{
+		{Name: "USB", InstallLocation: `E:\Apps\Tool`, UninstallString: `E:\Apps\Tool\uninstall.exe`},
+		{Name: "Share", InstallLocation: `\\server\apps\Tool`},
+		{Name: "Mixed", InstallLocation: staleLoc, UninstallString: `E:\Apps\Tool\uninstall.exe`},
+	} {
+		reachable := func(p string) bool { return strings.HasPrefix(strings.ToUpper(p), `C:`) }
+		if _, ok := checkStale(app, existsOnly(), reachable); ok {
+			t.Errorf("%s: entry on an unreachable volume should not be stale", app.Name)
+		}
+	}
+}
+
+func TestBackupPath_UniquePerKey(t *testing.T) {
+	dir := `C:\Backups`
+	a := backupPath(`HKLM\SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall\App`, "App", dir)
+	b := backupPath(`HKCU\SOFTWARE\Microsoft\Windows\CurrentVersion\Uninstall\App`, "App", dir)
+	c := backupPath(`HKLM\SOFTWARE\WOW6432Node\Microsoft\Windows\CurrentVersion\Uninstall\App`, "App", dir)
+	if a == b || a == c || b == c {
+		t.Errorf("backups of different keys with the same name must differ: %s, %s, %s", a, b, c)
+	}
+}
+
+func TestBackupPath_SanitizesName(t *testing.T) {
+	p := backupPath(`HKLM\Key`, `Bad:Name/With*Chars?`, `C:\Backups`)
+	name := p[strings.LastIndex(p, `\`)+1:]
+	if strings.ContainsAny(name, `<>:"/|?*`) {
+		t.Errorf("backup file name %q contains characters invalid in file names", name)
+	}
+}
@@ -0,0 +1,151 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ExpectedPublisher is the subject common name of the code-signing
+// certificate that release binaries are signed with. The release build
+// injects it from the PUREWIN_SIGN_PUBLISHER repository variable (the CN of
+// the PFX in PUREWIN_SIGN_PFX) via
+// -ldflags "-X github.com/cy-infamous/purewin/internal/update.ExpectedPublisher=...";
+// the build fails when it is missing (see .goreleaser.yml). A build without
+// it, such as a local `go build`, cannot verify updates and refuses them.
+var ExpectedPublisher = ""
+
+// cmsgSignerInfoParam is CMSG_SIGNER_INFO_PARAM for CryptMsgGetParam.
+const cmsgSignerInfoParam = 6
+
+var (
+	modCrypt32           = windows.NewLazySystemDLL("crypt32.dll")
+	procCryptMsgGetParam = modCrypt32.NewProc("CryptMsgGetParam")
+	procCryptMsgClose    = modCrypt32.NewProc("CryptMsgClose")
+)
+
+// cmsgSignerInfo mirrors the leading fields of CMSG_SIGNER_INFO. Only the
+// issuer and serial number are needed to locate the signing certificate.
+type cmsgSignerInfo struct {
+	Version      uint32
+	Issuer       windows.CertNameBlob
+	SerialNumber windows.CryptIntegerBlob
+}
+
+// VerifyAuthenticode checks that the file at path carries a valid
+// Authenticode signature that chains to a trusted root and that the signer
+// matches ExpectedPublisher. Unsigned, tampered, and re-signed binaries are
+// rejected, and so is every file when ExpectedPublisher is unset.
+func VerifyAuthenticode(path string) error {
+	if ExpectedPublisher == "" {
+		return fmt.Errorf("this build has no expected update publisher; download the release manually")
+	}
+	if err := verifyTrust(path); err != nil {
+		return err
+	}
+
+	signer, err := signerName(path)
+	if err != nil {
+		return fmt.Errorf("cannot read signer of %s: %w", path, err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(signer), ExpectedPublisher) {
+		return fmt.Errorf("unexpected signer %q (expected %q)", signer, ExpectedPublisher)
+	}
+	return nil
+}
+
+// verifyTrust runs WinVerifyTrust with the generic Authenticode policy and
+// whole-chain revocation checks.
+func verifyTrust(path string) error {
+	path16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+
+	data := &windows.WinTrustData{
+		Size:             uint32(unsafe.Sizeof(windows.WinTrustData{})),
+		UIChoice:         windows.WTD_UI_NONE,
+		RevocationChecks: windows.WTD_REVOKE_WHOLECHAIN,
+		UnionChoice:      windows.WTD_CHOICE_FILE,
+		StateAction:      windows.WTD_STATEACTION_VERIFY,
+		FileOrCatalogOrBlobOrSgnrOrCert: unsafe.Pointer(&windows.WinTrustFileInfo{
+			Size:     uint32(unsafe.Sizeof(windows.WinTrustFileInfo{})),
+			FilePath: path16,
+		}),
+	}
+
+	verifyErr := windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+	data.StateAction = windows.WTD_STATEACTION_CLOSE
+	_ = windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+
+	if verifyErr == nil {
+		return nil
+	}
+	var errno windows.Errno
+	if errors.As(verifyErr, &errno) && errno == windows.Errno(windows.TRUST_E_NOSIGNATURE) {
+		return fmt.Errorf("%s is not signed", path)
+	}
+	return fmt.Errorf("signature verification failed for %s: %w", path, verifyErr)
+}
+
+// signerName returns the simple display name of the certificate that
+// signed the file's embedded PKCS#7 signature.
+func signerName(path string) (string, error) {
+	path16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return "", err
+	}
+
+	var encoding, contentType, formatType uint32
+	var store, msg windows.Handle
+	err = windows.CryptQueryObject(
+		windows.CERT_QUERY_OBJECT_FILE,
+		unsafe.Pointer(path16),
+		windows.CERT_QUERY_CONTENT_FLAG_PKCS7_SIGNED_EMBED,
+		windows.CERT_QUERY_FORMAT_FLAG_BINARY,
+		0, &encoding, &contentType, &formatType, &store, &msg, nil)
+	if err != nil {
+		return "", fmt.Errorf("CryptQueryObject: %w", err)
+	}
+	defer windows.CertCloseStore(store, 0)
+	defer procCryptMsgClose.Call(uintptr(msg))
+
+	// First call sizes the buffer, second fills it.
+	var size uint32
+	if ret, _, callErr := procCryptMsgGetParam.Call(uintptr(msg), cmsgSignerInfoParam, 0, 0,
+		uintptr(unsafe.Pointer(&size))); ret == 0 {
+		return "", fmt.Errorf("CryptMsgGetParam: %w", callErr)
+	}
+	if size < uint32(unsafe.Sizeof(cmsgSignerInfo{})) {
+		return "", fmt.Errorf("signer info too small")
+	}
+	buf := make([]byte, size)
+	if ret, _, callErr := procCryptMsgGetParam.Call(uintptr(msg), cmsgSignerInfoParam, 0,
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size))); ret == 0 {
+		return "", fmt.Errorf("CryptMsgGetParam: %w", callErr)
+	}
+	info := (*cmsgSignerInfo)(unsafe.Pointer(&buf[0]))
+
+	certInfo := windows.CertInfo{
+		Issuer:       info.Issuer,
+		SerialNumber: info.SerialNumber,
+	}
+	cert, err := windows.CertFindCertificateInStore(store,
+		windows.X509_ASN_ENCODING|windows.PKCS_7_ASN_ENCODING, 0,
+		windows.CERT_FIND_SUBJECT_CERT, unsafe.Pointer(&certInfo), nil)
+	if err != nil {
+		return "", fmt.Errorf("signing certificate not found: %w", err)
+	}
+	defer windows.CertFreeCertificateContext(cert)
+
+	name := make([]uint16, 256)
+	n := windows.CertGetNameString(cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, 0, nil,
+		&name[0], uint32(len(name)))
+	if n <= 1 {
+		return "", fmt.Errorf("signing certificate has no subject name")
+	}
+	return windows.UTF16ToString(name[:n]), nil
+}
@@ -192,9 +192,31 @@ func DownloadUpdate(url string) (string, error) {
 	return tempFile, nil
 }
 
+// VerifiedUpdate is a downloaded update binary that passed the checks of
+// DownloadAndVerifyUpdate, the only way to obtain one.
+type VerifiedUpdate struct {
+	// Path is the downloaded binary; the caller removes it when done.
+	Path string
+
+	sha256 string // from the release's checksums file
+}
+
 // ApplyUpdate replaces the current binary with the downloaded update.
 // On Windows, this uses the rename trick to handle the "can't delete running exe" issue.
-func ApplyUpdate(tempPath string) error {
+// The SHA256 hash and the Authenticode signature are re-checked first, so a
+// file changed since it was verified is never installed.
+func ApplyUpdate(u *VerifiedUpdate) error {
+	if u == nil || u.sha256 == "" {
+		return fmt.Errorf("update was not verified")
+	}
+	tempPath := u.Path
+	if err := verifySHA256(tempPath, u.sha256); err != nil {
+		return err
+	}
+	if err := VerifyAuthenticode(tempPath); err != nil {
+		return err
+	}
+
 	// Get current executable path
 	currentExePath, err := os.Executable()
 	if err != nil {
@@ -367,10 +389,11 @@ func IsNewerVersion(current, newer string) bool {
 }
 
 // DownloadAndVerifyUpdate downloads the update binary and verifies its
-// integrity. It checks the file size against the GitHub API metadata and,
-// if a checksums file exists in the release assets, verifies the SHA256 hash.
+// integrity. It checks the file size against the GitHub API metadata and the
+// SHA256 hash against the release's checksums file, which must list the
+// binary. Finally the Authenticode signature and signer are checked.
 // This prevents corrupted or tampered binaries from being applied.
-func DownloadAndVerifyUpdate(release *ReleaseInfo) (string, error) {
+func DownloadAndVerifyUpdate(release *ReleaseInfo) (*VerifiedUpdate, error) {
 	assetName := getAssetNameForPlatform()
 
 	// Find the download URL and expected size from the release assets.
@@ -384,41 +407,56 @@ func DownloadAndVerifyUpdate(release *ReleaseInfo) (string, error) {
 		}
 	}
 	if downloadURL == "" {
-		return "", fmt.Errorf("asset %s not found in release %s", assetName, release.TagName)
+		return nil, fmt.Errorf("asset %s not found in release %s", assetName, release.TagName)
 	}
 
 	// Download the binary.
 	path, err := DownloadUpdate(downloadURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Verify file size matches GitHub API metadata.
 	info, err := os.Stat(path)
 	if err != nil {
 		os.Remove(path)
-		return "", fmt.Errorf("cannot stat downloaded file: %w", err)
+		return nil, fmt.Errorf("cannot stat downloaded file: %w", err)
 	}
 	if expectedSize > 0 && info.Size() != expectedSize {
 		os.Remove(path)
-		return "", fmt.Errorf("download size mismatch: expected %d bytes, got %d", expectedSize, info.Size())
+		return nil, fmt.Errorf("download size mismatch: expected %d bytes, got %d", expectedSize, info.Size())
 	}
 
-	// Look for a SHA256 checksums file in the release assets.
-	expectedHash, hashErr := fetchExpectedHash(release, assetName)
-	if hashErr == nil && expectedHash != "" {
-		actualHash, err := hashFileSHA256(path)
-		if err != nil {
-			os.Remove(path)
-			return "", fmt.Errorf("cannot hash downloaded file: %w", err)
-		}
-		if !strings.EqualFold(actualHash, expectedHash) {
-			os.Remove(path)
-			return "", fmt.Errorf("SHA256 mismatch: expected %s, got %s", expectedHash, actualHash)
-		}
+	// Verify the SHA256 hash against the release's checksums file.
+	expectedHash, err := fetchExpectedHash(release, assetName)
+	if err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("cannot verify download: %w", err)
+	}
+	if err := verifySHA256(path, expectedHash); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	// Verify the Authenticode signature and publisher.
+	if err := VerifyAuthenticode(path); err != nil {
+		os.Remove(path)
+		return nil, err
 	}
 
-	return path, nil
+	return &VerifiedUpdate{Path: path, sha256: expectedHash}, nil
+}
+
+// verifySHA256 checks the file at path against an expected SHA256 hash.
+func verifySHA256(path, expected string) error {
+	actual, err := hashFileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("cannot hash downloaded file: %w", err)
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("SHA256 mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
 }
 
 // fetchExpectedHash looks for a checksums file in the release assets and
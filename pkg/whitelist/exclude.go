@@ -0,0 +1,128 @@
+package whitelist
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cy-infamous/purewin/internal/envutil"
+)
+
+// ─── Run-Only Exclusions ─────────────────────────────────────────────────────
+// --exclude globs protect paths for a single run on top of the saved
+// patterns. Unlike saved patterns they support "**" across directories, and
+// a pattern without a separator (e.g. "*.log") matches at any depth. They
+// are never written to the whitelist file.
+
+// exclusion is a compiled --exclude glob.
+type exclusion struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// WithExclusions returns a copy of w that also treats paths matching globs
+// as whitelisted. w may be nil, in which case only the globs apply.
+func (w *Whitelist) WithExclusions(globs []string) (*Whitelist, error) {
+	out := &Whitelist{}
+	if w != nil {
+		w.mu.RLock()
+		out.path = w.path
+		out.patterns = append(out.patterns, w.patterns...)
+		out.exclusions = append(out.exclusions, w.exclusions...)
+		w.mu.RUnlock()
+	}
+	for _, g := range globs {
+		re, err := compileGlob(g)
+		if err != nil {
+			return nil, err
+		}
+		out.exclusions = append(out.exclusions, exclusion{pattern: strings.TrimSpace(g), re: re})
+	}
+	return out, nil
+}
+
+// Exclusions returns the run-only globs.
+func (w *Whitelist) Exclusions() []string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	result := make([]string, 0, len(w.exclusions))
+	for _, e := range w.exclusions {
+		result = append(result, e.pattern)
+	}
+	return result
+}
+
+// isExcluded reports whether any run-only glob matches path.
+func (w *Whitelist) isExcluded(paths ...string) bool {
+	for _, e := range w.exclusions {
+		for _, p := range paths {
+			if e.re.MatchString(globPath(p)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// globPath normalizes a path for glob matching: lower case, forward
+// slashes.
+func globPath(path string) string {
+	return strings.ToLower(strings.ReplaceAll(filepath.Clean(path), `\`, "/"))
+}
+
+// compileGlob translates a glob to an anchored, case-insensitive regular
+// expression. "**" matches any number of directories, "*" and "?" stay
+// within one. A pattern without glob characters also matches everything
+// below it, like a saved directory pattern.
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	p := strings.TrimSpace(glob)
+	if p == "" {
+		return nil, fmt.Errorf("exclude pattern cannot be empty")
+	}
+	p = strings.ToLower(strings.ReplaceAll(envutil.ExpandWindowsEnv(p), `\`, "/"))
+	if len(p) > 1 {
+		p = strings.TrimRight(p, "/")
+	}
+	if !strings.Contains(p, "/") {
+		p = "**/" + p
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(p); {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 3
+		case p[i:] == "/**":
+			sb.WriteString("(?:/.*)?")
+			i += 3
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i += 2
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+			i++
+		default:
+			r, size := utf8.DecodeRuneInString(p[i:])
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			i += size
+		}
+	}
+	if !strings.ContainsAny(strings.TrimPrefix(p, "**/"), "*?") {
+		sb.WriteString("(?:/.*)?")
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude pattern %q: %w", glob, err)
+	}
+	return re, nil
+}
@@ -22,9 +22,10 @@ var defaultPatterns = []string{
 // Whitelist manages a set of glob patterns representing paths that
 // should be excluded from cleanup operations.
 type Whitelist struct {
-	patterns []string
-	path     string
-	mu       sync.RWMutex
+	patterns   []string
+	exclusions []exclusion // run-only globs, see WithExclusions
+	path       string
+	mu         sync.RWMutex
 }
 
 // Load reads whitelist patterns from the given file path.
@@ -200,6 +201,7 @@ func (w *Whitelist) IsWhitelisted(path string) bool {
 	defer w.mu.RUnlock()
 
 	cleaned := filepath.Clean(path)
+	original := cleaned
 
 	// Resolve 8.3 short names, symlinks, and junctions to canonical form.
 	// This prevents bypass via alternate path representations.
@@ -207,6 +209,10 @@ func (w *Whitelist) IsWhitelisted(path string) bool {
 		cleaned = resolved
 	}
 
+	if w.isExcluded(original, cleaned) {
+		return true
+	}
+
 	for _, pattern := range w.patterns {
 		expanded := envutil.ExpandWindowsEnv(pattern)
 		expanded = filepath.Clean(expanded)
@@ -235,6 +241,61 @@ func (w *Whitelist) IsWhitelisted(path string) bool {
 	return false
 }
 
+// NormalizeFile upgrades a whitelist file written by an older version in
+// place: forward slashes become backslashes, trailing separators are
+// dropped and repeated patterns (compared case-insensitively) are removed.
+// Comments and blank lines are kept as written. It reports whether the
+// file changed; a missing file is not an error.
+func NormalizeFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot read whitelist file %s: %w", path, err)
+	}
+
+	normalized := normalizeLines(string(data))
+	if normalized == string(data) {
+		return false, nil
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(normalized), 0o644); err != nil {
+		return false, fmt.Errorf("cannot write whitelist file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return false, fmt.Errorf("cannot rename whitelist file: %w", err)
+	}
+	return true, nil
+}
+
+// normalizeLines applies NormalizeFile's rewrite to file contents.
+func normalizeLines(content string) string {
+	seen := make(map[string]bool)
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			sb.WriteString(line)
+			continue
+		}
+
+		pattern := strings.ReplaceAll(trimmed, "/", `\`)
+		if len(pattern) > 3 {
+			pattern = strings.TrimRight(pattern, `\`)
+		}
+		key := strings.ToLower(pattern)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		sb.WriteString(pattern + "\n")
+	}
+	return sb.String()
+}
+
 // List returns a copy of all current whitelist patterns.
 func (w *Whitelist) List() []string {
 	w.mu.RLock()
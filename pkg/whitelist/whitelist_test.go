@@ -173,3 +173,53 @@ func TestValidatePattern_RejectsDangerous(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeLines(t *testing.T) {
+	in := "# keep me\n\nC:/Users/test/keep/\n%LOCALAPPDATA%\\JetBrains\\*\n%localappdata%\\jetbrains\\*\n  D:\\Work\\cache\\  \n"
+	want := "# keep me\n\nC:\\Users\\test\\keep\n%LOCALAPPDATA%\\JetBrains\\*\nD:\\Work\\cache\n"
+	if got := normalizeLines(in); got != want {
+		t.Errorf("normalizeLines() =\n%q\nwant\n%q", got, want)
+	}
+	if got := normalizeLines(want); got != want {
+		t.Errorf("normalizeLines() is not idempotent: %q", got)
+	}
+}
+
+func TestWhitelist_WithExclusions(t *testing.T) {
+	base := &Whitelist{patterns: make([]string, 0)}
+	w, err := base.WithExclusions([]string{"**/*.log", `C:\Work\**`, "node_modules", "cache-?.bin"})
+	if err != nil {
+		t.Fatalf("WithExclusions error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{`C:/Temp/app/debug.log`, true},
+		{`C:/Temp/app/debug.LOG`, true},
+		{`C:/Temp/app/debug.log.txt`, false},
+		{`C:\Work`, true},
+		{`C:\Work\a\b\c.tmp`, true},
+		{`C:\Workspace\c.tmp`, false},
+		{`D:/src/web/node_modules`, true},
+		{`D:/src/web/node_modules/react/index.js`, true},
+		{`D:/src/web/cache-1.bin`, true},
+		{`D:/src/web/cache-10.bin`, false},
+	}
+	for _, tt := range tests {
+		if got := w.isExcluded(tt.path); got != tt.want {
+			t.Errorf("isExcluded(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if len(base.Exclusions()) != 0 {
+		t.Error("WithExclusions modified the original whitelist")
+	}
+	if got := w.Exclusions(); len(got) != 4 {
+		t.Errorf("Exclusions() = %v, want 4 patterns", got)
+	}
+	if _, err := (*Whitelist)(nil).WithExclusions([]string{" "}); err == nil {
+		t.Error("WithExclusions accepted an empty pattern")
+	}
+}
@@ -0,0 +1,29 @@
+#!/bin/sh
+# Authenticode-signs a release binary in place. goreleaser runs this after
+# each build. pw update refuses unsigned binaries, so a release without a
+# signing certificate fails here rather than shipping them.
+#
+#   PUREWIN_SIGN_PFX        base64-encoded PKCS#12 certificate and key
+#   PUREWIN_SIGN_PASSWORD   password of the PFX
+#   PUREWIN_SIGN_PUBLISHER  subject CN of the certificate, which the build
+#                           also bakes into update.ExpectedPublisher
+set -eu
+
+bin="$1"
+if [ -z "${PUREWIN_SIGN_PFX:-}" ]; then
+	echo "sign: PUREWIN_SIGN_PFX not set; release binaries must be signed" >&2
+	exit 1
+fi
+if [ -z "${PUREWIN_SIGN_PUBLISHER:-}" ]; then
+	echo "sign: PUREWIN_SIGN_PUBLISHER must name the certificate's subject CN" >&2
+	exit 1
+fi
+
+pfx=$(mktemp)
+trap 'rm -f "$pfx" "$bin.signed"' EXIT
+printf '%s' "$PUREWIN_SIGN_PFX" | base64 -d >"$pfx"
+
+osslsigncode sign -pkcs12 "$pfx" -pass "${PUREWIN_SIGN_PASSWORD:-}" \
+	-n PureWin -h sha256 -ts http://timestamp.digicert.com \
+	-in "$bin" -out "$bin.signed"
+mv "$bin.signed" "$bin"